@@ -0,0 +1,427 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/infrastructure/auth"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+)
+
+// defaultListPageSize is how many records a list shows by default, as for the GraphQL API
+const defaultListPageSize = 10
+
+// parentsResult is a page of parents, as the list command prints it in JSON and YAML
+type parentsResult struct {
+	Parents    []*domain.Parent `json:"parents"`
+	TotalCount int64            `json:"totalCount"`
+	Page       int              `json:"page"`
+	PageSize   int              `json:"pageSize"`
+	HasNext    bool             `json:"hasNext"`
+}
+
+// childrenResult is a page of children, as the list command prints it in JSON and YAML
+type childrenResult struct {
+	Children   []*domain.Child `json:"children"`
+	TotalCount int64           `json:"totalCount"`
+	Page       int             `json:"page"`
+	PageSize   int             `json:"pageSize"`
+	HasNext    bool            `json:"hasNext"`
+}
+
+// countResult is the number of records matching a filter
+type countResult struct {
+	Kind  string `json:"kind"`
+	Count int64  `json:"count"`
+}
+
+// deleteResult is a record marked as deleted
+type deleteResult struct {
+	Kind    string    `json:"kind"`
+	ID      uuid.UUID `json:"id"`
+	Deleted bool      `json:"deleted"`
+}
+
+// recordCommand is a command on a kind of records, whose service is set once its arguments are parsed
+type recordCommand struct {
+	kind    string
+	flags   *flag.FlagSet
+	tenant  *string
+	actor   *string
+	output  *string
+	service ports.FamilyService
+	out     io.Writer
+}
+
+// newRecordCommand returns the command of the given name on the kind of records named by the first argument,
+// which may be singular or plural. The other arguments are parsed by parse, once the command has its flags.
+func newRecordCommand(name string, args []string, operands string) (*recordCommand, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return nil, usageError(name + " needs the kind of records: parent or child")
+	}
+	kind, err := parseKind(args[0])
+	if err != nil {
+		return nil, usageError(err.Error())
+	}
+
+	synopsis := fmt.Sprintf("familyctl %s %s [flags]", name, args[0])
+	if operands != "" {
+		synopsis += " " + operands
+	}
+	cmd := &recordCommand{
+		kind:  kind,
+		flags: newFlagSet(name+" "+args[0], synopsis),
+		out:   os.Stdout,
+	}
+	cmd.tenant = cmd.flags.String("tenant", "", "the tenant of the records; the default tenant if empty")
+	cmd.output = outputFlag(cmd.flags)
+	return cmd, nil
+}
+
+// parseKind returns the kind of records a name designates, singular or plural
+func parseKind(name string) (string, error) {
+	switch strings.ToLower(name) {
+	case "parent", kindParents:
+		return kindParents, nil
+	case "child", kindChildren:
+		return kindChildren, nil
+	default:
+		return "", fmt.Errorf("unsupported kind of records %q, expected parent or child", name)
+	}
+}
+
+// parse parses the flags of the command, and returns its ID operand if it takes one
+func (c *recordCommand) parse(args []string, takesID bool) (uuid.UUID, error) {
+	if err := parseFlags(c.flags, args); err != nil {
+		return uuid.Nil, err
+	}
+	if err := checkOutput(*c.output); err != nil {
+		return uuid.Nil, usageError(err.Error())
+	}
+
+	if !takesID {
+		if c.flags.NArg() != 0 {
+			return uuid.Nil, usageError(fmt.Sprintf("unexpected argument %q", c.flags.Arg(0)))
+		}
+		return uuid.Nil, nil
+	}
+	if c.flags.NArg() != 1 {
+		return uuid.Nil, usageError("expected the ID of the " + singular(c.kind))
+	}
+	id, err := uuid.Parse(c.flags.Arg(0))
+	if err != nil {
+		return uuid.Nil, usageError(fmt.Sprintf("invalid ID %q", c.flags.Arg(0)))
+	}
+	return id, nil
+}
+
+// connect connects to the family service, and returns the context of the calls of the command
+func (c *recordCommand) connect(ctx context.Context) (context.Context, func(), error) {
+	container, err := connect(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.service = container.GetFamilyService()
+	return withCaller(ctx, *c.tenant, c.actorID()), func() { closeContainer(container) }, nil
+}
+
+// actorID returns the user the audit log attributes the changes of the command to, if the command has the flag
+func (c *recordCommand) actorID() string {
+	if c.actor == nil {
+		return ""
+	}
+	return *c.actor
+}
+
+// withCaller returns a context for the calls of a command to the family service, in the given tenant,
+// whose changes the audit log attributes to the given actor if there is one
+func withCaller(ctx context.Context, tenantID, actor string) context.Context {
+	ctx = ports.WithTenantID(ctx, tenantID)
+	if actor != "" {
+		ctx = auth.WithUserID(ctx, actor)
+	}
+	return ctx
+}
+
+// singular returns the singular of a kind of records
+func singular(kind string) string {
+	if kind == kindChildren {
+		return "child"
+	}
+	return "parent"
+}
+
+// runGet runs the get command, which prints a parent or child
+func runGet(ctx context.Context, args []string) error {
+	cmd, err := newRecordCommand("get", args, "ID")
+	if err != nil {
+		return err
+	}
+	id, err := cmd.parse(args[1:], true)
+	if err != nil {
+		return err
+	}
+
+	ctx, closeFn, err := cmd.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	if cmd.kind == kindParents {
+		parent, err := cmd.service.GetParentByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		return printResult(cmd.out, *cmd.output, parent, parentsTable(parent))
+	}
+
+	child, err := cmd.service.GetChildByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return printResult(cmd.out, *cmd.output, child, childrenTable(child))
+}
+
+// runList runs the list command, which prints a page of the parents or children matching the filters
+func runList(ctx context.Context, args []string) error {
+	cmd, err := newRecordCommand("list", args, "")
+	if err != nil {
+		return err
+	}
+	filter := filterFlags(cmd.flags)
+	page := cmd.flags.Int("page", 0, "the page to list, from 0")
+	pageSize := cmd.flags.Int("page-size", defaultListPageSize, "how many records a page has")
+	sortField := cmd.flags.String("sort", "createdAt", "the field to sort by: firstName, lastName, email (parents only), birthDate, createdAt or updatedAt")
+	desc := cmd.flags.Bool("desc", false, "sort in descending order")
+	if _, err := cmd.parse(args[1:], false); err != nil {
+		return err
+	}
+	if *page < 0 || *pageSize <= 0 {
+		return usageError("-page cannot be negative, and -page-size must be positive")
+	}
+	if *sortField == "email" && cmd.kind == kindChildren {
+		return usageError("children cannot be sorted by email")
+	}
+	options, err := queryOptions(filter, *sortField, *desc)
+	if err != nil {
+		return usageError(err.Error())
+	}
+	options.Pagination = ports.PaginationOptions{Page: *page, PageSize: *pageSize}
+
+	ctx, closeFn, err := cmd.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	if cmd.kind == kindParents {
+		parents, paged, err := cmd.service.ListParents(ctx, options)
+		if err != nil {
+			return err
+		}
+		result := parentsResult{Parents: parents, Page: *page, PageSize: *pageSize}
+		setPage(&result.TotalCount, &result.HasNext, paged)
+		t := parentsTable(parents...)
+		t.footer = pageFooter(*page, len(parents), result.TotalCount, kindParents)
+		return printResult(cmd.out, *cmd.output, result, t)
+	}
+
+	children, paged, err := cmd.service.ListChildren(ctx, options)
+	if err != nil {
+		return err
+	}
+	result := childrenResult{Children: children, Page: *page, PageSize: *pageSize}
+	setPage(&result.TotalCount, &result.HasNext, paged)
+	t := childrenTable(children...)
+	t.footer = pageFooter(*page, len(children), result.TotalCount, kindChildren)
+	return printResult(cmd.out, *cmd.output, result, t)
+}
+
+// queryOptions returns the query options of the filter flags and sort order of a list
+func queryOptions(filter *filters, sortField string, desc bool) (ports.QueryOptions, error) {
+	filterOptions, err := filter.options()
+	if err != nil {
+		return ports.QueryOptions{}, err
+	}
+
+	switch sortField {
+	case "firstName", "lastName", "email", "birthDate", "createdAt", "updatedAt":
+	default:
+		return ports.QueryOptions{}, fmt.Errorf("unsupported sort field %q", sortField)
+	}
+	direction := "asc"
+	if desc {
+		direction = "desc"
+	}
+
+	return ports.QueryOptions{
+		Filter: filterOptions,
+		Sort:   ports.SortOptions{Field: sortField, Direction: direction},
+	}, nil
+}
+
+// setPage sets the total count and whether there is a next page from the paged result of a list, if it has one
+func setPage(totalCount *int64, hasNext *bool, paged *ports.PagedResult) {
+	if paged == nil {
+		return
+	}
+	*totalCount = paged.TotalCount
+	*hasNext = paged.HasNext
+}
+
+// pageFooter tells which page of a list a table shows
+func pageFooter(page, count int, totalCount int64, kind string) string {
+	return fmt.Sprintf("Page %d: %d of %d %s", page, count, totalCount, kind)
+}
+
+// runCount runs the count command, which prints the number of parents or children matching the filters
+func runCount(ctx context.Context, args []string) error {
+	cmd, err := newRecordCommand("count", args, "")
+	if err != nil {
+		return err
+	}
+	filter := filterFlags(cmd.flags)
+	if _, err := cmd.parse(args[1:], false); err != nil {
+		return err
+	}
+	filterOptions, err := filter.options()
+	if err != nil {
+		return usageError(err.Error())
+	}
+
+	ctx, closeFn, err := cmd.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	var count int64
+	if cmd.kind == kindParents {
+		count, err = cmd.service.CountParents(ctx, filterOptions)
+	} else {
+		count, err = cmd.service.CountChildren(ctx, filterOptions)
+	}
+	if err != nil {
+		return err
+	}
+
+	result := countResult{Kind: cmd.kind, Count: count}
+	t := table{header: []string{"KIND", "COUNT"}, rows: [][]string{{cmd.kind, fmt.Sprint(count)}}}
+	return printResult(cmd.out, *cmd.output, result, t)
+}
+
+// runDelete runs the delete command, which marks a parent or child as deleted
+func runDelete(ctx context.Context, args []string) error {
+	cmd, err := newRecordCommand("delete", args, "ID")
+	if err != nil {
+		return err
+	}
+	cmd.actor = actorFlag(cmd.flags)
+	id, err := cmd.parse(args[1:], true)
+	if err != nil {
+		return err
+	}
+
+	ctx, closeFn, err := cmd.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	if cmd.kind == kindParents {
+		err = cmd.service.DeleteParent(ctx, id)
+	} else {
+		err = cmd.service.DeleteChild(ctx, id)
+	}
+	if err != nil {
+		return err
+	}
+
+	result := deleteResult{Kind: singular(cmd.kind), ID: id, Deleted: true}
+	t := table{header: []string{"KIND", "ID", "DELETED"}, rows: [][]string{{result.Kind, id.String(), "true"}}}
+	return printResult(cmd.out, *cmd.output, result, t)
+}
+
+// runRestore runs the restore command, which brings back a parent or child marked as deleted
+func runRestore(ctx context.Context, args []string) error {
+	cmd, err := newRecordCommand("restore", args, "ID")
+	if err != nil {
+		return err
+	}
+	cmd.actor = actorFlag(cmd.flags)
+	id, err := cmd.parse(args[1:], true)
+	if err != nil {
+		return err
+	}
+
+	ctx, closeFn, err := cmd.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	if cmd.kind == kindParents {
+		parent, err := cmd.service.RestoreParent(ctx, id)
+		if err != nil {
+			return err
+		}
+		return printResult(cmd.out, *cmd.output, parent, parentsTable(parent))
+	}
+
+	child, err := cmd.service.RestoreChild(ctx, id)
+	if err != nil {
+		return err
+	}
+	return printResult(cmd.out, *cmd.output, child, childrenTable(child))
+}
+
+// runTransfer runs the transfer command, which moves a child from one parent to another
+func runTransfer(ctx context.Context, args []string) error {
+	cmd, err := newRecordCommand("transfer", args, "-from PARENT_ID -to PARENT_ID ID")
+	if err != nil {
+		return err
+	}
+	if cmd.kind != kindChildren {
+		return usageError("only children can be transferred")
+	}
+	cmd.actor = actorFlag(cmd.flags)
+	from := cmd.flags.String("from", "", "the ID of the parent the child is transferred from")
+	to := cmd.flags.String("to", "", "the ID of the parent the child is transferred to")
+	reason := cmd.flags.String("reason", "", "why the child is transferred, recorded with the event and the audit record; required")
+	id, err := cmd.parse(args[1:], true)
+	if err != nil {
+		return err
+	}
+	fromID, err := uuid.Parse(*from)
+	if err != nil {
+		return usageError("-from must be the ID of a parent")
+	}
+	toID, err := uuid.Parse(*to)
+	if err != nil {
+		return usageError("-to must be the ID of a parent")
+	}
+
+	ctx, closeFn, err := cmd.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	child, err := cmd.service.TransferChild(ctx, id, fromID, toID, *reason)
+	if err != nil {
+		return err
+	}
+	return printResult(cmd.out, *cmd.output, child, childrenTable(child))
+}
+
+// actorFlag defines the flag that names the user the audit log attributes the changes of a command to
+func actorFlag(flags *flag.FlagSet) *string {
+	return flags.String("actor", "", "the user the audit log attributes the change to")
+}
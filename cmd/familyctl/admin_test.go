@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKind(t *testing.T) {
+	for name, expected := range map[string]string{
+		"parent":   kindParents,
+		"Parents":  kindParents,
+		"child":    kindChildren,
+		"children": kindChildren,
+	} {
+		kind, err := parseKind(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, expected, kind, name)
+	}
+
+	_, err := parseKind("household")
+	assert.Error(t, err)
+}
+
+func TestRecordCommand_Parse(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("ID after the flags", func(t *testing.T) {
+		// Arrange
+		args := []string{"child", "-tenant", "acme", "-output", "json", id.String()}
+		cmd, err := newRecordCommand("get", args, "ID")
+		require.NoError(t, err)
+
+		// Act
+		parsed, err := cmd.parse(args[1:], true)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, id, parsed)
+		assert.Equal(t, kindChildren, cmd.kind)
+		assert.Equal(t, "acme", *cmd.tenant)
+		assert.Equal(t, outputJSON, *cmd.output)
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		// Arrange
+		args := []string{"parent", "not-an-id"}
+		cmd, err := newRecordCommand("get", args, "ID")
+		require.NoError(t, err)
+
+		// Act
+		_, err = cmd.parse(args[1:], true)
+
+		// Assert
+		var usageErr usageError
+		assert.True(t, errors.As(err, &usageErr))
+	})
+
+	t.Run("unsupported output", func(t *testing.T) {
+		// Arrange
+		args := []string{"parents", "-output", "xml"}
+		cmd, err := newRecordCommand("count", args, "")
+		require.NoError(t, err)
+
+		// Act
+		_, err = cmd.parse(args[1:], false)
+
+		// Assert
+		var usageErr usageError
+		assert.True(t, errors.As(err, &usageErr))
+	})
+
+	t.Run("missing kind", func(t *testing.T) {
+		// Act
+		_, err := newRecordCommand("list", []string{"-output", "json"}, "")
+
+		// Assert
+		var usageErr usageError
+		assert.True(t, errors.As(err, &usageErr))
+	})
+}
+
+func TestQueryOptions(t *testing.T) {
+	// Arrange
+	flags := flag.NewFlagSet("list", flag.ContinueOnError)
+	filter := filterFlags(flags)
+	require.NoError(t, flags.Parse([]string{"-last-name", "Doe", "-min-age", "30", "-phone", "+1 (217) 555-0100"}))
+
+	// Act
+	options, err := queryOptions(filter, "lastName", true)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Doe", options.Filter.LastName)
+	assert.Equal(t, 30, options.Filter.MinAge)
+	assert.Equal(t, "+12175550100", options.Filter.Phone)
+	assert.Equal(t, "lastName", options.Sort.Field)
+	assert.Equal(t, "desc", options.Sort.Direction)
+
+	_, err = queryOptions(filter, "shoeSize", false)
+	assert.Error(t, err)
+}
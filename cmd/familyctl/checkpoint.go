@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// checkpoint records how far an import got, so that an interrupted import can be resumed
+// without importing its records twice
+type checkpoint struct {
+	path string
+
+	// Kind is the kind of records of the import
+	Kind string `json:"kind"`
+
+	// Records is the number of records of the file that were processed, whether they were imported or failed
+	Records int `json:"records"`
+
+	// UpdatedAt is when the checkpoint was last saved
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// loadCheckpoint reads the checkpoint of an import of the given kind of records from a file,
+// or starts a new checkpoint if the file does not exist
+func loadCheckpoint(path, kind string) (*checkpoint, error) {
+	cp := &checkpoint{path: path, Kind: kind}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the checkpoint: %w", err)
+	}
+
+	if err := json.Unmarshal(b, cp); err != nil {
+		return nil, fmt.Errorf("failed to decode the checkpoint %s: %w", path, err)
+	}
+	if cp.Kind != kind {
+		return nil, fmt.Errorf("the checkpoint %s is of an import of %s, not %s", path, cp.Kind, kind)
+	}
+	return cp, nil
+}
+
+// save records that the first records of the file were processed. The checkpoint is written to a temporary
+// file that replaces the previous one, so that an interruption never leaves a partial checkpoint.
+func (cp *checkpoint) save(records int) error {
+	cp.Records = records
+	cp.UpdatedAt = time.Now().UTC()
+
+	b, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the checkpoint: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(cp.path), filepath.Base(cp.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to save the checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(b, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save the checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save the checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), cp.path); err != nil {
+		return fmt.Errorf("failed to save the checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+)
+
+// defaultPageSize is how many records are read from the family service at once
+const defaultPageSize = 100
+
+// exporter writes the parents or children matching a filter to an export file, reading them from the
+// family service page by page, oldest first, so that exports of any size use little memory
+type exporter struct {
+	service  ports.FamilyService
+	kind     string
+	filter   ports.FilterOptions
+	pageSize int
+	writer   *recordWriter
+}
+
+// runExport runs the export command
+func runExport(ctx context.Context, args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return usageError("export needs the kind of records to export: parents or children")
+	}
+	kind := args[0]
+	if err := checkKind(kind); err != nil {
+		return usageError(err.Error())
+	}
+
+	flags := newFlagSet("export "+kind, "familyctl export "+kind+" [flags]")
+	output := flags.String("o", "-", "the file to write to, or - for standard output")
+	format := flags.String("format", "", "the format of the file, csv or ndjson; by default, from the extension of the file, or ndjson")
+	pageSize := flags.Int("page-size", defaultPageSize, "how many records to read at once")
+	tenant := flags.String("tenant", "", "the tenant whose records to export; the default tenant if empty")
+	filter := filterFlags(flags)
+	if err := parseFlags(flags, args[1:]); err != nil {
+		return err
+	}
+	if *format == "" {
+		*format = detectFormat(*output)
+	}
+	if err := checkFormat(*format); err != nil {
+		return usageError(err.Error())
+	}
+	if *pageSize <= 0 {
+		return usageError("-page-size must be positive")
+	}
+	filterOptions, err := filter.options()
+	if err != nil {
+		return usageError(err.Error())
+	}
+
+	container, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeContainer(container)
+
+	var w io.Writer = os.Stdout
+	if *output != "-" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("failed to create the export file: %w", err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	columns := parentColumns
+	if kind == kindChildren {
+		columns = childColumns
+	}
+	writer, err := newRecordWriter(w, *format, columns)
+	if err != nil {
+		return err
+	}
+
+	e := &exporter{
+		service:  container.GetFamilyService(),
+		kind:     kind,
+		filter:   filterOptions,
+		pageSize: *pageSize,
+		writer:   writer,
+	}
+	count, err := e.run(withCaller(ctx, *tenant, ""))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d %s\n", count, kind)
+	return nil
+}
+
+// run exports the records, and returns how many were exported
+func (e *exporter) run(ctx context.Context) (int, error) {
+	options := ports.QueryOptions{
+		Filter: e.filter,
+		Sort:   ports.SortOptions{Field: "createdAt", Direction: "asc"},
+		Cursor: ports.CursorOptions{First: e.pageSize},
+	}
+
+	count := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+
+		var written int
+		var hasNext bool
+		var err error
+		if e.kind == kindParents {
+			written, hasNext, err = e.exportParents(ctx, &options)
+		} else {
+			written, hasNext, err = e.exportChildren(ctx, &options)
+		}
+		count += written
+		if err != nil {
+			return count, err
+		}
+		if err := e.writer.flush(); err != nil {
+			return count, fmt.Errorf("failed to write the export file: %w", err)
+		}
+		if !hasNext {
+			return count, nil
+		}
+	}
+}
+
+// exportParents exports a page of parents, and moves the cursor of the options after it
+func (e *exporter) exportParents(ctx context.Context, options *ports.QueryOptions) (int, bool, error) {
+	parents, page, err := e.service.ListParents(ctx, *options)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to list parents: %w", err)
+	}
+
+	for i, parent := range parents {
+		if err := e.writer.write(newParentRecord(parent)); err != nil {
+			return i, false, fmt.Errorf("failed to write parent %s: %w", parent.ID, err)
+		}
+	}
+
+	if len(parents) > 0 {
+		last := parents[len(parents)-1]
+		options.Cursor.After = &ports.Cursor{SortField: "createdAt", SortValue: last.CreatedAt, ID: last.ID}
+	}
+	return len(parents), page != nil && page.HasNext && len(parents) > 0, nil
+}
+
+// exportChildren exports a page of children with the emails of their parents, and moves the cursor of the
+// options after it
+func (e *exporter) exportChildren(ctx context.Context, options *ports.QueryOptions) (int, bool, error) {
+	children, page, err := e.service.ListChildren(ctx, *options)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to list children: %w", err)
+	}
+
+	// The parents of the page are read at once; those that are deleted have no email in the export
+	parentIDs := make([]uuid.UUID, 0, len(children))
+	for _, child := range children {
+		parentIDs = append(parentIDs, child.ParentID)
+	}
+	parents, err := e.service.GetParentsByIDs(ctx, parentIDs)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get the parents of the children: %w", err)
+	}
+	parentsByID := make(map[uuid.UUID]*domain.Parent, len(parents))
+	for _, parent := range parents {
+		parentsByID[parent.ID] = parent
+	}
+
+	for i, child := range children {
+		parentEmail := ""
+		if parent := parentsByID[child.ParentID]; parent != nil {
+			parentEmail = parent.Email
+		}
+		if err := e.writer.write(newChildRecord(child, parentEmail)); err != nil {
+			return i, false, fmt.Errorf("failed to write child %s: %w", child.ID, err)
+		}
+	}
+
+	if len(children) > 0 {
+		last := children[len(children)-1]
+		options.Cursor.After = &ports.Cursor{SortField: "createdAt", SortValue: last.CreatedAt, ID: last.ID}
+	}
+	return len(children), page != nil && page.HasNext && len(children) > 0, nil
+}
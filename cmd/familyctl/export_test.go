@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/mocks"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExporter_Parents(t *testing.T) {
+	// Arrange
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	parents := make([]*domain.Parent, 5)
+	for i := range parents {
+		parents[i] = &domain.Parent{
+			ID:        uuid.New(),
+			FirstName: "Parent",
+			LastName:  "Doe",
+			Email:     "parent@example.com",
+			BirthDate: start.AddDate(-40, 0, 0),
+			CreatedAt: start.Add(time.Duration(i) * time.Hour),
+			UpdatedAt: start.Add(time.Duration(i) * time.Hour),
+		}
+	}
+
+	var cursors []*ports.Cursor
+	service := mocks.NewMockFamilyService()
+	service.ListParentsFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
+		assert.Equal(t, "Doe", options.Filter.LastName)
+		assert.Equal(t, "createdAt", options.Sort.Field)
+		cursors = append(cursors, options.Cursor.After)
+
+		// The parents after the cursor, oldest first
+		offset := 0
+		if options.Cursor.After != nil {
+			for i, parent := range parents {
+				if parent.ID == options.Cursor.After.ID {
+					offset = i + 1
+				}
+			}
+		}
+		end := offset + options.Cursor.First
+		if end > len(parents) {
+			end = len(parents)
+		}
+		return parents[offset:end], &ports.PagedResult{HasNext: end < len(parents)}, nil
+	}
+
+	var buf bytes.Buffer
+	writer, err := newRecordWriter(&buf, formatNDJSON, parentColumns)
+	require.NoError(t, err)
+	e := &exporter{
+		service:  service,
+		kind:     kindParents,
+		filter:   ports.FilterOptions{LastName: "Doe"},
+		pageSize: 2,
+		writer:   writer,
+	}
+
+	// Act
+	count, err := e.run(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+	assert.Equal(t, 5, bytes.Count(buf.Bytes(), []byte("\n")))
+	require.Len(t, cursors, 3)
+	assert.Nil(t, cursors[0])
+	assert.Equal(t, parents[1].ID, cursors[1].ID)
+	assert.Equal(t, parents[3].ID, cursors[2].ID)
+}
+
+func TestExporter_ChildrenWithParentEmails(t *testing.T) {
+	// Arrange
+	parent := &domain.Parent{ID: uuid.New(), Email: "john@example.com"}
+	deletedParentID := uuid.New()
+	children := []*domain.Child{
+		{ID: uuid.New(), FirstName: "Jane", LastName: "Doe", ParentID: parent.ID},
+		{ID: uuid.New(), FirstName: "Jim", LastName: "Doe", ParentID: deletedParentID},
+	}
+
+	service := mocks.NewMockFamilyService()
+	service.ListChildrenFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
+		return children, &ports.PagedResult{}, nil
+	}
+	service.GetParentsByIDsFunc = func(ctx context.Context, ids []uuid.UUID) ([]*domain.Parent, error) {
+		return []*domain.Parent{parent}, nil
+	}
+
+	var buf bytes.Buffer
+	writer, err := newRecordWriter(&buf, formatCSV, childColumns)
+	require.NoError(t, err)
+	e := &exporter{service: service, kind: kindChildren, pageSize: 10, writer: writer}
+
+	// Act
+	count, err := e.run(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	reader, err := newRecordReader(&buf, formatCSV)
+	require.NoError(t, err)
+	first, err := reader.next()
+	require.NoError(t, err)
+	second, err := reader.next()
+	require.NoError(t, err)
+	assert.Equal(t, "john@example.com", first.Fields["parent_email"])
+	assert.Equal(t, "", second.Fields["parent_email"])
+	assert.Equal(t, deletedParentID.String(), second.Fields["parent_id"])
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+)
+
+// filters holds the values of the flags that filter parents and children, which mirror ports.FilterOptions
+type filters struct {
+	firstName  *string
+	lastName   *string
+	email      *string
+	minAge     *int
+	maxAge     *int
+	postalCode *string
+	phone      *string
+	parentIDs  *string
+	deleted    *bool
+}
+
+// filterFlags defines the flags that filter parents and children
+func filterFlags(flags *flag.FlagSet) *filters {
+	return &filters{
+		firstName:  flags.String("first-name", "", "only the records whose first name contains the value"),
+		lastName:   flags.String("last-name", "", "only the records whose last name contains the value"),
+		email:      flags.String("email", "", "only the parents whose email contains the value"),
+		minAge:     flags.Int("min-age", 0, "only the records at least this old"),
+		maxAge:     flags.Int("max-age", 0, "only the records at most this old"),
+		postalCode: flags.String("postal-code", "", "only the parents whose address has the postal code"),
+		phone:      flags.String("phone", "", "only the parents who can be reached at the phone number"),
+		parentIDs:  flags.String("parent-ids", "", "only the given parents, or their children: comma-separated IDs"),
+		deleted:    flags.Bool("deleted", false, "only the records marked as deleted, instead of excluding them"),
+	}
+}
+
+// options returns the filter options of the flags
+func (f *filters) options() (ports.FilterOptions, error) {
+	options := ports.FilterOptions{
+		FirstName:  *f.firstName,
+		LastName:   *f.lastName,
+		Email:      *f.email,
+		MinAge:     *f.minAge,
+		MaxAge:     *f.maxAge,
+		PostalCode: *f.postalCode,
+		Phone:      domain.NormalizePhoneNumber(*f.phone),
+		Deleted:    *f.deleted,
+	}
+
+	if options.MinAge < 0 || options.MaxAge < 0 {
+		return options, fmt.Errorf("-min-age and -max-age cannot be negative")
+	}
+	if options.MaxAge > 0 && options.MinAge > options.MaxAge {
+		return options, fmt.Errorf("-min-age cannot be greater than -max-age")
+	}
+
+	for _, value := range strings.Split(*f.parentIDs, ",") {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return options, fmt.Errorf("invalid parent ID %q in -parent-ids", value)
+		}
+		options.ParentIDs = append(options.ParentIDs, id)
+	}
+
+	return options, nil
+}
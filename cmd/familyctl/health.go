@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/infrastructure/config"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/infrastructure/di"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/infrastructure/health"
+)
+
+// healthProvider provides the dependencies of the family service to the health check, none of which
+// are up when the container could not be built
+type healthProvider struct {
+	container *di.Container
+}
+
+// GetRepositoryFactory returns the repository factory of the container, or nil if there is no container
+func (p healthProvider) GetRepositoryFactory() any {
+	if p.container == nil {
+		return nil
+	}
+	return p.container.GetRepositoryFactory()
+}
+
+// runHealth runs the health command, which prints the health of the family service as its health check
+// endpoint reports it, and fails if the service is not healthy
+func runHealth(ctx context.Context, args []string) error {
+	flags := newFlagSet("health", "familyctl health [flags]")
+	output := outputFlag(flags)
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return usageError(fmt.Sprintf("unexpected argument %q", flags.Arg(0)))
+	}
+	if err := checkOutput(*output); err != nil {
+		return usageError(err.Error())
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load application configuration: %w", err)
+	}
+
+	// A container that cannot be built, as when the database is down, leaves the service degraded
+	provider := healthProvider{}
+	container, err := newContainer(ctx, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "familyctl: %v\n", err)
+	} else {
+		defer closeContainer(container)
+		provider.container = container
+	}
+
+	status := health.Check(provider, cfg.App.Version)
+	t := table{header: []string{"SERVICE", "STATUS"}, footer: fmt.Sprintf("%s (version %s, at %s)", status.Status, status.Version, status.Timestamp)}
+	services := make([]string, 0, len(status.Services))
+	for service := range status.Services {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	for _, service := range services {
+		t.rows = append(t.rows, []string{service, status.Services[service]})
+	}
+	if err := printResult(os.Stdout, *output, status, t); err != nil {
+		return err
+	}
+
+	if status.Status != health.StatusHealthy {
+		return fmt.Errorf("the family service is %s", status.Status)
+	}
+	return nil
+}
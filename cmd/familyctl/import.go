@@ -0,0 +1,404 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+)
+
+// importer imports the records of a file through the family service, in batches. Every record is imported
+// on its own, so that the valid records are imported even when others fail; the records that fail are
+// reported, and written to the error file if there is one.
+type importer struct {
+	service    ports.FamilyService
+	kind       string
+	dryRun     bool
+	batchSize  int
+	errors     *errorWriter
+	checkpoint *checkpoint
+	report     io.Writer
+
+	// parentIDs caches the IDs of the parents of the imported children by lower-cased email
+	parentIDs map[string]uuid.UUID
+
+	// emails holds the lower-cased emails of the valid parents of a dry run, which are not stored,
+	// to report the parents that have the email of an earlier parent of the file
+	emails map[string]bool
+}
+
+// importResult counts the records of an import
+type importResult struct {
+	// Read is the number of records read from the file
+	Read int
+
+	// Skipped is the number of records skipped, as the checkpoint records that they were processed before
+	Skipped int
+
+	// Imported is the number of records imported, or found valid by a dry run
+	Imported int
+
+	// Failed is the number of records that failed
+	Failed int
+}
+
+// runImport runs the import command
+func runImport(ctx context.Context, args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return usageError("import needs the kind of records to import: parents or children")
+	}
+	kind := args[0]
+	if err := checkKind(kind); err != nil {
+		return usageError(err.Error())
+	}
+
+	flags := newFlagSet("import "+kind, "familyctl import "+kind+" [flags] FILE")
+	format := flags.String("format", "", "the format of the file, csv or ndjson; by default, from the extension of the file, or ndjson")
+	dryRun := flags.Bool("dry-run", false, "only validate the records, and report those that would fail")
+	errorsPath := flags.String("errors", "", "the file to write the records that fail to, with their errors, in the format of the imported file")
+	checkpointPath := flags.String("checkpoint", "", "the file recording how far the import got, to resume it where it stopped")
+	batchSize := flags.Int("batch-size", ports.MaxBatchSize, fmt.Sprintf("how many records to import at once, at most %d", ports.MaxBatchSize))
+	tenant := flags.String("tenant", "", "the tenant to import the records into; the default tenant if empty")
+	actor := actorFlag(flags)
+	if err := parseFlags(flags, args[1:]); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return usageError("import needs the file to import, or - for standard input")
+	}
+	path := flags.Arg(0)
+	if *format == "" {
+		*format = detectFormat(path)
+	}
+	if err := checkFormat(*format); err != nil {
+		return usageError(err.Error())
+	}
+	if *batchSize <= 0 || *batchSize > ports.MaxBatchSize {
+		return usageError(fmt.Sprintf("-batch-size must be between 1 and %d", ports.MaxBatchSize))
+	}
+	if *dryRun && *checkpointPath != "" {
+		return usageError("-checkpoint cannot be used with -dry-run, which imports nothing")
+	}
+
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open the import file: %w", err)
+		}
+		defer file.Close()
+		r = file
+	}
+	reader, err := newRecordReader(r, *format)
+	if err != nil {
+		return err
+	}
+
+	im := &importer{
+		kind:      kind,
+		dryRun:    *dryRun,
+		batchSize: *batchSize,
+		report:    os.Stdout,
+		parentIDs: make(map[string]uuid.UUID),
+		emails:    make(map[string]bool),
+	}
+
+	if *checkpointPath != "" {
+		if im.checkpoint, err = loadCheckpoint(*checkpointPath, kind); err != nil {
+			return err
+		}
+	}
+
+	// The error file of a resumed import keeps the records that failed before
+	if *errorsPath != "" {
+		flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+		if im.checkpoint != nil && im.checkpoint.Records > 0 {
+			flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		}
+		file, err := os.OpenFile(*errorsPath, flags, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open the error file: %w", err)
+		}
+		defer file.Close()
+
+		info, err := file.Stat()
+		if err != nil {
+			return fmt.Errorf("failed to open the error file: %w", err)
+		}
+		if im.errors, err = newErrorWriter(file, *format, reader.header, info.Size() == 0); err != nil {
+			return err
+		}
+	}
+
+	container, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeContainer(container)
+	im.service = container.GetFamilyService()
+
+	result, err := im.run(withCaller(ctx, *tenant, *actor), reader)
+	im.printResult(result)
+	if err != nil {
+		if im.checkpoint != nil {
+			return fmt.Errorf("%w; run the import again with the same -checkpoint to resume it", err)
+		}
+		return err
+	}
+	if result.Failed > 0 {
+		return fmt.Errorf("%d of the %d records failed", result.Failed, result.Read-result.Skipped)
+	}
+	return nil
+}
+
+// run imports the records of the file, skipping those the checkpoint records as processed.
+// The checkpoint is saved after every batch.
+func (im *importer) run(ctx context.Context, reader *recordReader) (importResult, error) {
+	var result importResult
+
+	processed := 0
+	if im.checkpoint != nil {
+		processed = im.checkpoint.Records
+	}
+
+	batch := make([]rawRecord, 0, im.batchSize)
+	for {
+		raw, err := reader.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return result, err
+		}
+
+		result.Read++
+		if raw.Number <= processed {
+			result.Skipped++
+			continue
+		}
+
+		batch = append(batch, raw)
+		if len(batch) == im.batchSize {
+			if err := im.process(ctx, batch, &result); err != nil {
+				return result, err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) > 0 {
+		if err := im.process(ctx, batch, &result); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// process imports a batch of records, reports those that failed, and saves the checkpoint
+func (im *importer) process(ctx context.Context, batch []rawRecord, result *importResult) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var errs []error
+	var err error
+	if im.kind == kindParents {
+		errs, err = im.importParents(ctx, batch)
+	} else {
+		errs, err = im.importChildren(ctx, batch)
+	}
+	if err != nil {
+		return err
+	}
+
+	for i, raw := range batch {
+		if errs[i] == nil {
+			result.Imported++
+			continue
+		}
+
+		result.Failed++
+		fmt.Fprintf(im.report, "record %d: %v\n", raw.Number, errs[i])
+		if im.errors != nil {
+			if err := im.errors.write(raw, errs[i]); err != nil {
+				return fmt.Errorf("failed to write the error file: %w", err)
+			}
+		}
+	}
+
+	if im.errors != nil {
+		if err := im.errors.flush(); err != nil {
+			return fmt.Errorf("failed to write the error file: %w", err)
+		}
+	}
+	if im.checkpoint != nil {
+		return im.checkpoint.save(batch[len(batch)-1].Number)
+	}
+	return nil
+}
+
+// importParents imports a batch of parents, and returns the error of every record, or nil for those imported
+func (im *importer) importParents(ctx context.Context, batch []rawRecord) ([]error, error) {
+	errs := make([]error, len(batch))
+	inputs := make([]ports.ParentInput, 0, len(batch))
+	positions := make([]int, 0, len(batch))
+	for i, raw := range batch {
+		record, err := decodeParent(raw)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+
+		inputs = append(inputs, ports.ParentInput{
+			FirstName: record.FirstName,
+			LastName:  record.LastName,
+			Email:     record.Email,
+			BirthDate: record.BirthDate,
+			Contact:   record.Contact,
+		})
+		positions = append(positions, i)
+	}
+	if len(inputs) == 0 {
+		return errs, nil
+	}
+
+	if im.dryRun {
+		results, err := im.service.ValidateParents(ctx, inputs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate parents: %w", err)
+		}
+		for j, err := range results {
+			// The valid parents are not stored, so their emails are checked against the earlier parents of the file
+			if err == nil {
+				email := strings.ToLower(inputs[j].Email)
+				if im.emails[email] {
+					err = domain.NewDuplicateError("Parent", "email", inputs[j].Email)
+				}
+				im.emails[email] = true
+			}
+			errs[positions[j]] = err
+		}
+		return errs, nil
+	}
+
+	results, err := im.service.CreateParents(ctx, inputs, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create parents: %w", err)
+	}
+	for j, result := range results {
+		errs[positions[j]] = result.Err
+	}
+	return errs, nil
+}
+
+// importChildren imports a batch of children, and returns the error of every record, or nil for those imported
+func (im *importer) importChildren(ctx context.Context, batch []rawRecord) ([]error, error) {
+	errs := make([]error, len(batch))
+	inputs := make([]ports.ChildInput, 0, len(batch))
+	positions := make([]int, 0, len(batch))
+	for i, raw := range batch {
+		record, err := decodeChild(raw)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+
+		parentID, recordErr, err := im.parentOf(ctx, record)
+		if err != nil {
+			return nil, err
+		}
+		if recordErr != nil {
+			errs[i] = recordErr
+			continue
+		}
+
+		inputs = append(inputs, ports.ChildInput{
+			FirstName: record.FirstName,
+			LastName:  record.LastName,
+			BirthDate: record.BirthDate,
+			ParentID:  parentID,
+		})
+		positions = append(positions, i)
+	}
+	if len(inputs) == 0 {
+		return errs, nil
+	}
+
+	if im.dryRun {
+		results, err := im.service.ValidateChildren(ctx, inputs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate children: %w", err)
+		}
+		for j, err := range results {
+			errs[positions[j]] = err
+		}
+		return errs, nil
+	}
+
+	results, err := im.service.CreateChildren(ctx, inputs, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create children: %w", err)
+	}
+	for j, result := range results {
+		errs[positions[j]] = result.Err
+	}
+	return errs, nil
+}
+
+// parentOf returns the ID of the parent of an imported child: the parent with the parent email of the record
+// if it has one, and its parent ID otherwise.
+// Returns:
+//   - uuid.UUID: The ID of the parent
+//   - error: The error of the record, if it names no parent or a parent that does not exist
+//   - error: An error if the parent cannot be looked up
+func (im *importer) parentOf(ctx context.Context, record childRecord) (uuid.UUID, error, error) {
+	if record.ParentEmail == "" {
+		if record.ParentID == "" {
+			return uuid.Nil, domain.NewValidationError("Child", "parentEmail", "either the email or the ID of the parent is required"), nil
+		}
+		id, err := uuid.Parse(record.ParentID)
+		if err != nil {
+			return uuid.Nil, domain.NewValidationError("Child", "parentId", "must be a valid UUID"), nil
+		}
+		return id, nil, nil
+	}
+
+	email := strings.ToLower(record.ParentEmail)
+	if id, ok := im.parentIDs[email]; ok {
+		return id, nil, nil
+	}
+
+	// The email filter matches parts of emails, so the parent is the one with the whole email
+	parents, _, err := im.service.ListParents(ctx, ports.QueryOptions{
+		Filter:     ports.FilterOptions{Email: record.ParentEmail},
+		Pagination: ports.PaginationOptions{PageSize: defaultPageSize},
+	})
+	if err != nil {
+		return uuid.Nil, nil, fmt.Errorf("failed to find the parent with email %s: %w", record.ParentEmail, err)
+	}
+	for _, parent := range parents {
+		if strings.EqualFold(parent.Email, record.ParentEmail) {
+			im.parentIDs[email] = parent.ID
+			return parent.ID, nil, nil
+		}
+	}
+
+	return uuid.Nil, fmt.Errorf("no parent has the email %s: %w", record.ParentEmail, domain.ErrNotFound), nil
+}
+
+// printResult prints how many records were imported and failed
+func (im *importer) printResult(result importResult) {
+	if im.dryRun {
+		fmt.Fprintf(im.report, "Dry run of %d %s: %d valid, %d invalid\n", result.Read, im.kind, result.Imported, result.Failed)
+		return
+	}
+	fmt.Fprintf(im.report, "Read %d %s: %d imported, %d failed, %d skipped as processed before\n",
+		result.Read, im.kind, result.Imported, result.Failed, result.Skipped)
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/mocks"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestImporter returns an importer of the given kind of records through a mock family service
+func newTestImporter(kind string, service ports.FamilyService) (*importer, *bytes.Buffer) {
+	var report bytes.Buffer
+	return &importer{
+		service:   service,
+		kind:      kind,
+		batchSize: 2,
+		report:    &report,
+		parentIDs: make(map[string]uuid.UUID),
+		emails:    make(map[string]bool),
+	}, &report
+}
+
+// parentsCSV is an import file of five parents, the fourth of which has an invalid birth date
+const parentsCSV = `first_name,last_name,email,birth_date
+John,Doe,john@example.com,1980-01-02
+Jane,Doe,jane@example.com,1981-02-03
+Jim,Roe,jim@example.com,1982-03-04
+Jill,Roe,jill@example.com,yesterday
+Jack,Poe,jack@example.com,1984-05-06
+`
+
+// createParents creates parents like the family service does, failing those with an invalid birth date
+func createParents(created *[]string) func(ctx context.Context, inputs []ports.ParentInput, atomic bool) ([]ports.ParentResult, error) {
+	return func(ctx context.Context, inputs []ports.ParentInput, atomic bool) ([]ports.ParentResult, error) {
+		results := make([]ports.ParentResult, len(inputs))
+		for i, input := range inputs {
+			if input.BirthDate == "yesterday" {
+				results[i].Err = domain.NewValidationError("Parent", "birthDate", "must be a valid date")
+				continue
+			}
+			*created = append(*created, input.Email)
+			results[i].Parent = &domain.Parent{ID: uuid.New(), Email: input.Email}
+		}
+		return results, nil
+	}
+}
+
+func TestImporter_Parents(t *testing.T) {
+	// Arrange
+	var created []string
+	service := mocks.NewMockFamilyService()
+	service.CreateParentsFunc = createParents(&created)
+
+	dir := t.TempDir()
+	im, report := newTestImporter(kindParents, service)
+	var errorsFile bytes.Buffer
+	reader, err := newRecordReader(strings.NewReader(parentsCSV), formatCSV)
+	require.NoError(t, err)
+	im.errors, err = newErrorWriter(&errorsFile, formatCSV, reader.header, true)
+	require.NoError(t, err)
+	im.checkpoint, err = loadCheckpoint(filepath.Join(dir, "parents.checkpoint"), kindParents)
+	require.NoError(t, err)
+
+	// Act
+	result, err := im.run(context.Background(), reader)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, importResult{Read: 5, Imported: 4, Failed: 1}, result)
+	assert.Equal(t, []string{"john@example.com", "jane@example.com", "jim@example.com", "jack@example.com"}, created)
+	assert.Contains(t, report.String(), "record 4: ")
+	assert.Equal(t, "first_name,last_name,email,birth_date,row,error\n"+
+		"Jill,Roe,jill@example.com,yesterday,4,validation failed for Parent: field birth date must be a valid date\n", errorsFile.String())
+
+	saved, err := loadCheckpoint(filepath.Join(dir, "parents.checkpoint"), kindParents)
+	require.NoError(t, err)
+	assert.Equal(t, 5, saved.Records)
+}
+
+func TestImporter_ResumesFromCheckpoint(t *testing.T) {
+	// Arrange
+	var created []string
+	service := mocks.NewMockFamilyService()
+	service.CreateParentsFunc = createParents(&created)
+
+	path := filepath.Join(t.TempDir(), "parents.checkpoint")
+	cp, err := loadCheckpoint(path, kindParents)
+	require.NoError(t, err)
+	require.NoError(t, cp.save(2))
+
+	im, _ := newTestImporter(kindParents, service)
+	im.checkpoint, err = loadCheckpoint(path, kindParents)
+	require.NoError(t, err)
+	reader, err := newRecordReader(strings.NewReader(parentsCSV), formatCSV)
+	require.NoError(t, err)
+
+	// Act
+	result, err := im.run(context.Background(), reader)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, importResult{Read: 5, Skipped: 2, Imported: 2, Failed: 1}, result)
+	assert.Equal(t, []string{"jim@example.com", "jack@example.com"}, created)
+}
+
+func TestImporter_ServiceErrorKeepsCheckpoint(t *testing.T) {
+	// Arrange
+	calls := 0
+	service := mocks.NewMockFamilyService()
+	service.CreateParentsFunc = func(ctx context.Context, inputs []ports.ParentInput, atomic bool) ([]ports.ParentResult, error) {
+		calls++
+		if calls == 2 {
+			return nil, domain.NewDatabaseError("create", "Parent", errors.New("connection refused"))
+		}
+		return make([]ports.ParentResult, len(inputs)), nil
+	}
+
+	path := filepath.Join(t.TempDir(), "parents.checkpoint")
+	im, _ := newTestImporter(kindParents, service)
+	var err error
+	im.checkpoint, err = loadCheckpoint(path, kindParents)
+	require.NoError(t, err)
+	reader, err := newRecordReader(strings.NewReader(parentsCSV), formatCSV)
+	require.NoError(t, err)
+
+	// Act
+	result, err := im.run(context.Background(), reader)
+
+	// Assert
+	require.Error(t, err)
+	assert.Equal(t, 2, result.Imported)
+
+	saved, err := loadCheckpoint(path, kindParents)
+	require.NoError(t, err)
+	assert.Equal(t, 2, saved.Records)
+}
+
+func TestImporter_DryRun(t *testing.T) {
+	// Arrange
+	input := `{"firstName":"John","lastName":"Doe","email":"john@example.com","birthDate":"1980-01-02"}
+{"firstName":"Jane","lastName":"Doe","email":"jane@example.com","birthDate":"1981-02-03"}
+{"firstName":"Johnny","lastName":"Doe","email":"JOHN@example.com","birthDate":"1982-03-04"}
+`
+	service := mocks.NewMockFamilyService()
+	service.CreateParentsFunc = func(ctx context.Context, inputs []ports.ParentInput, atomic bool) ([]ports.ParentResult, error) {
+		t.Fatal("a dry run must not create parents")
+		return nil, nil
+	}
+
+	im, report := newTestImporter(kindParents, service)
+	im.dryRun = true
+	reader, err := newRecordReader(strings.NewReader(input), formatNDJSON)
+	require.NoError(t, err)
+
+	// Act
+	result, err := im.run(context.Background(), reader)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, importResult{Read: 3, Imported: 2, Failed: 1}, result)
+
+	// The third parent has the email of the first, which is in an earlier batch
+	assert.Contains(t, report.String(), "record 3: ")
+}
+
+func TestImporter_Children(t *testing.T) {
+	// Arrange
+	parentID := uuid.New()
+	otherParentID := uuid.New()
+	input := "first_name,last_name,birth_date,parent_email,parent_id\n" +
+		"Jane,Doe,2015-06-01,JOHN@example.com,\n" +
+		"Jim,Doe,2016-07-02,john@example.com,\n" +
+		"Jill,Roe,2017-08-03,,\n" +
+		"Jack,Roe,2018-09-04,,not-an-id\n" +
+		"June,Poe,2019-10-05,,\" " + otherParentID.String() + "\"\n" +
+		"Joe,Poe,2019-10-05,nobody@example.com,\n"
+
+	lookups := 0
+	service := mocks.NewMockFamilyService()
+	service.ListParentsFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
+		lookups++
+		assert.Equal(t, 0, options.Pagination.Page)
+
+		// The email filter matches parts of emails
+		if strings.Contains("john@example.com", strings.ToLower(options.Filter.Email)) {
+			return []*domain.Parent{
+				{ID: uuid.New(), Email: "big.john@example.com"},
+				{ID: parentID, Email: "john@example.com"},
+			}, &ports.PagedResult{}, nil
+		}
+		return nil, &ports.PagedResult{}, nil
+	}
+	var parentIDs []uuid.UUID
+	service.CreateChildrenFunc = func(ctx context.Context, inputs []ports.ChildInput, atomic bool) ([]ports.ChildResult, error) {
+		for _, input := range inputs {
+			parentIDs = append(parentIDs, input.ParentID)
+		}
+		return make([]ports.ChildResult, len(inputs)), nil
+	}
+
+	im, report := newTestImporter(kindChildren, service)
+	reader, err := newRecordReader(strings.NewReader(input), formatCSV)
+	require.NoError(t, err)
+
+	// Act
+	result, err := im.run(context.Background(), reader)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, importResult{Read: 6, Imported: 3, Failed: 3}, result)
+	assert.Equal(t, []uuid.UUID{parentID, parentID, otherParentID}, parentIDs)
+
+	// The parent of the second child comes from the cache
+	assert.Equal(t, 2, lookups)
+
+	assert.Contains(t, report.String(), "record 3: ")
+	assert.Contains(t, report.String(), "record 4: ")
+	assert.Contains(t, report.String(), "record 6: no parent has the email nobody@example.com")
+}
+
+func TestCheckpoint_KindMismatch(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	cp, err := loadCheckpoint(path, kindParents)
+	require.NoError(t, err)
+	require.NoError(t, cp.save(3))
+
+	// Act
+	_, err = loadCheckpoint(path, kindChildren)
+
+	// Assert
+	assert.Error(t, err)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "the temporary file of the checkpoint is removed")
+}
@@ -0,0 +1,179 @@
+// Package main is the entry point for familyctl, the command line tool of the family service.
+// Its commands go through the family service, as the GraphQL API does, against the database selected
+// by the configuration of the service, so that the records they change are validated, audited and
+// published in the same way.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/infrastructure/config"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/infrastructure/di"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/infrastructure/logging"
+	"go.uber.org/zap"
+)
+
+// usage describes the commands of familyctl
+const usage = `familyctl is the command line tool of the family service.
+
+Usage:
+  familyctl get parent|child [flags] ID
+  familyctl list parents|children [flags]
+  familyctl count parents|children [flags]
+  familyctl delete parent|child [flags] ID
+  familyctl restore parent|child [flags] ID
+  familyctl transfer child -from PARENT_ID -to PARENT_ID -reason REASON [flags] ID
+  familyctl export parents|children [flags]
+  familyctl import parents|children [flags] FILE
+  familyctl token -user USER_ID [-roles ROLES] [flags]
+  familyctl health [flags]
+  familyctl migrate up|down [flags] [N]
+  familyctl migrate goto|force [flags] VERSION
+  familyctl migrate status|redo [flags]
+
+The results are printed as a table, or in JSON or YAML with -output json or -output yaml.
+
+The database and the other settings are read from the configuration of the service,
+which APP_ENV and the environment variables select, as for the server.
+The migrate command connects to the database only, so that it can run before the service starts.
+Run familyctl COMMAND KIND -h, or familyctl COMMAND -h, for the flags of a command.
+`
+
+// usageError is an error in the arguments of a command
+type usageError string
+
+// Error returns the message of the usage error
+func (e usageError) Error() string {
+	return string(e)
+}
+
+// errHelp is returned by commands that printed their usage because it was asked for
+var errHelp = errors.New("help requested")
+
+func main() {
+	// Interrupting a command stops it between two pages or batches of records
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:])
+	stop()
+	os.Exit(code)
+}
+
+// run runs the command named by the arguments, and returns the exit code of familyctl
+func run(ctx context.Context, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+
+	var err error
+	switch args[0] {
+	case "get":
+		err = runGet(ctx, args[1:])
+	case "list":
+		err = runList(ctx, args[1:])
+	case "count":
+		err = runCount(ctx, args[1:])
+	case "delete":
+		err = runDelete(ctx, args[1:])
+	case "restore":
+		err = runRestore(ctx, args[1:])
+	case "transfer":
+		err = runTransfer(ctx, args[1:])
+	case "token":
+		err = runToken(ctx, args[1:])
+	case "health":
+		err = runHealth(ctx, args[1:])
+	case "export":
+		err = runExport(ctx, args[1:])
+	case "import":
+		err = runImport(ctx, args[1:])
+	case "migrate":
+		err = runMigrate(ctx, args[1:])
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(os.Stdout, usage)
+		return 0
+	default:
+		err = usageError(fmt.Sprintf("unknown command %q", args[0]))
+	}
+
+	var usageErr usageError
+	switch {
+	case err == nil, errors.Is(err, errHelp):
+		return 0
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(os.Stderr, "familyctl: %v\nRun 'familyctl help' for usage.\n", err)
+		return 2
+	default:
+		fmt.Fprintf(os.Stderr, "familyctl: %v\n", err)
+		return 1
+	}
+}
+
+// newFlagSet returns the flag set of a command, whose usage starts with the given synopsis
+func newFlagSet(name, synopsis string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s\n\nFlags:\n", synopsis)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// parseFlags parses the flags of a command, and prints its usage if it is asked for
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	// The errors are reported by run, with the other usage errors
+	flags.SetOutput(io.Discard)
+	err := flags.Parse(args)
+	flags.SetOutput(os.Stderr)
+
+	if errors.Is(err, flag.ErrHelp) {
+		flags.Usage()
+		return errHelp
+	}
+	if err != nil {
+		return usageError(err.Error())
+	}
+	return nil
+}
+
+// connect loads the configuration of the family service and builds its container
+func connect(ctx context.Context) (*di.Container, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load application configuration: %w", err)
+	}
+	return newContainer(ctx, cfg)
+}
+
+// newContainer builds the container of the family service, which connects to the database the configuration
+// selects. The logs go to standard error, as the results of the commands can go to standard output.
+// The relay and the webhook workers are left to the server, which would otherwise run them twice.
+func newContainer(ctx context.Context, cfg *config.Config) (*di.Container, error) {
+	logger, err := logging.NewLoggerWithOutput(cfg.Log.Level, cfg.Log.Development, os.Stderr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize logger: %w", err)
+	}
+
+	container, err := di.NewContainer(ctx, logger, cfg, di.WithoutBackgroundWorkers())
+	if err != nil {
+		_ = logger.Sync()
+		return nil, fmt.Errorf("failed to initialize dependency injection container: %w", err)
+	}
+	return container, nil
+}
+
+// closeContainer closes the connections of a container and flushes its logs
+func closeContainer(container *di.Container) {
+	logger := container.GetLogger()
+	if err := container.Close(); err != nil {
+		logger.Error("Error closing container", zap.Error(err))
+	}
+	_ = logger.Sync()
+}
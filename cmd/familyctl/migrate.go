@@ -0,0 +1,334 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	mongomigrations "github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/mongodb/migrations"
+	pgmigrations "github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/postgres/migrations"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/infrastructure/config"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/infrastructure/logging"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Databases whose migrations familyctl manages, as named by the configuration
+const (
+	databaseMongoDB  = "mongodb"
+	databasePostgres = "postgres"
+)
+
+// States of migrations
+const (
+	statePending = "pending"
+	stateApplied = "applied"
+
+	// stateDrifted is the state of an applied migration whose definition changed after it was applied
+	stateDrifted = "drifted"
+
+	// stateUnknown is the state of an applied migration this version of the service does not know
+	stateUnknown = "unknown"
+)
+
+// migrator applies and reverts the migrations of a database, through its migration manager
+type migrator interface {
+	Up(ctx context.Context, n int) error
+	Down(ctx context.Context, n int) error
+	Goto(ctx context.Context, version int) error
+	Redo(ctx context.Context) error
+	Force(ctx context.Context, version int) error
+	statuses(ctx context.Context) ([]migrationStatus, error)
+}
+
+// migrationStatus is the state of a migration, as the migrate command prints it
+type migrationStatus struct {
+	Version     int        `json:"version"`
+	Description string     `json:"description"`
+	State       string     `json:"state"`
+	AppliedAt   *time.Time `json:"appliedAt,omitempty"`
+	Duration    string     `json:"duration,omitempty"`
+}
+
+// newMigrationStatus returns the state of a migration as the migrate command prints it
+func newMigrationStatus(version int, description string, applied, registered, drifted bool, appliedAt time.Time, duration time.Duration) migrationStatus {
+	status := migrationStatus{Version: version, Description: description, State: statePending}
+	if applied {
+		switch {
+		case !registered:
+			status.State = stateUnknown
+		case drifted:
+			status.State = stateDrifted
+		default:
+			status.State = stateApplied
+		}
+		at := appliedAt.UTC()
+		status.AppliedAt = &at
+		status.Duration = duration.String()
+	}
+	return status
+}
+
+// postgresMigrator is the migrator of a PostgreSQL database
+type postgresMigrator struct {
+	*pgmigrations.MigrationManager
+}
+
+// statuses returns the state of every migration of the database
+func (m postgresMigrator) statuses(ctx context.Context) ([]migrationStatus, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]migrationStatus, 0, len(statuses))
+	for _, s := range statuses {
+		result = append(result, newMigrationStatus(s.Version, s.Description, s.Applied, s.Registered, s.Drifted, s.AppliedAt, s.Duration))
+	}
+	return result, nil
+}
+
+// mongoMigrator is the migrator of a MongoDB database
+type mongoMigrator struct {
+	*mongomigrations.MigrationManager
+}
+
+// statuses returns the state of every migration of the database
+func (m mongoMigrator) statuses(ctx context.Context) ([]migrationStatus, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]migrationStatus, 0, len(statuses))
+	for _, s := range statuses {
+		result = append(result, newMigrationStatus(s.Version, s.Description, s.Applied, s.Registered, s.Drifted, s.AppliedAt, s.Duration))
+	}
+	return result, nil
+}
+
+// runMigrate runs the migrate command, which applies, reverts and reports the migrations of the database
+// of the configuration. It connects to the database only, so that it works before the service can start.
+// The migration managers keep several processes from migrating at once, and refuse to change a database
+// whose applied migrations were edited since, until force accepts them.
+func runMigrate(ctx context.Context, args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return usageError("migrate needs a subcommand: up, down, status, goto, redo or force")
+	}
+	subcommand := args[0]
+
+	var operand string
+	switch subcommand {
+	case "up", "down":
+		operand = "[N]"
+	case "goto", "force":
+		operand = "VERSION"
+	case "status", "redo":
+	default:
+		return usageError(fmt.Sprintf("unknown migrate subcommand %q, expected up, down, status, goto, redo or force", subcommand))
+	}
+
+	flags := newFlagSet("migrate "+subcommand, strings.TrimSpace("familyctl migrate "+subcommand+" [flags] "+operand))
+	database := flags.String("database", "", "the database to migrate, mongodb or postgres; by default, the database of the configuration")
+	output := outputFlag(flags)
+	if err := parseFlags(flags, args[1:]); err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return usageError(err.Error())
+	}
+	n, err := migrateOperand(subcommand, flags.Args())
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load application configuration: %w", err)
+	}
+	if *database == "" {
+		*database = cfg.Database.Type
+	}
+
+	logger, err := logging.NewLoggerWithOutput(cfg.Log.Level, cfg.Log.Development, os.Stderr)
+	if err != nil {
+		return fmt.Errorf("failed to initialize logger: %w", err)
+	}
+	defer logger.Sync()
+
+	m, timeout, disconnect, err := openMigrator(ctx, cfg, *database, logger)
+	if err != nil {
+		return err
+	}
+	defer disconnect()
+
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	switch subcommand {
+	case "up":
+		err = m.Up(runCtx, n)
+	case "down":
+		err = m.Down(runCtx, n)
+	case "goto":
+		err = m.Goto(runCtx, n)
+	case "redo":
+		err = m.Redo(runCtx)
+	case "force":
+		err = m.Force(runCtx, n)
+	}
+	if err != nil {
+		return err
+	}
+
+	statuses, err := m.statuses(runCtx)
+	if err != nil {
+		return err
+	}
+	return printResult(os.Stdout, *output, statuses, migrationsTable(statuses))
+}
+
+// migrateOperand returns the operand of a migrate subcommand: the number of migrations to apply, 0 for all of
+// them, or to revert, 1 by default, or the version to go to or force
+func migrateOperand(subcommand string, args []string) (int, error) {
+	switch {
+	case len(args) > 1:
+		return 0, usageError(fmt.Sprintf("unexpected argument %q", args[1]))
+	case subcommand == "status" || subcommand == "redo":
+		if len(args) != 0 {
+			return 0, usageError(fmt.Sprintf("unexpected argument %q", args[0]))
+		}
+		return 0, nil
+	case len(args) == 0:
+		switch subcommand {
+		case "up":
+			return 0, nil
+		case "down":
+			return 1, nil
+		default:
+			return 0, usageError(subcommand + " needs the version of a migration")
+		}
+	}
+
+	value, err := strconv.Atoi(args[0])
+	if err != nil || value < 0 || (value == 0 && (subcommand == "up" || subcommand == "down")) {
+		if subcommand == "up" || subcommand == "down" {
+			return 0, usageError(fmt.Sprintf("invalid number of migrations %q", args[0]))
+		}
+		return 0, usageError(fmt.Sprintf("invalid version %q", args[0]))
+	}
+	return value, nil
+}
+
+// openMigrator connects to a database, and returns its migrator, how long the migrations may take,
+// and a function that disconnects from the database
+func openMigrator(ctx context.Context, cfg *config.Config, database string, logger *zap.Logger) (migrator, time.Duration, func(), error) {
+	switch database {
+	case databasePostgres:
+		connectCtx, cancel := context.WithTimeout(ctx, cfg.Database.Postgres.MigrationTimeout)
+		defer cancel()
+
+		poolConfig, err := pgxpool.ParseConfig(cfg.Database.Postgres.DSN)
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to parse connection string: %w", err)
+		}
+		// Migrations change the rows of every tenant, which the tenant isolation policies only admit with app.all_tenants
+		poolConfig.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+			_, err := conn.Exec(ctx, "SELECT set_config('app.all_tenants', 'on', false)")
+			return err
+		}
+
+		pool, err := pgxpool.NewWithConfig(connectCtx, poolConfig)
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to create connection pool: %w", err)
+		}
+		if err := pool.Ping(connectCtx); err != nil {
+			pool.Close()
+			return nil, 0, nil, fmt.Errorf("failed to ping PostgreSQL: %w", err)
+		}
+
+		manager := pgmigrations.NewRegistry(pool, logger).Manager()
+		return postgresMigrator{manager}, cfg.Database.Postgres.MigrationTimeout, pool.Close, nil
+
+	case databaseMongoDB:
+		connectCtx, cancel := context.WithTimeout(ctx, cfg.Database.MongoDB.ConnectionTimeout)
+		defer cancel()
+
+		dbName := extractDatabaseName(cfg.Database.MongoDB.URI)
+		if dbName == "" {
+			return nil, 0, nil, fmt.Errorf("could not extract database name from MongoDB URI")
+		}
+
+		client, err := mongo.Connect(connectCtx, options.Client().ApplyURI(cfg.Database.MongoDB.URI))
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to create MongoDB client: %w", err)
+		}
+		disconnect := func() {
+			disconnectCtx, cancel := context.WithTimeout(context.Background(), cfg.Database.MongoDB.DisconnectTimeout)
+			defer cancel()
+			if err := client.Disconnect(disconnectCtx); err != nil {
+				logger.Error("Failed to disconnect MongoDB client", zap.Error(err))
+			}
+		}
+		if err := client.Ping(connectCtx, nil); err != nil {
+			disconnect()
+			return nil, 0, nil, fmt.Errorf("failed to ping MongoDB: %w", err)
+		}
+
+		manager := mongomigrations.NewRegistry(client.Database(dbName), logger).Manager()
+		return mongoMigrator{manager}, cfg.Database.MongoDB.MigrationTimeout, disconnect, nil
+
+	default:
+		return nil, 0, nil, usageError(fmt.Sprintf("unsupported database %q, expected %s or %s", database, databaseMongoDB, databasePostgres))
+	}
+}
+
+// extractDatabaseName extracts the name of the database from a MongoDB URI
+func extractDatabaseName(uri string) string {
+	// Find the last '/' in the URI
+	lastSlashIndex := strings.LastIndex(uri, "/")
+	if lastSlashIndex == -1 || lastSlashIndex == len(uri)-1 {
+		return ""
+	}
+
+	// Extract the part after the last '/'
+	dbNameWithParams := uri[lastSlashIndex+1:]
+
+	// If there are query parameters, remove them
+	questionMarkIndex := strings.Index(dbNameWithParams, "?")
+	if questionMarkIndex != -1 {
+		return dbNameWithParams[:questionMarkIndex]
+	}
+
+	return dbNameWithParams
+}
+
+// migrationsTable returns the table of the states of migrations
+func migrationsTable(statuses []migrationStatus) table {
+	t := table{header: []string{"VERSION", "DESCRIPTION", "STATE", "APPLIED AT", "DURATION"}}
+
+	current, pending, drifted := 0, 0, 0
+	for _, status := range statuses {
+		appliedAt := ""
+		if status.AppliedAt != nil {
+			appliedAt = formatTime(*status.AppliedAt)
+			current = status.Version
+		}
+		switch status.State {
+		case statePending:
+			pending++
+		case stateDrifted:
+			drifted++
+		}
+		t.rows = append(t.rows, []string{strconv.Itoa(status.Version), status.Description, status.State, appliedAt, status.Duration})
+	}
+
+	t.footer = fmt.Sprintf("At version %d, with %d pending and %d drifted", current, pending, drifted)
+	return t
+}
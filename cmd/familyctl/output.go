@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats of the results of commands
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// outputFlag defines the flag that selects the output format of a command
+func outputFlag(flags *flag.FlagSet) *string {
+	return flags.String("output", outputTable, "the output format: table, json or yaml")
+}
+
+// checkOutput returns an error if an output format is not one familyctl prints
+func checkOutput(format string) error {
+	if format != outputTable && format != outputJSON && format != outputYAML {
+		return fmt.Errorf("unsupported output format %q, expected %s, %s or %s", format, outputTable, outputJSON, outputYAML)
+	}
+	return nil
+}
+
+// table is the result of a command as rows of aligned columns, for people to read
+type table struct {
+	header []string
+	rows   [][]string
+
+	// footer is printed after the rows, such as to tell which page of a list they are
+	footer string
+}
+
+// write writes the table with its columns aligned
+func (t table) write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if t.footer != "" {
+		_, err := fmt.Fprintln(w, t.footer)
+		return err
+	}
+	return nil
+}
+
+// printResult writes the result of a command in the given format: the table, or the result itself
+// in JSON or YAML, with the same field names as the JSON
+func printResult(w io.Writer, format string, result any, t table) error {
+	switch format {
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	case outputYAML:
+		return writeYAML(w, result)
+	default:
+		return t.write(w)
+	}
+}
+
+// writeYAML writes a result in YAML. The result is encoded in JSON first, which YAML reads, so that
+// the fields keep the names and order of the JSON, which the domain entities define.
+func writeYAML(w io.Writer, result any) error {
+	b, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return err
+	}
+	blockStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// blockStyle clears the JSON styles of the nodes of a YAML document, so that it is written in block style,
+// with the strings quoted only when they need to be
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// parentsTable returns the table of parents
+func parentsTable(parents ...*domain.Parent) table {
+	t := table{header: []string{"ID", "FIRST NAME", "LAST NAME", "EMAIL", "BIRTH DATE", "VERSION", "DELETED AT"}}
+	for _, parent := range parents {
+		t.rows = append(t.rows, []string{
+			parent.ID.String(),
+			parent.FirstName,
+			parent.LastName,
+			parent.Email,
+			parent.BirthDate.UTC().Format(time.DateOnly),
+			fmt.Sprint(parent.Version),
+			formatDeletedAt(parent.DeletedAt),
+		})
+	}
+	return t
+}
+
+// childrenTable returns the table of children
+func childrenTable(children ...*domain.Child) table {
+	t := table{header: []string{"ID", "FIRST NAME", "LAST NAME", "BIRTH DATE", "PARENT ID", "VERSION", "DELETED AT"}}
+	for _, child := range children {
+		t.rows = append(t.rows, []string{
+			child.ID.String(),
+			child.FirstName,
+			child.LastName,
+			child.BirthDate.UTC().Format(time.DateOnly),
+			child.ParentID.String(),
+			fmt.Sprint(child.Version),
+			formatDeletedAt(child.DeletedAt),
+		})
+	}
+	return t
+}
+
+// formatDeletedAt formats when a record was deleted, or returns an empty string if it is not deleted
+func formatDeletedAt(deletedAt *time.Time) string {
+	if deletedAt == nil {
+		return ""
+	}
+	return formatTime(*deletedAt)
+}
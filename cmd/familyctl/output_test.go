@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testParent returns a parent to print
+func testParent() *domain.Parent {
+	return &domain.Parent{
+		ID:        uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "john.doe@example.com",
+		BirthDate: time.Date(1980, 1, 2, 0, 0, 0, 0, time.UTC),
+		CreatedAt: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		Version:   2,
+	}
+}
+
+func TestPrintResult_Table(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	parent := testParent()
+	result := parentsResult{Parents: []*domain.Parent{parent}, TotalCount: 1, PageSize: 10}
+	tbl := parentsTable(parent)
+	tbl.footer = pageFooter(0, 1, 1, kindParents)
+
+	// Act
+	err := printResult(&buf, outputTable, result, tbl)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"ID                                    FIRST NAME  LAST NAME  EMAIL                 BIRTH DATE  VERSION  DELETED AT\n"+
+		"11111111-1111-1111-1111-111111111111  John        Doe        john.doe@example.com  1980-01-02  2        \n"+
+		"Page 0: 1 of 1 parents\n", buf.String())
+}
+
+func TestPrintResult_JSON(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := printResult(&buf, outputJSON, countResult{Kind: kindChildren, Count: 3}, table{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"kind\": \"children\",\n  \"count\": 3\n}\n", buf.String())
+}
+
+func TestPrintResult_YAML(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	parent := testParent()
+
+	// Act
+	err := printResult(&buf, outputYAML, parent, parentsTable(parent))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"id: 11111111-1111-1111-1111-111111111111\n"+
+		"firstName: John\n"+
+		"lastName: Doe\n"+
+		"email: john.doe@example.com\n"+
+		"birthDate: \"1980-01-02T00:00:00Z\"\n"+
+		"createdAt: \"2024-03-01T10:00:00Z\"\n"+
+		"updatedAt: \"2024-03-01T10:00:00Z\"\n"+
+		"version: 2\n", buf.String())
+}
+
+func TestCheckOutput(t *testing.T) {
+	assert.NoError(t, checkOutput(outputTable))
+	assert.NoError(t, checkOutput(outputJSON))
+	assert.NoError(t, checkOutput(outputYAML))
+	assert.Error(t, checkOutput("xml"))
+}
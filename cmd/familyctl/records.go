@@ -0,0 +1,411 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+)
+
+// Formats of the files familyctl reads and writes
+const (
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+)
+
+// Kinds of records
+const (
+	kindParents  = "parents"
+	kindChildren = "children"
+)
+
+// Columns of the CSV files of parents and children. The contact details of a parent are a JSON object
+// in the contact column, as they hold lists.
+var (
+	parentColumns = []string{"id", "first_name", "last_name", "email", "birth_date", "contact", "created_at", "updated_at", "deleted_at"}
+	childColumns  = []string{"id", "first_name", "last_name", "birth_date", "parent_id", "parent_email", "created_at", "updated_at", "deleted_at"}
+)
+
+// Fields added to the rows of an error file
+const (
+	rowField   = "row"
+	errorField = "error"
+)
+
+// parentRecord is a parent as a line of an NDJSON file or a row of a CSV file
+type parentRecord struct {
+	ID        string                 `json:"id,omitempty"`
+	FirstName string                 `json:"firstName"`
+	LastName  string                 `json:"lastName"`
+	Email     string                 `json:"email"`
+	BirthDate string                 `json:"birthDate"`
+	Contact   *domain.ContactDetails `json:"contact,omitempty"`
+	CreatedAt string                 `json:"createdAt,omitempty"`
+	UpdatedAt string                 `json:"updatedAt,omitempty"`
+	DeletedAt string                 `json:"deletedAt,omitempty"`
+}
+
+// childRecord is a child as a line of an NDJSON file or a row of a CSV file.
+// The parent of an imported child is the parent with ParentEmail if it is given, as the IDs of the parents
+// of another system are not known, and the parent with ParentID otherwise.
+type childRecord struct {
+	ID          string `json:"id,omitempty"`
+	FirstName   string `json:"firstName"`
+	LastName    string `json:"lastName"`
+	BirthDate   string `json:"birthDate"`
+	ParentID    string `json:"parentId,omitempty"`
+	ParentEmail string `json:"parentEmail,omitempty"`
+	CreatedAt   string `json:"createdAt,omitempty"`
+	UpdatedAt   string `json:"updatedAt,omitempty"`
+	DeletedAt   string `json:"deletedAt,omitempty"`
+}
+
+// newParentRecord returns the record of a parent
+func newParentRecord(parent *domain.Parent) parentRecord {
+	record := parentRecord{
+		ID:        parent.ID.String(),
+		FirstName: parent.FirstName,
+		LastName:  parent.LastName,
+		Email:     parent.Email,
+		BirthDate: formatTime(parent.BirthDate),
+		CreatedAt: formatTime(parent.CreatedAt),
+		UpdatedAt: formatTime(parent.UpdatedAt),
+	}
+	if parent.DeletedAt != nil {
+		record.DeletedAt = formatTime(*parent.DeletedAt)
+	}
+	if len(parent.Phones) > 0 || parent.Address != nil || len(parent.EmergencyContacts) > 0 {
+		contact := parent.ContactDetails
+		record.Contact = &contact
+	}
+	return record
+}
+
+// newChildRecord returns the record of a child, whose parent has the given email
+func newChildRecord(child *domain.Child, parentEmail string) childRecord {
+	record := childRecord{
+		ID:          child.ID.String(),
+		FirstName:   child.FirstName,
+		LastName:    child.LastName,
+		BirthDate:   formatTime(child.BirthDate),
+		ParentID:    child.ParentID.String(),
+		ParentEmail: parentEmail,
+		CreatedAt:   formatTime(child.CreatedAt),
+		UpdatedAt:   formatTime(child.UpdatedAt),
+	}
+	if child.DeletedAt != nil {
+		record.DeletedAt = formatTime(*child.DeletedAt)
+	}
+	return record
+}
+
+// row returns the CSV row of a parent, in the order of parentColumns
+func (r parentRecord) row() ([]string, error) {
+	contact := ""
+	if r.Contact != nil {
+		b, err := json.Marshal(r.Contact)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode the contact details of parent %s: %w", r.ID, err)
+		}
+		contact = string(b)
+	}
+	return []string{r.ID, r.FirstName, r.LastName, r.Email, r.BirthDate, contact, r.CreatedAt, r.UpdatedAt, r.DeletedAt}, nil
+}
+
+// row returns the CSV row of a child, in the order of childColumns
+func (r childRecord) row() ([]string, error) {
+	return []string{r.ID, r.FirstName, r.LastName, r.BirthDate, r.ParentID, r.ParentEmail, r.CreatedAt, r.UpdatedAt, r.DeletedAt}, nil
+}
+
+// formatTime formats a time of a record in RFC3339, in UTC
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// normalizeDate returns a birth date in the RFC3339 format the family service expects. Legacy systems often
+// hold dates without a time, such as 2015-01-02, which are taken as midnight UTC. Other values are returned
+// as they are, for the family service to reject them.
+func normalizeDate(value string) string {
+	value = strings.TrimSpace(value)
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return formatTime(date)
+	}
+	return value
+}
+
+// detectFormat returns the format of a file from its extension, or NDJSON when the extension is unknown
+func detectFormat(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return formatCSV
+	}
+	return formatNDJSON
+}
+
+// checkFormat returns an error if a format is not one familyctl reads and writes
+func checkFormat(format string) error {
+	if format != formatCSV && format != formatNDJSON {
+		return fmt.Errorf("unsupported format %q, expected %s or %s", format, formatCSV, formatNDJSON)
+	}
+	return nil
+}
+
+// checkKind returns an error if a kind of records is not one familyctl knows
+func checkKind(kind string) error {
+	if kind != kindParents && kind != kindChildren {
+		return fmt.Errorf("unsupported kind of records %q, expected %s or %s", kind, kindParents, kindChildren)
+	}
+	return nil
+}
+
+// recordWriter writes the records of an export file
+type recordWriter struct {
+	csv  *csv.Writer
+	json *json.Encoder
+}
+
+// newRecordWriter returns a writer of records in the given format. A CSV file starts with the given columns.
+func newRecordWriter(w io.Writer, format string, columns []string) (*recordWriter, error) {
+	if format == formatNDJSON {
+		return &recordWriter{json: json.NewEncoder(w)}, nil
+	}
+
+	writer := &recordWriter{csv: csv.NewWriter(w)}
+	if err := writer.csv.Write(columns); err != nil {
+		return nil, fmt.Errorf("failed to write the header: %w", err)
+	}
+	return writer, nil
+}
+
+// write writes a record, which is a parentRecord or a childRecord
+func (w *recordWriter) write(record interface{ row() ([]string, error) }) error {
+	if w.json != nil {
+		return w.json.Encode(record)
+	}
+
+	row, err := record.row()
+	if err != nil {
+		return err
+	}
+	return w.csv.Write(row)
+}
+
+// flush writes the buffered records
+func (w *recordWriter) flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// rawRecord is a record of an import file as it was read, before it is decoded
+type rawRecord struct {
+	// Number is the position of the record in the file, from 1: its line in an NDJSON file,
+	// or its row after the header in a CSV file
+	Number int
+
+	// Fields are the values of a CSV row by column
+	Fields map[string]string
+
+	// Row is the CSV row as it was read, and Line the NDJSON line
+	Row  []string
+	Line []byte
+
+	// Err is the error that kept the record from being read, such as a CSV row with too many values
+	Err error
+}
+
+// recordReader reads the records of an import file
+type recordReader struct {
+	csv     *csv.Reader
+	header  []string
+	scanner *bufio.Scanner
+	number  int
+}
+
+// newRecordReader returns a reader of records in the given format. A CSV file must start with a header
+// naming its columns, which can be in any order; unknown columns are ignored.
+func newRecordReader(r io.Reader, format string) (*recordReader, error) {
+	if format == formatNDJSON {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		return &recordReader{scanner: scanner}, nil
+	}
+
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("the file has no header")
+		}
+		return nil, fmt.Errorf("failed to read the header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+	return &recordReader{csv: reader, header: header}, nil
+}
+
+// next returns the next record of the file, or io.EOF at its end. Blank NDJSON lines are skipped,
+// but counted in the numbers of the records.
+func (r *recordReader) next() (rawRecord, error) {
+	if r.scanner != nil {
+		for r.scanner.Scan() {
+			r.number++
+			line := bytes.TrimSpace(r.scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			return rawRecord{Number: r.number, Line: append([]byte(nil), line...)}, nil
+		}
+		if err := r.scanner.Err(); err != nil {
+			return rawRecord{}, fmt.Errorf("failed to read line %d: %w", r.number+1, err)
+		}
+		return rawRecord{}, io.EOF
+	}
+
+	row, err := r.csv.Read()
+	if errors.Is(err, io.EOF) {
+		return rawRecord{}, io.EOF
+	}
+	r.number++
+	record := rawRecord{Number: r.number, Row: row, Fields: make(map[string]string, len(r.header))}
+	if err != nil {
+		// A row with the wrong number of values is reported, and the next rows can still be read
+		if !errors.Is(err, csv.ErrFieldCount) {
+			return rawRecord{}, fmt.Errorf("failed to read row %d: %w", r.number, err)
+		}
+		record.Err = errors.New("the row does not have as many values as the header has columns")
+	}
+	for i, column := range r.header {
+		if i < len(row) {
+			record.Fields[column] = strings.TrimSpace(row[i])
+		}
+	}
+	return record, nil
+}
+
+// decodeParent decodes the record of a parent
+func decodeParent(raw rawRecord) (parentRecord, error) {
+	var record parentRecord
+	if raw.Err != nil {
+		return record, raw.Err
+	}
+
+	if raw.Line != nil {
+		if err := json.Unmarshal(raw.Line, &record); err != nil {
+			return record, fmt.Errorf("invalid JSON: %w", err)
+		}
+	} else {
+		record = parentRecord{
+			ID:        raw.Fields["id"],
+			FirstName: raw.Fields["first_name"],
+			LastName:  raw.Fields["last_name"],
+			Email:     raw.Fields["email"],
+			BirthDate: raw.Fields["birth_date"],
+		}
+		if contact := raw.Fields["contact"]; contact != "" {
+			record.Contact = &domain.ContactDetails{}
+			if err := json.Unmarshal([]byte(contact), record.Contact); err != nil {
+				return record, fmt.Errorf("invalid contact details: %w", err)
+			}
+		}
+	}
+
+	record.BirthDate = normalizeDate(record.BirthDate)
+	return record, nil
+}
+
+// decodeChild decodes the record of a child
+func decodeChild(raw rawRecord) (childRecord, error) {
+	var record childRecord
+	if raw.Err != nil {
+		return record, raw.Err
+	}
+
+	if raw.Line != nil {
+		if err := json.Unmarshal(raw.Line, &record); err != nil {
+			return record, fmt.Errorf("invalid JSON: %w", err)
+		}
+	} else {
+		record = childRecord{
+			ID:          raw.Fields["id"],
+			FirstName:   raw.Fields["first_name"],
+			LastName:    raw.Fields["last_name"],
+			BirthDate:   raw.Fields["birth_date"],
+			ParentID:    raw.Fields["parent_id"],
+			ParentEmail: raw.Fields["parent_email"],
+		}
+	}
+
+	record.BirthDate = normalizeDate(record.BirthDate)
+	return record, nil
+}
+
+// errorWriter writes the records of an import file that failed to an error file, in the format of the
+// import file, with the number of the record and its error added, so that they can be fixed and imported again
+type errorWriter struct {
+	csv    *csv.Writer
+	w      io.Writer
+	header []string
+}
+
+// newErrorWriter returns a writer of failed records. The header of a CSV error file is written unless the
+// file already has rows, as when an import is resumed.
+func newErrorWriter(w io.Writer, format string, header []string, empty bool) (*errorWriter, error) {
+	if format == formatNDJSON {
+		return &errorWriter{w: w}, nil
+	}
+
+	writer := &errorWriter{csv: csv.NewWriter(w), header: header}
+	if empty {
+		if err := writer.csv.Write(append(append([]string{}, header...), rowField, errorField)); err != nil {
+			return nil, fmt.Errorf("failed to write the header of the error file: %w", err)
+		}
+	}
+	return writer, nil
+}
+
+// write writes a failed record with its error
+func (w *errorWriter) write(raw rawRecord, recordErr error) error {
+	if w.csv != nil {
+		row := make([]string, len(w.header), len(w.header)+2)
+		copy(row, raw.Row)
+		return w.csv.Write(append(row, strconv.Itoa(raw.Number), recordErr.Error()))
+	}
+
+	// A line that is not a JSON object is kept as a string
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw.Line, &fields); err != nil {
+		line, _ := json.Marshal(string(raw.Line))
+		fields = map[string]json.RawMessage{"line": line}
+	}
+	fields[rowField], _ = json.Marshal(raw.Number)
+	fields[errorField], _ = json.Marshal(recordErr.Error())
+
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	_, err = w.w.Write(append(b, '\n'))
+	return err
+}
+
+// flush writes the buffered records
+func (w *errorWriter) flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
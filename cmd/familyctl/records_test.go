@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordWriter_CSV(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	createdAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	parent := &domain.Parent{
+		ID:        uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "john.doe@example.com",
+		BirthDate: time.Date(1980, 1, 2, 0, 0, 0, 0, time.UTC),
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+	parent.Phones = []domain.Phone{{Number: "+15551234567", Type: domain.PhoneMobile}}
+
+	// Act
+	writer, err := newRecordWriter(&buf, formatCSV, parentColumns)
+	require.NoError(t, err)
+	require.NoError(t, writer.write(newParentRecord(parent)))
+	require.NoError(t, writer.flush())
+
+	// Assert
+	reader, err := newRecordReader(&buf, formatCSV)
+	require.NoError(t, err)
+	assert.Equal(t, parentColumns, reader.header)
+
+	raw, err := reader.next()
+	require.NoError(t, err)
+	assert.Equal(t, 1, raw.Number)
+
+	record, err := decodeParent(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "John", record.FirstName)
+	assert.Equal(t, "john.doe@example.com", record.Email)
+	assert.Equal(t, "1980-01-02T00:00:00Z", record.BirthDate)
+	require.NotNil(t, record.Contact)
+	assert.Equal(t, parent.Phones, record.Contact.Phones)
+
+	_, err = reader.next()
+	assert.True(t, errors.Is(err, io.EOF))
+}
+
+func TestRecordReader_NDJSON(t *testing.T) {
+	// Arrange
+	input := `{"firstName":"Jane","lastName":"Doe","birthDate":"2015-06-01","parentEmail":"john.doe@example.com"}
+
+not json
+`
+
+	// Act
+	reader, err := newRecordReader(strings.NewReader(input), formatNDJSON)
+	require.NoError(t, err)
+	first, err := reader.next()
+	require.NoError(t, err)
+	second, err := reader.next()
+	require.NoError(t, err)
+	_, err = reader.next()
+
+	// Assert
+	assert.True(t, errors.Is(err, io.EOF))
+
+	record, err := decodeChild(first)
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.Number)
+	assert.Equal(t, "Jane", record.FirstName)
+	assert.Equal(t, "2015-06-01T00:00:00Z", record.BirthDate)
+	assert.Equal(t, "john.doe@example.com", record.ParentEmail)
+
+	// The blank line is skipped, but counted
+	assert.Equal(t, 3, second.Number)
+	_, err = decodeChild(second)
+	assert.Error(t, err)
+}
+
+func TestRecordReader_CSVFieldCount(t *testing.T) {
+	// Arrange
+	input := "First_Name,last_name,birth_date,parent_email\nJane,Doe,2015-06-01\nJim,Doe,2016-07-02,john.doe@example.com\n"
+
+	// Act
+	reader, err := newRecordReader(strings.NewReader(input), formatCSV)
+	require.NoError(t, err)
+	short, err := reader.next()
+	require.NoError(t, err)
+	valid, err := reader.next()
+	require.NoError(t, err)
+
+	// Assert
+	_, err = decodeChild(short)
+	assert.Error(t, err)
+
+	record, err := decodeChild(valid)
+	require.NoError(t, err)
+	assert.Equal(t, 2, valid.Number)
+	assert.Equal(t, "Jim", record.FirstName)
+	assert.Equal(t, "john.doe@example.com", record.ParentEmail)
+}
+
+func TestErrorWriter(t *testing.T) {
+	t.Run("CSV", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+		header := []string{"first_name", "email"}
+		writer, err := newErrorWriter(&buf, formatCSV, header, true)
+		require.NoError(t, err)
+
+		// Act
+		err = writer.write(rawRecord{Number: 4, Row: []string{"John", "john@example.com"}}, errors.New("duplicate email"))
+		require.NoError(t, err)
+		require.NoError(t, writer.flush())
+
+		// Assert
+		assert.Equal(t, "first_name,email,row,error\nJohn,john@example.com,4,duplicate email\n", buf.String())
+	})
+
+	t.Run("CSV without header", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+		writer, err := newErrorWriter(&buf, formatCSV, []string{"first_name"}, false)
+		require.NoError(t, err)
+
+		// Act
+		require.NoError(t, writer.write(rawRecord{Number: 7, Row: []string{"John"}}, errors.New("invalid")))
+		require.NoError(t, writer.flush())
+
+		// Assert
+		assert.Equal(t, "John,7,invalid\n", buf.String())
+	})
+
+	t.Run("NDJSON", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+		writer, err := newErrorWriter(&buf, formatNDJSON, nil, true)
+		require.NoError(t, err)
+
+		// Act
+		require.NoError(t, writer.write(rawRecord{Number: 2, Line: []byte(`{"firstName":"John"}`)}, errors.New("invalid")))
+		require.NoError(t, writer.write(rawRecord{Number: 3, Line: []byte(`oops`)}, errors.New("invalid JSON")))
+
+		// Assert
+		assert.Equal(t, `{"error":"invalid","firstName":"John","row":2}`+"\n"+`{"error":"invalid JSON","line":"oops","row":3}`+"\n", buf.String())
+	})
+}
+
+func TestDetectFormat(t *testing.T) {
+	assert.Equal(t, formatCSV, detectFormat("parents.CSV"))
+	assert.Equal(t, formatNDJSON, detectFormat("parents.ndjson"))
+	assert.Equal(t, formatNDJSON, detectFormat("-"))
+}
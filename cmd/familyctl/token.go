@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/infrastructure/auth"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/infrastructure/config"
+	"go.uber.org/zap"
+)
+
+// tokenResult is a JWT minted by the token command, with its claims
+type tokenResult struct {
+	Token     string    `json:"token"`
+	UserID    string    `json:"userId"`
+	TenantID  string    `json:"tenantId,omitempty"`
+	Roles     []string  `json:"roles"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// runToken runs the token command, which mints a JWT signed with the secret key of the configuration,
+// to call the GraphQL API as a user while testing. It needs the configuration only, not the database.
+func runToken(ctx context.Context, args []string) error {
+	flags := newFlagSet("token", "familyctl token -user USER_ID [flags]")
+	user := flags.String("user", "", "the ID of the user the token is for; required")
+	roles := flags.String("roles", "", "the roles of the user: comma-separated, such as admin or parent")
+	tenant := flags.String("tenant", "", "the tenant of the user; the default tenant if empty")
+	output := outputFlag(flags)
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return usageError(fmt.Sprintf("unexpected argument %q", flags.Arg(0)))
+	}
+	if *user == "" {
+		return usageError("token needs the -user the token is for")
+	}
+	if err := checkOutput(*output); err != nil {
+		return usageError(err.Error())
+	}
+
+	result := tokenResult{UserID: *user, TenantID: *tenant, Roles: []string{}}
+	for _, role := range strings.Split(*roles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			result.Roles = append(result.Roles, role)
+		}
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load application configuration: %w", err)
+	}
+	result, err = mintToken(cfg.Auth, result)
+	if err != nil {
+		return err
+	}
+
+	t := table{
+		header: []string{"USER ID", "TENANT ID", "ROLES", "EXPIRES AT", "TOKEN"},
+		rows:   [][]string{{result.UserID, result.TenantID, strings.Join(result.Roles, ","), formatTime(result.ExpiresAt), result.Token}},
+	}
+	return printResult(os.Stdout, *output, result, t)
+}
+
+// mintToken mints the token of a result, for its user, tenant and roles, with the JWT settings of the configuration.
+// The token is minted whatever the auth mode, as the service it is for may be configured differently.
+func mintToken(cfg config.AuthConfig, result tokenResult) (tokenResult, error) {
+	if cfg.JWT.SecretKey == "" {
+		return result, usageError("token needs auth.jwt.secret_key, or JWT_SECRET_KEY, to sign the token")
+	}
+	if cfg.Mode != "jwt" && cfg.Mode != "both" {
+		fmt.Fprintf(os.Stderr, "familyctl: auth.mode is %q here, so a service with this configuration does not accept the token\n", cfg.Mode)
+	}
+
+	jwtService := auth.NewJWTService(auth.JWTConfig{
+		SecretKey:     cfg.JWT.SecretKey,
+		TokenDuration: cfg.JWT.TokenDuration,
+		Issuer:        cfg.JWT.Issuer,
+	}, zap.NewNop())
+
+	var err error
+	if result.TenantID == "" {
+		result.Token, err = jwtService.GenerateToken(result.UserID, result.Roles)
+	} else {
+		result.Token, err = jwtService.GenerateTenantToken(result.UserID, result.TenantID, result.Roles)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	claims, err := jwtService.ValidateToken(result.Token)
+	if err != nil {
+		return result, fmt.Errorf("failed to read the minted token: %w", err)
+	}
+	result.ExpiresAt = claims.ExpiresAt.Time.UTC()
+	return result, nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/infrastructure/auth"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/infrastructure/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMintToken(t *testing.T) {
+	jwt := config.JWTAuthConfig{SecretKey: "test-secret-key", Issuer: "family_service", TokenDuration: time.Hour}
+
+	// The token services of the container are not built for these modes
+	for _, mode := range []string{"disabled", "oidc"} {
+		t.Run(mode, func(t *testing.T) {
+			// Act
+			before := time.Now()
+			result, err := mintToken(config.AuthConfig{Mode: mode, JWT: jwt}, tokenResult{UserID: "ops", TenantID: "acme", Roles: []string{"admin"}})
+
+			// Assert
+			require.NoError(t, err)
+			assert.WithinDuration(t, before.Add(time.Hour), result.ExpiresAt, time.Minute)
+
+			claims, err := auth.NewJWTService(auth.JWTConfig{SecretKey: jwt.SecretKey, Issuer: jwt.Issuer, TokenDuration: jwt.TokenDuration}, zap.NewNop()).ValidateToken(result.Token)
+			require.NoError(t, err)
+			assert.Equal(t, "ops", claims.UserID)
+			assert.Equal(t, "acme", claims.TenantID)
+			assert.Equal(t, []string{"admin"}, claims.Roles)
+		})
+	}
+}
+
+func TestMintToken_MissingSecret(t *testing.T) {
+	// Act
+	_, err := mintToken(config.AuthConfig{Mode: "disabled", JWT: config.JWTAuthConfig{TokenDuration: time.Hour}}, tokenResult{UserID: "ops"})
+
+	// Assert
+	var usageErr usageError
+	assert.True(t, errors.As(err, &usageErr))
+}
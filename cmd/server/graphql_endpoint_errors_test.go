@@ -203,7 +203,7 @@ func TestGraphQLCreateParentMutation_ValidationError(t *testing.T) {
 		return true, nil
 	}
 
-	mockFamilyService.CreateParentFunc = func(ctx context.Context, firstName, lastName, email, birthDate string) (*domain.Parent, error) {
+	mockFamilyService.CreateParentFunc = func(ctx context.Context, firstName, lastName, email, birthDate string, contact *domain.ContactDetails) (*domain.Parent, error) {
 		return nil, errors.New("validation error: invalid email format")
 	}
 
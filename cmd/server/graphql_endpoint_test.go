@@ -29,7 +29,7 @@ func setupGraphQLTest(t *testing.T) (*httptest.Server, *mocks.MockFamilyService,
 	mockAuthService := mocks.NewMockAuthorizationService()
 
 	// Create a resolver
-	resolver := graphql.NewResolver(mockFamilyService, mockAuthService, logger)
+	resolver := graphql.NewResolver(mockFamilyService, mockAuthService, mocks.NewMockEventBroker(), logger)
 
 	// Create a GraphQL server
 	gqlServer := handler.NewDefaultServer(graphql.NewExecutableSchema(graphql.Config{
@@ -188,7 +188,7 @@ func TestGraphQLCreateParentMutation(t *testing.T) {
 		return true, nil
 	}
 
-	mockFamilyService.CreateParentFunc = func(ctx context.Context, firstName, lastName, email, birthDate string) (*domain.Parent, error) {
+	mockFamilyService.CreateParentFunc = func(ctx context.Context, firstName, lastName, email, birthDate string, contact *domain.ContactDetails) (*domain.Parent, error) {
 		assert.Equal(t, "Jane", firstName)
 		assert.Equal(t, "Smith", lastName)
 		assert.Equal(t, "jane.smith@example.com", email)
@@ -51,6 +51,11 @@ func main() {
 	}
 	defer logger.Sync()
 
+	// Requests without a token are rejected, so without authentication the server could not serve any
+	if cfg.Auth.Mode == "disabled" {
+		logger.Fatal("The server needs authentication; set auth.mode to jwt, oidc or both")
+	}
+
 	// Initialize dependency injection container
 	container, err := di.NewContainer(rootCtx, logger, cfg)
 	if err != nil {
@@ -81,12 +86,28 @@ func main() {
 		mux.Handle(metricsPath, telemetry.CreatePrometheusHandler())
 	}
 
-	// Set up GraphQL endpoint
-	resolver := graphql.NewResolver(container.GetFamilyService(), container.GetAuthorizationService(), logger)
+	// Set up GraphQL endpoint; the default server also serves subscriptions over WebSocket
+	resolver := graphql.NewResolver(container.GetFamilyService(), container.GetAuthorizationService(), container.GetEventBroker(), logger).
+		WithWebhooks(container.GetWebhookService()).
+		WithAudit(container.GetAuditService())
 	gqlServer := handler.NewDefaultServer(graphql.NewExecutableSchema(graphql.Config{
 		Resolvers: resolver,
 	}))
-	mux.HandleFunc("/graphql", gqlServer.ServeHTTP)
+	gqlServer.SetErrorPresenter(graphql.NewErrorPresenter(logger))
+
+	// Every request gets its own loaders, so nested fields are fetched in batches
+	graphqlHandler := graphql.LoaderMiddleware(container.GetFamilyService())(gqlServer)
+
+	// Authenticate requests before they reach the resolvers
+	authMiddleware := graphql.NewAuthMiddlewareWithOIDC(
+		container.GetAuthorizationService(),
+		container.GetJWTService(),
+		container.GetOIDCService(),
+		logger,
+	)
+	graphqlHandler = authMiddleware.Middleware(graphqlHandler)
+	logger.Info("GraphQL authentication enabled", zap.String("mode", cfg.Auth.Mode))
+	mux.Handle("/graphql", graphqlHandler)
 
 	// Create context logger
 	contextLogger := logging.NewContextLogger(logger)
@@ -69,6 +69,7 @@ read_secret() {
 # Set environment variables using the read_secret function
 export GF_SECURITY_ADMIN_PASSWORD=$(read_secret "gf_security_admin_password")
 export GF_SECURITY_ADMIN_USER=$(read_secret "gf_security_admin_user")
+export MONGODB_REPLICA_SET_KEY=$(read_secret "mongo_replica_set_key")
 export MONGODB_ROOT_PASSWORD=$(read_secret "mongo_root_password")
 export MONGODB_ROOT_USERNAME=$(read_secret "mongo_root_username")
 export POSTGRESQL_PASSWORD=$(read_secret "postgresql_password")
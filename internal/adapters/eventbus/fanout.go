@@ -0,0 +1,49 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+)
+
+// FanOutPublisher implements the ports.EventPublisher interface by publishing every event
+// to each of several publishers, such as the outbox publisher and the webhook enqueuer.
+// An event is published to every publisher even when an earlier one fails, so a publisher
+// fed by the outbox relay must tolerate receiving the event again when the relay retries.
+type FanOutPublisher struct {
+	publishers []ports.EventPublisher
+}
+
+// NewFanOutPublisher creates a new publisher that publishes every event to each of the publishers
+func NewFanOutPublisher(publishers ...ports.EventPublisher) *FanOutPublisher {
+	return &FanOutPublisher{publishers: publishers}
+}
+
+// Publish publishes the event to each publisher, returning the errors of those that failed
+func (p *FanOutPublisher) Publish(ctx context.Context, event domain.Event) error {
+	var errs []error
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes each publisher that can be closed
+func (p *FanOutPublisher) Close() error {
+	var errs []error
+	for _, publisher := range p.publishers {
+		if closer, ok := publisher.(interface{ Close() error }); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Ensure FanOutPublisher implements ports.EventPublisher
+var _ ports.EventPublisher = (*FanOutPublisher)(nil)
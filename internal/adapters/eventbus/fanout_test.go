@@ -0,0 +1,49 @@
+package eventbus_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/eventbus"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+)
+
+// failingPublisher is a publisher that always fails
+type failingPublisher struct {
+	published int
+}
+
+func (p *failingPublisher) Publish(ctx context.Context, event domain.Event) error {
+	p.published++
+	return errors.New("unavailable")
+}
+
+// TestFanOutPublisher_PublishesToEveryPublisher tests that an event reaches every publisher,
+// even when one of them fails
+func TestFanOutPublisher_PublishesToEveryPublisher(t *testing.T) {
+	// Arrange
+	var first, second bytes.Buffer
+	failing := &failingPublisher{}
+	publisher := eventbus.NewFanOutPublisher(
+		eventbus.NewWriterPublisher(&first, zaptest.NewLogger(t)),
+		failing,
+		eventbus.NewWriterPublisher(&second, zaptest.NewLogger(t)),
+	)
+
+	event := domain.NewEvent(domain.EventChildCreated, uuid.New(), uuid.New())
+
+	// Act
+	err := publisher.Publish(context.Background(), event)
+
+	// Assert
+	assert.EqualError(t, err, "unavailable")
+	assert.Equal(t, 1, failing.published)
+	assert.Contains(t, first.String(), event.ID.String())
+	assert.Contains(t, second.String(), event.ID.String())
+	assert.NoError(t, publisher.Close())
+}
@@ -0,0 +1,134 @@
+// Package eventbus provides implementations of the ports.EventBroker interface.
+// The in-memory broker fans events out to subscribers within a single process;
+// the Redis broker relays them through Redis pub/sub so that every instance of a
+// multi-instance deployment sees every event. The writer publisher writes events as
+// lines of JSON, for delivering the events of the outbox locally, and the fan-out
+// publisher publishes them to several publishers at once.
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// defaultBufferSize is the number of events buffered per subscriber when none is configured
+const defaultBufferSize = 64
+
+// ErrBrokerClosed is returned when publishing to or subscribing on a closed broker
+var ErrBrokerClosed = errors.New("event broker is closed")
+
+// InMemoryBroker implements the ports.EventBroker interface within a single process.
+// Each subscriber receives its own buffered channel. Publishing never blocks:
+// when a subscriber's buffer is full, the event is dropped for that subscriber.
+type InMemoryBroker struct {
+	mu          sync.RWMutex
+	subscribers map[chan domain.Event]struct{}
+	bufferSize  int
+	closed      bool
+	logger      *zap.Logger
+	tracer      trace.Tracer
+}
+
+// NewInMemoryBroker creates a new in-memory event broker
+func NewInMemoryBroker(bufferSize int, logger *zap.Logger) *InMemoryBroker {
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+
+	return &InMemoryBroker{
+		subscribers: make(map[chan domain.Event]struct{}),
+		bufferSize:  bufferSize,
+		logger:      logger,
+		tracer:      otel.Tracer("eventbus.memory"),
+	}
+}
+
+// Publish delivers an event to all current subscribers
+func (b *InMemoryBroker) Publish(ctx context.Context, event domain.Event) error {
+	_, span := b.tracer.Start(ctx, "InMemoryBroker.Publish")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("event.id", event.ID.String()),
+		attribute.String("event.type", string(event.Type)),
+	)
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return ErrBrokerClosed
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			b.logger.Warn("Dropping event for slow subscriber",
+				zap.String("event_id", event.ID.String()),
+				zap.String("event_type", string(event.Type)))
+		}
+	}
+
+	return nil
+}
+
+// Subscribe returns a channel of events that is closed when ctx is done or the broker is closed
+func (b *InMemoryBroker) Subscribe(ctx context.Context) (<-chan domain.Event, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, ErrBrokerClosed
+	}
+
+	ch := make(chan domain.Event, b.bufferSize)
+	b.subscribers[ch] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		b.unsubscribe(ch)
+	}()
+
+	return ch, nil
+}
+
+// unsubscribe removes a subscriber and closes its channel
+func (b *InMemoryBroker) unsubscribe(ch chan domain.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[ch]; ok {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+// Close closes all subscriptions and rejects further publishing
+func (b *InMemoryBroker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+
+	b.closed = true
+	for ch := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+
+	return nil
+}
+
+// Ensure InMemoryBroker implements ports.EventBroker
+var _ ports.EventBroker = (*InMemoryBroker)(nil)
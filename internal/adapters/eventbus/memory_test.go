@@ -0,0 +1,130 @@
+package eventbus_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/eventbus"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// receive waits for the next event on ch or fails the test
+func receive(t *testing.T, ch <-chan domain.Event) domain.Event {
+	t.Helper()
+
+	select {
+	case event, ok := <-ch:
+		require.True(t, ok, "channel closed before an event was received")
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+		return domain.Event{}
+	}
+}
+
+// TestInMemoryBroker_PublishSubscribe tests that every subscriber receives published events
+func TestInMemoryBroker_PublishSubscribe(t *testing.T) {
+	// Arrange
+	broker := eventbus.NewInMemoryBroker(10, zaptest.NewLogger(t))
+	defer broker.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first, err := broker.Subscribe(ctx)
+	require.NoError(t, err)
+	second, err := broker.Subscribe(ctx)
+	require.NoError(t, err)
+
+	event := domain.NewEvent(domain.EventParentCreated, uuid.New(), uuid.Nil)
+
+	// Act
+	err = broker.Publish(ctx, event)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, event.ID, receive(t, first).ID)
+	assert.Equal(t, event.ID, receive(t, second).ID)
+}
+
+// TestInMemoryBroker_UnsubscribeOnCancel tests that a subscription ends when its context is cancelled
+func TestInMemoryBroker_UnsubscribeOnCancel(t *testing.T) {
+	// Arrange
+	broker := eventbus.NewInMemoryBroker(10, zaptest.NewLogger(t))
+	defer broker.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := broker.Subscribe(ctx)
+	require.NoError(t, err)
+
+	// Act
+	cancel()
+
+	// Assert
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("subscription was not closed after context cancellation")
+	}
+
+	// Publishing after the subscriber left must still succeed
+	require.NoError(t, broker.Publish(context.Background(), domain.NewEvent(domain.EventParentUpdated, uuid.New(), uuid.Nil)))
+}
+
+// TestInMemoryBroker_SlowSubscriber tests that publishing does not block on a full subscriber buffer
+func TestInMemoryBroker_SlowSubscriber(t *testing.T) {
+	// Arrange
+	broker := eventbus.NewInMemoryBroker(1, zaptest.NewLogger(t))
+	defer broker.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := broker.Subscribe(ctx)
+	require.NoError(t, err)
+
+	firstEvent := domain.NewEvent(domain.EventParentCreated, uuid.New(), uuid.Nil)
+	droppedEvent := domain.NewEvent(domain.EventParentUpdated, firstEvent.ParentID, uuid.Nil)
+
+	// Act
+	require.NoError(t, broker.Publish(ctx, firstEvent))
+	require.NoError(t, broker.Publish(ctx, droppedEvent))
+
+	// Assert
+	assert.Equal(t, firstEvent.ID, receive(t, events).ID)
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event %s delivered to a full buffer", event.ID)
+	default:
+	}
+}
+
+// TestInMemoryBroker_Close tests that closing the broker ends subscriptions and rejects further use
+func TestInMemoryBroker_Close(t *testing.T) {
+	// Arrange
+	broker := eventbus.NewInMemoryBroker(0, zaptest.NewLogger(t))
+	events, err := broker.Subscribe(context.Background())
+	require.NoError(t, err)
+
+	// Act
+	require.NoError(t, broker.Close())
+
+	// Assert
+	_, ok := <-events
+	assert.False(t, ok)
+
+	err = broker.Publish(context.Background(), domain.NewEvent(domain.EventParentCreated, uuid.New(), uuid.Nil))
+	assert.ErrorIs(t, err, eventbus.ErrBrokerClosed)
+
+	_, err = broker.Subscribe(context.Background())
+	assert.ErrorIs(t, err, eventbus.ErrBrokerClosed)
+
+	// Closing twice is a no-op
+	assert.NoError(t, broker.Close())
+}
@@ -0,0 +1,167 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// RedisOptions contains the settings for connecting a RedisBroker
+type RedisOptions struct {
+	Address    string
+	Password   string
+	DB         int
+	Channel    string
+	BufferSize int
+}
+
+// RedisBroker implements the ports.EventBroker interface using Redis pub/sub.
+// Events are serialized as JSON and published on a single channel, so every
+// instance subscribed to that channel receives the events of every other instance.
+type RedisBroker struct {
+	client     *redis.Client
+	channel    string
+	bufferSize int
+	done       chan struct{}
+	closeOnce  sync.Once
+	logger     *zap.Logger
+	tracer     trace.Tracer
+}
+
+// NewRedisBroker creates a new Redis event broker and verifies the connection
+func NewRedisBroker(ctx context.Context, options RedisOptions, logger *zap.Logger) (*RedisBroker, error) {
+	if ctx == nil {
+		ctx = context.Background()
+		logger.Warn("Nil context provided to NewRedisBroker, using background context")
+	}
+
+	bufferSize := options.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     options.Address,
+		Password: options.Password,
+		DB:       options.DB,
+	})
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to ping redis: %w", err)
+	}
+
+	return &RedisBroker{
+		client:     client,
+		channel:    options.Channel,
+		bufferSize: bufferSize,
+		done:       make(chan struct{}),
+		logger:     logger,
+		tracer:     otel.Tracer("eventbus.redis"),
+	}, nil
+}
+
+// Publish delivers an event to all subscribers of the Redis channel
+func (b *RedisBroker) Publish(ctx context.Context, event domain.Event) error {
+	ctx, span := b.tracer.Start(ctx, "RedisBroker.Publish")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("event.id", event.ID.String()),
+		attribute.String("event.type", string(event.Type)),
+	)
+
+	select {
+	case <-b.done:
+		return ErrBrokerClosed
+	default:
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	if err := b.client.Publish(ctx, b.channel, payload).Err(); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe returns a channel of events that is closed when ctx is done or the broker is closed
+func (b *RedisBroker) Subscribe(ctx context.Context) (<-chan domain.Event, error) {
+	select {
+	case <-b.done:
+		return nil, ErrBrokerClosed
+	default:
+	}
+
+	pubsub := b.client.Subscribe(ctx, b.channel)
+
+	// Wait for the subscription to be confirmed so no event published afterwards is missed
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to events: %w", err)
+	}
+
+	events := make(chan domain.Event, b.bufferSize)
+	messages := pubsub.Channel()
+
+	go func() {
+		defer close(events)
+		defer pubsub.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-b.done:
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var event domain.Event
+				if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+					b.logger.Error("Failed to decode event", zap.Error(err))
+					continue
+				}
+
+				select {
+				case events <- event:
+				default:
+					b.logger.Warn("Dropping event for slow subscriber",
+						zap.String("event_id", event.ID.String()),
+						zap.String("event_type", string(event.Type)))
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// Close closes all subscriptions and the Redis client
+func (b *RedisBroker) Close() error {
+	var err error
+	b.closeOnce.Do(func() {
+		close(b.done)
+		err = b.client.Close()
+	})
+	return err
+}
+
+// Ensure RedisBroker implements ports.EventBroker
+var _ ports.EventBroker = (*RedisBroker)(nil)
@@ -0,0 +1,106 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// WriterPublisher implements the ports.EventPublisher interface by writing every event
+// as a line of JSON. It stands in for a message broker when running locally, writing
+// to the standard output or appending to a file.
+type WriterPublisher struct {
+	mu     sync.Mutex
+	writer io.Writer
+	file   *os.File // The file written to, which is synced and closed; nil for other writers
+	logger *zap.Logger
+	tracer trace.Tracer
+}
+
+// NewWriterPublisher creates a new publisher that writes the events to w
+func NewWriterPublisher(w io.Writer, logger *zap.Logger) *WriterPublisher {
+	return &WriterPublisher{
+		writer: w,
+		logger: logger,
+		tracer: otel.Tracer("eventbus.writer"),
+	}
+}
+
+// NewStdoutPublisher creates a new publisher that writes the events to the standard output
+func NewStdoutPublisher(logger *zap.Logger) *WriterPublisher {
+	return NewWriterPublisher(os.Stdout, logger)
+}
+
+// NewFilePublisher creates a new publisher that appends the events to the file at path,
+// creating the file if it does not exist
+func NewFilePublisher(path string, logger *zap.Logger) (*WriterPublisher, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event file %s: %w", path, err)
+	}
+
+	publisher := NewWriterPublisher(file, logger)
+	publisher.file = file
+	return publisher, nil
+}
+
+// Publish writes the event as a line of JSON.
+// Events written to a file are synced to disk before Publish returns.
+func (p *WriterPublisher) Publish(ctx context.Context, event domain.Event) error {
+	_, span := p.tracer.Start(ctx, "WriterPublisher.Publish")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("event.id", event.ID.String()),
+		attribute.String("event.type", string(event.Type)),
+	)
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	line = append(line, '\n')
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err := p.writer.Write(line); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+
+	if p.file != nil {
+		if err := p.file.Sync(); err != nil {
+			return fmt.Errorf("failed to sync event file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Close closes the file written to, if any
+func (p *WriterPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.file == nil {
+		return nil
+	}
+
+	err := p.file.Close()
+	p.file = nil
+	p.writer = io.Discard
+	return err
+}
+
+// Ensure WriterPublisher implements ports.EventPublisher
+var _ ports.EventPublisher = (*WriterPublisher)(nil)
@@ -0,0 +1,66 @@
+package eventbus_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/eventbus"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestWriterPublisher_WritesJSONLines tests that every event is written as a line of JSON
+func TestWriterPublisher_WritesJSONLines(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	publisher := eventbus.NewWriterPublisher(&buf, zaptest.NewLogger(t))
+
+	first := domain.NewEvent(domain.EventParentCreated, uuid.New(), uuid.Nil)
+	second := domain.NewEvent(domain.EventChildCreated, first.ParentID, uuid.New())
+
+	// Act
+	require.NoError(t, publisher.Publish(context.Background(), first))
+	require.NoError(t, publisher.Publish(context.Background(), second))
+
+	// Assert
+	var events []domain.Event
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var event domain.Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	require.Len(t, events, 2)
+	assert.Equal(t, first.ID, events[0].ID)
+	assert.Equal(t, second.ID, events[1].ID)
+	assert.Equal(t, second.ChildID, events[1].ChildID)
+}
+
+// TestFilePublisher_AppendsToFile tests that the file publisher keeps the events already in the file
+func TestFilePublisher_AppendsToFile(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("{}\n"), 0o644))
+
+	publisher, err := eventbus.NewFilePublisher(path, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	// Act
+	require.NoError(t, publisher.Publish(context.Background(), domain.NewEvent(domain.EventParentDeleted, uuid.New(), uuid.Nil)))
+	require.NoError(t, publisher.Close())
+
+	// Assert
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := bytes.Split(bytes.TrimSpace(content), []byte("\n"))
+	require.Len(t, lines, 2)
+	assert.Contains(t, string(lines[1]), string(domain.EventParentDeleted))
+}
@@ -0,0 +1,113 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// loaderWait is how long a loader collects keys before fetching a batch
+	loaderWait = 2 * time.Millisecond
+
+	// loaderMaxBatch is the number of keys that triggers a fetch before loaderWait elapses
+	loaderMaxBatch = 100
+
+	// loaderTimeout bounds each batch fetch, like the timeout of a top-level resolver
+	loaderTimeout = 5 * time.Second
+)
+
+// batchLoader collects the keys requested by concurrently running resolvers and
+// fetches them with a single call. Keys are de-duplicated within a batch, but
+// results are not cached between batches, so a long-lived context such as a
+// subscription never observes stale data.
+type batchLoader[K comparable, V any] struct {
+	ctx      context.Context
+	fetch    func(ctx context.Context, keys []K) (map[K]V, error)
+	wait     time.Duration
+	maxBatch int
+
+	mu    sync.Mutex
+	batch *loaderBatch[K, V]
+}
+
+// loaderBatch holds the keys of one pending fetch and, once done is closed, its results
+type loaderBatch[K comparable, V any] struct {
+	keys    []K
+	seen    map[K]struct{}
+	results map[K]V
+	err     error
+	done    chan struct{}
+}
+
+// newBatchLoader creates a loader whose fetches run with ctx, usually the request context
+func newBatchLoader[K comparable, V any](ctx context.Context, fetch func(ctx context.Context, keys []K) (map[K]V, error)) *batchLoader[K, V] {
+	return &batchLoader[K, V]{
+		ctx:      ctx,
+		fetch:    fetch,
+		wait:     loaderWait,
+		maxBatch: loaderMaxBatch,
+	}
+}
+
+// Load returns the value for key, fetching it together with the keys requested by other callers.
+// A key that the fetch does not return yields the zero value of V.
+func (l *batchLoader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+
+	b := l.batch
+	if b == nil {
+		b = &loaderBatch[K, V]{
+			seen: make(map[K]struct{}),
+			done: make(chan struct{}),
+		}
+		l.batch = b
+		go l.dispatchAfterWait(b)
+	}
+
+	if _, ok := b.seen[key]; !ok {
+		b.seen[key] = struct{}{}
+		b.keys = append(b.keys, key)
+	}
+
+	// Dispatch a full batch right away; the next Load starts a new one
+	if len(b.keys) >= l.maxBatch {
+		l.batch = nil
+		go l.run(b)
+	}
+
+	l.mu.Unlock()
+
+	select {
+	case <-b.done:
+		return b.results[key], b.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// dispatchAfterWait fetches the batch once the wait window closes, unless it was already dispatched as full
+func (l *batchLoader[K, V]) dispatchAfterWait(b *loaderBatch[K, V]) {
+	time.Sleep(l.wait)
+
+	l.mu.Lock()
+	if l.batch != b {
+		l.mu.Unlock()
+		return
+	}
+	l.batch = nil
+	l.mu.Unlock()
+
+	l.run(b)
+}
+
+// run fetches the keys of a batch and releases every caller waiting on it
+func (l *batchLoader[K, V]) run(b *loaderBatch[K, V]) {
+	defer close(b.done)
+
+	ctx, cancel := context.WithTimeout(l.ctx, loaderTimeout)
+	defer cancel()
+
+	b.results, b.err = l.fetch(ctx, b.keys)
+}
@@ -0,0 +1,209 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/infrastructure/logging"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Error codes reported in the "code" extension of GraphQL errors
+const (
+	// CodeNotFound is reported when an entity does not exist
+	CodeNotFound = "NOT_FOUND"
+
+	// CodeValidationFailed is reported when the input of an operation is invalid
+	CodeValidationFailed = "VALIDATION_FAILED"
+
+	// CodeForbidden is reported when the caller may not perform an operation
+	CodeForbidden = "FORBIDDEN"
+
+	// CodeUnauthenticated is reported when an operation requires the caller to authenticate
+	CodeUnauthenticated = "UNAUTHENTICATED"
+
+	// CodeConflict is reported when an entity was modified since the version the caller based its change on
+	CodeConflict = "CONFLICT"
+
+	// CodeDuplicate is reported when an entity would have the same value of a unique field as another entity
+	CodeDuplicate = "DUPLICATE"
+
+	// CodeAborted is reported for the items of an atomic batch that were rolled back because another item failed
+	CodeAborted = "ABORTED"
+
+	// CodeInternal is reported for every other error; its details are only logged
+	CodeInternal = "INTERNAL"
+)
+
+// internalErrorMessage replaces the message of internal errors, which may reveal details of the storage
+const internalErrorMessage = "internal error"
+
+// NewErrorPresenter creates the presenter of the errors returned by resolvers to clients.
+// Every error gets a code in its "code" extension, and validation and duplicate errors name the
+// offending input field in their "field" extension. The messages of internal errors are hidden from
+// clients; they are logged together with the trace ID, which clients get in the "traceId"
+// extension so that they can refer to the failure.
+//
+// Parameters:
+//   - logger: Logger for recording internal errors
+//
+// Returns:
+//   - graphql.ErrorPresenterFunc: The error presenter to set on the GraphQL server
+func NewErrorPresenter(logger *zap.Logger) graphql.ErrorPresenterFunc {
+	return func(ctx context.Context, err error) *gqlerror.Error {
+		gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+		if gqlErr.Extensions == nil {
+			gqlErr.Extensions = map[string]interface{}{}
+		}
+
+		code, cause := classifyError(err)
+		switch {
+		case code != "":
+			gqlErr.Extensions["code"] = code
+
+			// Errors of the storage are wrapped around the domain error; only the latter is meant for clients
+			if isStorageError(err) {
+				gqlErr.Message = cause.Error()
+			}
+
+			if field := errorField(err); field != "" {
+				gqlErr.Extensions["field"] = field
+			}
+
+		case isRequestError(err):
+			// Errors of the GraphQL layer describe the request, so they are presented as they are
+			return gqlErr
+
+		default:
+			gqlErr.Extensions["code"] = CodeInternal
+			gqlErr.Message = internalErrorMessage
+
+			spanCtx := trace.SpanContextFromContext(ctx)
+			if spanCtx.HasTraceID() {
+				gqlErr.Extensions["traceId"] = spanCtx.TraceID().String()
+			}
+
+			logging.WithTraceID(ctx, logger).Error("Internal error presented to client", zap.Error(err))
+		}
+
+		return gqlErr
+	}
+}
+
+// presentBatchError converts the error of an item of a bulk mutation to the BatchError reported for the item,
+// in the same way as NewErrorPresenter presents the errors of resolvers.
+//
+// Parameters:
+//   - ctx: The context of the request, used to log internal errors with the trace ID
+//   - logger: Logger for recording internal errors
+//   - err: The error of the item, or nil if the item succeeded
+//
+// Returns:
+//   - *BatchError: The error reported for the item, or nil if the item succeeded
+func presentBatchError(ctx context.Context, logger *zap.Logger, err error) *BatchError {
+	if err == nil {
+		return nil
+	}
+
+	code, cause := classifyError(err)
+	if code == "" {
+		logging.WithTraceID(ctx, logger).Error("Internal error of batch item presented to client", zap.Error(err))
+		return &BatchError{Code: BatchErrorCode(CodeInternal), Message: internalErrorMessage}
+	}
+
+	batchErr := &BatchError{Code: BatchErrorCode(code), Message: err.Error()}
+
+	// Errors of the storage are wrapped around the domain error; only the latter is meant for clients
+	if isStorageError(err) {
+		batchErr.Message = cause.Error()
+	}
+	if field := errorField(err); field != "" {
+		batchErr.Field = &field
+	}
+
+	return batchErr
+}
+
+// classifyError returns the code of a domain error, and the domain error itself.
+// It returns an empty code for errors that are not domain errors.
+func classifyError(err error) (string, error) {
+	var (
+		notFoundErr      *domain.NotFoundError
+		validationErr    *domain.ValidationError
+		forbiddenErr     *domain.ForbiddenError
+		authorizationErr *domain.AuthorizationError
+		conflictErr      *domain.ConflictError
+		duplicateErr     *domain.DuplicateError
+	)
+
+	switch {
+	case errors.As(err, &notFoundErr):
+		return CodeNotFound, notFoundErr
+	case errors.As(err, &validationErr):
+		return CodeValidationFailed, validationErr
+	case errors.As(err, &forbiddenErr):
+		return CodeForbidden, forbiddenErr
+	case errors.As(err, &authorizationErr):
+		if authorizationErr.Authenticated {
+			return CodeForbidden, authorizationErr
+		}
+		return CodeUnauthenticated, authorizationErr
+	case errors.As(err, &conflictErr):
+		return CodeConflict, conflictErr
+	case errors.As(err, &duplicateErr):
+		return CodeDuplicate, duplicateErr
+	case errors.Is(err, domain.ErrNotFound):
+		return CodeNotFound, domain.ErrNotFound
+	case errors.Is(err, domain.ErrValidation):
+		return CodeValidationFailed, domain.ErrValidation
+	case errors.Is(err, domain.ErrInvalidInput):
+		return CodeValidationFailed, domain.ErrInvalidInput
+	case errors.Is(err, domain.ErrForbidden):
+		return CodeForbidden, domain.ErrForbidden
+	case errors.Is(err, domain.ErrUnauthorized):
+		return CodeUnauthenticated, domain.ErrUnauthorized
+	case errors.Is(err, domain.ErrConflict):
+		return CodeConflict, domain.ErrConflict
+	case errors.Is(err, domain.ErrDuplicate):
+		return CodeDuplicate, domain.ErrDuplicate
+	case errors.Is(err, domain.ErrAborted):
+		return CodeAborted, domain.ErrAborted
+	}
+
+	return "", nil
+}
+
+// errorField returns the offending input field of a validation or duplicate error, if any
+func errorField(err error) string {
+	var (
+		validationErr *domain.ValidationError
+		duplicateErr  *domain.DuplicateError
+	)
+	if errors.As(err, &validationErr) && validationErr.Field != "" {
+		return validationErr.Field
+	}
+	if errors.As(err, &duplicateErr) && duplicateErr.Field != "" {
+		return duplicateErr.Field
+	}
+	return ""
+}
+
+// isStorageError reports whether an error was raised by a database operation or transaction
+func isStorageError(err error) bool {
+	var (
+		databaseErr    *domain.DatabaseError
+		transactionErr *domain.TransactionError
+	)
+	return errors.As(err, &databaseErr) || errors.As(err, &transactionErr)
+}
+
+// isRequestError reports whether an error was raised by the GraphQL layer, such as for an
+// argument that cannot be converted to its type, rather than by a resolver
+func isRequestError(err error) bool {
+	var gqlErr *gqlerror.Error
+	return errors.As(err, &gqlErr) && !isStorageError(err)
+}
@@ -0,0 +1,154 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/graphql"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestErrorPresenter_Codes(t *testing.T) {
+	presenter := graphql.NewErrorPresenter(zaptest.NewLogger(t))
+
+	tests := []struct {
+		name string
+		err  error
+		code string
+	}{
+		{
+			name: "not found",
+			err:  fmt.Errorf("failed to get parent: %w", domain.NewNotFoundError("Parent", "123")),
+			code: graphql.CodeNotFound,
+		},
+		{
+			name: "validation",
+			err:  fmt.Errorf("failed to create parent: %w", domain.NewValidationError("Parent", "firstName", "is required")),
+			code: graphql.CodeValidationFailed,
+		},
+		{
+			name: "forbidden family",
+			err:  fmt.Errorf("failed to get parent: %w", domain.NewForbiddenError("Parent", "123")),
+			code: graphql.CodeForbidden,
+		},
+		{
+			name: "authenticated caller without permission",
+			err:  domain.NewAuthorizationError("update parent", true),
+			code: graphql.CodeForbidden,
+		},
+		{
+			name: "anonymous caller without permission",
+			err:  domain.NewAuthorizationError("update parent", false),
+			code: graphql.CodeUnauthenticated,
+		},
+		{
+			name: "conflict",
+			err:  fmt.Errorf("failed to update parent: %w", domain.NewConflictError("Parent", "123", 2)),
+			code: graphql.CodeConflict,
+		},
+		{
+			name: "duplicate",
+			err:  fmt.Errorf("failed to create parent: %w", domain.NewDuplicateError("Parent", "email", "john.doe@example.com")),
+			code: graphql.CodeDuplicate,
+		},
+		{
+			name: "aborted",
+			err:  domain.ErrAborted,
+			code: graphql.CodeAborted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute
+			gqlErr := presenter(context.Background(), tt.err)
+
+			// Verify
+			assert.Equal(t, tt.code, gqlErr.Extensions["code"])
+			assert.Equal(t, tt.err.Error(), gqlErr.Message)
+		})
+	}
+}
+
+func TestErrorPresenter_ValidationField(t *testing.T) {
+	presenter := graphql.NewErrorPresenter(zaptest.NewLogger(t))
+
+	// Execute
+	gqlErr := presenter(context.Background(), fmt.Errorf("invalid parent ID: %w", domain.NewValidationError("Parent", "parentId", "must be a valid UUID")))
+
+	// Verify
+	assert.Equal(t, graphql.CodeValidationFailed, gqlErr.Extensions["code"])
+	assert.Equal(t, "parentId", gqlErr.Extensions["field"])
+}
+
+func TestErrorPresenter_DomainErrorInStorageError(t *testing.T) {
+	presenter := graphql.NewErrorPresenter(zaptest.NewLogger(t))
+	err := domain.NewDatabaseError("get", "Parent", domain.NewNotFoundError("Parent", "123"))
+
+	// Execute
+	gqlErr := presenter(context.Background(), fmt.Errorf("failed to get parent: %w", err))
+
+	// Verify
+	assert.Equal(t, graphql.CodeNotFound, gqlErr.Extensions["code"])
+	assert.Equal(t, "Parent with ID 123 not found", gqlErr.Message)
+}
+
+func TestErrorPresenter_DuplicateInStorageError(t *testing.T) {
+	presenter := graphql.NewErrorPresenter(zaptest.NewLogger(t))
+	err := domain.NewDatabaseError("create", "Parent", domain.NewDuplicateError("Parent", "email", "john.doe@example.com"))
+
+	// Execute
+	gqlErr := presenter(context.Background(), err)
+
+	// Verify
+	assert.Equal(t, graphql.CodeDuplicate, gqlErr.Extensions["code"])
+	assert.Equal(t, "email", gqlErr.Extensions["field"])
+	assert.Equal(t, "Parent with email john.doe@example.com already exists", gqlErr.Message)
+}
+
+func TestErrorPresenter_Internal(t *testing.T) {
+	core, logs := observer.New(zap.ErrorLevel)
+	presenter := graphql.NewErrorPresenter(zap.New(core))
+
+	traceID := trace.TraceID{0x01, 0x02, 0x03}
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  trace.SpanID{0x04},
+	}))
+	err := domain.NewDatabaseError("get", "Parent", errors.New(`relation "parents" does not exist`))
+
+	// Execute
+	gqlErr := presenter(ctx, fmt.Errorf("failed to get parent: %w", err))
+
+	// Verify the details are hidden from the client
+	assert.Equal(t, graphql.CodeInternal, gqlErr.Extensions["code"])
+	assert.Equal(t, "internal error", gqlErr.Message)
+	assert.Equal(t, traceID.String(), gqlErr.Extensions["traceId"])
+
+	// Verify the details are logged with the trace ID
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, traceID.String(), fields["trace_id"])
+	assert.Contains(t, fields["error"], `relation "parents" does not exist`)
+}
+
+func TestErrorPresenter_RequestError(t *testing.T) {
+	presenter := graphql.NewErrorPresenter(zaptest.NewLogger(t))
+	err := &gqlerror.Error{Message: "cannot use String as Int"}
+
+	// Execute
+	gqlErr := presenter(context.Background(), err)
+
+	// Verify the error of the GraphQL layer is presented as it is
+	assert.Equal(t, "cannot use String as Int", gqlErr.Message)
+	assert.Nil(t, gqlErr.Extensions["code"])
+}
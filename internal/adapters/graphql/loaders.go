@@ -0,0 +1,116 @@
+package graphql
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+)
+
+// loadersKey is the context key for the request's Loaders
+type loadersKey struct{}
+
+// Loaders batches the lookups made by nested field resolvers, so that resolving
+// Parent.children or Child.parent for a list of N items costs one query instead of N.
+type Loaders struct {
+	childrenByParentID *batchLoader[uuid.UUID, []domain.Child]
+	parentByID         *batchLoader[uuid.UUID, *domain.Parent]
+	guardiansByChildID *batchLoader[uuid.UUID, []*domain.Guardianship]
+	wardsByParentID    *batchLoader[uuid.UUID, []*domain.Guardianship]
+}
+
+// NewLoaders creates the loaders for a single request
+func NewLoaders(ctx context.Context, familyService ports.FamilyService) *Loaders {
+	return &Loaders{
+		childrenByParentID: newBatchLoader(ctx, func(ctx context.Context, parentIDs []uuid.UUID) (map[uuid.UUID][]domain.Child, error) {
+			children, err := familyService.ListChildrenByParentIDs(ctx, parentIDs)
+			if err != nil {
+				return nil, err
+			}
+
+			// Every requested parent gets a list, even if it has no children
+			results := make(map[uuid.UUID][]domain.Child, len(parentIDs))
+			for _, id := range parentIDs {
+				results[id] = []domain.Child{}
+			}
+			for _, child := range children {
+				results[child.ParentID] = append(results[child.ParentID], *child)
+			}
+
+			return results, nil
+		}),
+		parentByID: newBatchLoader(ctx, func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.Parent, error) {
+			parents, err := familyService.GetParentsByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+
+			results := make(map[uuid.UUID]*domain.Parent, len(parents))
+			for _, parent := range parents {
+				results[parent.ID] = parent
+			}
+
+			return results, nil
+		}),
+		guardiansByChildID: newBatchLoader(ctx, func(ctx context.Context, childIDs []uuid.UUID) (map[uuid.UUID][]*domain.Guardianship, error) {
+			guardianships, err := familyService.ListGuardianshipsByChildIDs(ctx, childIDs)
+			if err != nil {
+				return nil, err
+			}
+
+			// Every requested child gets a list, even if it has no guardians
+			results := make(map[uuid.UUID][]*domain.Guardianship, len(childIDs))
+			for _, id := range childIDs {
+				results[id] = []*domain.Guardianship{}
+			}
+			for _, guardianship := range guardianships {
+				results[guardianship.ChildID] = append(results[guardianship.ChildID], guardianship)
+			}
+
+			return results, nil
+		}),
+		wardsByParentID: newBatchLoader(ctx, func(ctx context.Context, parentIDs []uuid.UUID) (map[uuid.UUID][]*domain.Guardianship, error) {
+			guardianships, err := familyService.ListGuardianshipsByParentIDs(ctx, parentIDs)
+			if err != nil {
+				return nil, err
+			}
+
+			// Every requested parent gets a list, even if it has no wards
+			results := make(map[uuid.UUID][]*domain.Guardianship, len(parentIDs))
+			for _, id := range parentIDs {
+				results[id] = []*domain.Guardianship{}
+			}
+			for _, guardianship := range guardianships {
+				results[guardianship.ParentID] = append(results[guardianship.ParentID], guardianship)
+			}
+
+			return results, nil
+		}),
+	}
+}
+
+// WithLoaders returns a copy of ctx that carries the given loaders
+func WithLoaders(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, loaders)
+}
+
+// LoaderMiddleware is a middleware that attaches a fresh set of Loaders to every request
+func LoaderMiddleware(familyService ports.FamilyService) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := WithLoaders(r.Context(), NewLoaders(r.Context(), familyService))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// loaders returns the Loaders of the request, or a new set when the context carries none
+func (r *Resolver) loaders(ctx context.Context) *Loaders {
+	if loaders, ok := ctx.Value(loadersKey{}).(*Loaders); ok && loaders != nil {
+		return loaders
+	}
+
+	return NewLoaders(ctx, r.familyService)
+}
@@ -0,0 +1,209 @@
+package graphql
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+)
+
+// cursorPayload is the serialized form of a ports.Cursor
+type cursorPayload struct {
+	Field string `json:"f"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+// normalizeSortField maps a sort field to its canonical name, defaulting to createdAt like the repositories do
+func normalizeSortField(field string) string {
+	switch field {
+	case "firstName", "first_name":
+		return "firstName"
+	case "lastName", "last_name":
+		return "lastName"
+	case "email":
+		return "email"
+	case "birthDate", "birth_date":
+		return "birthDate"
+	case "updatedAt", "updated_at":
+		return "updatedAt"
+	default:
+		return "createdAt"
+	}
+}
+
+// isTimeSortField reports whether the canonical sort field holds a timestamp
+func isTimeSortField(field string) bool {
+	return field == "birthDate" || field == "createdAt" || field == "updatedAt"
+}
+
+// encodeCursor serializes a keyset position into an opaque cursor
+func encodeCursor(cursor ports.Cursor) string {
+	payload := cursorPayload{
+		Field: cursor.SortField,
+		ID:    cursor.ID.String(),
+	}
+
+	switch v := cursor.SortValue.(type) {
+	case time.Time:
+		payload.Value = v.UTC().Format(time.RFC3339Nano)
+	case string:
+		payload.Value = v
+	default:
+		payload.Value = fmt.Sprint(v)
+	}
+
+	b, _ := json.Marshal(payload)
+	return base64.URLEncoding.EncodeToString(b)
+}
+
+// decodeCursor parses an opaque cursor back into a keyset position
+func decodeCursor(s string) (*ports.Cursor, error) {
+	b, err := base64.URLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, domain.NewValidationError("Cursor", "cursor", "is not a valid cursor")
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(b, &payload); err != nil {
+		return nil, domain.NewValidationError("Cursor", "cursor", "is not a valid cursor")
+	}
+
+	id, err := uuid.Parse(payload.ID)
+	if err != nil {
+		return nil, domain.NewValidationError("Cursor", "cursor", "is not a valid cursor")
+	}
+
+	cursor := &ports.Cursor{
+		SortField: normalizeSortField(payload.Field),
+		SortValue: payload.Value,
+		ID:        id,
+	}
+
+	if isTimeSortField(cursor.SortField) {
+		t, err := time.Parse(time.RFC3339Nano, payload.Value)
+		if err != nil {
+			return nil, domain.NewValidationError("Cursor", "cursor", "is not a valid cursor")
+		}
+		cursor.SortValue = t
+	}
+
+	return cursor, nil
+}
+
+// parentCursor returns the cursor of a parent for the given sort field
+func parentCursor(parent *domain.Parent, sortField string) string {
+	field := normalizeSortField(sortField)
+
+	var value interface{}
+	switch field {
+	case "firstName":
+		value = parent.FirstName
+	case "lastName":
+		value = parent.LastName
+	case "email":
+		value = parent.Email
+	case "birthDate":
+		value = parent.BirthDate
+	case "updatedAt":
+		value = parent.UpdatedAt
+	default:
+		value = parent.CreatedAt
+	}
+
+	return encodeCursor(ports.Cursor{SortField: field, SortValue: value, ID: parent.ID})
+}
+
+// childCursor returns the cursor of a child for the given sort field.
+// Children cannot be sorted by email, so the repositories fall back to createdAt for it.
+func childCursor(child *domain.Child, sortField string) string {
+	field := normalizeSortField(sortField)
+	if field == "email" {
+		field = "createdAt"
+	}
+
+	var value interface{}
+	switch field {
+	case "firstName":
+		value = child.FirstName
+	case "lastName":
+		value = child.LastName
+	case "birthDate":
+		value = child.BirthDate
+	case "updatedAt":
+		value = child.UpdatedAt
+	default:
+		value = child.CreatedAt
+	}
+
+	return encodeCursor(ports.Cursor{SortField: field, SortValue: value, ID: child.ID})
+}
+
+// buildPageOptions converts the GraphQL pagination input into page and cursor options.
+// Cursors must have been issued for the same sort field, since the keyset is built from it.
+func buildPageOptions(pagination *PaginationInput, sortOptions ports.SortOptions, allowEmail bool) (ports.PaginationOptions, ports.CursorOptions, error) {
+	paginationOptions := ports.PaginationOptions{
+		Page:     0,
+		PageSize: 10,
+	}
+	cursorOptions := ports.CursorOptions{}
+
+	if pagination == nil {
+		return paginationOptions, cursorOptions, nil
+	}
+
+	if pagination.Page != nil {
+		paginationOptions.Page = *pagination.Page
+	}
+	if pagination.PageSize != nil {
+		paginationOptions.PageSize = *pagination.PageSize
+	}
+
+	if pagination.First != nil {
+		if *pagination.First < 0 {
+			return paginationOptions, cursorOptions, domain.NewValidationError("Pagination", "first", "must not be negative")
+		}
+		cursorOptions.First = *pagination.First
+	}
+	if pagination.Last != nil {
+		if *pagination.Last < 0 {
+			return paginationOptions, cursorOptions, domain.NewValidationError("Pagination", "last", "must not be negative")
+		}
+		cursorOptions.Last = *pagination.Last
+	}
+	if cursorOptions.First > 0 && cursorOptions.Last > 0 {
+		return paginationOptions, cursorOptions, domain.NewValidationError("Pagination", "last", "cannot be combined with first")
+	}
+
+	sortField := normalizeSortField(sortOptions.Field)
+	if sortField == "email" && !allowEmail {
+		sortField = "createdAt"
+	}
+
+	if pagination.After != nil {
+		cursor, err := decodeCursor(*pagination.After)
+		if err != nil {
+			return paginationOptions, cursorOptions, err
+		}
+		if cursor.SortField != sortField {
+			return paginationOptions, cursorOptions, domain.NewValidationError("Pagination", "after", "was issued for a different sort field")
+		}
+		cursorOptions.After = cursor
+	}
+	if pagination.Before != nil {
+		cursor, err := decodeCursor(*pagination.Before)
+		if err != nil {
+			return paginationOptions, cursorOptions, err
+		}
+		if cursor.SortField != sortField {
+			return paginationOptions, cursorOptions, domain.NewValidationError("Pagination", "before", "was issued for a different sort field")
+		}
+		cursorOptions.Before = cursor
+	}
+
+	return paginationOptions, cursorOptions, nil
+}
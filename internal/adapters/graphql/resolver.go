@@ -1,26 +1,173 @@
 package graphql
 
 import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/infrastructure/auth"
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
-// Resolver is the resolver for GraphQL queries and mutations
+// Resolver is the resolver for GraphQL queries, mutations, and subscriptions
 type Resolver struct {
 	familyService ports.FamilyService
 	authService   ports.AuthorizationService
+	events        ports.EventSubscriber
+	webhooks      ports.WebhookService
+	audit         ports.AuditService
 	logger        *zap.Logger
 	tracer        trace.Tracer
 }
 
 // NewResolver creates a new resolver
-func NewResolver(familyService ports.FamilyService, authService ports.AuthorizationService, logger *zap.Logger) *Resolver {
+func NewResolver(familyService ports.FamilyService, authService ports.AuthorizationService, events ports.EventSubscriber, logger *zap.Logger) *Resolver {
 	return &Resolver{
 		familyService: familyService,
 		authService:   authService,
+		events:        events,
 		logger:        logger,
 		tracer:        otel.Tracer("graphql.resolver"),
 	}
 }
+
+// WithWebhooks sets the service that manages webhook subscriptions and returns the resolver.
+// Without it, the webhook queries and mutations fail.
+func (r *Resolver) WithWebhooks(webhooks ports.WebhookService) *Resolver {
+	r.webhooks = webhooks
+	return r
+}
+
+// webhookService returns the service that manages webhook subscriptions,
+// or an error when the resolver was created without one.
+func (r *Resolver) webhookService() (ports.WebhookService, error) {
+	if r.webhooks == nil {
+		return nil, fmt.Errorf("webhooks are not configured")
+	}
+	return r.webhooks, nil
+}
+
+// WithAudit sets the service that reads the audit log and returns the resolver.
+// Without it, the audit log query fails.
+func (r *Resolver) WithAudit(audit ports.AuditService) *Resolver {
+	r.audit = audit
+	return r
+}
+
+// auditService returns the service that reads the audit log,
+// or an error when the resolver was created without one.
+func (r *Resolver) auditService() (ports.AuditService, error) {
+	if r.audit == nil {
+		return nil, fmt.Errorf("the audit log is not configured")
+	}
+	return r.audit, nil
+}
+
+// authorizeFamily checks whether the caller may perform an operation.
+// A caller without the operation's permission may still perform it on their own family
+// when they hold the operation's ":own" permission; the returned context then carries
+// an access scope that restricts the family service to that family.
+func (r *Resolver) authorizeFamily(ctx context.Context, operation string) (context.Context, bool, error) {
+	authorized, err := r.authService.IsAuthorized(ctx, operation)
+	if err != nil || authorized {
+		return ctx, authorized, err
+	}
+
+	authorized, err = r.authService.IsAuthorized(ctx, operation+auth.OwnSuffix)
+	if err != nil || !authorized {
+		return ctx, false, err
+	}
+
+	// Only an authenticated caller has a family of their own
+	userID, err := r.authService.GetUserID(ctx)
+	if err != nil || userID == "" {
+		return ctx, false, nil
+	}
+
+	return ports.WithAccessScope(ctx, ports.AccessScope{Restricted: true, UserID: userID}), true, nil
+}
+
+// notAuthorized returns the error for a caller that is not authorized to perform an operation,
+// such as "update parent". It tells callers that are not authenticated apart from those that are.
+func (r *Resolver) notAuthorized(ctx context.Context, operation string) error {
+	userID, err := r.authService.GetUserID(ctx)
+	return domain.NewAuthorizationError(operation, err == nil && userID != "")
+}
+
+// parseOptionalTime parses an optional time argument in RFC3339 format, returning nil when it is absent.
+func parseOptionalTime(value *string) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// parseIDs parses a list of ID arguments into UUIDs, failing on the first that is not a valid UUID.
+func parseIDs(values []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// addressFromInput converts an optional address argument, returning nil when it is absent.
+func addressFromInput(input *AddressInput) *domain.Address {
+	if input == nil {
+		return nil
+	}
+
+	address := &domain.Address{
+		Street:     input.Street,
+		City:       input.City,
+		PostalCode: input.PostalCode,
+		Country:    input.Country,
+	}
+	if input.State != nil {
+		address.State = *input.State
+	}
+	return address
+}
+
+// phonesFromInput converts a list of phone number arguments.
+func phonesFromInput(inputs []PhoneInput) []domain.Phone {
+	phones := make([]domain.Phone, 0, len(inputs))
+	for _, input := range inputs {
+		phone := domain.Phone{Number: input.Number}
+		if input.Type != nil {
+			phone.Type = domain.PhoneType(*input.Type)
+		}
+		phones = append(phones, phone)
+	}
+	return phones
+}
+
+// emergencyContactsFromInput converts a list of emergency contact arguments.
+func emergencyContactsFromInput(inputs []EmergencyContactInput) []domain.EmergencyContact {
+	emergencyContacts := make([]domain.EmergencyContact, 0, len(inputs))
+	for _, input := range inputs {
+		emergencyContact := domain.EmergencyContact{Name: input.Name}
+		if input.Relationship != nil {
+			emergencyContact.Relationship = *input.Relationship
+		}
+		if input.Phone != nil {
+			emergencyContact.Phone = phonesFromInput([]PhoneInput{*input.Phone})[0]
+		}
+		emergencyContacts = append(emergencyContacts, emergencyContact)
+	}
+	return emergencyContacts
+}
@@ -3,11 +3,15 @@ package graphql_test
 import (
 	"context"
 	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/graphql"
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/infrastructure/auth"
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/mocks"
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
 	"github.com/google/uuid"
@@ -23,7 +27,7 @@ func setupResolverTest(t *testing.T) (*graphql.Resolver, *mocks.MockFamilyServic
 	logger := zaptest.NewLogger(t)
 
 	// Create resolver
-	resolver := graphql.NewResolver(mockFamilyService, mockAuthService, logger)
+	resolver := graphql.NewResolver(mockFamilyService, mockAuthService, mocks.NewMockEventBroker(), logger)
 
 	return resolver, mockFamilyService, mockAuthService
 }
@@ -35,7 +39,7 @@ func TestNewResolver(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 
 	// Create resolver
-	resolver := graphql.NewResolver(mockFamilyService, mockAuthService, logger)
+	resolver := graphql.NewResolver(mockFamilyService, mockAuthService, mocks.NewMockEventBroker(), logger)
 
 	// Assert
 	assert.NotNil(t, resolver)
@@ -45,6 +49,12 @@ func TestNewResolver(t *testing.T) {
 	assert.NotNil(t, resolver.Child())
 	assert.NotNil(t, resolver.ParentConnection())
 	assert.NotNil(t, resolver.ChildConnection())
+	assert.NotNil(t, resolver.Subscription())
+	assert.NotNil(t, resolver.ChangeEvent())
+	assert.NotNil(t, resolver.WebhookSubscription())
+	assert.NotNil(t, resolver.WebhookDelivery())
+	assert.NotNil(t, resolver.AuditRecord())
+	assert.NotNil(t, resolver.FieldChange())
 }
 
 func TestQueryResolver_Parent(t *testing.T) {
@@ -70,7 +80,7 @@ func TestQueryResolver_Parent(t *testing.T) {
 	}
 
 	// Execute
-	result, err := resolver.Query().Parent(ctx, parentIDStr)
+	result, err := resolver.Query().Parent(ctx, parentIDStr, nil)
 
 	// Assert
 	require.NoError(t, err)
@@ -91,7 +101,7 @@ func TestQueryResolver_Parent_AuthError(t *testing.T) {
 	}
 
 	// Execute
-	result, err := resolver.Query().Parent(ctx, parentIDStr)
+	result, err := resolver.Query().Parent(ctx, parentIDStr, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -112,12 +122,35 @@ func TestQueryResolver_Parent_Unauthorized(t *testing.T) {
 	}
 
 	// Execute
-	result, err := resolver.Query().Parent(ctx, parentIDStr)
+	result, err := resolver.Query().Parent(ctx, parentIDStr, nil)
 
 	// Assert
 	require.Error(t, err)
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "not authorized")
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+}
+
+func TestQueryResolver_Parent_Unauthenticated(t *testing.T) {
+	// Setup
+	resolver, _, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return false, nil
+	}
+	mockAuthService.GetUserIDFunc = func(ctx context.Context) (string, error) {
+		return "", errors.New("user ID not found in context")
+	}
+
+	// Execute
+	result, err := resolver.Query().Parent(ctx, uuid.New().String(), nil)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
 }
 
 func TestQueryResolver_Parent_InvalidID(t *testing.T) {
@@ -131,12 +164,13 @@ func TestQueryResolver_Parent_InvalidID(t *testing.T) {
 	}
 
 	// Execute
-	result, err := resolver.Query().Parent(ctx, "invalid-uuid")
+	result, err := resolver.Query().Parent(ctx, "invalid-uuid", nil)
 
 	// Assert
 	require.Error(t, err)
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "invalid parent ID")
+	assert.ErrorIs(t, err, domain.ErrValidation)
 }
 
 func TestQueryResolver_Parent_GetError(t *testing.T) {
@@ -156,7 +190,7 @@ func TestQueryResolver_Parent_GetError(t *testing.T) {
 	}
 
 	// Execute
-	result, err := resolver.Query().Parent(ctx, parentIDStr)
+	result, err := resolver.Query().Parent(ctx, parentIDStr, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -171,7 +205,7 @@ func TestQueryResolver_Parent_NilContext(t *testing.T) {
 	parentIDStr := parentID.String()
 
 	// Execute
-	result, err := resolver.Query().Parent(nil, parentIDStr)
+	result, err := resolver.Query().Parent(nil, parentIDStr, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -312,6 +346,44 @@ func TestQueryResolver_Child_NilContext(t *testing.T) {
 	assert.Contains(t, err.Error(), "nil context")
 }
 
+func TestQueryResolver_MyPermissions(t *testing.T) {
+	// Setup
+	resolver, _, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Configure mocks
+	mockAuthService.GetPermissionsFunc = func(ctx context.Context) ([]string, error) {
+		return []string{"child:read", "parent:read", "parent:update"}, nil
+	}
+
+	// Execute
+	result, err := resolver.Query().MyPermissions(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"child:read", "parent:read", "parent:update"}, result)
+	assert.Empty(t, mockAuthService.IsAuthorizedCalls)
+}
+
+func TestQueryResolver_MyPermissions_Error(t *testing.T) {
+	// Setup
+	resolver, _, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Configure mocks
+	mockAuthService.GetPermissionsFunc = func(ctx context.Context) ([]string, error) {
+		return nil, errors.New("policy error")
+	}
+
+	// Execute
+	result, err := resolver.Query().MyPermissions(ctx)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to get permissions")
+}
+
 func TestParentResolver_ID(t *testing.T) {
 	// Setup
 	resolver, _, _ := setupResolverTest(t)
@@ -475,16 +547,120 @@ func TestChildResolver_UpdatedAt(t *testing.T) {
 	assert.Equal(t, updatedAt.Format(time.RFC3339), result)
 }
 
+func TestChildResolver_Guardians(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, _ := setupResolverTest(t)
+	ctx := graphql.WithLoaders(context.Background(), graphql.NewLoaders(context.Background(), mockFamilyService))
+	father := uuid.New()
+	mother := uuid.New()
+	child1 := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), father)
+	child2 := domain.NewChild("Jack", "Doe", time.Now().AddDate(-3, 0, 0), father)
+	fatherOf1 := domain.NewGuardianship(father, child1.ID, domain.GuardianshipFather, child1.CreatedAt, true)
+	motherOf1 := domain.NewGuardianship(mother, child1.ID, domain.GuardianshipMother, child1.CreatedAt, false)
+
+	calls := 0
+	mockFamilyService.ListGuardianshipsByChildIDsFunc = func(ctx context.Context, childIDs []uuid.UUID) ([]*domain.Guardianship, error) {
+		calls++
+		assert.ElementsMatch(t, []uuid.UUID{child1.ID, child2.ID}, childIDs)
+		return []*domain.Guardianship{fatherOf1, motherOf1}, nil
+	}
+
+	// Execute the resolvers of both children concurrently, as gqlgen does for a list
+	results := make([][]*domain.Guardianship, 2)
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i, child := range []*domain.Child{child1, child2} {
+		wg.Add(1)
+		go func(i int, child *domain.Child) {
+			defer wg.Done()
+			results[i], errs[i] = resolver.Child().Guardians(ctx, child)
+		}(i, child)
+	}
+	wg.Wait()
+
+	// Assert that the guardians were loaded in one batch
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, []*domain.Guardianship{fatherOf1, motherOf1}, results[0])
+	assert.Empty(t, results[1])
+}
+
+func TestParentResolver_Wards(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, _ := setupResolverTest(t)
+	ctx := context.Background()
+	testParent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	ward := domain.NewGuardianship(testParent.ID, uuid.New(), domain.GuardianshipFoster, time.Now(), false)
+
+	mockFamilyService.ListGuardianshipsByParentIDsFunc = func(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Guardianship, error) {
+		assert.Equal(t, []uuid.UUID{testParent.ID}, parentIDs)
+		return []*domain.Guardianship{ward}, nil
+	}
+
+	// Execute
+	result, err := resolver.Parent().Wards(ctx, testParent)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.Guardianship{ward}, result)
+}
+
+func TestGuardianshipResolver(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, _ := setupResolverTest(t)
+	ctx := context.Background()
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	child := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parent.ID)
+	guardianship := domain.NewGuardianship(parent.ID, child.ID, domain.GuardianshipFather, child.CreatedAt, true)
+
+	mockFamilyService.GetParentsByIDsFunc = func(ctx context.Context, ids []uuid.UUID) ([]*domain.Parent, error) {
+		return []*domain.Parent{parent}, nil
+	}
+	mockFamilyService.GetChildByIDFunc = func(ctx context.Context, id uuid.UUID) (*domain.Child, error) {
+		if id == child.ID {
+			return child, nil
+		}
+		return nil, domain.NewNotFoundError("Child", id.String())
+	}
+
+	// Execute
+	id, err := resolver.Guardianship().ID(ctx, guardianship)
+	require.NoError(t, err)
+	guardianshipType, err := resolver.Guardianship().Type(ctx, guardianship)
+	require.NoError(t, err)
+	resolvedParent, err := resolver.Guardianship().Parent(ctx, guardianship)
+	require.NoError(t, err)
+	resolvedChild, err := resolver.Guardianship().Child(ctx, guardianship)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, guardianship.ID.String(), id)
+	assert.Equal(t, graphql.GuardianshipTypeFather, guardianshipType)
+	assert.Equal(t, parent, resolvedParent)
+	assert.Equal(t, child, resolvedChild)
+
+	// A deleted child resolves to null
+	guardianship.ChildID = uuid.New()
+	resolvedChild, err = resolver.Guardianship().Child(ctx, guardianship)
+	require.NoError(t, err)
+	assert.Nil(t, resolvedChild)
+}
+
 func TestParentResolver_Children(t *testing.T) {
 	// Setup
-	resolver, _, _ := setupResolverTest(t)
+	resolver, mockFamilyService, _ := setupResolverTest(t)
 	ctx := context.Background()
 
 	// Create a test parent with children
 	testParent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
 	child1 := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), testParent.ID)
 	child2 := domain.NewChild("Jack", "Doe", time.Now().AddDate(-3, 0, 0), testParent.ID)
-	testParent.Children = []domain.Child{*child1, *child2}
+
+	mockFamilyService.ListChildrenByParentIDsFunc = func(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Child, error) {
+		assert.Equal(t, []uuid.UUID{testParent.ID}, parentIDs)
+		return []*domain.Child{child1, child2}, nil
+	}
 
 	// Execute
 	result, err := resolver.Parent().Children(ctx, testParent)
@@ -492,7 +668,113 @@ func TestParentResolver_Children(t *testing.T) {
 	// Assert
 	require.NoError(t, err)
 	assert.Len(t, result, 2)
-	assert.Equal(t, testParent.Children, result)
+	assert.Equal(t, []domain.Child{*child1, *child2}, result)
+}
+
+func TestParentResolver_Children_Batched(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, _ := setupResolverTest(t)
+
+	parents := make([]*domain.Parent, 5)
+	children := make([]*domain.Child, 0, len(parents))
+	for i := range parents {
+		parents[i] = domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+		children = append(children, domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parents[i].ID))
+	}
+
+	var calls int32
+	mockFamilyService.ListChildrenByParentIDsFunc = func(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Child, error) {
+		atomic.AddInt32(&calls, 1)
+		assert.Len(t, parentIDs, len(parents))
+		return children, nil
+	}
+
+	ctx := graphql.WithLoaders(context.Background(), graphql.NewLoaders(context.Background(), mockFamilyService))
+
+	// Execute: resolve the children of every parent concurrently, as gqlgen does for list items
+	results := make([][]domain.Child, len(parents))
+	var wg sync.WaitGroup
+	for i, parent := range parents {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := resolver.Parent().Children(ctx, parent)
+			assert.NoError(t, err)
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for i := range parents {
+		require.Len(t, results[i], 1)
+		assert.Equal(t, *children[i], results[i][0])
+	}
+}
+
+func TestParentResolver_Children_Error(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, _ := setupResolverTest(t)
+	ctx := context.Background()
+	testParent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+
+	mockFamilyService.ListChildrenByParentIDsFunc = func(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Child, error) {
+		return nil, errors.New("database error")
+	}
+
+	// Execute
+	result, err := resolver.Parent().Children(ctx, testParent)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to load children")
+}
+
+func TestChildResolver_Parent(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+
+	testParent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	child := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), testParent.ID)
+	orphan := domain.NewChild("Jack", "Doe", time.Now().AddDate(-3, 0, 0), uuid.New())
+
+	mockFamilyService.GetParentsByIDsFunc = func(ctx context.Context, ids []uuid.UUID) ([]*domain.Parent, error) {
+		return []*domain.Parent{testParent}, nil
+	}
+
+	// Execute
+	result, err := resolver.Child().Parent(ctx, child)
+	require.NoError(t, err)
+	missing, err := resolver.Child().Parent(ctx, orphan)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, testParent, result)
+	assert.Nil(t, missing)
+	assert.Contains(t, mockAuthService.IsAuthorizedCalls, "parent:read")
+}
+
+func TestChildResolver_Parent_Unauthorized(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	mockAuthService.DefaultIsAuthorized = false
+
+	mockFamilyService.GetParentsByIDsFunc = func(ctx context.Context, ids []uuid.UUID) ([]*domain.Parent, error) {
+		t.Fatal("parents must not be loaded without authorization")
+		return nil, nil
+	}
+
+	// Execute
+	result, err := resolver.Child().Parent(ctx, domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), uuid.New()))
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "not authorized")
 }
 
 func TestMutationResolver_CreateParent(t *testing.T) {
@@ -517,7 +799,7 @@ func TestMutationResolver_CreateParent(t *testing.T) {
 		return true, nil
 	}
 
-	mockFamilyService.CreateParentFunc = func(ctx context.Context, firstName, lastName, email, birthDate string) (*domain.Parent, error) {
+	mockFamilyService.CreateParentFunc = func(ctx context.Context, firstName, lastName, email, birthDate string, contact *domain.ContactDetails) (*domain.Parent, error) {
 		assert.Equal(t, input.FirstName, firstName)
 		assert.Equal(t, input.LastName, lastName)
 		assert.Equal(t, input.Email, email)
@@ -534,6 +816,47 @@ func TestMutationResolver_CreateParent(t *testing.T) {
 	assert.Equal(t, testParent, result)
 }
 
+func TestMutationResolver_CreateParent_ContactDetails(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	mobile := graphql.PhoneTypeMobile
+	relationship := "grandmother"
+	input := graphql.CreateParentInput{
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "john.doe@example.com",
+		BirthDate: time.Now().AddDate(-30, 0, 0).Format(time.RFC3339),
+		Phones:    []graphql.PhoneInput{{Number: "+12175550100", Type: &mobile}},
+		Address:   &graphql.AddressInput{Street: "1 Main St", City: "Springfield", PostalCode: "62701", Country: "US"},
+		EmergencyContacts: []graphql.EmergencyContactInput{
+			{Name: "Mary Doe", Relationship: &relationship, Phone: &graphql.PhoneInput{Number: "+12175550199"}},
+		},
+	}
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	mockFamilyService.CreateParentFunc = func(ctx context.Context, firstName, lastName, email, birthDate string, contact *domain.ContactDetails) (*domain.Parent, error) {
+		require.NotNil(t, contact)
+		assert.Equal(t, []domain.Phone{{Number: "+12175550100", Type: domain.PhoneMobile}}, contact.Phones)
+		assert.Equal(t, "62701", contact.Address.PostalCode)
+		assert.Equal(t, []domain.EmergencyContact{
+			{Name: "Mary Doe", Relationship: "grandmother", Phone: domain.Phone{Number: "+12175550199"}},
+		}, contact.EmergencyContacts)
+		return domain.NewParent(firstName, lastName, email, time.Now().AddDate(-30, 0, 0)), nil
+	}
+
+	// Execute
+	result, err := resolver.Mutation().CreateParent(ctx, input)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+}
+
 func TestMutationResolver_CreateParent_AuthError(t *testing.T) {
 	// Setup
 	resolver, _, mockAuthService := setupResolverTest(t)
@@ -606,7 +929,7 @@ func TestMutationResolver_CreateParent_ServiceError(t *testing.T) {
 		return true, nil
 	}
 
-	mockFamilyService.CreateParentFunc = func(ctx context.Context, firstName, lastName, email, birthDate string) (*domain.Parent, error) {
+	mockFamilyService.CreateParentFunc = func(ctx context.Context, firstName, lastName, email, birthDate string, contact *domain.ContactDetails) (*domain.Parent, error) {
 		return nil, errors.New("service error")
 	}
 
@@ -640,6 +963,100 @@ func TestMutationResolver_CreateParent_NilContext(t *testing.T) {
 	assert.Contains(t, err.Error(), "nil context")
 }
 
+func TestMutationResolver_CreateParents(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	birthDate := time.Now().AddDate(-30, 0, 0).Format(time.RFC3339)
+	inputs := []graphql.CreateParentInput{
+		{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com", BirthDate: birthDate},
+		{FirstName: "Jane", LastName: "Doe", Email: "john.doe@example.com", BirthDate: birthDate},
+	}
+	testParent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	atomic := false
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		assert.Equal(t, "parent:create", permission)
+		return true, nil
+	}
+
+	mockFamilyService.CreateParentsFunc = func(ctx context.Context, parentInputs []ports.ParentInput, isAtomic bool) ([]ports.ParentResult, error) {
+		require.Len(t, parentInputs, 2)
+		assert.Equal(t, "Jane", parentInputs[1].FirstName)
+		assert.Equal(t, birthDate, parentInputs[1].BirthDate)
+		assert.False(t, isAtomic)
+		return []ports.ParentResult{
+			{Parent: testParent},
+			{Err: domain.NewDatabaseError("create", "Parent", domain.NewDuplicateError("Parent", "email", "john.doe@example.com"))},
+		}, nil
+	}
+
+	// Execute
+	results, err := resolver.Mutation().CreateParents(ctx, inputs, &atomic)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, testParent, results[0].Parent)
+	assert.Nil(t, results[0].Error)
+	assert.Nil(t, results[1].Parent)
+	require.NotNil(t, results[1].Error)
+	assert.Equal(t, graphql.BatchErrorCodeDuplicate, results[1].Error.Code)
+	assert.Equal(t, "Parent with email john.doe@example.com already exists", results[1].Error.Message)
+	require.NotNil(t, results[1].Error.Field)
+	assert.Equal(t, "email", *results[1].Error.Field)
+}
+
+func TestMutationResolver_CreateParents_InternalError(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	atomic := true
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	mockFamilyService.CreateParentsFunc = func(ctx context.Context, parentInputs []ports.ParentInput, isAtomic bool) ([]ports.ParentResult, error) {
+		assert.True(t, isAtomic)
+		return []ports.ParentResult{
+			{Err: domain.NewDatabaseError("create", "Parent", errors.New("connection refused"))},
+			{Err: domain.ErrAborted},
+		}, nil
+	}
+
+	// Execute
+	results, err := resolver.Mutation().CreateParents(ctx, []graphql.CreateParentInput{{}, {}}, &atomic)
+
+	// Assert: the details of internal errors are hidden
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, graphql.BatchErrorCodeInternal, results[0].Error.Code)
+	assert.Equal(t, "internal error", results[0].Error.Message)
+	assert.Equal(t, graphql.BatchErrorCodeAborted, results[1].Error.Code)
+}
+
+func TestMutationResolver_CreateParents_Unauthorized(t *testing.T) {
+	// Setup
+	resolver, _, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return false, nil
+	}
+
+	// Execute
+	results, err := resolver.Mutation().CreateParents(ctx, []graphql.CreateParentInput{{}}, nil)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, results)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+}
+
 func TestMutationResolver_CreateChild(t *testing.T) {
 	// Setup
 	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
@@ -719,12 +1136,14 @@ func TestMutationResolver_UpdateParent(t *testing.T) {
 		return testParent, nil
 	}
 
-	mockFamilyService.UpdateParentFunc = func(ctx context.Context, id uuid.UUID, firstName, lastName, email, birthDate string) (*domain.Parent, error) {
+	mockFamilyService.UpdateParentFunc = func(ctx context.Context, id uuid.UUID, firstName, lastName, email, birthDate string, contact *domain.ContactDetails, expectedVersion *int) (*domain.Parent, error) {
 		assert.Equal(t, parentID, id)
 		assert.Equal(t, updatedFirstName, firstName)
 		assert.Equal(t, updatedLastName, lastName)
 		assert.Equal(t, updatedEmail, email)
 		assert.Equal(t, updatedBirthDate, birthDate)
+		require.NotNil(t, expectedVersion)
+		assert.Equal(t, testParent.Version, *expectedVersion)
 		return updatedParent, nil
 	}
 
@@ -737,6 +1156,58 @@ func TestMutationResolver_UpdateParent(t *testing.T) {
 	assert.Equal(t, updatedParent, result)
 }
 
+func TestMutationResolver_UpdateParent_ContactDetails(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	testParent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	testParent.Phones = []domain.Phone{{Number: "+12175550100"}}
+	testParent.Address = &domain.Address{Street: "1 Main St", City: "Springfield", PostalCode: "62701", Country: "US"}
+
+	// Only the phones are given, so the address is kept
+	input := graphql.UpdateParentInput{
+		Phones: []graphql.PhoneInput{{Number: "+12175550111"}},
+	}
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	mockFamilyService.GetParentByIDFunc = func(ctx context.Context, id uuid.UUID) (*domain.Parent, error) {
+		return testParent, nil
+	}
+
+	mockFamilyService.UpdateParentFunc = func(ctx context.Context, id uuid.UUID, firstName, lastName, email, birthDate string, contact *domain.ContactDetails, expectedVersion *int) (*domain.Parent, error) {
+		require.NotNil(t, contact)
+		assert.Equal(t, []domain.Phone{{Number: "+12175550111"}}, contact.Phones)
+		assert.Equal(t, testParent.Address, contact.Address)
+		return testParent, nil
+	}
+
+	// Execute
+	_, err := resolver.Mutation().UpdateParent(ctx, testParent.ID.String(), input)
+
+	// Assert
+	require.NoError(t, err)
+}
+
+func TestPhoneResolver_Type(t *testing.T) {
+	// Setup
+	resolver, _, _ := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Execute
+	withType, err := resolver.Phone().Type(ctx, &domain.Phone{Number: "+12175550100", Type: domain.PhoneHome})
+	require.NoError(t, err)
+	withoutType, err := resolver.Phone().Type(ctx, &domain.Phone{Number: "+12175550100"})
+	require.NoError(t, err)
+
+	// Assert
+	require.NotNil(t, withType)
+	assert.Equal(t, graphql.PhoneTypeHome, *withType)
+	assert.Nil(t, withoutType)
+}
 func TestMutationResolver_UpdateParent_AuthError(t *testing.T) {
 	// Setup
 	resolver, _, mockAuthService := setupResolverTest(t)
@@ -873,7 +1344,7 @@ func TestMutationResolver_UpdateParent_UpdateError(t *testing.T) {
 		return testParent, nil
 	}
 
-	mockFamilyService.UpdateParentFunc = func(ctx context.Context, id uuid.UUID, firstName, lastName, email, birthDate string) (*domain.Parent, error) {
+	mockFamilyService.UpdateParentFunc = func(ctx context.Context, id uuid.UUID, firstName, lastName, email, birthDate string, contact *domain.ContactDetails, expectedVersion *int) (*domain.Parent, error) {
 		return nil, errors.New("update error")
 	}
 
@@ -886,11 +1357,51 @@ func TestMutationResolver_UpdateParent_UpdateError(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to update parent")
 }
 
-func TestMutationResolver_UpdateParent_NilContext(t *testing.T) {
+func TestMutationResolver_UpdateParent_ExpectedVersion(t *testing.T) {
 	// Setup
-	resolver, _, _ := setupResolverTest(t)
-	parentID := uuid.New()
-	parentIDStr := parentID.String()
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+
+	testParent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	testParent.Version = 3
+
+	// Input data
+	updatedFirstName := "Jane"
+	staleVersion := 2
+	input := graphql.UpdateParentInput{
+		FirstName:       &updatedFirstName,
+		ExpectedVersion: &staleVersion,
+	}
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	mockFamilyService.GetParentByIDFunc = func(ctx context.Context, id uuid.UUID) (*domain.Parent, error) {
+		return testParent, nil
+	}
+
+	mockFamilyService.UpdateParentFunc = func(ctx context.Context, id uuid.UUID, firstName, lastName, email, birthDate string, contact *domain.ContactDetails, expectedVersion *int) (*domain.Parent, error) {
+		require.NotNil(t, expectedVersion)
+		assert.Equal(t, staleVersion, *expectedVersion)
+		return nil, domain.NewConflictError("Parent", id.String(), *expectedVersion)
+	}
+
+	// Execute
+	result, err := resolver.Mutation().UpdateParent(ctx, testParent.ID.String(), input)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+}
+
+func TestMutationResolver_UpdateParent_NilContext(t *testing.T) {
+	// Setup
+	resolver, _, _ := setupResolverTest(t)
+	parentID := uuid.New()
+	parentIDStr := parentID.String()
 
 	// Input data
 	updatedFirstName := "Jane"
@@ -1034,6 +1545,191 @@ func TestMutationResolver_DeleteParent_NilContext(t *testing.T) {
 	assert.Contains(t, err.Error(), "nil context")
 }
 
+func TestMutationResolver_RestoreParent(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		assert.Equal(t, "parent:restore", permission)
+		return true, nil
+	}
+
+	mockFamilyService.RestoreParentFunc = func(ctx context.Context, id uuid.UUID) (*domain.Parent, error) {
+		assert.Equal(t, parent.ID, id)
+		return parent, nil
+	}
+
+	// Execute
+	result, err := resolver.Mutation().RestoreParent(ctx, parent.ID.String())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, parent, result)
+}
+
+func TestMutationResolver_RestoreParent_Unauthorized(t *testing.T) {
+	// Setup
+	resolver, _, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return false, nil
+	}
+
+	// Execute
+	result, err := resolver.Mutation().RestoreParent(ctx, uuid.New().String())
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+}
+
+func TestMutationResolver_RestoreParent_NotFound(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	parentID := uuid.New()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	mockFamilyService.RestoreParentFunc = func(ctx context.Context, id uuid.UUID) (*domain.Parent, error) {
+		return nil, domain.NewNotFoundError("Parent", id.String())
+	}
+
+	// Execute
+	result, err := resolver.Mutation().RestoreParent(ctx, parentID.String())
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestMutationResolver_MergeParents(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	survivor := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	duplicateID := uuid.New()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		assert.Equal(t, "parent:merge", permission)
+		return true, nil
+	}
+
+	mockFamilyService.MergeParentsFunc = func(ctx context.Context, survivorID, dupID uuid.UUID) (*domain.Parent, error) {
+		assert.Equal(t, survivor.ID, survivorID)
+		assert.Equal(t, duplicateID, dupID)
+		return survivor, nil
+	}
+
+	// Execute
+	result, err := resolver.Mutation().MergeParents(ctx, survivor.ID.String(), duplicateID.String())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, survivor, result)
+}
+
+func TestMutationResolver_MergeParents_InvalidID(t *testing.T) {
+	// Setup
+	resolver, _, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	// Execute
+	result, err := resolver.Mutation().MergeParents(ctx, uuid.New().String(), "invalid-uuid")
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "duplicateId", validationErr.Field)
+}
+
+func TestMutationResolver_CreateChildren_InvalidParentID(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	parentID := uuid.New()
+	birthDate := time.Now().AddDate(-5, 0, 0).Format(time.RFC3339)
+	inputs := []graphql.CreateChildInput{
+		{FirstName: "Jane", LastName: "Doe", BirthDate: birthDate, ParentID: "invalid-uuid"},
+		{FirstName: "Jim", LastName: "Doe", BirthDate: birthDate, ParentID: parentID.String()},
+	}
+	testChild := domain.NewChild("Jim", "Doe", time.Now().AddDate(-5, 0, 0), parentID)
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		assert.Equal(t, "child:create", permission)
+		return true, nil
+	}
+
+	mockFamilyService.CreateChildrenFunc = func(ctx context.Context, childInputs []ports.ChildInput, isAtomic bool) ([]ports.ChildResult, error) {
+		require.Len(t, childInputs, 1)
+		assert.Equal(t, parentID, childInputs[0].ParentID)
+		return []ports.ChildResult{{Child: testChild}}, nil
+	}
+
+	// Execute
+	results, err := resolver.Mutation().CreateChildren(ctx, inputs, nil)
+
+	// Assert: the invalid input fails on its own, and the other keeps its position
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.NotNil(t, results[0].Error)
+	assert.Equal(t, graphql.BatchErrorCodeValidationFailed, results[0].Error.Code)
+	assert.Equal(t, "parentId", *results[0].Error.Field)
+	assert.Equal(t, testChild, results[1].Child)
+	assert.Nil(t, results[1].Error)
+}
+
+func TestMutationResolver_CreateChildren_InvalidParentIDAtomic(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	birthDate := time.Now().AddDate(-5, 0, 0).Format(time.RFC3339)
+	inputs := []graphql.CreateChildInput{
+		{FirstName: "Jane", LastName: "Doe", BirthDate: birthDate, ParentID: uuid.New().String()},
+		{FirstName: "Jim", LastName: "Doe", BirthDate: birthDate, ParentID: "invalid-uuid"},
+	}
+	atomic := true
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	mockFamilyService.CreateChildrenFunc = func(ctx context.Context, childInputs []ports.ChildInput, isAtomic bool) ([]ports.ChildResult, error) {
+		t.Fatal("no child should be created")
+		return nil, nil
+	}
+
+	// Execute
+	results, err := resolver.Mutation().CreateChildren(ctx, inputs, &atomic)
+
+	// Assert: the valid input is aborted with the invalid one
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Nil(t, results[0].Child)
+	assert.Equal(t, graphql.BatchErrorCodeAborted, results[0].Error.Code)
+	assert.Equal(t, graphql.BatchErrorCodeValidationFailed, results[1].Error.Code)
+}
+
 func TestMutationResolver_UpdateChild(t *testing.T) {
 	// Setup
 	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
@@ -1072,7 +1768,7 @@ func TestMutationResolver_UpdateChild(t *testing.T) {
 		return testChild, nil
 	}
 
-	mockFamilyService.UpdateChildFunc = func(ctx context.Context, id uuid.UUID, firstName, lastName, birthDate string) (*domain.Child, error) {
+	mockFamilyService.UpdateChildFunc = func(ctx context.Context, id uuid.UUID, firstName, lastName, birthDate string, expectedVersion *int) (*domain.Child, error) {
 		assert.Equal(t, childID, id)
 		assert.Equal(t, updatedFirstName, firstName)
 		assert.Equal(t, updatedLastName, lastName)
@@ -1226,7 +1922,7 @@ func TestMutationResolver_UpdateChild_UpdateError(t *testing.T) {
 		return testChild, nil
 	}
 
-	mockFamilyService.UpdateChildFunc = func(ctx context.Context, id uuid.UUID, firstName, lastName, birthDate string) (*domain.Child, error) {
+	mockFamilyService.UpdateChildFunc = func(ctx context.Context, id uuid.UUID, firstName, lastName, birthDate string, expectedVersion *int) (*domain.Child, error) {
 		return nil, errors.New("update error")
 	}
 
@@ -1387,6 +2083,112 @@ func TestMutationResolver_DeleteChild_NilContext(t *testing.T) {
 	assert.Contains(t, err.Error(), "nil context")
 }
 
+func TestMutationResolver_DeleteChildren(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	deletedID := uuid.New()
+	missingID := uuid.New()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		assert.Equal(t, "child:delete", permission)
+		return true, nil
+	}
+
+	mockFamilyService.DeleteChildrenFunc = func(ctx context.Context, ids []uuid.UUID, isAtomic bool) ([]ports.DeleteResult, error) {
+		assert.Equal(t, []uuid.UUID{deletedID, missingID}, ids)
+		return []ports.DeleteResult{
+			{ID: deletedID},
+			{ID: missingID, Err: domain.NewNotFoundError("Child", missingID.String())},
+		}, nil
+	}
+
+	// Execute
+	results, err := resolver.Mutation().DeleteChildren(ctx, []string{deletedID.String(), "invalid-uuid", missingID.String()}, nil)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, deletedID.String(), results[0].ID)
+	assert.True(t, results[0].Deleted)
+	assert.Nil(t, results[0].Error)
+	assert.Equal(t, "invalid-uuid", results[1].ID)
+	assert.False(t, results[1].Deleted)
+	assert.Equal(t, graphql.BatchErrorCodeValidationFailed, results[1].Error.Code)
+	assert.Equal(t, missingID.String(), results[2].ID)
+	assert.False(t, results[2].Deleted)
+	assert.Equal(t, graphql.BatchErrorCodeNotFound, results[2].Error.Code)
+}
+
+func TestMutationResolver_DeleteChildren_ServiceError(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	atomic := true
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	mockFamilyService.DeleteChildrenFunc = func(ctx context.Context, ids []uuid.UUID, isAtomic bool) ([]ports.DeleteResult, error) {
+		return nil, domain.NewTransactionError("commit", errors.New("connection lost"))
+	}
+
+	// Execute
+	results, err := resolver.Mutation().DeleteChildren(ctx, []string{uuid.New().String()}, &atomic)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, results)
+	assert.Contains(t, err.Error(), "failed to delete children")
+}
+
+func TestMutationResolver_RestoreChild(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	child := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), uuid.New())
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		assert.Equal(t, "child:restore", permission)
+		return true, nil
+	}
+
+	mockFamilyService.RestoreChildFunc = func(ctx context.Context, id uuid.UUID) (*domain.Child, error) {
+		assert.Equal(t, child.ID, id)
+		return child, nil
+	}
+
+	// Execute
+	result, err := resolver.Mutation().RestoreChild(ctx, child.ID.String())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, child, result)
+}
+
+func TestMutationResolver_RestoreChild_InvalidID(t *testing.T) {
+	// Setup
+	resolver, _, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	// Execute
+	result, err := resolver.Mutation().RestoreChild(ctx, "invalid-uuid")
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrValidation)
+}
+
 func TestMutationResolver_AddChildToParent(t *testing.T) {
 	// Setup
 	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
@@ -1402,14 +2204,42 @@ func TestMutationResolver_AddChildToParent(t *testing.T) {
 		return true, nil
 	}
 
-	mockFamilyService.AddChildToParentFunc = func(ctx context.Context, pID, cID uuid.UUID) error {
+	mockFamilyService.AddChildToParentFunc = func(ctx context.Context, pID, cID uuid.UUID, guardianshipType domain.GuardianshipType, primaryContact bool, startDate *time.Time) error {
 		assert.Equal(t, parentID, pID)
 		assert.Equal(t, childID, cID)
+		assert.Equal(t, domain.GuardianshipGuardian, guardianshipType)
+		assert.False(t, primaryContact)
+		assert.Nil(t, startDate)
+		return nil
+	}
+
+	// Execute
+	result, err := resolver.Mutation().AddChildToParent(ctx, parentIDStr, childIDStr, nil, nil, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, result)
+}
+
+func TestMutationResolver_AddChildToParent_Guardianship(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, _ := setupResolverTest(t)
+	ctx := context.Background()
+	parentID := uuid.New()
+	childID := uuid.New()
+	guardianshipType := graphql.GuardianshipTypeMother
+	primaryContact := true
+	startDate := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	mockFamilyService.AddChildToParentFunc = func(ctx context.Context, pID, cID uuid.UUID, gType domain.GuardianshipType, primary bool, start *time.Time) error {
+		assert.Equal(t, domain.GuardianshipMother, gType)
+		assert.True(t, primary)
+		assert.Equal(t, &startDate, start)
 		return nil
 	}
 
 	// Execute
-	result, err := resolver.Mutation().AddChildToParent(ctx, parentIDStr, childIDStr)
+	result, err := resolver.Mutation().AddChildToParent(ctx, parentID.String(), childID.String(), &guardianshipType, &primaryContact, &startDate)
 
 	// Assert
 	require.NoError(t, err)
@@ -1431,7 +2261,7 @@ func TestMutationResolver_AddChildToParent_AuthError(t *testing.T) {
 	}
 
 	// Execute
-	result, err := resolver.Mutation().AddChildToParent(ctx, parentIDStr, childIDStr)
+	result, err := resolver.Mutation().AddChildToParent(ctx, parentIDStr, childIDStr, nil, nil, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -1454,7 +2284,7 @@ func TestMutationResolver_AddChildToParent_Unauthorized(t *testing.T) {
 	}
 
 	// Execute
-	result, err := resolver.Mutation().AddChildToParent(ctx, parentIDStr, childIDStr)
+	result, err := resolver.Mutation().AddChildToParent(ctx, parentIDStr, childIDStr, nil, nil, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -1475,7 +2305,7 @@ func TestMutationResolver_AddChildToParent_InvalidParentID(t *testing.T) {
 	}
 
 	// Execute
-	result, err := resolver.Mutation().AddChildToParent(ctx, "invalid-uuid", childIDStr)
+	result, err := resolver.Mutation().AddChildToParent(ctx, "invalid-uuid", childIDStr, nil, nil, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -1496,7 +2326,7 @@ func TestMutationResolver_AddChildToParent_InvalidChildID(t *testing.T) {
 	}
 
 	// Execute
-	result, err := resolver.Mutation().AddChildToParent(ctx, parentIDStr, "invalid-uuid")
+	result, err := resolver.Mutation().AddChildToParent(ctx, parentIDStr, "invalid-uuid", nil, nil, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -1518,12 +2348,12 @@ func TestMutationResolver_AddChildToParent_ServiceError(t *testing.T) {
 		return true, nil
 	}
 
-	mockFamilyService.AddChildToParentFunc = func(ctx context.Context, pID, cID uuid.UUID) error {
+	mockFamilyService.AddChildToParentFunc = func(ctx context.Context, pID, cID uuid.UUID, guardianshipType domain.GuardianshipType, primaryContact bool, startDate *time.Time) error {
 		return errors.New("service error")
 	}
 
 	// Execute
-	result, err := resolver.Mutation().AddChildToParent(ctx, parentIDStr, childIDStr)
+	result, err := resolver.Mutation().AddChildToParent(ctx, parentIDStr, childIDStr, nil, nil, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -1540,7 +2370,7 @@ func TestMutationResolver_AddChildToParent_NilContext(t *testing.T) {
 	childIDStr := childID.String()
 
 	// Execute
-	result, err := resolver.Mutation().AddChildToParent(nil, parentIDStr, childIDStr)
+	result, err := resolver.Mutation().AddChildToParent(nil, parentIDStr, childIDStr, nil, nil, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -1709,69 +2539,58 @@ func TestMutationResolver_RemoveChildFromParent_NilContext(t *testing.T) {
 	assert.Contains(t, err.Error(), "nil context")
 }
 
-func TestQueryResolver_Parents(t *testing.T) {
+func TestMutationResolver_TransferChild(t *testing.T) {
 	// Setup
 	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
-
-	// Create test parents
-	parent1 := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
-	parent2 := domain.NewParent("Jane", "Smith", "jane.smith@example.com", time.Now().AddDate(-25, 0, 0))
-	parents := []*domain.Parent{parent1, parent2}
-
-	// Create paged result
-	pagedResult := &ports.PagedResult{
-		Page:       0,
-		PageSize:   10,
-		TotalCount: 2,
-		HasNext:    false,
-	}
+	fromParentID := uuid.New()
+	toParentID := uuid.New()
+	child := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), toParentID)
 
 	// Configure mocks
 	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
-		assert.Equal(t, "parent:list", permission)
+		assert.Equal(t, "child:transfer", permission)
 		return true, nil
 	}
 
-	mockFamilyService.ListParentsFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
-		// Verify options
-		assert.Equal(t, 0, options.Pagination.Page)
-		assert.Equal(t, 10, options.Pagination.PageSize)
-		return parents, pagedResult, nil
+	mockFamilyService.TransferChildFunc = func(ctx context.Context, childID, fromID, toID uuid.UUID, reason string) (*domain.Child, error) {
+		assert.Equal(t, child.ID, childID)
+		assert.Equal(t, fromParentID, fromID)
+		assert.Equal(t, toParentID, toID)
+		assert.Equal(t, "Custody order", reason)
+		return child, nil
 	}
 
 	// Execute
-	result, err := resolver.Query().Parents(ctx, nil, nil, nil)
+	result, err := resolver.Mutation().TransferChild(ctx, child.ID.String(), fromParentID.String(), toParentID.String(), "Custody order")
 
 	// Assert
 	require.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Equal(t, 2, result.TotalCount)
-	assert.Len(t, result.Edges, 2)
-	assert.Equal(t, parent1, result.Edges[0].Node)
-	assert.Equal(t, parent2, result.Edges[1].Node)
+	assert.Equal(t, child, result)
 }
 
-func TestQueryResolver_Parents_AuthError(t *testing.T) {
+func TestMutationResolver_TransferChild_InvalidToParentID(t *testing.T) {
 	// Setup
 	resolver, _, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
 
 	// Configure mocks
 	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
-		return false, errors.New("authorization error")
+		return true, nil
 	}
 
 	// Execute
-	result, err := resolver.Query().Parents(ctx, nil, nil, nil)
+	result, err := resolver.Mutation().TransferChild(ctx, uuid.New().String(), uuid.New().String(), "invalid-uuid", "Custody order")
 
 	// Assert
 	require.Error(t, err)
 	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "failed to check authorization")
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "toParentId", validationErr.Field)
 }
 
-func TestQueryResolver_Parents_Unauthorized(t *testing.T) {
+func TestMutationResolver_TransferChild_Unauthorized(t *testing.T) {
 	// Setup
 	resolver, _, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
@@ -1782,181 +2601,179 @@ func TestQueryResolver_Parents_Unauthorized(t *testing.T) {
 	}
 
 	// Execute
-	result, err := resolver.Query().Parents(ctx, nil, nil, nil)
+	result, err := resolver.Mutation().TransferChild(ctx, uuid.New().String(), uuid.New().String(), uuid.New().String(), "Custody order")
 
 	// Assert
 	require.Error(t, err)
 	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "not authorized")
+	assert.ErrorIs(t, err, domain.ErrForbidden)
 }
 
-func TestQueryResolver_Parents_WithFilter(t *testing.T) {
+func TestMutationResolver_LinkParentUser(t *testing.T) {
 	// Setup
 	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	userID := "user-1"
 
-	// Create test parents
-	parent1 := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
-	parents := []*domain.Parent{parent1}
-
-	// Create paged result
-	pagedResult := &ports.PagedResult{
-		Page:       0,
-		PageSize:   10,
-		TotalCount: 1,
-		HasNext:    false,
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		assert.Equal(t, "parent:link", permission)
+		return true, nil
 	}
 
-	// Create filter
-	firstName := "John"
-	lastName := "Doe"
-	email := "john.doe@example.com"
-	minAge := 25
-	maxAge := 35
-	filter := &graphql.ParentFilter{
-		FirstName: &firstName,
-		LastName:  &lastName,
-		Email:     &email,
-		MinAge:    &minAge,
-		MaxAge:    &maxAge,
+	mockFamilyService.LinkParentUserFunc = func(ctx context.Context, id uuid.UUID, linkedUserID string) (*domain.Parent, error) {
+		assert.Equal(t, parent.ID, id)
+		assert.Equal(t, userID, linkedUserID)
+		parent.LinkUser(linkedUserID)
+		return parent, nil
 	}
 
+	// Execute
+	result, err := resolver.Mutation().LinkParentUser(ctx, parent.ID.String(), &userID)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	linkedUserID, err := resolver.Parent().UserID(ctx, result)
+	require.NoError(t, err)
+	require.NotNil(t, linkedUserID)
+	assert.Equal(t, userID, *linkedUserID)
+}
+
+func TestMutationResolver_LinkParentUser_Unlink(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+
 	// Configure mocks
 	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
 		return true, nil
 	}
 
-	mockFamilyService.ListParentsFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
-		// Verify filter options
-		assert.Equal(t, firstName, options.Filter.FirstName)
-		assert.Equal(t, lastName, options.Filter.LastName)
-		assert.Equal(t, email, options.Filter.Email)
-		assert.Equal(t, minAge, options.Filter.MinAge)
-		assert.Equal(t, maxAge, options.Filter.MaxAge)
-		return parents, pagedResult, nil
+	mockFamilyService.LinkParentUserFunc = func(ctx context.Context, id uuid.UUID, linkedUserID string) (*domain.Parent, error) {
+		assert.Empty(t, linkedUserID)
+		return parent, nil
 	}
 
 	// Execute
-	result, err := resolver.Query().Parents(ctx, filter, nil, nil)
+	result, err := resolver.Mutation().LinkParentUser(ctx, parent.ID.String(), nil)
 
 	// Assert
 	require.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Equal(t, 1, result.TotalCount)
-	assert.Len(t, result.Edges, 1)
-	assert.Equal(t, parent1, result.Edges[0].Node)
+	linkedUserID, err := resolver.Parent().UserID(ctx, result)
+	require.NoError(t, err)
+	assert.Nil(t, linkedUserID)
 }
 
-func TestQueryResolver_Parents_WithPagination(t *testing.T) {
+func TestMutationResolver_LinkParentUser_Unauthorized(t *testing.T) {
 	// Setup
-	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	resolver, _, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
+	userID := "user-1"
 
-	// Create test parents
-	parent1 := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
-	parents := []*domain.Parent{parent1}
-
-	// Create paged result
-	pagedResult := &ports.PagedResult{
-		Page:       2,
-		PageSize:   5,
-		TotalCount: 15,
-		HasNext:    true,
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return false, nil
 	}
 
-	// Create pagination
-	page := 2
-	pageSize := 5
-	pagination := &graphql.PaginationInput{
-		Page:     &page,
-		PageSize: &pageSize,
-	}
+	// Execute
+	result, err := resolver.Mutation().LinkParentUser(ctx, uuid.New().String(), &userID)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "not authorized")
+}
+
+func TestMutationResolver_PurgeDeleted(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
 
 	// Configure mocks
+	var permissions []string
 	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		permissions = append(permissions, permission)
 		return true, nil
 	}
 
-	mockFamilyService.ListParentsFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
-		// Verify pagination options
-		assert.Equal(t, page, options.Pagination.Page)
-		assert.Equal(t, pageSize, options.Pagination.PageSize)
-		return parents, pagedResult, nil
+	mockFamilyService.PurgeDeletedFunc = func(ctx context.Context) (*ports.PurgeResult, error) {
+		return &ports.PurgeResult{Parents: 2, Children: 3}, nil
 	}
 
 	// Execute
-	result, err := resolver.Query().Parents(ctx, nil, pagination, nil)
+	result, err := resolver.Mutation().PurgeDeleted(ctx)
 
 	// Assert
 	require.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Equal(t, 15, result.TotalCount)
-	assert.Len(t, result.Edges, 1)
-	assert.Equal(t, parent1, result.Edges[0].Node)
-	assert.True(t, result.PageInfo.HasNextPage)
-	assert.True(t, result.PageInfo.HasPreviousPage)
+	assert.Equal(t, &ports.PurgeResult{Parents: 2, Children: 3}, result)
+	assert.Equal(t, []string{"parent:purge", "child:purge"}, permissions)
 }
 
-func TestQueryResolver_Parents_WithSort(t *testing.T) {
+func TestMutationResolver_PurgeDeleted_Unauthorized(t *testing.T) {
 	// Setup
 	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
 
-	// Create test parents
-	parent1 := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
-	parents := []*domain.Parent{parent1}
-
-	// Create paged result
-	pagedResult := &ports.PagedResult{
-		Page:       0,
-		PageSize:   10,
-		TotalCount: 1,
-		HasNext:    false,
+	// Configure mocks; the caller may purge parents but not children
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return permission == "parent:purge", nil
 	}
 
-	// Create sort
-	field := "firstName"
-	direction := graphql.SortDirectionAsc
-	sort := &graphql.SortInput{
-		Field:     &field,
-		Direction: &direction,
+	mockFamilyService.PurgeDeletedFunc = func(ctx context.Context) (*ports.PurgeResult, error) {
+		t.Fatal("PurgeDeleted should not be called")
+		return nil, nil
 	}
 
-	// Configure mocks
+	// Execute
+	result, err := resolver.Mutation().PurgeDeleted(ctx)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "not authorized")
+}
+
+func TestQueryResolver_Parent_OwnFamily(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+
+	// Configure mocks: the caller may only read their own family
 	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
-		return true, nil
+		return permission == "parent:read:own", nil
+	}
+	mockAuthService.GetUserIDFunc = func(ctx context.Context) (string, error) {
+		return "user-1", nil
 	}
 
-	mockFamilyService.ListParentsFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
-		// Verify sort options
-		assert.Equal(t, field, options.Sort.Field)
-		assert.Equal(t, "asc", options.Sort.Direction)
-		return parents, pagedResult, nil
+	mockFamilyService.GetParentByIDFunc = func(ctx context.Context, id uuid.UUID) (*domain.Parent, error) {
+		assert.Equal(t, ports.AccessScope{Restricted: true, UserID: "user-1"}, ports.AccessScopeFromContext(ctx))
+		return parent, nil
 	}
 
 	// Execute
-	result, err := resolver.Query().Parents(ctx, nil, nil, sort)
+	result, err := resolver.Query().Parent(ctx, parent.ID.String(), nil)
 
 	// Assert
 	require.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Equal(t, 1, result.TotalCount)
-	assert.Len(t, result.Edges, 1)
-	assert.Equal(t, parent1, result.Edges[0].Node)
+	assert.Equal(t, parent, result)
 }
 
-func TestQueryResolver_Parents_ListError(t *testing.T) {
+func TestQueryResolver_Parents_OwnFamilyWithoutUser(t *testing.T) {
 	// Setup
-	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	resolver, _, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
 
-	// Configure mocks
+	// Configure mocks: an anonymous caller has no family of their own
 	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
-		return true, nil
+		return permission == "parent:list:own", nil
 	}
-
-	mockFamilyService.ListParentsFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
-		return nil, nil, errors.New("list error")
+	mockAuthService.GetUserIDFunc = func(ctx context.Context) (string, error) {
+		return "", errors.New("user ID not found in context")
 	}
 
 	// Execute
@@ -1965,19 +2782,76 @@ func TestQueryResolver_Parents_ListError(t *testing.T) {
 	// Assert
 	require.Error(t, err)
 	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "failed to list parents")
+	assert.Contains(t, err.Error(), "not authorized")
 }
 
-func TestQueryResolver_Children(t *testing.T) {
+func TestQueryResolver_Parent_AnonymousWithShippedPolicy(t *testing.T) {
+	// Setup: the policy the service ships with, for a caller without a token
+	logger := zaptest.NewLogger(t)
+	policy, err := auth.LoadPolicyFile(filepath.Join("..", "..", "..", "config", "policy.yaml"))
+	require.NoError(t, err)
+	engine, err := auth.NewPolicyEngine(policy, logger)
+	require.NoError(t, err)
+	mockFamilyService := mocks.NewMockFamilyService()
+	resolver := graphql.NewResolver(mockFamilyService, auth.NewAuthorizationServiceWithPolicy(engine, logger), mocks.NewMockEventBroker(), logger)
+
+	mockFamilyService.GetParentByIDFunc = func(ctx context.Context, id uuid.UUID) (*domain.Parent, error) {
+		t.Fatal("an anonymous caller must not reach the family service")
+		return nil, nil
+	}
+	mockFamilyService.ListParentsFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
+		t.Fatal("an anonymous caller must not reach the family service")
+		return nil, nil, nil
+	}
+
+	// Execute
+	parent, parentErr := resolver.Query().Parent(context.Background(), uuid.New().String(), nil)
+	parents, parentsErr := resolver.Query().Parents(context.Background(), nil, nil, nil)
+
+	// Assert
+	require.Error(t, parentErr)
+	assert.Nil(t, parent)
+	assert.Contains(t, parentErr.Error(), "not authorized")
+	require.Error(t, parentsErr)
+	assert.Nil(t, parents)
+}
+
+func TestQueryResolver_Parent_GuardianWithDefaultPolicy(t *testing.T) {
+	// Setup: the policy used without a policy file, for a guardian linked to another family
+	logger := zaptest.NewLogger(t)
+	engine, err := auth.NewPolicyEngine(auth.DefaultPolicy(), logger)
+	require.NoError(t, err)
+	mockFamilyService := mocks.NewMockFamilyService()
+	resolver := graphql.NewResolver(mockFamilyService, auth.NewAuthorizationServiceWithPolicy(engine, logger), mocks.NewMockEventBroker(), logger)
+	ctx := auth.WithUserRoles(auth.WithUserID(context.Background(), "guardian-user"), []string{"guardian"})
+
+	otherParentID := uuid.New()
+	mockFamilyService.GetParentByIDFunc = func(ctx context.Context, id uuid.UUID) (*domain.Parent, error) {
+		// The service forbids other families to callers whose access is restricted to their own
+		scope := ports.AccessScopeFromContext(ctx)
+		assert.True(t, scope.Restricted, "a guardian must only reach their own family")
+		assert.Equal(t, "guardian-user", scope.UserID)
+		return nil, domain.NewForbiddenError("Parent", id.String())
+	}
+
+	// Execute
+	parent, err := resolver.Query().Parent(ctx, otherParentID.String(), nil)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, parent)
+	assert.True(t, errors.Is(err, domain.ErrForbidden))
+}
+
+func TestQueryResolver_Parents(t *testing.T) {
 	// Setup
 	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
-	parentID := uuid.New()
 
-	// Create test children
-	child1 := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parentID)
-	child2 := domain.NewChild("Jack", "Doe", time.Now().AddDate(-3, 0, 0), parentID)
-	children := []*domain.Child{child1, child2}
+	// Create test parents
+	parent1 := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	parent2 := domain.NewParent("Jane", "Smith", "jane.smith@example.com", time.Now().AddDate(-25, 0, 0))
+	parents := []*domain.Parent{parent1, parent2}
 
 	// Create paged result
 	pagedResult := &ports.PagedResult{
@@ -1989,30 +2863,30 @@ func TestQueryResolver_Children(t *testing.T) {
 
 	// Configure mocks
 	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
-		assert.Equal(t, "child:list", permission)
+		assert.Equal(t, "parent:list", permission)
 		return true, nil
 	}
 
-	mockFamilyService.ListChildrenFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
+	mockFamilyService.ListParentsFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
 		// Verify options
 		assert.Equal(t, 0, options.Pagination.Page)
 		assert.Equal(t, 10, options.Pagination.PageSize)
-		return children, pagedResult, nil
+		return parents, pagedResult, nil
 	}
 
 	// Execute
-	result, err := resolver.Query().Children(ctx, nil, nil, nil)
+	result, err := resolver.Query().Parents(ctx, nil, nil, nil)
 
 	// Assert
 	require.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, 2, result.TotalCount)
 	assert.Len(t, result.Edges, 2)
-	assert.Equal(t, child1, result.Edges[0].Node)
-	assert.Equal(t, child2, result.Edges[1].Node)
+	assert.Equal(t, parent1, result.Edges[0].Node)
+	assert.Equal(t, parent2, result.Edges[1].Node)
 }
 
-func TestQueryResolver_Children_AuthError(t *testing.T) {
+func TestQueryResolver_Parents_AuthError(t *testing.T) {
 	// Setup
 	resolver, _, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
@@ -2023,7 +2897,7 @@ func TestQueryResolver_Children_AuthError(t *testing.T) {
 	}
 
 	// Execute
-	result, err := resolver.Query().Children(ctx, nil, nil, nil)
+	result, err := resolver.Query().Parents(ctx, nil, nil, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -2031,7 +2905,7 @@ func TestQueryResolver_Children_AuthError(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to check authorization")
 }
 
-func TestQueryResolver_Children_Unauthorized(t *testing.T) {
+func TestQueryResolver_Parents_Unauthorized(t *testing.T) {
 	// Setup
 	resolver, _, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
@@ -2042,7 +2916,7 @@ func TestQueryResolver_Children_Unauthorized(t *testing.T) {
 	}
 
 	// Execute
-	result, err := resolver.Query().Children(ctx, nil, nil, nil)
+	result, err := resolver.Query().Parents(ctx, nil, nil, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -2050,15 +2924,14 @@ func TestQueryResolver_Children_Unauthorized(t *testing.T) {
 	assert.Contains(t, err.Error(), "not authorized")
 }
 
-func TestQueryResolver_Children_WithFilter(t *testing.T) {
+func TestQueryResolver_Parents_WithFilter(t *testing.T) {
 	// Setup
 	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
-	parentID := uuid.New()
 
-	// Create test children
-	child1 := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parentID)
-	children := []*domain.Child{child1}
+	// Create test parents
+	parent1 := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	parents := []*domain.Parent{parent1}
 
 	// Create paged result
 	pagedResult := &ports.PagedResult{
@@ -2069,13 +2942,15 @@ func TestQueryResolver_Children_WithFilter(t *testing.T) {
 	}
 
 	// Create filter
-	firstName := "Jane"
+	firstName := "John"
 	lastName := "Doe"
-	minAge := 3
-	maxAge := 10
-	filter := &graphql.ChildFilter{
+	email := "john.doe@example.com"
+	minAge := 25
+	maxAge := 35
+	filter := &graphql.ParentFilter{
 		FirstName: &firstName,
 		LastName:  &lastName,
+		Email:     &email,
 		MinAge:    &minAge,
 		MaxAge:    &maxAge,
 	}
@@ -2085,37 +2960,37 @@ func TestQueryResolver_Children_WithFilter(t *testing.T) {
 		return true, nil
 	}
 
-	mockFamilyService.ListChildrenFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
+	mockFamilyService.ListParentsFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
 		// Verify filter options
 		assert.Equal(t, firstName, options.Filter.FirstName)
 		assert.Equal(t, lastName, options.Filter.LastName)
+		assert.Equal(t, email, options.Filter.Email)
 		assert.Equal(t, minAge, options.Filter.MinAge)
 		assert.Equal(t, maxAge, options.Filter.MaxAge)
-		return children, pagedResult, nil
+		return parents, pagedResult, nil
 	}
 
 	// Execute
-	result, err := resolver.Query().Children(ctx, filter, nil, nil)
+	result, err := resolver.Query().Parents(ctx, filter, nil, nil)
 
 	// Assert
 	require.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, 1, result.TotalCount)
 	assert.Len(t, result.Edges, 1)
-	assert.Equal(t, child1, result.Edges[0].Node)
+	assert.Equal(t, parent1, result.Edges[0].Node)
 }
 
-func TestQueryResolver_Children_WithPagination(t *testing.T) {
+func TestQueryResolver_Parents_WithPagination(t *testing.T) {
 	// Setup
 	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
-	parentID := uuid.New()
-
-	// Create test children
-	child1 := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parentID)
-	children := []*domain.Child{child1}
 
-	// Create paged result
+	// Create test parents
+	parent1 := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	parents := []*domain.Parent{parent1}
+
+	// Create paged result
 	pagedResult := &ports.PagedResult{
 		Page:       2,
 		PageSize:   5,
@@ -2136,35 +3011,107 @@ func TestQueryResolver_Children_WithPagination(t *testing.T) {
 		return true, nil
 	}
 
-	mockFamilyService.ListChildrenFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
+	mockFamilyService.ListParentsFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
 		// Verify pagination options
 		assert.Equal(t, page, options.Pagination.Page)
 		assert.Equal(t, pageSize, options.Pagination.PageSize)
-		return children, pagedResult, nil
+		return parents, pagedResult, nil
 	}
 
 	// Execute
-	result, err := resolver.Query().Children(ctx, nil, pagination, nil)
+	result, err := resolver.Query().Parents(ctx, nil, pagination, nil)
 
 	// Assert
 	require.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, 15, result.TotalCount)
 	assert.Len(t, result.Edges, 1)
-	assert.Equal(t, child1, result.Edges[0].Node)
+	assert.Equal(t, parent1, result.Edges[0].Node)
 	assert.True(t, result.PageInfo.HasNextPage)
 	assert.True(t, result.PageInfo.HasPreviousPage)
 }
 
-func TestQueryResolver_Children_WithSort(t *testing.T) {
+func TestQueryResolver_Parents_WithCursor(t *testing.T) {
 	// Setup
 	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
-	parentID := uuid.New()
 
-	// Create test children
-	child1 := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parentID)
-	children := []*domain.Child{child1}
+	// Create test parents
+	parent1 := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	parent2 := domain.NewParent("Jane", "Doe", "jane.doe@example.com", time.Now().AddDate(-28, 0, 0))
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	mockFamilyService.ListParentsFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
+		if options.Cursor.After == nil {
+			return []*domain.Parent{parent1}, &ports.PagedResult{TotalCount: 2, PageSize: 1, HasNext: true}, nil
+		}
+
+		// Verify the cursor was decoded into the keyset of the last row of the first page
+		assert.Equal(t, 1, options.Cursor.First)
+		assert.Equal(t, "lastName", options.Cursor.After.SortField)
+		assert.Equal(t, parent1.LastName, options.Cursor.After.SortValue)
+		assert.Equal(t, parent1.ID, options.Cursor.After.ID)
+		return []*domain.Parent{parent2}, &ports.PagedResult{TotalCount: 2, PageSize: 1, HasPrevious: true}, nil
+	}
+
+	first := 1
+	field := "lastName"
+	sort := &graphql.SortInput{Field: &field}
+
+	// Execute first page
+	result, err := resolver.Query().Parents(ctx, nil, &graphql.PaginationInput{First: &first}, sort)
+	require.NoError(t, err)
+	require.NotNil(t, result.PageInfo.EndCursor)
+	assert.True(t, result.PageInfo.HasNextPage)
+	assert.False(t, result.PageInfo.HasPreviousPage)
+
+	// Execute second page
+	result, err = resolver.Query().Parents(ctx, nil, &graphql.PaginationInput{First: &first, After: result.PageInfo.EndCursor}, sort)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, result.Edges, 1)
+	assert.Equal(t, parent2, result.Edges[0].Node)
+	assert.False(t, result.PageInfo.HasNextPage)
+	assert.True(t, result.PageInfo.HasPreviousPage)
+}
+
+func TestQueryResolver_Parents_InvalidCursor(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	mockFamilyService.ListParentsFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
+		t.Fatal("ListParents should not be called with an invalid cursor")
+		return nil, nil, nil
+	}
+
+	// Execute with a cursor that cannot be decoded
+	after := "not-a-cursor"
+	_, err := resolver.Query().Parents(ctx, nil, &graphql.PaginationInput{After: &after}, nil)
+
+	// Assert
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrValidation))
+}
+
+func TestQueryResolver_Parents_WithSort(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Create test parents
+	parent1 := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	parents := []*domain.Parent{parent1}
 
 	// Create paged result
 	pagedResult := &ports.PagedResult{
@@ -2187,25 +3134,25 @@ func TestQueryResolver_Children_WithSort(t *testing.T) {
 		return true, nil
 	}
 
-	mockFamilyService.ListChildrenFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
+	mockFamilyService.ListParentsFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
 		// Verify sort options
 		assert.Equal(t, field, options.Sort.Field)
 		assert.Equal(t, "asc", options.Sort.Direction)
-		return children, pagedResult, nil
+		return parents, pagedResult, nil
 	}
 
 	// Execute
-	result, err := resolver.Query().Children(ctx, nil, nil, sort)
+	result, err := resolver.Query().Parents(ctx, nil, nil, sort)
 
 	// Assert
 	require.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, 1, result.TotalCount)
 	assert.Len(t, result.Edges, 1)
-	assert.Equal(t, child1, result.Edges[0].Node)
+	assert.Equal(t, parent1, result.Edges[0].Node)
 }
 
-func TestQueryResolver_Children_ListError(t *testing.T) {
+func TestQueryResolver_Parents_ListError(t *testing.T) {
 	// Setup
 	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
@@ -2215,25 +3162,122 @@ func TestQueryResolver_Children_ListError(t *testing.T) {
 		return true, nil
 	}
 
-	mockFamilyService.ListChildrenFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
+	mockFamilyService.ListParentsFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
 		return nil, nil, errors.New("list error")
 	}
 
 	// Execute
-	result, err := resolver.Query().Children(ctx, nil, nil, nil)
+	result, err := resolver.Query().Parents(ctx, nil, nil, nil)
 
 	// Assert
 	require.Error(t, err)
 	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "failed to list children")
+	assert.Contains(t, err.Error(), "failed to list parents")
 }
 
-func TestQueryResolver_ChildrenByParent(t *testing.T) {
+func TestQueryResolver_DeletedParents(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	parent.MarkAsDeleted()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		assert.Equal(t, "parent:list-deleted", permission)
+		return true, nil
+	}
+
+	mockFamilyService.ListParentsFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
+		assert.True(t, options.Filter.Deleted)
+		return []*domain.Parent{parent}, &ports.PagedResult{PageSize: 10, TotalCount: 1}, nil
+	}
+
+	// Execute
+	result, err := resolver.Query().DeletedParents(ctx, nil, nil, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.TotalCount)
+	require.Len(t, result.Edges, 1)
+	assert.Equal(t, parent, result.Edges[0].Node)
+}
+
+func TestQueryResolver_DeletedParents_Unauthorized(t *testing.T) {
+	// Setup
+	resolver, _, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return false, nil
+	}
+
+	// Execute
+	result, err := resolver.Query().DeletedParents(ctx, nil, nil, nil)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "not authorized")
+}
+
+func TestQueryResolver_PossibleDuplicateParents(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	duplicate := domain.NewParent("Jon", "Doe", "john.doe@example.com", parent.BirthDate)
+	minScore := 0.8
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		assert.Equal(t, "parent:list-duplicates", permission)
+		return true, nil
+	}
+
+	mockFamilyService.FindDuplicateParentsFunc = func(ctx context.Context, parentID *uuid.UUID, score float64, limit int) ([]*domain.ParentDuplicate, error) {
+		require.NotNil(t, parentID)
+		assert.Equal(t, parent.ID, *parentID)
+		assert.Equal(t, minScore, score)
+		assert.Zero(t, limit)
+		return []*domain.ParentDuplicate{{Parent: parent, Duplicate: duplicate, Score: 0.938}}, nil
+	}
+
+	// Execute
+	parentID := parent.ID.String()
+	result, err := resolver.Query().PossibleDuplicateParents(ctx, &parentID, &minScore, nil)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, duplicate, result[0].Duplicate)
+}
+
+func TestQueryResolver_PossibleDuplicateParents_Unauthorized(t *testing.T) {
+	// Setup
+	resolver, _, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return false, nil
+	}
+
+	// Execute
+	result, err := resolver.Query().PossibleDuplicateParents(ctx, nil, nil, nil)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+}
+
+func TestQueryResolver_Children(t *testing.T) {
 	// Setup
 	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
 	parentID := uuid.New()
-	parentIDStr := parentID.String()
 
 	// Create test children
 	child1 := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parentID)
@@ -2254,16 +3298,15 @@ func TestQueryResolver_ChildrenByParent(t *testing.T) {
 		return true, nil
 	}
 
-	mockFamilyService.ListChildrenByParentIDFunc = func(ctx context.Context, pID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
-		// Verify parent ID and options
-		assert.Equal(t, parentID, pID)
+	mockFamilyService.ListChildrenFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
+		// Verify options
 		assert.Equal(t, 0, options.Pagination.Page)
 		assert.Equal(t, 10, options.Pagination.PageSize)
 		return children, pagedResult, nil
 	}
 
 	// Execute
-	result, err := resolver.Query().ChildrenByParent(ctx, parentIDStr, nil, nil, nil)
+	result, err := resolver.Query().Children(ctx, nil, nil, nil)
 
 	// Assert
 	require.NoError(t, err)
@@ -2274,12 +3317,10 @@ func TestQueryResolver_ChildrenByParent(t *testing.T) {
 	assert.Equal(t, child2, result.Edges[1].Node)
 }
 
-func TestQueryResolver_ChildrenByParent_AuthError(t *testing.T) {
+func TestQueryResolver_Children_AuthError(t *testing.T) {
 	// Setup
 	resolver, _, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
-	parentID := uuid.New()
-	parentIDStr := parentID.String()
 
 	// Configure mocks
 	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
@@ -2287,7 +3328,7 @@ func TestQueryResolver_ChildrenByParent_AuthError(t *testing.T) {
 	}
 
 	// Execute
-	result, err := resolver.Query().ChildrenByParent(ctx, parentIDStr, nil, nil, nil)
+	result, err := resolver.Query().Children(ctx, nil, nil, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -2295,12 +3336,10 @@ func TestQueryResolver_ChildrenByParent_AuthError(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to check authorization")
 }
 
-func TestQueryResolver_ChildrenByParent_Unauthorized(t *testing.T) {
+func TestQueryResolver_Children_Unauthorized(t *testing.T) {
 	// Setup
 	resolver, _, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
-	parentID := uuid.New()
-	parentIDStr := parentID.String()
 
 	// Configure mocks
 	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
@@ -2308,7 +3347,7 @@ func TestQueryResolver_ChildrenByParent_Unauthorized(t *testing.T) {
 	}
 
 	// Execute
-	result, err := resolver.Query().ChildrenByParent(ctx, parentIDStr, nil, nil, nil)
+	result, err := resolver.Query().Children(ctx, nil, nil, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -2316,31 +3355,11 @@ func TestQueryResolver_ChildrenByParent_Unauthorized(t *testing.T) {
 	assert.Contains(t, err.Error(), "not authorized")
 }
 
-func TestQueryResolver_ChildrenByParent_InvalidParentID(t *testing.T) {
-	// Setup
-	resolver, _, mockAuthService := setupResolverTest(t)
-	ctx := context.Background()
-
-	// Configure mocks
-	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
-		return true, nil
-	}
-
-	// Execute
-	result, err := resolver.Query().ChildrenByParent(ctx, "invalid-uuid", nil, nil, nil)
-
-	// Assert
-	require.Error(t, err)
-	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "invalid parent ID")
-}
-
-func TestQueryResolver_ChildrenByParent_WithFilter(t *testing.T) {
+func TestQueryResolver_Children_WithFilter(t *testing.T) {
 	// Setup
 	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
 	parentID := uuid.New()
-	parentIDStr := parentID.String()
 
 	// Create test children
 	child1 := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parentID)
@@ -2371,9 +3390,8 @@ func TestQueryResolver_ChildrenByParent_WithFilter(t *testing.T) {
 		return true, nil
 	}
 
-	mockFamilyService.ListChildrenByParentIDFunc = func(ctx context.Context, pID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
-		// Verify parent ID and filter options
-		assert.Equal(t, parentID, pID)
+	mockFamilyService.ListChildrenFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
+		// Verify filter options
 		assert.Equal(t, firstName, options.Filter.FirstName)
 		assert.Equal(t, lastName, options.Filter.LastName)
 		assert.Equal(t, minAge, options.Filter.MinAge)
@@ -2382,7 +3400,7 @@ func TestQueryResolver_ChildrenByParent_WithFilter(t *testing.T) {
 	}
 
 	// Execute
-	result, err := resolver.Query().ChildrenByParent(ctx, parentIDStr, filter, nil, nil)
+	result, err := resolver.Query().Children(ctx, filter, nil, nil)
 
 	// Assert
 	require.NoError(t, err)
@@ -2392,12 +3410,11 @@ func TestQueryResolver_ChildrenByParent_WithFilter(t *testing.T) {
 	assert.Equal(t, child1, result.Edges[0].Node)
 }
 
-func TestQueryResolver_ChildrenByParent_WithPagination(t *testing.T) {
+func TestQueryResolver_Children_WithPagination(t *testing.T) {
 	// Setup
 	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
 	parentID := uuid.New()
-	parentIDStr := parentID.String()
 
 	// Create test children
 	child1 := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parentID)
@@ -2424,16 +3441,15 @@ func TestQueryResolver_ChildrenByParent_WithPagination(t *testing.T) {
 		return true, nil
 	}
 
-	mockFamilyService.ListChildrenByParentIDFunc = func(ctx context.Context, pID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
-		// Verify parent ID and pagination options
-		assert.Equal(t, parentID, pID)
+	mockFamilyService.ListChildrenFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
+		// Verify pagination options
 		assert.Equal(t, page, options.Pagination.Page)
 		assert.Equal(t, pageSize, options.Pagination.PageSize)
 		return children, pagedResult, nil
 	}
 
 	// Execute
-	result, err := resolver.Query().ChildrenByParent(ctx, parentIDStr, nil, pagination, nil)
+	result, err := resolver.Query().Children(ctx, nil, pagination, nil)
 
 	// Assert
 	require.NoError(t, err)
@@ -2445,12 +3461,11 @@ func TestQueryResolver_ChildrenByParent_WithPagination(t *testing.T) {
 	assert.True(t, result.PageInfo.HasPreviousPage)
 }
 
-func TestQueryResolver_ChildrenByParent_WithSort(t *testing.T) {
+func TestQueryResolver_Children_WithSort(t *testing.T) {
 	// Setup
 	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
 	parentID := uuid.New()
-	parentIDStr := parentID.String()
 
 	// Create test children
 	child1 := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parentID)
@@ -2477,16 +3492,15 @@ func TestQueryResolver_ChildrenByParent_WithSort(t *testing.T) {
 		return true, nil
 	}
 
-	mockFamilyService.ListChildrenByParentIDFunc = func(ctx context.Context, pID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
-		// Verify parent ID and sort options
-		assert.Equal(t, parentID, pID)
+	mockFamilyService.ListChildrenFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
+		// Verify sort options
 		assert.Equal(t, field, options.Sort.Field)
 		assert.Equal(t, "asc", options.Sort.Direction)
 		return children, pagedResult, nil
 	}
 
 	// Execute
-	result, err := resolver.Query().ChildrenByParent(ctx, parentIDStr, nil, nil, sort)
+	result, err := resolver.Query().Children(ctx, nil, nil, sort)
 
 	// Assert
 	require.NoError(t, err)
@@ -2496,174 +3510,1423 @@ func TestQueryResolver_ChildrenByParent_WithSort(t *testing.T) {
 	assert.Equal(t, child1, result.Edges[0].Node)
 }
 
-func TestQueryResolver_ChildrenByParent_ListError(t *testing.T) {
+func TestQueryResolver_Children_ListError(t *testing.T) {
 	// Setup
 	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
-	parentID := uuid.New()
-	parentIDStr := parentID.String()
 
 	// Configure mocks
 	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
 		return true, nil
 	}
 
-	mockFamilyService.ListChildrenByParentIDFunc = func(ctx context.Context, pID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
+	mockFamilyService.ListChildrenFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
 		return nil, nil, errors.New("list error")
 	}
 
 	// Execute
-	result, err := resolver.Query().ChildrenByParent(ctx, parentIDStr, nil, nil, nil)
+	result, err := resolver.Query().Children(ctx, nil, nil, nil)
 
 	// Assert
 	require.Error(t, err)
 	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "failed to list children by parent")
+	assert.Contains(t, err.Error(), "failed to list children")
 }
 
-func TestParentConnectionResolver_Edges(t *testing.T) {
+func TestQueryResolver_DeletedChildren(t *testing.T) {
 	// Setup
-	resolver, _, _ := setupResolverTest(t)
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
+	child := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), uuid.New())
+	child.MarkAsDeleted()
 
-	// Create test parent connection
-	parent1 := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
-	parent2 := domain.NewParent("Jane", "Smith", "jane.smith@example.com", time.Now().AddDate(-25, 0, 0))
-
-	edges := []graphql.ParentEdge{
-		{Node: parent1, Cursor: "cursor1"},
-		{Node: parent2, Cursor: "cursor2"},
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		assert.Equal(t, "child:list-deleted", permission)
+		return true, nil
 	}
 
-	connection := &graphql.ParentConnection{
-		Edges: edges,
-		PageInfo: &graphql.PageInfo{
-			HasNextPage:     false,
-			HasPreviousPage: false,
-		},
-		TotalCount: 2,
+	mockFamilyService.ListChildrenFunc = func(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
+		assert.True(t, options.Filter.Deleted)
+		return []*domain.Child{child}, &ports.PagedResult{PageSize: 10, TotalCount: 1}, nil
 	}
 
 	// Execute
-	result, err := resolver.ParentConnection().Edges(ctx, connection)
+	result, err := resolver.Query().DeletedChildren(ctx, nil, nil, nil)
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, edges, result)
+	assert.Equal(t, 1, result.TotalCount)
+	require.Len(t, result.Edges, 1)
+	assert.Equal(t, child, result.Edges[0].Node)
 }
 
-func TestParentConnectionResolver_PageInfo(t *testing.T) {
+func TestQueryResolver_ChildrenByParent(t *testing.T) {
 	// Setup
-	resolver, _, _ := setupResolverTest(t)
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
+	parentID := uuid.New()
+	parentIDStr := parentID.String()
 
-	// Create test parent connection
-	pageInfo := &graphql.PageInfo{
+	// Create test children
+	child1 := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parentID)
+	child2 := domain.NewChild("Jack", "Doe", time.Now().AddDate(-3, 0, 0), parentID)
+	children := []*domain.Child{child1, child2}
+
+	// Create paged result
+	pagedResult := &ports.PagedResult{
+		Page:       0,
+		PageSize:   10,
+		TotalCount: 2,
+		HasNext:    false,
+	}
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		assert.Equal(t, "child:list", permission)
+		return true, nil
+	}
+
+	mockFamilyService.ListChildrenByParentIDFunc = func(ctx context.Context, pID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
+		// Verify parent ID and options
+		assert.Equal(t, parentID, pID)
+		assert.Equal(t, 0, options.Pagination.Page)
+		assert.Equal(t, 10, options.Pagination.PageSize)
+		return children, pagedResult, nil
+	}
+
+	// Execute
+	result, err := resolver.Query().ChildrenByParent(ctx, parentIDStr, nil, nil, nil, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 2, result.TotalCount)
+	assert.Len(t, result.Edges, 2)
+	assert.Equal(t, child1, result.Edges[0].Node)
+	assert.Equal(t, child2, result.Edges[1].Node)
+}
+
+func TestQueryResolver_ChildrenByParent_AuthError(t *testing.T) {
+	// Setup
+	resolver, _, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	parentID := uuid.New()
+	parentIDStr := parentID.String()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return false, errors.New("authorization error")
+	}
+
+	// Execute
+	result, err := resolver.Query().ChildrenByParent(ctx, parentIDStr, nil, nil, nil, nil)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to check authorization")
+}
+
+func TestQueryResolver_ChildrenByParent_Unauthorized(t *testing.T) {
+	// Setup
+	resolver, _, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	parentID := uuid.New()
+	parentIDStr := parentID.String()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return false, nil
+	}
+
+	// Execute
+	result, err := resolver.Query().ChildrenByParent(ctx, parentIDStr, nil, nil, nil, nil)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "not authorized")
+}
+
+func TestQueryResolver_ChildrenByParent_InvalidParentID(t *testing.T) {
+	// Setup
+	resolver, _, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	// Execute
+	result, err := resolver.Query().ChildrenByParent(ctx, "invalid-uuid", nil, nil, nil, nil)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "invalid parent ID")
+}
+
+func TestQueryResolver_ChildrenByParent_WithFilter(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	parentID := uuid.New()
+	parentIDStr := parentID.String()
+
+	// Create test children
+	child1 := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parentID)
+	children := []*domain.Child{child1}
+
+	// Create paged result
+	pagedResult := &ports.PagedResult{
+		Page:       0,
+		PageSize:   10,
+		TotalCount: 1,
+		HasNext:    false,
+	}
+
+	// Create filter
+	firstName := "Jane"
+	lastName := "Doe"
+	minAge := 3
+	maxAge := 10
+	filter := &graphql.ChildFilter{
+		FirstName: &firstName,
+		LastName:  &lastName,
+		MinAge:    &minAge,
+		MaxAge:    &maxAge,
+	}
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	mockFamilyService.ListChildrenByParentIDFunc = func(ctx context.Context, pID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
+		// Verify parent ID and filter options
+		assert.Equal(t, parentID, pID)
+		assert.Equal(t, firstName, options.Filter.FirstName)
+		assert.Equal(t, lastName, options.Filter.LastName)
+		assert.Equal(t, minAge, options.Filter.MinAge)
+		assert.Equal(t, maxAge, options.Filter.MaxAge)
+		return children, pagedResult, nil
+	}
+
+	// Execute
+	result, err := resolver.Query().ChildrenByParent(ctx, parentIDStr, filter, nil, nil, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 1, result.TotalCount)
+	assert.Len(t, result.Edges, 1)
+	assert.Equal(t, child1, result.Edges[0].Node)
+}
+
+func TestQueryResolver_ChildrenByParent_WithPagination(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	parentID := uuid.New()
+	parentIDStr := parentID.String()
+
+	// Create test children
+	child1 := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parentID)
+	children := []*domain.Child{child1}
+
+	// Create paged result
+	pagedResult := &ports.PagedResult{
+		Page:       2,
+		PageSize:   5,
+		TotalCount: 15,
+		HasNext:    true,
+	}
+
+	// Create pagination
+	page := 2
+	pageSize := 5
+	pagination := &graphql.PaginationInput{
+		Page:     &page,
+		PageSize: &pageSize,
+	}
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	mockFamilyService.ListChildrenByParentIDFunc = func(ctx context.Context, pID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
+		// Verify parent ID and pagination options
+		assert.Equal(t, parentID, pID)
+		assert.Equal(t, page, options.Pagination.Page)
+		assert.Equal(t, pageSize, options.Pagination.PageSize)
+		return children, pagedResult, nil
+	}
+
+	// Execute
+	result, err := resolver.Query().ChildrenByParent(ctx, parentIDStr, nil, pagination, nil, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 15, result.TotalCount)
+	assert.Len(t, result.Edges, 1)
+	assert.Equal(t, child1, result.Edges[0].Node)
+	assert.True(t, result.PageInfo.HasNextPage)
+	assert.True(t, result.PageInfo.HasPreviousPage)
+}
+
+func TestQueryResolver_ChildrenByParent_WithSort(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	parentID := uuid.New()
+	parentIDStr := parentID.String()
+
+	// Create test children
+	child1 := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parentID)
+	children := []*domain.Child{child1}
+
+	// Create paged result
+	pagedResult := &ports.PagedResult{
+		Page:       0,
+		PageSize:   10,
+		TotalCount: 1,
+		HasNext:    false,
+	}
+
+	// Create sort
+	field := "firstName"
+	direction := graphql.SortDirectionAsc
+	sort := &graphql.SortInput{
+		Field:     &field,
+		Direction: &direction,
+	}
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	mockFamilyService.ListChildrenByParentIDFunc = func(ctx context.Context, pID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
+		// Verify parent ID and sort options
+		assert.Equal(t, parentID, pID)
+		assert.Equal(t, field, options.Sort.Field)
+		assert.Equal(t, "asc", options.Sort.Direction)
+		return children, pagedResult, nil
+	}
+
+	// Execute
+	result, err := resolver.Query().ChildrenByParent(ctx, parentIDStr, nil, nil, sort, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 1, result.TotalCount)
+	assert.Len(t, result.Edges, 1)
+	assert.Equal(t, child1, result.Edges[0].Node)
+}
+
+func TestQueryResolver_ChildrenByParent_ListError(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	parentID := uuid.New()
+	parentIDStr := parentID.String()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	mockFamilyService.ListChildrenByParentIDFunc = func(ctx context.Context, pID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
+		return nil, nil, errors.New("list error")
+	}
+
+	// Execute
+	result, err := resolver.Query().ChildrenByParent(ctx, parentIDStr, nil, nil, nil, nil)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to list children by parent")
+}
+
+func TestParentConnectionResolver_Edges(t *testing.T) {
+	// Setup
+	resolver, _, _ := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Create test parent connection
+	parent1 := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	parent2 := domain.NewParent("Jane", "Smith", "jane.smith@example.com", time.Now().AddDate(-25, 0, 0))
+
+	edges := []graphql.ParentEdge{
+		{Node: parent1, Cursor: "cursor1"},
+		{Node: parent2, Cursor: "cursor2"},
+	}
+
+	connection := &graphql.ParentConnection{
+		Edges: edges,
+		PageInfo: &graphql.PageInfo{
+			HasNextPage:     false,
+			HasPreviousPage: false,
+		},
+		TotalCount: 2,
+	}
+
+	// Execute
+	result, err := resolver.ParentConnection().Edges(ctx, connection)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, edges, result)
+}
+
+func TestParentConnectionResolver_PageInfo(t *testing.T) {
+	// Setup
+	resolver, _, _ := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Create test parent connection
+	pageInfo := &graphql.PageInfo{
+		HasNextPage:     true,
+		HasPreviousPage: false,
+	}
+
+	connection := &graphql.ParentConnection{
+		PageInfo:   pageInfo,
+		TotalCount: 2,
+	}
+
+	// Execute
+	result, err := resolver.ParentConnection().PageInfo(ctx, connection)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, pageInfo, result)
+}
+
+func TestParentConnectionResolver_TotalCount(t *testing.T) {
+	// Setup
+	resolver, _, _ := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Create test parent connection
+	connection := &graphql.ParentConnection{
+		TotalCount: 42,
+	}
+
+	// Execute
+	result, err := resolver.ParentConnection().TotalCount(ctx, connection)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 42, result)
+}
+
+func TestChildConnectionResolver_Edges(t *testing.T) {
+	// Setup
+	resolver, _, _ := setupResolverTest(t)
+	ctx := context.Background()
+	parentID := uuid.New()
+
+	// Create test child connection
+	child1 := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parentID)
+	child2 := domain.NewChild("Jack", "Doe", time.Now().AddDate(-3, 0, 0), parentID)
+
+	edges := []graphql.ChildEdge{
+		{Node: child1, Cursor: "cursor1"},
+		{Node: child2, Cursor: "cursor2"},
+	}
+
+	connection := &graphql.ChildConnection{
+		Edges: edges,
+		PageInfo: &graphql.PageInfo{
+			HasNextPage:     false,
+			HasPreviousPage: false,
+		},
+		TotalCount: 2,
+	}
+
+	// Execute
+	result, err := resolver.ChildConnection().Edges(ctx, connection)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, edges, result)
+}
+
+func TestChildConnectionResolver_PageInfo(t *testing.T) {
+	// Setup
+	resolver, _, _ := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Create test child connection
+	pageInfo := &graphql.PageInfo{
 		HasNextPage:     true,
 		HasPreviousPage: false,
 	}
 
-	connection := &graphql.ParentConnection{
-		PageInfo:   pageInfo,
-		TotalCount: 2,
+	connection := &graphql.ChildConnection{
+		PageInfo:   pageInfo,
+		TotalCount: 2,
+	}
+
+	// Execute
+	result, err := resolver.ChildConnection().PageInfo(ctx, connection)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, pageInfo, result)
+}
+
+func TestChildConnectionResolver_TotalCount(t *testing.T) {
+	// Setup
+	resolver, _, _ := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Create test child connection
+	connection := &graphql.ChildConnection{
+		TotalCount: 42,
+	}
+
+	// Execute
+	result, err := resolver.ChildConnection().TotalCount(ctx, connection)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 42, result)
+}
+
+func setupSubscriptionTest(t *testing.T) (*graphql.Resolver, *mocks.MockAuthorizationService, chan domain.Event) {
+	// Create mocks
+	mockFamilyService := mocks.NewMockFamilyService()
+	mockAuthService := mocks.NewMockAuthorizationService()
+	mockBroker := mocks.NewMockEventBroker()
+	source := make(chan domain.Event, 10)
+	mockBroker.SubscribeFunc = func(ctx context.Context) (<-chan domain.Event, error) {
+		return source, nil
+	}
+
+	// Create resolver
+	resolver := graphql.NewResolver(mockFamilyService, mockAuthService, mockBroker, zaptest.NewLogger(t))
+
+	return resolver, mockAuthService, source
+}
+
+func TestSubscriptionResolver_ParentChanged(t *testing.T) {
+	// Setup
+	resolver, mockAuthService, source := setupSubscriptionTest(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	child := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parent.ID)
+
+	// Execute
+	events, err := resolver.Subscription().ParentChanged(ctx)
+	require.NoError(t, err)
+
+	source <- domain.NewChildEvent(domain.EventChildCreated, child)
+	source <- domain.NewParentEvent(domain.EventParentUpdated, parent)
+	close(source)
+
+	// Assert
+	var received []*domain.Event
+	for event := range events {
+		received = append(received, event)
+	}
+	require.Len(t, received, 1)
+	assert.Equal(t, domain.EventParentUpdated, received[0].Type)
+	assert.Equal(t, parent.ID, received[0].ParentID)
+	assert.Contains(t, mockAuthService.IsAuthorizedCalls, "parent:read")
+}
+
+func TestSubscriptionResolver_FamilyChanged(t *testing.T) {
+	// Setup
+	resolver, mockAuthService, source := setupSubscriptionTest(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	parentID := uuid.New()
+	childID := uuid.New()
+
+	// Execute
+	events, err := resolver.Subscription().FamilyChanged(ctx, parentID.String())
+	require.NoError(t, err)
+
+	transfer := domain.NewEvent(domain.EventChildTransferred, uuid.New(), childID)
+	transfer.PreviousParentID = &parentID
+
+	source <- domain.NewEvent(domain.EventParentUpdated, uuid.New(), uuid.Nil)
+	source <- domain.NewEvent(domain.EventChildAddedToParent, parentID, childID)
+	source <- domain.NewEvent(domain.EventParentDeleted, parentID, uuid.Nil)
+	source <- transfer
+	close(source)
+
+	// Assert
+	var received []*domain.Event
+	for event := range events {
+		received = append(received, event)
+	}
+	require.Len(t, received, 3)
+	assert.Equal(t, domain.EventChildAddedToParent, received[0].Type)
+	assert.Equal(t, childID, received[0].ChildID)
+	assert.Equal(t, domain.EventParentDeleted, received[1].Type)
+	assert.Equal(t, domain.EventChildTransferred, received[2].Type)
+	assert.Equal(t, []string{"parent:read", "child:read"}, mockAuthService.IsAuthorizedCalls)
+}
+
+func TestSubscriptionResolver_ChildChanged_OwnFamily(t *testing.T) {
+	// Setup: a guardian who may only read the children of their own family
+	mockFamilyService := mocks.NewMockFamilyService()
+	mockAuthService := mocks.NewMockAuthorizationService()
+	mockBroker := mocks.NewMockEventBroker()
+	source := make(chan domain.Event, 10)
+	mockBroker.SubscribeFunc = func(ctx context.Context) (<-chan domain.Event, error) {
+		return source, nil
+	}
+	resolver := graphql.NewResolver(mockFamilyService, mockAuthService, mockBroker, zaptest.NewLogger(t))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	wardID := uuid.New()
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return permission == "child:read:own", nil
+	}
+	mockFamilyService.IsEventInFamilyFunc = func(ctx context.Context, event domain.Event) (bool, error) {
+		assert.Equal(t, ports.AccessScope{Restricted: true, UserID: "test-user-id"}, ports.AccessScopeFromContext(ctx))
+		return event.ChildID == wardID, nil
+	}
+
+	// Execute
+	events, err := resolver.Subscription().ChildChanged(ctx)
+	require.NoError(t, err)
+
+	source <- domain.NewEvent(domain.EventChildUpdated, uuid.New(), uuid.New())
+	source <- domain.NewEvent(domain.EventChildUpdated, uuid.New(), wardID)
+	close(source)
+
+	// Assert
+	var received []*domain.Event
+	for event := range events {
+		received = append(received, event)
+	}
+	require.Len(t, received, 1)
+	assert.Equal(t, wardID, received[0].ChildID)
+}
+
+func TestSubscriptionResolver_ParentChanged_OtherTenant(t *testing.T) {
+	// Setup
+	resolver, _, source := setupSubscriptionTest(t)
+	ctx, cancel := context.WithCancel(ports.WithTenantID(context.Background(), "tenant-1"))
+	defer cancel()
+
+	ownParent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	otherParent := domain.NewParent("Jane", "Smith", "jane.smith@example.com", time.Now().AddDate(-25, 0, 0))
+
+	// Execute
+	events, err := resolver.Subscription().ParentChanged(ctx)
+	require.NoError(t, err)
+
+	otherEvent := domain.NewParentEvent(domain.EventParentUpdated, otherParent)
+	otherEvent.TenantID = "tenant-2"
+	ownEvent := domain.NewParentEvent(domain.EventParentUpdated, ownParent)
+	ownEvent.TenantID = "tenant-1"
+	source <- otherEvent
+	source <- ownEvent
+	close(source)
+
+	// Assert
+	var received []*domain.Event
+	for event := range events {
+		received = append(received, event)
+	}
+	require.Len(t, received, 1)
+	assert.Equal(t, ownParent.ID, received[0].ParentID)
+}
+
+func TestSubscriptionResolver_FamilyChanged_InvalidID(t *testing.T) {
+	// Setup
+	resolver, _, _ := setupSubscriptionTest(t)
+
+	// Execute
+	events, err := resolver.Subscription().FamilyChanged(context.Background(), "invalid-uuid")
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, events)
+	assert.Contains(t, err.Error(), "invalid parent ID")
+}
+
+func TestSubscriptionResolver_ChildChanged_Unauthorized(t *testing.T) {
+	// Setup
+	resolver, mockAuthService, _ := setupSubscriptionTest(t)
+	mockAuthService.DefaultIsAuthorized = false
+
+	// Execute
+	events, err := resolver.Subscription().ChildChanged(context.Background())
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, events)
+	assert.Contains(t, err.Error(), "not authorized")
+}
+
+func TestSubscriptionResolver_Unavailable(t *testing.T) {
+	// Setup
+	resolver := graphql.NewResolver(mocks.NewMockFamilyService(), mocks.NewMockAuthorizationService(), nil, zaptest.NewLogger(t))
+
+	// Execute
+	events, err := resolver.Subscription().ParentChanged(context.Background())
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, events)
+	assert.Contains(t, err.Error(), "subscriptions are not available")
+}
+
+func TestChangeEventResolver_Fields(t *testing.T) {
+	// Setup
+	resolver, _, _ := setupResolverTest(t)
+	ctx := context.Background()
+	parentID := uuid.New()
+	childID := uuid.New()
+	parentEvent := domain.NewEvent(domain.EventParentCreated, parentID, uuid.Nil)
+	childEvent := domain.NewEvent(domain.EventChildUpdated, parentID, childID)
+
+	// Execute
+	id, err := resolver.ChangeEvent().ID(ctx, &parentEvent)
+	require.NoError(t, err)
+	changeType, err := resolver.ChangeEvent().Type(ctx, &parentEvent)
+	require.NoError(t, err)
+	eventParentID, err := resolver.ChangeEvent().ParentID(ctx, &childEvent)
+	require.NoError(t, err)
+	noChildID, err := resolver.ChangeEvent().ChildID(ctx, &parentEvent)
+	require.NoError(t, err)
+	eventChildID, err := resolver.ChangeEvent().ChildID(ctx, &childEvent)
+	require.NoError(t, err)
+	occurredAt, err := resolver.ChangeEvent().OccurredAt(ctx, &parentEvent)
+	require.NoError(t, err)
+	noPreviousParentID, err := resolver.ChangeEvent().PreviousParentID(ctx, &childEvent)
+	require.NoError(t, err)
+	noReason, err := resolver.ChangeEvent().Reason(ctx, &childEvent)
+	require.NoError(t, err)
+
+	transfer := domain.NewEvent(domain.EventChildTransferred, parentID, childID)
+	transfer.PreviousParentID = &childEvent.ParentID
+	transfer.Reason = "Custody order"
+	previousParentID, err := resolver.ChangeEvent().PreviousParentID(ctx, &transfer)
+	require.NoError(t, err)
+	reason, err := resolver.ChangeEvent().Reason(ctx, &transfer)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, parentEvent.ID.String(), id)
+	assert.Equal(t, graphql.ChangeTypeParentCreated, changeType)
+	assert.Equal(t, parentID.String(), eventParentID)
+	assert.Nil(t, noChildID)
+	require.NotNil(t, eventChildID)
+	assert.Equal(t, childID.String(), *eventChildID)
+	assert.Equal(t, parentEvent.OccurredAt.Format(time.RFC3339), occurredAt)
+	assert.Nil(t, noPreviousParentID)
+	assert.Nil(t, noReason)
+	require.NotNil(t, previousParentID)
+	assert.Equal(t, parentID.String(), *previousParentID)
+	require.NotNil(t, reason)
+	assert.Equal(t, "Custody order", *reason)
+}
+
+func setupWebhookResolverTest(t *testing.T) (*graphql.Resolver, *mocks.MockWebhookService, *mocks.MockAuthorizationService) {
+	resolver, _, mockAuthService := setupResolverTest(t)
+	mockWebhookService := mocks.NewMockWebhookService()
+
+	return resolver.WithWebhooks(mockWebhookService), mockWebhookService, mockAuthService
+}
+
+func TestMutationResolver_CreateWebhookSubscription(t *testing.T) {
+	// Setup
+	resolver, mockWebhookService, mockAuthService := setupWebhookResolverTest(t)
+	ctx := context.Background()
+
+	// Configure mocks
+	var permissions []string
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		permissions = append(permissions, permission)
+		return true, nil
+	}
+
+	var gotEventTypes []domain.EventType
+	mockWebhookService.CreateWebhookSubscriptionFunc = func(ctx context.Context, url string, eventTypes []domain.EventType, secret string) (*domain.WebhookSubscription, error) {
+		gotEventTypes = eventTypes
+		return domain.NewWebhookSubscription(url, eventTypes, secret), nil
+	}
+
+	// Execute
+	result, err := resolver.Mutation().CreateWebhookSubscription(ctx, graphql.CreateWebhookSubscriptionInput{
+		URL:        "https://example.com/hooks",
+		EventTypes: []graphql.ChangeType{graphql.ChangeTypeChildCreated, graphql.ChangeTypeChildDeleted},
+		Secret:     "0123456789abcdef",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/hooks", result.URL)
+	assert.Equal(t, []domain.EventType{domain.EventChildCreated, domain.EventChildDeleted}, gotEventTypes)
+	assert.Equal(t, []string{"webhook:create"}, permissions)
+
+	eventTypes, err := resolver.WebhookSubscription().EventTypes(ctx, result)
+	require.NoError(t, err)
+	assert.Equal(t, []graphql.ChangeType{graphql.ChangeTypeChildCreated, graphql.ChangeTypeChildDeleted}, eventTypes)
+}
+
+func TestMutationResolver_CreateWebhookSubscription_Unauthorized(t *testing.T) {
+	// Setup
+	resolver, mockWebhookService, mockAuthService := setupWebhookResolverTest(t)
+	ctx := context.Background()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return false, nil
+	}
+
+	mockWebhookService.CreateWebhookSubscriptionFunc = func(ctx context.Context, url string, eventTypes []domain.EventType, secret string) (*domain.WebhookSubscription, error) {
+		t.Fatal("CreateWebhookSubscription should not be called")
+		return nil, nil
+	}
+
+	// Execute
+	result, err := resolver.Mutation().CreateWebhookSubscription(ctx, graphql.CreateWebhookSubscriptionInput{
+		URL:        "https://example.com/hooks",
+		EventTypes: []graphql.ChangeType{graphql.ChangeTypeChildCreated},
+		Secret:     "0123456789abcdef",
+	})
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "not authorized")
+}
+
+func TestMutationResolver_CreateWebhookSubscription_NotConfigured(t *testing.T) {
+	// Setup; the resolver has no webhook service
+	resolver, _, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	// Execute
+	result, err := resolver.Mutation().CreateWebhookSubscription(ctx, graphql.CreateWebhookSubscriptionInput{
+		URL:        "https://example.com/hooks",
+		EventTypes: []graphql.ChangeType{graphql.ChangeTypeChildCreated},
+		Secret:     "0123456789abcdef",
+	})
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "webhooks are not configured")
+}
+
+func TestMutationResolver_DeleteWebhookSubscription(t *testing.T) {
+	// Setup
+	resolver, mockWebhookService, mockAuthService := setupWebhookResolverTest(t)
+	ctx := context.Background()
+	subscriptionID := uuid.New()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return permission == "webhook:delete", nil
+	}
+
+	mockWebhookService.DeleteWebhookSubscriptionFunc = func(ctx context.Context, id uuid.UUID) error {
+		if id != subscriptionID {
+			return domain.NewNotFoundError("WebhookSubscription", id.String())
+		}
+		return nil
+	}
+
+	// Execute
+	result, err := resolver.Mutation().DeleteWebhookSubscription(ctx, subscriptionID.String())
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, result)
+
+	// An unknown subscription is not found, and an invalid ID is rejected
+	_, err = resolver.Mutation().DeleteWebhookSubscription(ctx, uuid.New().String())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = resolver.Mutation().DeleteWebhookSubscription(ctx, "invalid-uuid")
+	assert.ErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestQueryResolver_WebhookSubscriptions(t *testing.T) {
+	// Setup
+	resolver, mockWebhookService, mockAuthService := setupWebhookResolverTest(t)
+	ctx := context.Background()
+	subscription := domain.NewWebhookSubscription("https://example.com/hooks", []domain.EventType{domain.EventChildCreated}, "0123456789abcdef")
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return permission == "webhook:list", nil
+	}
+
+	mockWebhookService.GetWebhookSubscriptionsFunc = func(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+		return []*domain.WebhookSubscription{subscription}, nil
+	}
+
+	// Execute
+	result, err := resolver.Query().WebhookSubscriptions(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.WebhookSubscription{subscription}, result)
+}
+
+func TestQueryResolver_WebhookDeliveries(t *testing.T) {
+	// Setup
+	resolver, mockWebhookService, mockAuthService := setupWebhookResolverTest(t)
+	ctx := context.Background()
+	subscription := domain.NewWebhookSubscription("https://example.com/hooks", []domain.EventType{domain.EventChildCreated}, "0123456789abcdef")
+	delivery := domain.NewWebhookDelivery(subscription, domain.NewEvent(domain.EventChildCreated, uuid.New(), uuid.New()), `{}`)
+	delivery.MarkFailed(503, "unavailable", time.Now())
+	delivery.MarkDeadLetter("")
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return permission == "webhook:list-deliveries", nil
+	}
+
+	var gotFilter ports.WebhookDeliveryFilter
+	mockWebhookService.GetWebhookDeliveriesFunc = func(ctx context.Context, filter ports.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+		gotFilter = filter
+		return []*domain.WebhookDelivery{delivery}, nil
+	}
+
+	// Execute
+	subscriptionID := subscription.ID.String()
+	status := graphql.WebhookDeliveryStatusDeadLetter
+	limit := 20
+	result, err := resolver.Query().WebhookDeliveries(ctx, &subscriptionID, &status, &limit)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.WebhookDelivery{delivery}, result)
+	require.NotNil(t, gotFilter.SubscriptionID)
+	assert.Equal(t, subscription.ID, *gotFilter.SubscriptionID)
+	require.NotNil(t, gotFilter.Status)
+	assert.Equal(t, domain.DeliveryDeadLetter, *gotFilter.Status)
+	assert.Equal(t, 20, gotFilter.Limit)
+
+	// The fields of a dead letter
+	deliveryStatus, err := resolver.WebhookDelivery().Status(ctx, delivery)
+	require.NoError(t, err)
+	assert.Equal(t, graphql.WebhookDeliveryStatusDeadLetter, deliveryStatus)
+	nextAttemptAt, err := resolver.WebhookDelivery().NextAttemptAt(ctx, delivery)
+	require.NoError(t, err)
+	assert.Nil(t, nextAttemptAt)
+	lastError, err := resolver.WebhookDelivery().LastError(ctx, delivery)
+	require.NoError(t, err)
+	require.NotNil(t, lastError)
+	assert.Equal(t, "unavailable", *lastError)
+	responseStatus, err := resolver.WebhookDelivery().ResponseStatus(ctx, delivery)
+	require.NoError(t, err)
+	require.NotNil(t, responseStatus)
+	assert.Equal(t, 503, *responseStatus)
+	deliveredAt, err := resolver.WebhookDelivery().DeliveredAt(ctx, delivery)
+	require.NoError(t, err)
+	assert.Nil(t, deliveredAt)
+}
+
+func TestQueryResolver_WebhookDeliveries_InvalidSubscriptionID(t *testing.T) {
+	// Setup
+	resolver, mockWebhookService, mockAuthService := setupWebhookResolverTest(t)
+	ctx := context.Background()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	mockWebhookService.GetWebhookDeliveriesFunc = func(ctx context.Context, filter ports.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+		t.Fatal("GetWebhookDeliveries should not be called")
+		return nil, nil
 	}
 
 	// Execute
-	result, err := resolver.ParentConnection().PageInfo(ctx, connection)
+	subscriptionID := "invalid-uuid"
+	result, err := resolver.Query().WebhookDeliveries(ctx, &subscriptionID, nil, nil)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrValidation)
+}
+
+func setupAuditResolverTest(t *testing.T) (*graphql.Resolver, *mocks.MockAuditService, *mocks.MockAuthorizationService) {
+	resolver, _, mockAuthService := setupResolverTest(t)
+	mockAuditService := mocks.NewMockAuditService()
+
+	return resolver.WithAudit(mockAuditService), mockAuditService, mockAuthService
+}
+
+func TestQueryResolver_AuditLog(t *testing.T) {
+	// Setup
+	resolver, mockAuditService, mockAuthService := setupAuditResolverTest(t)
+	ctx := context.Background()
+	childID := uuid.New()
+	record := domain.NewAuditRecord("UpdateChild", domain.AuditEntityChild, childID,
+		domain.AuditSnapshot{"birthDate": "2015-01-02T00:00:00Z"}, domain.AuditSnapshot{"birthDate": "2015-02-01T00:00:00Z", "userId": "user-1"})
+	record.Actor = "staff-1"
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return permission == "audit:read", nil
+	}
+
+	var gotFrom, gotTo *time.Time
+	mockAuditService.GetAuditLogFunc = func(ctx context.Context, entityID uuid.UUID, from, to *time.Time) ([]*domain.AuditRecord, error) {
+		assert.Equal(t, childID, entityID)
+		gotFrom, gotTo = from, to
+		return []*domain.AuditRecord{record}, nil
+	}
+
+	// Execute
+	from := "2024-01-01T00:00:00Z"
+	result, err := resolver.Query().AuditLog(ctx, childID.String(), &from, nil)
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, pageInfo, result)
+	assert.Equal(t, []*domain.AuditRecord{record}, result)
+	require.NotNil(t, gotFrom)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), gotFrom.UTC())
+	assert.Nil(t, gotTo)
+
+	// The fields of the record
+	actor, err := resolver.AuditRecord().Actor(ctx, record)
+	require.NoError(t, err)
+	require.NotNil(t, actor)
+	assert.Equal(t, "staff-1", *actor)
+	traceID, err := resolver.AuditRecord().TraceID(ctx, record)
+	require.NoError(t, err)
+	assert.Nil(t, traceID)
+	entityID, err := resolver.AuditRecord().EntityID(ctx, record)
+	require.NoError(t, err)
+	assert.Equal(t, childID.String(), entityID)
+
+	// The fields of the changes; a field that was not set has no value before the change
+	require.Len(t, record.Changes, 2)
+	before, err := resolver.FieldChange().Before(ctx, &record.Changes[0])
+	require.NoError(t, err)
+	require.NotNil(t, before)
+	assert.Equal(t, "2015-01-02T00:00:00Z", *before)
+	before, err = resolver.FieldChange().Before(ctx, &record.Changes[1])
+	require.NoError(t, err)
+	assert.Nil(t, before)
+	after, err := resolver.FieldChange().After(ctx, &record.Changes[1])
+	require.NoError(t, err)
+	require.NotNil(t, after)
+	assert.Equal(t, "user-1", *after)
 }
 
-func TestParentConnectionResolver_TotalCount(t *testing.T) {
+func TestQueryResolver_AuditLog_Unauthorized(t *testing.T) {
 	// Setup
-	resolver, _, _ := setupResolverTest(t)
+	resolver, mockAuditService, mockAuthService := setupAuditResolverTest(t)
 	ctx := context.Background()
 
-	// Create test parent connection
-	connection := &graphql.ParentConnection{
-		TotalCount: 42,
+	// Configure mocks; the caller may read parents and children, but not the audit log
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return permission != "audit:read", nil
+	}
+
+	mockAuditService.GetAuditLogFunc = func(ctx context.Context, entityID uuid.UUID, from, to *time.Time) ([]*domain.AuditRecord, error) {
+		t.Fatal("GetAuditLog should not be called")
+		return nil, nil
 	}
 
 	// Execute
-	result, err := resolver.ParentConnection().TotalCount(ctx, connection)
+	result, err := resolver.Query().AuditLog(ctx, uuid.New().String(), nil, nil)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "not authorized")
+}
+
+func TestQueryResolver_AuditLog_InvalidArguments(t *testing.T) {
+	// Setup
+	resolver, mockAuditService, mockAuthService := setupAuditResolverTest(t)
+	ctx := context.Background()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	mockAuditService.GetAuditLogFunc = func(ctx context.Context, entityID uuid.UUID, from, to *time.Time) ([]*domain.AuditRecord, error) {
+		t.Fatal("GetAuditLog should not be called")
+		return nil, nil
+	}
+
+	// Execute and assert
+	_, err := resolver.Query().AuditLog(ctx, "invalid-uuid", nil, nil)
+	assert.ErrorIs(t, err, domain.ErrValidation)
+
+	to := "yesterday"
+	_, err = resolver.Query().AuditLog(ctx, uuid.New().String(), nil, &to)
+	assert.ErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestQueryResolver_AuditLog_NotConfigured(t *testing.T) {
+	// Setup; the resolver has no audit service
+	resolver, _, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	// Execute
+	result, err := resolver.Query().AuditLog(ctx, uuid.New().String(), nil, nil)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "the audit log is not configured")
+}
+
+func TestQueryResolver_Parent_AsOf(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	asOf := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testParent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	testParent.AsOf = &asOf
+	child := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), testParent.ID)
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return permission == "parent:read" || permission == "child:list", nil
+	}
+
+	mockFamilyService.GetParentByIDFunc = func(ctx context.Context, id uuid.UUID) (*domain.Parent, error) {
+		t.Fatal("GetParentByID should not be called")
+		return nil, nil
+	}
+
+	mockFamilyService.GetParentAsOfFunc = func(ctx context.Context, id uuid.UUID, at time.Time) (*domain.Parent, error) {
+		assert.Equal(t, testParent.ID, id)
+		assert.Equal(t, asOf, at)
+		return testParent, nil
+	}
+
+	// The children of the parent are those it had at that time, not the current ones
+	mockFamilyService.ListChildrenByParentIDsFunc = func(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Child, error) {
+		t.Fatal("ListChildrenByParentIDs should not be called")
+		return nil, nil
+	}
+
+	mockFamilyService.ListChildrenByParentIDAsOfFunc = func(ctx context.Context, parentID uuid.UUID, at time.Time) ([]*domain.Child, error) {
+		assert.Equal(t, testParent.ID, parentID)
+		assert.Equal(t, asOf, at)
+		return []*domain.Child{child}, nil
+	}
+
+	// Execute
+	result, err := resolver.Query().Parent(ctx, testParent.ID.String(), &asOf)
+	require.NoError(t, err)
+	assert.Equal(t, testParent, result)
+
+	children, err := resolver.Parent().Children(ctx, result)
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, 42, result)
+	assert.Equal(t, []domain.Child{*child}, children)
 }
 
-func TestChildConnectionResolver_Edges(t *testing.T) {
+func TestQueryResolver_ChildrenByParent_AsOf(t *testing.T) {
 	// Setup
-	resolver, _, _ := setupResolverTest(t)
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
 	parentID := uuid.New()
+	asOf := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 
-	// Create test child connection
 	child1 := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parentID)
 	child2 := domain.NewChild("Jack", "Doe", time.Now().AddDate(-3, 0, 0), parentID)
 
-	edges := []graphql.ChildEdge{
-		{Node: child1, Cursor: "cursor1"},
-		{Node: child2, Cursor: "cursor2"},
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return permission == "child:list", nil
 	}
 
-	connection := &graphql.ChildConnection{
-		Edges: edges,
-		PageInfo: &graphql.PageInfo{
-			HasNextPage:     false,
-			HasPreviousPage: false,
+	mockFamilyService.ListChildrenByParentIDAsOfFunc = func(ctx context.Context, pID uuid.UUID, at time.Time) ([]*domain.Child, error) {
+		assert.Equal(t, parentID, pID)
+		assert.Equal(t, asOf, at)
+		return []*domain.Child{child1, child2}, nil
+	}
+
+	// Execute
+	result, err := resolver.Query().ChildrenByParent(ctx, parentID.String(), nil, nil, nil, &asOf)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.TotalCount)
+	require.Len(t, result.Edges, 2)
+	assert.Equal(t, child1, result.Edges[0].Node)
+	assert.Equal(t, child2, result.Edges[1].Node)
+	assert.False(t, result.PageInfo.HasNextPage)
+	require.NotNil(t, result.PageInfo.EndCursor)
+	assert.Equal(t, result.Edges[1].Cursor, *result.PageInfo.EndCursor)
+}
+
+func TestQueryResolver_ChildrenByParent_AsOfWithPagination(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	asOf := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	pageSize := 10
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	mockFamilyService.ListChildrenByParentIDAsOfFunc = func(ctx context.Context, pID uuid.UUID, at time.Time) ([]*domain.Child, error) {
+		t.Fatal("ListChildrenByParentIDAsOf should not be called")
+		return nil, nil
+	}
+
+	// Execute
+	result, err := resolver.Query().ChildrenByParent(ctx, uuid.New().String(), nil, &graphql.PaginationInput{PageSize: &pageSize}, nil, &asOf)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestQueryResolver_History(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	created := domain.NewParentRevision(parent, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	deletedParent := *parent
+	deletedParent.MarkAsDeleted()
+	deleted := domain.NewParentRevision(&deletedParent, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return permission == "parent:read", nil
+	}
+
+	mockFamilyService.GetHistoryFunc = func(ctx context.Context, id uuid.UUID) ([]*domain.Revision, error) {
+		assert.Equal(t, parent.ID, id)
+		return []*domain.Revision{created, deleted}, nil
+	}
+
+	// Execute
+	result, err := resolver.Query().History(ctx, parent.ID.String())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.Revision{created, deleted}, result)
+
+	isDeleted, err := resolver.Revision().Deleted(ctx, created)
+	require.NoError(t, err)
+	assert.False(t, isDeleted)
+	isDeleted, err = resolver.Revision().Deleted(ctx, deleted)
+	require.NoError(t, err)
+	assert.True(t, isDeleted)
+}
+
+func TestQueryResolver_History_Errors(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	mockFamilyService.GetHistoryFunc = func(ctx context.Context, id uuid.UUID) ([]*domain.Revision, error) {
+		return nil, domain.NewNotFoundError("Revision", id.String())
+	}
+
+	// Execute and assert
+	_, err := resolver.Query().History(ctx, "invalid-uuid")
+	assert.ErrorIs(t, err, domain.ErrValidation)
+
+	_, err = resolver.Query().History(ctx, uuid.New().String())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Contains(t, err.Error(), "failed to get history")
+}
+
+func TestMutationResolver_CreateHousehold(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	parentID := uuid.New()
+	childID := uuid.New()
+	state := "IL"
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return permission == "household:create", nil
+	}
+
+	mockFamilyService.CreateHouseholdFunc = func(ctx context.Context, name string, address domain.Address, phoneNumbers []string, parentIDs, childIDs []uuid.UUID) (*domain.Household, error) {
+		assert.Equal(t, "The Doe family", name)
+		assert.Equal(t, domain.Address{Street: "1 Main St", City: "Springfield", State: "IL", PostalCode: "62701", Country: "US"}, address)
+		assert.Equal(t, []string{"+12175550100"}, phoneNumbers)
+		assert.Equal(t, []uuid.UUID{parentID}, parentIDs)
+		assert.Equal(t, []uuid.UUID{childID}, childIDs)
+		return domain.NewHousehold(name, address, phoneNumbers, parentIDs, childIDs), nil
+	}
+
+	input := graphql.CreateHouseholdInput{
+		Name: "The Doe family",
+		Address: &graphql.AddressInput{
+			Street:     "1 Main St",
+			City:       "Springfield",
+			State:      &state,
+			PostalCode: "62701",
+			Country:    "US",
 		},
-		TotalCount: 2,
+		PhoneNumbers: []string{"+12175550100"},
+		ParentIDs:    []string{parentID.String()},
+		ChildIDs:     []string{childID.String()},
 	}
 
 	// Execute
-	result, err := resolver.ChildConnection().Edges(ctx, connection)
+	result, err := resolver.Mutation().CreateHousehold(ctx, input)
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, edges, result)
+	assert.Equal(t, "The Doe family", result.Name)
+	assert.Equal(t, []uuid.UUID{childID}, result.ChildIDs)
+
+	// An invalid member ID is rejected
+	input.ChildIDs = []string{"invalid-uuid"}
+	_, err = resolver.Mutation().CreateHousehold(ctx, input)
+	assert.ErrorIs(t, err, domain.ErrValidation)
 }
 
-func TestChildConnectionResolver_PageInfo(t *testing.T) {
+func TestMutationResolver_CreateHousehold_Unauthorized(t *testing.T) {
 	// Setup
-	resolver, _, _ := setupResolverTest(t)
+	resolver, _, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
 
-	// Create test child connection
-	pageInfo := &graphql.PageInfo{
-		HasNextPage:     true,
-		HasPreviousPage: false,
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return false, nil
 	}
 
-	connection := &graphql.ChildConnection{
-		PageInfo:   pageInfo,
-		TotalCount: 2,
+	// Execute
+	_, err := resolver.Mutation().CreateHousehold(ctx, graphql.CreateHouseholdInput{Name: "The Doe family"})
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not authorized")
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+}
+
+func TestMutationResolver_MoveChildToHousehold(t *testing.T) {
+	// Setup
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
+	ctx := context.Background()
+	childID := uuid.New()
+	household := domain.NewHousehold("The Doe family", domain.Address{}, nil, []uuid.UUID{uuid.New()}, []uuid.UUID{childID})
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return permission == "household:update", nil
+	}
+
+	mockFamilyService.MoveChildToHouseholdFunc = func(ctx context.Context, gotChildID, householdID uuid.UUID) (*domain.Household, error) {
+		if householdID != household.ID {
+			return nil, domain.NewNotFoundError("Household", householdID.String())
+		}
+		assert.Equal(t, childID, gotChildID)
+		return household, nil
 	}
 
 	// Execute
-	result, err := resolver.ChildConnection().PageInfo(ctx, connection)
+	result, err := resolver.Mutation().MoveChildToHousehold(ctx, childID.String(), household.ID.String())
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, pageInfo, result)
+	assert.Equal(t, household, result)
+
+	// An unknown household is not found, and invalid IDs are rejected
+	_, err = resolver.Mutation().MoveChildToHousehold(ctx, childID.String(), uuid.New().String())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = resolver.Mutation().MoveChildToHousehold(ctx, "invalid-uuid", household.ID.String())
+	assert.ErrorIs(t, err, domain.ErrValidation)
+	_, err = resolver.Mutation().MoveChildToHousehold(ctx, childID.String(), "invalid-uuid")
+	assert.ErrorIs(t, err, domain.ErrValidation)
 }
 
-func TestChildConnectionResolver_TotalCount(t *testing.T) {
+func TestQueryResolver_Household(t *testing.T) {
 	// Setup
-	resolver, _, _ := setupResolverTest(t)
+	resolver, mockFamilyService, mockAuthService := setupResolverTest(t)
 	ctx := context.Background()
 
-	// Create test child connection
-	connection := &graphql.ChildConnection{
-		TotalCount: 42,
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	child := domain.NewChild("Jimmy", "Doe", time.Now().AddDate(-5, 0, 0), parent.ID)
+	gone := uuid.New()
+	household := domain.NewHousehold("The Doe family", domain.Address{}, nil, []uuid.UUID{parent.ID}, []uuid.UUID{child.ID, gone})
+
+	// Configure mocks
+	mockAuthService.IsAuthorizedFunc = func(ctx context.Context, permission string) (bool, error) {
+		return true, nil
+	}
+
+	mockFamilyService.GetHouseholdByIDFunc = func(ctx context.Context, id uuid.UUID) (*domain.Household, error) {
+		if id != household.ID {
+			return nil, domain.NewNotFoundError("Household", id.String())
+		}
+		return household, nil
+	}
+	mockFamilyService.GetParentsByIDsFunc = func(ctx context.Context, ids []uuid.UUID) ([]*domain.Parent, error) {
+		return []*domain.Parent{parent}, nil
+	}
+	mockFamilyService.GetChildByIDFunc = func(ctx context.Context, id uuid.UUID) (*domain.Child, error) {
+		if id != child.ID {
+			return nil, domain.NewNotFoundError("Child", id.String())
+		}
+		return child, nil
 	}
 
 	// Execute
-	result, err := resolver.ChildConnection().TotalCount(ctx, connection)
+	result, err := resolver.Query().Household(ctx, household.ID.String())
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, 42, result)
+	assert.Equal(t, household, result)
+
+	parents, err := resolver.Household().Parents(ctx, result)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Parent{*parent}, parents)
+
+	// A child that is gone is left out
+	children, err := resolver.Household().Children(ctx, result)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Child{*child}, children)
+
+	// An unknown household is not found, and an invalid ID is rejected
+	_, err = resolver.Query().Household(ctx, uuid.New().String())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = resolver.Query().Household(ctx, "invalid-uuid")
+	assert.ErrorIs(t, err, domain.ErrValidation)
 }
@@ -6,7 +6,7 @@ package graphql
 
 import (
 	"context"
-	"encoding/base64"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -18,6 +18,97 @@ import (
 	"go.uber.org/zap"
 )
 
+// ID is the resolver for the id field.
+func (r *auditRecordResolver) ID(ctx context.Context, obj *domain.AuditRecord) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// Actor is the resolver for the actor field.
+func (r *auditRecordResolver) Actor(ctx context.Context, obj *domain.AuditRecord) (*string, error) {
+	if obj.Actor == "" {
+		return nil, nil
+	}
+
+	return &obj.Actor, nil
+}
+
+// EntityID is the resolver for the entityId field.
+func (r *auditRecordResolver) EntityID(ctx context.Context, obj *domain.AuditRecord) (string, error) {
+	return obj.EntityID.String(), nil
+}
+
+// Reason is the resolver for the reason field.
+func (r *auditRecordResolver) Reason(ctx context.Context, obj *domain.AuditRecord) (*string, error) {
+	if obj.Reason == "" {
+		return nil, nil
+	}
+
+	return &obj.Reason, nil
+}
+
+// TraceID is the resolver for the traceId field.
+func (r *auditRecordResolver) TraceID(ctx context.Context, obj *domain.AuditRecord) (*string, error) {
+	if obj.TraceID == "" {
+		return nil, nil
+	}
+
+	return &obj.TraceID, nil
+}
+
+// OccurredAt is the resolver for the occurredAt field.
+func (r *auditRecordResolver) OccurredAt(ctx context.Context, obj *domain.AuditRecord) (string, error) {
+	return obj.OccurredAt.Format(time.RFC3339), nil
+}
+
+// ID is the resolver for the id field.
+func (r *changeEventResolver) ID(ctx context.Context, obj *domain.Event) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// Type is the resolver for the type field.
+func (r *changeEventResolver) Type(ctx context.Context, obj *domain.Event) (ChangeType, error) {
+	return ChangeType(obj.Type), nil
+}
+
+// ParentID is the resolver for the parentId field.
+func (r *changeEventResolver) ParentID(ctx context.Context, obj *domain.Event) (string, error) {
+	return obj.ParentID.String(), nil
+}
+
+// ChildID is the resolver for the childId field.
+func (r *changeEventResolver) ChildID(ctx context.Context, obj *domain.Event) (*string, error) {
+	if obj.ChildID == uuid.Nil {
+		return nil, nil
+	}
+
+	childID := obj.ChildID.String()
+	return &childID, nil
+}
+
+// PreviousParentID is the resolver for the previousParentId field.
+func (r *changeEventResolver) PreviousParentID(ctx context.Context, obj *domain.Event) (*string, error) {
+	if obj.PreviousParentID == nil {
+		return nil, nil
+	}
+
+	previousParentID := obj.PreviousParentID.String()
+	return &previousParentID, nil
+}
+
+// Reason is the resolver for the reason field.
+func (r *changeEventResolver) Reason(ctx context.Context, obj *domain.Event) (*string, error) {
+	if obj.Reason == "" {
+		return nil, nil
+	}
+
+	return &obj.Reason, nil
+}
+
+// OccurredAt is the resolver for the occurredAt field.
+func (r *changeEventResolver) OccurredAt(ctx context.Context, obj *domain.Event) (string, error) {
+	return obj.OccurredAt.Format(time.RFC3339), nil
+}
+
 // ID is the resolver for the id field.
 func (r *childResolver) ID(ctx context.Context, obj *domain.Child) (string, error) {
 	return obj.ID.String(), nil
@@ -33,6 +124,51 @@ func (r *childResolver) ParentID(ctx context.Context, obj *domain.Child) (string
 	return obj.ParentID.String(), nil
 }
 
+// Parent is the resolver for the parent field.
+func (r *childResolver) Parent(ctx context.Context, obj *domain.Child) (*domain.Parent, error) {
+	// Check authorization; the query that returned the child already
+	// limited it to the caller's family, and the parent of a child is in the same family
+	_, authorized, err := r.authorizeFamily(ctx, "parent:read")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		return nil, r.notAuthorized(ctx, "read parent")
+	}
+
+	// Batch the lookup with the other children being resolved in this request
+	parent, err := r.loaders(ctx).parentByID.Load(ctx, obj.ParentID)
+	if err != nil {
+		r.logger.Error("Failed to load parent", zap.Error(err), zap.String("parent_id", obj.ParentID.String()))
+		return nil, fmt.Errorf("failed to load parent: %w", err)
+	}
+
+	return parent, nil
+}
+
+// Guardians is the resolver for the guardians field.
+func (r *childResolver) Guardians(ctx context.Context, obj *domain.Child) ([]*domain.Guardianship, error) {
+	// Check authorization; the query that returned the child already limited it to the caller's family
+	_, authorized, err := r.authorizeFamily(ctx, "child:read")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		return nil, r.notAuthorized(ctx, "read child")
+	}
+
+	// Batch the lookup with the other children being resolved in this request
+	guardianships, err := r.loaders(ctx).guardiansByChildID.Load(ctx, obj.ID)
+	if err != nil {
+		r.logger.Error("Failed to load guardians", zap.Error(err), zap.String("child_id", obj.ID.String()))
+		return nil, fmt.Errorf("failed to load guardians: %w", err)
+	}
+
+	return guardianships, nil
+}
+
 // CreatedAt is the resolver for the createdAt field.
 func (r *childResolver) CreatedAt(ctx context.Context, obj *domain.Child) (string, error) {
 	return obj.CreatedAt.Format(time.RFC3339), nil
@@ -58,6 +194,153 @@ func (r *childConnectionResolver) TotalCount(ctx context.Context, obj *ChildConn
 	return obj.TotalCount, nil
 }
 
+// Before is the resolver for the before field.
+func (r *fieldChangeResolver) Before(ctx context.Context, obj *domain.FieldChange) (*string, error) {
+	if obj.Before == "" {
+		return nil, nil
+	}
+
+	return &obj.Before, nil
+}
+
+// After is the resolver for the after field.
+func (r *fieldChangeResolver) After(ctx context.Context, obj *domain.FieldChange) (*string, error) {
+	if obj.After == "" {
+		return nil, nil
+	}
+
+	return &obj.After, nil
+}
+
+// ID is the resolver for the id field.
+func (r *guardianshipResolver) ID(ctx context.Context, obj *domain.Guardianship) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// Type is the resolver for the type field.
+func (r *guardianshipResolver) Type(ctx context.Context, obj *domain.Guardianship) (GuardianshipType, error) {
+	return GuardianshipType(obj.Type), nil
+}
+
+// Parent is the resolver for the parent field.
+func (r *guardianshipResolver) Parent(ctx context.Context, obj *domain.Guardianship) (*domain.Parent, error) {
+	// Check authorization; a guardian is related to a child of the caller's family
+	_, authorized, err := r.authorizeFamily(ctx, "parent:read")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		return nil, r.notAuthorized(ctx, "read parent")
+	}
+
+	// Batch the lookup with the other guardianships being resolved in this request
+	parent, err := r.loaders(ctx).parentByID.Load(ctx, obj.ParentID)
+	if err != nil {
+		r.logger.Error("Failed to load parent", zap.Error(err), zap.String("parent_id", obj.ParentID.String()))
+		return nil, fmt.Errorf("failed to load parent: %w", err)
+	}
+
+	return parent, nil
+}
+
+// Child is the resolver for the child field.
+func (r *guardianshipResolver) Child(ctx context.Context, obj *domain.Guardianship) (*domain.Child, error) {
+	// Check authorization
+	ctx, authorized, err := r.authorizeFamily(ctx, "child:read")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		return nil, r.notAuthorized(ctx, "read child")
+	}
+
+	// A deleted child, or the former ward of a parent of the caller's family, resolves to null
+	child, err := r.familyService.GetChildByID(ctx, obj.ChildID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrForbidden) {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get child", zap.Error(err), zap.String("child_id", obj.ChildID.String()))
+		return nil, fmt.Errorf("failed to get child: %w", err)
+	}
+
+	return child, nil
+}
+
+// ID is the resolver for the id field.
+func (r *householdResolver) ID(ctx context.Context, obj *domain.Household) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// Parents is the resolver for the parents field.
+func (r *householdResolver) Parents(ctx context.Context, obj *domain.Household) ([]domain.Parent, error) {
+	// Check authorization; the query that returned the household already limited it to the caller's family
+	_, authorized, err := r.authorizeFamily(ctx, "parent:read")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		return nil, r.notAuthorized(ctx, "read parent")
+	}
+
+	// Batch the lookups with the other parents being resolved in this request; deleted parents are left out
+	parents := make([]domain.Parent, 0, len(obj.ParentIDs))
+	for _, parentID := range obj.ParentIDs {
+		parent, err := r.loaders(ctx).parentByID.Load(ctx, parentID)
+		if err != nil {
+			r.logger.Error("Failed to load parent", zap.Error(err), zap.String("parent_id", parentID.String()))
+			return nil, fmt.Errorf("failed to load parent: %w", err)
+		}
+		if parent != nil {
+			parents = append(parents, *parent)
+		}
+	}
+
+	return parents, nil
+}
+
+// Children is the resolver for the children field.
+func (r *householdResolver) Children(ctx context.Context, obj *domain.Household) ([]domain.Child, error) {
+	// Check authorization
+	ctx, authorized, err := r.authorizeFamily(ctx, "child:read")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		return nil, r.notAuthorized(ctx, "read child")
+	}
+
+	// Deleted children, and the children outside the caller's family, are left out
+	children := make([]domain.Child, 0, len(obj.ChildIDs))
+	for _, childID := range obj.ChildIDs {
+		child, err := r.familyService.GetChildByID(ctx, childID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrForbidden) {
+				continue
+			}
+			r.logger.Error("Failed to get child", zap.Error(err), zap.String("child_id", childID.String()))
+			return nil, fmt.Errorf("failed to get child: %w", err)
+		}
+		children = append(children, *child)
+	}
+
+	return children, nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *householdResolver) CreatedAt(ctx context.Context, obj *domain.Household) (string, error) {
+	return obj.CreatedAt.Format(time.RFC3339), nil
+}
+
+// UpdatedAt is the resolver for the updatedAt field.
+func (r *householdResolver) UpdatedAt(ctx context.Context, obj *domain.Household) (string, error) {
+	return obj.UpdatedAt.Format(time.RFC3339), nil
+}
+
 // CreateParent is the resolver for the createParent field.
 func (r *mutationResolver) CreateParent(ctx context.Context, input CreateParentInput) (*domain.Parent, error) {
 	// Validate context
@@ -88,13 +371,20 @@ func (r *mutationResolver) CreateParent(ctx context.Context, input CreateParentI
 		return nil, fmt.Errorf("failed to check authorization: %w", err)
 	}
 	if !authorized {
-		err := fmt.Errorf("not authorized to create parent")
+		err := r.notAuthorized(ctx, "create parent")
 		span.RecordError(err)
 		return nil, err
 	}
 
+	// Convert the contact details
+	contact := &domain.ContactDetails{
+		Phones:            phonesFromInput(input.Phones),
+		Address:           addressFromInput(input.Address),
+		EmergencyContacts: emergencyContactsFromInput(input.EmergencyContacts),
+	}
+
 	// Create parent
-	parent, err := r.familyService.CreateParent(ctx, input.FirstName, input.LastName, input.Email, input.BirthDate)
+	parent, err := r.familyService.CreateParent(ctx, input.FirstName, input.LastName, input.Email, input.BirthDate, contact)
 	if err != nil {
 		r.logger.Error("Failed to create parent", zap.Error(err))
 		span.RecordError(err)
@@ -107,6 +397,90 @@ func (r *mutationResolver) CreateParent(ctx context.Context, input CreateParentI
 	return parent, nil
 }
 
+// CreateParents is the resolver for the createParents field.
+func (r *mutationResolver) CreateParents(ctx context.Context, inputs []CreateParentInput, atomic *bool) ([]*CreateParentResult, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to CreateParents")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Mutation.CreateParents")
+	defer span.End()
+
+	// Add operation attributes to the span
+	isAtomic := atomic != nil && *atomic
+	span.SetAttributes(
+		attribute.Int("batch.size", len(inputs)),
+		attribute.Bool("batch.atomic", isAtomic),
+	)
+
+	// Create a timeout for this operation, which is longer for a batch
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// Check authorization
+	authorized, err := r.authService.IsAuthorized(ctx, "parent:create")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "create parent")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Convert the inputs
+	parentInputs := make([]ports.ParentInput, 0, len(inputs))
+	for _, input := range inputs {
+		parentInputs = append(parentInputs, ports.ParentInput{
+			FirstName: input.FirstName,
+			LastName:  input.LastName,
+			Email:     input.Email,
+			BirthDate: input.BirthDate,
+			Contact: &domain.ContactDetails{
+				Phones:            phonesFromInput(input.Phones),
+				Address:           addressFromInput(input.Address),
+				EmergencyContacts: emergencyContactsFromInput(input.EmergencyContacts),
+			},
+		})
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// Create parents
+	parentResults, err := r.familyService.CreateParents(ctx, parentInputs, isAtomic)
+	if err != nil {
+		r.logger.Error("Failed to create parents", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to create parents: %w", err)
+	}
+
+	results := make([]*CreateParentResult, 0, len(parentResults))
+	for _, result := range parentResults {
+		results = append(results, &CreateParentResult{
+			Parent: result.Parent,
+			Error:  presentBatchError(ctx, r.logger, result.Err),
+		})
+	}
+
+	// Add success attribute to the span
+	span.SetAttributes(attribute.String("result", "success"))
+
+	return results, nil
+}
+
 // UpdateParent is the resolver for the updateParent field.
 func (r *mutationResolver) UpdateParent(ctx context.Context, id string, input UpdateParentInput) (*domain.Parent, error) {
 	// Validate context
@@ -125,15 +499,15 @@ func (r *mutationResolver) UpdateParent(ctx context.Context, id string, input Up
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	// Check authorization
-	authorized, err := r.authService.IsAuthorized(ctx, "parent:update")
+	// Check authorization; a caller allowed only their own family gets a restricted scope
+	ctx, authorized, err := r.authorizeFamily(ctx, "parent:update")
 	if err != nil {
 		r.logger.Error("Failed to check authorization", zap.Error(err))
 		span.RecordError(err)
 		return nil, fmt.Errorf("failed to check authorization: %w", err)
 	}
 	if !authorized {
-		err := fmt.Errorf("not authorized to update parent")
+		err := r.notAuthorized(ctx, "update parent")
 		span.RecordError(err)
 		return nil, err
 	}
@@ -143,7 +517,7 @@ func (r *mutationResolver) UpdateParent(ctx context.Context, id string, input Up
 	if err != nil {
 		r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("id", id))
 		span.RecordError(err)
-		return nil, fmt.Errorf("invalid parent ID: %w", err)
+		return nil, fmt.Errorf("invalid parent ID: %w", domain.NewValidationError("Parent", "id", "must be a valid UUID"))
 	}
 
 	// Get current values
@@ -179,6 +553,25 @@ func (r *mutationResolver) UpdateParent(ctx context.Context, id string, input Up
 		span.SetAttributes(attribute.String("birthDate", birthDate))
 	}
 
+	// Contact details that are provided replace the current ones
+	contact := parent.ContactDetails
+	if input.Phones != nil {
+		contact.Phones = phonesFromInput(input.Phones)
+	}
+	if input.Address != nil {
+		contact.Address = addressFromInput(input.Address)
+	}
+	if input.EmergencyContacts != nil {
+		contact.EmergencyContacts = emergencyContactsFromInput(input.EmergencyContacts)
+	}
+
+	// The merged values are based on the version read above, so the update must not apply to any other version
+	expectedVersion := parent.Version
+	if input.ExpectedVersion != nil {
+		expectedVersion = *input.ExpectedVersion
+		span.SetAttributes(attribute.Int("expectedVersion", expectedVersion))
+	}
+
 	// Check for context cancellation before proceeding
 	select {
 	case <-ctx.Done():
@@ -191,7 +584,7 @@ func (r *mutationResolver) UpdateParent(ctx context.Context, id string, input Up
 	}
 
 	// Update parent
-	updatedParent, err := r.familyService.UpdateParent(ctx, parentID, firstName, lastName, email, birthDate)
+	updatedParent, err := r.familyService.UpdateParent(ctx, parentID, firstName, lastName, email, birthDate, &contact, &expectedVersion)
 	if err != nil {
 		r.logger.Error("Failed to update parent", zap.Error(err), zap.String("id", id))
 		span.RecordError(err)
@@ -230,7 +623,7 @@ func (r *mutationResolver) DeleteParent(ctx context.Context, id string) (bool, e
 		return false, fmt.Errorf("failed to check authorization: %w", err)
 	}
 	if !authorized {
-		err := fmt.Errorf("not authorized to delete parent")
+		err := r.notAuthorized(ctx, "delete parent")
 		span.RecordError(err)
 		return false, err
 	}
@@ -240,7 +633,7 @@ func (r *mutationResolver) DeleteParent(ctx context.Context, id string) (bool, e
 	if err != nil {
 		r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("id", id))
 		span.RecordError(err)
-		return false, fmt.Errorf("invalid parent ID: %w", err)
+		return false, fmt.Errorf("invalid parent ID: %w", domain.NewValidationError("Parent", "id", "must be a valid UUID"))
 	}
 
 	// Check for context cancellation before proceeding
@@ -268,47 +661,43 @@ func (r *mutationResolver) DeleteParent(ctx context.Context, id string) (bool, e
 	return true, nil
 }
 
-// CreateChild is the resolver for the createChild field.
-func (r *mutationResolver) CreateChild(ctx context.Context, input CreateChildInput) (*domain.Child, error) {
+// RestoreParent is the resolver for the restoreParent field.
+func (r *mutationResolver) RestoreParent(ctx context.Context, id string) (*domain.Parent, error) {
 	// Validate context
 	if ctx == nil {
-		return nil, fmt.Errorf("nil context provided to CreateChild")
+		return nil, fmt.Errorf("nil context provided to RestoreParent")
 	}
 
 	// Create a span for this operation
-	ctx, span := r.tracer.Start(ctx, "Mutation.CreateChild")
+	ctx, span := r.tracer.Start(ctx, "Mutation.RestoreParent")
 	defer span.End()
 
 	// Add operation attributes to the span
-	span.SetAttributes(
-		attribute.String("firstName", input.FirstName),
-		attribute.String("lastName", input.LastName),
-		attribute.String("parentId", input.ParentID),
-	)
+	span.SetAttributes(attribute.String("parent.id", id))
 
 	// Create a timeout for this operation
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Check authorization
-	authorized, err := r.authService.IsAuthorized(ctx, "child:create")
+	authorized, err := r.authService.IsAuthorized(ctx, "parent:restore")
 	if err != nil {
 		r.logger.Error("Failed to check authorization", zap.Error(err))
 		span.RecordError(err)
 		return nil, fmt.Errorf("failed to check authorization: %w", err)
 	}
 	if !authorized {
-		err := fmt.Errorf("not authorized to create child")
+		err := r.notAuthorized(ctx, "restore parent")
 		span.RecordError(err)
 		return nil, err
 	}
 
-	// Convert parent ID string to UUID
-	parentID, err := uuid.Parse(input.ParentID)
+	// Convert ID string to UUID
+	parentID, err := uuid.Parse(id)
 	if err != nil {
-		r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("parentId", input.ParentID))
+		r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("id", id))
 		span.RecordError(err)
-		return nil, fmt.Errorf("invalid parent ID: %w", err)
+		return nil, fmt.Errorf("invalid parent ID: %w", domain.NewValidationError("Parent", "id", "must be a valid UUID"))
 	}
 
 	// Check for context cancellation before proceeding
@@ -322,84 +711,68 @@ func (r *mutationResolver) CreateChild(ctx context.Context, input CreateChildInp
 		// Continue with the operation
 	}
 
-	// Create child
-	child, err := r.familyService.CreateChild(ctx, input.FirstName, input.LastName, input.BirthDate, parentID)
+	// Restore parent
+	parent, err := r.familyService.RestoreParent(ctx, parentID)
 	if err != nil {
-		r.logger.Error("Failed to create child", zap.Error(err))
+		r.logger.Error("Failed to restore parent", zap.Error(err), zap.String("id", id))
 		span.RecordError(err)
-		return nil, fmt.Errorf("failed to create child: %w", err)
+		return nil, fmt.Errorf("failed to restore parent: %w", err)
 	}
 
 	// Add success attribute to the span
-	span.SetAttributes(attribute.String("child.id", child.ID.String()))
+	span.SetAttributes(attribute.String("result", "success"))
 
-	return child, nil
+	return parent, nil
 }
 
-// UpdateChild is the resolver for the updateChild field.
-func (r *mutationResolver) UpdateChild(ctx context.Context, id string, input UpdateChildInput) (*domain.Child, error) {
+// MergeParents is the resolver for the mergeParents field.
+func (r *mutationResolver) MergeParents(ctx context.Context, survivorID string, duplicateID string) (*domain.Parent, error) {
 	// Validate context
 	if ctx == nil {
-		return nil, fmt.Errorf("nil context provided to UpdateChild")
+		return nil, fmt.Errorf("nil context provided to MergeParents")
 	}
 
 	// Create a span for this operation
-	ctx, span := r.tracer.Start(ctx, "Mutation.UpdateChild")
+	ctx, span := r.tracer.Start(ctx, "Mutation.MergeParents")
 	defer span.End()
 
 	// Add operation attributes to the span
-	span.SetAttributes(attribute.String("child.id", id))
+	span.SetAttributes(
+		attribute.String("survivor.id", survivorID),
+		attribute.String("duplicate.id", duplicateID),
+	)
 
 	// Create a timeout for this operation
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Check authorization
-	authorized, err := r.authService.IsAuthorized(ctx, "child:update")
+	authorized, err := r.authService.IsAuthorized(ctx, "parent:merge")
 	if err != nil {
 		r.logger.Error("Failed to check authorization", zap.Error(err))
 		span.RecordError(err)
 		return nil, fmt.Errorf("failed to check authorization: %w", err)
 	}
 	if !authorized {
-		err := fmt.Errorf("not authorized to update child")
+		err := r.notAuthorized(ctx, "merge parents")
 		span.RecordError(err)
 		return nil, err
 	}
 
-	// Convert ID string to UUID
-	childID, err := uuid.Parse(id)
+	// Convert survivor ID string to UUID
+	survivorUUID, err := uuid.Parse(survivorID)
 	if err != nil {
-		r.logger.Error("Invalid child ID", zap.Error(err), zap.String("id", id))
+		r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("survivorId", survivorID))
 		span.RecordError(err)
-		return nil, fmt.Errorf("invalid child ID: %w", err)
+		return nil, fmt.Errorf("invalid parent ID: %w", domain.NewValidationError("Parent", "survivorId", "must be a valid UUID"))
 	}
 
-	// Get current values
-	child, err := r.familyService.GetChildByID(ctx, childID)
+	// Convert duplicate ID string to UUID
+	duplicateUUID, err := uuid.Parse(duplicateID)
 	if err != nil {
-		r.logger.Error("Failed to get child", zap.Error(err), zap.String("id", id))
+		r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("duplicateId", duplicateID))
 		span.RecordError(err)
-		return nil, fmt.Errorf("failed to get child: %w", err)
-	}
-
-	// Update with new values if provided
-	firstName := child.FirstName
-	if input.FirstName != nil {
-		firstName = *input.FirstName
-		span.SetAttributes(attribute.String("firstName", firstName))
-	}
-
-	lastName := child.LastName
-	if input.LastName != nil {
-		lastName = *input.LastName
-		span.SetAttributes(attribute.String("lastName", lastName))
-	}
-
-	birthDate := child.BirthDate.Format(time.RFC3339)
-	if input.BirthDate != nil {
-		birthDate = *input.BirthDate
-		span.SetAttributes(attribute.String("birthDate", birthDate))
+		return nil, fmt.Errorf("invalid parent ID: %w", domain.NewValidationError("Parent", "duplicateId", "must be a valid UUID"))
 	}
 
 	// Check for context cancellation before proceeding
@@ -413,57 +786,61 @@ func (r *mutationResolver) UpdateChild(ctx context.Context, id string, input Upd
 		// Continue with the operation
 	}
 
-	// Update child
-	updatedChild, err := r.familyService.UpdateChild(ctx, childID, firstName, lastName, birthDate)
+	// Merge the duplicate into the survivor
+	parent, err := r.familyService.MergeParents(ctx, survivorUUID, duplicateUUID)
 	if err != nil {
-		r.logger.Error("Failed to update child", zap.Error(err), zap.String("id", id))
+		r.logger.Error("Failed to merge parents", zap.Error(err), zap.String("survivorId", survivorID), zap.String("duplicateId", duplicateID))
 		span.RecordError(err)
-		return nil, fmt.Errorf("failed to update child: %w", err)
+		return nil, fmt.Errorf("failed to merge parents: %w", err)
 	}
 
 	// Add success attribute to the span
 	span.SetAttributes(attribute.String("result", "success"))
 
-	return updatedChild, nil
+	return parent, nil
 }
 
-// DeleteChild is the resolver for the deleteChild field.
-func (r *mutationResolver) DeleteChild(ctx context.Context, id string) (bool, error) {
+// CreateChild is the resolver for the createChild field.
+func (r *mutationResolver) CreateChild(ctx context.Context, input CreateChildInput) (*domain.Child, error) {
 	// Validate context
 	if ctx == nil {
-		return false, fmt.Errorf("nil context provided to DeleteChild")
+		return nil, fmt.Errorf("nil context provided to CreateChild")
 	}
 
 	// Create a span for this operation
-	ctx, span := r.tracer.Start(ctx, "Mutation.DeleteChild")
+	ctx, span := r.tracer.Start(ctx, "Mutation.CreateChild")
 	defer span.End()
 
 	// Add operation attributes to the span
-	span.SetAttributes(attribute.String("child.id", id))
+	span.SetAttributes(
+		attribute.String("firstName", input.FirstName),
+		attribute.String("lastName", input.LastName),
+		attribute.String("parentId", input.ParentID),
+	)
 
 	// Create a timeout for this operation
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Check authorization
-	authorized, err := r.authService.IsAuthorized(ctx, "child:delete")
+	authorized, err := r.authService.IsAuthorized(ctx, "child:create")
 	if err != nil {
 		r.logger.Error("Failed to check authorization", zap.Error(err))
 		span.RecordError(err)
-		return false, fmt.Errorf("failed to check authorization: %w", err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
 	}
 	if !authorized {
-		err := fmt.Errorf("not authorized to delete child")
+		err := r.notAuthorized(ctx, "create child")
 		span.RecordError(err)
-		return false, err
+		return nil, err
 	}
 
-	// Convert ID string to UUID
-	childID, err := uuid.Parse(id)
+	// Convert parent ID string to UUID
+	parentID, err := uuid.Parse(input.ParentID)
 	if err != nil {
-		r.logger.Error("Invalid child ID", zap.Error(err), zap.String("id", id))
+		r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("parentId", input.ParentID))
 		span.RecordError(err)
-		return false, fmt.Errorf("invalid child ID: %w", err)
+		return nil, fmt.Errorf("invalid parent ID: %w", domain.NewValidationError("Parent", "parentId", "must be a valid UUID"))
 	}
 
 	// Check for context cancellation before proceeding
@@ -472,73 +849,90 @@ func (r *mutationResolver) DeleteChild(ctx context.Context, id string) (bool, er
 		err := ctx.Err()
 		r.logger.Error("Context cancelled or timed out", zap.Error(err))
 		span.RecordError(err)
-		return false, fmt.Errorf("operation cancelled or timed out: %w", err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
 	default:
 		// Continue with the operation
 	}
 
-	// Delete child
-	err = r.familyService.DeleteChild(ctx, childID)
+	// Create child
+	child, err := r.familyService.CreateChild(ctx, input.FirstName, input.LastName, input.BirthDate, parentID)
 	if err != nil {
-		r.logger.Error("Failed to delete child", zap.Error(err), zap.String("id", id))
+		r.logger.Error("Failed to create child", zap.Error(err))
 		span.RecordError(err)
-		return false, fmt.Errorf("failed to delete child: %w", err)
+		return nil, fmt.Errorf("failed to create child: %w", err)
 	}
 
 	// Add success attribute to the span
-	span.SetAttributes(attribute.String("result", "success"))
+	span.SetAttributes(attribute.String("child.id", child.ID.String()))
 
-	return true, nil
+	return child, nil
 }
 
-// AddChildToParent is the resolver for the addChildToParent field.
-func (r *mutationResolver) AddChildToParent(ctx context.Context, parentID string, childID string) (bool, error) {
+// CreateChildren is the resolver for the createChildren field.
+func (r *mutationResolver) CreateChildren(ctx context.Context, inputs []CreateChildInput, atomic *bool) ([]*CreateChildResult, error) {
 	// Validate context
 	if ctx == nil {
-		return false, fmt.Errorf("nil context provided to AddChildToParent")
+		return nil, fmt.Errorf("nil context provided to CreateChildren")
 	}
 
 	// Create a span for this operation
-	ctx, span := r.tracer.Start(ctx, "Mutation.AddChildToParent")
+	ctx, span := r.tracer.Start(ctx, "Mutation.CreateChildren")
 	defer span.End()
 
 	// Add operation attributes to the span
+	isAtomic := atomic != nil && *atomic
 	span.SetAttributes(
-		attribute.String("parent.id", parentID),
-		attribute.String("child.id", childID),
+		attribute.Int("batch.size", len(inputs)),
+		attribute.Bool("batch.atomic", isAtomic),
 	)
 
-	// Create a timeout for this operation
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	// Create a timeout for this operation, which is longer for a batch
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	// Check authorization
-	authorized, err := r.authService.IsAuthorized(ctx, "parent:update")
+	authorized, err := r.authService.IsAuthorized(ctx, "child:create")
 	if err != nil {
 		r.logger.Error("Failed to check authorization", zap.Error(err))
 		span.RecordError(err)
-		return false, fmt.Errorf("failed to check authorization: %w", err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
 	}
 	if !authorized {
-		err := fmt.Errorf("not authorized to update parent")
+		err := r.notAuthorized(ctx, "create child")
 		span.RecordError(err)
-		return false, err
+		return nil, err
 	}
 
-	// Convert parent ID string to UUID
-	parentUUID, err := uuid.Parse(parentID)
-	if err != nil {
-		r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("parentId", parentID))
-		span.RecordError(err)
-		return false, fmt.Errorf("invalid parent ID: %w", err)
+	// Convert the inputs; an input with an invalid parent ID fails on its own,
+	// and the others keep their positions
+	results := make([]*CreateChildResult, len(inputs))
+	childInputs := make([]ports.ChildInput, 0, len(inputs))
+	positions := make([]int, 0, len(inputs))
+	for i, input := range inputs {
+		parentID, err := uuid.Parse(input.ParentID)
+		if err != nil {
+			r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("parentId", input.ParentID))
+			results[i] = &CreateChildResult{
+				Error: presentBatchError(ctx, r.logger, domain.NewValidationError("Parent", "parentId", "must be a valid UUID")),
+			}
+			continue
+		}
+
+		childInputs = append(childInputs, ports.ChildInput{
+			FirstName: input.FirstName,
+			LastName:  input.LastName,
+			BirthDate: input.BirthDate,
+			ParentID:  parentID,
+		})
+		positions = append(positions, i)
 	}
 
-	// Convert child ID string to UUID
-	childUUID, err := uuid.Parse(childID)
-	if err != nil {
-		r.logger.Error("Invalid child ID", zap.Error(err), zap.String("childId", childID))
-		span.RecordError(err)
-		return false, fmt.Errorf("invalid child ID: %w", err)
+	// In atomic mode, an invalid input fails the whole batch
+	if isAtomic && len(childInputs) < len(inputs) {
+		for _, i := range positions {
+			results[i] = &CreateChildResult{Error: presentBatchError(ctx, r.logger, domain.ErrAborted)}
+		}
+		return results, nil
 	}
 
 	// Check for context cancellation before proceeding
@@ -547,73 +941,103 @@ func (r *mutationResolver) AddChildToParent(ctx context.Context, parentID string
 		err := ctx.Err()
 		r.logger.Error("Context cancelled or timed out", zap.Error(err))
 		span.RecordError(err)
-		return false, fmt.Errorf("operation cancelled or timed out: %w", err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
 	default:
 		// Continue with the operation
 	}
 
-	// Add child to parent
-	err = r.familyService.AddChildToParent(ctx, parentUUID, childUUID)
+	// Create children
+	childResults, err := r.familyService.CreateChildren(ctx, childInputs, isAtomic)
 	if err != nil {
-		r.logger.Error("Failed to add child to parent", zap.Error(err), zap.String("parentId", parentID), zap.String("childId", childID))
+		r.logger.Error("Failed to create children", zap.Error(err))
 		span.RecordError(err)
-		return false, fmt.Errorf("failed to add child to parent: %w", err)
+		return nil, fmt.Errorf("failed to create children: %w", err)
+	}
+
+	for j, result := range childResults {
+		results[positions[j]] = &CreateChildResult{
+			Child: result.Child,
+			Error: presentBatchError(ctx, r.logger, result.Err),
+		}
 	}
 
 	// Add success attribute to the span
 	span.SetAttributes(attribute.String("result", "success"))
 
-	return true, nil
+	return results, nil
 }
 
-// RemoveChildFromParent is the resolver for the removeChildFromParent field.
-func (r *mutationResolver) RemoveChildFromParent(ctx context.Context, parentID string, childID string) (bool, error) {
+// UpdateChild is the resolver for the updateChild field.
+func (r *mutationResolver) UpdateChild(ctx context.Context, id string, input UpdateChildInput) (*domain.Child, error) {
 	// Validate context
 	if ctx == nil {
-		return false, fmt.Errorf("nil context provided to RemoveChildFromParent")
+		return nil, fmt.Errorf("nil context provided to UpdateChild")
 	}
 
 	// Create a span for this operation
-	ctx, span := r.tracer.Start(ctx, "Mutation.RemoveChildFromParent")
+	ctx, span := r.tracer.Start(ctx, "Mutation.UpdateChild")
 	defer span.End()
 
 	// Add operation attributes to the span
-	span.SetAttributes(
-		attribute.String("parent.id", parentID),
-		attribute.String("child.id", childID),
-	)
+	span.SetAttributes(attribute.String("child.id", id))
 
 	// Create a timeout for this operation
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	// Check authorization
-	authorized, err := r.authService.IsAuthorized(ctx, "parent:update")
+	// Check authorization; a caller allowed only their own family gets a restricted scope
+	ctx, authorized, err := r.authorizeFamily(ctx, "child:update")
 	if err != nil {
 		r.logger.Error("Failed to check authorization", zap.Error(err))
 		span.RecordError(err)
-		return false, fmt.Errorf("failed to check authorization: %w", err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
 	}
 	if !authorized {
-		err := fmt.Errorf("not authorized to update parent")
+		err := r.notAuthorized(ctx, "update child")
 		span.RecordError(err)
-		return false, err
+		return nil, err
 	}
 
-	// Convert parent ID string to UUID
-	parentUUID, err := uuid.Parse(parentID)
+	// Convert ID string to UUID
+	childID, err := uuid.Parse(id)
 	if err != nil {
-		r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("parentId", parentID))
+		r.logger.Error("Invalid child ID", zap.Error(err), zap.String("id", id))
 		span.RecordError(err)
-		return false, fmt.Errorf("invalid parent ID: %w", err)
+		return nil, fmt.Errorf("invalid child ID: %w", domain.NewValidationError("Child", "id", "must be a valid UUID"))
 	}
 
-	// Convert child ID string to UUID
-	childUUID, err := uuid.Parse(childID)
+	// Get current values
+	child, err := r.familyService.GetChildByID(ctx, childID)
 	if err != nil {
-		r.logger.Error("Invalid child ID", zap.Error(err), zap.String("childId", childID))
+		r.logger.Error("Failed to get child", zap.Error(err), zap.String("id", id))
 		span.RecordError(err)
-		return false, fmt.Errorf("invalid child ID: %w", err)
+		return nil, fmt.Errorf("failed to get child: %w", err)
+	}
+
+	// Update with new values if provided
+	firstName := child.FirstName
+	if input.FirstName != nil {
+		firstName = *input.FirstName
+		span.SetAttributes(attribute.String("firstName", firstName))
+	}
+
+	lastName := child.LastName
+	if input.LastName != nil {
+		lastName = *input.LastName
+		span.SetAttributes(attribute.String("lastName", lastName))
+	}
+
+	birthDate := child.BirthDate.Format(time.RFC3339)
+	if input.BirthDate != nil {
+		birthDate = *input.BirthDate
+		span.SetAttributes(attribute.String("birthDate", birthDate))
+	}
+
+	// The merged values are based on the version read above, so the update must not apply to any other version
+	expectedVersion := child.Version
+	if input.ExpectedVersion != nil {
+		expectedVersion = *input.ExpectedVersion
+		span.SetAttributes(attribute.Int("expectedVersion", expectedVersion))
 	}
 
 	// Check for context cancellation before proceeding
@@ -622,102 +1046,62 @@ func (r *mutationResolver) RemoveChildFromParent(ctx context.Context, parentID s
 		err := ctx.Err()
 		r.logger.Error("Context cancelled or timed out", zap.Error(err))
 		span.RecordError(err)
-		return false, fmt.Errorf("operation cancelled or timed out: %w", err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
 	default:
 		// Continue with the operation
 	}
 
-	// Remove child from parent
-	err = r.familyService.RemoveChildFromParent(ctx, parentUUID, childUUID)
+	// Update child
+	updatedChild, err := r.familyService.UpdateChild(ctx, childID, firstName, lastName, birthDate, &expectedVersion)
 	if err != nil {
-		r.logger.Error("Failed to remove child from parent", zap.Error(err), zap.String("parentId", parentID), zap.String("childId", childID))
+		r.logger.Error("Failed to update child", zap.Error(err), zap.String("id", id))
 		span.RecordError(err)
-		return false, fmt.Errorf("failed to remove child from parent: %w", err)
+		return nil, fmt.Errorf("failed to update child: %w", err)
 	}
 
 	// Add success attribute to the span
 	span.SetAttributes(attribute.String("result", "success"))
 
-	return true, nil
-}
-
-// ID is the resolver for the id field.
-func (r *parentResolver) ID(ctx context.Context, obj *domain.Parent) (string, error) {
-	return obj.ID.String(), nil
-}
-
-// BirthDate is the resolver for the birthDate field.
-func (r *parentResolver) BirthDate(ctx context.Context, obj *domain.Parent) (string, error) {
-	return obj.BirthDate.Format(time.RFC3339), nil
-}
-
-// Children is the resolver for the children field.
-func (r *parentResolver) Children(ctx context.Context, obj *domain.Parent) ([]domain.Child, error) {
-	return obj.Children, nil
-}
-
-// CreatedAt is the resolver for the createdAt field.
-func (r *parentResolver) CreatedAt(ctx context.Context, obj *domain.Parent) (string, error) {
-	return obj.CreatedAt.Format(time.RFC3339), nil
-}
-
-// UpdatedAt is the resolver for the updatedAt field.
-func (r *parentResolver) UpdatedAt(ctx context.Context, obj *domain.Parent) (string, error) {
-	return obj.UpdatedAt.Format(time.RFC3339), nil
-}
-
-// Edges is the resolver for the edges field.
-func (r *parentConnectionResolver) Edges(ctx context.Context, obj *ParentConnection) ([]ParentEdge, error) {
-	return obj.Edges, nil
-}
-
-// PageInfo is the resolver for the pageInfo field.
-func (r *parentConnectionResolver) PageInfo(ctx context.Context, obj *ParentConnection) (*PageInfo, error) {
-	return obj.PageInfo, nil
-}
-
-// TotalCount is the resolver for the totalCount field.
-func (r *parentConnectionResolver) TotalCount(ctx context.Context, obj *ParentConnection) (int, error) {
-	return obj.TotalCount, nil
+	return updatedChild, nil
 }
 
-// Parent is the resolver for the parent field.
-func (r *queryResolver) Parent(ctx context.Context, id string) (*domain.Parent, error) {
+// DeleteChild is the resolver for the deleteChild field.
+func (r *mutationResolver) DeleteChild(ctx context.Context, id string) (bool, error) {
 	// Validate context
 	if ctx == nil {
-		return nil, fmt.Errorf("nil context provided to Parent query")
+		return false, fmt.Errorf("nil context provided to DeleteChild")
 	}
 
 	// Create a span for this operation
-	ctx, span := r.tracer.Start(ctx, "Query.Parent")
+	ctx, span := r.tracer.Start(ctx, "Mutation.DeleteChild")
 	defer span.End()
 
 	// Add operation attributes to the span
-	span.SetAttributes(attribute.String("parent.id", id))
+	span.SetAttributes(attribute.String("child.id", id))
 
 	// Create a timeout for this operation
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Check authorization
-	authorized, err := r.authService.IsAuthorized(ctx, "parent:read")
+	authorized, err := r.authService.IsAuthorized(ctx, "child:delete")
 	if err != nil {
 		r.logger.Error("Failed to check authorization", zap.Error(err))
 		span.RecordError(err)
-		return nil, fmt.Errorf("failed to check authorization: %w", err)
+		return false, fmt.Errorf("failed to check authorization: %w", err)
 	}
 	if !authorized {
-		err := fmt.Errorf("not authorized to read parent")
+		err := r.notAuthorized(ctx, "delete child")
 		span.RecordError(err)
-		return nil, err
+		return false, err
 	}
 
 	// Convert ID string to UUID
-	parentID, err := uuid.Parse(id)
+	childID, err := uuid.Parse(id)
 	if err != nil {
-		r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("id", id))
+		r.logger.Error("Invalid child ID", zap.Error(err), zap.String("id", id))
 		span.RecordError(err)
-		return nil, fmt.Errorf("invalid parent ID: %w", err)
+		return false, fmt.Errorf("invalid child ID: %w", domain.NewValidationError("Child", "id", "must be a valid UUID"))
 	}
 
 	// Check for context cancellation before proceeding
@@ -726,53 +1110,87 @@ func (r *queryResolver) Parent(ctx context.Context, id string) (*domain.Parent,
 		err := ctx.Err()
 		r.logger.Error("Context cancelled or timed out", zap.Error(err))
 		span.RecordError(err)
-		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
+		return false, fmt.Errorf("operation cancelled or timed out: %w", err)
 	default:
 		// Continue with the operation
 	}
 
-	// Get parent by ID
-	parent, err := r.familyService.GetParentByID(ctx, parentID)
+	// Delete child
+	err = r.familyService.DeleteChild(ctx, childID)
 	if err != nil {
-		r.logger.Error("Failed to get parent", zap.Error(err), zap.String("id", id))
+		r.logger.Error("Failed to delete child", zap.Error(err), zap.String("id", id))
 		span.RecordError(err)
-		return nil, fmt.Errorf("failed to get parent: %w", err)
+		return false, fmt.Errorf("failed to delete child: %w", err)
 	}
 
 	// Add success attribute to the span
 	span.SetAttributes(attribute.String("result", "success"))
 
-	return parent, nil
+	return true, nil
 }
 
-// Parents is the resolver for the parents field.
-func (r *queryResolver) Parents(ctx context.Context, filter *ParentFilter, pagination *PaginationInput, sort *SortInput) (*ParentConnection, error) {
+// DeleteChildren is the resolver for the deleteChildren field.
+func (r *mutationResolver) DeleteChildren(ctx context.Context, ids []string, atomic *bool) ([]*DeleteChildResult, error) {
 	// Validate context
 	if ctx == nil {
-		return nil, fmt.Errorf("nil context provided to Parents query")
+		return nil, fmt.Errorf("nil context provided to DeleteChildren")
 	}
 
 	// Create a span for this operation
-	ctx, span := r.tracer.Start(ctx, "Query.Parents")
+	ctx, span := r.tracer.Start(ctx, "Mutation.DeleteChildren")
 	defer span.End()
 
-	// Create a timeout for this operation
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	// Add operation attributes to the span
+	isAtomic := atomic != nil && *atomic
+	span.SetAttributes(
+		attribute.Int("batch.size", len(ids)),
+		attribute.Bool("batch.atomic", isAtomic),
+	)
+
+	// Create a timeout for this operation, which is longer for a batch
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	// Check authorization
-	authorized, err := r.authService.IsAuthorized(ctx, "parent:list")
+	authorized, err := r.authService.IsAuthorized(ctx, "child:delete")
 	if err != nil {
 		r.logger.Error("Failed to check authorization", zap.Error(err))
 		span.RecordError(err)
 		return nil, fmt.Errorf("failed to check authorization: %w", err)
 	}
 	if !authorized {
-		err := fmt.Errorf("not authorized to list parents")
+		err := r.notAuthorized(ctx, "delete child")
 		span.RecordError(err)
 		return nil, err
 	}
 
+	// Convert ID strings to UUIDs; an invalid ID fails on its own, and the others keep their positions
+	results := make([]*DeleteChildResult, len(ids))
+	childIDs := make([]uuid.UUID, 0, len(ids))
+	positions := make([]int, 0, len(ids))
+	for i, id := range ids {
+		childID, err := uuid.Parse(id)
+		if err != nil {
+			r.logger.Error("Invalid child ID", zap.Error(err), zap.String("id", id))
+			results[i] = &DeleteChildResult{
+				ID:    id,
+				Error: presentBatchError(ctx, r.logger, domain.NewValidationError("Child", "id", "must be a valid UUID")),
+			}
+			continue
+		}
+
+		childIDs = append(childIDs, childID)
+		positions = append(positions, i)
+	}
+
+	// In atomic mode, an invalid ID fails the whole batch
+	if isAtomic && len(childIDs) < len(ids) {
+		for _, i := range positions {
+			results[i] = &DeleteChildResult{ID: ids[i], Error: presentBatchError(ctx, r.logger, domain.ErrAborted)}
+		}
+		return results, nil
+	}
+
 	// Check for context cancellation before proceeding
 	select {
 	case <-ctx.Done():
@@ -784,37 +1202,1712 @@ func (r *queryResolver) Parents(ctx context.Context, filter *ParentFilter, pagin
 		// Continue with the operation
 	}
 
-	// Convert GraphQL filter to domain filter
-	filterOptions := ports.FilterOptions{}
-	if filter != nil {
-		if filter.FirstName != nil {
-			filterOptions.FirstName = *filter.FirstName
+	// Delete children
+	deleteResults, err := r.familyService.DeleteChildren(ctx, childIDs, isAtomic)
+	if err != nil {
+		r.logger.Error("Failed to delete children", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to delete children: %w", err)
+	}
+
+	for j, result := range deleteResults {
+		results[positions[j]] = &DeleteChildResult{
+			ID:      result.ID.String(),
+			Deleted: result.Err == nil,
+			Error:   presentBatchError(ctx, r.logger, result.Err),
 		}
-		if filter.LastName != nil {
-			filterOptions.LastName = *filter.LastName
+	}
+
+	// Add success attribute to the span
+	span.SetAttributes(attribute.String("result", "success"))
+
+	return results, nil
+}
+
+// RestoreChild is the resolver for the restoreChild field.
+func (r *mutationResolver) RestoreChild(ctx context.Context, id string) (*domain.Child, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to RestoreChild")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Mutation.RestoreChild")
+	defer span.End()
+
+	// Add operation attributes to the span
+	span.SetAttributes(attribute.String("child.id", id))
+
+	// Create a timeout for this operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Check authorization
+	authorized, err := r.authService.IsAuthorized(ctx, "child:restore")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "restore child")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Convert ID string to UUID
+	childID, err := uuid.Parse(id)
+	if err != nil {
+		r.logger.Error("Invalid child ID", zap.Error(err), zap.String("id", id))
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid child ID: %w", domain.NewValidationError("Child", "id", "must be a valid UUID"))
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// Restore child; it is added back to its parent's children
+	child, err := r.familyService.RestoreChild(ctx, childID)
+	if err != nil {
+		r.logger.Error("Failed to restore child", zap.Error(err), zap.String("id", id))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to restore child: %w", err)
+	}
+
+	// Add success attribute to the span
+	span.SetAttributes(attribute.String("result", "success"))
+
+	return child, nil
+}
+
+// AddChildToParent is the resolver for the addChildToParent field.
+func (r *mutationResolver) AddChildToParent(ctx context.Context, parentID string, childID string, typeArg *GuardianshipType, primaryContact *bool, startDate *time.Time) (bool, error) {
+	// Validate context
+	if ctx == nil {
+		return false, fmt.Errorf("nil context provided to AddChildToParent")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Mutation.AddChildToParent")
+	defer span.End()
+
+	// Add operation attributes to the span
+	span.SetAttributes(
+		attribute.String("parent.id", parentID),
+		attribute.String("child.id", childID),
+	)
+
+	// Create a timeout for this operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Check authorization
+	authorized, err := r.authService.IsAuthorized(ctx, "parent:update")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "update parent")
+		span.RecordError(err)
+		return false, err
+	}
+
+	// Convert parent ID string to UUID
+	parentUUID, err := uuid.Parse(parentID)
+	if err != nil {
+		r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("parentId", parentID))
+		span.RecordError(err)
+		return false, fmt.Errorf("invalid parent ID: %w", domain.NewValidationError("Parent", "parentId", "must be a valid UUID"))
+	}
+
+	// Convert child ID string to UUID
+	childUUID, err := uuid.Parse(childID)
+	if err != nil {
+		r.logger.Error("Invalid child ID", zap.Error(err), zap.String("childId", childID))
+		span.RecordError(err)
+		return false, fmt.Errorf("invalid child ID: %w", domain.NewValidationError("Child", "childId", "must be a valid UUID"))
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return false, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// The parent is a guardian unless told otherwise
+	guardianshipType := domain.GuardianshipGuardian
+	if typeArg != nil {
+		guardianshipType = domain.GuardianshipType(*typeArg)
+	}
+	makePrimary := primaryContact != nil && *primaryContact
+
+	// Add child to parent
+	err = r.familyService.AddChildToParent(ctx, parentUUID, childUUID, guardianshipType, makePrimary, startDate)
+	if err != nil {
+		r.logger.Error("Failed to add child to parent", zap.Error(err), zap.String("parentId", parentID), zap.String("childId", childID))
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to add child to parent: %w", err)
+	}
+
+	// Add success attribute to the span
+	span.SetAttributes(attribute.String("result", "success"))
+
+	return true, nil
+}
+
+// RemoveChildFromParent is the resolver for the removeChildFromParent field.
+func (r *mutationResolver) RemoveChildFromParent(ctx context.Context, parentID string, childID string) (bool, error) {
+	// Validate context
+	if ctx == nil {
+		return false, fmt.Errorf("nil context provided to RemoveChildFromParent")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Mutation.RemoveChildFromParent")
+	defer span.End()
+
+	// Add operation attributes to the span
+	span.SetAttributes(
+		attribute.String("parent.id", parentID),
+		attribute.String("child.id", childID),
+	)
+
+	// Create a timeout for this operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Check authorization
+	authorized, err := r.authService.IsAuthorized(ctx, "parent:update")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "update parent")
+		span.RecordError(err)
+		return false, err
+	}
+
+	// Convert parent ID string to UUID
+	parentUUID, err := uuid.Parse(parentID)
+	if err != nil {
+		r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("parentId", parentID))
+		span.RecordError(err)
+		return false, fmt.Errorf("invalid parent ID: %w", domain.NewValidationError("Parent", "parentId", "must be a valid UUID"))
+	}
+
+	// Convert child ID string to UUID
+	childUUID, err := uuid.Parse(childID)
+	if err != nil {
+		r.logger.Error("Invalid child ID", zap.Error(err), zap.String("childId", childID))
+		span.RecordError(err)
+		return false, fmt.Errorf("invalid child ID: %w", domain.NewValidationError("Child", "childId", "must be a valid UUID"))
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return false, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// Remove child from parent
+	err = r.familyService.RemoveChildFromParent(ctx, parentUUID, childUUID)
+	if err != nil {
+		r.logger.Error("Failed to remove child from parent", zap.Error(err), zap.String("parentId", parentID), zap.String("childId", childID))
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to remove child from parent: %w", err)
+	}
+
+	// Add success attribute to the span
+	span.SetAttributes(attribute.String("result", "success"))
+
+	return true, nil
+}
+
+// TransferChild is the resolver for the transferChild field.
+func (r *mutationResolver) TransferChild(ctx context.Context, childID string, fromParentID string, toParentID string, reason string) (*domain.Child, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to TransferChild")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Mutation.TransferChild")
+	defer span.End()
+
+	// Add operation attributes to the span
+	span.SetAttributes(
+		attribute.String("child.id", childID),
+		attribute.String("parent.from_id", fromParentID),
+		attribute.String("parent.to_id", toParentID),
+	)
+
+	// Create a timeout for this operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Check authorization
+	authorized, err := r.authService.IsAuthorized(ctx, "child:transfer")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "transfer child")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Convert child ID string to UUID
+	childUUID, err := uuid.Parse(childID)
+	if err != nil {
+		r.logger.Error("Invalid child ID", zap.Error(err), zap.String("childId", childID))
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid child ID: %w", domain.NewValidationError("Child", "childId", "must be a valid UUID"))
+	}
+
+	// Convert the ID of the parent the child leaves to UUID
+	fromParentUUID, err := uuid.Parse(fromParentID)
+	if err != nil {
+		r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("fromParentId", fromParentID))
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid parent ID: %w", domain.NewValidationError("Parent", "fromParentId", "must be a valid UUID"))
+	}
+
+	// Convert the ID of the parent the child joins to UUID
+	toParentUUID, err := uuid.Parse(toParentID)
+	if err != nil {
+		r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("toParentId", toParentID))
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid parent ID: %w", domain.NewValidationError("Parent", "toParentId", "must be a valid UUID"))
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// Transfer child
+	child, err := r.familyService.TransferChild(ctx, childUUID, fromParentUUID, toParentUUID, reason)
+	if err != nil {
+		r.logger.Error("Failed to transfer child", zap.Error(err), zap.String("childId", childID), zap.String("fromParentId", fromParentID), zap.String("toParentId", toParentID))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to transfer child: %w", err)
+	}
+
+	// Add success attribute to the span
+	span.SetAttributes(attribute.String("result", "success"))
+
+	return child, nil
+}
+
+// LinkParentUser is the resolver for the linkParentUser field.
+func (r *mutationResolver) LinkParentUser(ctx context.Context, parentID string, userID *string) (*domain.Parent, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to LinkParentUser")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Mutation.LinkParentUser")
+	defer span.End()
+
+	// Add operation attributes to the span
+	span.SetAttributes(attribute.String("parent.id", parentID))
+
+	// Create a timeout for this operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Check authorization
+	authorized, err := r.authService.IsAuthorized(ctx, "parent:link")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "link parent")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Convert parent ID string to UUID
+	parentUUID, err := uuid.Parse(parentID)
+	if err != nil {
+		r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("parentId", parentID))
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid parent ID: %w", domain.NewValidationError("Parent", "parentId", "must be a valid UUID"))
+	}
+
+	// A null user ID removes the link
+	linkedUserID := ""
+	if userID != nil {
+		linkedUserID = *userID
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// Link parent to user
+	parent, err := r.familyService.LinkParentUser(ctx, parentUUID, linkedUserID)
+	if err != nil {
+		r.logger.Error("Failed to link parent to user", zap.Error(err), zap.String("parentId", parentID))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to link parent to user: %w", err)
+	}
+
+	// Add success attribute to the span
+	span.SetAttributes(attribute.String("result", "success"))
+
+	return parent, nil
+}
+
+// PurgeDeleted is the resolver for the purgeDeleted field.
+func (r *mutationResolver) PurgeDeleted(ctx context.Context) (*ports.PurgeResult, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to PurgeDeleted")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Mutation.PurgeDeleted")
+	defer span.End()
+
+	// Create a timeout for this operation; purging may remove many records at once
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// Check authorization; purging removes both parents and children
+	for _, operation := range []string{"parent:purge", "child:purge"} {
+		authorized, err := r.authService.IsAuthorized(ctx, operation)
+		if err != nil {
+			r.logger.Error("Failed to check authorization", zap.Error(err))
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to check authorization: %w", err)
+		}
+		if !authorized {
+			err := r.notAuthorized(ctx, "purge deleted records")
+			span.RecordError(err)
+			return nil, err
+		}
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// Purge the records deleted longer ago than the retention period
+	result, err := r.familyService.PurgeDeleted(ctx)
+	if err != nil {
+		r.logger.Error("Failed to purge deleted records", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to purge deleted records: %w", err)
+	}
+
+	// Add result attributes to the span
+	span.SetAttributes(
+		attribute.Int64("purged.parents", result.Parents),
+		attribute.Int64("purged.children", result.Children),
+		attribute.String("result", "success"),
+	)
+
+	return result, nil
+}
+
+// CreateWebhookSubscription is the resolver for the createWebhookSubscription field.
+func (r *mutationResolver) CreateWebhookSubscription(ctx context.Context, input CreateWebhookSubscriptionInput) (*domain.WebhookSubscription, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to CreateWebhookSubscription")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Mutation.CreateWebhookSubscription")
+	defer span.End()
+
+	// Add operation attributes to the span; the secret is never recorded
+	span.SetAttributes(attribute.String("webhook.url", input.URL))
+
+	// Create a timeout for this operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Check authorization
+	authorized, err := r.authService.IsAuthorized(ctx, "webhook:create")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "create webhook subscription")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	webhooks, err := r.webhookService()
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Convert the change types to event types
+	eventTypes := make([]domain.EventType, 0, len(input.EventTypes))
+	for _, changeType := range input.EventTypes {
+		eventTypes = append(eventTypes, domain.EventType(changeType))
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// Create the subscription
+	subscription, err := webhooks.CreateWebhookSubscription(ctx, input.URL, eventTypes, input.Secret)
+	if err != nil {
+		r.logger.Error("Failed to create webhook subscription", zap.Error(err), zap.String("url", input.URL))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	// Add result attributes to the span
+	span.SetAttributes(
+		attribute.String("webhook.id", subscription.ID.String()),
+		attribute.String("result", "success"),
+	)
+
+	return subscription, nil
+}
+
+// DeleteWebhookSubscription is the resolver for the deleteWebhookSubscription field.
+func (r *mutationResolver) DeleteWebhookSubscription(ctx context.Context, id string) (bool, error) {
+	// Validate context
+	if ctx == nil {
+		return false, fmt.Errorf("nil context provided to DeleteWebhookSubscription")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Mutation.DeleteWebhookSubscription")
+	defer span.End()
+
+	// Add operation attributes to the span
+	span.SetAttributes(attribute.String("webhook.id", id))
+
+	// Create a timeout for this operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Check authorization
+	authorized, err := r.authService.IsAuthorized(ctx, "webhook:delete")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "delete webhook subscription")
+		span.RecordError(err)
+		return false, err
+	}
+
+	webhooks, err := r.webhookService()
+	if err != nil {
+		span.RecordError(err)
+		return false, err
+	}
+
+	// Convert ID string to UUID
+	subscriptionID, err := uuid.Parse(id)
+	if err != nil {
+		r.logger.Error("Invalid webhook subscription ID", zap.Error(err), zap.String("id", id))
+		span.RecordError(err)
+		return false, fmt.Errorf("invalid webhook subscription ID: %w", domain.NewValidationError("WebhookSubscription", "id", "must be a valid UUID"))
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return false, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// Delete the subscription
+	if err := webhooks.DeleteWebhookSubscription(ctx, subscriptionID); err != nil {
+		r.logger.Error("Failed to delete webhook subscription", zap.Error(err), zap.String("id", id))
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	// Add success attribute to the span
+	span.SetAttributes(attribute.String("result", "success"))
+
+	return true, nil
+}
+
+// CreateHousehold is the resolver for the createHousehold field.
+func (r *mutationResolver) CreateHousehold(ctx context.Context, input CreateHouseholdInput) (*domain.Household, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to CreateHousehold")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Mutation.CreateHousehold")
+	defer span.End()
+
+	// Add operation attributes to the span
+	span.SetAttributes(
+		attribute.String("household.name", input.Name),
+		attribute.Int("parent.count", len(input.ParentIDs)),
+		attribute.Int("child.count", len(input.ChildIDs)),
+	)
+
+	// Create a timeout for this operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Check authorization
+	authorized, err := r.authService.IsAuthorized(ctx, "household:create")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "create household")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Convert the member ID strings to UUIDs
+	parentIDs, err := parseIDs(input.ParentIDs)
+	if err != nil {
+		r.logger.Error("Invalid parent ID", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid parent ID: %w", domain.NewValidationError("Household", "parentIds", "must be valid UUIDs"))
+	}
+	childIDs, err := parseIDs(input.ChildIDs)
+	if err != nil {
+		r.logger.Error("Invalid child ID", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid child ID: %w", domain.NewValidationError("Household", "childIds", "must be valid UUIDs"))
+	}
+
+	// Convert the address
+	var address domain.Address
+	if input.Address != nil {
+		address = *addressFromInput(input.Address)
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// Create household
+	household, err := r.familyService.CreateHousehold(ctx, input.Name, address, input.PhoneNumbers, parentIDs, childIDs)
+	if err != nil {
+		r.logger.Error("Failed to create household", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to create household: %w", err)
+	}
+
+	// Add result attributes to the span
+	span.SetAttributes(
+		attribute.String("household.id", household.ID.String()),
+		attribute.String("result", "success"),
+	)
+
+	return household, nil
+}
+
+// MoveChildToHousehold is the resolver for the moveChildToHousehold field.
+func (r *mutationResolver) MoveChildToHousehold(ctx context.Context, childID string, householdID string) (*domain.Household, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to MoveChildToHousehold")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Mutation.MoveChildToHousehold")
+	defer span.End()
+
+	// Add operation attributes to the span
+	span.SetAttributes(
+		attribute.String("child.id", childID),
+		attribute.String("household.id", householdID),
+	)
+
+	// Create a timeout for this operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Check authorization
+	authorized, err := r.authService.IsAuthorized(ctx, "household:update")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "update household")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Convert child ID string to UUID
+	childUUID, err := uuid.Parse(childID)
+	if err != nil {
+		r.logger.Error("Invalid child ID", zap.Error(err), zap.String("childId", childID))
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid child ID: %w", domain.NewValidationError("Child", "childId", "must be a valid UUID"))
+	}
+
+	// Convert household ID string to UUID
+	householdUUID, err := uuid.Parse(householdID)
+	if err != nil {
+		r.logger.Error("Invalid household ID", zap.Error(err), zap.String("householdId", householdID))
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid household ID: %w", domain.NewValidationError("Household", "householdId", "must be a valid UUID"))
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// Move the child; it leaves the household it belonged to
+	household, err := r.familyService.MoveChildToHousehold(ctx, childUUID, householdUUID)
+	if err != nil {
+		r.logger.Error("Failed to move child to household", zap.Error(err), zap.String("childId", childID), zap.String("householdId", householdID))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to move child to household: %w", err)
+	}
+
+	// Add success attribute to the span
+	span.SetAttributes(attribute.String("result", "success"))
+
+	return household, nil
+}
+
+// ID is the resolver for the id field.
+func (r *parentResolver) ID(ctx context.Context, obj *domain.Parent) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// BirthDate is the resolver for the birthDate field.
+func (r *parentResolver) BirthDate(ctx context.Context, obj *domain.Parent) (string, error) {
+	return obj.BirthDate.Format(time.RFC3339), nil
+}
+
+// UserID is the resolver for the userId field.
+func (r *parentResolver) UserID(ctx context.Context, obj *domain.Parent) (*string, error) {
+	if obj.UserID == "" {
+		return nil, nil
+	}
+	return &obj.UserID, nil
+}
+
+// Children is the resolver for the children field.
+func (r *parentResolver) Children(ctx context.Context, obj *domain.Parent) ([]domain.Child, error) {
+	// Check authorization; the query that returned the parent already
+	// limited it to the caller's family, and the children of a parent are in the same family
+	_, authorized, err := r.authorizeFamily(ctx, "child:list")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		return nil, r.notAuthorized(ctx, "list children")
+	}
+
+	// A parent as of a past time has the children it had at that time
+	if obj.AsOf != nil {
+		children, err := r.familyService.ListChildrenByParentIDAsOf(ctx, obj.ID, *obj.AsOf)
+		if err != nil {
+			r.logger.Error("Failed to list children as of time", zap.Error(err), zap.String("parent_id", obj.ID.String()))
+			return nil, fmt.Errorf("failed to list children as of time: %w", err)
+		}
+
+		result := make([]domain.Child, len(children))
+		for i, child := range children {
+			result[i] = *child
+		}
+		return result, nil
+	}
+
+	// Batch the lookup with the other parents being resolved in this request
+	children, err := r.loaders(ctx).childrenByParentID.Load(ctx, obj.ID)
+	if err != nil {
+		r.logger.Error("Failed to load children", zap.Error(err), zap.String("parent_id", obj.ID.String()))
+		return nil, fmt.Errorf("failed to load children: %w", err)
+	}
+
+	return children, nil
+}
+
+// Wards is the resolver for the wards field.
+func (r *parentResolver) Wards(ctx context.Context, obj *domain.Parent) ([]*domain.Guardianship, error) {
+	// Check authorization; the query that returned the parent already limited it to the caller's family
+	_, authorized, err := r.authorizeFamily(ctx, "child:list")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		return nil, r.notAuthorized(ctx, "list children")
+	}
+
+	// Batch the lookup with the other parents being resolved in this request
+	guardianships, err := r.loaders(ctx).wardsByParentID.Load(ctx, obj.ID)
+	if err != nil {
+		r.logger.Error("Failed to load wards", zap.Error(err), zap.String("parent_id", obj.ID.String()))
+		return nil, fmt.Errorf("failed to load wards: %w", err)
+	}
+
+	return guardianships, nil
+}
+
+// MergedInto is the resolver for the mergedInto field.
+func (r *parentResolver) MergedInto(ctx context.Context, obj *domain.Parent) (*string, error) {
+	if obj.MergedInto == nil {
+		return nil, nil
+	}
+	id := obj.MergedInto.String()
+	return &id, nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *parentResolver) CreatedAt(ctx context.Context, obj *domain.Parent) (string, error) {
+	return obj.CreatedAt.Format(time.RFC3339), nil
+}
+
+// UpdatedAt is the resolver for the updatedAt field.
+func (r *parentResolver) UpdatedAt(ctx context.Context, obj *domain.Parent) (string, error) {
+	return obj.UpdatedAt.Format(time.RFC3339), nil
+}
+
+// Edges is the resolver for the edges field.
+func (r *parentConnectionResolver) Edges(ctx context.Context, obj *ParentConnection) ([]ParentEdge, error) {
+	return obj.Edges, nil
+}
+
+// PageInfo is the resolver for the pageInfo field.
+func (r *parentConnectionResolver) PageInfo(ctx context.Context, obj *ParentConnection) (*PageInfo, error) {
+	return obj.PageInfo, nil
+}
+
+// TotalCount is the resolver for the totalCount field.
+func (r *parentConnectionResolver) TotalCount(ctx context.Context, obj *ParentConnection) (int, error) {
+	return obj.TotalCount, nil
+}
+
+// Type is the resolver for the type field.
+func (r *phoneResolver) Type(ctx context.Context, obj *domain.Phone) (*PhoneType, error) {
+	if obj.Type == "" {
+		return nil, nil
+	}
+	phoneType := PhoneType(obj.Type)
+	return &phoneType, nil
+}
+
+// Parent is the resolver for the parent field.
+func (r *queryResolver) Parent(ctx context.Context, id string, asOf *time.Time) (*domain.Parent, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to Parent query")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Query.Parent")
+	defer span.End()
+
+	// Add operation attributes to the span
+	span.SetAttributes(attribute.String("parent.id", id))
+
+	// Create a timeout for this operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Check authorization; a caller allowed only their own family gets a restricted scope
+	ctx, authorized, err := r.authorizeFamily(ctx, "parent:read")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "read parent")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Convert ID string to UUID
+	parentID, err := uuid.Parse(id)
+	if err != nil {
+		r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("id", id))
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid parent ID: %w", domain.NewValidationError("Parent", "id", "must be a valid UUID"))
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// Get the parent as of the given time, if any
+	if asOf != nil {
+		span.SetAttributes(attribute.String("parent.as_of", asOf.Format(time.RFC3339)))
+
+		parent, err := r.familyService.GetParentAsOf(ctx, parentID, *asOf)
+		if err != nil {
+			r.logger.Error("Failed to get parent as of time", zap.Error(err), zap.String("id", id))
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to get parent as of time: %w", err)
+		}
+
+		span.SetAttributes(attribute.String("result", "success"))
+		return parent, nil
+	}
+
+	// Get parent by ID
+	parent, err := r.familyService.GetParentByID(ctx, parentID)
+	if err != nil {
+		r.logger.Error("Failed to get parent", zap.Error(err), zap.String("id", id))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get parent: %w", err)
+	}
+
+	// Add success attribute to the span
+	span.SetAttributes(attribute.String("result", "success"))
+
+	return parent, nil
+}
+
+// Parents is the resolver for the parents field.
+func (r *queryResolver) Parents(ctx context.Context, filter *ParentFilter, pagination *PaginationInput, sort *SortInput) (*ParentConnection, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to Parents query")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Query.Parents")
+	defer span.End()
+
+	// Create a timeout for this operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Check authorization; a caller allowed only their own family gets a restricted scope
+	ctx, authorized, err := r.authorizeFamily(ctx, "parent:list")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "list parents")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// Convert GraphQL filter to domain filter
+	filterOptions := ports.FilterOptions{}
+	if filter != nil {
+		if filter.FirstName != nil {
+			filterOptions.FirstName = *filter.FirstName
+		}
+		if filter.LastName != nil {
+			filterOptions.LastName = *filter.LastName
+		}
+		if filter.Email != nil {
+			filterOptions.Email = *filter.Email
+		}
+		if filter.MinAge != nil {
+			filterOptions.MinAge = *filter.MinAge
+		}
+		if filter.MaxAge != nil {
+			filterOptions.MaxAge = *filter.MaxAge
+		}
+		if filter.PostalCode != nil {
+			filterOptions.PostalCode = *filter.PostalCode
+		}
+		if filter.Phone != nil {
+			filterOptions.Phone = domain.NormalizePhoneNumber(*filter.Phone)
+		}
+	}
+
+	// Convert GraphQL sort to domain sort
+	sortOptions := ports.SortOptions{
+		Field:     "createdAt",
+		Direction: "desc",
+	}
+	if sort != nil {
+		if sort.Field != nil {
+			sortOptions.Field = *sort.Field
+		}
+		if sort.Direction != nil {
+			sortOptions.Direction = strings.ToLower(string(*sort.Direction))
+		}
+	}
+
+	// Convert GraphQL pagination to domain pagination, decoding any cursors into keyset positions
+	paginationOptions, cursorOptions, err := buildPageOptions(pagination, sortOptions, true)
+	if err != nil {
+		r.logger.Error("Invalid pagination", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid pagination: %w", err)
+	}
+
+	// Create query options
+	queryOptions := ports.QueryOptions{
+		Filter:     filterOptions,
+		Pagination: paginationOptions,
+		Sort:       sortOptions,
+		Cursor:     cursorOptions,
+	}
+
+	// List parents
+	parents, pagedResult, err := r.familyService.ListParents(ctx, queryOptions)
+	if err != nil {
+		r.logger.Error("Failed to list parents", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list parents: %w", err)
+	}
+
+	// Create connection
+	connection := &ParentConnection{
+		Edges:      make([]ParentEdge, len(parents)),
+		PageInfo:   &PageInfo{},
+		TotalCount: int(pagedResult.TotalCount),
+	}
+
+	// Create edges
+	for i, parent := range parents {
+		connection.Edges[i] = ParentEdge{
+			Node:   parent,
+			Cursor: parentCursor(parent, sortOptions.Field),
+		}
+	}
+
+	// Set page info
+	connection.PageInfo.HasNextPage = pagedResult.HasNext
+	connection.PageInfo.HasPreviousPage = pagedResult.HasPrevious || pagedResult.Page > 0
+	if len(parents) > 0 {
+		startCursor := parentCursor(parents[0], sortOptions.Field)
+		endCursor := parentCursor(parents[len(parents)-1], sortOptions.Field)
+		connection.PageInfo.StartCursor = &startCursor
+		connection.PageInfo.EndCursor = &endCursor
+	}
+
+	return connection, nil
+}
+
+// DeletedParents is the resolver for the deletedParents field.
+func (r *queryResolver) DeletedParents(ctx context.Context, filter *ParentFilter, pagination *PaginationInput, sort *SortInput) (*ParentConnection, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to DeletedParents query")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Query.DeletedParents")
+	defer span.End()
+
+	// Create a timeout for this operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Check authorization; deleted parents are only listed for callers allowed to restore or purge them
+	authorized, err := r.authService.IsAuthorized(ctx, "parent:list-deleted")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "list deleted parents")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// Convert GraphQL filter to domain filter, selecting the deleted parents
+	filterOptions := ports.FilterOptions{Deleted: true}
+	if filter != nil {
+		if filter.FirstName != nil {
+			filterOptions.FirstName = *filter.FirstName
+		}
+		if filter.LastName != nil {
+			filterOptions.LastName = *filter.LastName
+		}
+		if filter.Email != nil {
+			filterOptions.Email = *filter.Email
+		}
+		if filter.MinAge != nil {
+			filterOptions.MinAge = *filter.MinAge
+		}
+		if filter.MaxAge != nil {
+			filterOptions.MaxAge = *filter.MaxAge
+		}
+		if filter.PostalCode != nil {
+			filterOptions.PostalCode = *filter.PostalCode
+		}
+		if filter.Phone != nil {
+			filterOptions.Phone = domain.NormalizePhoneNumber(*filter.Phone)
+		}
+	}
+
+	// Convert GraphQL sort to domain sort
+	sortOptions := ports.SortOptions{
+		Field:     "createdAt",
+		Direction: "desc",
+	}
+	if sort != nil {
+		if sort.Field != nil {
+			sortOptions.Field = *sort.Field
+		}
+		if sort.Direction != nil {
+			sortOptions.Direction = strings.ToLower(string(*sort.Direction))
+		}
+	}
+
+	// Convert GraphQL pagination to domain pagination, decoding any cursors into keyset positions
+	paginationOptions, cursorOptions, err := buildPageOptions(pagination, sortOptions, true)
+	if err != nil {
+		r.logger.Error("Invalid pagination", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid pagination: %w", err)
+	}
+
+	// Create query options
+	queryOptions := ports.QueryOptions{
+		Filter:     filterOptions,
+		Pagination: paginationOptions,
+		Sort:       sortOptions,
+		Cursor:     cursorOptions,
+	}
+
+	// List deleted parents
+	parents, pagedResult, err := r.familyService.ListParents(ctx, queryOptions)
+	if err != nil {
+		r.logger.Error("Failed to list deleted parents", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list deleted parents: %w", err)
+	}
+
+	// Create connection
+	connection := &ParentConnection{
+		Edges:      make([]ParentEdge, len(parents)),
+		PageInfo:   &PageInfo{},
+		TotalCount: int(pagedResult.TotalCount),
+	}
+
+	// Create edges
+	for i, parent := range parents {
+		connection.Edges[i] = ParentEdge{
+			Node:   parent,
+			Cursor: parentCursor(parent, sortOptions.Field),
+		}
+	}
+
+	// Set page info
+	connection.PageInfo.HasNextPage = pagedResult.HasNext
+	connection.PageInfo.HasPreviousPage = pagedResult.HasPrevious || pagedResult.Page > 0
+	if len(parents) > 0 {
+		startCursor := parentCursor(parents[0], sortOptions.Field)
+		endCursor := parentCursor(parents[len(parents)-1], sortOptions.Field)
+		connection.PageInfo.StartCursor = &startCursor
+		connection.PageInfo.EndCursor = &endCursor
+	}
+
+	return connection, nil
+}
+
+// PossibleDuplicateParents is the resolver for the possibleDuplicateParents field.
+func (r *queryResolver) PossibleDuplicateParents(ctx context.Context, parentID *string, minScore *float64, limit *int) ([]*domain.ParentDuplicate, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to PossibleDuplicateParents query")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Query.PossibleDuplicateParents")
+	defer span.End()
+
+	// Create a timeout for this operation; finding duplicates compares every parent of the tenant
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// Check authorization
+	authorized, err := r.authService.IsAuthorized(ctx, "parent:list-duplicates")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "list duplicate parents")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Convert the optional parent ID string to UUID
+	var parentUUID *uuid.UUID
+	if parentID != nil {
+		span.SetAttributes(attribute.String("parent.id", *parentID))
+		id, err := uuid.Parse(*parentID)
+		if err != nil {
+			r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("parentId", *parentID))
+			span.RecordError(err)
+			return nil, fmt.Errorf("invalid parent ID: %w", domain.NewValidationError("Parent", "parentId", "must be a valid UUID"))
 		}
-		if filter.Email != nil {
-			filterOptions.Email = *filter.Email
+		parentUUID = &id
+	}
+
+	// Zero selects the defaults of the service
+	score := 0.0
+	if minScore != nil {
+		score = *minScore
+	}
+	count := 0
+	if limit != nil {
+		count = *limit
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// Find the possible duplicates
+	duplicates, err := r.familyService.FindDuplicateParents(ctx, parentUUID, score, count)
+	if err != nil {
+		r.logger.Error("Failed to find duplicate parents", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to find duplicate parents: %w", err)
+	}
+
+	// Add success attribute to the span
+	span.SetAttributes(
+		attribute.String("result", "success"),
+		attribute.Int("duplicates.count", len(duplicates)),
+	)
+
+	return duplicates, nil
+}
+
+// Child is the resolver for the child field.
+func (r *queryResolver) Child(ctx context.Context, id string) (*domain.Child, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to Child query")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Query.Child")
+	defer span.End()
+
+	// Add operation attributes to the span
+	span.SetAttributes(attribute.String("child.id", id))
+
+	// Create a timeout for this operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Check authorization; a caller allowed only their own family gets a restricted scope
+	ctx, authorized, err := r.authorizeFamily(ctx, "child:read")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "read child")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Convert ID string to UUID
+	childID, err := uuid.Parse(id)
+	if err != nil {
+		r.logger.Error("Invalid child ID", zap.Error(err), zap.String("id", id))
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid child ID: %w", domain.NewValidationError("Child", "id", "must be a valid UUID"))
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// Get child by ID
+	child, err := r.familyService.GetChildByID(ctx, childID)
+	if err != nil {
+		r.logger.Error("Failed to get child", zap.Error(err), zap.String("id", id))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get child: %w", err)
+	}
+
+	// Add success attribute to the span
+	span.SetAttributes(attribute.String("result", "success"))
+
+	return child, nil
+}
+
+// Children is the resolver for the children field.
+func (r *queryResolver) Children(ctx context.Context, filter *ChildFilter, pagination *PaginationInput, sort *SortInput) (*ChildConnection, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to Children query")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Query.Children")
+	defer span.End()
+
+	// Create a timeout for this operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Check authorization; a caller allowed only their own family gets a restricted scope
+	ctx, authorized, err := r.authorizeFamily(ctx, "child:list")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "list children")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// Convert GraphQL filter to domain filter
+	filterOptions := ports.FilterOptions{}
+	if filter != nil {
+		if filter.FirstName != nil {
+			filterOptions.FirstName = *filter.FirstName
+		}
+		if filter.LastName != nil {
+			filterOptions.LastName = *filter.LastName
+		}
+		if filter.MinAge != nil {
+			filterOptions.MinAge = *filter.MinAge
+		}
+		if filter.MaxAge != nil {
+			filterOptions.MaxAge = *filter.MaxAge
+		}
+	}
+
+	// Convert GraphQL sort to domain sort
+	sortOptions := ports.SortOptions{
+		Field:     "createdAt",
+		Direction: "desc",
+	}
+	if sort != nil {
+		if sort.Field != nil {
+			sortOptions.Field = *sort.Field
+		}
+		if sort.Direction != nil {
+			sortOptions.Direction = strings.ToLower(string(*sort.Direction))
+		}
+	}
+
+	// Convert GraphQL pagination to domain pagination, decoding any cursors into keyset positions
+	paginationOptions, cursorOptions, err := buildPageOptions(pagination, sortOptions, false)
+	if err != nil {
+		r.logger.Error("Invalid pagination", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid pagination: %w", err)
+	}
+
+	// Create query options
+	queryOptions := ports.QueryOptions{
+		Filter:     filterOptions,
+		Pagination: paginationOptions,
+		Sort:       sortOptions,
+		Cursor:     cursorOptions,
+	}
+
+	// List children
+	children, pagedResult, err := r.familyService.ListChildren(ctx, queryOptions)
+	if err != nil {
+		r.logger.Error("Failed to list children", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list children: %w", err)
+	}
+
+	// Create connection
+	connection := &ChildConnection{
+		Edges:      make([]ChildEdge, len(children)),
+		PageInfo:   &PageInfo{},
+		TotalCount: int(pagedResult.TotalCount),
+	}
+
+	// Create edges
+	for i, child := range children {
+		connection.Edges[i] = ChildEdge{
+			Node:   child,
+			Cursor: childCursor(child, sortOptions.Field),
+		}
+	}
+
+	// Set page info
+	connection.PageInfo.HasNextPage = pagedResult.HasNext
+	connection.PageInfo.HasPreviousPage = pagedResult.HasPrevious || pagedResult.Page > 0
+	if len(children) > 0 {
+		startCursor := childCursor(children[0], sortOptions.Field)
+		endCursor := childCursor(children[len(children)-1], sortOptions.Field)
+		connection.PageInfo.StartCursor = &startCursor
+		connection.PageInfo.EndCursor = &endCursor
+	}
+
+	return connection, nil
+}
+
+// DeletedChildren is the resolver for the deletedChildren field.
+func (r *queryResolver) DeletedChildren(ctx context.Context, filter *ChildFilter, pagination *PaginationInput, sort *SortInput) (*ChildConnection, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to DeletedChildren query")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Query.DeletedChildren")
+	defer span.End()
+
+	// Create a timeout for this operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Check authorization; deleted children are only listed for callers allowed to restore or purge them
+	authorized, err := r.authService.IsAuthorized(ctx, "child:list-deleted")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "list deleted children")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// Convert GraphQL filter to domain filter, selecting the deleted children
+	filterOptions := ports.FilterOptions{Deleted: true}
+	if filter != nil {
+		if filter.FirstName != nil {
+			filterOptions.FirstName = *filter.FirstName
+		}
+		if filter.LastName != nil {
+			filterOptions.LastName = *filter.LastName
+		}
+		if filter.MinAge != nil {
+			filterOptions.MinAge = *filter.MinAge
+		}
+		if filter.MaxAge != nil {
+			filterOptions.MaxAge = *filter.MaxAge
+		}
+	}
+
+	// Convert GraphQL sort to domain sort
+	sortOptions := ports.SortOptions{
+		Field:     "createdAt",
+		Direction: "desc",
+	}
+	if sort != nil {
+		if sort.Field != nil {
+			sortOptions.Field = *sort.Field
 		}
-		if filter.MinAge != nil {
-			filterOptions.MinAge = *filter.MinAge
+		if sort.Direction != nil {
+			sortOptions.Direction = strings.ToLower(string(*sort.Direction))
 		}
-		if filter.MaxAge != nil {
-			filterOptions.MaxAge = *filter.MaxAge
+	}
+
+	// Convert GraphQL pagination to domain pagination, decoding any cursors into keyset positions
+	paginationOptions, cursorOptions, err := buildPageOptions(pagination, sortOptions, false)
+	if err != nil {
+		r.logger.Error("Invalid pagination", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid pagination: %w", err)
+	}
+
+	// Create query options
+	queryOptions := ports.QueryOptions{
+		Filter:     filterOptions,
+		Pagination: paginationOptions,
+		Sort:       sortOptions,
+		Cursor:     cursorOptions,
+	}
+
+	// List deleted children
+	children, pagedResult, err := r.familyService.ListChildren(ctx, queryOptions)
+	if err != nil {
+		r.logger.Error("Failed to list deleted children", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list deleted children: %w", err)
+	}
+
+	// Create connection
+	connection := &ChildConnection{
+		Edges:      make([]ChildEdge, len(children)),
+		PageInfo:   &PageInfo{},
+		TotalCount: int(pagedResult.TotalCount),
+	}
+
+	// Create edges
+	for i, child := range children {
+		connection.Edges[i] = ChildEdge{
+			Node:   child,
+			Cursor: childCursor(child, sortOptions.Field),
 		}
 	}
 
-	// Convert GraphQL pagination to domain pagination
-	paginationOptions := ports.PaginationOptions{
-		Page:     0,
-		PageSize: 10,
+	// Set page info
+	connection.PageInfo.HasNextPage = pagedResult.HasNext
+	connection.PageInfo.HasPreviousPage = pagedResult.HasPrevious || pagedResult.Page > 0
+	if len(children) > 0 {
+		startCursor := childCursor(children[0], sortOptions.Field)
+		endCursor := childCursor(children[len(children)-1], sortOptions.Field)
+		connection.PageInfo.StartCursor = &startCursor
+		connection.PageInfo.EndCursor = &endCursor
+	}
+
+	return connection, nil
+}
+
+// ChildrenByParent is the resolver for the childrenByParent field.
+func (r *queryResolver) ChildrenByParent(ctx context.Context, parentID string, filter *ChildFilter, pagination *PaginationInput, sort *SortInput, asOf *time.Time) (*ChildConnection, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to ChildrenByParent query")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Query.ChildrenByParent")
+	defer span.End()
+
+	// Add operation attributes to the span
+	span.SetAttributes(attribute.String("parent.id", parentID))
+
+	// Create a timeout for this operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Check authorization; a caller allowed only their own family gets a restricted scope
+	ctx, authorized, err := r.authorizeFamily(ctx, "child:list")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "list children")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Convert parent ID string to UUID
+	parentUUID, err := uuid.Parse(parentID)
+	if err != nil {
+		r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("parentId", parentID))
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid parent ID: %w", domain.NewValidationError("Parent", "parentId", "must be a valid UUID"))
+	}
+
+	// The children as of a time are listed in a single page, so they cannot be filtered or paged
+	if asOf != nil && (filter != nil || pagination != nil || sort != nil) {
+		err := domain.NewValidationError("Child", "asOf", "cannot be combined with filter, pagination or sort")
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// List the children the parent had at the given time, if any
+	if asOf != nil {
+		span.SetAttributes(attribute.String("parent.as_of", asOf.Format(time.RFC3339)))
+
+		children, err := r.familyService.ListChildrenByParentIDAsOf(ctx, parentUUID, *asOf)
+		if err != nil {
+			r.logger.Error("Failed to list children by parent as of time", zap.Error(err), zap.String("parentId", parentID))
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to list children by parent as of time: %w", err)
+		}
+
+		connection := &ChildConnection{
+			Edges:      make([]ChildEdge, len(children)),
+			PageInfo:   &PageInfo{},
+			TotalCount: len(children),
+		}
+		for i, child := range children {
+			connection.Edges[i] = ChildEdge{
+				Node:   child,
+				Cursor: childCursor(child, "createdAt"),
+			}
+		}
+		if len(children) > 0 {
+			startCursor := connection.Edges[0].Cursor
+			endCursor := connection.Edges[len(children)-1].Cursor
+			connection.PageInfo.StartCursor = &startCursor
+			connection.PageInfo.EndCursor = &endCursor
+		}
+
+		return connection, nil
 	}
-	if pagination != nil {
-		if pagination.Page != nil {
-			paginationOptions.Page = *pagination.Page
+
+	// Convert GraphQL filter to domain filter
+	filterOptions := ports.FilterOptions{}
+	if filter != nil {
+		if filter.FirstName != nil {
+			filterOptions.FirstName = *filter.FirstName
+		}
+		if filter.LastName != nil {
+			filterOptions.LastName = *filter.LastName
 		}
-		if pagination.PageSize != nil {
-			paginationOptions.PageSize = *pagination.PageSize
+		if filter.MinAge != nil {
+			filterOptions.MinAge = *filter.MinAge
+		}
+		if filter.MaxAge != nil {
+			filterOptions.MaxAge = *filter.MaxAge
 		}
 	}
 
@@ -832,86 +2925,280 @@ func (r *queryResolver) Parents(ctx context.Context, filter *ParentFilter, pagin
 		}
 	}
 
+	// Convert GraphQL pagination to domain pagination, decoding any cursors into keyset positions
+	paginationOptions, cursorOptions, err := buildPageOptions(pagination, sortOptions, false)
+	if err != nil {
+		r.logger.Error("Invalid pagination", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid pagination: %w", err)
+	}
+
 	// Create query options
 	queryOptions := ports.QueryOptions{
 		Filter:     filterOptions,
 		Pagination: paginationOptions,
 		Sort:       sortOptions,
+		Cursor:     cursorOptions,
 	}
 
-	// List parents
-	parents, pagedResult, err := r.familyService.ListParents(ctx, queryOptions)
+	// List children by parent ID
+	children, pagedResult, err := r.familyService.ListChildrenByParentID(ctx, parentUUID, queryOptions)
 	if err != nil {
-		r.logger.Error("Failed to list parents", zap.Error(err))
+		r.logger.Error("Failed to list children by parent", zap.Error(err), zap.String("parentId", parentID))
 		span.RecordError(err)
-		return nil, fmt.Errorf("failed to list parents: %w", err)
+		return nil, fmt.Errorf("failed to list children by parent: %w", err)
 	}
 
 	// Create connection
-	connection := &ParentConnection{
-		Edges:      make([]ParentEdge, len(parents)),
+	connection := &ChildConnection{
+		Edges:      make([]ChildEdge, len(children)),
 		PageInfo:   &PageInfo{},
 		TotalCount: int(pagedResult.TotalCount),
 	}
 
-	// Create edges
-	for i, parent := range parents {
-		connection.Edges[i] = ParentEdge{
-			Node:   parent,
-			Cursor: encodeCursor(parent.ID.String()),
-		}
+	// Create edges
+	for i, child := range children {
+		connection.Edges[i] = ChildEdge{
+			Node:   child,
+			Cursor: childCursor(child, sortOptions.Field),
+		}
+	}
+
+	// Set page info
+	connection.PageInfo.HasNextPage = pagedResult.HasNext
+	connection.PageInfo.HasPreviousPage = pagedResult.HasPrevious || pagedResult.Page > 0
+	if len(children) > 0 {
+		startCursor := childCursor(children[0], sortOptions.Field)
+		endCursor := childCursor(children[len(children)-1], sortOptions.Field)
+		connection.PageInfo.StartCursor = &startCursor
+		connection.PageInfo.EndCursor = &endCursor
+	}
+
+	return connection, nil
+}
+
+// MyPermissions is the resolver for the myPermissions field.
+func (r *queryResolver) MyPermissions(ctx context.Context) ([]string, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to MyPermissions query")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Query.MyPermissions")
+	defer span.End()
+
+	// Every caller may ask for their own permissions, so no authorization check is needed
+	permissions, err := r.authService.GetPermissions(ctx)
+	if err != nil {
+		r.logger.Error("Failed to get permissions", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get permissions: %w", err)
+	}
+
+	// Add success attribute to the span
+	span.SetAttributes(attribute.String("result", "success"))
+
+	return permissions, nil
+}
+
+// WebhookSubscriptions is the resolver for the webhookSubscriptions field.
+func (r *queryResolver) WebhookSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to WebhookSubscriptions query")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Query.WebhookSubscriptions")
+	defer span.End()
+
+	// Create a timeout for this operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Check authorization
+	authorized, err := r.authService.IsAuthorized(ctx, "webhook:list")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "list webhook subscriptions")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	webhooks, err := r.webhookService()
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// Get the subscriptions
+	subscriptions, err := webhooks.GetWebhookSubscriptions(ctx)
+	if err != nil {
+		r.logger.Error("Failed to get webhook subscriptions", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+
+	// Add result attributes to the span
+	span.SetAttributes(
+		attribute.Int("result.count", len(subscriptions)),
+		attribute.String("result", "success"),
+	)
+
+	return subscriptions, nil
+}
+
+// WebhookDeliveries is the resolver for the webhookDeliveries field.
+func (r *queryResolver) WebhookDeliveries(ctx context.Context, subscriptionID *string, status *WebhookDeliveryStatus, limit *int) ([]*domain.WebhookDelivery, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to WebhookDeliveries query")
+	}
+
+	// Create a span for this operation
+	ctx, span := r.tracer.Start(ctx, "Query.WebhookDeliveries")
+	defer span.End()
+
+	// Create a timeout for this operation
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Check authorization
+	authorized, err := r.authService.IsAuthorized(ctx, "webhook:list-deliveries")
+	if err != nil {
+		r.logger.Error("Failed to check authorization", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to check authorization: %w", err)
+	}
+	if !authorized {
+		err := r.notAuthorized(ctx, "list webhook deliveries")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	webhooks, err := r.webhookService()
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Convert the arguments to a filter
+	filter := ports.WebhookDeliveryFilter{}
+	if subscriptionID != nil {
+		id, err := uuid.Parse(*subscriptionID)
+		if err != nil {
+			r.logger.Error("Invalid webhook subscription ID", zap.Error(err), zap.String("id", *subscriptionID))
+			span.RecordError(err)
+			return nil, fmt.Errorf("invalid webhook subscription ID: %w", domain.NewValidationError("WebhookDelivery", "subscriptionId", "must be a valid UUID"))
+		}
+		filter.SubscriptionID = &id
+		span.SetAttributes(attribute.String("webhook.id", id.String()))
+	}
+	if status != nil {
+		deliveryStatus := domain.DeliveryStatus(*status)
+		filter.Status = &deliveryStatus
+	}
+	if limit != nil {
+		filter.Limit = *limit
+	}
+
+	// Check for context cancellation before proceeding
+	select {
+	case <-ctx.Done():
+		err := ctx.Err()
+		r.logger.Error("Context cancelled or timed out", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("operation cancelled or timed out: %w", err)
+	default:
+		// Continue with the operation
+	}
+
+	// Get the deliveries
+	deliveries, err := webhooks.GetWebhookDeliveries(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to get webhook deliveries", zap.Error(err))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
 	}
 
-	// Set page info
-	connection.PageInfo.HasNextPage = pagedResult.HasNext
-	connection.PageInfo.HasPreviousPage = pagedResult.Page > 0
-	if len(parents) > 0 {
-		startCursor := encodeCursor(parents[0].ID.String())
-		endCursor := encodeCursor(parents[len(parents)-1].ID.String())
-		connection.PageInfo.StartCursor = &startCursor
-		connection.PageInfo.EndCursor = &endCursor
-	}
+	// Add result attributes to the span
+	span.SetAttributes(
+		attribute.Int("result.count", len(deliveries)),
+		attribute.String("result", "success"),
+	)
 
-	return connection, nil
+	return deliveries, nil
 }
 
-// Child is the resolver for the child field.
-func (r *queryResolver) Child(ctx context.Context, id string) (*domain.Child, error) {
+// AuditLog is the resolver for the auditLog field.
+func (r *queryResolver) AuditLog(ctx context.Context, entityID string, from *string, to *string) ([]*domain.AuditRecord, error) {
 	// Validate context
 	if ctx == nil {
-		return nil, fmt.Errorf("nil context provided to Child query")
+		return nil, fmt.Errorf("nil context provided to AuditLog query")
 	}
 
 	// Create a span for this operation
-	ctx, span := r.tracer.Start(ctx, "Query.Child")
+	ctx, span := r.tracer.Start(ctx, "Query.AuditLog")
 	defer span.End()
 
-	// Add operation attributes to the span
-	span.SetAttributes(attribute.String("child.id", id))
+	// Add attributes to the span
+	span.SetAttributes(attribute.String("entity.id", entityID))
 
 	// Create a timeout for this operation
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Check authorization
-	authorized, err := r.authService.IsAuthorized(ctx, "child:read")
+	authorized, err := r.authService.IsAuthorized(ctx, "audit:read")
 	if err != nil {
 		r.logger.Error("Failed to check authorization", zap.Error(err))
 		span.RecordError(err)
 		return nil, fmt.Errorf("failed to check authorization: %w", err)
 	}
 	if !authorized {
-		err := fmt.Errorf("not authorized to read child")
+		err := r.notAuthorized(ctx, "read the audit log")
 		span.RecordError(err)
 		return nil, err
 	}
 
-	// Convert ID string to UUID
-	childID, err := uuid.Parse(id)
+	audit, err := r.auditService()
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Parse the arguments
+	id, err := uuid.Parse(entityID)
+	if err != nil {
+		r.logger.Error("Invalid entity ID", zap.Error(err), zap.String("id", entityID))
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid entity ID: %w", domain.NewValidationError("AuditRecord", "entityId", "must be a valid UUID"))
+	}
+	fromTime, err := parseOptionalTime(from)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid from time: %w", domain.NewValidationError("AuditRecord", "from", "invalid format, expected RFC3339"))
+	}
+	toTime, err := parseOptionalTime(to)
 	if err != nil {
-		r.logger.Error("Invalid child ID", zap.Error(err), zap.String("id", id))
 		span.RecordError(err)
-		return nil, fmt.Errorf("invalid child ID: %w", err)
+		return nil, fmt.Errorf("invalid to time: %w", domain.NewValidationError("AuditRecord", "to", "invalid format, expected RFC3339"))
 	}
 
 	// Check for context cancellation before proceeding
@@ -925,48 +3212,63 @@ func (r *queryResolver) Child(ctx context.Context, id string) (*domain.Child, er
 		// Continue with the operation
 	}
 
-	// Get child by ID
-	child, err := r.familyService.GetChildByID(ctx, childID)
+	// Get the audit records
+	records, err := audit.GetAuditLog(ctx, id, fromTime, toTime)
 	if err != nil {
-		r.logger.Error("Failed to get child", zap.Error(err), zap.String("id", id))
+		r.logger.Error("Failed to get audit log", zap.Error(err), zap.String("entity_id", entityID))
 		span.RecordError(err)
-		return nil, fmt.Errorf("failed to get child: %w", err)
+		return nil, fmt.Errorf("failed to get audit log: %w", err)
 	}
 
-	// Add success attribute to the span
-	span.SetAttributes(attribute.String("result", "success"))
+	// Add result attributes to the span
+	span.SetAttributes(
+		attribute.Int("result.count", len(records)),
+		attribute.String("result", "success"),
+	)
 
-	return child, nil
+	return records, nil
 }
 
-// Children is the resolver for the children field.
-func (r *queryResolver) Children(ctx context.Context, filter *ChildFilter, pagination *PaginationInput, sort *SortInput) (*ChildConnection, error) {
+// History is the resolver for the history field.
+func (r *queryResolver) History(ctx context.Context, id string) ([]*domain.Revision, error) {
 	// Validate context
 	if ctx == nil {
-		return nil, fmt.Errorf("nil context provided to Children query")
+		return nil, fmt.Errorf("nil context provided to History query")
 	}
 
 	// Create a span for this operation
-	ctx, span := r.tracer.Start(ctx, "Query.Children")
+	ctx, span := r.tracer.Start(ctx, "Query.History")
 	defer span.End()
 
+	// Add attributes to the span
+	span.SetAttributes(attribute.String("entity.id", id))
+
 	// Create a timeout for this operation
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	// Check authorization
-	authorized, err := r.authService.IsAuthorized(ctx, "child:list")
+	// Check authorization; the history of parents and children is read with the parents,
+	// and a caller allowed only their own family gets a restricted scope
+	ctx, authorized, err := r.authorizeFamily(ctx, "parent:read")
 	if err != nil {
 		r.logger.Error("Failed to check authorization", zap.Error(err))
 		span.RecordError(err)
 		return nil, fmt.Errorf("failed to check authorization: %w", err)
 	}
 	if !authorized {
-		err := fmt.Errorf("not authorized to list children")
+		err := r.notAuthorized(ctx, "read history")
 		span.RecordError(err)
 		return nil, err
 	}
 
+	// Convert ID string to UUID
+	entityID, err := uuid.Parse(id)
+	if err != nil {
+		r.logger.Error("Invalid entity ID", zap.Error(err), zap.String("id", id))
+		span.RecordError(err)
+		return nil, fmt.Errorf("invalid entity ID: %w", domain.NewValidationError("Revision", "id", "must be a valid UUID"))
+	}
+
 	// Check for context cancellation before proceeding
 	select {
 	case <-ctx.Done():
@@ -978,131 +3280,60 @@ func (r *queryResolver) Children(ctx context.Context, filter *ChildFilter, pagin
 		// Continue with the operation
 	}
 
-	// Convert GraphQL filter to domain filter
-	filterOptions := ports.FilterOptions{}
-	if filter != nil {
-		if filter.FirstName != nil {
-			filterOptions.FirstName = *filter.FirstName
-		}
-		if filter.LastName != nil {
-			filterOptions.LastName = *filter.LastName
-		}
-		if filter.MinAge != nil {
-			filterOptions.MinAge = *filter.MinAge
-		}
-		if filter.MaxAge != nil {
-			filterOptions.MaxAge = *filter.MaxAge
-		}
-	}
-
-	// Convert GraphQL pagination to domain pagination
-	paginationOptions := ports.PaginationOptions{
-		Page:     0,
-		PageSize: 10,
-	}
-	if pagination != nil {
-		if pagination.Page != nil {
-			paginationOptions.Page = *pagination.Page
-		}
-		if pagination.PageSize != nil {
-			paginationOptions.PageSize = *pagination.PageSize
-		}
-	}
-
-	// Convert GraphQL sort to domain sort
-	sortOptions := ports.SortOptions{
-		Field:     "createdAt",
-		Direction: "desc",
-	}
-	if sort != nil {
-		if sort.Field != nil {
-			sortOptions.Field = *sort.Field
-		}
-		if sort.Direction != nil {
-			sortOptions.Direction = strings.ToLower(string(*sort.Direction))
-		}
-	}
-
-	// Create query options
-	queryOptions := ports.QueryOptions{
-		Filter:     filterOptions,
-		Pagination: paginationOptions,
-		Sort:       sortOptions,
-	}
-
-	// List children
-	children, pagedResult, err := r.familyService.ListChildren(ctx, queryOptions)
+	// Get the revisions
+	revisions, err := r.familyService.GetHistory(ctx, entityID)
 	if err != nil {
-		r.logger.Error("Failed to list children", zap.Error(err))
+		r.logger.Error("Failed to get history", zap.Error(err), zap.String("id", id))
 		span.RecordError(err)
-		return nil, fmt.Errorf("failed to list children: %w", err)
-	}
-
-	// Create connection
-	connection := &ChildConnection{
-		Edges:      make([]ChildEdge, len(children)),
-		PageInfo:   &PageInfo{},
-		TotalCount: int(pagedResult.TotalCount),
-	}
-
-	// Create edges
-	for i, child := range children {
-		connection.Edges[i] = ChildEdge{
-			Node:   child,
-			Cursor: encodeCursor(child.ID.String()),
-		}
+		return nil, fmt.Errorf("failed to get history: %w", err)
 	}
 
-	// Set page info
-	connection.PageInfo.HasNextPage = pagedResult.HasNext
-	connection.PageInfo.HasPreviousPage = pagedResult.Page > 0
-	if len(children) > 0 {
-		startCursor := encodeCursor(children[0].ID.String())
-		endCursor := encodeCursor(children[len(children)-1].ID.String())
-		connection.PageInfo.StartCursor = &startCursor
-		connection.PageInfo.EndCursor = &endCursor
-	}
+	// Add result attributes to the span
+	span.SetAttributes(
+		attribute.Int("result.count", len(revisions)),
+		attribute.String("result", "success"),
+	)
 
-	return connection, nil
+	return revisions, nil
 }
 
-// ChildrenByParent is the resolver for the childrenByParent field.
-func (r *queryResolver) ChildrenByParent(ctx context.Context, parentID string, filter *ChildFilter, pagination *PaginationInput, sort *SortInput) (*ChildConnection, error) {
+// Household is the resolver for the household field.
+func (r *queryResolver) Household(ctx context.Context, id string) (*domain.Household, error) {
 	// Validate context
 	if ctx == nil {
-		return nil, fmt.Errorf("nil context provided to ChildrenByParent query")
+		return nil, fmt.Errorf("nil context provided to Household query")
 	}
 
 	// Create a span for this operation
-	ctx, span := r.tracer.Start(ctx, "Query.ChildrenByParent")
+	ctx, span := r.tracer.Start(ctx, "Query.Household")
 	defer span.End()
 
 	// Add operation attributes to the span
-	span.SetAttributes(attribute.String("parent.id", parentID))
+	span.SetAttributes(attribute.String("household.id", id))
 
 	// Create a timeout for this operation
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	// Check authorization
-	authorized, err := r.authService.IsAuthorized(ctx, "child:list")
+	// Check authorization; a caller allowed only their own family gets a restricted scope
+	ctx, authorized, err := r.authorizeFamily(ctx, "household:read")
 	if err != nil {
 		r.logger.Error("Failed to check authorization", zap.Error(err))
 		span.RecordError(err)
 		return nil, fmt.Errorf("failed to check authorization: %w", err)
 	}
 	if !authorized {
-		err := fmt.Errorf("not authorized to list children")
+		err := r.notAuthorized(ctx, "read household")
 		span.RecordError(err)
 		return nil, err
 	}
 
-	// Convert parent ID string to UUID
-	parentUUID, err := uuid.Parse(parentID)
+	// Convert ID string to UUID
+	householdID, err := uuid.Parse(id)
 	if err != nil {
-		r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("parentId", parentID))
+		r.logger.Error("Invalid household ID", zap.Error(err), zap.String("id", id))
 		span.RecordError(err)
-		return nil, fmt.Errorf("invalid parent ID: %w", err)
+		return nil, fmt.Errorf("invalid household ID: %w", domain.NewValidationError("Household", "id", "must be a valid UUID"))
 	}
 
 	// Check for context cancellation before proceeding
@@ -1116,100 +3347,161 @@ func (r *queryResolver) ChildrenByParent(ctx context.Context, parentID string, f
 		// Continue with the operation
 	}
 
-	// Convert GraphQL filter to domain filter
-	filterOptions := ports.FilterOptions{}
-	if filter != nil {
-		if filter.FirstName != nil {
-			filterOptions.FirstName = *filter.FirstName
-		}
-		if filter.LastName != nil {
-			filterOptions.LastName = *filter.LastName
-		}
-		if filter.MinAge != nil {
-			filterOptions.MinAge = *filter.MinAge
-		}
-		if filter.MaxAge != nil {
-			filterOptions.MaxAge = *filter.MaxAge
-		}
+	// Get household by ID
+	household, err := r.familyService.GetHouseholdByID(ctx, householdID)
+	if err != nil {
+		r.logger.Error("Failed to get household", zap.Error(err), zap.String("id", id))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get household: %w", err)
 	}
 
-	// Convert GraphQL pagination to domain pagination
-	paginationOptions := ports.PaginationOptions{
-		Page:     0,
-		PageSize: 10,
-	}
-	if pagination != nil {
-		if pagination.Page != nil {
-			paginationOptions.Page = *pagination.Page
-		}
-		if pagination.PageSize != nil {
-			paginationOptions.PageSize = *pagination.PageSize
-		}
-	}
+	// Add success attribute to the span
+	span.SetAttributes(attribute.String("result", "success"))
 
-	// Convert GraphQL sort to domain sort
-	sortOptions := ports.SortOptions{
-		Field:     "createdAt",
-		Direction: "desc",
+	return household, nil
+}
+
+// Deleted is the resolver for the deleted field.
+func (r *revisionResolver) Deleted(ctx context.Context, obj *domain.Revision) (bool, error) {
+	if obj.Parent != nil {
+		return obj.Parent.IsDeleted(), nil
 	}
-	if sort != nil {
-		if sort.Field != nil {
-			sortOptions.Field = *sort.Field
-		}
-		if sort.Direction != nil {
-			sortOptions.Direction = strings.ToLower(string(*sort.Direction))
-		}
+	return obj.Child.IsDeleted(), nil
+}
+
+// ParentChanged is the resolver for the parentChanged field.
+func (r *subscriptionResolver) ParentChanged(ctx context.Context) (<-chan *domain.Event, error) {
+	return r.subscribe(ctx, "ParentChanged", []string{"parent:read"}, domain.Event.IsParentEvent)
+}
+
+// ChildChanged is the resolver for the childChanged field.
+func (r *subscriptionResolver) ChildChanged(ctx context.Context) (<-chan *domain.Event, error) {
+	return r.subscribe(ctx, "ChildChanged", []string{"child:read"}, domain.Event.IsChildEvent)
+}
+
+// FamilyChanged is the resolver for the familyChanged field.
+func (r *subscriptionResolver) FamilyChanged(ctx context.Context, parentID string) (<-chan *domain.Event, error) {
+	// Convert ID string to UUID
+	id, err := uuid.Parse(parentID)
+	if err != nil {
+		r.logger.Error("Invalid parent ID", zap.Error(err), zap.String("id", parentID))
+		return nil, fmt.Errorf("invalid parent ID: %w", domain.NewValidationError("Parent", "parentId", "must be a valid UUID"))
 	}
 
-	// Create query options
-	queryOptions := ports.QueryOptions{
-		Filter:     filterOptions,
-		Pagination: paginationOptions,
-		Sort:       sortOptions,
+	return r.subscribe(ctx, "FamilyChanged", []string{"parent:read", "child:read"}, func(event domain.Event) bool {
+		return event.BelongsToFamily(id)
+	})
+}
+
+// ID is the resolver for the id field.
+func (r *webhookDeliveryResolver) ID(ctx context.Context, obj *domain.WebhookDelivery) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// SubscriptionID is the resolver for the subscriptionId field.
+func (r *webhookDeliveryResolver) SubscriptionID(ctx context.Context, obj *domain.WebhookDelivery) (string, error) {
+	return obj.SubscriptionID.String(), nil
+}
+
+// EventID is the resolver for the eventId field.
+func (r *webhookDeliveryResolver) EventID(ctx context.Context, obj *domain.WebhookDelivery) (string, error) {
+	return obj.EventID.String(), nil
+}
+
+// EventType is the resolver for the eventType field.
+func (r *webhookDeliveryResolver) EventType(ctx context.Context, obj *domain.WebhookDelivery) (ChangeType, error) {
+	return ChangeType(obj.EventType), nil
+}
+
+// Status is the resolver for the status field.
+func (r *webhookDeliveryResolver) Status(ctx context.Context, obj *domain.WebhookDelivery) (WebhookDeliveryStatus, error) {
+	return WebhookDeliveryStatus(obj.Status), nil
+}
+
+// NextAttemptAt is the resolver for the nextAttemptAt field.
+func (r *webhookDeliveryResolver) NextAttemptAt(ctx context.Context, obj *domain.WebhookDelivery) (*string, error) {
+	// Only a pending delivery will be attempted again
+	if obj.Status != domain.DeliveryPending {
+		return nil, nil
 	}
 
-	// List children by parent ID
-	children, pagedResult, err := r.familyService.ListChildrenByParentID(ctx, parentUUID, queryOptions)
-	if err != nil {
-		r.logger.Error("Failed to list children by parent", zap.Error(err), zap.String("parentId", parentID))
-		span.RecordError(err)
-		return nil, fmt.Errorf("failed to list children by parent: %w", err)
+	nextAttemptAt := obj.NextAttemptAt.Format(time.RFC3339)
+	return &nextAttemptAt, nil
+}
+
+// LastError is the resolver for the lastError field.
+func (r *webhookDeliveryResolver) LastError(ctx context.Context, obj *domain.WebhookDelivery) (*string, error) {
+	if obj.LastError == "" {
+		return nil, nil
 	}
 
-	// Create connection
-	connection := &ChildConnection{
-		Edges:      make([]ChildEdge, len(children)),
-		PageInfo:   &PageInfo{},
-		TotalCount: int(pagedResult.TotalCount),
+	return &obj.LastError, nil
+}
+
+// ResponseStatus is the resolver for the responseStatus field.
+func (r *webhookDeliveryResolver) ResponseStatus(ctx context.Context, obj *domain.WebhookDelivery) (*int, error) {
+	if obj.ResponseStatus == 0 {
+		return nil, nil
 	}
 
-	// Create edges
-	for i, child := range children {
-		connection.Edges[i] = ChildEdge{
-			Node:   child,
-			Cursor: encodeCursor(child.ID.String()),
-		}
+	return &obj.ResponseStatus, nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *webhookDeliveryResolver) CreatedAt(ctx context.Context, obj *domain.WebhookDelivery) (string, error) {
+	return obj.CreatedAt.Format(time.RFC3339), nil
+}
+
+// DeliveredAt is the resolver for the deliveredAt field.
+func (r *webhookDeliveryResolver) DeliveredAt(ctx context.Context, obj *domain.WebhookDelivery) (*string, error) {
+	if obj.DeliveredAt == nil {
+		return nil, nil
 	}
 
-	// Set page info
-	connection.PageInfo.HasNextPage = pagedResult.HasNext
-	connection.PageInfo.HasPreviousPage = pagedResult.Page > 0
-	if len(children) > 0 {
-		startCursor := encodeCursor(children[0].ID.String())
-		endCursor := encodeCursor(children[len(children)-1].ID.String())
-		connection.PageInfo.StartCursor = &startCursor
-		connection.PageInfo.EndCursor = &endCursor
+	deliveredAt := obj.DeliveredAt.Format(time.RFC3339)
+	return &deliveredAt, nil
+}
+
+// ID is the resolver for the id field.
+func (r *webhookSubscriptionResolver) ID(ctx context.Context, obj *domain.WebhookSubscription) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// EventTypes is the resolver for the eventTypes field.
+func (r *webhookSubscriptionResolver) EventTypes(ctx context.Context, obj *domain.WebhookSubscription) ([]ChangeType, error) {
+	changeTypes := make([]ChangeType, 0, len(obj.EventTypes))
+	for _, eventType := range obj.EventTypes {
+		changeTypes = append(changeTypes, ChangeType(eventType))
 	}
+	return changeTypes, nil
+}
 
-	return connection, nil
+// CreatedAt is the resolver for the createdAt field.
+func (r *webhookSubscriptionResolver) CreatedAt(ctx context.Context, obj *domain.WebhookSubscription) (string, error) {
+	return obj.CreatedAt.Format(time.RFC3339), nil
 }
 
+// AuditRecord returns AuditRecordResolver implementation.
+func (r *Resolver) AuditRecord() AuditRecordResolver { return &auditRecordResolver{r} }
+
+// ChangeEvent returns ChangeEventResolver implementation.
+func (r *Resolver) ChangeEvent() ChangeEventResolver { return &changeEventResolver{r} }
+
 // Child returns ChildResolver implementation.
 func (r *Resolver) Child() ChildResolver { return &childResolver{r} }
 
 // ChildConnection returns ChildConnectionResolver implementation.
 func (r *Resolver) ChildConnection() ChildConnectionResolver { return &childConnectionResolver{r} }
 
+// FieldChange returns FieldChangeResolver implementation.
+func (r *Resolver) FieldChange() FieldChangeResolver { return &fieldChangeResolver{r} }
+
+// Guardianship returns GuardianshipResolver implementation.
+func (r *Resolver) Guardianship() GuardianshipResolver { return &guardianshipResolver{r} }
+
+// Household returns HouseholdResolver implementation.
+func (r *Resolver) Household() HouseholdResolver { return &householdResolver{r} }
+
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
@@ -1219,30 +3511,39 @@ func (r *Resolver) Parent() ParentResolver { return &parentResolver{r} }
 // ParentConnection returns ParentConnectionResolver implementation.
 func (r *Resolver) ParentConnection() ParentConnectionResolver { return &parentConnectionResolver{r} }
 
+// Phone returns PhoneResolver implementation.
+func (r *Resolver) Phone() PhoneResolver { return &phoneResolver{r} }
+
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
+// Revision returns RevisionResolver implementation.
+func (r *Resolver) Revision() RevisionResolver { return &revisionResolver{r} }
+
+// Subscription returns SubscriptionResolver implementation.
+func (r *Resolver) Subscription() SubscriptionResolver { return &subscriptionResolver{r} }
+
+// WebhookDelivery returns WebhookDeliveryResolver implementation.
+func (r *Resolver) WebhookDelivery() WebhookDeliveryResolver { return &webhookDeliveryResolver{r} }
+
+// WebhookSubscription returns WebhookSubscriptionResolver implementation.
+func (r *Resolver) WebhookSubscription() WebhookSubscriptionResolver {
+	return &webhookSubscriptionResolver{r}
+}
+
+type auditRecordResolver struct{ *Resolver }
+type changeEventResolver struct{ *Resolver }
 type childResolver struct{ *Resolver }
 type childConnectionResolver struct{ *Resolver }
+type fieldChangeResolver struct{ *Resolver }
+type guardianshipResolver struct{ *Resolver }
+type householdResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type parentResolver struct{ *Resolver }
 type parentConnectionResolver struct{ *Resolver }
+type phoneResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
-
-// !!! WARNING !!!
-// The code below was going to be deleted when updating resolvers. It has been copied here so you have
-// one last chance to move it out of harms way if you want. There are two reasons this happens:
-//  - When renaming or deleting a resolver the old code will be put in here. You can safely delete
-//    it when you're done.
-//  - You have helper methods in this file. Move them out to keep these resolver files clean.
-
-func encodeCursor(s string) string {
-	return base64.StdEncoding.EncodeToString([]byte(s))
-}
-func decodeCursor(s string) (string, error) {
-	b, err := base64.StdEncoding.DecodeString(s)
-	if err != nil {
-		return "", err
-	}
-	return string(b), nil
-}
+type revisionResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
+type webhookDeliveryResolver struct{ *Resolver }
+type webhookSubscriptionResolver struct{ *Resolver }
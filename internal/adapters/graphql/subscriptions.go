@@ -0,0 +1,93 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// subscribe authorizes the caller for every operation, subscribes to the event
+// broker, and returns a channel of the events of the caller's tenant accepted by match.
+// A caller who only holds the ":own" permission of an operation only receives the events
+// of their own family, as the family service's guardianships define it when the event arrives.
+// The returned channel is closed when ctx is done or the broker subscription ends.
+func (r *Resolver) subscribe(ctx context.Context, name string, operations []string, match func(domain.Event) bool) (<-chan *domain.Event, error) {
+	// Validate context
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context provided to %s subscription", name)
+	}
+
+	// Create a span for setting up the subscription; events are delivered after it ends
+	ctx, span := r.tracer.Start(ctx, "Subscription."+name)
+	defer span.End()
+
+	if r.events == nil {
+		err := fmt.Errorf("subscriptions are not available")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Check authorization; the events are restricted to the caller's family when any operation is
+	scopedCtx := ctx
+	for _, operation := range operations {
+		operationCtx, authorized, err := r.authorizeFamily(ctx, operation)
+		if err != nil {
+			r.logger.Error("Failed to check authorization", zap.Error(err))
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to check authorization: %w", err)
+		}
+		if !authorized {
+			err := r.notAuthorized(ctx, "subscribe to "+name)
+			span.RecordError(err)
+			return nil, err
+		}
+		if ports.AccessScopeFromContext(operationCtx).Restricted {
+			scopedCtx = operationCtx
+		}
+	}
+	restricted := ports.AccessScopeFromContext(scopedCtx).Restricted
+
+	source, err := r.events.Subscribe(ctx)
+	if err != nil {
+		r.logger.Error("Failed to subscribe to events", zap.Error(err), zap.String("subscription", name))
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to subscribe to events: %w", err)
+	}
+
+	tenantID := ports.TenantIDFromContext(ctx)
+
+	events := make(chan *domain.Event, 1)
+	go func() {
+		defer close(events)
+
+		for event := range source {
+			if event.TenantID != tenantID || !match(event) {
+				continue
+			}
+			if restricted {
+				inFamily, err := r.familyService.IsEventInFamily(scopedCtx, event)
+				if err != nil {
+					r.logger.Error("Failed to check the family of an event", zap.Error(err), zap.String("subscription", name), zap.String("event_id", event.ID.String()))
+				}
+				if err != nil || !inFamily {
+					continue
+				}
+			}
+
+			select {
+			case events <- &event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Add success attribute to the span
+	span.SetAttributes(attribute.String("result", "success"))
+
+	return events, nil
+}
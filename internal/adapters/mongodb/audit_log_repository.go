@@ -0,0 +1,118 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// AuditLogRepository implements the ports.AuditLogRepository interface for MongoDB.
+// Records are stored in the audit_log collection, which the repository only ever inserts into.
+type AuditLogRepository struct {
+	collection *mongo.Collection // MongoDB collection for audit records
+	logger     *zap.Logger       // Logger for recording repository operations
+	tracer     trace.Tracer      // Tracer for distributed tracing
+}
+
+// NewAuditLogRepository creates a new MongoDB audit log repository.
+// Parameters:
+//   - db: The MongoDB database connection
+//   - logger: Logger for recording repository operations
+//
+// Returns:
+//   - *AuditLogRepository: A new instance of the audit log repository
+func NewAuditLogRepository(db *mongo.Database, logger *zap.Logger) *AuditLogRepository {
+	return &AuditLogRepository{
+		collection: db.Collection("audit_log"),
+		logger:     logger,
+		tracer:     otel.Tracer("mongodb.audit_log_repository"),
+	}
+}
+
+// Append stores a new record of the caller's tenant within the transaction carried by ctx, if any.
+// Parameters:
+//   - ctx: The context for the operation, carrying the transaction's session and the caller's tenant
+//   - record: The record to store
+//
+// Returns:
+//   - error: An error if the record could not be stored
+func (r *AuditLogRepository) Append(ctx context.Context, record *domain.AuditRecord) error {
+	ctx, span := r.tracer.Start(ctx, "AuditLogRepository.Append")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("audit.operation", record.Operation),
+		attribute.String("audit.entity_id", record.EntityID.String()),
+	)
+
+	record.TenantID = ports.TenantIDFromContext(ctx)
+
+	_, err := r.collection.InsertOne(ctx, record)
+	if err != nil {
+		r.logger.Error("Failed to append audit record", zap.Error(err), zap.String("entity_id", record.EntityID.String()))
+		return fmt.Errorf("audit_record.append.failed: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves the records of the caller's tenant selected by the filter, oldest first.
+// Parameters:
+//   - ctx: The context for the operation, holding the caller's tenant
+//   - filter: Selects the records by entity and time, and limits their number
+//
+// Returns:
+//   - []*domain.AuditRecord: The records
+//   - error: An error if the records could not be retrieved
+func (r *AuditLogRepository) List(ctx context.Context, filter ports.AuditLogFilter) ([]*domain.AuditRecord, error) {
+	ctx, span := r.tracer.Start(ctx, "AuditLogRepository.List")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("audit.entity_id", filter.EntityID.String()))
+
+	query := withTenant(ctx, bson.M{"entityId": filter.EntityID})
+	occurredAt := bson.M{}
+	if filter.From != nil {
+		occurredAt["$gte"] = filter.From.UTC()
+	}
+	if filter.To != nil {
+		occurredAt["$lte"] = filter.To.UTC()
+	}
+	if len(occurredAt) > 0 {
+		query["occurredAt"] = occurredAt
+	}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "occurredAt", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(filter.Limit))
+
+	cursor, err := r.collection.Find(ctx, query, findOptions)
+	if err != nil {
+		r.logger.Error("Failed to list audit records", zap.Error(err), zap.String("entity_id", filter.EntityID.String()))
+		return nil, fmt.Errorf("audit_record.list.failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	records := []*domain.AuditRecord{}
+	if err := cursor.All(ctx, &records); err != nil {
+		r.logger.Error("Failed to decode audit records", zap.Error(err))
+		return nil, fmt.Errorf("audit_record.decode.failed: %w", err)
+	}
+
+	for _, record := range records {
+		record.OccurredAt = record.OccurredAt.UTC()
+	}
+
+	return records, nil
+}
+
+// Ensure AuditLogRepository implements ports.AuditLogRepository
+var _ ports.AuditLogRepository = (*AuditLogRepository)(nil)
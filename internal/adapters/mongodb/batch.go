@@ -0,0 +1,32 @@
+package mongodb
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// firstWriteError returns the error of the first document an InsertMany failed to write, whose Index is the
+// position of the document. An ordered InsertMany stops at that document; within a transaction, the documents
+// before it are rolled back with the transaction.
+//
+// Parameters:
+//   - err: The error of the InsertMany
+//
+// Returns:
+//   - The error of the first document that failed
+//   - false if the error is not about a document, such as when the database cannot be reached
+func firstWriteError(err error) (mongo.WriteError, bool) {
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || len(bulkErr.WriteErrors) == 0 {
+		return mongo.WriteError{}, false
+	}
+
+	first := bulkErr.WriteErrors[0].WriteError
+	for _, writeErr := range bulkErr.WriteErrors[1:] {
+		if writeErr.Index < first.Index {
+			first = writeErr.WriteError
+		}
+	}
+	return first, true
+}
@@ -101,7 +101,8 @@ func NewChildRepository(ctx context.Context, db *mongo.Database, logger *zap.Log
 }
 
 // Create creates a new child in the database.
-// It first checks if the parent exists before creating the child to maintain referential integrity.
+// It first checks if the parent exists in the caller's tenant before creating the child to maintain
+// referential integrity, and creates the child in that tenant.
 // The method uses OpenTelemetry for tracing and logs relevant information during the operation.
 // Parameters:
 //   - ctx: The context for the operation, used for tracing and cancellation
@@ -118,14 +119,16 @@ func (r *ChildRepository) Create(ctx context.Context, child *domain.Child) error
 		attribute.String("parent.id", child.ParentID.String()),
 	)
 
+	child.TenantID = ports.TenantIDFromContext(ctx)
+
 	// First check if the parent exists
 	db := r.collection.Database()
 	parentsCollection := db.Collection("parents")
 
-	parentFilter := bson.M{
+	parentFilter := withTenant(ctx, bson.M{
 		"_id":        child.ParentID,
 		"deleted_at": nil,
-	}
+	})
 
 	var parentCount int64
 	parentCount, err := parentsCollection.CountDocuments(ctx, parentFilter)
@@ -136,6 +139,7 @@ func (r *ChildRepository) Create(ctx context.Context, child *domain.Child) error
 
 	if parentCount == 0 {
 		r.logger.Debug("Parent not found for child creation", zap.String("parent_id", child.ParentID.String()))
+		reportCrossTenantAccess(ctx, parentsCollection, r.logger, "Parent", child.ParentID)
 		return errors.New("child.parent.notFound")
 	}
 
@@ -145,11 +149,94 @@ func (r *ChildRepository) Create(ctx context.Context, child *domain.Child) error
 		return fmt.Errorf("child.create.failed: %w", err)
 	}
 
+	if err := recordHistory(ctx, r.collection, childHistoryCollection, withTenant(ctx, bson.M{"_id": child.ID})); err != nil {
+		r.logger.Error("Failed to record child history", zap.Error(err), zap.String("child_id", child.ID.String()))
+		return err
+	}
+
+	return nil
+}
+
+// CreateMany creates many children in the database with a single ordered InsertMany, in the caller's tenant.
+// It first checks that the parents of the children exist in that tenant, to maintain referential integrity.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - children: The child entities to create in the database
+//
+// Returns:
+//   - error: A domain.BatchError holding the position and the error of the child that could not be created,
+//     such as a domain.NotFoundError when its parent doesn't exist, or an error if there's a database error
+func (r *ChildRepository) CreateMany(ctx context.Context, children []*domain.Child) error {
+	ctx, span := r.tracer.Start(ctx, "ChildRepository.CreateMany")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("child.count", len(children)))
+
+	tenantID := ports.TenantIDFromContext(ctx)
+	documents := make([]interface{}, 0, len(children))
+	ids := make([]uuid.UUID, 0, len(children))
+	parentIDs := make([]uuid.UUID, 0, len(children))
+	for _, child := range children {
+		child.TenantID = tenantID
+		documents = append(documents, child)
+		ids = append(ids, child.ID)
+		parentIDs = append(parentIDs, child.ParentID)
+	}
+
+	// First check that the parents exist
+	parentsCollection := r.collection.Database().Collection("parents")
+	parentFilter := withTenant(ctx, bson.M{
+		"_id":        bson.M{"$in": parentIDs},
+		"deleted_at": nil,
+	})
+
+	cursor, err := parentsCollection.Find(ctx, parentFilter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		r.logger.Error("Failed to check parent existence", zap.Error(err), zap.Int("count", len(parentIDs)))
+		return fmt.Errorf("child.parent.check.failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var existing []struct {
+		ID uuid.UUID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &existing); err != nil {
+		r.logger.Error("Failed to decode parent IDs", zap.Error(err))
+		return fmt.Errorf("child.parent.check.failed: %w", err)
+	}
+
+	found := make(map[uuid.UUID]bool, len(existing))
+	for _, parent := range existing {
+		found[parent.ID] = true
+	}
+	for i, child := range children {
+		if !found[child.ParentID] {
+			r.logger.Debug("Parent not found for child creation", zap.String("parent_id", child.ParentID.String()))
+			reportCrossTenantAccess(ctx, parentsCollection, r.logger, "Parent", child.ParentID)
+			return domain.NewBatchError(i, domain.NewNotFoundError("Parent", child.ParentID.String()))
+		}
+	}
+
+	_, err = r.collection.InsertMany(ctx, documents)
+	if err != nil {
+		if writeErr, ok := firstWriteError(err); ok {
+			r.logger.Error("Failed to create child", zap.Error(writeErr), zap.String("child_id", children[writeErr.Index].ID.String()))
+			return fmt.Errorf("child.createMany.failed: %w", domain.NewBatchError(writeErr.Index, writeErr))
+		}
+		r.logger.Error("Failed to create children", zap.Error(err), zap.Int("count", len(children)))
+		return fmt.Errorf("child.createMany.failed: %w", err)
+	}
+
+	if err := recordHistory(ctx, r.collection, childHistoryCollection, withTenant(ctx, bson.M{"_id": bson.M{"$in": ids}})); err != nil {
+		r.logger.Error("Failed to record child history", zap.Error(err), zap.Int("count", len(children)))
+		return err
+	}
+
 	return nil
 }
 
 // GetByID retrieves a child by ID from the database.
-// It only returns children that are not marked as deleted (soft delete).
+// It only returns children of the caller's tenant that are not marked as deleted (soft delete).
 // The method uses OpenTelemetry for tracing and logs relevant information during the operation.
 // Parameters:
 //   - ctx: The context for the operation, used for tracing and cancellation
@@ -164,16 +251,17 @@ func (r *ChildRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ch
 
 	span.SetAttributes(attribute.String("child.id", id.String()))
 
-	filter := bson.M{
+	filter := withTenant(ctx, bson.M{
 		"_id":        id,
 		"deleted_at": nil,
-	}
+	})
 
 	var child domain.Child
 	err := r.collection.FindOne(ctx, filter).Decode(&child)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			r.logger.Debug("Child not found", zap.String("child_id", id.String()))
+			reportCrossTenantAccess(ctx, r.collection, r.logger, "Child", id)
 			return nil, fmt.Errorf("child not found")
 		}
 		r.logger.Error("Failed to get child", zap.Error(err), zap.String("child_id", id.String()))
@@ -184,15 +272,16 @@ func (r *ChildRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ch
 }
 
 // Update updates an existing child in the database.
-// It only updates children that are not marked as deleted (soft delete) and
-// automatically updates the UpdatedAt timestamp.
+// It only updates children of the caller's tenant that are not marked as deleted (soft delete) and are
+// still at the version of the given child, automatically updates the UpdatedAt timestamp, and increments the version.
 // The method uses OpenTelemetry for tracing and logs relevant information during the operation.
 // Parameters:
 //   - ctx: The context for the operation, used for tracing and cancellation
 //   - child: The child entity with updated information
 //
 // Returns:
-//   - error: An error if the child is not found or if there's a database error
+//   - error: A ConflictError if the child is at another version, or an error if the child is not found
+//     or if there's a database error
 func (r *ChildRepository) Update(ctx context.Context, child *domain.Child) error {
 	ctx, span := r.tracer.Start(ctx, "ChildRepository.Update")
 	defer span.End()
@@ -201,10 +290,11 @@ func (r *ChildRepository) Update(ctx context.Context, child *domain.Child) error
 
 	child.UpdatedAt = time.Now().UTC()
 
-	filter := bson.M{
+	filter := withTenant(ctx, bson.M{
 		"_id":        child.ID,
+		"version":    child.Version,
 		"deleted_at": nil,
-	}
+	})
 
 	update := bson.M{
 		"$set": bson.M{
@@ -213,6 +303,7 @@ func (r *ChildRepository) Update(ctx context.Context, child *domain.Child) error
 			"birthDate": child.BirthDate,
 			"updatedAt": child.UpdatedAt,
 		},
+		"$inc": bson.M{"version": 1},
 	}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
@@ -222,16 +313,29 @@ func (r *ChildRepository) Update(ctx context.Context, child *domain.Child) error
 	}
 
 	if result.MatchedCount == 0 {
+		if isVersionConflict(ctx, r.collection, child.ID) {
+			r.logger.Debug("Child version conflict", zap.String("child_id", child.ID.String()), zap.Int("version", child.Version))
+			return domain.NewConflictError("Child", child.ID.String(), child.Version)
+		}
 		r.logger.Debug("Child not found for update", zap.String("child_id", child.ID.String()))
+		reportCrossTenantAccess(ctx, r.collection, r.logger, "Child", child.ID)
 		return fmt.Errorf("child not found")
 	}
 
+	child.Version++
+
+	if err := recordHistory(ctx, r.collection, childHistoryCollection, withTenant(ctx, bson.M{"_id": child.ID})); err != nil {
+		r.logger.Error("Failed to record child history", zap.Error(err), zap.String("child_id", child.ID.String()))
+		return err
+	}
+
 	return nil
 }
 
 // Delete marks a child as deleted in the database.
 // This is a soft delete operation that sets the DeletedAt timestamp rather than
 // removing the document from the database. It also updates the UpdatedAt timestamp.
+// Only children of the caller's tenant are deleted.
 // The method uses OpenTelemetry for tracing and logs relevant information during the operation.
 // Parameters:
 //   - ctx: The context for the operation, used for tracing and cancellation
@@ -247,16 +351,17 @@ func (r *ChildRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 	now := time.Now().UTC()
 
-	filter := bson.M{
+	filter := withTenant(ctx, bson.M{
 		"_id":        id,
 		"deleted_at": nil,
-	}
+	})
 
 	update := bson.M{
 		"$set": bson.M{
 			"deleted_at": now,
 			"updatedAt":  now,
 		},
+		"$inc": bson.M{"version": 1},
 	}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
@@ -267,26 +372,131 @@ func (r *ChildRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 	if result.MatchedCount == 0 {
 		r.logger.Debug("Child not found for deletion", zap.String("child_id", id.String()))
+		reportCrossTenantAccess(ctx, r.collection, r.logger, "Child", id)
 		return fmt.Errorf("child not found")
 	}
 
+	if err := recordHistory(ctx, r.collection, childHistoryCollection, withTenant(ctx, bson.M{"_id": id})); err != nil {
+		r.logger.Error("Failed to record child history", zap.Error(err), zap.String("child_id", id.String()))
+		return err
+	}
+
+	return nil
+}
+
+// Restore unmarks a child that was marked as deleted in the database.
+// Only children of the caller's tenant are restored; the version of the child is incremented.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - id: The unique identifier of the child to restore
+//
+// Returns:
+//   - error: An error wrapping domain.ErrNotFound if no deleted child has the ID, or an error if there's a database error
+func (r *ChildRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "ChildRepository.Restore")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("child.id", id.String()))
+
+	filter := withTenant(ctx, bson.M{
+		"_id":        id,
+		"deleted_at": bson.M{"$ne": nil},
+	})
+
+	update := bson.M{
+		"$set": bson.M{
+			"deleted_at": nil,
+			"updatedAt":  time.Now().UTC(),
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to restore child", zap.Error(err), zap.String("child_id", id.String()))
+		return fmt.Errorf("child.restore.failed: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		r.logger.Debug("Child not found for restore", zap.String("child_id", id.String()))
+		reportCrossTenantAccess(ctx, r.collection, r.logger, "Child", id)
+		return fmt.Errorf("child not found for restore: %w", domain.ErrNotFound)
+	}
+
+	if err := recordHistory(ctx, r.collection, childHistoryCollection, withTenant(ctx, bson.M{"_id": id})); err != nil {
+		r.logger.Error("Failed to record child history", zap.Error(err), zap.String("child_id", id.String()))
+		return err
+	}
+
 	return nil
 }
 
+// Purge permanently removes the children of the caller's tenant that were marked as deleted before the given time.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - deletedBefore: The time before which the children must have been deleted
+//
+// Returns:
+//   - int64: The number of children removed
+//   - error: An error if there's a database error
+func (r *ChildRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, span := r.tracer.Start(ctx, "ChildRepository.Purge")
+	defer span.End()
+
+	filter := withTenant(ctx, bson.M{
+		"deleted_at": bson.M{"$lt": deletedBefore},
+	})
+
+	// The revisions of the purged children are removed with them
+	filter, err := purgeHistory(ctx, r.collection, childHistoryCollection, filter)
+	if err != nil {
+		r.logger.Error("Failed to purge child history", zap.Error(err))
+		return 0, fmt.Errorf("child.purge.failed: %w", err)
+	}
+
+	// So are their guardianships
+	guardiansCollection := r.collection.Database().Collection(guardianshipsCollection)
+	_, err = guardiansCollection.DeleteMany(ctx, withTenant(ctx, bson.M{"childId": filter["_id"]}))
+	if err != nil {
+		r.logger.Error("Failed to purge guardianships", zap.Error(err))
+		return 0, fmt.Errorf("child.purge.failed: %w", err)
+	}
+
+	// And they leave their households
+	households := r.collection.Database().Collection(householdsCollection)
+	_, err = households.UpdateMany(ctx, withTenant(ctx, bson.M{}), bson.M{"$pull": bson.M{"childIds": filter["_id"]}})
+	if err != nil {
+		r.logger.Error("Failed to remove purged children from households", zap.Error(err))
+		return 0, fmt.Errorf("child.purge.failed: %w", err)
+	}
+
+	result, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to purge children", zap.Error(err))
+		return 0, fmt.Errorf("child.purge.failed: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int64("child.purged", result.DeletedCount))
+
+	return result.DeletedCount, nil
+}
+
 // buildListFilter builds a MongoDB filter document for listing children with filtering.
 // It constructs a BSON filter based on the provided filter options and optional parent ID.
-// The filter includes conditions for soft delete (deleted_at is nil) and supports
+// The filter includes conditions for soft delete (deleted_at is nil, or not nil when filter.Deleted is set)
+// and the caller's tenant, and supports
 // filtering by first name, last name, and age range.
 // Parameters:
+//   - ctx: The context holding the caller's tenant
 //   - filter: The filter options containing criteria for filtering children
 //   - parentID: Optional parent ID to filter children by parent
 //
 // Returns:
 //   - bson.M: A MongoDB filter document that can be used in Find and Count operations
-func (r *ChildRepository) buildListFilter(filter ports.FilterOptions, parentID *uuid.UUID) bson.M {
-	mongoFilter := bson.M{
-		"deleted_at": nil,
-	}
+func (r *ChildRepository) buildListFilter(ctx context.Context, filter ports.FilterOptions, parentID *uuid.UUID) bson.M {
+	mongoFilter := withTenant(ctx, bson.M{
+		"deleted_at": deletedCondition(filter),
+	})
 
 	if parentID != nil {
 		mongoFilter["parentId"] = *parentID
@@ -312,9 +522,59 @@ func (r *ChildRepository) buildListFilter(filter ports.FilterOptions, parentID *
 		}
 	}
 
+	if len(filter.ParentIDs) > 0 {
+		parentFilter := bson.M{"$in": filter.ParentIDs}
+		if parentID != nil {
+			parentFilter["$eq"] = *parentID
+		}
+		mongoFilter["parentId"] = parentFilter
+	}
+
+	if len(filter.ChildIDs) > 0 {
+		mongoFilter["_id"] = bson.M{"$in": filter.ChildIDs}
+	}
+
 	return mongoFilter
 }
 
+// sortKey maps the sort options to a document field and a MongoDB sort order.
+// Without a sort field, the newest documents come first.
+//
+// Parameters:
+//   - sort: The sort options containing the field and direction
+//
+// Returns:
+//   - string: The document field to sort by
+//   - int: 1 for ascending or -1 for descending order
+func (r *ChildRepository) sortKey(sort ports.SortOptions) (string, int) {
+	if sort.Field == "" {
+		return "createdAt", -1
+	}
+
+	direction := 1 // ascending
+	if sort.Direction == "desc" {
+		direction = -1
+	}
+
+	var sortField string
+	switch sort.Field {
+	case "firstName", "first_name":
+		sortField = "firstName"
+	case "lastName", "last_name":
+		sortField = "lastName"
+	case "birthDate", "birth_date":
+		sortField = "birthDate"
+	case "createdAt", "created_at":
+		sortField = "createdAt"
+	case "updatedAt", "updated_at":
+		sortField = "updatedAt"
+	default:
+		sortField = "createdAt"
+	}
+
+	return sortField, direction
+}
+
 // ListByParentID retrieves children for a specific parent with pagination, filtering, and sorting.
 // It performs concurrent database operations for finding children and counting the total results
 // to optimize performance. The method supports filtering by various criteria, sorting by different
@@ -335,52 +595,11 @@ func (r *ChildRepository) ListByParentID(ctx context.Context, parentID uuid.UUID
 
 	span.SetAttributes(attribute.String("parent.id", parentID.String()))
 
-	filter := r.buildListFilter(queryOptions.Filter, &parentID)
+	filter := r.buildListFilter(ctx, queryOptions.Filter, &parentID)
 
-	// Build sort options
-	sortOptions := bson.D{}
-	if queryOptions.Sort.Field != "" {
-		direction := 1 // ascending
-		if queryOptions.Sort.Direction == "desc" {
-			direction = -1
-		}
-
-		var sortField string
-		switch queryOptions.Sort.Field {
-		case "firstName", "first_name":
-			sortField = "firstName"
-		case "lastName", "last_name":
-			sortField = "lastName"
-		case "birthDate", "birth_date":
-			sortField = "birthDate"
-		case "createdAt", "created_at":
-			sortField = "createdAt"
-		case "updatedAt", "updated_at":
-			sortField = "updatedAt"
-		default:
-			sortField = "createdAt"
-		}
-
-		sortOptions = append(sortOptions, bson.E{Key: sortField, Value: direction})
-	} else {
-		sortOptions = append(sortOptions, bson.E{Key: "createdAt", Value: -1})
-	}
-
-	// Build pagination options
-	limit := int64(queryOptions.Pagination.PageSize)
-	if limit <= 0 {
-		limit = 10 // Default page size
-	}
-
-	skip := int64(queryOptions.Pagination.Page * queryOptions.Pagination.PageSize)
-	if skip < 0 {
-		skip = 0
-	}
-
-	findOpts := options.Find()
-	findOpts.SetSort(sortOptions)
-	findOpts.SetLimit(limit)
-	findOpts.SetSkip(skip)
+	// Build sort and pagination options
+	sortField, direction := r.sortKey(queryOptions.Sort)
+	findFilter, findOpts, limit, skip := applyWindow(filter, sortField, direction, queryOptions)
 
 	// Create channels for concurrent operations
 	type findResult struct {
@@ -396,7 +615,7 @@ func (r *ChildRepository) ListByParentID(ctx context.Context, parentID uuid.UUID
 
 	// Execute Find operation concurrently
 	go func() {
-		cursor, err := r.collection.Find(ctx, filter, findOpts)
+		cursor, err := r.collection.Find(ctx, findFilter, findOpts)
 		if err != nil {
 			findCh <- findResult{nil, fmt.Errorf("child.list.byParent.failed: %w", err)}
 			return
@@ -435,14 +654,51 @@ func (r *ChildRepository) ListByParentID(ctx context.Context, parentID uuid.UUID
 		return nil, nil, countRes.err
 	}
 
-	pagedResult := &ports.PagedResult{
-		TotalCount: countRes.count,
-		Page:       queryOptions.Pagination.Page,
-		PageSize:   int(limit),
-		HasNext:    (skip + int64(len(findRes.children))) < countRes.count,
+	children, pagedResult := windowResult(findRes.children, queryOptions, limit, skip, countRes.count)
+
+	return children, pagedResult, nil
+}
+
+// ListByParentIDs retrieves all children of the given parents in a single query.
+// It only returns children of the caller's tenant that are not marked as deleted (soft delete),
+// ordered by parent ID and creation time.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - parentIDs: The unique identifiers of the parents whose children to retrieve
+//
+// Returns:
+//   - []*domain.Child: The children of all the given parents
+//   - error: An error if there's a database error
+func (r *ChildRepository) ListByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Child, error) {
+	ctx, span := r.tracer.Start(ctx, "ChildRepository.ListByParentIDs")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("parent.count", len(parentIDs)))
+
+	filter := withTenant(ctx, bson.M{
+		"parentId":   bson.M{"$in": parentIDs},
+		"deleted_at": nil,
+	})
+	findOpts := options.Find().SetSort(bson.D{
+		{Key: "parentId", Value: 1},
+		{Key: "createdAt", Value: 1},
+		{Key: "_id", Value: 1},
+	})
+
+	cursor, err := r.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		r.logger.Error("Failed to list children by parent IDs", zap.Error(err), zap.Int("count", len(parentIDs)))
+		return nil, fmt.Errorf("child.list.byParents.failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	children := []*domain.Child{}
+	if err := cursor.All(ctx, &children); err != nil {
+		r.logger.Error("Failed to decode children", zap.Error(err))
+		return nil, fmt.Errorf("child.decode.failed: %w", err)
 	}
 
-	return findRes.children, pagedResult, nil
+	return children, nil
 }
 
 // List retrieves a list of all children with pagination, filtering, and sorting.
@@ -463,52 +719,11 @@ func (r *ChildRepository) List(ctx context.Context, queryOptions ports.QueryOpti
 	ctx, span := r.tracer.Start(ctx, "ChildRepository.List")
 	defer span.End()
 
-	filter := r.buildListFilter(queryOptions.Filter, nil)
-
-	// Build sort options
-	sortOptions := bson.D{}
-	if queryOptions.Sort.Field != "" {
-		direction := 1 // ascending
-		if queryOptions.Sort.Direction == "desc" {
-			direction = -1
-		}
-
-		var sortField string
-		switch queryOptions.Sort.Field {
-		case "firstName", "first_name":
-			sortField = "firstName"
-		case "lastName", "last_name":
-			sortField = "lastName"
-		case "birthDate", "birth_date":
-			sortField = "birthDate"
-		case "createdAt", "created_at":
-			sortField = "createdAt"
-		case "updatedAt", "updated_at":
-			sortField = "updatedAt"
-		default:
-			sortField = "createdAt"
-		}
+	filter := r.buildListFilter(ctx, queryOptions.Filter, nil)
 
-		sortOptions = append(sortOptions, bson.E{Key: sortField, Value: direction})
-	} else {
-		sortOptions = append(sortOptions, bson.E{Key: "createdAt", Value: -1})
-	}
-
-	// Build pagination options
-	limit := int64(queryOptions.Pagination.PageSize)
-	if limit <= 0 {
-		limit = 10 // Default page size
-	}
-
-	skip := int64(queryOptions.Pagination.Page * queryOptions.Pagination.PageSize)
-	if skip < 0 {
-		skip = 0
-	}
-
-	findOpts := options.Find()
-	findOpts.SetSort(sortOptions)
-	findOpts.SetLimit(limit)
-	findOpts.SetSkip(skip)
+	// Build sort and pagination options
+	sortField, direction := r.sortKey(queryOptions.Sort)
+	findFilter, findOpts, limit, skip := applyWindow(filter, sortField, direction, queryOptions)
 
 	// Create channels for concurrent operations
 	type findResult struct {
@@ -524,7 +739,7 @@ func (r *ChildRepository) List(ctx context.Context, queryOptions ports.QueryOpti
 
 	// Execute Find operation concurrently
 	go func() {
-		cursor, err := r.collection.Find(ctx, filter, findOpts)
+		cursor, err := r.collection.Find(ctx, findFilter, findOpts)
 		if err != nil {
 			findCh <- findResult{nil, fmt.Errorf("child.list.failed: %w", err)}
 			return
@@ -563,14 +778,9 @@ func (r *ChildRepository) List(ctx context.Context, queryOptions ports.QueryOpti
 		return nil, nil, countRes.err
 	}
 
-	pagedResult := &ports.PagedResult{
-		TotalCount: countRes.count,
-		Page:       queryOptions.Pagination.Page,
-		PageSize:   int(limit),
-		HasNext:    (skip + int64(len(findRes.children))) < countRes.count,
-	}
+	children, pagedResult := windowResult(findRes.children, queryOptions, limit, skip, countRes.count)
 
-	return findRes.children, pagedResult, nil
+	return children, pagedResult, nil
 }
 
 // countByParentID returns the total count of children for a specific parent matching the filter.
@@ -590,7 +800,7 @@ func (r *ChildRepository) countByParentID(ctx context.Context, parentID uuid.UUI
 
 	span.SetAttributes(attribute.String("parent.id", parentID.String()))
 
-	mongoFilter := r.buildListFilter(filter, &parentID)
+	mongoFilter := r.buildListFilter(ctx, filter, &parentID)
 
 	count, err := r.collection.CountDocuments(ctx, mongoFilter)
 	if err != nil {
@@ -602,7 +812,7 @@ func (r *ChildRepository) countByParentID(ctx context.Context, parentID uuid.UUI
 }
 
 // Count returns the total count of children matching the filter.
-// This method counts all children (not deleted) that match the specified filter criteria,
+// This method counts all children (not deleted) of the caller's tenant that match the specified filter criteria,
 // regardless of their parent. It's used for pagination and statistics.
 // The method uses OpenTelemetry for tracing and logs relevant information during the operation.
 // Parameters:
@@ -616,7 +826,7 @@ func (r *ChildRepository) Count(ctx context.Context, filter ports.FilterOptions)
 	ctx, span := r.tracer.Start(ctx, "ChildRepository.Count")
 	defer span.End()
 
-	mongoFilter := r.buildListFilter(filter, nil)
+	mongoFilter := r.buildListFilter(ctx, filter, nil)
 
 	count, err := r.collection.CountDocuments(ctx, mongoFilter)
 	if err != nil {
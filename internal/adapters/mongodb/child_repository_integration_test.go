@@ -1,6 +1,7 @@
 package mongodb_test
 
 import (
+	"errors"
 	"os"
 	"testing"
 	"time"
@@ -289,10 +290,116 @@ func TestChildRepositoryIntegration(t *testing.T) {
 		assert.Contains(t, err.Error(), "child not found")
 	})
 
+	// Test updating a child that was modified since it was read
+	t.Run("UpdateStaleVersion", func(t *testing.T) {
+		child := domain.NewChild("Stale", "Child", time.Now().AddDate(-5, 0, 0), parent.ID)
+		err := childRepo.Create(ctx, child)
+		require.NoError(t, err, "Failed to create child")
+
+		// Read the child twice, as two concurrent callers would
+		first, err := childRepo.GetByID(ctx, child.ID)
+		require.NoError(t, err, "Failed to retrieve child")
+		second, err := childRepo.GetByID(ctx, child.ID)
+		require.NoError(t, err, "Failed to retrieve child")
+
+		// The first update wins and increments the version
+		first.FirstName = "First"
+		err = childRepo.Update(ctx, first)
+		require.NoError(t, err, "Failed to update child")
+		assert.Equal(t, 2, first.Version)
+
+		// The second update is based on the old version and must be rejected
+		second.FirstName = "Second"
+		err = childRepo.Update(ctx, second)
+		require.Error(t, err, "Expected error when updating a stale child")
+		assert.True(t, errors.Is(err, domain.ErrConflict))
+
+		retrievedChild, err := childRepo.GetByID(ctx, child.ID)
+		require.NoError(t, err, "Failed to retrieve child")
+		assert.Equal(t, "First", retrievedChild.FirstName)
+		assert.Equal(t, 2, retrievedChild.Version)
+	})
+
 	// Test deleting a non-existent child
 	t.Run("DeleteNonExistent", func(t *testing.T) {
 		err := childRepo.Delete(ctx, uuid.New())
 		require.Error(t, err, "Expected error when deleting non-existent child")
 		assert.Contains(t, err.Error(), "child not found")
 	})
+
+	// Test restoring a deleted child
+	t.Run("Restore", func(t *testing.T) {
+		// Create and delete a child
+		child := domain.NewChild("Rita", "Reed", time.Now().AddDate(-3, 0, 0), parent.ID)
+		require.NoError(t, childRepo.Create(ctx, child), "Failed to create child")
+		require.NoError(t, childRepo.Delete(ctx, child.ID), "Failed to delete child")
+
+		// Restore child
+		err := childRepo.Restore(ctx, child.ID)
+		require.NoError(t, err, "Failed to restore child")
+
+		// Retrieve restored child
+		retrievedChild, err := childRepo.GetByID(ctx, child.ID)
+		require.NoError(t, err, "Failed to retrieve restored child")
+		assert.Nil(t, retrievedChild.DeletedAt)
+		assert.Equal(t, 3, retrievedChild.Version)
+	})
+
+	// Test restoring a child that is not deleted
+	t.Run("RestoreNotDeleted", func(t *testing.T) {
+		err := childRepo.Restore(ctx, uuid.New())
+		require.Error(t, err, "Expected error when restoring a child that is not deleted")
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+	})
+
+	// Test listing deleted children
+	t.Run("ListDeleted", func(t *testing.T) {
+		// Create and delete a child
+		child := domain.NewChild("Dora", "Dell", time.Now().AddDate(-4, 0, 0), parent.ID)
+		require.NoError(t, childRepo.Create(ctx, child), "Failed to create child")
+		require.NoError(t, childRepo.Delete(ctx, child.ID), "Failed to delete child")
+
+		// List deleted children
+		children, _, err := childRepo.List(ctx, ports.QueryOptions{
+			Filter:     ports.FilterOptions{Deleted: true, FirstName: "Dora"},
+			Pagination: ports.PaginationOptions{Page: 0, PageSize: 10},
+		})
+		require.NoError(t, err, "Failed to list deleted children")
+		require.Len(t, children, 1)
+		assert.Equal(t, child.ID, children[0].ID)
+	})
+
+	// Test restoring a parent together with the children deleted with it
+	t.Run("RestoreWithParent", func(t *testing.T) {
+		// Create a family and delete the parent, which also deletes the child
+		otherParent := domain.NewParent("Owen", "Olsen", "owen.olsen@example.com", time.Now().AddDate(-30, 0, 0))
+		require.NoError(t, parentRepo.Create(ctx, otherParent), "Failed to create parent")
+		child := domain.NewChild("Olly", "Olsen", time.Now().AddDate(-6, 0, 0), otherParent.ID)
+		require.NoError(t, childRepo.Create(ctx, child), "Failed to create child")
+		require.NoError(t, parentRepo.Delete(ctx, otherParent.ID), "Failed to delete parent")
+
+		// Restore parent
+		err := parentRepo.Restore(ctx, otherParent.ID)
+		require.NoError(t, err, "Failed to restore parent")
+
+		// The child is restored with it
+		_, err = childRepo.GetByID(ctx, child.ID)
+		require.NoError(t, err, "Failed to retrieve child restored with its parent")
+	})
+
+	// Test purging deleted children
+	t.Run("Purge", func(t *testing.T) {
+		// Create and delete a child
+		child := domain.NewChild("Paul", "Pike", time.Now().AddDate(-7, 0, 0), parent.ID)
+		require.NoError(t, childRepo.Create(ctx, child), "Failed to create child")
+		require.NoError(t, childRepo.Delete(ctx, child.ID), "Failed to delete child")
+
+		// A cutoff after the deletion removes it for good
+		purged, err := childRepo.Purge(ctx, time.Now().Add(time.Minute))
+		require.NoError(t, err, "Failed to purge children")
+		assert.GreaterOrEqual(t, purged, int64(1))
+
+		err = childRepo.Restore(ctx, child.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+	})
 }
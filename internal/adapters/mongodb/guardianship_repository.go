@@ -0,0 +1,168 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// guardianshipsCollection holds the guardianships between parents and children
+const guardianshipsCollection = "guardianships"
+
+// GuardianshipRepository implements the ports.GuardianshipRepository interface for MongoDB.
+// Guardianships are stored in the guardianships collection; the parentId of the children
+// documents remains as the primary contact of each child.
+type GuardianshipRepository struct {
+	collection *mongo.Collection // MongoDB collection for guardianships
+	logger     *zap.Logger       // Logger for recording repository operations
+	tracer     trace.Tracer      // Tracer for distributed tracing
+}
+
+// NewGuardianshipRepository creates a new MongoDB guardianship repository.
+// Parameters:
+//   - db: The MongoDB database connection
+//   - logger: Logger for recording repository operations
+//
+// Returns:
+//   - *GuardianshipRepository: A new instance of the guardianship repository
+func NewGuardianshipRepository(db *mongo.Database, logger *zap.Logger) *GuardianshipRepository {
+	return &GuardianshipRepository{
+		collection: db.Collection(guardianshipsCollection),
+		logger:     logger,
+		tracer:     otel.Tracer("mongodb.guardianship_repository"),
+	}
+}
+
+// Create stores a new guardianship of the caller's tenant.
+// Parameters:
+//   - ctx: The context for the operation, holding the caller's tenant
+//   - guardianship: The guardianship to store
+//
+// Returns:
+//   - error: An error if the guardianship could not be stored
+func (r *GuardianshipRepository) Create(ctx context.Context, guardianship *domain.Guardianship) error {
+	ctx, span := r.tracer.Start(ctx, "GuardianshipRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("guardianship.id", guardianship.ID.String()))
+
+	guardianship.TenantID = ports.TenantIDFromContext(ctx)
+
+	_, err := r.collection.InsertOne(ctx, guardianship)
+	if err != nil {
+		r.logger.Error("Failed to create guardianship", zap.Error(err), zap.String("guardianship_id", guardianship.ID.String()))
+		return fmt.Errorf("guardianship.create.failed: %w", err)
+	}
+
+	return nil
+}
+
+// Update stores the type, dates and primary contact mark of a guardianship of the caller's tenant.
+// Parameters:
+//   - ctx: The context for the operation, holding the caller's tenant
+//   - guardianship: The guardianship to store
+//
+// Returns:
+//   - error: An error wrapping domain.ErrNotFound if the guardianship does not exist, or an error if there's a database error
+func (r *GuardianshipRepository) Update(ctx context.Context, guardianship *domain.Guardianship) error {
+	ctx, span := r.tracer.Start(ctx, "GuardianshipRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("guardianship.id", guardianship.ID.String()))
+
+	update := bson.M{
+		"$set": bson.M{
+			"type":           guardianship.Type,
+			"startDate":      guardianship.StartDate.UTC(),
+			"endDate":        guardianship.EndDate,
+			"primaryContact": guardianship.PrimaryContact,
+			"updatedAt":      guardianship.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, withTenant(ctx, bson.M{"_id": guardianship.ID}), update)
+	if err != nil {
+		r.logger.Error("Failed to update guardianship", zap.Error(err), zap.String("guardianship_id", guardianship.ID.String()))
+		return fmt.Errorf("guardianship.update.failed: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("guardianship not found: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// ListByChildIDs retrieves the guardianships of the given children of the caller's tenant
+// in a single query, ordered by child and start date.
+// Parameters:
+//   - ctx: The context for the operation, holding the caller's tenant
+//   - childIDs: The UUIDs of the children
+//
+// Returns:
+//   - []*domain.Guardianship: The guardianships, including those that ended
+//   - error: An error if the guardianships could not be retrieved
+func (r *GuardianshipRepository) ListByChildIDs(ctx context.Context, childIDs []uuid.UUID) ([]*domain.Guardianship, error) {
+	ctx, span := r.tracer.Start(ctx, "GuardianshipRepository.ListByChildIDs")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("child.count", len(childIDs)))
+
+	return r.find(ctx, withTenant(ctx, bson.M{"childId": bson.M{"$in": childIDs}}), "childId")
+}
+
+// ListByParentIDs retrieves the guardianships of the given parents of the caller's tenant
+// in a single query, ordered by parent and start date.
+// Parameters:
+//   - ctx: The context for the operation, holding the caller's tenant
+//   - parentIDs: The UUIDs of the parents
+//
+// Returns:
+//   - []*domain.Guardianship: The guardianships, including those that ended
+//   - error: An error if the guardianships could not be retrieved
+func (r *GuardianshipRepository) ListByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Guardianship, error) {
+	ctx, span := r.tracer.Start(ctx, "GuardianshipRepository.ListByParentIDs")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("parent.count", len(parentIDs)))
+
+	return r.find(ctx, withTenant(ctx, bson.M{"parentId": bson.M{"$in": parentIDs}}), "parentId")
+}
+
+// find retrieves the guardianships selected by the filter, ordered by the given field and start date
+func (r *GuardianshipRepository) find(ctx context.Context, filter bson.M, orderBy string) ([]*domain.Guardianship, error) {
+	findOptions := options.Find().SetSort(bson.D{
+		{Key: orderBy, Value: 1},
+		{Key: "startDate", Value: 1},
+		{Key: "createdAt", Value: 1},
+		{Key: "_id", Value: 1},
+	})
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		r.logger.Error("Failed to list guardianships", zap.Error(err))
+		return nil, fmt.Errorf("guardianship.list.failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	guardianships := []*domain.Guardianship{}
+	if err := cursor.All(ctx, &guardianships); err != nil {
+		r.logger.Error("Failed to decode guardianships", zap.Error(err))
+		return nil, fmt.Errorf("guardianship.decode.failed: %w", err)
+	}
+
+	return guardianships, nil
+}
+
+// Ensure GuardianshipRepository implements ports.GuardianshipRepository
+var _ ports.GuardianshipRepository = (*GuardianshipRepository)(nil)
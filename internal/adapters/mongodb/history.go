@@ -0,0 +1,113 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Collections holding the revisions of parents and children
+const (
+	parentHistoryCollection = "parent_history"
+	childHistoryCollection  = "child_history"
+)
+
+// historyDocument is a revision of a parent or child document.
+// State holds the document as it was written; the other fields are copied from it to be queried.
+type historyDocument struct {
+	EntityID   uuid.UUID  `bson:"entityId"`
+	ParentID   *uuid.UUID `bson:"parentId,omitempty"`
+	TenantID   string     `bson:"tenantId"`
+	Version    int        `bson:"version"`
+	DeletedAt  *time.Time `bson:"deletedAt,omitempty"`
+	RecordedAt time.Time  `bson:"recordedAt"`
+	State      bson.Raw   `bson:"state"`
+}
+
+// recordHistory records a revision of the documents that were just written, within the transaction
+// carried by ctx, if any. The repositories call it after every write of parents and children,
+// as MongoDB has no triggers that would do it for them.
+//
+// Parameters:
+//   - ctx: Context for the database operation
+//   - collection: The collection of the written documents
+//   - historyName: The name of the collection holding their revisions
+//   - filter: Selects the written documents
+//
+// Returns:
+//   - An error if the documents could not be read or their revisions stored
+func recordHistory(ctx context.Context, collection *mongo.Collection, historyName string, filter bson.M) error {
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("history.record.failed: %w", err)
+	}
+
+	var documents []bson.Raw
+	if err := cursor.All(ctx, &documents); err != nil {
+		return fmt.Errorf("history.record.failed: %w", err)
+	}
+	if len(documents) == 0 {
+		return nil
+	}
+
+	recordedAt := time.Now().UTC()
+	revisions := make([]interface{}, 0, len(documents))
+	for _, document := range documents {
+		var head struct {
+			ID        uuid.UUID  `bson:"_id"`
+			ParentID  *uuid.UUID `bson:"parentId,omitempty"`
+			TenantID  string     `bson:"tenantId"`
+			Version   int        `bson:"version"`
+			DeletedAt *time.Time `bson:"deleted_at,omitempty"`
+		}
+		if err := bson.Unmarshal(document, &head); err != nil {
+			return fmt.Errorf("history.record.failed: %w", err)
+		}
+
+		revisions = append(revisions, historyDocument{
+			EntityID:   head.ID,
+			ParentID:   head.ParentID,
+			TenantID:   head.TenantID,
+			Version:    head.Version,
+			DeletedAt:  head.DeletedAt,
+			RecordedAt: recordedAt,
+			State:      document,
+		})
+	}
+
+	if _, err := collection.Database().Collection(historyName).InsertMany(ctx, revisions); err != nil {
+		return fmt.Errorf("history.record.failed: %w", err)
+	}
+
+	return nil
+}
+
+// purgeHistory removes the revisions of the documents that are about to be purged, and returns
+// the filter that selects those documents by ID, so that documents written in the meantime are kept.
+//
+// Parameters:
+//   - ctx: Context holding the caller's tenant
+//   - collection: The collection of the purged documents
+//   - historyName: The name of the collection holding their revisions
+//   - filter: Selects the purged documents
+//
+// Returns:
+//   - The filter that selects the purged documents by ID
+//   - An error if the revisions could not be removed
+func purgeHistory(ctx context.Context, collection *mongo.Collection, historyName string, filter bson.M) (bson.M, error) {
+	ids, err := collection.Distinct(ctx, "_id", filter)
+	if err != nil {
+		return nil, fmt.Errorf("history.purge.failed: %w", err)
+	}
+
+	historyFilter := withTenant(ctx, bson.M{"entityId": bson.M{"$in": ids}})
+	if _, err := collection.Database().Collection(historyName).DeleteMany(ctx, historyFilter); err != nil {
+		return nil, fmt.Errorf("history.purge.failed: %w", err)
+	}
+
+	return withTenant(ctx, bson.M{"_id": bson.M{"$in": ids}}), nil
+}
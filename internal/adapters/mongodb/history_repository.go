@@ -0,0 +1,259 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// HistoryRepository implements the ports.HistoryRepository interface for MongoDB.
+// The parent_history and child_history collections are filled by the parent and child repositories,
+// which record a revision after every write.
+type HistoryRepository struct {
+	parents  *mongo.Collection // MongoDB collection for the revisions of parents
+	children *mongo.Collection // MongoDB collection for the revisions of children
+	logger   *zap.Logger       // Logger for recording repository operations
+	tracer   trace.Tracer      // Tracer for distributed tracing
+}
+
+// NewHistoryRepository creates a new MongoDB history repository.
+// Parameters:
+//   - db: The MongoDB database connection
+//   - logger: Logger for recording repository operations
+//
+// Returns:
+//   - *HistoryRepository: A new instance of the history repository
+func NewHistoryRepository(db *mongo.Database, logger *zap.Logger) *HistoryRepository {
+	return &HistoryRepository{
+		parents:  db.Collection(parentHistoryCollection),
+		children: db.Collection(childHistoryCollection),
+		logger:   logger,
+		tracer:   otel.Tracer("mongodb.history_repository"),
+	}
+}
+
+// GetParentAsOf retrieves the latest revision of a parent of the caller's tenant recorded at or before asOf.
+// Parameters:
+//   - ctx: The context for the operation, holding the caller's tenant
+//   - id: The unique identifier of the parent
+//   - asOf: The point in time
+//
+// Returns:
+//   - *domain.Parent: The parent as it was at that time
+//   - error: An error wrapping domain.ErrNotFound if the parent did not exist or was deleted at that time,
+//     or an error if the revision could not be retrieved
+func (r *HistoryRepository) GetParentAsOf(ctx context.Context, id uuid.UUID, asOf time.Time) (*domain.Parent, error) {
+	ctx, span := r.tracer.Start(ctx, "HistoryRepository.GetParentAsOf")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("parent.id", id.String()))
+
+	filter := withTenant(ctx, bson.M{
+		"entityId":   id,
+		"recordedAt": bson.M{"$lte": asOf.UTC()},
+	})
+	findOptions := options.FindOne().SetSort(bson.D{{Key: "recordedAt", Value: -1}, {Key: "_id", Value: -1}})
+
+	var document historyDocument
+	if err := r.parents.FindOne(ctx, filter, findOptions).Decode(&document); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("parent not found as of %s: %w", asOf.Format(time.RFC3339), domain.ErrNotFound)
+		}
+		r.logger.Error("Failed to get parent as of time", zap.Error(err), zap.String("parent_id", id.String()))
+		return nil, fmt.Errorf("parent_history.get.failed: %w", err)
+	}
+
+	if document.DeletedAt != nil {
+		return nil, fmt.Errorf("parent deleted as of %s: %w", asOf.Format(time.RFC3339), domain.ErrNotFound)
+	}
+
+	revision, err := document.parentRevision()
+	if err != nil {
+		r.logger.Error("Failed to decode parent revision", zap.Error(err), zap.String("parent_id", id.String()))
+		return nil, err
+	}
+
+	return revision.Parent, nil
+}
+
+// ListChildrenAsOf retrieves the children of the caller's tenant whose latest revision recorded at or before asOf
+// belongs to the parent and is not deleted, ordered by creation time.
+// Parameters:
+//   - ctx: The context for the operation, holding the caller's tenant
+//   - parentID: The unique identifier of the parent
+//   - asOf: The point in time
+//
+// Returns:
+//   - []*domain.Child: The children as they were at that time
+//   - error: An error if the revisions could not be retrieved
+func (r *HistoryRepository) ListChildrenAsOf(ctx context.Context, parentID uuid.UUID, asOf time.Time) ([]*domain.Child, error) {
+	ctx, span := r.tracer.Start(ctx, "HistoryRepository.ListChildrenAsOf")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("parent.id", parentID.String()))
+
+	// Only the children that ever belonged to the parent are candidates, but a candidate
+	// counts only if it still belonged to the parent in its latest revision as of the time
+	candidates, err := r.children.Distinct(ctx, "entityId", withTenant(ctx, bson.M{"parentId": parentID}))
+	if err != nil {
+		r.logger.Error("Failed to list children as of time", zap.Error(err), zap.String("parent_id", parentID.String()))
+		return nil, fmt.Errorf("child_history.list.failed: %w", err)
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: withTenant(ctx, bson.M{
+			"entityId":   bson.M{"$in": candidates},
+			"recordedAt": bson.M{"$lte": asOf.UTC()},
+		})}},
+		{{Key: "$sort", Value: bson.D{{Key: "recordedAt", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$entityId", "latest": bson.M{"$first": "$$ROOT"}}}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$latest"}}},
+		{{Key: "$match", Value: bson.M{"parentId": parentID, "deletedAt": nil}}},
+		{{Key: "$sort", Value: bson.D{{Key: "state.createdAt", Value: 1}, {Key: "entityId", Value: 1}}}},
+	}
+
+	cursor, err := r.children.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Error("Failed to list children as of time", zap.Error(err), zap.String("parent_id", parentID.String()))
+		return nil, fmt.Errorf("child_history.list.failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var documents []historyDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		r.logger.Error("Failed to decode child revisions", zap.Error(err))
+		return nil, fmt.Errorf("child_history.decode.failed: %w", err)
+	}
+
+	children := make([]*domain.Child, 0, len(documents))
+	for _, document := range documents {
+		revision, err := document.childRevision()
+		if err != nil {
+			r.logger.Error("Failed to decode child revision", zap.Error(err), zap.String("child_id", document.EntityID.String()))
+			return nil, err
+		}
+		children = append(children, revision.Child)
+	}
+
+	return children, nil
+}
+
+// ListParentRevisions retrieves the revisions of a parent of the caller's tenant, oldest first.
+// Parameters:
+//   - ctx: The context for the operation, holding the caller's tenant
+//   - id: The unique identifier of the parent
+//
+// Returns:
+//   - []*domain.Revision: The revisions
+//   - error: An error if the revisions could not be retrieved
+func (r *HistoryRepository) ListParentRevisions(ctx context.Context, id uuid.UUID) ([]*domain.Revision, error) {
+	ctx, span := r.tracer.Start(ctx, "HistoryRepository.ListParentRevisions")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("parent.id", id.String()))
+
+	documents, err := r.list(ctx, r.parents, id)
+	if err != nil {
+		r.logger.Error("Failed to list parent revisions", zap.Error(err), zap.String("parent_id", id.String()))
+		return nil, fmt.Errorf("parent_history.list.failed: %w", err)
+	}
+
+	revisions := make([]*domain.Revision, 0, len(documents))
+	for _, document := range documents {
+		revision, err := document.parentRevision()
+		if err != nil {
+			r.logger.Error("Failed to decode parent revision", zap.Error(err), zap.String("parent_id", id.String()))
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+// ListChildRevisions retrieves the revisions of a child of the caller's tenant, oldest first.
+// Parameters:
+//   - ctx: The context for the operation, holding the caller's tenant
+//   - id: The unique identifier of the child
+//
+// Returns:
+//   - []*domain.Revision: The revisions
+//   - error: An error if the revisions could not be retrieved
+func (r *HistoryRepository) ListChildRevisions(ctx context.Context, id uuid.UUID) ([]*domain.Revision, error) {
+	ctx, span := r.tracer.Start(ctx, "HistoryRepository.ListChildRevisions")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("child.id", id.String()))
+
+	documents, err := r.list(ctx, r.children, id)
+	if err != nil {
+		r.logger.Error("Failed to list child revisions", zap.Error(err), zap.String("child_id", id.String()))
+		return nil, fmt.Errorf("child_history.list.failed: %w", err)
+	}
+
+	revisions := make([]*domain.Revision, 0, len(documents))
+	for _, document := range documents {
+		revision, err := document.childRevision()
+		if err != nil {
+			r.logger.Error("Failed to decode child revision", zap.Error(err), zap.String("child_id", id.String()))
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+// list retrieves the revision documents of an entity of the caller's tenant, oldest first
+func (r *HistoryRepository) list(ctx context.Context, collection *mongo.Collection, id uuid.UUID) ([]historyDocument, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "recordedAt", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := collection.Find(ctx, withTenant(ctx, bson.M{"entityId": id}), findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var documents []historyDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+// parentRevision decodes the parent of a revision document
+func (d *historyDocument) parentRevision() (*domain.Revision, error) {
+	var parent domain.Parent
+	if err := bson.Unmarshal(d.State, &parent); err != nil {
+		return nil, fmt.Errorf("parent_history.decode.failed: %w", err)
+	}
+
+	parent.DeletedAt = d.DeletedAt
+	return domain.NewParentRevision(&parent, d.RecordedAt.UTC()), nil
+}
+
+// childRevision decodes the child of a revision document
+func (d *historyDocument) childRevision() (*domain.Revision, error) {
+	var child domain.Child
+	if err := bson.Unmarshal(d.State, &child); err != nil {
+		return nil, fmt.Errorf("child_history.decode.failed: %w", err)
+	}
+
+	child.DeletedAt = d.DeletedAt
+	return domain.NewChildRevision(&child, d.RecordedAt.UTC()), nil
+}
+
+// Ensure HistoryRepository implements ports.HistoryRepository
+var _ ports.HistoryRepository = (*HistoryRepository)(nil)
@@ -0,0 +1,172 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// householdsCollection holds the households that group parents and children
+const householdsCollection = "households"
+
+// HouseholdRepository implements the ports.HouseholdRepository interface for MongoDB.
+// A household document holds the IDs of its members in parentIds and childIds; the unique
+// index on childIds keeps a child in a single household.
+type HouseholdRepository struct {
+	collection *mongo.Collection // MongoDB collection for households
+	logger     *zap.Logger       // Logger for recording repository operations
+	tracer     trace.Tracer      // Tracer for distributed tracing
+}
+
+// NewHouseholdRepository creates a new MongoDB household repository.
+// Parameters:
+//   - db: The MongoDB database connection
+//   - logger: Logger for recording repository operations
+//
+// Returns:
+//   - *HouseholdRepository: A new instance of the household repository
+func NewHouseholdRepository(db *mongo.Database, logger *zap.Logger) *HouseholdRepository {
+	return &HouseholdRepository{
+		collection: db.Collection(householdsCollection),
+		logger:     logger,
+		tracer:     otel.Tracer("mongodb.household_repository"),
+	}
+}
+
+// Create stores a new household of the caller's tenant, with its members.
+// Parameters:
+//   - ctx: The context for the operation, holding the caller's tenant
+//   - household: The household to store
+//
+// Returns:
+//   - error: An error if the household could not be stored, such as when a child already belongs to a household
+func (r *HouseholdRepository) Create(ctx context.Context, household *domain.Household) error {
+	ctx, span := r.tracer.Start(ctx, "HouseholdRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("household.id", household.ID.String()))
+
+	household.TenantID = ports.TenantIDFromContext(ctx)
+
+	_, err := r.collection.InsertOne(ctx, household)
+	if err != nil {
+		r.logger.Error("Failed to create household", zap.Error(err), zap.String("household_id", household.ID.String()))
+		return fmt.Errorf("household.create.failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a household of the caller's tenant by ID.
+// Parameters:
+//   - ctx: The context for the operation, holding the caller's tenant
+//   - id: The UUID of the household
+//
+// Returns:
+//   - *domain.Household: The household
+//   - error: An error wrapping domain.ErrNotFound if the household does not exist, or an error if there's a database error
+func (r *HouseholdRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Household, error) {
+	ctx, span := r.tracer.Start(ctx, "HouseholdRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("household.id", id.String()))
+
+	household, err := r.findOne(ctx, withTenant(ctx, bson.M{"_id": id}))
+	if errors.Is(err, domain.ErrNotFound) {
+		reportCrossTenantAccess(ctx, r.collection, r.logger, "Household", id)
+	}
+	return household, err
+}
+
+// GetByChildID retrieves the household of the caller's tenant that a child belongs to.
+// Parameters:
+//   - ctx: The context for the operation, holding the caller's tenant
+//   - childID: The UUID of the child
+//
+// Returns:
+//   - *domain.Household: The household
+//   - error: An error wrapping domain.ErrNotFound if the child is not a member of a household, or an error if there's a database error
+func (r *HouseholdRepository) GetByChildID(ctx context.Context, childID uuid.UUID) (*domain.Household, error) {
+	ctx, span := r.tracer.Start(ctx, "HouseholdRepository.GetByChildID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("child.id", childID.String()))
+
+	return r.findOne(ctx, withTenant(ctx, bson.M{"childIds": childID}))
+}
+
+// Update stores the name, address, phone numbers and members of a household of the caller's tenant.
+// Parameters:
+//   - ctx: The context for the operation, holding the caller's tenant
+//   - household: The household to store
+//
+// Returns:
+//   - error: An error wrapping domain.ErrNotFound if the household does not exist, or an error if there's a database error
+func (r *HouseholdRepository) Update(ctx context.Context, household *domain.Household) error {
+	ctx, span := r.tracer.Start(ctx, "HouseholdRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("household.id", household.ID.String()))
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":         household.Name,
+			"address":      household.Address,
+			"phoneNumbers": household.PhoneNumbers,
+			"parentIds":    household.ParentIDs,
+			"childIds":     household.ChildIDs,
+			"updatedAt":    household.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, withTenant(ctx, bson.M{"_id": household.ID}), update)
+	if err != nil {
+		r.logger.Error("Failed to update household", zap.Error(err), zap.String("household_id", household.ID.String()))
+		return fmt.Errorf("household.update.failed: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("household not found: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// findOne retrieves the household selected by the filter
+func (r *HouseholdRepository) findOne(ctx context.Context, filter bson.M) (*domain.Household, error) {
+	var household domain.Household
+	err := r.collection.FindOne(ctx, filter).Decode(&household)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("household not found: %w", domain.ErrNotFound)
+		}
+		r.logger.Error("Failed to get household", zap.Error(err))
+		return nil, fmt.Errorf("household.get.failed: %w", err)
+	}
+
+	// Households stored without members decode with nil lists
+	if household.PhoneNumbers == nil {
+		household.PhoneNumbers = []string{}
+	}
+	if household.ParentIDs == nil {
+		household.ParentIDs = []uuid.UUID{}
+	}
+	if household.ChildIDs == nil {
+		household.ChildIDs = []uuid.UUID{}
+	}
+
+	return &household, nil
+}
+
+// Ensure HouseholdRepository implements ports.HouseholdRepository
+var _ ports.HouseholdRepository = (*HouseholdRepository)(nil)
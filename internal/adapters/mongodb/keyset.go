@@ -0,0 +1,104 @@
+package mongodb
+
+import (
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// applyWindow builds the find filter and find options for a list query.
+// Cursor requests are served with a keyset seek on (sort field, _id) and fetch one extra document
+// to detect further pages; page/pageSize requests keep using skip and limit. The _id field is always
+// added to the sort as a tie-breaker so that the ordering is total and cursors stay stable.
+//
+// Parameters:
+//   - filter: The list filter built from the filter options
+//   - sortField: The document field to sort by
+//   - direction: The requested sort order, 1 for ascending and -1 for descending
+//   - queryOptions: Options for filtering, sorting, and pagination
+//
+// Returns:
+//   - bson.M: The filter to pass to Find
+//   - *options.FindOptions: The sort, limit, and skip to pass to Find
+//   - int64: The page size
+//   - int64: The number of documents skipped
+func applyWindow(filter bson.M, sortField string, direction int, queryOptions ports.QueryOptions) (bson.M, *options.FindOptions, int64, int64) {
+	cursor := queryOptions.Cursor
+	findOpts := options.Find()
+
+	if !cursor.IsSet() {
+		limit := int64(queryOptions.Pagination.PageSize)
+		if limit <= 0 {
+			limit = 10 // Default page size
+		}
+
+		skip := int64(queryOptions.Pagination.Page) * limit
+		if skip < 0 {
+			skip = 0
+		}
+
+		findOpts.SetSort(bson.D{{Key: sortField, Value: direction}, {Key: "_id", Value: direction}})
+		findOpts.SetLimit(limit)
+		findOpts.SetSkip(skip)
+
+		return filter, findOpts, limit, skip
+	}
+
+	// Documents after the cursor come later in the requested order, documents before it come earlier
+	conditions := bson.A{filter}
+	if cursor.After != nil {
+		operator := "$gt"
+		if direction < 0 {
+			operator = "$lt"
+		}
+		conditions = append(conditions, seekCondition(sortField, operator, cursor.After))
+	}
+	if cursor.Before != nil {
+		operator := "$lt"
+		if direction < 0 {
+			operator = "$gt"
+		}
+		conditions = append(conditions, seekCondition(sortField, operator, cursor.Before))
+	}
+
+	// Reading backwards scans in the opposite direction; ports.KeysetPage restores the order
+	scanDirection := direction
+	if cursor.IsBackward() {
+		scanDirection = -direction
+	}
+
+	limit := int64(cursor.Limit(queryOptions.Pagination.PageSize))
+
+	findOpts.SetSort(bson.D{{Key: sortField, Value: scanDirection}, {Key: "_id", Value: scanDirection}})
+	findOpts.SetLimit(limit + 1)
+
+	return bson.M{"$and": conditions}, findOpts, limit, 0
+}
+
+// seekCondition matches the documents positioned past the cursor on (sortField, _id)
+func seekCondition(sortField, operator string, cursor *ports.Cursor) bson.M {
+	return bson.M{
+		"$or": bson.A{
+			bson.M{sortField: bson.M{operator: cursor.SortValue}},
+			bson.M{sortField: cursor.SortValue, "_id": bson.M{operator: cursor.ID}},
+		},
+	}
+}
+
+// windowResult builds the paged result for documents fetched with applyWindow
+func windowResult[T any](items []T, queryOptions ports.QueryOptions, limit, skip int64, totalCount int64) ([]T, *ports.PagedResult) {
+	pagedResult := &ports.PagedResult{
+		TotalCount: totalCount,
+		Page:       queryOptions.Pagination.Page,
+		PageSize:   int(limit),
+	}
+
+	if !queryOptions.Cursor.IsSet() {
+		pagedResult.HasNext = (skip + int64(len(items))) < totalCount
+		pagedResult.HasPrevious = skip > 0
+		return items, pagedResult
+	}
+
+	items, pagedResult.HasNext, pagedResult.HasPrevious = ports.KeysetPage(queryOptions.Cursor, items, int(limit))
+	return items, pagedResult
+}
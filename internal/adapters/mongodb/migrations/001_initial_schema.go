@@ -115,6 +115,22 @@ func (m *InitialSchemaMigration) Down(ctx context.Context) error {
 	return nil
 }
 
+// Definition returns what the migration changes in the database
+func (m *InitialSchemaMigration) Definition() string {
+	return `
+		up:
+			parents.createIndex({deleted_at: 1}, {name: "idx_parents_deleted_at"})
+			parents.createIndex({email: 1}, {name: "idx_parents_email"})
+			children.dropIndex("idx_children_parent_id")
+			children.dropIndex("idx_children_parent_id_new")
+			children.createIndex({deleted_at: 1}, {name: "idx_children_deleted_at"})
+			children.createIndex({parentId: 1}, {name: "idx_children_parent_id"})
+		down:
+			children.drop()
+			parents.drop()
+	`
+}
+
 // insertSampleData inserts sample data into the database
 func (m *InitialSchemaMigration) insertSampleData(ctx context.Context) error {
 	// Create sample parents
@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// ParentUserLinkMigration indexes the link between a parent and the user account that may access its family
+type ParentUserLinkMigration struct {
+	db     *mongo.Database
+	logger *zap.Logger
+}
+
+// NewParentUserLinkMigration creates a new parent user link migration
+func NewParentUserLinkMigration(db *mongo.Database, logger *zap.Logger) *ParentUserLinkMigration {
+	return &ParentUserLinkMigration{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Up runs the migration
+func (m *ParentUserLinkMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running parent user link migration for MongoDB")
+
+	// A user account can be linked to at most one parent; unlinked parents have no userId field
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "userId", Value: 1}},
+		Options: options.Index().
+			SetName("idx_parents_user_id").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"userId": bson.M{"$exists": true}}),
+	}
+
+	_, err := m.db.Collection("parents").Indexes().CreateOne(ctx, indexModel)
+	if err != nil {
+		m.logger.Error("Failed to create user index for parents collection", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Parent user link migration for MongoDB completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *ParentUserLinkMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back parent user link migration for MongoDB")
+
+	_, err := m.db.Collection("parents").Indexes().DropOne(ctx, "idx_parents_user_id")
+	if err != nil {
+		m.logger.Error("Failed to drop user index of parents collection", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Parent user link migration for MongoDB rolled back successfully")
+	return nil
+}
+
+// Definition returns what the migration changes in the database
+func (m *ParentUserLinkMigration) Definition() string {
+	return `
+		up:
+			parents.createIndex({userId: 1}, {name: "idx_parents_user_id", unique: true, partialFilterExpression: {userId: {$exists: true}}})
+		down:
+			parents.dropIndex("idx_parents_user_id")
+	`
+}
@@ -0,0 +1,134 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// TenantIsolationMigration assigns every document to a tenant and indexes the tenant of parents and children.
+// Existing documents are assigned to the default tenant, whose ID is empty.
+type TenantIsolationMigration struct {
+	db     *mongo.Database
+	logger *zap.Logger
+}
+
+// NewTenantIsolationMigration creates a new tenant isolation migration
+func NewTenantIsolationMigration(db *mongo.Database, logger *zap.Logger) *TenantIsolationMigration {
+	return &TenantIsolationMigration{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Up runs the migration
+func (m *TenantIsolationMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running tenant isolation migration for MongoDB")
+
+	for _, collectionName := range []string{"parents", "children"} {
+		collection := m.db.Collection(collectionName)
+
+		// Every query filters on the tenant, so documents without one would no longer be found
+		_, err := collection.UpdateMany(ctx,
+			bson.M{"tenantId": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"tenantId": ""}})
+		if err != nil {
+			m.logger.Error("Failed to assign documents to the default tenant", zap.Error(err), zap.String("collection", collectionName))
+			return err
+		}
+
+		indexModel := mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenantId", Value: 1}},
+			Options: options.Index().SetName("idx_" + collectionName + "_tenant_id"),
+		}
+		if _, err := collection.Indexes().CreateOne(ctx, indexModel); err != nil {
+			m.logger.Error("Failed to create tenant index", zap.Error(err), zap.String("collection", collectionName))
+			return err
+		}
+	}
+
+	// A user account is linked to at most one parent per tenant
+	parents := m.db.Collection("parents")
+	if _, err := parents.Indexes().DropOne(ctx, "idx_parents_user_id"); err != nil {
+		m.logger.Error("Failed to drop user index of parents collection", zap.Error(err))
+		return err
+	}
+
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "tenantId", Value: 1}, {Key: "userId", Value: 1}},
+		Options: options.Index().
+			SetName("idx_parents_user_id").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"userId": bson.M{"$exists": true}}),
+	}
+	if _, err := parents.Indexes().CreateOne(ctx, indexModel); err != nil {
+		m.logger.Error("Failed to create user index for parents collection", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Tenant isolation migration for MongoDB completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *TenantIsolationMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back tenant isolation migration for MongoDB")
+
+	parents := m.db.Collection("parents")
+	if _, err := parents.Indexes().DropOne(ctx, "idx_parents_user_id"); err != nil {
+		m.logger.Error("Failed to drop user index of parents collection", zap.Error(err))
+		return err
+	}
+
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "userId", Value: 1}},
+		Options: options.Index().
+			SetName("idx_parents_user_id").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"userId": bson.M{"$exists": true}}),
+	}
+	if _, err := parents.Indexes().CreateOne(ctx, indexModel); err != nil {
+		m.logger.Error("Failed to create user index for parents collection", zap.Error(err))
+		return err
+	}
+
+	for _, collectionName := range []string{"parents", "children"} {
+		collection := m.db.Collection(collectionName)
+
+		if _, err := collection.Indexes().DropOne(ctx, "idx_"+collectionName+"_tenant_id"); err != nil {
+			m.logger.Error("Failed to drop tenant index", zap.Error(err), zap.String("collection", collectionName))
+			return err
+		}
+
+		if _, err := collection.UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"tenantId": ""}}); err != nil {
+			m.logger.Error("Failed to remove tenants from documents", zap.Error(err), zap.String("collection", collectionName))
+			return err
+		}
+	}
+
+	m.logger.Info("Tenant isolation migration for MongoDB rolled back successfully")
+	return nil
+}
+
+// Definition returns what the migration changes in the database
+func (m *TenantIsolationMigration) Definition() string {
+	return `
+		up:
+			parents.updateMany({tenantId: {$exists: false}}, {$set: {tenantId: ""}})
+			parents.createIndex({tenantId: 1}, {name: "idx_parents_tenant_id"})
+			children.updateMany({tenantId: {$exists: false}}, {$set: {tenantId: ""}})
+			children.createIndex({tenantId: 1}, {name: "idx_children_tenant_id"})
+			parents.dropIndex("idx_parents_user_id")
+			parents.createIndex({tenantId: 1, userId: 1}, {name: "idx_parents_user_id", unique: true, partialFilterExpression: {userId: {$exists: true}}})
+		down:
+			parents.dropIndex("idx_parents_user_id")
+			parents.createIndex({userId: 1}, {name: "idx_parents_user_id", unique: true, partialFilterExpression: {userId: {$exists: true}}})
+			parents.dropIndex("idx_parents_tenant_id")
+			parents.updateMany({}, {$unset: {tenantId: ""}})
+			children.dropIndex("idx_children_tenant_id")
+			children.updateMany({}, {$unset: {tenantId: ""}})
+	`
+}
@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// EntityVersionsMigration adds the version used for optimistic concurrency control to parents and children
+type EntityVersionsMigration struct {
+	db     *mongo.Database
+	logger *zap.Logger
+}
+
+// NewEntityVersionsMigration creates a new entity versions migration
+func NewEntityVersionsMigration(db *mongo.Database, logger *zap.Logger) *EntityVersionsMigration {
+	return &EntityVersionsMigration{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Up runs the migration
+func (m *EntityVersionsMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running entity versions migration for MongoDB")
+
+	// Updates only match the version that was read, so documents without one could no longer be updated
+	for _, collectionName := range []string{"parents", "children"} {
+		_, err := m.db.Collection(collectionName).UpdateMany(ctx,
+			bson.M{"version": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"version": 1}})
+		if err != nil {
+			m.logger.Error("Failed to add versions to documents", zap.Error(err), zap.String("collection", collectionName))
+			return err
+		}
+	}
+
+	m.logger.Info("Entity versions migration for MongoDB completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *EntityVersionsMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back entity versions migration for MongoDB")
+
+	for _, collectionName := range []string{"parents", "children"} {
+		_, err := m.db.Collection(collectionName).UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"version": ""}})
+		if err != nil {
+			m.logger.Error("Failed to remove versions from documents", zap.Error(err), zap.String("collection", collectionName))
+			return err
+		}
+	}
+
+	m.logger.Info("Entity versions migration for MongoDB rolled back successfully")
+	return nil
+}
+
+// Definition returns what the migration changes in the database
+func (m *EntityVersionsMigration) Definition() string {
+	return `
+		up:
+			parents.updateMany({version: {$exists: false}}, {$set: {version: 1}})
+			children.updateMany({version: {$exists: false}}, {$set: {version: 1}})
+		down:
+			parents.updateMany({}, {$unset: {version: ""}})
+			children.updateMany({}, {$unset: {version: ""}})
+	`
+}
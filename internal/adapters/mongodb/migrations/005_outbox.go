@@ -0,0 +1,81 @@
+package migrations
+
+import (
+	"context"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// OutboxMigration adds the transactional outbox of domain events
+type OutboxMigration struct {
+	db     *mongo.Database
+	logger *zap.Logger
+}
+
+// NewOutboxMigration creates a new outbox migration
+func NewOutboxMigration(db *mongo.Database, logger *zap.Logger) *OutboxMigration {
+	return &OutboxMigration{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// outboxCollections are the collections written by the outbox, which are created up front because
+// creating a collection within a transaction is not supported by every MongoDB version
+var outboxCollections = []string{"outbox", "outbox_aggregates", "counters"}
+
+// Up runs the migration
+func (m *OutboxMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running outbox migration for MongoDB")
+
+	existing, err := m.db.ListCollectionNames(ctx, bson.M{"name": bson.M{"$in": outboxCollections}})
+	if err != nil {
+		m.logger.Error("Failed to list outbox collections", zap.Error(err))
+		return err
+	}
+
+	for _, collectionName := range outboxCollections {
+		if slices.Contains(existing, collectionName) {
+			continue
+		}
+		if err := m.db.CreateCollection(ctx, collectionName); err != nil {
+			m.logger.Error("Failed to create outbox collection", zap.Error(err), zap.String("collection", collectionName))
+			return err
+		}
+	}
+
+	m.logger.Info("Outbox migration for MongoDB completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *OutboxMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back outbox migration for MongoDB")
+
+	for _, collectionName := range outboxCollections {
+		if err := m.db.Collection(collectionName).Drop(ctx); err != nil {
+			m.logger.Error("Failed to drop outbox collection", zap.Error(err), zap.String("collection", collectionName))
+			return err
+		}
+	}
+
+	m.logger.Info("Outbox migration for MongoDB rolled back successfully")
+	return nil
+}
+
+// Definition returns what the migration changes in the database
+func (m *OutboxMigration) Definition() string {
+	return `
+		up:
+			createCollection("outbox")
+			createCollection("outbox_aggregates")
+			createCollection("counters")
+		down:
+			outbox.drop()
+			outbox_aggregates.drop()
+			counters.drop()
+	`
+}
@@ -0,0 +1,90 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// WebhooksMigration indexes the webhook subscriptions and their deliveries
+type WebhooksMigration struct {
+	db     *mongo.Database
+	logger *zap.Logger
+}
+
+// NewWebhooksMigration creates a new webhooks migration
+func NewWebhooksMigration(db *mongo.Database, logger *zap.Logger) *WebhooksMigration {
+	return &WebhooksMigration{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Up runs the migration
+func (m *WebhooksMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running webhooks migration for MongoDB")
+
+	subscriptions := m.db.Collection("webhook_subscriptions")
+	if _, err := subscriptions.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenantId", Value: 1}, {Key: "createdAt", Value: 1}},
+		Options: options.Index().SetName("idx_webhook_subscriptions_tenant_id"),
+	}); err != nil {
+		m.logger.Error("Failed to create tenant index for webhook subscriptions", zap.Error(err))
+		return err
+	}
+
+	// The unique index makes enqueuing an event for a subscription idempotent
+	deliveries := m.db.Collection("webhook_deliveries")
+	if _, err := deliveries.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "subscriptionId", Value: 1}, {Key: "eventId", Value: 1}},
+			Options: options.Index().SetName("idx_webhook_deliveries_event").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "nextAttemptAt", Value: 1}},
+			Options: options.Index().SetName("idx_webhook_deliveries_due"),
+		},
+		{
+			Keys:    bson.D{{Key: "tenantId", Value: 1}, {Key: "createdAt", Value: -1}},
+			Options: options.Index().SetName("idx_webhook_deliveries_tenant_created_at"),
+		},
+	}); err != nil {
+		m.logger.Error("Failed to create indexes for webhook deliveries", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Webhooks migration for MongoDB completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *WebhooksMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back webhooks migration for MongoDB")
+
+	for _, collectionName := range []string{"webhook_deliveries", "webhook_subscriptions"} {
+		if err := m.db.Collection(collectionName).Drop(ctx); err != nil {
+			m.logger.Error("Failed to drop webhook collection", zap.Error(err), zap.String("collection", collectionName))
+			return err
+		}
+	}
+
+	m.logger.Info("Webhooks migration for MongoDB rolled back successfully")
+	return nil
+}
+
+// Definition returns what the migration changes in the database
+func (m *WebhooksMigration) Definition() string {
+	return `
+		up:
+			webhook_subscriptions.createIndex({tenantId: 1, createdAt: 1}, {name: "idx_webhook_subscriptions_tenant_id"})
+			webhook_deliveries.createIndex({subscriptionId: 1, eventId: 1}, {name: "idx_webhook_deliveries_event", unique: true})
+			webhook_deliveries.createIndex({status: 1, nextAttemptAt: 1}, {name: "idx_webhook_deliveries_due"})
+			webhook_deliveries.createIndex({tenantId: 1, createdAt: -1}, {name: "idx_webhook_deliveries_tenant_created_at"})
+		down:
+			webhook_deliveries.drop()
+			webhook_subscriptions.drop()
+	`
+}
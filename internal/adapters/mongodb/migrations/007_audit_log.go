@@ -0,0 +1,64 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// AuditLogMigration indexes the audit log of changes to parents and children
+type AuditLogMigration struct {
+	db     *mongo.Database
+	logger *zap.Logger
+}
+
+// NewAuditLogMigration creates a new audit log migration
+func NewAuditLogMigration(db *mongo.Database, logger *zap.Logger) *AuditLogMigration {
+	return &AuditLogMigration{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Up runs the migration
+func (m *AuditLogMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running audit log migration for MongoDB")
+
+	auditLog := m.db.Collection("audit_log")
+	if _, err := auditLog.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenantId", Value: 1}, {Key: "entityId", Value: 1}, {Key: "occurredAt", Value: 1}},
+		Options: options.Index().SetName("idx_audit_log_entity"),
+	}); err != nil {
+		m.logger.Error("Failed to create entity index for the audit log", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Audit log migration for MongoDB completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *AuditLogMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back audit log migration for MongoDB")
+
+	if err := m.db.Collection("audit_log").Drop(ctx); err != nil {
+		m.logger.Error("Failed to drop audit log collection", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Audit log migration for MongoDB rolled back successfully")
+	return nil
+}
+
+// Definition returns what the migration changes in the database
+func (m *AuditLogMigration) Definition() string {
+	return `
+		up:
+			audit_log.createIndex({tenantId: 1, entityId: 1, occurredAt: 1}, {name: "idx_audit_log_entity"})
+		down:
+			audit_log.drop()
+	`
+}
@@ -0,0 +1,123 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// EntityHistoryMigration indexes the versioned history of parents and children,
+// and records the existing parents and children as its first revisions
+type EntityHistoryMigration struct {
+	db     *mongo.Database
+	logger *zap.Logger
+}
+
+// NewEntityHistoryMigration creates a new entity history migration
+func NewEntityHistoryMigration(db *mongo.Database, logger *zap.Logger) *EntityHistoryMigration {
+	return &EntityHistoryMigration{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Up runs the migration
+func (m *EntityHistoryMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running entity history migration for MongoDB")
+
+	for _, history := range []struct{ entities, revisions string }{
+		{entities: "parents", revisions: "parent_history"},
+		{entities: "children", revisions: "child_history"},
+	} {
+		revisions := m.db.Collection(history.revisions)
+		if _, err := revisions.Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "tenantId", Value: 1}, {Key: "entityId", Value: 1}, {Key: "recordedAt", Value: 1}},
+				Options: options.Index().SetName("idx_" + history.revisions + "_entity"),
+			},
+			{
+				Keys:    bson.D{{Key: "tenantId", Value: 1}, {Key: "parentId", Value: 1}},
+				Options: options.Index().SetName("idx_" + history.revisions + "_parent_id"),
+			},
+		}); err != nil {
+			m.logger.Error("Failed to create indexes for history", zap.Error(err), zap.String("collection", history.revisions))
+			return err
+		}
+
+		// The existing documents are recorded as of their last update, as their earlier versions are not known.
+		// A history that already has revisions was recorded by an earlier run.
+		count, err := revisions.CountDocuments(ctx, bson.M{})
+		if err != nil {
+			m.logger.Error("Failed to count revisions", zap.Error(err), zap.String("collection", history.revisions))
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+
+		pipeline := mongo.Pipeline{
+			{{Key: "$project", Value: bson.M{
+				"_id":        0,
+				"entityId":   "$_id",
+				"parentId":   "$parentId",
+				"tenantId":   "$tenantId",
+				"version":    "$version",
+				"deletedAt":  "$deleted_at",
+				"recordedAt": "$updatedAt",
+				"state":      "$$ROOT",
+			}}},
+			{{Key: "$merge", Value: bson.M{"into": history.revisions, "whenNotMatched": "insert"}}},
+		}
+		cursor, err := m.db.Collection(history.entities).Aggregate(ctx, pipeline)
+		if err != nil {
+			m.logger.Error("Failed to record existing documents in history", zap.Error(err), zap.String("collection", history.entities))
+			return err
+		}
+		if err := cursor.Close(ctx); err != nil {
+			return err
+		}
+	}
+
+	m.logger.Info("Entity history migration for MongoDB completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *EntityHistoryMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back entity history migration for MongoDB")
+
+	for _, collectionName := range []string{"child_history", "parent_history"} {
+		if err := m.db.Collection(collectionName).Drop(ctx); err != nil {
+			m.logger.Error("Failed to drop history collection", zap.Error(err), zap.String("collection", collectionName))
+			return err
+		}
+	}
+
+	m.logger.Info("Entity history migration for MongoDB rolled back successfully")
+	return nil
+}
+
+// Definition returns what the migration changes in the database
+func (m *EntityHistoryMigration) Definition() string {
+	return `
+		up:
+			parent_history.createIndex({tenantId: 1, entityId: 1, recordedAt: 1}, {name: "idx_parent_history_entity"})
+			parent_history.createIndex({tenantId: 1, parentId: 1}, {name: "idx_parent_history_parent_id"})
+			parents.aggregate([
+				{$project: {_id: 0, entityId: "$_id", parentId: "$parentId", tenantId: "$tenantId", version: "$version", deletedAt: "$deleted_at", recordedAt: "$updatedAt", state: "$$ROOT"}},
+				{$merge: {into: "parent_history", whenNotMatched: "insert"}}
+			]) unless parent_history has documents
+			child_history.createIndex({tenantId: 1, entityId: 1, recordedAt: 1}, {name: "idx_child_history_entity"})
+			child_history.createIndex({tenantId: 1, parentId: 1}, {name: "idx_child_history_parent_id"})
+			children.aggregate([
+				{$project: {_id: 0, entityId: "$_id", parentId: "$parentId", tenantId: "$tenantId", version: "$version", deletedAt: "$deleted_at", recordedAt: "$updatedAt", state: "$$ROOT"}},
+				{$merge: {into: "child_history", whenNotMatched: "insert"}}
+			]) unless child_history has documents
+		down:
+			child_history.drop()
+			parent_history.drop()
+	`
+}
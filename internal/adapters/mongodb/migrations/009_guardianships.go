@@ -0,0 +1,123 @@
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// GuardianshipsMigration indexes the guardianships between parents and children,
+// and makes every existing child the ward of its parent
+type GuardianshipsMigration struct {
+	db     *mongo.Database
+	logger *zap.Logger
+}
+
+// NewGuardianshipsMigration creates a new guardianships migration
+func NewGuardianshipsMigration(db *mongo.Database, logger *zap.Logger) *GuardianshipsMigration {
+	return &GuardianshipsMigration{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Up runs the migration
+func (m *GuardianshipsMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running guardianships migration for MongoDB")
+
+	guardianships := m.db.Collection("guardianships")
+	if _, err := guardianships.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "tenantId", Value: 1}, {Key: "childId", Value: 1}},
+			Options: options.Index().SetName("idx_guardianships_child_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "tenantId", Value: 1}, {Key: "parentId", Value: 1}},
+			Options: options.Index().SetName("idx_guardianships_parent_id"),
+		},
+	}); err != nil {
+		m.logger.Error("Failed to create indexes for guardianships", zap.Error(err))
+		return err
+	}
+
+	// The parent of every child that has no guardianship yet becomes its guardian and primary
+	// contact, since the child was created
+	cursor, err := m.db.Collection("children").Find(ctx, bson.M{})
+	if err != nil {
+		m.logger.Error("Failed to list children", zap.Error(err))
+		return err
+	}
+
+	var children []struct {
+		ID        uuid.UUID `bson:"_id"`
+		ParentID  uuid.UUID `bson:"parentId"`
+		TenantID  string    `bson:"tenantId"`
+		CreatedAt time.Time `bson:"createdAt"`
+	}
+	if err := cursor.All(ctx, &children); err != nil {
+		m.logger.Error("Failed to decode children", zap.Error(err))
+		return err
+	}
+
+	for _, child := range children {
+		count, err := guardianships.CountDocuments(ctx, bson.M{"childId": child.ID})
+		if err != nil {
+			m.logger.Error("Failed to count guardianships", zap.Error(err), zap.String("child_id", child.ID.String()))
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+
+		_, err = guardianships.InsertOne(ctx, bson.M{
+			"_id":            uuid.New(),
+			"parentId":       child.ParentID,
+			"childId":        child.ID,
+			"tenantId":       child.TenantID,
+			"type":           "GUARDIAN",
+			"startDate":      child.CreatedAt,
+			"primaryContact": true,
+			"createdAt":      child.CreatedAt,
+			"updatedAt":      child.CreatedAt,
+		})
+		if err != nil {
+			m.logger.Error("Failed to create guardianship", zap.Error(err), zap.String("child_id", child.ID.String()))
+			return err
+		}
+	}
+
+	m.logger.Info("Guardianships migration for MongoDB completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *GuardianshipsMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back guardianships migration for MongoDB")
+
+	if err := m.db.Collection("guardianships").Drop(ctx); err != nil {
+		m.logger.Error("Failed to drop guardianships collection", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Guardianships migration for MongoDB rolled back successfully")
+	return nil
+}
+
+// Definition returns what the migration changes in the database
+func (m *GuardianshipsMigration) Definition() string {
+	return `
+		up:
+			guardianships.createIndex({tenantId: 1, childId: 1}, {name: "idx_guardianships_child_id"})
+			guardianships.createIndex({tenantId: 1, parentId: 1}, {name: "idx_guardianships_parent_id"})
+			for each child without guardianships:
+				guardianships.insertOne({_id: uuid(), parentId: child.parentId, childId: child._id, tenantId: child.tenantId,
+					type: "GUARDIAN", startDate: child.createdAt, primaryContact: true, createdAt: child.createdAt, updatedAt: child.createdAt})
+		down:
+			guardianships.drop()
+	`
+}
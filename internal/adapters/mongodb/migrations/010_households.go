@@ -0,0 +1,76 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// HouseholdsMigration indexes the households that group parents and children
+type HouseholdsMigration struct {
+	db     *mongo.Database
+	logger *zap.Logger
+}
+
+// NewHouseholdsMigration creates a new households migration
+func NewHouseholdsMigration(db *mongo.Database, logger *zap.Logger) *HouseholdsMigration {
+	return &HouseholdsMigration{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Up runs the migration
+func (m *HouseholdsMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running households migration for MongoDB")
+
+	// A child belongs to at most one household; households without children are left out of
+	// the unique index, since their empty lists would otherwise collide
+	households := m.db.Collection("households")
+	if _, err := households.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "childIds", Value: 1}},
+			Options: options.Index().
+				SetName("idx_households_child_ids").
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"childIds.0": bson.M{"$exists": true}}),
+		},
+		{
+			Keys:    bson.D{{Key: "tenantId", Value: 1}, {Key: "parentIds", Value: 1}},
+			Options: options.Index().SetName("idx_households_parent_ids"),
+		},
+	}); err != nil {
+		m.logger.Error("Failed to create indexes for households", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Households migration for MongoDB completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *HouseholdsMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back households migration for MongoDB")
+
+	if err := m.db.Collection("households").Drop(ctx); err != nil {
+		m.logger.Error("Failed to drop households collection", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Households migration for MongoDB rolled back successfully")
+	return nil
+}
+
+// Definition returns what the migration changes in the database
+func (m *HouseholdsMigration) Definition() string {
+	return `
+		up:
+			households.createIndex({childIds: 1}, {name: "idx_households_child_ids", unique: true, partialFilterExpression: {"childIds.0": {$exists: true}}})
+			households.createIndex({tenantId: 1, parentIds: 1}, {name: "idx_households_parent_ids"})
+		down:
+			households.drop()
+	`
+}
@@ -0,0 +1,74 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// ParentContactDetailsMigration indexes the postal code and phone numbers of parents, by which parents can be filtered
+type ParentContactDetailsMigration struct {
+	db     *mongo.Database
+	logger *zap.Logger
+}
+
+// NewParentContactDetailsMigration creates a new parent contact details migration
+func NewParentContactDetailsMigration(db *mongo.Database, logger *zap.Logger) *ParentContactDetailsMigration {
+	return &ParentContactDetailsMigration{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Up runs the migration
+func (m *ParentContactDetailsMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running parent contact details migration for MongoDB")
+
+	// Parents without contact details have no address or phones field, so the documents need no change
+	if _, err := m.db.Collection("parents").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "tenantId", Value: 1}, {Key: "address.postalCode", Value: 1}},
+			Options: options.Index().SetName("idx_parents_postal_code"),
+		},
+		{
+			Keys:    bson.D{{Key: "tenantId", Value: 1}, {Key: "phones.number", Value: 1}},
+			Options: options.Index().SetName("idx_parents_phones"),
+		},
+	}); err != nil {
+		m.logger.Error("Failed to create contact details indexes for parents collection", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Parent contact details migration for MongoDB completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *ParentContactDetailsMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back parent contact details migration for MongoDB")
+
+	for _, name := range []string{"idx_parents_postal_code", "idx_parents_phones"} {
+		if _, err := m.db.Collection("parents").Indexes().DropOne(ctx, name); err != nil {
+			m.logger.Error("Failed to drop contact details index of parents collection", zap.String("index", name), zap.Error(err))
+			return err
+		}
+	}
+
+	m.logger.Info("Parent contact details migration for MongoDB rolled back successfully")
+	return nil
+}
+
+// Definition returns what the migration changes in the database
+func (m *ParentContactDetailsMigration) Definition() string {
+	return `
+		up:
+			parents.createIndex({tenantId: 1, "address.postalCode": 1}, {name: "idx_parents_postal_code"})
+			parents.createIndex({tenantId: 1, "phones.number": 1}, {name: "idx_parents_phones"})
+		down:
+			parents.dropIndex("idx_parents_postal_code")
+			parents.dropIndex("idx_parents_phones")
+	`
+}
@@ -0,0 +1,122 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// UniqueParentEmailMigration makes the email of a parent unique among the non-deleted parents of a tenant
+type UniqueParentEmailMigration struct {
+	db     *mongo.Database
+	logger *zap.Logger
+}
+
+// NewUniqueParentEmailMigration creates a new unique parent email migration
+func NewUniqueParentEmailMigration(db *mongo.Database, logger *zap.Logger) *UniqueParentEmailMigration {
+	return &UniqueParentEmailMigration{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Up runs the migration
+func (m *UniqueParentEmailMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running unique parent email migration for MongoDB")
+
+	parents := m.db.Collection("parents")
+
+	// The index cannot be created while parents share an email, so they are reported first
+	cursor, err := parents.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"deleted_at": nil}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"tenantId": "$tenantId", "email": bson.M{"$toLower": "$email"}},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+		{{Key: "$count", Value: "emails"}},
+	})
+	if err != nil {
+		m.logger.Error("Failed to look for duplicate parent emails", zap.Error(err))
+		return err
+	}
+	var result []struct {
+		Emails int `bson:"emails"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		m.logger.Error("Failed to look for duplicate parent emails", zap.Error(err))
+		return err
+	}
+	if len(result) > 0 && result[0].Emails > 0 {
+		m.logger.Error("Parents share an email", zap.Int("emails", result[0].Emails))
+		return fmt.Errorf("%d emails are shared by several parents; merge or delete them before migrating", result[0].Emails)
+	}
+
+	// The index replaces the one of the initial schema, which has the same name
+	if _, err := parents.Indexes().DropOne(ctx, "idx_parents_email"); err != nil {
+		m.logger.Info("No existing index idx_parents_email to drop, continuing")
+	}
+
+	// Emails that differ only in case are the same, and deleted parents release their email
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "tenantId", Value: 1}, {Key: "email", Value: 1}},
+		Options: options.Index().
+			SetName("idx_parents_email").
+			SetUnique(true).
+			SetCollation(&options.Collation{Locale: "en", Strength: 2}).
+			SetPartialFilterExpression(bson.M{"deleted_at": nil}),
+	}
+
+	if _, err := parents.Indexes().CreateOne(ctx, indexModel); err != nil {
+		m.logger.Error("Failed to create unique email index for parents collection", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Unique parent email migration for MongoDB completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *UniqueParentEmailMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back unique parent email migration for MongoDB")
+
+	parents := m.db.Collection("parents")
+	if _, err := parents.Indexes().DropOne(ctx, "idx_parents_email"); err != nil {
+		m.logger.Error("Failed to drop unique email index of parents collection", zap.Error(err))
+		return err
+	}
+
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().SetName("idx_parents_email"),
+	}
+	if _, err := parents.Indexes().CreateOne(ctx, indexModel); err != nil {
+		m.logger.Error("Failed to restore email index of parents collection", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Unique parent email migration for MongoDB rolled back successfully")
+	return nil
+}
+
+// Definition returns what the migration changes in the database
+func (m *UniqueParentEmailMigration) Definition() string {
+	return `
+		up:
+			fail if parents.aggregate([
+				{$match: {deleted_at: null}},
+				{$group: {_id: {tenantId: "$tenantId", email: {$toLower: "$email"}}, count: {$sum: 1}}},
+				{$match: {count: {$gt: 1}}}
+			]) has documents
+			parents.dropIndex("idx_parents_email")
+			parents.createIndex({tenantId: 1, email: 1}, {name: "idx_parents_email", unique: true,
+				collation: {locale: "en", strength: 2}, partialFilterExpression: {deleted_at: null}})
+		down:
+			parents.dropIndex("idx_parents_email")
+			parents.createIndex({email: 1}, {name: "idx_parents_email"})
+	`
+}
@@ -0,0 +1,102 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// OutboxLeasesMigration adds what relays need to lease the messages of the outbox family by family,
+// and the dead letters of the messages that cannot be delivered
+type OutboxLeasesMigration struct {
+	db     *mongo.Database
+	logger *zap.Logger
+}
+
+// NewOutboxLeasesMigration creates a new outbox leases migration
+func NewOutboxLeasesMigration(db *mongo.Database, logger *zap.Logger) *OutboxLeasesMigration {
+	return &OutboxLeasesMigration{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Up runs the migration
+func (m *OutboxLeasesMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running outbox leases migration for MongoDB")
+
+	existing, err := m.db.ListCollectionNames(ctx, bson.M{"name": "outbox_dead_letters"})
+	if err != nil {
+		m.logger.Error("Failed to list outbox collections", zap.Error(err))
+		return err
+	}
+	if len(existing) == 0 {
+		if err := m.db.CreateCollection(ctx, "outbox_dead_letters"); err != nil {
+			m.logger.Error("Failed to create outbox_dead_letters collection", zap.Error(err))
+			return err
+		}
+	}
+
+	// Relays look up the earliest message of a family, and select the messages that are due
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "aggregateId", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("idx_outbox_aggregate_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "nextAttemptAt", Value: 1}},
+			Options: options.Index().SetName("idx_outbox_next_attempt_at"),
+		},
+	}
+
+	if _, err := m.db.Collection("outbox").Indexes().CreateMany(ctx, indexModels); err != nil {
+		m.logger.Error("Failed to create indexes for outbox collection", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Outbox leases migration for MongoDB completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *OutboxLeasesMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back outbox leases migration for MongoDB")
+
+	outbox := m.db.Collection("outbox")
+	for _, name := range []string{"idx_outbox_aggregate_id", "idx_outbox_next_attempt_at"} {
+		if _, err := outbox.Indexes().DropOne(ctx, name); err != nil {
+			m.logger.Info("No existing index to drop, continuing", zap.String("index", name))
+		}
+	}
+
+	if _, err := outbox.UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"leasedUntil": ""}}); err != nil {
+		m.logger.Error("Failed to remove the leases of outbox messages", zap.Error(err))
+		return err
+	}
+
+	if err := m.db.Collection("outbox_dead_letters").Drop(ctx); err != nil {
+		m.logger.Error("Failed to drop outbox_dead_letters collection", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Outbox leases migration for MongoDB rolled back successfully")
+	return nil
+}
+
+// Definition returns what the migration changes in the database
+func (m *OutboxLeasesMigration) Definition() string {
+	return `
+		up:
+			createCollection("outbox_dead_letters")
+			outbox.createIndex({aggregateId: 1, _id: 1}, {name: "idx_outbox_aggregate_id"})
+			outbox.createIndex({nextAttemptAt: 1}, {name: "idx_outbox_next_attempt_at"})
+		down:
+			outbox.dropIndex("idx_outbox_aggregate_id")
+			outbox.dropIndex("idx_outbox_next_attempt_at")
+			outbox.updateMany({}, {$unset: {leasedUntil: ""}})
+			outbox_dead_letters.drop()
+	`
+}
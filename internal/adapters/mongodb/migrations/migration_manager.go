@@ -3,9 +3,14 @@ package migrations
 import (
 	"context"
 	"fmt"
+	"os"
 	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -14,19 +19,80 @@ import (
 
 // Migration represents a database migration
 type Migration struct {
-	Version     int       `bson:"version"`
-	Description string    `bson:"description"`
-	AppliedAt   time.Time `bson:"applied_at"`
+	Version     int           `bson:"version"`
+	Description string        `bson:"description"`
+	AppliedAt   time.Time     `bson:"applied_at"`
+	Duration    time.Duration `bson:"duration"`
+
+	// Checksum is the checksum of the definition of the migration when it was applied, or empty for
+	// migrations applied before checksums were recorded
+	Checksum string `bson:"checksum,omitempty"`
 }
 
 // MigrationFunc is a function that performs a migration
 type MigrationFunc func(ctx context.Context, db *mongo.Database) error
 
-// MigrationDefinition defines a migration with its version, description, and function
+// MigrationDefinition defines a migration with its version, description, the checksum of its definition,
+// and the functions that apply and revert it
 type MigrationDefinition struct {
 	Version     int
 	Description string
-	Migrate     MigrationFunc
+	Checksum    string
+	Up          MigrationFunc
+	Down        MigrationFunc
+}
+
+// MigrationStatus is the state of a migration that is registered, applied, or both
+type MigrationStatus struct {
+	Version     int
+	Description string
+
+	// Applied reports whether the migration is applied, in which case AppliedAt and Duration tell when
+	// it was applied and how long it took
+	Applied   bool
+	AppliedAt time.Time
+	Duration  time.Duration
+
+	// Registered is false for an applied migration this version of the service does not know, such as
+	// one applied by a newer version
+	Registered bool
+
+	// Drifted reports whether the definition of an applied migration changed after it was applied
+	Drifted bool
+}
+
+// DriftError is returned when the definitions of applied migrations changed after they were applied,
+// so that the database may not have the schema they now define
+type DriftError struct {
+	Versions []int
+}
+
+// Error returns the message of the drift error
+func (e *DriftError) Error() string {
+	versions := make([]string, len(e.Versions))
+	for i, version := range e.Versions {
+		versions[i] = strconv.Itoa(version)
+	}
+	return fmt.Sprintf("migrations %s changed after they were applied; restore them, or force the current version to accept their definitions",
+		strings.Join(versions, ", "))
+}
+
+// The lock that keeps processes from running migrations at the same time is a document of the migration
+// locks collection, which expires unless its owner renews it, so that a process that dies while it holds
+// the lock does not keep the others waiting
+const (
+	lockCollection    = "migration_locks"
+	lockID            = "migrations"
+	lockTTL           = time.Minute
+	lockRetryInterval = time.Second
+)
+
+// migrationLock is the lock document
+type migrationLock struct {
+	ID        string    `bson:"_id"`
+	Owner     string    `bson:"owner"`
+	LockedAt  time.Time `bson:"lockedAt"`
+	ExpiresAt time.Time `bson:"expiresAt"`
 }
 
 // MigrationManager manages database migrations
@@ -45,12 +111,15 @@ func NewMigrationManager(db *mongo.Database, logger *zap.Logger) *MigrationManag
 	}
 }
 
-// RegisterMigration registers a migration
-func (m *MigrationManager) RegisterMigration(version int, description string, migrate MigrationFunc) {
+// RegisterMigration registers a migration with the checksum of its definition, and the functions that apply
+// and revert it. An empty checksum is never reported as drifted.
+func (m *MigrationManager) RegisterMigration(version int, description, checksum string, up, down MigrationFunc) {
 	m.migrations[version] = MigrationDefinition{
 		Version:     version,
 		Description: description,
-		Migrate:     migrate,
+		Checksum:    checksum,
+		Up:          up,
+		Down:        down,
 	}
 }
 
@@ -85,10 +154,11 @@ func (m *MigrationManager) EnsureMigrationsCollection(ctx context.Context) error
 	return nil
 }
 
-// GetAppliedMigrations gets all applied migrations
+// GetAppliedMigrations gets all applied migrations by version, with when they were applied and how long they took
 func (m *MigrationManager) GetAppliedMigrations(ctx context.Context) ([]Migration, error) {
 	// Get all migrations from the migrations collection
-	cursor, err := m.db.Collection("migrations").Find(ctx, bson.M{})
+	findOptions := options.Find().SetSort(bson.D{{Key: "version", Value: 1}})
+	cursor, err := m.db.Collection("migrations").Find(ctx, bson.M{}, findOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
 	}
@@ -103,62 +173,451 @@ func (m *MigrationManager) GetAppliedMigrations(ctx context.Context) ([]Migratio
 	return migrations, nil
 }
 
-// MigrateUp applies all pending migrations
-func (m *MigrationManager) MigrateUp(ctx context.Context) error {
+// Status gets the state of every registered or applied migration, by version
+func (m *MigrationManager) Status(ctx context.Context) ([]MigrationStatus, error) {
 	// Ensure migrations collection exists
 	if err := m.EnsureMigrationsCollection(ctx); err != nil {
-		return err
+		return nil, err
 	}
 
-	// Get applied migrations
 	appliedMigrations, err := m.GetAppliedMigrations(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Create a map of applied migrations for quick lookup
-	appliedMap := make(map[int]bool)
+	drifted := make(map[int]bool)
+	for _, version := range m.drift(appliedMigrations) {
+		drifted[version] = true
+	}
+
+	statuses := make(map[int]*MigrationStatus)
+	for _, version := range m.versions() {
+		migration := m.migrations[version]
+		statuses[version] = &MigrationStatus{Version: version, Description: migration.Description, Registered: true}
+	}
 	for _, migration := range appliedMigrations {
-		appliedMap[migration.Version] = true
+		status, ok := statuses[migration.Version]
+		if !ok {
+			status = &MigrationStatus{Version: migration.Version, Description: migration.Description}
+			statuses[migration.Version] = status
+		}
+		status.Applied = true
+		status.AppliedAt = migration.AppliedAt
+		status.Duration = migration.Duration
+		status.Drifted = drifted[migration.Version]
 	}
 
-	// Get all migration versions
-	var versions []int
-	for version := range m.migrations {
-		versions = append(versions, version)
+	result := make([]MigrationStatus, 0, len(statuses))
+	for _, status := range statuses {
+		result = append(result, *status)
 	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result, nil
+}
 
-	// Sort versions
-	sort.Ints(versions)
+// MigrateUp applies all pending migrations
+func (m *MigrationManager) MigrateUp(ctx context.Context) error {
+	return m.Up(ctx, 0)
+}
+
+// Up applies the first n pending migrations, or all of them if n is 0, in the order of their versions
+func (m *MigrationManager) Up(ctx context.Context, n int) error {
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
-	// Apply pending migrations
-	for _, version := range versions {
+	appliedMigrations, err := m.prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	appliedMap := make(map[int]bool, len(appliedMigrations))
+	for _, migration := range appliedMigrations {
+		appliedMap[migration.Version] = true
+	}
+
+	applied := 0
+	for _, version := range m.versions() {
 		// Skip if already applied
 		if appliedMap[version] {
 			m.logger.Info("Migration already applied", zap.Int("version", version))
 			continue
 		}
+		if n > 0 && applied == n {
+			break
+		}
 
-		migration := m.migrations[version]
-		m.logger.Info("Applying migration", zap.Int("version", version), zap.String("description", migration.Description))
+		if err := m.apply(ctx, m.migrations[version]); err != nil {
+			return err
+		}
+		applied++
+	}
 
-		// Apply migration
-		if err := migration.Migrate(ctx, m.db); err != nil {
-			return fmt.Errorf("failed to apply migration %d: %w", version, err)
+	if applied == 0 {
+		m.logger.Info("No pending migrations")
+	}
+	return nil
+}
+
+// Down reverts the n latest applied migrations, latest first
+func (m *MigrationManager) Down(ctx context.Context, n int) error {
+	if n <= 0 {
+		return fmt.Errorf("the number of migrations to revert must be positive")
+	}
+
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	appliedMigrations, err := m.prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := len(appliedMigrations) - 1; i >= 0 && n > 0; i-- {
+		if err := m.revert(ctx, appliedMigrations[i].Version); err != nil {
+			return err
+		}
+		n--
+	}
+
+	return nil
+}
+
+// Goto applies or reverts migrations until the applied migrations are those up to the given version:
+// the pending migrations up to it are applied, and the applied migrations after it are reverted.
+// Version 0 reverts all migrations.
+func (m *MigrationManager) Goto(ctx context.Context, version int) error {
+	if _, ok := m.migrations[version]; !ok && version != 0 {
+		return fmt.Errorf("migration %d does not exist", version)
+	}
+
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	appliedMigrations, err := m.prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Revert the later migrations first, latest first
+	appliedMap := make(map[int]bool)
+	for i := len(appliedMigrations) - 1; i >= 0; i-- {
+		applied := appliedMigrations[i].Version
+		if applied <= version {
+			appliedMap[applied] = true
+			continue
 		}
+		if err := m.revert(ctx, applied); err != nil {
+			return err
+		}
+	}
 
-		// Record migration
-		_, err := m.db.Collection("migrations").InsertOne(ctx, Migration{
-			Version:     version,
-			Description: migration.Description,
-			AppliedAt:   time.Now().UTC(),
-		})
+	for _, pending := range m.versions() {
+		if pending > version {
+			break
+		}
+		if appliedMap[pending] {
+			continue
+		}
+		if err := m.apply(ctx, m.migrations[pending]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Redo reverts the latest applied migration and applies it again
+func (m *MigrationManager) Redo(ctx context.Context) error {
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	appliedMigrations, err := m.prepare(ctx)
+	if err != nil {
+		return err
+	}
+	if len(appliedMigrations) == 0 {
+		return fmt.Errorf("no migration is applied")
+	}
+
+	version := appliedMigrations[len(appliedMigrations)-1].Version
+	if err := m.revert(ctx, version); err != nil {
+		return err
+	}
+	return m.apply(ctx, m.migrations[version])
+}
+
+// Force records the migrations up to the given version as applied, with the checksums of their current
+// definitions, and those after it as not applied, without applying or reverting any of them. It repairs
+// the migrations collection after a migration failed halfway and the database was fixed by hand, and
+// accepts the definitions of drifted migrations once the database matches them.
+func (m *MigrationManager) Force(ctx context.Context, version int) error {
+	if _, ok := m.migrations[version]; !ok && version != 0 {
+		return fmt.Errorf("migration %d does not exist", version)
+	}
+
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Ensure migrations collection exists
+	if err := m.EnsureMigrationsCollection(ctx); err != nil {
+		return err
+	}
+
+	collection := m.db.Collection("migrations")
+	if _, err := collection.DeleteMany(ctx, bson.M{"version": bson.M{"$gt": version}}); err != nil {
+		return fmt.Errorf("failed to force version %d: %w", version, err)
+	}
+	for _, registered := range m.versions() {
+		if registered > version {
+			break
+		}
+		_, err := collection.UpdateOne(ctx,
+			bson.M{"version": registered},
+			bson.M{
+				"$set": bson.M{"checksum": m.migrations[registered].Checksum},
+				"$setOnInsert": bson.M{
+					"description": m.migrations[registered].Description,
+					"applied_at":  time.Now().UTC(),
+					"duration":    time.Duration(0),
+				},
+			},
+			options.Update().SetUpsert(true))
+		if err != nil {
+			return fmt.Errorf("failed to force version %d: %w", version, err)
+		}
+	}
+
+	m.logger.Warn("Forced migration version", zap.Int("version", version))
+	return nil
+}
+
+// versions returns the versions of the registered migrations in ascending order
+func (m *MigrationManager) versions() []int {
+	versions := make([]int, 0, len(m.migrations))
+	for version := range m.migrations {
+		versions = append(versions, version)
+	}
+	sort.Ints(versions)
+	return versions
+}
+
+// lock takes the lock of migrations, waiting for another process that holds it to release it or let it
+// expire, and returns the function that releases it. The lock is renewed until it is released, however
+// long the migrations take.
+func (m *MigrationManager) lock(ctx context.Context) (func(), error) {
+	collection := m.db.Collection(lockCollection)
+
+	// The TTL index removes expired locks, such as those of processes that died
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+		Options: options.Index().SetName("idx_migration_locks_expires_at").SetExpireAfterSeconds(0),
+	}
+	if _, err := collection.Indexes().CreateOne(ctx, indexModel); err != nil {
+		return nil, fmt.Errorf("failed to create index on migration locks collection: %w", err)
+	}
+
+	hostname, _ := os.Hostname()
+	owner := hostname + "/" + uuid.NewString()
+
+	for waiting := false; ; waiting = true {
+		acquired, err := m.tryLock(ctx, collection, owner)
+		if err != nil {
+			return nil, err
+		}
+		if acquired {
+			break
+		}
+
+		if !waiting {
+			m.logger.Info("Waiting for another process to finish its migrations")
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to acquire the migration lock: %w", ctx.Err())
+		case <-time.After(lockRetryInterval):
+		}
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		m.renewLock(collection, owner, done)
+	}()
+
+	return func() {
+		close(done)
+		wg.Wait()
+
+		// The lock is released even when the migrations were interrupted
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+
+		if _, err := collection.DeleteOne(releaseCtx, bson.M{"_id": lockID, "owner": owner}); err != nil {
+			m.logger.Error("Failed to release the migration lock", zap.Error(err))
+		}
+	}, nil
+}
+
+// tryLock takes the lock of migrations if no other process holds it, and reports whether it did
+func (m *MigrationManager) tryLock(ctx context.Context, collection *mongo.Collection, owner string) (bool, error) {
+	now := time.Now().UTC()
+
+	// The lock document is taken over once it expired, as the TTL index removes it only once a minute;
+	// while another process holds it, the upsert fails on its ID
+	_, err := collection.ReplaceOne(ctx,
+		bson.M{"_id": lockID, "expiresAt": bson.M{"$lte": now}},
+		migrationLock{ID: lockID, Owner: owner, LockedAt: now, ExpiresAt: now.Add(lockTTL)},
+		options.Replace().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire the migration lock: %w", err)
+	}
+	return true, nil
+}
+
+// renewLock pushes back the expiry of the lock of migrations until done is closed
+func (m *MigrationManager) renewLock(collection *mongo.Collection, owner string, done <-chan struct{}) {
+	ticker := time.NewTicker(lockTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), lockTTL/3)
+		result, err := collection.UpdateOne(ctx,
+			bson.M{"_id": lockID, "owner": owner},
+			bson.M{"$set": bson.M{"expiresAt": time.Now().UTC().Add(lockTTL)}})
+		cancel()
+
+		switch {
+		case err != nil:
+			m.logger.Error("Failed to renew the migration lock", zap.Error(err))
+		case result.MatchedCount == 0:
+			m.logger.Error("Lost the migration lock, which another process may have taken")
+		}
+	}
+}
+
+// prepare ensures that the migrations collection exists, records the checksums of the migrations applied
+// before checksums were recorded, and returns the applied migrations, or a DriftError if the definitions
+// of some of them changed after they were applied
+func (m *MigrationManager) prepare(ctx context.Context) ([]Migration, error) {
+	// Ensure migrations collection exists
+	if err := m.EnsureMigrationsCollection(ctx); err != nil {
+		return nil, err
+	}
+
+	appliedMigrations, err := m.GetAppliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, migration := range appliedMigrations {
+		definition, ok := m.migrations[migration.Version]
+		if migration.Checksum != "" || !ok || definition.Checksum == "" {
+			continue
+		}
+		_, err := m.db.Collection("migrations").UpdateOne(ctx,
+			bson.M{"version": migration.Version},
+			bson.M{"$set": bson.M{"checksum": definition.Checksum}})
 		if err != nil {
-			return fmt.Errorf("failed to record migration %d: %w", version, err)
+			return nil, fmt.Errorf("failed to record the checksum of migration %d: %w", migration.Version, err)
 		}
+		appliedMigrations[i].Checksum = definition.Checksum
+		m.logger.Info("Recorded the checksum of migration", zap.Int("version", migration.Version))
+	}
+
+	if drifted := m.drift(appliedMigrations); len(drifted) > 0 {
+		return nil, &DriftError{Versions: drifted}
+	}
+	return appliedMigrations, nil
+}
+
+// drift returns the versions of the applied migrations whose definitions changed after they were applied
+func (m *MigrationManager) drift(appliedMigrations []Migration) []int {
+	var drifted []int
+	for _, migration := range appliedMigrations {
+		definition, ok := m.migrations[migration.Version]
+		if !ok || migration.Checksum == "" || definition.Checksum == "" {
+			continue
+		}
+		if migration.Checksum != definition.Checksum {
+			drifted = append(drifted, migration.Version)
+		}
+	}
+	return drifted
+}
+
+// apply applies a migration and records it, with how long it took
+func (m *MigrationManager) apply(ctx context.Context, migration MigrationDefinition) error {
+	version := migration.Version
+	m.logger.Info("Applying migration", zap.Int("version", version), zap.String("description", migration.Description))
+
+	// Apply migration
+	start := time.Now()
+	if err := migration.Up(ctx, m.db); err != nil {
+		return fmt.Errorf("failed to apply migration %d: %w", version, err)
+	}
+	duration := time.Since(start)
+
+	// Record migration
+	_, err := m.db.Collection("migrations").InsertOne(ctx, Migration{
+		Version:     version,
+		Description: migration.Description,
+		AppliedAt:   time.Now().UTC(),
+		Duration:    duration,
+		Checksum:    migration.Checksum,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record migration %d: %w", version, err)
+	}
+
+	m.logger.Info("Migration applied successfully", zap.Int("version", version), zap.Duration("duration", duration))
+	return nil
+}
+
+// revert reverts an applied migration and removes its record
+func (m *MigrationManager) revert(ctx context.Context, version int) error {
+	migration, ok := m.migrations[version]
+	if !ok {
+		return fmt.Errorf("migration %d is applied but unknown to this version of the service, so it cannot be reverted", version)
+	}
+	m.logger.Info("Reverting migration", zap.Int("version", version), zap.String("description", migration.Description))
+
+	// Revert migration
+	start := time.Now()
+	if err := migration.Down(ctx, m.db); err != nil {
+		return fmt.Errorf("failed to revert migration %d: %w", version, err)
+	}
 
-		m.logger.Info("Migration applied successfully", zap.Int("version", version))
+	// Remove the record of the migration
+	if _, err := m.db.Collection("migrations").DeleteOne(ctx, bson.M{"version": version}); err != nil {
+		return fmt.Errorf("failed to remove the record of migration %d: %w", version, err)
 	}
 
+	m.logger.Info("Migration reverted successfully", zap.Int("version", version), zap.Duration("duration", time.Since(start)))
 	return nil
 }
@@ -2,6 +2,9 @@ package migrations
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
@@ -29,14 +32,85 @@ func NewRegistry(db *mongo.Database, logger *zap.Logger) *Registry {
 // registerMigrations registers all migrations
 func (r *Registry) registerMigrations() {
 	// Register initial schema migration
-	r.manager.RegisterMigration(1, "Initial schema and sample data", func(ctx context.Context, db *mongo.Database) error {
-		migration := NewInitialSchemaMigration(db, r.logger)
-		return migration.Up(ctx)
-	})
+	register(r, 1, "Initial schema and sample data", NewInitialSchemaMigration)
+
+	// Register the link between parents and user accounts
+	register(r, 2, "Link parents to user accounts", NewParentUserLinkMigration)
+
+	// Register the isolation of tenants
+	register(r, 3, "Isolate tenants", NewTenantIsolationMigration)
+
+	// Register the versions used for optimistic concurrency control
+	register(r, 4, "Version parents and children", NewEntityVersionsMigration)
+
+	// Register the transactional outbox of domain events
+	register(r, 5, "Add the outbox of domain events", NewOutboxMigration)
+
+	// Register the webhook subscriptions and deliveries
+	register(r, 6, "Add webhook subscriptions and deliveries", NewWebhooksMigration)
+
+	// Register the audit log of changes
+	register(r, 7, "Add the audit log", NewAuditLogMigration)
+
+	// Register the versioned history of parents and children
+	register(r, 8, "Add the history of parents and children", NewEntityHistoryMigration)
+
+	// Register the guardianships between parents and children
+	register(r, 9, "Relate parents and children through guardianships", NewGuardianshipsMigration)
+
+	// Register the households of parents and children
+	register(r, 10, "Group parents and children in households", NewHouseholdsMigration)
+
+	// Register the contact details of parents
+	register(r, 11, "Add the contact details of parents", NewParentContactDetailsMigration)
+
+	// Register the uniqueness of the emails of parents
+	register(r, 12, "Make the emails of parents unique", NewUniqueParentEmailMigration)
+
+	// Register the leases and dead letters of the outbox
+	register(r, 13, "Lease outbox messages and quarantine dead letters", NewOutboxLeasesMigration)
 
 	// Add more migrations here as needed
 }
 
+// reversibleMigration is a migration that can be applied and reverted
+type reversibleMigration interface {
+	Up(ctx context.Context) error
+	Down(ctx context.Context) error
+}
+
+// definedMigration is a reversible migration with a definition of what it changes in the database, whose
+// checksum tells whether the migration was edited after it was applied. The migrations are code, so each
+// declares its definition, which must change whenever what the migration does changes.
+type definedMigration interface {
+	reversibleMigration
+	Definition() string
+}
+
+// register registers a migration, which the Up and Down methods of the migration newMigration creates
+// apply and revert
+func register[M definedMigration](r *Registry, version int, description string, newMigration func(db *mongo.Database, logger *zap.Logger) M) {
+	r.manager.RegisterMigration(version, description, checksum(newMigration(nil, r.logger).Definition()),
+		func(ctx context.Context, db *mongo.Database) error {
+			return newMigration(db, r.logger).Up(ctx)
+		},
+		func(ctx context.Context, db *mongo.Database) error {
+			return newMigration(db, r.logger).Down(ctx)
+		})
+}
+
+// checksum returns the checksum of the definition of a migration: the SHA-256 of the definition, with every
+// run of white space read as a single space, so that reindenting it or changing its line endings is no change
+func checksum(definition string) string {
+	sum := sha256.Sum256([]byte(strings.Join(strings.Fields(definition), " ")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Manager returns the migration manager, to apply, revert and inspect the migrations one by one
+func (r *Registry) Manager() *MigrationManager {
+	return r.manager
+}
+
 // MigrateUp runs all migrations
 func (r *Registry) MigrateUp(ctx context.Context) error {
 	r.logger.Info("Running MongoDB migrations")
@@ -0,0 +1,382 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// outboxSequenceTimeout bounds the increment of the outbox sequence, which runs outside of the caller's context
+const outboxSequenceTimeout = 5 * time.Second
+
+// outboxDocument is the MongoDB representation of a message in the outbox.
+// The event is stored as JSON, in the form in which it is delivered.
+type outboxDocument struct {
+	Sequence      int64     `bson:"_id"`
+	EventID       string    `bson:"eventId"`
+	TenantID      string    `bson:"tenantId"`
+	AggregateID   string    `bson:"aggregateId"`
+	EventType     string    `bson:"eventType"`
+	Payload       string    `bson:"payload"`
+	OccurredAt    time.Time `bson:"occurredAt"`
+	Attempts      int       `bson:"attempts"`
+	NextAttemptAt time.Time `bson:"nextAttemptAt"`
+	LastError     string    `bson:"lastError,omitempty"`
+
+	// LeasedUntil is when the lease of the relay that is delivering the message ends
+	LeasedUntil *time.Time `bson:"leasedUntil,omitempty"`
+}
+
+// outboxDeadLetterDocument is the MongoDB representation of a message that was quarantined
+type outboxDeadLetterDocument struct {
+	outboxDocument `bson:",inline"`
+	Reason         string    `bson:"reason"`
+	QuarantinedAt  time.Time `bson:"quarantinedAt"`
+}
+
+// OutboxRepository implements the ports.OutboxRepository interface for MongoDB.
+// Messages are stored in the outbox collection. Their sequence is taken from the counters
+// collection, and the outbox_aggregates collection serializes the transactions that record
+// events of the same family. Messages that cannot be delivered are moved to the outbox_dead_letters collection.
+type OutboxRepository struct {
+	collection  *mongo.Collection // MongoDB collection for storing outbox messages
+	aggregates  *mongo.Collection // MongoDB collection for serializing the messages of each family
+	counters    *mongo.Collection // MongoDB collection holding the outbox sequence
+	deadLetters *mongo.Collection // MongoDB collection for storing quarantined messages
+	logger      *zap.Logger       // Logger for recording repository operations
+	tracer      trace.Tracer      // Tracer for distributed tracing
+}
+
+// NewOutboxRepository creates a new MongoDB outbox repository.
+// Parameters:
+//   - db: The MongoDB database connection
+//   - logger: Logger for recording repository operations
+//
+// Returns:
+//   - *OutboxRepository: A new instance of the outbox repository
+func NewOutboxRepository(db *mongo.Database, logger *zap.Logger) *OutboxRepository {
+	return &OutboxRepository{
+		collection:  db.Collection("outbox"),
+		aggregates:  db.Collection("outbox_aggregates"),
+		counters:    db.Collection("counters"),
+		deadLetters: db.Collection("outbox_dead_letters"),
+		logger:      logger,
+		tracer:      otel.Tracer("mongodb.outbox_repository"),
+	}
+}
+
+// Append records an event within the transaction carried by ctx.
+// It first updates the document of the event's family within the transaction, so that a concurrent
+// transaction recording an event of the same family fails with a write conflict instead of committing
+// out of order. The sequence is then incremented outside of the transaction, which makes it follow
+// the commit order within the family without serializing the transactions of different families.
+// Parameters:
+//   - ctx: The context for the operation, carrying the transaction's session
+//   - event: The event to record
+//
+// Returns:
+//   - error: An error if the event could not be recorded
+func (r *OutboxRepository) Append(ctx context.Context, event domain.Event) error {
+	ctx, span := r.tracer.Start(ctx, "OutboxRepository.Append")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("event.id", event.ID.String()),
+		attribute.String("event.type", string(event.Type)),
+	)
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("outbox.encode.failed: %w", err)
+	}
+
+	_, err = r.aggregates.UpdateOne(ctx,
+		bson.M{"_id": event.ParentID.String()},
+		bson.M{"$inc": bson.M{"messages": 1}},
+		options.Update().SetUpsert(true))
+	if err != nil {
+		r.logger.Error("Failed to lock the outbox of the family", zap.Error(err), zap.String("parent_id", event.ParentID.String()))
+		return fmt.Errorf("outbox.lock.failed: %w", err)
+	}
+
+	sequence, err := r.nextSequence()
+	if err != nil {
+		r.logger.Error("Failed to take an outbox sequence", zap.Error(err))
+		return fmt.Errorf("outbox.sequence.failed: %w", err)
+	}
+
+	doc := outboxDocument{
+		Sequence:      sequence,
+		EventID:       event.ID.String(),
+		TenantID:      event.TenantID,
+		AggregateID:   event.ParentID.String(),
+		EventType:     string(event.Type),
+		Payload:       string(payload),
+		OccurredAt:    event.OccurredAt,
+		NextAttemptAt: event.OccurredAt,
+	}
+
+	_, err = r.collection.InsertOne(ctx, doc)
+	if err != nil {
+		r.logger.Error("Failed to append event to outbox", zap.Error(err), zap.String("event_id", event.ID.String()))
+		return fmt.Errorf("outbox.append.failed: %w", err)
+	}
+
+	return nil
+}
+
+// nextSequence increments and returns the outbox sequence.
+// The driver joins any operation whose context carries a session to its transaction,
+// so the increment runs in a context of its own.
+func (r *OutboxRepository) nextSequence() (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), outboxSequenceTimeout)
+	defer cancel()
+
+	var counter struct {
+		Sequence int64 `bson:"sequence"`
+	}
+	err := r.counters.FindOneAndUpdate(ctx,
+		bson.M{"_id": "outbox"},
+		bson.M{"$inc": bson.M{"sequence": int64(1)}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+	if err != nil {
+		return 0, err
+	}
+
+	return counter.Sequence, nil
+}
+
+// LeasePending leases up to limit undelivered messages of all tenants until leaseUntil, ordered by sequence.
+// A relay takes a family by leasing its earliest message, on the condition that no other relay holds it,
+// and then leases the later messages of the families it took. The later messages of a family are never
+// taken on their own, as an earlier message of their family is either leased or waiting for its next attempt.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - limit: The maximum number of messages to lease
+//   - now: The current time, at which the messages must be due
+//   - leaseUntil: When the lease ends, after which other relays may lease the messages again
+//
+// Returns:
+//   - []ports.OutboxMessage: The leased messages
+//   - error: An error if the messages could not be leased
+func (r *OutboxRepository) LeasePending(ctx context.Context, limit int, now, leaseUntil time.Time) ([]ports.OutboxMessage, error) {
+	ctx, span := r.tracer.Start(ctx, "OutboxRepository.LeasePending")
+	defer span.End()
+
+	now = now.UTC()
+	leaseUntil = leaseUntil.UTC()
+	free := bson.A{bson.M{"leasedUntil": nil}, bson.M{"leasedUntil": bson.M{"$lte": now}}}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.M{"_id": 1, "aggregateId": 1})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"nextAttemptAt": bson.M{"$lte": now}, "$or": free}, findOptions)
+	if err != nil {
+		r.logger.Error("Failed to fetch pending outbox messages", zap.Error(err))
+		return nil, fmt.Errorf("outbox.fetch.failed: %w", err)
+	}
+	var candidates []outboxDocument
+	if err := cursor.All(ctx, &candidates); err != nil {
+		r.logger.Error("Failed to decode outbox messages", zap.Error(err))
+		return nil, fmt.Errorf("outbox.decode.failed: %w", err)
+	}
+
+	// Take the families whose candidate is their earliest message
+	var families []string
+	considered := make(map[string]bool)
+	for _, candidate := range candidates {
+		if considered[candidate.AggregateID] {
+			continue
+		}
+		considered[candidate.AggregateID] = true
+
+		var head outboxDocument
+		err := r.collection.FindOne(ctx,
+			bson.M{"aggregateId": candidate.AggregateID},
+			options.FindOne().SetSort(bson.D{{Key: "_id", Value: 1}}).SetProjection(bson.M{"_id": 1}),
+		).Decode(&head)
+		if err == mongo.ErrNoDocuments || (err == nil && head.Sequence != candidate.Sequence) {
+			continue
+		}
+		if err != nil {
+			r.logger.Error("Failed to fetch the earliest outbox message of a family", zap.Error(err), zap.String("aggregate_id", candidate.AggregateID))
+			return nil, fmt.Errorf("outbox.fetch.failed: %w", err)
+		}
+
+		result, err := r.collection.UpdateOne(ctx,
+			bson.M{"_id": candidate.Sequence, "$or": free},
+			bson.M{"$set": bson.M{"leasedUntil": leaseUntil}})
+		if err != nil {
+			r.logger.Error("Failed to lease outbox message", zap.Error(err), zap.Int64("sequence", candidate.Sequence))
+			return nil, fmt.Errorf("outbox.lease.failed: %w", err)
+		}
+		if result.ModifiedCount == 1 {
+			families = append(families, candidate.AggregateID)
+		}
+	}
+	if len(families) == 0 {
+		return nil, nil
+	}
+
+	// Lease the later messages of the families that were taken
+	cursor, err = r.collection.Find(ctx, bson.M{"aggregateId": bson.M{"$in": families}},
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(limit)))
+	if err != nil {
+		r.logger.Error("Failed to fetch pending outbox messages", zap.Error(err))
+		return nil, fmt.Errorf("outbox.fetch.failed: %w", err)
+	}
+	var docs []outboxDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		r.logger.Error("Failed to decode outbox messages", zap.Error(err))
+		return nil, fmt.Errorf("outbox.decode.failed: %w", err)
+	}
+
+	sequences := make([]int64, 0, len(docs))
+	for _, doc := range docs {
+		sequences = append(sequences, doc.Sequence)
+	}
+	_, err = r.collection.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": sequences}},
+		bson.M{"$set": bson.M{"leasedUntil": leaseUntil}})
+	if err != nil {
+		r.logger.Error("Failed to lease outbox messages", zap.Error(err))
+		return nil, fmt.Errorf("outbox.lease.failed: %w", err)
+	}
+
+	messages := make([]ports.OutboxMessage, 0, len(docs))
+	for _, doc := range docs {
+		message := ports.OutboxMessage{
+			Sequence:      doc.Sequence,
+			Attempts:      doc.Attempts,
+			NextAttemptAt: doc.NextAttemptAt.UTC(),
+			LastError:     doc.LastError,
+		}
+		if err := json.Unmarshal([]byte(doc.Payload), &message.Event); err != nil {
+			r.logger.Error("Failed to decode outbox message, quarantining it", zap.Error(err), zap.Int64("sequence", doc.Sequence))
+			if err := r.Quarantine(ctx, doc.Sequence, "payload cannot be decoded"); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+// Acknowledge removes a delivered message from the outbox.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - sequence: The sequence of the delivered message
+//
+// Returns:
+//   - error: An error if the message could not be removed
+func (r *OutboxRepository) Acknowledge(ctx context.Context, sequence int64) error {
+	ctx, span := r.tracer.Start(ctx, "OutboxRepository.Acknowledge")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("outbox.sequence", sequence))
+
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": sequence})
+	if err != nil {
+		r.logger.Error("Failed to acknowledge outbox message", zap.Error(err), zap.Int64("sequence", sequence))
+		return fmt.Errorf("outbox.acknowledge.failed: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed records a failed attempt to deliver a message and when to attempt it again, and releases its lease.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - sequence: The sequence of the message that could not be delivered
+//   - nextAttemptAt: The earliest time at which delivery may be attempted again
+//   - lastError: Why the attempt failed
+//
+// Returns:
+//   - error: An error if the attempt could not be recorded
+func (r *OutboxRepository) MarkFailed(ctx context.Context, sequence int64, nextAttemptAt time.Time, lastError string) error {
+	ctx, span := r.tracer.Start(ctx, "OutboxRepository.MarkFailed")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("outbox.sequence", sequence))
+
+	update := bson.M{
+		"$set": bson.M{
+			"nextAttemptAt": nextAttemptAt.UTC(),
+			"lastError":     lastError,
+		},
+		"$unset": bson.M{"leasedUntil": ""},
+		"$inc":   bson.M{"attempts": 1},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": sequence}, update)
+	if err != nil {
+		r.logger.Error("Failed to mark outbox message as failed", zap.Error(err), zap.Int64("sequence", sequence))
+		return fmt.Errorf("outbox.update.failed: %w", err)
+	}
+
+	return nil
+}
+
+// Quarantine moves a message that cannot be delivered from the outbox to its dead letters, with the reason.
+// The message is copied before it is removed, so that a failure in between leaves it in both collections
+// rather than in neither, and quarantining it again completes the move.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - sequence: The sequence of the message to quarantine
+//   - reason: Why the message cannot be delivered
+//
+// Returns:
+//   - error: An error if the message could not be quarantined
+func (r *OutboxRepository) Quarantine(ctx context.Context, sequence int64, reason string) error {
+	ctx, span := r.tracer.Start(ctx, "OutboxRepository.Quarantine")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("outbox.sequence", sequence))
+
+	var doc outboxDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": sequence}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	if err != nil {
+		r.logger.Error("Failed to fetch outbox message to quarantine", zap.Error(err), zap.Int64("sequence", sequence))
+		return fmt.Errorf("outbox.quarantine.failed: %w", err)
+	}
+
+	doc.LeasedUntil = nil
+	deadLetter := outboxDeadLetterDocument{
+		outboxDocument: doc,
+		Reason:         reason,
+		QuarantinedAt:  time.Now().UTC(),
+	}
+	if _, err := r.deadLetters.InsertOne(ctx, deadLetter); err != nil && !mongo.IsDuplicateKeyError(err) {
+		r.logger.Error("Failed to quarantine outbox message", zap.Error(err), zap.Int64("sequence", sequence))
+		return fmt.Errorf("outbox.quarantine.failed: %w", err)
+	}
+
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": sequence}); err != nil {
+		r.logger.Error("Failed to remove quarantined outbox message", zap.Error(err), zap.Int64("sequence", sequence))
+		return fmt.Errorf("outbox.quarantine.failed: %w", err)
+	}
+
+	return nil
+}
+
+// Ensure OutboxRepository implements ports.OutboxRepository
+var _ ports.OutboxRepository = (*OutboxRepository)(nil)
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/abitofhelp/family_service_hexarch_graphql/pkg/stringutil"
+	"regexp"
 	"time"
 
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
@@ -79,7 +80,7 @@ func NewParentRepository(ctx context.Context, db *mongo.Database, logger *zap.Lo
 }
 
 // Create creates a new parent in the database.
-// It inserts the parent document into the MongoDB collection.
+// It inserts the parent document into the MongoDB collection, in the caller's tenant.
 //
 // Parameters:
 //   - ctx: Context for the database operation
@@ -93,17 +94,78 @@ func (r *ParentRepository) Create(ctx context.Context, parent *domain.Parent) er
 
 	span.SetAttributes(attribute.String("parent.id", parent.ID.String()))
 
+	parent.TenantID = ports.TenantIDFromContext(ctx)
+
 	_, err := r.collection.InsertOne(ctx, parent)
 	if err != nil {
+		if isDuplicateKey(err, parentEmailIndex) {
+			r.logger.Debug("Parent email already exists", zap.String("parent_id", parent.ID.String()))
+			return domain.NewDuplicateError("Parent", "email", parent.Email)
+		}
 		r.logger.Error("Failed to create parent", zap.Error(err), zap.String("parent_id", parent.ID.String()))
 		return fmt.Errorf("parent.create.failed: %w", err)
 	}
 
+	if err := recordHistory(ctx, r.collection, parentHistoryCollection, withTenant(ctx, bson.M{"_id": parent.ID})); err != nil {
+		r.logger.Error("Failed to record parent history", zap.Error(err), zap.String("parent_id", parent.ID.String()))
+		return err
+	}
+
+	return nil
+}
+
+// CreateMany creates many parents in the database with a single ordered InsertMany,
+// in the caller's tenant.
+//
+// Parameters:
+//   - ctx: Context for the database operation
+//   - parents: The parent entities to create
+//
+// Returns:
+//   - A domain.BatchError holding the position and the error of the parent that could not be created,
+//     an error if the creation fails otherwise, or nil on success
+func (r *ParentRepository) CreateMany(ctx context.Context, parents []*domain.Parent) error {
+	ctx, span := r.tracer.Start(ctx, "ParentRepository.CreateMany")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("parent.count", len(parents)))
+
+	tenantID := ports.TenantIDFromContext(ctx)
+	documents := make([]interface{}, 0, len(parents))
+	ids := make([]uuid.UUID, 0, len(parents))
+	for _, parent := range parents {
+		parent.TenantID = tenantID
+		documents = append(documents, parent)
+		ids = append(ids, parent.ID)
+	}
+
+	_, err := r.collection.InsertMany(ctx, documents)
+	if err != nil {
+		writeErr, ok := firstWriteError(err)
+		if !ok {
+			r.logger.Error("Failed to create parents", zap.Error(err), zap.Int("count", len(parents)))
+			return fmt.Errorf("parent.createMany.failed: %w", err)
+		}
+
+		parent := parents[writeErr.Index]
+		if isDuplicateKey(writeErr, parentEmailIndex) {
+			r.logger.Debug("Parent email already exists", zap.String("parent_id", parent.ID.String()))
+			return domain.NewBatchError(writeErr.Index, domain.NewDuplicateError("Parent", "email", parent.Email))
+		}
+		r.logger.Error("Failed to create parent", zap.Error(writeErr), zap.String("parent_id", parent.ID.String()))
+		return fmt.Errorf("parent.createMany.failed: %w", domain.NewBatchError(writeErr.Index, writeErr))
+	}
+
+	if err := recordHistory(ctx, r.collection, parentHistoryCollection, withTenant(ctx, bson.M{"_id": bson.M{"$in": ids}})); err != nil {
+		r.logger.Error("Failed to record parent history", zap.Error(err), zap.Int("count", len(parents)))
+		return err
+	}
+
 	return nil
 }
 
 // GetByID retrieves a parent by ID from the database.
-// It only returns non-deleted parents (where deleted_at is nil).
+// It only returns non-deleted parents (where deleted_at is nil) of the caller's tenant.
 //
 // Parameters:
 //   - ctx: Context for the database operation
@@ -118,16 +180,17 @@ func (r *ParentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.P
 
 	span.SetAttributes(attribute.String("parent.id", id.String()))
 
-	filter := bson.M{
+	filter := withTenant(ctx, bson.M{
 		"_id":        id,
 		"deleted_at": nil,
-	}
+	})
 
 	var parent domain.Parent
 	err := r.collection.FindOne(ctx, filter).Decode(&parent)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			r.logger.Debug("Parent not found", zap.String("parent_id", id.String()))
+			reportCrossTenantAccess(ctx, r.collection, r.logger, "Parent", id)
 			return nil, fmt.Errorf("parent not found")
 		}
 		r.logger.Error("Failed to get parent", zap.Error(err), zap.String("parent_id", id.String()))
@@ -137,8 +200,128 @@ func (r *ParentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.P
 	return &parent, nil
 }
 
+// GetByIDs retrieves the parents with the given IDs from the database in a single query.
+// It only returns non-deleted parents of the caller's tenant; IDs that do not match a parent are skipped.
+//
+// Parameters:
+//   - ctx: Context for the database operation
+//   - ids: The UUIDs of the parents to retrieve
+//
+// Returns:
+//   - The parent entities that were found, in no particular order
+//   - An error if retrieval fails
+func (r *ParentRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Parent, error) {
+	ctx, span := r.tracer.Start(ctx, "ParentRepository.GetByIDs")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("parent.count", len(ids)))
+
+	filter := withTenant(ctx, bson.M{
+		"_id":        bson.M{"$in": ids},
+		"deleted_at": nil,
+	})
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to get parents by IDs", zap.Error(err), zap.Int("count", len(ids)))
+		return nil, fmt.Errorf("parent.getByIDs.failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	parents := []*domain.Parent{}
+	if err := cursor.All(ctx, &parents); err != nil {
+		r.logger.Error("Failed to decode parents", zap.Error(err))
+		return nil, fmt.Errorf("parent.decode.failed: %w", err)
+	}
+
+	return parents, nil
+}
+
+// GetByUserID retrieves the parent linked to the given user account from the database.
+// It only returns non-deleted parents of the caller's tenant.
+//
+// Parameters:
+//   - ctx: Context for the database operation
+//   - userID: The ID of the user account
+//
+// Returns:
+//   - The parent entity linked to the user
+//   - An error wrapping domain.ErrNotFound if no parent is linked to the user, or if retrieval fails
+func (r *ParentRepository) GetByUserID(ctx context.Context, userID string) (*domain.Parent, error) {
+	ctx, span := r.tracer.Start(ctx, "ParentRepository.GetByUserID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID))
+
+	filter := withTenant(ctx, bson.M{
+		"userId":     userID,
+		"deleted_at": nil,
+	})
+
+	var parent domain.Parent
+	err := r.collection.FindOne(ctx, filter).Decode(&parent)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			r.logger.Debug("No parent linked to user", zap.String("user_id", userID))
+			return nil, fmt.Errorf("parent not found for user: %w", domain.ErrNotFound)
+		}
+		r.logger.Error("Failed to get parent by user ID", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("parent.getByUserID.failed: %w", err)
+	}
+
+	return &parent, nil
+}
+
+// FindTakenEmails returns those of the given emails that non-deleted parents of the caller's tenant already have.
+// Emails are compared ignoring case, with the collation of the unique index of the emails.
+//
+// Parameters:
+//   - ctx: Context for the database operation
+//   - emails: The emails to look for
+//
+// Returns:
+//   - The emails that are taken, as the parents have them
+//   - An error if retrieval fails
+func (r *ParentRepository) FindTakenEmails(ctx context.Context, emails []string) ([]string, error) {
+	ctx, span := r.tracer.Start(ctx, "ParentRepository.FindTakenEmails")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("emails.count", len(emails)))
+
+	filter := withTenant(ctx, bson.M{
+		"email":      bson.M{"$in": emails},
+		"deleted_at": nil,
+	})
+	findOptions := options.Find().
+		SetProjection(bson.M{"email": 1}).
+		SetCollation(&options.Collation{Locale: "en", Strength: 2})
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		r.logger.Error("Failed to find taken emails", zap.Error(err), zap.Int("count", len(emails)))
+		return nil, fmt.Errorf("parent.findTakenEmails.failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var documents []struct {
+		Email string `bson:"email"`
+	}
+	if err := cursor.All(ctx, &documents); err != nil {
+		r.logger.Error("Failed to decode emails", zap.Error(err))
+		return nil, fmt.Errorf("parent.decode.failed: %w", err)
+	}
+
+	taken := make([]string, 0, len(documents))
+	for _, document := range documents {
+		taken = append(taken, document.Email)
+	}
+
+	return taken, nil
+}
+
 // Update updates an existing parent in the database.
-// It only updates non-deleted parents and sets the updated_at timestamp.
+// It only updates non-deleted parents of the caller's tenant that are still at the version of the given parent,
+// sets the updated_at timestamp, and increments the version.
 // After updating, it verifies the update by retrieving the updated document.
 //
 // Parameters:
@@ -146,7 +329,8 @@ func (r *ParentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.P
 //   - parent: The parent entity with updated fields
 //
 // Returns:
-//   - An error if the parent is not found or if the update fails, or nil on success
+//   - A ConflictError if the parent is at another version, an error if the parent is not found
+//     or if the update fails, or nil on success
 func (r *ParentRepository) Update(ctx context.Context, parent *domain.Parent) error {
 	ctx, span := r.tracer.Start(ctx, "ParentRepository.Update")
 	defer span.End()
@@ -155,10 +339,11 @@ func (r *ParentRepository) Update(ctx context.Context, parent *domain.Parent) er
 
 	parent.UpdatedAt = time.Now().UTC()
 
-	filter := bson.M{
+	filter := withTenant(ctx, bson.M{
 		"_id":        parent.ID,
+		"version":    parent.Version,
 		"deleted_at": nil,
-	}
+	})
 
 	// Log the parent before update
 	r.logger.Debug("Updating parent",
@@ -166,31 +351,62 @@ func (r *ParentRepository) Update(ctx context.Context, parent *domain.Parent) er
 		zap.Int("children_count", len(parent.Children)),
 		zap.Any("parent", parent))
 
-	update := bson.M{
-		"$set": bson.M{
-			"firstName": parent.FirstName,
-			"lastName":  parent.LastName,
-			"email":     parent.Email,
-			"birthDate": parent.BirthDate,
-			"children":  parent.Children,
-			"updatedAt": parent.UpdatedAt,
-		},
+	set := bson.M{
+		"firstName": parent.FirstName,
+		"lastName":  parent.LastName,
+		"email":     parent.Email,
+		"birthDate": parent.BirthDate,
+		"children":  parent.Children,
+		"updatedAt": parent.UpdatedAt,
+
+		"phones":            parent.Phones,
+		"address":           parent.Address,
+		"emergencyContacts": parent.EmergencyContacts,
+	}
+	update := bson.M{"$set": set, "$inc": bson.M{"version": 1}}
+
+	// Like on insert, a parent that is not linked to a user has no userId field
+	if parent.UserID != "" {
+		set["userId"] = parent.UserID
+	} else {
+		update["$unset"] = bson.M{"userId": ""}
+	}
+
+	// Only a duplicate merged into another parent has a mergedInto field
+	if parent.MergedInto != nil {
+		set["mergedInto"] = parent.MergedInto
 	}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
+		if isDuplicateKey(err, parentEmailIndex) {
+			r.logger.Debug("Parent email already exists", zap.String("parent_id", parent.ID.String()))
+			return domain.NewDuplicateError("Parent", "email", parent.Email)
+		}
 		r.logger.Error("Failed to update parent", zap.Error(err), zap.String("parent_id", parent.ID.String()))
 		return fmt.Errorf("parent.update.failed: %w", err)
 	}
 
 	if result.MatchedCount == 0 {
+		if isVersionConflict(ctx, r.collection, parent.ID) {
+			r.logger.Debug("Parent version conflict", zap.String("parent_id", parent.ID.String()), zap.Int("version", parent.Version))
+			return domain.NewConflictError("Parent", parent.ID.String(), parent.Version)
+		}
 		r.logger.Debug("Parent not found for update", zap.String("parent_id", parent.ID.String()))
+		reportCrossTenantAccess(ctx, r.collection, r.logger, "Parent", parent.ID)
 		return fmt.Errorf("parent not found")
 	}
 
+	parent.Version++
+
+	if err := recordHistory(ctx, r.collection, parentHistoryCollection, withTenant(ctx, bson.M{"_id": parent.ID})); err != nil {
+		r.logger.Error("Failed to record parent history", zap.Error(err), zap.String("parent_id", parent.ID.String()))
+		return err
+	}
+
 	// Verify the update
 	var updatedParent domain.Parent
-	err = r.collection.FindOne(ctx, filter).Decode(&updatedParent)
+	err = r.collection.FindOne(ctx, withTenant(ctx, bson.M{"_id": parent.ID})).Decode(&updatedParent)
 	if err != nil {
 		r.logger.Error("Failed to verify parent update", zap.Error(err), zap.String("parent_id", parent.ID.String()))
 	} else {
@@ -205,7 +421,7 @@ func (r *ParentRepository) Update(ctx context.Context, parent *domain.Parent) er
 
 // Delete marks a parent as deleted in the database.
 // It performs a soft delete by setting the deleted_at timestamp rather than removing the document.
-// It also marks all children of the parent as deleted.
+// It also marks all children of the parent as deleted. Only parents of the caller's tenant are deleted.
 //
 // Parameters:
 //   - ctx: Context for the database operation
@@ -222,16 +438,17 @@ func (r *ParentRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	now := time.Now().UTC()
 
 	// Mark parent as deleted
-	parentFilter := bson.M{
+	parentFilter := withTenant(ctx, bson.M{
 		"_id":        id,
 		"deleted_at": nil,
-	}
+	})
 
 	parentUpdate := bson.M{
 		"$set": bson.M{
 			"deleted_at": now,
 			"updatedAt":  now,
 		},
+		"$inc": bson.M{"version": 1},
 	}
 
 	result, err := r.collection.UpdateOne(ctx, parentFilter, parentUpdate)
@@ -242,20 +459,22 @@ func (r *ParentRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 	if result.MatchedCount == 0 {
 		r.logger.Debug("Parent not found for deletion", zap.String("parent_id", id.String()))
+		reportCrossTenantAccess(ctx, r.collection, r.logger, "Parent", id)
 		return fmt.Errorf("parent not found")
 	}
 
 	// Mark all children as deleted
-	childrenFilter := bson.M{
+	childrenFilter := withTenant(ctx, bson.M{
 		"parentId":   id,
 		"deleted_at": nil,
-	}
+	})
 
 	childrenUpdate := bson.M{
 		"$set": bson.M{
 			"deleted_at": now,
 			"updatedAt":  now,
 		},
+		"$inc": bson.M{"version": 1},
 	}
 
 	db := r.collection.Database()
@@ -267,23 +486,187 @@ func (r *ParentRepository) Delete(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("parent.children.delete.failed: %w", err)
 	}
 
+	// Record the parent and the children deleted with it, which share its update time
+	if err := recordHistory(ctx, r.collection, parentHistoryCollection, withTenant(ctx, bson.M{"_id": id})); err != nil {
+		r.logger.Error("Failed to record parent history", zap.Error(err), zap.String("parent_id", id.String()))
+		return err
+	}
+	if err := recordHistory(ctx, childrenCollection, childHistoryCollection, withTenant(ctx, bson.M{"parentId": id, "updatedAt": now})); err != nil {
+		r.logger.Error("Failed to record children history", zap.Error(err), zap.String("parent_id", id.String()))
+		return err
+	}
+
+	return nil
+}
+
+// Restore unmarks a parent that was marked as deleted in the database.
+// It also unmarks the children that were marked as deleted together with the parent, which are
+// the children that share its deletion time. Only parents of the caller's tenant are restored.
+//
+// Parameters:
+//   - ctx: Context for the database operation
+//   - id: The UUID of the parent to restore
+//
+// Returns:
+//   - An error wrapping domain.ErrNotFound if no deleted parent has the ID, an error if the restore fails, or nil on success
+func (r *ParentRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "ParentRepository.Restore")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("parent.id", id.String()))
+
+	parentFilter := withTenant(ctx, bson.M{
+		"_id":        id,
+		"deleted_at": bson.M{"$ne": nil},
+	})
+
+	var deleted struct {
+		DeletedAt time.Time `bson:"deleted_at"`
+	}
+	opts := options.FindOne().SetProjection(bson.M{"deleted_at": 1})
+	if err := r.collection.FindOne(ctx, parentFilter, opts).Decode(&deleted); err != nil {
+		if err == mongo.ErrNoDocuments {
+			r.logger.Debug("Parent not found for restore", zap.String("parent_id", id.String()))
+			reportCrossTenantAccess(ctx, r.collection, r.logger, "Parent", id)
+			return fmt.Errorf("parent not found for restore: %w", domain.ErrNotFound)
+		}
+		r.logger.Error("Failed to get deleted parent", zap.Error(err), zap.String("parent_id", id.String()))
+		return fmt.Errorf("parent.restore.failed: %w", err)
+	}
+
+	now := time.Now().UTC()
+	update := bson.M{
+		"$set": bson.M{
+			"deleted_at": nil,
+			"updatedAt":  now,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	// Restore the children deleted with the parent
+	childrenFilter := withTenant(ctx, bson.M{
+		"parentId":   id,
+		"deleted_at": deleted.DeletedAt,
+	})
+
+	childrenCollection := r.collection.Database().Collection("children")
+	if _, err := childrenCollection.UpdateMany(ctx, childrenFilter, update); err != nil {
+		r.logger.Error("Failed to restore children", zap.Error(err), zap.String("parent_id", id.String()))
+		return fmt.Errorf("parent.children.restore.failed: %w", err)
+	}
+
+	result, err := r.collection.UpdateOne(ctx, parentFilter, update)
+	if err != nil {
+		// Another parent may have taken the email since the parent was deleted
+		if isDuplicateKey(err, parentEmailIndex) {
+			r.logger.Debug("Parent email already exists", zap.String("parent_id", id.String()))
+			return domain.NewDuplicateError("Parent", "email", "")
+		}
+		r.logger.Error("Failed to restore parent", zap.Error(err), zap.String("parent_id", id.String()))
+		return fmt.Errorf("parent.restore.failed: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		r.logger.Debug("Parent not found for restore", zap.String("parent_id", id.String()))
+		return fmt.Errorf("parent not found for restore: %w", domain.ErrNotFound)
+	}
+
+	// Record the parent and the children restored with it, which share its update time
+	if err := recordHistory(ctx, r.collection, parentHistoryCollection, withTenant(ctx, bson.M{"_id": id})); err != nil {
+		r.logger.Error("Failed to record parent history", zap.Error(err), zap.String("parent_id", id.String()))
+		return err
+	}
+	if err := recordHistory(ctx, childrenCollection, childHistoryCollection, withTenant(ctx, bson.M{"parentId": id, "updatedAt": now})); err != nil {
+		r.logger.Error("Failed to record children history", zap.Error(err), zap.String("parent_id", id.String()))
+		return err
+	}
+
 	return nil
 }
 
+// Purge permanently removes the parents of the caller's tenant that were marked as deleted before the given time.
+// Parents that still have children, whether they are deleted or not, are kept, and so are parents
+// that are still a guardian of a child.
+//
+// Parameters:
+//   - ctx: Context for the database operation
+//   - deletedBefore: The time before which the parents must have been deleted
+//
+// Returns:
+//   - The number of parents removed
+//   - An error if the removal fails
+func (r *ParentRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, span := r.tracer.Start(ctx, "ParentRepository.Purge")
+	defer span.End()
+
+	childrenCollection := r.collection.Database().Collection("children")
+	parentIDs, err := childrenCollection.Distinct(ctx, "parentId", withTenant(ctx, bson.M{}))
+	if err != nil {
+		r.logger.Error("Failed to get parents with children", zap.Error(err))
+		return 0, fmt.Errorf("parent.purge.failed: %w", err)
+	}
+
+	guardiansCollection := r.collection.Database().Collection(guardianshipsCollection)
+	guardianIDs, err := guardiansCollection.Distinct(ctx, "parentId", withTenant(ctx, bson.M{"endDate": nil}))
+	if err != nil {
+		r.logger.Error("Failed to get parents that are guardians", zap.Error(err))
+		return 0, fmt.Errorf("parent.purge.failed: %w", err)
+	}
+
+	filter := withTenant(ctx, bson.M{
+		"deleted_at": bson.M{"$lt": deletedBefore},
+		"_id":        bson.M{"$nin": append(parentIDs, guardianIDs...)},
+	})
+
+	// The revisions of the purged parents are removed with them
+	filter, err = purgeHistory(ctx, r.collection, parentHistoryCollection, filter)
+	if err != nil {
+		r.logger.Error("Failed to purge parent history", zap.Error(err))
+		return 0, fmt.Errorf("parent.purge.failed: %w", err)
+	}
+
+	// So are the guardianships that ended
+	_, err = guardiansCollection.DeleteMany(ctx, withTenant(ctx, bson.M{"parentId": filter["_id"]}))
+	if err != nil {
+		r.logger.Error("Failed to purge guardianships", zap.Error(err))
+		return 0, fmt.Errorf("parent.purge.failed: %w", err)
+	}
+
+	// And the purged parents leave their households
+	households := r.collection.Database().Collection(householdsCollection)
+	_, err = households.UpdateMany(ctx, withTenant(ctx, bson.M{}), bson.M{"$pull": bson.M{"parentIds": filter["_id"]}})
+	if err != nil {
+		r.logger.Error("Failed to remove purged parents from households", zap.Error(err))
+		return 0, fmt.Errorf("parent.purge.failed: %w", err)
+	}
+
+	result, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to purge parents", zap.Error(err))
+		return 0, fmt.Errorf("parent.purge.failed: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int64("parent.purged", result.DeletedCount))
+
+	return result.DeletedCount, nil
+}
+
 // buildListFilter builds a MongoDB filter document for listing parents with filtering.
 // It converts the generic FilterOptions into a MongoDB-specific filter document.
-// The filter always excludes deleted parents (where deleted_at is not nil).
+// The filter excludes the parents of other tenants, and either the deleted parents (where deleted_at is not nil)
+// or, when filter.Deleted is set, the parents that are not deleted.
 // It supports filtering by first name, last name, email, and age range.
 //
 // Parameters:
+//   - ctx: Context holding the caller's tenant
 //   - filter: The generic filter options containing filter criteria
 //
 // Returns:
 //   - A MongoDB filter document (bson.M) that can be used in queries
-func (r *ParentRepository) buildListFilter(filter ports.FilterOptions) bson.M {
-	mongoFilter := bson.M{
-		"deleted_at": nil,
-	}
+func (r *ParentRepository) buildListFilter(ctx context.Context, filter ports.FilterOptions) bson.M {
+	mongoFilter := withTenant(ctx, bson.M{
+		"deleted_at": deletedCondition(filter),
+	})
 
 	if filter.FirstName != "" {
 		mongoFilter["firstName"] = bson.M{"$regex": filter.FirstName, "$options": "i"}
@@ -293,8 +676,9 @@ func (r *ParentRepository) buildListFilter(filter ports.FilterOptions) bson.M {
 		mongoFilter["lastName"] = bson.M{"$regex": filter.LastName, "$options": "i"}
 	}
 
+	// Emails hold dots and plus signs, so they are matched literally, as in PostgreSQL
 	if filter.Email != "" {
-		mongoFilter["email"] = bson.M{"$regex": filter.Email, "$options": "i"}
+		mongoFilter["email"] = bson.M{"$regex": regexp.QuoteMeta(filter.Email), "$options": "i"}
 	}
 
 	if filter.MinAge > 0 {
@@ -309,9 +693,61 @@ func (r *ParentRepository) buildListFilter(filter ports.FilterOptions) bson.M {
 		}
 	}
 
+	if len(filter.ParentIDs) > 0 {
+		mongoFilter["_id"] = bson.M{"$in": filter.ParentIDs}
+	}
+
+	if filter.PostalCode != "" {
+		mongoFilter["address.postalCode"] = filter.PostalCode
+	}
+
+	if filter.Phone != "" {
+		mongoFilter["phones.number"] = filter.Phone
+	}
+
 	return mongoFilter
 }
 
+// sortKey maps the sort options to a document field and a MongoDB sort order.
+// Without a sort field, the newest documents come first.
+//
+// Parameters:
+//   - sort: The sort options containing the field and direction
+//
+// Returns:
+//   - string: The document field to sort by
+//   - int: 1 for ascending or -1 for descending order
+func (r *ParentRepository) sortKey(sort ports.SortOptions) (string, int) {
+	if sort.Field == "" {
+		return "createdAt", -1
+	}
+
+	direction := 1 // ascending
+	if sort.Direction == "desc" {
+		direction = -1
+	}
+
+	var sortField string
+	switch sort.Field {
+	case "firstName", "first_name":
+		sortField = "firstName"
+	case "lastName", "last_name":
+		sortField = "lastName"
+	case "email":
+		sortField = "email"
+	case "birthDate", "birth_date":
+		sortField = "birthDate"
+	case "createdAt", "created_at":
+		sortField = "createdAt"
+	case "updatedAt", "updated_at":
+		sortField = "updatedAt"
+	default:
+		sortField = "createdAt"
+	}
+
+	return sortField, direction
+}
+
 // List retrieves a list of parents with pagination, filtering, and sorting.
 // It performs the find and count operations concurrently for better performance.
 // The method handles context cancellation at various stages of the operation.
@@ -334,54 +770,11 @@ func (r *ParentRepository) List(ctx context.Context, queryOptions ports.QueryOpt
 		return nil, nil, domain.NewDatabaseError("list", "Parent", ctx.Err())
 	}
 
-	filter := r.buildListFilter(queryOptions.Filter)
+	filter := r.buildListFilter(ctx, queryOptions.Filter)
 
-	// Build sort options
-	sortOptions := bson.D{}
-	if queryOptions.Sort.Field != "" {
-		direction := 1 // ascending
-		if queryOptions.Sort.Direction == "desc" {
-			direction = -1
-		}
-
-		var sortField string
-		switch queryOptions.Sort.Field {
-		case "firstName", "first_name":
-			sortField = "firstName"
-		case "lastName", "last_name":
-			sortField = "lastName"
-		case "email":
-			sortField = "email"
-		case "birthDate", "birth_date":
-			sortField = "birthDate"
-		case "createdAt", "created_at":
-			sortField = "createdAt"
-		case "updatedAt", "updated_at":
-			sortField = "updatedAt"
-		default:
-			sortField = "createdAt"
-		}
-
-		sortOptions = append(sortOptions, bson.E{Key: sortField, Value: direction})
-	} else {
-		sortOptions = append(sortOptions, bson.E{Key: "createdAt", Value: -1})
-	}
-
-	// Build pagination options
-	limit := int64(queryOptions.Pagination.PageSize)
-	if limit <= 0 {
-		limit = 10 // Default page size
-	}
-
-	skip := int64(queryOptions.Pagination.Page * queryOptions.Pagination.PageSize)
-	if skip < 0 {
-		skip = 0
-	}
-
-	findOpts := options.Find()
-	findOpts.SetSort(sortOptions)
-	findOpts.SetLimit(limit)
-	findOpts.SetSkip(skip)
+	// Build sort and pagination options
+	sortField, direction := r.sortKey(queryOptions.Sort)
+	findFilter, findOpts, limit, skip := applyWindow(filter, sortField, direction, queryOptions)
 
 	// Create a context with cancellation for the goroutines
 	// This ensures we can cancel the goroutines if one of them fails
@@ -402,7 +795,7 @@ func (r *ParentRepository) List(ctx context.Context, queryOptions ports.QueryOpt
 
 	// Execute Find operation concurrently
 	go func() {
-		cursor, err := r.collection.Find(goCtx, filter, findOpts)
+		cursor, err := r.collection.Find(goCtx, findFilter, findOpts)
 		if err != nil {
 			if goCtx.Err() != nil {
 				// Context was cancelled
@@ -501,14 +894,9 @@ func (r *ParentRepository) List(ctx context.Context, queryOptions ports.QueryOpt
 		return nil, nil, countRes.err
 	}
 
-	pagedResult := &ports.PagedResult{
-		TotalCount: countRes.count,
-		Page:       queryOptions.Pagination.Page,
-		PageSize:   int(limit),
-		HasNext:    (skip + int64(len(findRes.parents))) < countRes.count,
-	}
+	parents, pagedResult := windowResult(findRes.parents, queryOptions, limit, skip, countRes.count)
 
-	return findRes.parents, pagedResult, nil
+	return parents, pagedResult, nil
 }
 
 // Count returns the total count of parents matching the filter.
@@ -525,7 +913,7 @@ func (r *ParentRepository) Count(ctx context.Context, filter ports.FilterOptions
 	ctx, span := r.tracer.Start(ctx, "ParentRepository.Count")
 	defer span.End()
 
-	mongoFilter := r.buildListFilter(filter)
+	mongoFilter := r.buildListFilter(ctx, filter)
 
 	count, err := r.collection.CountDocuments(ctx, mongoFilter)
 	if err != nil {
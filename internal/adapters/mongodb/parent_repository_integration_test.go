@@ -2,11 +2,13 @@ package mongodb_test
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/mongodb"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/mongodb/migrations"
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
 	"github.com/google/uuid"
@@ -238,6 +240,46 @@ func TestParentRepositoryIntegration(t *testing.T) {
 		assert.Equal(t, "Unique", parents[0].FirstName, "Expected first name to be 'Unique'")
 	})
 
+	// Test filtering parents by their contact details
+	t.Run("FilterByContactDetails", func(t *testing.T) {
+		// Create a parent with contact details
+		parent := domain.NewParent("Reachable", "Parent", "reachable.parent@example.com", time.Now().AddDate(-35, 0, 0))
+		parent.UpdateContactDetails(domain.ContactDetails{
+			Phones:            []domain.Phone{{Number: "+12175550142", Type: domain.PhoneMobile}},
+			Address:           &domain.Address{Street: "42 Elm St", City: "Springfield", PostalCode: "62742", Country: "US"},
+			EmergencyContacts: []domain.EmergencyContact{{Name: "Mary Parent", Phone: domain.Phone{Number: "+12175550143"}}},
+		})
+
+		// Save parent to repository
+		err := repo.Create(ctx, parent)
+		require.NoError(t, err, "Failed to create parent")
+
+		// The contact details are read back
+		retrievedParent, err := repo.GetByID(ctx, parent.ID)
+		require.NoError(t, err, "Failed to retrieve parent")
+		assert.Equal(t, parent.ContactDetails, retrievedParent.ContactDetails)
+
+		// Filter by postal code and by phone number
+		for _, filter := range []ports.FilterOptions{{PostalCode: "62742"}, {Phone: "+12175550142"}} {
+			parents, _, err := repo.List(ctx, ports.QueryOptions{
+				Filter:     filter,
+				Pagination: ports.PaginationOptions{Page: 0, PageSize: 10},
+			})
+			require.NoError(t, err, "Failed to filter parents")
+			require.Equal(t, 1, len(parents), "Expected 1 parent")
+			assert.Equal(t, parent.ID, parents[0].ID)
+
+			count, err := repo.Count(ctx, filter)
+			require.NoError(t, err, "Failed to count parents")
+			assert.Equal(t, int64(1), count, "Expected 1 parent")
+		}
+
+		// The number of an emergency contact is not a phone of the parent
+		count, err := repo.Count(ctx, ports.FilterOptions{Phone: "+12175550143"})
+		require.NoError(t, err, "Failed to count parents")
+		assert.Equal(t, int64(0), count, "Expected no parent")
+	})
+
 	// Test counting parents
 	t.Run("Count", func(t *testing.T) {
 		// Count all parents
@@ -253,6 +295,56 @@ func TestParentRepositoryIntegration(t *testing.T) {
 		assert.Equal(t, int64(1), count, "Expected 1 parent with first name 'Unique'")
 	})
 
+	// Test that the email of non-deleted parents is unique, regardless of case
+	t.Run("DuplicateEmail", func(t *testing.T) {
+		// The unique index is created by a migration
+		require.NoError(t, migrations.NewUniqueParentEmailMigration(db, logger).Up(ctx), "Failed to create unique email index")
+
+		// Create a parent, and another one with the same email in other case
+		parent := domain.NewParent("Dana", "Dupe", "dana.dupe@example.com", time.Now().AddDate(-35, 0, 0))
+		require.NoError(t, repo.Create(ctx, parent), "Failed to create parent")
+		duplicate := domain.NewParent("Dana", "Dupe", "Dana.Dupe@Example.com", time.Now().AddDate(-35, 0, 0))
+
+		err := repo.Create(ctx, duplicate)
+		assert.ErrorIs(t, err, domain.ErrDuplicate)
+
+		// Updating another parent to the email fails the same way
+		other := domain.NewParent("Otto", "Other", "otto.other@example.com", time.Now().AddDate(-35, 0, 0))
+		require.NoError(t, repo.Create(ctx, other), "Failed to create parent")
+		other.Email = "DANA.DUPE@example.com"
+		err = repo.Update(ctx, other)
+		assert.ErrorIs(t, err, domain.ErrDuplicate)
+
+		// A deleted parent releases its email, and cannot be restored while it is taken
+		require.NoError(t, repo.Delete(ctx, parent.ID), "Failed to delete parent")
+		require.NoError(t, repo.Create(ctx, duplicate), "Failed to create parent with released email")
+		err = repo.Restore(ctx, parent.ID)
+		assert.ErrorIs(t, err, domain.ErrDuplicate)
+	})
+
+	// Test recording the parent a duplicate was merged into
+	t.Run("MergedInto", func(t *testing.T) {
+		survivor := domain.NewParent("Mona", "Merge", "mona.merge@example.com", time.Now().AddDate(-40, 0, 0))
+		require.NoError(t, repo.Create(ctx, survivor), "Failed to create survivor")
+		duplicate := domain.NewParent("Mona", "Merge", "mona.merge@example.org", time.Now().AddDate(-40, 0, 0))
+		require.NoError(t, repo.Create(ctx, duplicate), "Failed to create duplicate")
+
+		// Merge the duplicate into the survivor
+		duplicate.MergeInto(survivor.ID)
+		require.NoError(t, repo.Update(ctx, duplicate), "Failed to update duplicate")
+
+		// Verify the survivor is recorded
+		merged, err := repo.GetByID(ctx, duplicate.ID)
+		require.NoError(t, err, "Failed to get duplicate")
+		require.NotNil(t, merged.MergedInto)
+		assert.Equal(t, survivor.ID, *merged.MergedInto)
+
+		// The survivor was not merged into another parent
+		kept, err := repo.GetByID(ctx, survivor.ID)
+		require.NoError(t, err, "Failed to get survivor")
+		assert.Nil(t, kept.MergedInto)
+	})
+
 	// Test getting a non-existent parent
 	t.Run("GetNonExistent", func(t *testing.T) {
 		_, err := repo.GetByID(ctx, uuid.New())
@@ -268,10 +360,107 @@ func TestParentRepositoryIntegration(t *testing.T) {
 		assert.Contains(t, err.Error(), "parent not found")
 	})
 
+	// Test updating a parent that was modified since it was read
+	t.Run("UpdateStaleVersion", func(t *testing.T) {
+		parent := domain.NewParent("Stale", "Parent", "stale.parent@example.com", time.Now().AddDate(-30, 0, 0))
+		err := repo.Create(ctx, parent)
+		require.NoError(t, err, "Failed to create parent")
+
+		// Read the parent twice, as two concurrent callers would
+		first, err := repo.GetByID(ctx, parent.ID)
+		require.NoError(t, err, "Failed to retrieve parent")
+		second, err := repo.GetByID(ctx, parent.ID)
+		require.NoError(t, err, "Failed to retrieve parent")
+
+		// The first update wins and increments the version
+		first.FirstName = "First"
+		err = repo.Update(ctx, first)
+		require.NoError(t, err, "Failed to update parent")
+		assert.Equal(t, 2, first.Version)
+
+		// The second update is based on the old version and must be rejected
+		second.FirstName = "Second"
+		err = repo.Update(ctx, second)
+		require.Error(t, err, "Expected error when updating a stale parent")
+		assert.True(t, errors.Is(err, domain.ErrConflict))
+
+		retrievedParent, err := repo.GetByID(ctx, parent.ID)
+		require.NoError(t, err, "Failed to retrieve parent")
+		assert.Equal(t, "First", retrievedParent.FirstName)
+		assert.Equal(t, 2, retrievedParent.Version)
+	})
+
 	// Test deleting a non-existent parent
 	t.Run("DeleteNonExistent", func(t *testing.T) {
 		err := repo.Delete(ctx, uuid.New())
 		require.Error(t, err, "Expected error when deleting non-existent parent")
 		assert.Contains(t, err.Error(), "parent not found")
 	})
+
+	// Test restoring a deleted parent
+	t.Run("Restore", func(t *testing.T) {
+		// Create and delete a parent
+		parent := domain.NewParent("Rita", "Reed", "rita.reed@example.com", time.Now().AddDate(-35, 0, 0))
+		require.NoError(t, repo.Create(ctx, parent), "Failed to create parent")
+		require.NoError(t, repo.Delete(ctx, parent.ID), "Failed to delete parent")
+
+		// Restore parent
+		err := repo.Restore(ctx, parent.ID)
+		require.NoError(t, err, "Failed to restore parent")
+
+		// Retrieve restored parent
+		retrievedParent, err := repo.GetByID(ctx, parent.ID)
+		require.NoError(t, err, "Failed to retrieve restored parent")
+		assert.Nil(t, retrievedParent.DeletedAt)
+		assert.Equal(t, 3, retrievedParent.Version)
+	})
+
+	// Test restoring a parent that is not deleted
+	t.Run("RestoreNotDeleted", func(t *testing.T) {
+		parent := domain.NewParent("Nick", "Nash", "nick.nash@example.com", time.Now().AddDate(-35, 0, 0))
+		require.NoError(t, repo.Create(ctx, parent), "Failed to create parent")
+
+		err := repo.Restore(ctx, parent.ID)
+		require.Error(t, err, "Expected error when restoring a parent that is not deleted")
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+	})
+
+	// Test listing deleted parents
+	t.Run("ListDeleted", func(t *testing.T) {
+		// Create and delete a parent
+		parent := domain.NewParent("Dora", "Dell", "dora.dell@example.com", time.Now().AddDate(-45, 0, 0))
+		require.NoError(t, repo.Create(ctx, parent), "Failed to create parent")
+		require.NoError(t, repo.Delete(ctx, parent.ID), "Failed to delete parent")
+
+		// List deleted parents
+		parents, _, err := repo.List(ctx, ports.QueryOptions{
+			Filter:     ports.FilterOptions{Deleted: true, FirstName: "Dora"},
+			Pagination: ports.PaginationOptions{Page: 0, PageSize: 10},
+		})
+		require.NoError(t, err, "Failed to list deleted parents")
+		require.Len(t, parents, 1)
+		assert.Equal(t, parent.ID, parents[0].ID)
+		assert.NotNil(t, parents[0].DeletedAt)
+	})
+
+	// Test purging deleted parents
+	t.Run("Purge", func(t *testing.T) {
+		// Create and delete a parent
+		parent := domain.NewParent("Paul", "Pike", "paul.pike@example.com", time.Now().AddDate(-50, 0, 0))
+		require.NoError(t, repo.Create(ctx, parent), "Failed to create parent")
+		require.NoError(t, repo.Delete(ctx, parent.ID), "Failed to delete parent")
+
+		// A cutoff before the deletion keeps the parent
+		purged, err := repo.Purge(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err, "Failed to purge parents")
+		assert.Equal(t, int64(0), purged)
+
+		// A cutoff after the deletion removes it for good
+		purged, err = repo.Purge(ctx, time.Now().Add(time.Minute))
+		require.NoError(t, err, "Failed to purge parents")
+		assert.GreaterOrEqual(t, purged, int64(1))
+
+		err = repo.Restore(ctx, parent.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+	})
 }
@@ -14,12 +14,18 @@ import (
 
 // RepositoryFactory implements the ports.RepositoryFactory interface for MongoDB
 type RepositoryFactory struct {
-	client             *mongo.Client
-	db                 *mongo.Database
-	logger             *zap.Logger
-	transactionManager *TransactionManager
-	parentRepository   *ParentRepository
-	childRepository    *ChildRepository
+	client                 *mongo.Client
+	db                     *mongo.Database
+	logger                 *zap.Logger
+	transactionManager     *TransactionManager
+	parentRepository       *ParentRepository
+	childRepository        *ChildRepository
+	outboxRepository       *OutboxRepository
+	webhookRepository      *WebhookRepository
+	auditLogRepository     *AuditLogRepository
+	historyRepository      *HistoryRepository
+	guardianshipRepository *GuardianshipRepository
+	householdRepository    *HouseholdRepository
 }
 
 // NewRepositoryFactory creates a new MongoDB repository factory
@@ -88,14 +94,26 @@ func NewRepositoryFactory(ctx context.Context, logger *zap.Logger, config ports.
 	// Create repositories
 	parentRepository := NewParentRepository(ctx, db, logger, config)
 	childRepository := NewChildRepository(ctx, db, logger, config)
+	outboxRepository := NewOutboxRepository(db, logger)
+	webhookRepository := NewWebhookRepository(db, logger)
+	auditLogRepository := NewAuditLogRepository(db, logger)
+	historyRepository := NewHistoryRepository(db, logger)
+	guardianshipRepository := NewGuardianshipRepository(db, logger)
+	householdRepository := NewHouseholdRepository(db, logger)
 
 	return &RepositoryFactory{
-		client:             client,
-		db:                 db,
-		logger:             logger,
-		transactionManager: transactionManager,
-		parentRepository:   parentRepository,
-		childRepository:    childRepository,
+		client:                 client,
+		db:                     db,
+		logger:                 logger,
+		transactionManager:     transactionManager,
+		parentRepository:       parentRepository,
+		childRepository:        childRepository,
+		outboxRepository:       outboxRepository,
+		webhookRepository:      webhookRepository,
+		auditLogRepository:     auditLogRepository,
+		historyRepository:      historyRepository,
+		guardianshipRepository: guardianshipRepository,
+		householdRepository:    householdRepository,
 	}, nil
 }
 
@@ -109,6 +127,36 @@ func (f *RepositoryFactory) NewChildRepository() ports.ChildRepository {
 	return f.childRepository
 }
 
+// NewOutboxRepository returns an outbox repository
+func (f *RepositoryFactory) NewOutboxRepository() ports.OutboxRepository {
+	return f.outboxRepository
+}
+
+// NewWebhookRepository returns a webhook repository
+func (f *RepositoryFactory) NewWebhookRepository() ports.WebhookRepository {
+	return f.webhookRepository
+}
+
+// NewAuditLogRepository returns an audit log repository
+func (f *RepositoryFactory) NewAuditLogRepository() ports.AuditLogRepository {
+	return f.auditLogRepository
+}
+
+// NewHistoryRepository returns a history repository
+func (f *RepositoryFactory) NewHistoryRepository() ports.HistoryRepository {
+	return f.historyRepository
+}
+
+// NewGuardianshipRepository returns a guardianship repository
+func (f *RepositoryFactory) NewGuardianshipRepository() ports.GuardianshipRepository {
+	return f.guardianshipRepository
+}
+
+// NewHouseholdRepository returns a household repository
+func (f *RepositoryFactory) NewHouseholdRepository() ports.HouseholdRepository {
+	return f.householdRepository
+}
+
 // GetTransactionManager returns the transaction manager
 func (f *RepositoryFactory) GetTransactionManager() ports.TransactionManager {
 	return f.transactionManager
@@ -0,0 +1,21 @@
+package mongodb
+
+import (
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// deletedCondition returns the condition on the deleted_at field that selects the documents a filter asks for:
+// the documents marked as deleted when filter.Deleted is set, and the other documents otherwise
+//
+// Parameters:
+//   - filter: The generic filter options
+//
+// Returns:
+//   - The condition to use as the value of the deleted_at field in a filter document
+func deletedCondition(filter ports.FilterOptions) interface{} {
+	if filter.Deleted {
+		return bson.M{"$ne": nil}
+	}
+	return nil
+}
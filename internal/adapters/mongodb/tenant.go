@@ -0,0 +1,54 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// withTenant restricts a filter document to the documents of the caller's tenant.
+//
+// Parameters:
+//   - ctx: Context holding the caller's tenant
+//   - filter: The filter document to restrict; it is modified in place
+//
+// Returns:
+//   - The restricted filter document
+func withTenant(ctx context.Context, filter bson.M) bson.M {
+	filter["tenantId"] = ports.TenantIDFromContext(ctx)
+	return filter
+}
+
+// reportCrossTenantAccess logs a security event when a document that was not found for the
+// caller's tenant exists in another tenant. The caller still gets a not-found error, so that
+// the existence of the document is not disclosed.
+//
+// Parameters:
+//   - ctx: Context holding the caller's tenant
+//   - collection: The collection holding the document
+//   - logger: Logger for recording the security event
+//   - entityType: The type of the entity, such as "Parent"
+//   - id: The UUID of the document
+func reportCrossTenantAccess(ctx context.Context, collection *mongo.Collection, logger *zap.Logger, entityType string, id uuid.UUID) {
+	tenantID := ports.TenantIDFromContext(ctx)
+
+	var owner struct {
+		TenantID string `bson:"tenantId"`
+	}
+	opts := options.FindOne().SetProjection(bson.M{"tenantId": 1})
+	if err := collection.FindOne(ctx, bson.M{"_id": id}, opts).Decode(&owner); err != nil || owner.TenantID == tenantID {
+		return
+	}
+
+	logger.Warn("Cross-tenant access denied",
+		zap.String("security_event", "cross_tenant_access"),
+		zap.String("entity_type", entityType),
+		zap.String("id", id.String()),
+		zap.String("tenant_id", tenantID),
+		zap.String("owner_tenant_id", owner.TenantID))
+}
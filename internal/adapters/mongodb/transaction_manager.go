@@ -47,7 +47,8 @@ func NewTransactionManager(client *mongo.Client, logger *zap.Logger) *Transactio
 }
 
 // BeginTx begins a new MongoDB transaction and stores the session in the context.
-// If a session already exists in the context, it returns the context unchanged.
+// Operations run with the returned context are part of the transaction, which requires MongoDB
+// to run as a replica set. If a session already exists in the context, it returns the context unchanged.
 // The method handles context cancellation and session creation errors.
 //
 // Parameters:
@@ -79,8 +80,8 @@ func (tm *TransactionManager) BeginTx(ctx context.Context) (context.Context, err
 		return ctx, domain.NewTransactionError("begin", err)
 	}
 
-	// Store session in context
-	ctx = context.WithValue(ctx, sessionKey, session)
+	// Store session in context, binding it so that the operations of the repositories join the transaction
+	ctx = mongo.NewSessionContext(context.WithValue(ctx, sessionKey, session), session)
 
 	// Start a transaction
 	err = session.StartTransaction()
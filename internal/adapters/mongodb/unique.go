@@ -0,0 +1,23 @@
+package mongodb
+
+import (
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// parentEmailIndex is the unique index of the emails of the non-deleted parents of a tenant, regardless of case
+const parentEmailIndex = "idx_parents_email"
+
+// isDuplicateKey reports whether a write failed because it would have stored a value that another
+// document already has in the given unique index.
+//
+// Parameters:
+//   - err: The error of the write
+//   - indexName: The name of the unique index
+//
+// Returns:
+//   - true if the write violated the index
+func isDuplicateKey(err error, indexName string) bool {
+	return mongo.IsDuplicateKeyError(err) && strings.Contains(err.Error(), indexName)
+}
@@ -0,0 +1,31 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// isVersionConflict reports whether an update that matched no document failed because the document of the
+// caller's tenant is at another version than the one that was read, rather than because it does not exist.
+//
+// Parameters:
+//   - ctx: Context holding the caller's tenant
+//   - collection: The collection holding the document
+//   - id: The UUID of the document
+//
+// Returns:
+//   - true if a non-deleted document with the ID exists in the caller's tenant
+func isVersionConflict(ctx context.Context, collection *mongo.Collection, id uuid.UUID) bool {
+	count, err := collection.CountDocuments(ctx, withTenant(ctx, bson.M{
+		"_id":        id,
+		"deleted_at": nil,
+	}))
+	if err != nil {
+		return false
+	}
+
+	return count > 0
+}
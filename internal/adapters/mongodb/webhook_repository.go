@@ -0,0 +1,300 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// WebhookRepository implements the ports.WebhookRepository interface for MongoDB.
+// Subscriptions are stored in the webhook_subscriptions collection and their deliveries in the
+// webhook_deliveries collection, whose unique index on the subscription and the event makes
+// enqueuing idempotent.
+type WebhookRepository struct {
+	subscriptions *mongo.Collection // MongoDB collection for webhook subscriptions
+	deliveries    *mongo.Collection // MongoDB collection for webhook deliveries
+	logger        *zap.Logger       // Logger for recording repository operations
+	tracer        trace.Tracer      // Tracer for distributed tracing
+}
+
+// NewWebhookRepository creates a new MongoDB webhook repository.
+// Parameters:
+//   - db: The MongoDB database connection
+//   - logger: Logger for recording repository operations
+//
+// Returns:
+//   - *WebhookRepository: A new instance of the webhook repository
+func NewWebhookRepository(db *mongo.Database, logger *zap.Logger) *WebhookRepository {
+	return &WebhookRepository{
+		subscriptions: db.Collection("webhook_subscriptions"),
+		deliveries:    db.Collection("webhook_deliveries"),
+		logger:        logger,
+		tracer:        otel.Tracer("mongodb.webhook_repository"),
+	}
+}
+
+// CreateSubscription stores a new subscription of the caller's tenant.
+// Parameters:
+//   - ctx: The context for the operation, holding the caller's tenant
+//   - subscription: The subscription to store
+//
+// Returns:
+//   - error: An error if the subscription could not be stored
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	ctx, span := r.tracer.Start(ctx, "WebhookRepository.CreateSubscription")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("webhook.subscription_id", subscription.ID.String()))
+
+	subscription.TenantID = ports.TenantIDFromContext(ctx)
+
+	_, err := r.subscriptions.InsertOne(ctx, subscription)
+	if err != nil {
+		r.logger.Error("Failed to create webhook subscription", zap.Error(err), zap.String("subscription_id", subscription.ID.String()))
+		return fmt.Errorf("webhook_subscription.create.failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetSubscription retrieves a subscription of the caller's tenant by ID.
+// Parameters:
+//   - ctx: The context for the operation, holding the caller's tenant
+//   - id: The UUID of the subscription
+//
+// Returns:
+//   - *domain.WebhookSubscription: The subscription if found
+//   - error: An error if the subscription is not found or could not be retrieved
+func (r *WebhookRepository) GetSubscription(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	ctx, span := r.tracer.Start(ctx, "WebhookRepository.GetSubscription")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("webhook.subscription_id", id.String()))
+
+	var subscription domain.WebhookSubscription
+	err := r.subscriptions.FindOne(ctx, withTenant(ctx, bson.M{"_id": id})).Decode(&subscription)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			r.logger.Debug("Webhook subscription not found", zap.String("subscription_id", id.String()))
+			reportCrossTenantAccess(ctx, r.subscriptions, r.logger, "WebhookSubscription", id)
+			return nil, fmt.Errorf("webhook subscription not found")
+		}
+		r.logger.Error("Failed to get webhook subscription", zap.Error(err), zap.String("subscription_id", id.String()))
+		return nil, fmt.Errorf("webhook_subscription.get.failed: %w", err)
+	}
+
+	return &subscription, nil
+}
+
+// ListSubscriptions retrieves the subscriptions of the caller's tenant, oldest first.
+// Parameters:
+//   - ctx: The context for the operation, holding the caller's tenant
+//
+// Returns:
+//   - []*domain.WebhookSubscription: The subscriptions
+//   - error: An error if the subscriptions could not be retrieved
+func (r *WebhookRepository) ListSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	ctx, span := r.tracer.Start(ctx, "WebhookRepository.ListSubscriptions")
+	defer span.End()
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.subscriptions.Find(ctx, withTenant(ctx, bson.M{}), findOptions)
+	if err != nil {
+		r.logger.Error("Failed to list webhook subscriptions", zap.Error(err))
+		return nil, fmt.Errorf("webhook_subscription.list.failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	subscriptions := []*domain.WebhookSubscription{}
+	if err := cursor.All(ctx, &subscriptions); err != nil {
+		r.logger.Error("Failed to decode webhook subscriptions", zap.Error(err))
+		return nil, fmt.Errorf("webhook_subscription.decode.failed: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// DeleteSubscription removes a subscription of the caller's tenant together with its deliveries.
+// Parameters:
+//   - ctx: The context for the operation, holding the caller's tenant
+//   - id: The UUID of the subscription
+//
+// Returns:
+//   - error: An error if the subscription is not found or could not be removed
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "WebhookRepository.DeleteSubscription")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("webhook.subscription_id", id.String()))
+
+	result, err := r.subscriptions.DeleteOne(ctx, withTenant(ctx, bson.M{"_id": id}))
+	if err != nil {
+		r.logger.Error("Failed to delete webhook subscription", zap.Error(err), zap.String("subscription_id", id.String()))
+		return fmt.Errorf("webhook_subscription.delete.failed: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		r.logger.Debug("Webhook subscription not found for deletion", zap.String("subscription_id", id.String()))
+		return fmt.Errorf("webhook subscription not found for deletion")
+	}
+
+	_, err = r.deliveries.DeleteMany(ctx, withTenant(ctx, bson.M{"subscriptionId": id}))
+	if err != nil {
+		r.logger.Error("Failed to delete webhook deliveries", zap.Error(err), zap.String("subscription_id", id.String()))
+		return fmt.Errorf("webhook_delivery.delete.failed: %w", err)
+	}
+
+	return nil
+}
+
+// EnqueueDelivery stores a new delivery, unless the event has already been enqueued for the subscription.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - delivery: The delivery to store
+//
+// Returns:
+//   - error: An error if the delivery could not be stored
+func (r *WebhookRepository) EnqueueDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	ctx, span := r.tracer.Start(ctx, "WebhookRepository.EnqueueDelivery")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("webhook.subscription_id", delivery.SubscriptionID.String()),
+		attribute.String("event.id", delivery.EventID.String()),
+	)
+
+	_, err := r.deliveries.InsertOne(ctx, delivery)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			r.logger.Debug("Webhook delivery already enqueued",
+				zap.String("subscription_id", delivery.SubscriptionID.String()),
+				zap.String("event_id", delivery.EventID.String()))
+			return nil
+		}
+		r.logger.Error("Failed to enqueue webhook delivery", zap.Error(err), zap.String("delivery_id", delivery.ID.String()))
+		return fmt.Errorf("webhook_delivery.enqueue.failed: %w", err)
+	}
+
+	return nil
+}
+
+// FetchDueDeliveries retrieves up to limit pending deliveries of all tenants that are due at now, oldest first.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - now: The time at which the deliveries are due
+//   - limit: The maximum number of deliveries to retrieve
+//
+// Returns:
+//   - []*domain.WebhookDelivery: The due deliveries
+//   - error: An error if the deliveries could not be retrieved
+func (r *WebhookRepository) FetchDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	ctx, span := r.tracer.Start(ctx, "WebhookRepository.FetchDueDeliveries")
+	defer span.End()
+
+	filter := bson.M{
+		"status":        domain.DeliveryPending,
+		"nextAttemptAt": bson.M{"$lte": now.UTC()},
+	}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "nextAttemptAt", Value: 1}, {Key: "createdAt", Value: 1}}).
+		SetLimit(int64(limit))
+
+	return r.findDeliveries(ctx, filter, findOptions)
+}
+
+// UpdateDelivery stores the outcome of an attempt to deliver.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - delivery: The delivery after the attempt
+//
+// Returns:
+//   - error: An error if the delivery could not be updated
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	ctx, span := r.tracer.Start(ctx, "WebhookRepository.UpdateDelivery")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("webhook.delivery_id", delivery.ID.String()))
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":         delivery.Status,
+			"attempts":       delivery.Attempts,
+			"nextAttemptAt":  delivery.NextAttemptAt.UTC(),
+			"lastError":      delivery.LastError,
+			"responseStatus": delivery.ResponseStatus,
+			"deliveredAt":    delivery.DeliveredAt,
+		},
+	}
+
+	_, err := r.deliveries.UpdateOne(ctx, bson.M{"_id": delivery.ID, "tenantId": delivery.TenantID}, update)
+	if err != nil {
+		r.logger.Error("Failed to update webhook delivery", zap.Error(err), zap.String("delivery_id", delivery.ID.String()))
+		return fmt.Errorf("webhook_delivery.update.failed: %w", err)
+	}
+
+	return nil
+}
+
+// ListDeliveries retrieves the deliveries of the caller's tenant selected by the filter, newest first.
+// Parameters:
+//   - ctx: The context for the operation, holding the caller's tenant
+//   - filter: Selects the deliveries by subscription and status, and limits their number
+//
+// Returns:
+//   - []*domain.WebhookDelivery: The deliveries
+//   - error: An error if the deliveries could not be retrieved
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, filter ports.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	ctx, span := r.tracer.Start(ctx, "WebhookRepository.ListDeliveries")
+	defer span.End()
+
+	query := withTenant(ctx, bson.M{})
+	if filter.SubscriptionID != nil {
+		query["subscriptionId"] = *filter.SubscriptionID
+	}
+	if filter.Status != nil {
+		query["status"] = *filter.Status
+	}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(filter.Limit))
+
+	return r.findDeliveries(ctx, query, findOptions)
+}
+
+// findDeliveries runs a query of the webhook_deliveries collection and decodes the deliveries
+func (r *WebhookRepository) findDeliveries(ctx context.Context, filter bson.M, findOptions *options.FindOptions) ([]*domain.WebhookDelivery, error) {
+	cursor, err := r.deliveries.Find(ctx, filter, findOptions)
+	if err != nil {
+		r.logger.Error("Failed to list webhook deliveries", zap.Error(err))
+		return nil, fmt.Errorf("webhook_delivery.list.failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := []*domain.WebhookDelivery{}
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		r.logger.Error("Failed to decode webhook deliveries", zap.Error(err))
+		return nil, fmt.Errorf("webhook_delivery.decode.failed: %w", err)
+	}
+
+	for _, delivery := range deliveries {
+		delivery.NextAttemptAt = delivery.NextAttemptAt.UTC()
+		delivery.CreatedAt = delivery.CreatedAt.UTC()
+	}
+
+	return deliveries, nil
+}
+
+// Ensure WebhookRepository implements ports.WebhookRepository
+var _ ports.WebhookRepository = (*WebhookRepository)(nil)
@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// AuditLogRepository implements the ports.AuditLogRepository interface for PostgreSQL.
+// The audit_log table rejects updates and deletes, so records can only be appended.
+type AuditLogRepository struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+	tracer trace.Tracer
+}
+
+// NewAuditLogRepository creates a new PostgreSQL audit log repository
+func NewAuditLogRepository(pool *pgxpool.Pool, logger *zap.Logger) *AuditLogRepository {
+	return &AuditLogRepository{
+		pool:   pool,
+		logger: logger,
+		tracer: otel.Tracer("postgres.audit_log_repository"),
+	}
+}
+
+// Append stores a new record within the transaction carried by ctx, if any,
+// so that the record is kept if and only if the audited change commits
+func (r *AuditLogRepository) Append(ctx context.Context, record *domain.AuditRecord) error {
+	ctx, span := r.tracer.Start(ctx, "AuditLogRepository.Append")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("audit.operation", record.Operation),
+		attribute.String("audit.entity_id", record.EntityID.String()),
+	)
+
+	record.TenantID = ports.TenantIDFromContext(ctx)
+
+	changes, err := json.Marshal(record.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record changes: %w", err)
+	}
+
+	query := `
+		INSERT INTO audit_log (id, tenant_id, actor, operation, entity_type, entity_id, changes, reason, trace_id, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err = conn(ctx, r.pool).Exec(ctx, query,
+		record.ID,
+		record.TenantID,
+		record.Actor,
+		record.Operation,
+		record.EntityType,
+		record.EntityID,
+		changes,
+		record.Reason,
+		record.TraceID,
+		record.OccurredAt,
+	)
+	if err != nil {
+		r.logger.Error("Failed to append audit record", zap.Error(err), zap.String("entity_id", record.EntityID.String()))
+		return fmt.Errorf("failed to append audit record: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves the records of the caller's tenant selected by the filter, oldest first
+func (r *AuditLogRepository) List(ctx context.Context, filter ports.AuditLogFilter) ([]*domain.AuditRecord, error) {
+	ctx, span := r.tracer.Start(ctx, "AuditLogRepository.List")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("audit.entity_id", filter.EntityID.String()))
+
+	conditions := []string{"tenant_id = $1", "entity_id = $2"}
+	args := []any{ports.TenantIDFromContext(ctx), filter.EntityID}
+
+	if filter.From != nil {
+		args = append(args, filter.From.UTC())
+		conditions = append(conditions, fmt.Sprintf("occurred_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, filter.To.UTC())
+		conditions = append(conditions, fmt.Sprintf("occurred_at <= $%d", len(args)))
+	}
+	args = append(args, filter.Limit)
+
+	query := `
+		SELECT id, tenant_id, actor, operation, entity_type, entity_id, changes, reason, trace_id, occurred_at
+		FROM audit_log
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY occurred_at, id
+		LIMIT ` + fmt.Sprintf("$%d", len(args))
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Failed to list audit records", zap.Error(err), zap.String("entity_id", filter.EntityID.String()))
+		return nil, fmt.Errorf("failed to list audit records: %w", err)
+	}
+	defer rows.Close()
+
+	records := []*domain.AuditRecord{}
+	for rows.Next() {
+		var record domain.AuditRecord
+		var changes []byte
+
+		err := rows.Scan(
+			&record.ID,
+			&record.TenantID,
+			&record.Actor,
+			&record.Operation,
+			&record.EntityType,
+			&record.EntityID,
+			&changes,
+			&record.Reason,
+			&record.TraceID,
+			&record.OccurredAt,
+		)
+		if err != nil {
+			r.logger.Error("Failed to scan audit record", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan audit record: %w", err)
+		}
+
+		if err := json.Unmarshal(changes, &record.Changes); err != nil {
+			r.logger.Error("Failed to decode audit record changes", zap.Error(err), zap.String("audit_record_id", record.ID.String()))
+			return nil, fmt.Errorf("failed to decode audit record changes: %w", err)
+		}
+		record.OccurredAt = record.OccurredAt.UTC()
+		records = append(records, &record)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating audit records", zap.Error(err))
+		return nil, fmt.Errorf("error iterating audit records: %w", err)
+	}
+
+	return records, nil
+}
+
+// Ensure AuditLogRepository implements ports.AuditLogRepository
+var _ ports.AuditLogRepository = (*AuditLogRepository)(nil)
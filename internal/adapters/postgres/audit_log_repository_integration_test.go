@@ -0,0 +1,82 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/postgres"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAuditLogRepositoryIntegration tests the PostgreSQL audit log repository with a real PostgreSQL database
+func TestAuditLogRepositoryIntegration(t *testing.T) {
+	// Skip if short flag is set
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	// Set up test repositories using the helper
+	factory, ctx, cleanup := postgres.SetupTestRepositories(t)
+	defer cleanup()
+
+	auditLog := factory.NewAuditLogRepository()
+	tenantCtx := ports.WithTenantID(ctx, "tenant-a")
+	childID := uuid.New()
+
+	created := domain.NewAuditRecord("CreateChild", domain.AuditEntityChild, childID, nil,
+		domain.AuditSnapshot{"firstName": "Ann", "birthDate": "2015-01-02T00:00:00Z"})
+	created.Actor = "user-1"
+	created.TraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	created.OccurredAt = time.Now().UTC().Add(-time.Hour).Truncate(time.Microsecond)
+	require.NoError(t, auditLog.Append(tenantCtx, created))
+
+	updated := domain.NewAuditRecord("UpdateChild", domain.AuditEntityChild, childID,
+		domain.AuditSnapshot{"birthDate": "2015-01-02T00:00:00Z"}, domain.AuditSnapshot{"birthDate": "2015-02-01T00:00:00Z"})
+	updated.Reason = "Corrected birth date"
+	updated.OccurredAt = time.Now().UTC().Truncate(time.Microsecond)
+	require.NoError(t, auditLog.Append(tenantCtx, updated))
+
+	// Test that the records of an entity are listed oldest first with their changes
+	t.Run("ListOfEntity", func(t *testing.T) {
+		records, err := auditLog.List(tenantCtx, ports.AuditLogFilter{EntityID: childID, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, created.ID, records[0].ID)
+		assert.Equal(t, "user-1", records[0].Actor)
+		assert.Equal(t, created.TraceID, records[0].TraceID)
+		assert.Empty(t, records[0].Reason)
+		assert.Equal(t, "Corrected birth date", records[1].Reason)
+		assert.Equal(t, "tenant-a", records[0].TenantID)
+		assert.Equal(t, created.Changes, records[0].Changes)
+		assert.True(t, created.OccurredAt.Equal(records[0].OccurredAt))
+		assert.Equal(t, []domain.FieldChange{
+			{Field: "birthDate", Before: "2015-01-02T00:00:00Z", After: "2015-02-01T00:00:00Z"},
+		}, records[1].Changes)
+	})
+
+	// Test that the time range selects the records
+	t.Run("ListInTimeRange", func(t *testing.T) {
+		from := time.Now().UTC().Add(-time.Minute)
+		records, err := auditLog.List(tenantCtx, ports.AuditLogFilter{EntityID: childID, From: &from, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, updated.ID, records[0].ID)
+
+		to := from
+		records, err = auditLog.List(tenantCtx, ports.AuditLogFilter{EntityID: childID, To: &to, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, created.ID, records[0].ID)
+	})
+
+	// Test that records are only visible to their tenant
+	t.Run("ListOfOtherTenant", func(t *testing.T) {
+		records, err := auditLog.List(ports.WithTenantID(ctx, "tenant-b"), ports.AuditLogFilter{EntityID: childID, Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, records)
+	})
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"reflect"
@@ -27,7 +28,8 @@ type BaseRepository[T domain.Entity] struct {
 	tableName    string
 	entityType   reflect.Type
 	scanFunc     func(row pgx.Row) (T, error)
-	buildListSQL func(filter ports.FilterOptions, sort ports.SortOptions) (string, []interface{})
+	buildListSQL func(ctx context.Context, filter ports.FilterOptions) (string, []interface{})
+	sortColumn   func(sort ports.SortOptions) (string, bool)
 }
 
 // NewBaseRepository creates a new base repository
@@ -37,7 +39,8 @@ func NewBaseRepository[T domain.Entity](
 	tracerName string,
 	tableName string,
 	scanFunc func(row pgx.Row) (T, error),
-	buildListSQL func(filter ports.FilterOptions, sort ports.SortOptions) (string, []interface{}),
+	buildListSQL func(ctx context.Context, filter ports.FilterOptions) (string, []interface{}),
+	sortColumn func(sort ports.SortOptions) (string, bool),
 ) *BaseRepository[T] {
 	// Get the entity type using reflection
 	var entity T
@@ -51,10 +54,11 @@ func NewBaseRepository[T domain.Entity](
 		entityType:   entityType,
 		scanFunc:     scanFunc,
 		buildListSQL: buildListSQL,
+		sortColumn:   sortColumn,
 	}
 }
 
-// GetByID retrieves an entity by ID from the database
+// GetByID retrieves an entity of the caller's tenant by ID from the database
 func (r *BaseRepository[T]) GetByID(ctx context.Context, id uuid.UUID) (T, error) {
 	var zero T
 
@@ -65,15 +69,16 @@ func (r *BaseRepository[T]) GetByID(ctx context.Context, id uuid.UUID) (T, error
 
 	query := fmt.Sprintf(`
 		SELECT * FROM %s
-		WHERE id = $1 AND deleted_at IS NULL
+		WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL
 	`, r.tableName)
 
-	row := r.pool.QueryRow(ctx, query, id)
+	row := conn(ctx, r.pool).QueryRow(ctx, query, id, ports.TenantIDFromContext(ctx))
 	entity, err := r.scanFunc(row)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			r.logger.Debug(fmt.Sprintf("%s not found", r.entityType.Name()), zap.String("id", id.String()))
+			reportCrossTenantAccess(ctx, r.pool, r.logger, r.tableName, r.entityType.Name(), id)
 			return zero, fmt.Errorf("%s not found: %w", strings.ToLower(r.entityType.Name()), err)
 		}
 		r.logger.Error(fmt.Sprintf("Failed to get %s", r.entityType.Name()), zap.Error(err), zap.String("id", id.String()))
@@ -83,7 +88,47 @@ func (r *BaseRepository[T]) GetByID(ctx context.Context, id uuid.UUID) (T, error
 	return entity, nil
 }
 
-// Delete marks an entity as deleted in the database
+// GetByIDs retrieves the entities of the caller's tenant with the given IDs from the database
+// in a single query. IDs that do not match an entity are skipped.
+func (r *BaseRepository[T]) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]T, error) {
+	ctx, span := r.tracer.Start(ctx, fmt.Sprintf("%s.GetByIDs", r.entityType.Name()))
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("entity.count", len(ids)))
+
+	query := fmt.Sprintf(`
+		SELECT * FROM %s
+		WHERE id = ANY($1) AND tenant_id = $2 AND deleted_at IS NULL
+	`, r.tableName)
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, ids, ports.TenantIDFromContext(ctx))
+	if err != nil {
+		r.logger.Error(fmt.Sprintf("Failed to get %ss by IDs", r.entityType.Name()), zap.Error(err), zap.Int("count", len(ids)))
+		return nil, fmt.Errorf("failed to get %ss by IDs: %w", strings.ToLower(r.entityType.Name()), err)
+	}
+	defer rows.Close()
+
+	entities := make([]T, 0, len(ids))
+
+	for rows.Next() {
+		entity, err := r.scanFunc(rows)
+		if err != nil {
+			r.logger.Error(fmt.Sprintf("Failed to scan %s row", r.entityType.Name()), zap.Error(err))
+			return nil, fmt.Errorf("failed to scan %s row: %w", strings.ToLower(r.entityType.Name()), err)
+		}
+
+		entities = append(entities, entity)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error(fmt.Sprintf("Error iterating %s rows", r.entityType.Name()), zap.Error(err))
+		return nil, fmt.Errorf("error iterating %s rows: %w", strings.ToLower(r.entityType.Name()), err)
+	}
+
+	return entities, nil
+}
+
+// Delete marks an entity of the caller's tenant as deleted in the database
 func (r *BaseRepository[T]) Delete(ctx context.Context, id uuid.UUID) error {
 	ctx, span := r.tracer.Start(ctx, fmt.Sprintf("%s.Delete", r.entityType.Name()))
 	defer span.End()
@@ -94,11 +139,11 @@ func (r *BaseRepository[T]) Delete(ctx context.Context, id uuid.UUID) error {
 
 	query := fmt.Sprintf(`
 		UPDATE %s
-		SET deleted_at = $1, updated_at = $1
-		WHERE id = $2 AND deleted_at IS NULL
+		SET deleted_at = $1, updated_at = $1, version = version + 1
+		WHERE id = $2 AND tenant_id = $3 AND deleted_at IS NULL
 	`, r.tableName)
 
-	result, err := r.pool.Exec(ctx, query, now, id)
+	result, err := conn(ctx, r.pool).Exec(ctx, query, now, id, ports.TenantIDFromContext(ctx))
 	if err != nil {
 		r.logger.Error(fmt.Sprintf("Failed to delete %s", r.entityType.Name()), zap.Error(err), zap.String("id", id.String()))
 		return fmt.Errorf("failed to delete %s: %w", strings.ToLower(r.entityType.Name()), err)
@@ -106,34 +151,66 @@ func (r *BaseRepository[T]) Delete(ctx context.Context, id uuid.UUID) error {
 
 	if result.RowsAffected() == 0 {
 		r.logger.Debug(fmt.Sprintf("%s not found for deletion", r.entityType.Name()), zap.String("id", id.String()))
+		reportCrossTenantAccess(ctx, r.pool, r.logger, r.tableName, r.entityType.Name(), id)
 		return fmt.Errorf("%s not found for deletion", strings.ToLower(r.entityType.Name()))
 	}
 
 	return nil
 }
 
-// List retrieves a list of entities with pagination, filtering, and sorting
-func (r *BaseRepository[T]) List(ctx context.Context, options ports.QueryOptions) ([]T, *ports.PagedResult, error) {
-	ctx, span := r.tracer.Start(ctx, fmt.Sprintf("%s.List", r.entityType.Name()))
+// Restore unmarks an entity of the caller's tenant that was marked as deleted in the database
+func (r *BaseRepository[T]) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, fmt.Sprintf("%s.Restore", r.entityType.Name()))
 	defer span.End()
 
-	baseQuery, params := r.buildListSQL(options.Filter, options.Sort)
+	span.SetAttributes(attribute.String("entity.id", id.String()))
+
+	found, err := restoreRow(ctx, conn(ctx, r.pool), r.tableName, id)
+	if err != nil {
+		r.logger.Error(fmt.Sprintf("Failed to restore %s", r.entityType.Name()), zap.Error(err), zap.String("id", id.String()))
+		return fmt.Errorf("failed to restore %s: %w", strings.ToLower(r.entityType.Name()), err)
+	}
 
-	// Add pagination
-	limit := options.Pagination.PageSize
-	if limit <= 0 {
-		limit = 10 // Default page size
+	if !found {
+		r.logger.Debug(fmt.Sprintf("%s not found for restore", r.entityType.Name()), zap.String("id", id.String()))
+		reportCrossTenantAccess(ctx, r.pool, r.logger, r.tableName, r.entityType.Name(), id)
+		return fmt.Errorf("%s not found for restore: %w", strings.ToLower(r.entityType.Name()), domain.ErrNotFound)
 	}
 
-	offset := options.Pagination.Page * limit
-	if offset < 0 {
-		offset = 0
+	return nil
+}
+
+// Purge permanently removes the entities of the caller's tenant that were marked as deleted
+// before the given time from the database, and returns how many were removed
+func (r *BaseRepository[T]) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, span := r.tracer.Start(ctx, fmt.Sprintf("%s.Purge", r.entityType.Name()))
+	defer span.End()
+
+	span.SetAttributes(attribute.String("deleted_before", deletedBefore.Format(time.RFC3339)))
+
+	purged, err := purgeRows(ctx, conn(ctx, r.pool), r.tableName, deletedBefore)
+	if err != nil {
+		r.logger.Error(fmt.Sprintf("Failed to purge deleted %s entities", r.entityType.Name()), zap.Error(err))
+		return 0, fmt.Errorf("failed to purge deleted %s entities: %w", strings.ToLower(r.entityType.Name()), err)
 	}
 
-	query := baseQuery + fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(params)+1, len(params)+2)
-	params = append(params, limit, offset)
+	span.SetAttributes(attribute.Int64("entity.purged", purged))
+
+	return purged, nil
+}
+
+// List retrieves a list of entities with pagination, filtering, and sorting
+func (r *BaseRepository[T]) List(ctx context.Context, options ports.QueryOptions) ([]T, *ports.PagedResult, error) {
+	ctx, span := r.tracer.Start(ctx, fmt.Sprintf("%s.List", r.entityType.Name()))
+	defer span.End()
+
+	baseQuery, params := r.buildListSQL(ctx, options.Filter)
+	sortColumn, desc := r.sortColumn(options.Sort)
+
+	// Add ordering and pagination
+	query, params, limit, offset := appendWindow(baseQuery, params, sortColumn, "id", desc, options)
 
-	rows, err := r.pool.Query(ctx, query, params...)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, params...)
 	if err != nil {
 		r.logger.Error(fmt.Sprintf("Failed to list %ss", r.entityType.Name()), zap.Error(err))
 		return nil, nil, fmt.Errorf("failed to list %ss: %w", strings.ToLower(r.entityType.Name()), err)
@@ -164,12 +241,7 @@ func (r *BaseRepository[T]) List(ctx context.Context, options ports.QueryOptions
 		return nil, nil, fmt.Errorf("failed to get total count: %w", err)
 	}
 
-	pagedResult := &ports.PagedResult{
-		TotalCount: totalCount,
-		Page:       options.Pagination.Page,
-		PageSize:   limit,
-		HasNext:    int64(offset+len(entities)) < totalCount,
-	}
+	entities, pagedResult := windowResult(entities, options, limit, offset, totalCount)
 
 	return entities, pagedResult, nil
 }
@@ -179,48 +251,12 @@ func (r *BaseRepository[T]) Count(ctx context.Context, filter ports.FilterOption
 	ctx, span := r.tracer.Start(ctx, fmt.Sprintf("%s.Count", r.entityType.Name()))
 	defer span.End()
 
-	// This is a simplified count query that doesn't use buildListSQL
-	// In a real implementation, you might want to extract the WHERE conditions from buildListSQL
-	query := fmt.Sprintf(`
-		SELECT COUNT(*)
-		FROM %s
-		WHERE deleted_at IS NULL
-	`, r.tableName)
-
-	params := []interface{}{}
-	paramIndex := 1
-	whereConditions := []string{}
-
-	if filter.FirstName != "" {
-		whereConditions = append(whereConditions, fmt.Sprintf("first_name ILIKE $%d", paramIndex))
-		params = append(params, "%"+filter.FirstName+"%")
-		paramIndex++
-	}
-
-	if filter.LastName != "" {
-		whereConditions = append(whereConditions, fmt.Sprintf("last_name ILIKE $%d", paramIndex))
-		params = append(params, "%"+filter.LastName+"%")
-		paramIndex++
-	}
-
-	if filter.MinAge > 0 {
-		whereConditions = append(whereConditions, fmt.Sprintf("birth_date <= $%d", paramIndex))
-		params = append(params, time.Now().AddDate(-filter.MinAge, 0, 0))
-		paramIndex++
-	}
-
-	if filter.MaxAge > 0 {
-		whereConditions = append(whereConditions, fmt.Sprintf("birth_date >= $%d", paramIndex))
-		params = append(params, time.Now().AddDate(-filter.MaxAge, 0, 0))
-		paramIndex++
-	}
-
-	if len(whereConditions) > 0 {
-		query += " AND " + strings.Join(whereConditions, " AND ")
-	}
+	// Count the rows of the list query, so that the count applies exactly the same filters
+	listQuery, params := r.buildListSQL(ctx, filter)
+	query := fmt.Sprintf("SELECT COUNT(*) FROM (%s) AS filtered", listQuery)
 
 	var count int64
-	err := r.pool.QueryRow(ctx, query, params...).Scan(&count)
+	err := conn(ctx, r.pool).QueryRow(ctx, query, params...).Scan(&count)
 	if err != nil {
 		r.logger.Error(fmt.Sprintf("Failed to count %ss", r.entityType.Name()), zap.Error(err))
 		return 0, fmt.Errorf("failed to count %ss: %w", strings.ToLower(r.entityType.Name()), err)
@@ -228,3 +264,8 @@ func (r *BaseRepository[T]) Count(ctx context.Context, filter ports.FilterOption
 
 	return count, nil
 }
+
+// nullString converts an optional string to a nullable column value, storing an empty string as NULL
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// The rows of a batch are inserted with a pipeline of INSERT statements rather than with COPY,
+// since COPY FROM is not supported on tables that force row-level security, as all tables do.
+
+// sendBatch sends the statements of a batch, one for each item, in a single round trip, and returns
+// a domain.BatchError holding the position and the error of the first statement that fails.
+// A batch sent outside a transaction runs in an implicit transaction, so that it is all-or-nothing as well.
+func sendBatch(ctx context.Context, q querier, batch *pgx.Batch) error {
+	results := q.SendBatch(ctx, batch)
+
+	for i := 0; i < batch.Len(); i++ {
+		if _, err := results.Exec(); err != nil {
+			_ = results.Close()
+			return domain.NewBatchError(i, err)
+		}
+	}
+
+	return results.Close()
+}
+
+// insertParents inserts parents of the caller's tenant in a single round trip.
+// A parent whose email is taken fails with a domain.DuplicateError.
+func insertParents(ctx context.Context, q querier, parents []*domain.Parent) error {
+	query := `
+		INSERT INTO parents (id, first_name, last_name, email, birth_date, created_at, updated_at, user_id, contact_details, tenant_id, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	batch := &pgx.Batch{}
+	for _, parent := range parents {
+		parent.TenantID = ports.TenantIDFromContext(ctx)
+		batch.Queue(query,
+			parent.ID,
+			parent.FirstName,
+			parent.LastName,
+			parent.Email,
+			parent.BirthDate,
+			parent.CreatedAt,
+			parent.UpdatedAt,
+			nullString(parent.UserID),
+			parent.ContactDetails,
+			parent.TenantID,
+			parent.Version,
+		)
+	}
+
+	err := sendBatch(ctx, q, batch)
+	var batchErr *domain.BatchError
+	if errors.As(err, &batchErr) && isUniqueViolation(batchErr.Err, parentEmailIndex) {
+		return domain.NewBatchError(batchErr.Index, domain.NewDuplicateError("Parent", "email", parents[batchErr.Index].Email))
+	}
+	return err
+}
+
+// insertChildren inserts children of the caller's tenant in a single round trip, after checking that their
+// parents exist in the same tenant. A child whose parent does not exist fails with a domain.NotFoundError.
+func insertChildren(ctx context.Context, q querier, children []*domain.Child) error {
+	tenantID := ports.TenantIDFromContext(ctx)
+
+	parentIDs := make([]uuid.UUID, 0, len(children))
+	for _, child := range children {
+		parentIDs = append(parentIDs, child.ParentID)
+	}
+
+	rows, err := q.Query(ctx, `
+		SELECT id FROM parents WHERE id = ANY($1) AND tenant_id = $2 AND deleted_at IS NULL
+	`, parentIDs, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to check parent existence: %w", err)
+	}
+	defer rows.Close()
+
+	found := make(map[uuid.UUID]bool, len(parentIDs))
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return fmt.Errorf("failed to check parent existence: %w", err)
+		}
+		found[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to check parent existence: %w", err)
+	}
+	rows.Close()
+
+	for i, child := range children {
+		if !found[child.ParentID] {
+			return domain.NewBatchError(i, domain.NewNotFoundError("Parent", child.ParentID.String()))
+		}
+	}
+
+	query := `
+		INSERT INTO children (id, first_name, last_name, birth_date, parent_id, created_at, updated_at, tenant_id, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	batch := &pgx.Batch{}
+	for _, child := range children {
+		child.TenantID = tenantID
+		batch.Queue(query,
+			child.ID,
+			child.FirstName,
+			child.LastName,
+			child.BirthDate,
+			child.ParentID,
+			child.CreatedAt,
+			child.UpdatedAt,
+			child.TenantID,
+			child.Version,
+		)
+	}
+
+	return sendBatch(ctx, q, batch)
+}
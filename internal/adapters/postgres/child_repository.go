@@ -35,7 +35,7 @@ func NewChildRepository(pool *pgxpool.Pool, logger *zap.Logger) *ChildRepository
 	}
 }
 
-// Create creates a new child in the database
+// Create creates a new child of the caller's tenant in the database
 func (r *ChildRepository) Create(ctx context.Context, child *domain.Child) error {
 	ctx, span := r.tracer.Start(ctx, "ChildRepository.Create")
 	defer span.End()
@@ -45,15 +45,18 @@ func (r *ChildRepository) Create(ctx context.Context, child *domain.Child) error
 		attribute.String("parent.id", child.ParentID.String()),
 	)
 
-	// First check if the parent exists
+	child.TenantID = ports.TenantIDFromContext(ctx)
+
+	// First check if the parent exists in the same tenant
 	parentQuery := `
-		SELECT 1 FROM parents WHERE id = $1 AND deleted_at IS NULL
+		SELECT 1 FROM parents WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL
 	`
 	var exists int
-	err := r.pool.QueryRow(ctx, parentQuery, child.ParentID).Scan(&exists)
+	err := conn(ctx, r.pool).QueryRow(ctx, parentQuery, child.ParentID, child.TenantID).Scan(&exists)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			r.logger.Debug("Parent not found for child creation", zap.String("parent_id", child.ParentID.String()))
+			reportCrossTenantAccess(ctx, r.pool, r.logger, "parents", "Parent", child.ParentID)
 			return fmt.Errorf("parent not found for child creation")
 		}
 		r.logger.Error("Failed to check parent existence", zap.Error(err), zap.String("parent_id", child.ParentID.String()))
@@ -61,11 +64,11 @@ func (r *ChildRepository) Create(ctx context.Context, child *domain.Child) error
 	}
 
 	query := `
-		INSERT INTO children (id, first_name, last_name, birth_date, parent_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO children (id, first_name, last_name, birth_date, parent_id, created_at, updated_at, tenant_id, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
-	_, err = r.pool.Exec(ctx, query,
+	_, err = conn(ctx, r.pool).Exec(ctx, query,
 		child.ID,
 		child.FirstName,
 		child.LastName,
@@ -73,6 +76,8 @@ func (r *ChildRepository) Create(ctx context.Context, child *domain.Child) error
 		child.ParentID,
 		child.CreatedAt,
 		child.UpdatedAt,
+		child.TenantID,
+		child.Version,
 	)
 
 	if err != nil {
@@ -83,7 +88,29 @@ func (r *ChildRepository) Create(ctx context.Context, child *domain.Child) error
 	return nil
 }
 
-// GetByID retrieves a child by ID from the database
+// CreateMany creates many children of the caller's tenant in the database in a single round trip,
+// after checking that their parents exist in the same tenant
+func (r *ChildRepository) CreateMany(ctx context.Context, children []*domain.Child) error {
+	ctx, span := r.tracer.Start(ctx, "ChildRepository.CreateMany")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("children.count", len(children)))
+
+	if err := insertChildren(ctx, conn(ctx, r.pool), children); err != nil {
+		var batchErr *domain.BatchError
+		if errors.As(err, &batchErr) && errors.Is(batchErr.Err, domain.ErrNotFound) {
+			r.logger.Debug("Parent not found for child creation", zap.String("parent_id", children[batchErr.Index].ParentID.String()))
+			reportCrossTenantAccess(ctx, r.pool, r.logger, "parents", "Parent", children[batchErr.Index].ParentID)
+			return err
+		}
+		r.logger.Error("Failed to create children", zap.Error(err), zap.Int("count", len(children)))
+		return fmt.Errorf("failed to create children: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a child of the caller's tenant by ID from the database
 func (r *ChildRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Child, error) {
 	ctx, span := r.tracer.Start(ctx, "ChildRepository.GetByID")
 	defer span.End()
@@ -91,12 +118,12 @@ func (r *ChildRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ch
 	span.SetAttributes(attribute.String("child.id", id.String()))
 
 	query := `
-		SELECT c.id, c.first_name, c.last_name, c.birth_date, c.parent_id, c.created_at, c.updated_at, c.deleted_at
+		SELECT c.id, c.first_name, c.last_name, c.birth_date, c.parent_id, c.created_at, c.updated_at, c.deleted_at, c.tenant_id, c.version
 		FROM children c
-		WHERE c.id = $1 AND c.deleted_at IS NULL
+		WHERE c.id = $1 AND c.tenant_id = $2 AND c.deleted_at IS NULL
 	`
 
-	row := r.pool.QueryRow(ctx, query, id)
+	row := conn(ctx, r.pool).QueryRow(ctx, query, id, ports.TenantIDFromContext(ctx))
 
 	var child domain.Child
 	var deletedAt sql.NullTime
@@ -110,11 +137,14 @@ func (r *ChildRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ch
 		&child.CreatedAt,
 		&child.UpdatedAt,
 		&deletedAt,
+		&child.TenantID,
+		&child.Version,
 	)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			r.logger.Debug("Child not found", zap.String("child_id", id.String()))
+			reportCrossTenantAccess(ctx, r.pool, r.logger, "children", "Child", id)
 			return nil, fmt.Errorf("child not found: %w", err)
 		}
 		r.logger.Error("Failed to get child", zap.Error(err), zap.String("child_id", id.String()))
@@ -128,7 +158,8 @@ func (r *ChildRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ch
 	return &child, nil
 }
 
-// Update updates an existing child in the database
+// Update updates an existing child of the caller's tenant in the database, provided that it is still
+// at the version of the given child, and increments the version
 func (r *ChildRepository) Update(ctx context.Context, child *domain.Child) error {
 	ctx, span := r.tracer.Start(ctx, "ChildRepository.Update")
 	defer span.End()
@@ -137,16 +168,19 @@ func (r *ChildRepository) Update(ctx context.Context, child *domain.Child) error
 
 	query := `
 		UPDATE children
-		SET first_name = $1, last_name = $2, birth_date = $3, updated_at = $4
-		WHERE id = $5 AND deleted_at IS NULL
+		SET first_name = $1, last_name = $2, birth_date = $3, parent_id = $4, updated_at = $5, version = version + 1
+		WHERE id = $6 AND tenant_id = $7 AND version = $8 AND deleted_at IS NULL
 	`
 
-	result, err := r.pool.Exec(ctx, query,
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
 		child.FirstName,
 		child.LastName,
 		child.BirthDate,
+		child.ParentID,
 		time.Now().UTC(),
 		child.ID,
+		ports.TenantIDFromContext(ctx),
+		child.Version,
 	)
 
 	if err != nil {
@@ -155,14 +189,20 @@ func (r *ChildRepository) Update(ctx context.Context, child *domain.Child) error
 	}
 
 	if result.RowsAffected() == 0 {
+		if isVersionConflict(ctx, conn(ctx, r.pool), "children", child.ID) {
+			r.logger.Debug("Child version conflict", zap.String("child_id", child.ID.String()), zap.Int("version", child.Version))
+			return domain.NewConflictError("Child", child.ID.String(), child.Version)
+		}
 		r.logger.Debug("Child not found for update", zap.String("child_id", child.ID.String()))
+		reportCrossTenantAccess(ctx, r.pool, r.logger, "children", "Child", child.ID)
 		return fmt.Errorf("child not found")
 	}
 
+	child.Version++
 	return nil
 }
 
-// Delete marks a child as deleted in the database
+// Delete marks a child of the caller's tenant as deleted in the database
 func (r *ChildRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	ctx, span := r.tracer.Start(ctx, "ChildRepository.Delete")
 	defer span.End()
@@ -173,11 +213,11 @@ func (r *ChildRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 	query := `
 		UPDATE children
-		SET deleted_at = $1, updated_at = $1
-		WHERE id = $2 AND deleted_at IS NULL
+		SET deleted_at = $1, updated_at = $1, version = version + 1
+		WHERE id = $2 AND tenant_id = $3 AND deleted_at IS NULL
 	`
 
-	result, err := r.pool.Exec(ctx, query, now, id)
+	result, err := conn(ctx, r.pool).Exec(ctx, query, now, id, ports.TenantIDFromContext(ctx))
 	if err != nil {
 		r.logger.Error("Failed to delete child", zap.Error(err), zap.String("child_id", id.String()))
 		return fmt.Errorf("failed to delete child: %w", err)
@@ -185,22 +225,62 @@ func (r *ChildRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 	if result.RowsAffected() == 0 {
 		r.logger.Debug("Child not found for deletion", zap.String("child_id", id.String()))
+		reportCrossTenantAccess(ctx, r.pool, r.logger, "children", "Child", id)
 		return fmt.Errorf("child not found")
 	}
 
 	return nil
 }
 
-// buildListQuery builds a query for listing children with filtering, pagination, and sorting
-func (r *ChildRepository) buildListQuery(filter ports.FilterOptions, sort ports.SortOptions, parentID *uuid.UUID) (string, []interface{}) {
+// Restore unmarks a child of the caller's tenant that was marked as deleted in the database
+func (r *ChildRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "ChildRepository.Restore")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("child.id", id.String()))
+
+	found, err := restoreRow(ctx, conn(ctx, r.pool), "children", id)
+	if err != nil {
+		r.logger.Error("Failed to restore child", zap.Error(err), zap.String("child_id", id.String()))
+		return fmt.Errorf("failed to restore child: %w", err)
+	}
+
+	if !found {
+		r.logger.Debug("Child not found for restore", zap.String("child_id", id.String()))
+		reportCrossTenantAccess(ctx, r.pool, r.logger, "children", "Child", id)
+		return fmt.Errorf("child not found for restore: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// Purge permanently removes the children of the caller's tenant that were marked as deleted before
+// the given time from the database, and returns how many were removed
+func (r *ChildRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, span := r.tracer.Start(ctx, "ChildRepository.Purge")
+	defer span.End()
+
+	purged, err := purgeRows(ctx, conn(ctx, r.pool), "children", deletedBefore)
+	if err != nil {
+		r.logger.Error("Failed to purge children", zap.Error(err))
+		return 0, fmt.Errorf("failed to purge children: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int64("child.purged", purged))
+
+	return purged, nil
+}
+
+// buildListQuery builds a query for listing the children of the caller's tenant with filtering
+func (r *ChildRepository) buildListQuery(ctx context.Context, filter ports.FilterOptions, parentID *uuid.UUID) (string, []interface{}) {
 	query := `
-		SELECT c.id, c.first_name, c.last_name, c.birth_date, c.parent_id, c.created_at, c.updated_at, c.deleted_at
+		SELECT c.id, c.first_name, c.last_name, c.birth_date, c.parent_id, c.created_at, c.updated_at, c.deleted_at, c.tenant_id, c.version
 		FROM children c
-		WHERE c.deleted_at IS NULL
+		WHERE ` + deletedCondition("c.deleted_at", filter) + ` AND c.tenant_id = $1
 	`
 
-	params := []interface{}{}
-	paramIndex := 1
+	params := []interface{}{ports.TenantIDFromContext(ctx)}
+	paramIndex := 2
 	whereConditions := []string{}
 
 	if parentID != nil {
@@ -233,41 +313,50 @@ func (r *ChildRepository) buildListQuery(filter ports.FilterOptions, sort ports.
 		paramIndex++
 	}
 
-	if len(whereConditions) > 0 {
-		query += " AND " + strings.Join(whereConditions, " AND ")
+	if len(filter.ParentIDs) > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf("c.parent_id = ANY($%d)", paramIndex))
+		params = append(params, filter.ParentIDs)
+		paramIndex++
 	}
 
-	// Add sorting
-	if sort.Field != "" {
-		direction := "ASC"
-		if strings.ToLower(sort.Direction) == "desc" {
-			direction = "DESC"
-		}
-
-		var sortField string
-		switch strings.ToLower(sort.Field) {
-		case "firstname", "first_name":
-			sortField = "c.first_name"
-		case "lastname", "last_name":
-			sortField = "c.last_name"
-		case "birthdate", "birth_date":
-			sortField = "c.birth_date"
-		case "createdat", "created_at":
-			sortField = "c.created_at"
-		case "updatedat", "updated_at":
-			sortField = "c.updated_at"
-		default:
-			sortField = "c.created_at"
-		}
+	if len(filter.ChildIDs) > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf("c.id = ANY($%d)", paramIndex))
+		params = append(params, filter.ChildIDs)
+		paramIndex++
+	}
 
-		query += fmt.Sprintf(" ORDER BY %s %s", sortField, direction)
-	} else {
-		query += " ORDER BY c.created_at DESC"
+	if len(whereConditions) > 0 {
+		query += " AND " + strings.Join(whereConditions, " AND ")
 	}
 
 	return query, params
 }
 
+// sortColumn maps the sort options to a column and reports whether the order is descending
+func (r *ChildRepository) sortColumn(sort ports.SortOptions) (string, bool) {
+	if sort.Field == "" {
+		return "c.created_at", true
+	}
+
+	var sortField string
+	switch strings.ToLower(sort.Field) {
+	case "firstname", "first_name":
+		sortField = "c.first_name"
+	case "lastname", "last_name":
+		sortField = "c.last_name"
+	case "birthdate", "birth_date":
+		sortField = "c.birth_date"
+	case "createdat", "created_at":
+		sortField = "c.created_at"
+	case "updatedat", "updated_at":
+		sortField = "c.updated_at"
+	default:
+		sortField = "c.created_at"
+	}
+
+	return sortField, strings.ToLower(sort.Direction) == "desc"
+}
+
 // ListByParentID retrieves children for a specific parent with pagination, filtering, and sorting
 func (r *ChildRepository) ListByParentID(ctx context.Context, parentID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
 	ctx, span := r.tracer.Start(ctx, "ChildRepository.ListByParentID")
@@ -275,21 +364,12 @@ func (r *ChildRepository) ListByParentID(ctx context.Context, parentID uuid.UUID
 
 	span.SetAttributes(attribute.String("parent.id", parentID.String()))
 
-	baseQuery, params := r.buildListQuery(options.Filter, options.Sort, &parentID)
-
-	// Add pagination
-	limit := options.Pagination.PageSize
-	if limit <= 0 {
-		limit = 10 // Default page size
-	}
+	baseQuery, params := r.buildListQuery(ctx, options.Filter, &parentID)
 
-	offset := options.Pagination.Page * limit
-	if offset < 0 {
-		offset = 0
-	}
+	sortColumn, desc := r.sortColumn(options.Sort)
 
-	query := baseQuery + fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(params)+1, len(params)+2)
-	params = append(params, limit, offset)
+	// Add ordering and pagination
+	query, params, limit, offset := appendWindow(baseQuery, params, sortColumn, "c.id", desc, options)
 
 	// Create channels for concurrent operations
 	type queryResult struct {
@@ -305,7 +385,7 @@ func (r *ChildRepository) ListByParentID(ctx context.Context, parentID uuid.UUID
 
 	// Execute query operation concurrently
 	go func() {
-		rows, err := r.pool.Query(ctx, query, params...)
+		rows, err := conn(ctx, r.pool).Query(ctx, query, params...)
 		if err != nil {
 			queryCh <- queryResult{nil, fmt.Errorf("failed to list children by parent ID: %w", err)}
 			return
@@ -327,6 +407,8 @@ func (r *ChildRepository) ListByParentID(ctx context.Context, parentID uuid.UUID
 				&child.CreatedAt,
 				&child.UpdatedAt,
 				&deletedAt,
+				&child.TenantID,
+				&child.Version,
 			)
 
 			if err != nil {
@@ -374,14 +456,70 @@ func (r *ChildRepository) ListByParentID(ctx context.Context, parentID uuid.UUID
 		return nil, nil, countRes.err
 	}
 
-	pagedResult := &ports.PagedResult{
-		TotalCount: countRes.count,
-		Page:       options.Pagination.Page,
-		PageSize:   limit,
-		HasNext:    int64(offset+len(queryRes.children)) < countRes.count,
+	children, pagedResult := windowResult(queryRes.children, options, limit, offset, countRes.count)
+
+	return children, pagedResult, nil
+}
+
+// ListByParentIDs retrieves all children of the given parents of the caller's tenant from the database
+// in a single query
+func (r *ChildRepository) ListByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Child, error) {
+	ctx, span := r.tracer.Start(ctx, "ChildRepository.ListByParentIDs")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("parent.count", len(parentIDs)))
+
+	query := `
+		SELECT c.id, c.first_name, c.last_name, c.birth_date, c.parent_id, c.created_at, c.updated_at, c.deleted_at, c.tenant_id, c.version
+		FROM children c
+		WHERE c.parent_id = ANY($1) AND c.tenant_id = $2 AND c.deleted_at IS NULL
+		ORDER BY c.parent_id, c.created_at, c.id
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, parentIDs, ports.TenantIDFromContext(ctx))
+	if err != nil {
+		r.logger.Error("Failed to list children by parent IDs", zap.Error(err), zap.Int("count", len(parentIDs)))
+		return nil, fmt.Errorf("failed to list children by parent IDs: %w", err)
+	}
+	defer rows.Close()
+
+	children := []*domain.Child{}
+
+	for rows.Next() {
+		var child domain.Child
+		var deletedAt sql.NullTime
+
+		err := rows.Scan(
+			&child.ID,
+			&child.FirstName,
+			&child.LastName,
+			&child.BirthDate,
+			&child.ParentID,
+			&child.CreatedAt,
+			&child.UpdatedAt,
+			&deletedAt,
+			&child.TenantID,
+			&child.Version,
+		)
+
+		if err != nil {
+			r.logger.Error("Failed to scan child row", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan child row: %w", err)
+		}
+
+		if deletedAt.Valid {
+			child.DeletedAt = &deletedAt.Time
+		}
+
+		children = append(children, &child)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating child rows", zap.Error(err))
+		return nil, fmt.Errorf("error iterating child rows: %w", err)
 	}
 
-	return queryRes.children, pagedResult, nil
+	return children, nil
 }
 
 // List retrieves a list of children with pagination, filtering, and sorting
@@ -389,21 +527,12 @@ func (r *ChildRepository) List(ctx context.Context, options ports.QueryOptions)
 	ctx, span := r.tracer.Start(ctx, "ChildRepository.List")
 	defer span.End()
 
-	baseQuery, params := r.buildListQuery(options.Filter, options.Sort, nil)
+	baseQuery, params := r.buildListQuery(ctx, options.Filter, nil)
 
-	// Add pagination
-	limit := options.Pagination.PageSize
-	if limit <= 0 {
-		limit = 10 // Default page size
-	}
-
-	offset := options.Pagination.Page * limit
-	if offset < 0 {
-		offset = 0
-	}
+	sortColumn, desc := r.sortColumn(options.Sort)
 
-	query := baseQuery + fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(params)+1, len(params)+2)
-	params = append(params, limit, offset)
+	// Add ordering and pagination
+	query, params, limit, offset := appendWindow(baseQuery, params, sortColumn, "c.id", desc, options)
 
 	// Create channels for concurrent operations
 	type queryResult struct {
@@ -419,7 +548,7 @@ func (r *ChildRepository) List(ctx context.Context, options ports.QueryOptions)
 
 	// Execute query operation concurrently
 	go func() {
-		rows, err := r.pool.Query(ctx, query, params...)
+		rows, err := conn(ctx, r.pool).Query(ctx, query, params...)
 		if err != nil {
 			queryCh <- queryResult{nil, fmt.Errorf("failed to list children: %w", err)}
 			return
@@ -441,6 +570,8 @@ func (r *ChildRepository) List(ctx context.Context, options ports.QueryOptions)
 				&child.CreatedAt,
 				&child.UpdatedAt,
 				&deletedAt,
+				&child.TenantID,
+				&child.Version,
 			)
 
 			if err != nil {
@@ -487,14 +618,9 @@ func (r *ChildRepository) List(ctx context.Context, options ports.QueryOptions)
 		return nil, nil, countRes.err
 	}
 
-	pagedResult := &ports.PagedResult{
-		TotalCount: countRes.count,
-		Page:       options.Pagination.Page,
-		PageSize:   limit,
-		HasNext:    int64(offset+len(queryRes.children)) < countRes.count,
-	}
+	children, pagedResult := windowResult(queryRes.children, options, limit, offset, countRes.count)
 
-	return queryRes.children, pagedResult, nil
+	return children, pagedResult, nil
 }
 
 // countByParentID returns the total count of children for a specific parent matching the filter
@@ -507,11 +633,11 @@ func (r *ChildRepository) countByParentID(ctx context.Context, parentID uuid.UUI
 	query := `
 		SELECT COUNT(*)
 		FROM children c
-		WHERE c.deleted_at IS NULL AND c.parent_id = $1
+		WHERE ` + deletedCondition("c.deleted_at", filter) + ` AND c.parent_id = $1 AND c.tenant_id = $2
 	`
 
-	params := []interface{}{parentID}
-	paramIndex := 2
+	params := []interface{}{parentID, ports.TenantIDFromContext(ctx)}
+	paramIndex := 3
 	whereConditions := []string{}
 
 	if filter.FirstName != "" {
@@ -538,12 +664,24 @@ func (r *ChildRepository) countByParentID(ctx context.Context, parentID uuid.UUI
 		paramIndex++
 	}
 
+	if len(filter.ParentIDs) > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf("c.parent_id = ANY($%d)", paramIndex))
+		params = append(params, filter.ParentIDs)
+		paramIndex++
+	}
+
+	if len(filter.ChildIDs) > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf("c.id = ANY($%d)", paramIndex))
+		params = append(params, filter.ChildIDs)
+		paramIndex++
+	}
+
 	if len(whereConditions) > 0 {
 		query += " AND " + strings.Join(whereConditions, " AND ")
 	}
 
 	var count int64
-	err := r.pool.QueryRow(ctx, query, params...).Scan(&count)
+	err := conn(ctx, r.pool).QueryRow(ctx, query, params...).Scan(&count)
 	if err != nil {
 		r.logger.Error("Failed to count children by parent ID", zap.Error(err), zap.String("parent_id", parentID.String()))
 		return 0, fmt.Errorf("failed to count children by parent ID: %w", err)
@@ -552,7 +690,7 @@ func (r *ChildRepository) countByParentID(ctx context.Context, parentID uuid.UUI
 	return count, nil
 }
 
-// Count returns the total count of children matching the filter
+// Count returns the total count of the children of the caller's tenant matching the filter
 func (r *ChildRepository) Count(ctx context.Context, filter ports.FilterOptions) (int64, error) {
 	ctx, span := r.tracer.Start(ctx, "ChildRepository.Count")
 	defer span.End()
@@ -560,11 +698,11 @@ func (r *ChildRepository) Count(ctx context.Context, filter ports.FilterOptions)
 	query := `
 		SELECT COUNT(*)
 		FROM children c
-		WHERE c.deleted_at IS NULL
+		WHERE ` + deletedCondition("c.deleted_at", filter) + ` AND c.tenant_id = $1
 	`
 
-	params := []interface{}{}
-	paramIndex := 1
+	params := []interface{}{ports.TenantIDFromContext(ctx)}
+	paramIndex := 2
 	whereConditions := []string{}
 
 	if filter.FirstName != "" {
@@ -591,12 +729,24 @@ func (r *ChildRepository) Count(ctx context.Context, filter ports.FilterOptions)
 		paramIndex++
 	}
 
+	if len(filter.ParentIDs) > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf("c.parent_id = ANY($%d)", paramIndex))
+		params = append(params, filter.ParentIDs)
+		paramIndex++
+	}
+
+	if len(filter.ChildIDs) > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf("c.id = ANY($%d)", paramIndex))
+		params = append(params, filter.ChildIDs)
+		paramIndex++
+	}
+
 	if len(whereConditions) > 0 {
 		query += " AND " + strings.Join(whereConditions, " AND ")
 	}
 
 	var count int64
-	err := r.pool.QueryRow(ctx, query, params...).Scan(&count)
+	err := conn(ctx, r.pool).QueryRow(ctx, query, params...).Scan(&count)
 	if err != nil {
 		r.logger.Error("Failed to count children", zap.Error(err))
 		return 0, fmt.Errorf("failed to count children: %w", err)
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -37,6 +38,7 @@ func NewGenericChildRepository(pool *pgxpool.Pool, logger *zap.Logger) *GenericC
 		"children",
 		repo.scanChild,
 		repo.buildListQuery,
+		repo.sortColumn,
 	)
 
 	repo.BaseRepository = baseRepo
@@ -48,6 +50,7 @@ func (r *GenericChildRepository) scanChild(row pgx.Row) (*domain.Child, error) {
 	var child domain.Child
 	var deletedAt sql.NullTime
 
+	// The columns are in table order, so that the row of a SELECT * can be scanned too
 	err := row.Scan(
 		&child.ID,
 		&child.FirstName,
@@ -57,6 +60,8 @@ func (r *GenericChildRepository) scanChild(row pgx.Row) (*domain.Child, error) {
 		&child.CreatedAt,
 		&child.UpdatedAt,
 		&deletedAt,
+		&child.TenantID,
+		&child.Version,
 	)
 
 	if err != nil {
@@ -70,16 +75,16 @@ func (r *GenericChildRepository) scanChild(row pgx.Row) (*domain.Child, error) {
 	return &child, nil
 }
 
-// buildListQuery builds a query for listing children with filtering and sorting
-func (r *GenericChildRepository) buildListQuery(filter ports.FilterOptions, sort ports.SortOptions) (string, []interface{}) {
+// buildListQuery builds a query for listing the children of the caller's tenant with filtering
+func (r *GenericChildRepository) buildListQuery(ctx context.Context, filter ports.FilterOptions) (string, []interface{}) {
 	query := `
-		SELECT id, first_name, last_name, birth_date, parent_id, created_at, updated_at, deleted_at
+		SELECT id, first_name, last_name, birth_date, parent_id, created_at, updated_at, deleted_at, tenant_id, version
 		FROM children
-		WHERE deleted_at IS NULL
+		WHERE ` + deletedCondition("deleted_at", filter) + ` AND tenant_id = $1
 	`
 
-	params := []interface{}{}
-	paramIndex := 1
+	params := []interface{}{ports.TenantIDFromContext(ctx)}
+	paramIndex := 2
 	whereConditions := []string{}
 
 	if filter.FirstName != "" {
@@ -106,6 +111,18 @@ func (r *GenericChildRepository) buildListQuery(filter ports.FilterOptions, sort
 		paramIndex++
 	}
 
+	if len(filter.ParentIDs) > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf("parent_id = ANY($%d)", paramIndex))
+		params = append(params, filter.ParentIDs)
+		paramIndex++
+	}
+
+	if len(filter.ChildIDs) > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf("id = ANY($%d)", paramIndex))
+		params = append(params, filter.ChildIDs)
+		paramIndex++
+	}
+
 	if len(whereConditions) > 0 {
 		query += " AND " + fmt.Sprintf("(%s)", whereConditions[0])
 		for i := 1; i < len(whereConditions); i++ {
@@ -113,38 +130,35 @@ func (r *GenericChildRepository) buildListQuery(filter ports.FilterOptions, sort
 		}
 	}
 
-	// Add sorting
-	if sort.Field != "" {
-		direction := "ASC"
-		if sort.Direction == "desc" {
-			direction = "DESC"
-		}
+	return query, params
+}
 
-		var sortField string
-		switch sort.Field {
-		case "firstName", "first_name":
-			sortField = "first_name"
-		case "lastName", "last_name":
-			sortField = "last_name"
-		case "birthDate", "birth_date":
-			sortField = "birth_date"
-		case "createdAt", "created_at":
-			sortField = "created_at"
-		case "updatedAt", "updated_at":
-			sortField = "updated_at"
-		default:
-			sortField = "created_at"
-		}
+// sortColumn maps the sort options to a column and reports whether the order is descending
+func (r *GenericChildRepository) sortColumn(sort ports.SortOptions) (string, bool) {
+	if sort.Field == "" {
+		return "created_at", true
+	}
 
-		query += fmt.Sprintf(" ORDER BY %s %s", sortField, direction)
-	} else {
-		query += " ORDER BY created_at DESC"
+	var sortField string
+	switch sort.Field {
+	case "firstName", "first_name":
+		sortField = "first_name"
+	case "lastName", "last_name":
+		sortField = "last_name"
+	case "birthDate", "birth_date":
+		sortField = "birth_date"
+	case "createdAt", "created_at":
+		sortField = "created_at"
+	case "updatedAt", "updated_at":
+		sortField = "updated_at"
+	default:
+		sortField = "created_at"
 	}
 
-	return query, params
+	return sortField, sort.Direction == "desc"
 }
 
-// Create creates a new child in the database
+// Create creates a new child of the caller's tenant in the database
 func (r *GenericChildRepository) Create(ctx context.Context, child *domain.Child) error {
 	ctx, span := r.tracer.Start(ctx, "GenericChildRepository.Create")
 	defer span.End()
@@ -154,15 +168,18 @@ func (r *GenericChildRepository) Create(ctx context.Context, child *domain.Child
 		attribute.String("parent.id", child.ParentID.String()),
 	)
 
-	// First check if the parent exists
+	child.TenantID = ports.TenantIDFromContext(ctx)
+
+	// First check if the parent exists in the same tenant
 	parentQuery := `
-		SELECT 1 FROM parents WHERE id = $1 AND deleted_at IS NULL
+		SELECT 1 FROM parents WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL
 	`
 	var exists bool
-	err := r.pool.QueryRow(ctx, parentQuery, child.ParentID).Scan(&exists)
+	err := conn(ctx, r.pool).QueryRow(ctx, parentQuery, child.ParentID, child.TenantID).Scan(&exists)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			r.logger.Debug("Parent not found for child creation", zap.String("parent_id", child.ParentID.String()))
+			reportCrossTenantAccess(ctx, r.pool, r.logger, "parents", "Parent", child.ParentID)
 			return fmt.Errorf("parent not found for child creation")
 		}
 		r.logger.Error("Failed to check parent existence", zap.Error(err), zap.String("parent_id", child.ParentID.String()))
@@ -170,11 +187,11 @@ func (r *GenericChildRepository) Create(ctx context.Context, child *domain.Child
 	}
 
 	query := `
-		INSERT INTO children (id, first_name, last_name, birth_date, parent_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO children (id, first_name, last_name, birth_date, parent_id, created_at, updated_at, tenant_id, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
-	_, err = r.pool.Exec(ctx, query,
+	_, err = conn(ctx, r.pool).Exec(ctx, query,
 		child.ID,
 		child.FirstName,
 		child.LastName,
@@ -182,6 +199,8 @@ func (r *GenericChildRepository) Create(ctx context.Context, child *domain.Child
 		child.ParentID,
 		child.CreatedAt,
 		child.UpdatedAt,
+		child.TenantID,
+		child.Version,
 	)
 
 	if err != nil {
@@ -192,7 +211,30 @@ func (r *GenericChildRepository) Create(ctx context.Context, child *domain.Child
 	return nil
 }
 
-// Update updates an existing child in the database
+// CreateMany creates many children of the caller's tenant in the database in a single round trip,
+// after checking that their parents exist in the same tenant
+func (r *GenericChildRepository) CreateMany(ctx context.Context, children []*domain.Child) error {
+	ctx, span := r.tracer.Start(ctx, "GenericChildRepository.CreateMany")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("children.count", len(children)))
+
+	if err := insertChildren(ctx, conn(ctx, r.pool), children); err != nil {
+		var batchErr *domain.BatchError
+		if errors.As(err, &batchErr) && errors.Is(batchErr.Err, domain.ErrNotFound) {
+			r.logger.Debug("Parent not found for child creation", zap.String("parent_id", children[batchErr.Index].ParentID.String()))
+			reportCrossTenantAccess(ctx, r.pool, r.logger, "parents", "Parent", children[batchErr.Index].ParentID)
+			return err
+		}
+		r.logger.Error("Failed to create children", zap.Error(err), zap.Int("count", len(children)))
+		return fmt.Errorf("failed to create children: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates an existing child of the caller's tenant in the database, provided that it is still
+// at the version of the given child, and increments the version
 func (r *GenericChildRepository) Update(ctx context.Context, child *domain.Child) error {
 	ctx, span := r.tracer.Start(ctx, "GenericChildRepository.Update")
 	defer span.End()
@@ -201,16 +243,20 @@ func (r *GenericChildRepository) Update(ctx context.Context, child *domain.Child
 
 	query := `
 		UPDATE children
-		SET first_name = $1, last_name = $2, birth_date = $3, updated_at = $4
-		WHERE id = $5 AND deleted_at IS NULL
+		SET first_name = $1, last_name = $2, birth_date = $3, parent_id = $4, updated_at = $5, version = version + 1
+		WHERE id = $6 AND tenant_id = $7 AND version = $8 AND deleted_at IS NULL
 	`
 
-	result, err := r.pool.Exec(ctx, query,
+	q := conn(ctx, r.pool)
+	result, err := q.Exec(ctx, query,
 		child.FirstName,
 		child.LastName,
 		child.BirthDate,
+		child.ParentID,
 		time.Now().UTC(),
 		child.ID,
+		ports.TenantIDFromContext(ctx),
+		child.Version,
 	)
 
 	if err != nil {
@@ -219,14 +265,21 @@ func (r *GenericChildRepository) Update(ctx context.Context, child *domain.Child
 	}
 
 	if result.RowsAffected() == 0 {
+		if isVersionConflict(ctx, q, "children", child.ID) {
+			r.logger.Debug("Child version conflict", zap.String("child_id", child.ID.String()), zap.Int("version", child.Version))
+			return domain.NewConflictError("Child", child.ID.String(), child.Version)
+		}
 		r.logger.Debug("Child not found for update", zap.String("child_id", child.ID.String()))
+		reportCrossTenantAccess(ctx, r.pool, r.logger, "children", "Child", child.ID)
 		return fmt.Errorf("child not found for update")
 	}
 
+	child.Version++
 	return nil
 }
 
-// ListByParentID retrieves children for a specific parent with pagination, filtering, and sorting
+// ListByParentID retrieves the children of a specific parent of the caller's tenant with pagination,
+// filtering, and sorting
 func (r *GenericChildRepository) ListByParentID(ctx context.Context, parentID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
 	ctx, span := r.tracer.Start(ctx, "GenericChildRepository.ListByParentID")
 	defer span.End()
@@ -235,13 +288,13 @@ func (r *GenericChildRepository) ListByParentID(ctx context.Context, parentID uu
 
 	// Modify the base query to filter by parent ID
 	query := `
-		SELECT id, first_name, last_name, birth_date, parent_id, created_at, updated_at, deleted_at
+		SELECT id, first_name, last_name, birth_date, parent_id, created_at, updated_at, deleted_at, tenant_id, version
 		FROM children
-		WHERE deleted_at IS NULL AND parent_id = $1
+		WHERE ` + deletedCondition("deleted_at", options.Filter) + ` AND parent_id = $1 AND tenant_id = $2
 	`
 
-	params := []interface{}{parentID}
-	paramIndex := 2
+	params := []interface{}{parentID, ports.TenantIDFromContext(ctx)}
+	paramIndex := 3
 	whereConditions := []string{}
 
 	if options.Filter.FirstName != "" {
@@ -275,49 +328,11 @@ func (r *GenericChildRepository) ListByParentID(ctx context.Context, parentID uu
 		}
 	}
 
-	// Add sorting
-	if options.Sort.Field != "" {
-		direction := "ASC"
-		if options.Sort.Direction == "desc" {
-			direction = "DESC"
-		}
-
-		var sortField string
-		switch options.Sort.Field {
-		case "firstName", "first_name":
-			sortField = "first_name"
-		case "lastName", "last_name":
-			sortField = "last_name"
-		case "birthDate", "birth_date":
-			sortField = "birth_date"
-		case "createdAt", "created_at":
-			sortField = "created_at"
-		case "updatedAt", "updated_at":
-			sortField = "updated_at"
-		default:
-			sortField = "created_at"
-		}
-
-		query += fmt.Sprintf(" ORDER BY %s %s", sortField, direction)
-	} else {
-		query += " ORDER BY created_at DESC"
-	}
-
-	// Add pagination
-	limit := options.Pagination.PageSize
-	if limit <= 0 {
-		limit = 10 // Default page size
-	}
+	// Add ordering and pagination
+	sortColumn, desc := r.sortColumn(options.Sort)
+	query, params, limit, offset := appendWindow(query, params, sortColumn, "id", desc, options)
 
-	offset := options.Pagination.Page * limit
-	if offset < 0 {
-		offset = 0
-	}
-
-	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", paramIndex, paramIndex+1)
-	params = append(params, limit, offset)
-
-	rows, err := r.pool.Query(ctx, query, params...)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, params...)
 	if err != nil {
 		r.logger.Error("Failed to list children by parent ID", zap.Error(err), zap.String("parent_id", parentID.String()))
 		return nil, nil, fmt.Errorf("failed to list children by parent ID: %w", err)
@@ -345,10 +360,10 @@ func (r *GenericChildRepository) ListByParentID(ctx context.Context, parentID uu
 	countQuery := `
 		SELECT COUNT(*)
 		FROM children
-		WHERE deleted_at IS NULL AND parent_id = $1
+		WHERE ` + deletedCondition("deleted_at", options.Filter) + ` AND parent_id = $1 AND tenant_id = $2
 	`
-	countParams := []interface{}{parentID}
-	countParamIndex := 2
+	countParams := []interface{}{parentID, ports.TenantIDFromContext(ctx)}
+	countParamIndex := 3
 	countWhereConditions := []string{}
 
 	if options.Filter.FirstName != "" {
@@ -383,21 +398,57 @@ func (r *GenericChildRepository) ListByParentID(ctx context.Context, parentID uu
 	}
 
 	var totalCount int64
-	err = r.pool.QueryRow(ctx, countQuery, countParams...).Scan(&totalCount)
+	err = conn(ctx, r.pool).QueryRow(ctx, countQuery, countParams...).Scan(&totalCount)
 	if err != nil {
 		r.logger.Error("Failed to count children by parent ID", zap.Error(err), zap.String("parent_id", parentID.String()))
 		return nil, nil, fmt.Errorf("failed to count children by parent ID: %w", err)
 	}
 
-	pagedResult := &ports.PagedResult{
-		TotalCount: totalCount,
-		Page:       options.Pagination.Page,
-		PageSize:   limit,
-		HasNext:    int64(offset+len(children)) < totalCount,
-	}
+	children, pagedResult := windowResult(children, options, limit, offset, totalCount)
 
 	return children, pagedResult, nil
 }
 
+// ListByParentIDs retrieves all children of the given parents of the caller's tenant in a single query
+func (r *GenericChildRepository) ListByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Child, error) {
+	ctx, span := r.tracer.Start(ctx, "GenericChildRepository.ListByParentIDs")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("parent.count", len(parentIDs)))
+
+	query := `
+		SELECT id, first_name, last_name, birth_date, parent_id, created_at, updated_at, deleted_at, tenant_id, version
+		FROM children
+		WHERE deleted_at IS NULL AND parent_id = ANY($1) AND tenant_id = $2
+		ORDER BY parent_id, created_at, id
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, parentIDs, ports.TenantIDFromContext(ctx))
+	if err != nil {
+		r.logger.Error("Failed to list children by parent IDs", zap.Error(err), zap.Int("count", len(parentIDs)))
+		return nil, fmt.Errorf("failed to list children by parent IDs: %w", err)
+	}
+	defer rows.Close()
+
+	children := []*domain.Child{}
+
+	for rows.Next() {
+		child, err := r.scanChild(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan child row", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan child row: %w", err)
+		}
+
+		children = append(children, child)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating child rows", zap.Error(err))
+		return nil, fmt.Errorf("error iterating child rows: %w", err)
+	}
+
+	return children, nil
+}
+
 // Ensure GenericChildRepository implements ports.Repository
 var _ ports.Repository[*domain.Child] = (*GenericChildRepository)(nil)
@@ -3,11 +3,14 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.opentelemetry.io/otel/attribute"
@@ -36,6 +39,7 @@ func NewGenericParentRepository(pool *pgxpool.Pool, logger *zap.Logger) *Generic
 		"parents",
 		repo.scanParent,
 		repo.buildListQuery,
+		repo.sortColumn,
 	)
 
 	repo.BaseRepository = baseRepo
@@ -46,7 +50,9 @@ func NewGenericParentRepository(pool *pgxpool.Pool, logger *zap.Logger) *Generic
 func (r *GenericParentRepository) scanParent(row pgx.Row) (*domain.Parent, error) {
 	var parent domain.Parent
 	var deletedAt sql.NullTime
+	var userID sql.NullString
 
+	// The columns are in table order, so that the row of a SELECT * can be scanned too
 	err := row.Scan(
 		&parent.ID,
 		&parent.FirstName,
@@ -56,6 +62,11 @@ func (r *GenericParentRepository) scanParent(row pgx.Row) (*domain.Parent, error
 		&parent.CreatedAt,
 		&parent.UpdatedAt,
 		&deletedAt,
+		&userID,
+		&parent.TenantID,
+		&parent.Version,
+		&parent.ContactDetails,
+		&parent.MergedInto,
 	)
 
 	if err != nil {
@@ -65,20 +76,21 @@ func (r *GenericParentRepository) scanParent(row pgx.Row) (*domain.Parent, error
 	if deletedAt.Valid {
 		parent.DeletedAt = &deletedAt.Time
 	}
+	parent.UserID = userID.String
 
 	return &parent, nil
 }
 
-// buildListQuery builds a query for listing parents with filtering and sorting
-func (r *GenericParentRepository) buildListQuery(filter ports.FilterOptions, sort ports.SortOptions) (string, []interface{}) {
+// buildListQuery builds a query for listing the parents of the caller's tenant with filtering
+func (r *GenericParentRepository) buildListQuery(ctx context.Context, filter ports.FilterOptions) (string, []interface{}) {
 	query := `
-		SELECT id, first_name, last_name, email, birth_date, created_at, updated_at, deleted_at
+		SELECT id, first_name, last_name, email, birth_date, created_at, updated_at, deleted_at, user_id, tenant_id, version, contact_details, merged_into
 		FROM parents
-		WHERE deleted_at IS NULL
+		WHERE ` + deletedCondition("deleted_at", filter) + ` AND tenant_id = $1
 	`
 
-	params := []interface{}{}
-	paramIndex := 1
+	params := []interface{}{ports.TenantIDFromContext(ctx)}
+	paramIndex := 2
 	whereConditions := []string{}
 
 	if filter.FirstName != "" {
@@ -111,6 +123,24 @@ func (r *GenericParentRepository) buildListQuery(filter ports.FilterOptions, sor
 		paramIndex++
 	}
 
+	if filter.PostalCode != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("contact_details->'address'->>'postalCode' = $%d", paramIndex))
+		params = append(params, filter.PostalCode)
+		paramIndex++
+	}
+
+	if filter.Phone != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("contact_details->'phones' @> $%d::jsonb", paramIndex))
+		params = append(params, []domain.Phone{{Number: filter.Phone}})
+		paramIndex++
+	}
+
+	if len(filter.ParentIDs) > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf("id = ANY($%d)", paramIndex))
+		params = append(params, filter.ParentIDs)
+		paramIndex++
+	}
+
 	if len(whereConditions) > 0 {
 		query += " AND " + fmt.Sprintf("(%s)", whereConditions[0])
 		for i := 1; i < len(whereConditions); i++ {
@@ -118,52 +148,51 @@ func (r *GenericParentRepository) buildListQuery(filter ports.FilterOptions, sor
 		}
 	}
 
-	// Add sorting
-	if sort.Field != "" {
-		direction := "ASC"
-		if sort.Direction == "desc" {
-			direction = "DESC"
-		}
+	return query, params
+}
 
-		var sortField string
-		switch sort.Field {
-		case "firstName", "first_name":
-			sortField = "first_name"
-		case "lastName", "last_name":
-			sortField = "last_name"
-		case "email":
-			sortField = "email"
-		case "birthDate", "birth_date":
-			sortField = "birth_date"
-		case "createdAt", "created_at":
-			sortField = "created_at"
-		case "updatedAt", "updated_at":
-			sortField = "updated_at"
-		default:
-			sortField = "created_at"
-		}
+// sortColumn maps the sort options to a column and reports whether the order is descending
+func (r *GenericParentRepository) sortColumn(sort ports.SortOptions) (string, bool) {
+	if sort.Field == "" {
+		return "created_at", true
+	}
 
-		query += fmt.Sprintf(" ORDER BY %s %s", sortField, direction)
-	} else {
-		query += " ORDER BY created_at DESC"
+	var sortField string
+	switch sort.Field {
+	case "firstName", "first_name":
+		sortField = "first_name"
+	case "lastName", "last_name":
+		sortField = "last_name"
+	case "email":
+		sortField = "email"
+	case "birthDate", "birth_date":
+		sortField = "birth_date"
+	case "createdAt", "created_at":
+		sortField = "created_at"
+	case "updatedAt", "updated_at":
+		sortField = "updated_at"
+	default:
+		sortField = "created_at"
 	}
 
-	return query, params
+	return sortField, sort.Direction == "desc"
 }
 
-// Create creates a new parent in the database
+// Create creates a new parent of the caller's tenant in the database
 func (r *GenericParentRepository) Create(ctx context.Context, parent *domain.Parent) error {
 	ctx, span := r.tracer.Start(ctx, "GenericParentRepository.Create")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("parent.id", parent.ID.String()))
 
+	parent.TenantID = ports.TenantIDFromContext(ctx)
+
 	query := `
-		INSERT INTO parents (id, first_name, last_name, email, birth_date, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO parents (id, first_name, last_name, email, birth_date, created_at, updated_at, user_id, tenant_id, version, contact_details)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
 		parent.ID,
 		parent.FirstName,
 		parent.LastName,
@@ -171,9 +200,17 @@ func (r *GenericParentRepository) Create(ctx context.Context, parent *domain.Par
 		parent.BirthDate,
 		parent.CreatedAt,
 		parent.UpdatedAt,
+		nullString(parent.UserID),
+		parent.TenantID,
+		parent.Version,
+		parent.ContactDetails,
 	)
 
 	if err != nil {
+		if isUniqueViolation(err, parentEmailIndex) {
+			r.logger.Debug("Parent email already exists", zap.String("parent_id", parent.ID.String()))
+			return domain.NewDuplicateError("Parent", "email", parent.Email)
+		}
 		r.logger.Error("Failed to create parent", zap.Error(err), zap.String("parent_id", parent.ID.String()))
 		return fmt.Errorf("failed to create parent: %w", err)
 	}
@@ -181,7 +218,94 @@ func (r *GenericParentRepository) Create(ctx context.Context, parent *domain.Par
 	return nil
 }
 
-// Update updates an existing parent in the database
+// CreateMany creates many parents of the caller's tenant in the database in a single round trip
+func (r *GenericParentRepository) CreateMany(ctx context.Context, parents []*domain.Parent) error {
+	ctx, span := r.tracer.Start(ctx, "GenericParentRepository.CreateMany")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("parents.count", len(parents)))
+
+	if err := insertParents(ctx, conn(ctx, r.pool), parents); err != nil {
+		var batchErr *domain.BatchError
+		if errors.As(err, &batchErr) && errors.Is(batchErr.Err, domain.ErrDuplicate) {
+			r.logger.Debug("Parent email already exists", zap.String("parent_id", parents[batchErr.Index].ID.String()))
+			return err
+		}
+		r.logger.Error("Failed to create parents", zap.Error(err), zap.Int("count", len(parents)))
+		return fmt.Errorf("failed to create parents: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUserID retrieves the parent of the caller's tenant linked to the given user account from the database
+func (r *GenericParentRepository) GetByUserID(ctx context.Context, userID string) (*domain.Parent, error) {
+	ctx, span := r.tracer.Start(ctx, "GenericParentRepository.GetByUserID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID))
+
+	query := `
+		SELECT id, first_name, last_name, email, birth_date, created_at, updated_at, deleted_at, user_id, tenant_id, version, contact_details, merged_into
+		FROM parents
+		WHERE user_id = $1 AND tenant_id = $2 AND deleted_at IS NULL
+	`
+
+	parent, err := r.scanParent(conn(ctx, r.pool).QueryRow(ctx, query, userID, ports.TenantIDFromContext(ctx)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			r.logger.Debug("No parent linked to user", zap.String("user_id", userID))
+			return nil, fmt.Errorf("parent not found for user: %w", domain.ErrNotFound)
+		}
+		r.logger.Error("Failed to get parent by user ID", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get parent by user ID: %w", err)
+	}
+
+	return parent, nil
+}
+
+// FindTakenEmails returns those of the given emails that non-deleted parents of the caller's tenant already have,
+// ignoring case
+func (r *GenericParentRepository) FindTakenEmails(ctx context.Context, emails []string) ([]string, error) {
+	ctx, span := r.tracer.Start(ctx, "GenericParentRepository.FindTakenEmails")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("emails.count", len(emails)))
+
+	// The unique index of the emails is on their lower-cased values
+	lowered := make([]string, 0, len(emails))
+	for _, email := range emails {
+		lowered = append(lowered, strings.ToLower(email))
+	}
+
+	rows, err := conn(ctx, r.pool).Query(ctx, `
+		SELECT email FROM parents WHERE tenant_id = $1 AND lower(email) = ANY($2) AND deleted_at IS NULL
+	`, ports.TenantIDFromContext(ctx), lowered)
+	if err != nil {
+		r.logger.Error("Failed to find taken emails", zap.Error(err), zap.Int("count", len(emails)))
+		return nil, fmt.Errorf("failed to find taken emails: %w", err)
+	}
+	defer rows.Close()
+
+	taken := []string{}
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			r.logger.Error("Failed to scan email", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan email: %w", err)
+		}
+		taken = append(taken, email)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating over emails", zap.Error(err))
+		return nil, fmt.Errorf("error iterating over emails: %w", err)
+	}
+
+	return taken, nil
+}
+
+// Update updates an existing parent of the caller's tenant in the database, provided that it is still
+// at the version of the given parent, and increments the version
 func (r *GenericParentRepository) Update(ctx context.Context, parent *domain.Parent) error {
 	ctx, span := r.tracer.Start(ctx, "GenericParentRepository.Update")
 	defer span.End()
@@ -190,31 +314,75 @@ func (r *GenericParentRepository) Update(ctx context.Context, parent *domain.Par
 
 	query := `
 		UPDATE parents
-		SET first_name = $1, last_name = $2, email = $3, birth_date = $4, updated_at = $5
-		WHERE id = $6 AND deleted_at IS NULL
+		SET first_name = $1, last_name = $2, email = $3, birth_date = $4, updated_at = $5, user_id = $6,
+			contact_details = $7, merged_into = $8, version = version + 1
+		WHERE id = $9 AND tenant_id = $10 AND version = $11 AND deleted_at IS NULL
 	`
 
-	result, err := r.pool.Exec(ctx, query,
+	q := conn(ctx, r.pool)
+	result, err := q.Exec(ctx, query,
 		parent.FirstName,
 		parent.LastName,
 		parent.Email,
 		parent.BirthDate,
 		time.Now().UTC(),
+		nullString(parent.UserID),
+		parent.ContactDetails,
+		parent.MergedInto,
 		parent.ID,
+		ports.TenantIDFromContext(ctx),
+		parent.Version,
 	)
 
 	if err != nil {
+		if isUniqueViolation(err, parentEmailIndex) {
+			r.logger.Debug("Parent email already exists", zap.String("parent_id", parent.ID.String()))
+			return domain.NewDuplicateError("Parent", "email", parent.Email)
+		}
 		r.logger.Error("Failed to update parent", zap.Error(err), zap.String("parent_id", parent.ID.String()))
 		return fmt.Errorf("failed to update parent: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
+		if isVersionConflict(ctx, q, "parents", parent.ID) {
+			r.logger.Debug("Parent version conflict", zap.String("parent_id", parent.ID.String()), zap.Int("version", parent.Version))
+			return domain.NewConflictError("Parent", parent.ID.String(), parent.Version)
+		}
 		r.logger.Debug("Parent not found for update", zap.String("parent_id", parent.ID.String()))
+		reportCrossTenantAccess(ctx, r.pool, r.logger, "parents", "Parent", parent.ID)
 		return fmt.Errorf("parent not found for update")
 	}
 
+	parent.Version++
+	return nil
+}
+
+// Restore unmarks a parent of the caller's tenant that was marked as deleted in the database,
+// together with the children that were marked as deleted with it
+func (r *GenericParentRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "GenericParentRepository.Restore")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("parent.id", id.String()))
+
+	if err := restoreChildrenDeletedWithParent(ctx, conn(ctx, r.pool), id); err != nil {
+		r.logger.Error("Failed to restore children", zap.Error(err), zap.String("parent_id", id.String()))
+		return fmt.Errorf("failed to restore children: %w", err)
+	}
+
+	// Another parent may have taken the email since the parent was deleted
+	if err := r.BaseRepository.Restore(ctx, id); err != nil {
+		if isUniqueViolation(err, parentEmailIndex) {
+			return domain.NewDuplicateError("Parent", "email", "")
+		}
+		return err
+	}
+
 	return nil
 }
 
-// Ensure GenericParentRepository implements ports.Repository
-var _ ports.Repository[*domain.Parent] = (*GenericParentRepository)(nil)
+// Ensure GenericParentRepository implements ports.Repository and ports.ParentRepository
+var (
+	_ ports.Repository[*domain.Parent] = (*GenericParentRepository)(nil)
+	_ ports.ParentRepository           = (*GenericParentRepository)(nil)
+)
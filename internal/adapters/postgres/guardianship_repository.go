@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// guardianshipColumns are the columns read by scanGuardianship, in order
+const guardianshipColumns = `id, parent_id, child_id, tenant_id, type, start_date, end_date, primary_contact, created_at, updated_at`
+
+// GuardianshipRepository implements the ports.GuardianshipRepository interface for PostgreSQL
+type GuardianshipRepository struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+	tracer trace.Tracer
+}
+
+// NewGuardianshipRepository creates a new PostgreSQL guardianship repository
+func NewGuardianshipRepository(pool *pgxpool.Pool, logger *zap.Logger) *GuardianshipRepository {
+	return &GuardianshipRepository{
+		pool:   pool,
+		logger: logger,
+		tracer: otel.Tracer("postgres.guardianship_repository"),
+	}
+}
+
+// Create stores a new guardianship of the caller's tenant
+func (r *GuardianshipRepository) Create(ctx context.Context, guardianship *domain.Guardianship) error {
+	ctx, span := r.tracer.Start(ctx, "GuardianshipRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("guardianship.id", guardianship.ID.String()))
+
+	guardianship.TenantID = ports.TenantIDFromContext(ctx)
+
+	query := `
+		INSERT INTO guardianships (id, parent_id, child_id, tenant_id, type, start_date, end_date, primary_contact, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		guardianship.ID,
+		guardianship.ParentID,
+		guardianship.ChildID,
+		guardianship.TenantID,
+		string(guardianship.Type),
+		guardianship.StartDate.UTC(),
+		guardianship.EndDate,
+		guardianship.PrimaryContact,
+		guardianship.CreatedAt,
+		guardianship.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.Error("Failed to create guardianship", zap.Error(err), zap.String("guardianship_id", guardianship.ID.String()))
+		return fmt.Errorf("failed to create guardianship: %w", err)
+	}
+
+	return nil
+}
+
+// Update stores the type, dates and primary contact mark of a guardianship of the caller's tenant
+func (r *GuardianshipRepository) Update(ctx context.Context, guardianship *domain.Guardianship) error {
+	ctx, span := r.tracer.Start(ctx, "GuardianshipRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("guardianship.id", guardianship.ID.String()))
+
+	query := `
+		UPDATE guardianships
+		SET type = $1, start_date = $2, end_date = $3, primary_contact = $4, updated_at = $5
+		WHERE id = $6 AND tenant_id = $7
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
+		string(guardianship.Type),
+		guardianship.StartDate.UTC(),
+		guardianship.EndDate,
+		guardianship.PrimaryContact,
+		guardianship.UpdatedAt,
+		guardianship.ID,
+		ports.TenantIDFromContext(ctx),
+	)
+	if err != nil {
+		r.logger.Error("Failed to update guardianship", zap.Error(err), zap.String("guardianship_id", guardianship.ID.String()))
+		return fmt.Errorf("failed to update guardianship: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("guardianship not found: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// ListByChildIDs retrieves the guardianships of the given children of the caller's tenant
+// in a single query, ordered by child and start date
+func (r *GuardianshipRepository) ListByChildIDs(ctx context.Context, childIDs []uuid.UUID) ([]*domain.Guardianship, error) {
+	ctx, span := r.tracer.Start(ctx, "GuardianshipRepository.ListByChildIDs")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("child.count", len(childIDs)))
+
+	query := `
+		SELECT ` + guardianshipColumns + `
+		FROM guardianships
+		WHERE child_id = ANY($1) AND tenant_id = $2
+		ORDER BY child_id, start_date, created_at, id
+	`
+
+	return r.queryGuardianships(ctx, query, childIDs, ports.TenantIDFromContext(ctx))
+}
+
+// ListByParentIDs retrieves the guardianships of the given parents of the caller's tenant
+// in a single query, ordered by parent and start date
+func (r *GuardianshipRepository) ListByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Guardianship, error) {
+	ctx, span := r.tracer.Start(ctx, "GuardianshipRepository.ListByParentIDs")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("parent.count", len(parentIDs)))
+
+	query := `
+		SELECT ` + guardianshipColumns + `
+		FROM guardianships
+		WHERE parent_id = ANY($1) AND tenant_id = $2
+		ORDER BY parent_id, start_date, created_at, id
+	`
+
+	return r.queryGuardianships(ctx, query, parentIDs, ports.TenantIDFromContext(ctx))
+}
+
+// queryGuardianships runs a query selecting guardianshipColumns and scans the guardianships
+func (r *GuardianshipRepository) queryGuardianships(ctx context.Context, query string, args ...any) ([]*domain.Guardianship, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Failed to list guardianships", zap.Error(err))
+		return nil, fmt.Errorf("failed to list guardianships: %w", err)
+	}
+	defer rows.Close()
+
+	guardianships := []*domain.Guardianship{}
+	for rows.Next() {
+		guardianship, err := scanGuardianship(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan guardianship", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan guardianship: %w", err)
+		}
+		guardianships = append(guardianships, guardianship)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating guardianships", zap.Error(err))
+		return nil, fmt.Errorf("error iterating guardianships: %w", err)
+	}
+
+	return guardianships, nil
+}
+
+// scanGuardianship scans a row of guardianshipColumns
+func scanGuardianship(row pgx.Row) (*domain.Guardianship, error) {
+	var guardianship domain.Guardianship
+	var guardianshipType string
+
+	err := row.Scan(
+		&guardianship.ID,
+		&guardianship.ParentID,
+		&guardianship.ChildID,
+		&guardianship.TenantID,
+		&guardianshipType,
+		&guardianship.StartDate,
+		&guardianship.EndDate,
+		&guardianship.PrimaryContact,
+		&guardianship.CreatedAt,
+		&guardianship.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	guardianship.Type = domain.GuardianshipType(guardianshipType)
+
+	return &guardianship, nil
+}
+
+// Ensure GuardianshipRepository implements ports.GuardianshipRepository
+var _ ports.GuardianshipRepository = (*GuardianshipRepository)(nil)
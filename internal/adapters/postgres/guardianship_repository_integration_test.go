@@ -0,0 +1,93 @@
+package postgres_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/postgres"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGuardianshipRepositoryIntegration tests the PostgreSQL guardianship repository with a real PostgreSQL database
+func TestGuardianshipRepositoryIntegration(t *testing.T) {
+	// Skip if short flag is set
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	// Set up test repositories using the helper
+	factory, ctx, cleanup := postgres.SetupTestRepositories(t)
+	defer cleanup()
+
+	parentRepo := factory.NewParentRepository()
+	childRepo := factory.NewChildRepository()
+	guardians := factory.NewGuardianshipRepository()
+	tenantCtx := ports.WithTenantID(ctx, "tenant-a")
+
+	// A child with a father, who is its primary contact, and a mother
+	father := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	mother := domain.NewParent("Mary", "Doe", "mary.doe@example.com", time.Now().AddDate(-29, 0, 0))
+	require.NoError(t, parentRepo.Create(tenantCtx, father))
+	require.NoError(t, parentRepo.Create(tenantCtx, mother))
+	child := domain.NewChild("Jimmy", "Doe", time.Now().AddDate(-5, 0, 0), father.ID)
+	require.NoError(t, childRepo.Create(tenantCtx, child))
+
+	fatherOf := domain.NewGuardianship(father.ID, child.ID, domain.GuardianshipFather, child.CreatedAt, true)
+	motherOf := domain.NewGuardianship(mother.ID, child.ID, domain.GuardianshipMother, child.CreatedAt.Add(time.Hour), false)
+	require.NoError(t, guardians.Create(tenantCtx, fatherOf))
+	require.NoError(t, guardians.Create(tenantCtx, motherOf))
+
+	// Test that the guardianships are listed by child and by parent, oldest first
+	t.Run("List", func(t *testing.T) {
+		guardianships, err := guardians.ListByChildIDs(tenantCtx, []uuid.UUID{child.ID})
+		require.NoError(t, err)
+		require.Len(t, guardianships, 2)
+		assert.Equal(t, fatherOf.ID, guardianships[0].ID)
+		assert.Equal(t, domain.GuardianshipFather, guardianships[0].Type)
+		assert.True(t, guardianships[0].PrimaryContact)
+		assert.Equal(t, motherOf.ID, guardianships[1].ID)
+
+		wards, err := guardians.ListByParentIDs(tenantCtx, []uuid.UUID{mother.ID})
+		require.NoError(t, err)
+		require.Len(t, wards, 1)
+		assert.Equal(t, child.ID, wards[0].ChildID)
+	})
+
+	// Test that a second primary contact of the same child is rejected
+	t.Run("SinglePrimaryContact", func(t *testing.T) {
+		other := domain.NewParent("Jim", "Doe", "jim.doe@example.com", time.Now().AddDate(-40, 0, 0))
+		require.NoError(t, parentRepo.Create(tenantCtx, other))
+
+		err := guardians.Create(tenantCtx, domain.NewGuardianship(other.ID, child.ID, domain.GuardianshipGuardian, time.Now(), true))
+		assert.Error(t, err)
+	})
+
+	// Test that an ended guardianship is kept with its end date
+	t.Run("End", func(t *testing.T) {
+		motherOf.End()
+		require.NoError(t, guardians.Update(tenantCtx, motherOf))
+
+		guardianships, err := guardians.ListByParentIDs(tenantCtx, []uuid.UUID{mother.ID})
+		require.NoError(t, err)
+		require.Len(t, guardianships, 1)
+		assert.NotNil(t, guardianships[0].EndDate)
+		assert.False(t, guardianships[0].IsActive())
+	})
+
+	// Test that guardianships are only visible to their tenant
+	t.Run("OtherTenant", func(t *testing.T) {
+		otherCtx := ports.WithTenantID(ctx, "tenant-b")
+
+		guardianships, err := guardians.ListByChildIDs(otherCtx, []uuid.UUID{child.ID})
+		require.NoError(t, err)
+		assert.Empty(t, guardianships)
+
+		err = guardians.Update(otherCtx, fatherOf)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+	})
+}
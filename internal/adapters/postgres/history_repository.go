@@ -0,0 +1,267 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// HistoryRepository implements the ports.HistoryRepository interface for PostgreSQL.
+// The parent_history and child_history tables are filled by triggers on the parents and children
+// tables, so every write is recorded, including those of the generic repositories.
+type HistoryRepository struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+	tracer trace.Tracer
+}
+
+// NewHistoryRepository creates a new PostgreSQL history repository
+func NewHistoryRepository(pool *pgxpool.Pool, logger *zap.Logger) *HistoryRepository {
+	return &HistoryRepository{
+		pool:   pool,
+		logger: logger,
+		tracer: otel.Tracer("postgres.history_repository"),
+	}
+}
+
+const (
+	parentHistoryColumns = `id, first_name, last_name, email, birth_date, user_id, contact_details, merged_into, tenant_id, version, created_at, updated_at, deleted_at, recorded_at`
+	childHistoryColumns  = `id, first_name, last_name, birth_date, parent_id, tenant_id, version, created_at, updated_at, deleted_at, recorded_at`
+)
+
+// GetParentAsOf retrieves the latest revision of a parent of the caller's tenant recorded at or before asOf
+func (r *HistoryRepository) GetParentAsOf(ctx context.Context, id uuid.UUID, asOf time.Time) (*domain.Parent, error) {
+	ctx, span := r.tracer.Start(ctx, "HistoryRepository.GetParentAsOf")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("parent.id", id.String()))
+
+	query := `
+		SELECT ` + parentHistoryColumns + `
+		FROM parent_history
+		WHERE id = $1 AND tenant_id = $2 AND recorded_at <= $3
+		ORDER BY recorded_at DESC, history_id DESC
+		LIMIT 1
+	`
+
+	revision, err := scanParentRevision(conn(ctx, r.pool).QueryRow(ctx, query, id, ports.TenantIDFromContext(ctx), asOf.UTC()))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("parent not found as of %s: %w", asOf.Format(time.RFC3339), domain.ErrNotFound)
+		}
+		r.logger.Error("Failed to get parent as of time", zap.Error(err), zap.String("parent_id", id.String()))
+		return nil, fmt.Errorf("failed to get parent as of time: %w", err)
+	}
+
+	if revision.Parent.IsDeleted() {
+		return nil, fmt.Errorf("parent deleted as of %s: %w", asOf.Format(time.RFC3339), domain.ErrNotFound)
+	}
+
+	return revision.Parent, nil
+}
+
+// ListChildrenAsOf retrieves the children of the caller's tenant whose latest revision recorded at or before asOf
+// belongs to the parent and is not deleted, ordered by creation time
+func (r *HistoryRepository) ListChildrenAsOf(ctx context.Context, parentID uuid.UUID, asOf time.Time) ([]*domain.Child, error) {
+	ctx, span := r.tracer.Start(ctx, "HistoryRepository.ListChildrenAsOf")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("parent.id", parentID.String()))
+
+	// Only the children that ever belonged to the parent are candidates, but a candidate
+	// counts only if it still belonged to the parent in its latest revision as of the time
+	query := `
+		SELECT ` + childHistoryColumns + `
+		FROM (
+			SELECT DISTINCT ON (id) ` + childHistoryColumns + `
+			FROM child_history
+			WHERE tenant_id = $2 AND recorded_at <= $3
+				AND id IN (SELECT id FROM child_history WHERE parent_id = $1 AND tenant_id = $2)
+			ORDER BY id, recorded_at DESC, history_id DESC
+		) latest
+		WHERE parent_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at, id
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, parentID, ports.TenantIDFromContext(ctx), asOf.UTC())
+	if err != nil {
+		r.logger.Error("Failed to list children as of time", zap.Error(err), zap.String("parent_id", parentID.String()))
+		return nil, fmt.Errorf("failed to list children as of time: %w", err)
+	}
+	defer rows.Close()
+
+	children := []*domain.Child{}
+	for rows.Next() {
+		revision, err := scanChildRevision(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan child revision", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan child revision: %w", err)
+		}
+		children = append(children, revision.Child)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating child revisions", zap.Error(err))
+		return nil, fmt.Errorf("error iterating child revisions: %w", err)
+	}
+
+	return children, nil
+}
+
+// ListParentRevisions retrieves the revisions of a parent of the caller's tenant, oldest first
+func (r *HistoryRepository) ListParentRevisions(ctx context.Context, id uuid.UUID) ([]*domain.Revision, error) {
+	ctx, span := r.tracer.Start(ctx, "HistoryRepository.ListParentRevisions")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("parent.id", id.String()))
+
+	query := `
+		SELECT ` + parentHistoryColumns + `
+		FROM parent_history
+		WHERE id = $1 AND tenant_id = $2
+		ORDER BY recorded_at, history_id
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, id, ports.TenantIDFromContext(ctx))
+	if err != nil {
+		r.logger.Error("Failed to list parent revisions", zap.Error(err), zap.String("parent_id", id.String()))
+		return nil, fmt.Errorf("failed to list parent revisions: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := []*domain.Revision{}
+	for rows.Next() {
+		revision, err := scanParentRevision(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan parent revision", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan parent revision: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating parent revisions", zap.Error(err))
+		return nil, fmt.Errorf("error iterating parent revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// ListChildRevisions retrieves the revisions of a child of the caller's tenant, oldest first
+func (r *HistoryRepository) ListChildRevisions(ctx context.Context, id uuid.UUID) ([]*domain.Revision, error) {
+	ctx, span := r.tracer.Start(ctx, "HistoryRepository.ListChildRevisions")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("child.id", id.String()))
+
+	query := `
+		SELECT ` + childHistoryColumns + `
+		FROM child_history
+		WHERE id = $1 AND tenant_id = $2
+		ORDER BY recorded_at, history_id
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, id, ports.TenantIDFromContext(ctx))
+	if err != nil {
+		r.logger.Error("Failed to list child revisions", zap.Error(err), zap.String("child_id", id.String()))
+		return nil, fmt.Errorf("failed to list child revisions: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := []*domain.Revision{}
+	for rows.Next() {
+		revision, err := scanChildRevision(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan child revision", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan child revision: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating child revisions", zap.Error(err))
+		return nil, fmt.Errorf("error iterating child revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// scanParentRevision scans a row of parentHistoryColumns into a parent revision
+func scanParentRevision(row pgx.Row) (*domain.Revision, error) {
+	var parent domain.Parent
+	var userID sql.NullString
+	var deletedAt sql.NullTime
+	var recordedAt time.Time
+
+	err := row.Scan(
+		&parent.ID,
+		&parent.FirstName,
+		&parent.LastName,
+		&parent.Email,
+		&parent.BirthDate,
+		&userID,
+		&parent.ContactDetails,
+		&parent.MergedInto,
+		&parent.TenantID,
+		&parent.Version,
+		&parent.CreatedAt,
+		&parent.UpdatedAt,
+		&deletedAt,
+		&recordedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	parent.UserID = userID.String
+	if deletedAt.Valid {
+		parent.DeletedAt = &deletedAt.Time
+	}
+
+	return domain.NewParentRevision(&parent, recordedAt.UTC()), nil
+}
+
+// scanChildRevision scans a row of childHistoryColumns into a child revision
+func scanChildRevision(row pgx.Row) (*domain.Revision, error) {
+	var child domain.Child
+	var deletedAt sql.NullTime
+	var recordedAt time.Time
+
+	err := row.Scan(
+		&child.ID,
+		&child.FirstName,
+		&child.LastName,
+		&child.BirthDate,
+		&child.ParentID,
+		&child.TenantID,
+		&child.Version,
+		&child.CreatedAt,
+		&child.UpdatedAt,
+		&deletedAt,
+		&recordedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if deletedAt.Valid {
+		child.DeletedAt = &deletedAt.Time
+	}
+
+	return domain.NewChildRevision(&child, recordedAt.UTC()), nil
+}
+
+// Ensure HistoryRepository implements ports.HistoryRepository
+var _ ports.HistoryRepository = (*HistoryRepository)(nil)
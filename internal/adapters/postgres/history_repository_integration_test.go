@@ -0,0 +1,88 @@
+package postgres_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/postgres"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHistoryRepositoryIntegration tests the PostgreSQL history repository with a real PostgreSQL database
+func TestHistoryRepositoryIntegration(t *testing.T) {
+	// Skip if short flag is set
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	// Set up test repositories using the helper
+	factory, ctx, cleanup := postgres.SetupTestRepositories(t)
+	defer cleanup()
+
+	parentRepo := factory.NewParentRepository()
+	childRepo := factory.NewChildRepository()
+	history := factory.NewHistoryRepository()
+	tenantCtx := ports.WithTenantID(ctx, "tenant-a")
+
+	// Write a parent and a child, then rename the parent and delete the child
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	require.NoError(t, parentRepo.Create(tenantCtx, parent))
+	child := domain.NewChild("Jimmy", "Doe", time.Now().AddDate(-5, 0, 0), parent.ID)
+	require.NoError(t, childRepo.Create(tenantCtx, child))
+	created := time.Now().UTC()
+	time.Sleep(10 * time.Millisecond)
+
+	parent.FirstName = "Johnny"
+	require.NoError(t, parentRepo.Update(tenantCtx, parent))
+	require.NoError(t, childRepo.Delete(tenantCtx, child.ID))
+	changed := time.Now().UTC()
+
+	// Test that the parent and its children are as they were at each time
+	t.Run("AsOf", func(t *testing.T) {
+		original, err := history.GetParentAsOf(tenantCtx, parent.ID, created)
+		require.NoError(t, err)
+		assert.Equal(t, "John", original.FirstName)
+
+		children, err := history.ListChildrenAsOf(tenantCtx, parent.ID, created)
+		require.NoError(t, err)
+		require.Len(t, children, 1)
+		assert.Equal(t, child.ID, children[0].ID)
+
+		renamed, err := history.GetParentAsOf(tenantCtx, parent.ID, changed)
+		require.NoError(t, err)
+		assert.Equal(t, "Johnny", renamed.FirstName)
+
+		children, err = history.ListChildrenAsOf(tenantCtx, parent.ID, changed)
+		require.NoError(t, err)
+		assert.Empty(t, children)
+
+		_, err = history.GetParentAsOf(tenantCtx, parent.ID, created.Add(-time.Hour))
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+	})
+
+	// Test that every write is listed, oldest first
+	t.Run("Revisions", func(t *testing.T) {
+		revisions, err := history.ListParentRevisions(tenantCtx, parent.ID)
+		require.NoError(t, err)
+		require.Len(t, revisions, 2)
+		assert.Equal(t, "John", revisions[0].Parent.FirstName)
+		assert.Equal(t, "Johnny", revisions[1].Parent.FirstName)
+
+		revisions, err = history.ListChildRevisions(tenantCtx, child.ID)
+		require.NoError(t, err)
+		require.Len(t, revisions, 2)
+		assert.False(t, revisions[0].Child.IsDeleted())
+		assert.True(t, revisions[1].Child.IsDeleted())
+	})
+
+	// Test that revisions are only visible to their tenant
+	t.Run("RevisionsOfOtherTenant", func(t *testing.T) {
+		revisions, err := history.ListParentRevisions(ports.WithTenantID(ctx, "tenant-b"), parent.ID)
+		require.NoError(t, err)
+		assert.Empty(t, revisions)
+	})
+}
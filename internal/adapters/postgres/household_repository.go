@@ -0,0 +1,249 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// HouseholdRepository implements the ports.HouseholdRepository interface for PostgreSQL.
+// The members of a household are stored in household_parents and household_children, in the
+// order they were added; the key of household_children keeps a child in a single household.
+type HouseholdRepository struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+	tracer trace.Tracer
+}
+
+// NewHouseholdRepository creates a new PostgreSQL household repository
+func NewHouseholdRepository(pool *pgxpool.Pool, logger *zap.Logger) *HouseholdRepository {
+	return &HouseholdRepository{
+		pool:   pool,
+		logger: logger,
+		tracer: otel.Tracer("postgres.household_repository"),
+	}
+}
+
+// Create stores a new household of the caller's tenant, with its members
+func (r *HouseholdRepository) Create(ctx context.Context, household *domain.Household) error {
+	ctx, span := r.tracer.Start(ctx, "HouseholdRepository.Create")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("household.id", household.ID.String()))
+
+	household.TenantID = ports.TenantIDFromContext(ctx)
+
+	query := `
+		INSERT INTO households (id, tenant_id, name, street, city, state, postal_code, country, phone_numbers, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		household.ID,
+		household.TenantID,
+		household.Name,
+		household.Address.Street,
+		household.Address.City,
+		household.Address.State,
+		household.Address.PostalCode,
+		household.Address.Country,
+		household.PhoneNumbers,
+		household.CreatedAt,
+		household.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.Error("Failed to create household", zap.Error(err), zap.String("household_id", household.ID.String()))
+		return fmt.Errorf("failed to create household: %w", err)
+	}
+
+	if err := r.insertMembers(ctx, household); err != nil {
+		r.logger.Error("Failed to create household members", zap.Error(err), zap.String("household_id", household.ID.String()))
+		return fmt.Errorf("failed to create household members: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a household of the caller's tenant by ID, with its members
+func (r *HouseholdRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Household, error) {
+	ctx, span := r.tracer.Start(ctx, "HouseholdRepository.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("household.id", id.String()))
+
+	query := `
+		SELECT id, tenant_id, name, street, city, state, postal_code, country, phone_numbers, created_at, updated_at
+		FROM households
+		WHERE id = $1 AND tenant_id = $2
+	`
+
+	var household domain.Household
+	err := conn(ctx, r.pool).QueryRow(ctx, query, id, ports.TenantIDFromContext(ctx)).Scan(
+		&household.ID,
+		&household.TenantID,
+		&household.Name,
+		&household.Address.Street,
+		&household.Address.City,
+		&household.Address.State,
+		&household.Address.PostalCode,
+		&household.Address.Country,
+		&household.PhoneNumbers,
+		&household.CreatedAt,
+		&household.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("household not found: %w", domain.ErrNotFound)
+		}
+		r.logger.Error("Failed to get household", zap.Error(err), zap.String("household_id", id.String()))
+		return nil, fmt.Errorf("failed to get household: %w", err)
+	}
+
+	household.ParentIDs, err = r.queryMembers(ctx, `SELECT parent_id FROM household_parents WHERE household_id = $1 ORDER BY position`, id)
+	if err != nil {
+		r.logger.Error("Failed to get household parents", zap.Error(err), zap.String("household_id", id.String()))
+		return nil, fmt.Errorf("failed to get household parents: %w", err)
+	}
+	household.ChildIDs, err = r.queryMembers(ctx, `SELECT child_id FROM household_children WHERE household_id = $1 ORDER BY position`, id)
+	if err != nil {
+		r.logger.Error("Failed to get household children", zap.Error(err), zap.String("household_id", id.String()))
+		return nil, fmt.Errorf("failed to get household children: %w", err)
+	}
+
+	return &household, nil
+}
+
+// GetByChildID retrieves the household of the caller's tenant that a child belongs to
+func (r *HouseholdRepository) GetByChildID(ctx context.Context, childID uuid.UUID) (*domain.Household, error) {
+	ctx, span := r.tracer.Start(ctx, "HouseholdRepository.GetByChildID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("child.id", childID.String()))
+
+	query := `
+		SELECT household_id
+		FROM household_children
+		WHERE child_id = $1 AND tenant_id = $2
+	`
+
+	var householdID uuid.UUID
+	err := conn(ctx, r.pool).QueryRow(ctx, query, childID, ports.TenantIDFromContext(ctx)).Scan(&householdID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("household not found: %w", domain.ErrNotFound)
+		}
+		r.logger.Error("Failed to get household of child", zap.Error(err), zap.String("child_id", childID.String()))
+		return nil, fmt.Errorf("failed to get household of child: %w", err)
+	}
+
+	return r.GetByID(ctx, householdID)
+}
+
+// Update stores the name, address, phone numbers and members of a household of the caller's tenant
+func (r *HouseholdRepository) Update(ctx context.Context, household *domain.Household) error {
+	ctx, span := r.tracer.Start(ctx, "HouseholdRepository.Update")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("household.id", household.ID.String()))
+
+	query := `
+		UPDATE households
+		SET name = $1, street = $2, city = $3, state = $4, postal_code = $5, country = $6, phone_numbers = $7, updated_at = $8
+		WHERE id = $9 AND tenant_id = $10
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
+		household.Name,
+		household.Address.Street,
+		household.Address.City,
+		household.Address.State,
+		household.Address.PostalCode,
+		household.Address.Country,
+		household.PhoneNumbers,
+		household.UpdatedAt,
+		household.ID,
+		ports.TenantIDFromContext(ctx),
+	)
+	if err != nil {
+		r.logger.Error("Failed to update household", zap.Error(err), zap.String("household_id", household.ID.String()))
+		return fmt.Errorf("failed to update household: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("household not found: %w", domain.ErrNotFound)
+	}
+
+	// Replace the members, so that their order follows the household
+	for _, table := range []string{"household_parents", "household_children"} {
+		if _, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM `+table+` WHERE household_id = $1`, household.ID); err != nil {
+			r.logger.Error("Failed to remove household members", zap.Error(err), zap.String("household_id", household.ID.String()))
+			return fmt.Errorf("failed to remove household members: %w", err)
+		}
+	}
+	household.TenantID = ports.TenantIDFromContext(ctx)
+	if err := r.insertMembers(ctx, household); err != nil {
+		r.logger.Error("Failed to update household members", zap.Error(err), zap.String("household_id", household.ID.String()))
+		return fmt.Errorf("failed to update household members: %w", err)
+	}
+
+	return nil
+}
+
+// insertMembers stores the parents and children of a household, in order.
+// It fails when a child already belongs to another household.
+func (r *HouseholdRepository) insertMembers(ctx context.Context, household *domain.Household) error {
+	for position, parentID := range household.ParentIDs {
+		_, err := conn(ctx, r.pool).Exec(ctx, `
+			INSERT INTO household_parents (household_id, parent_id, tenant_id, position)
+			VALUES ($1, $2, $3, $4)
+		`, household.ID, parentID, household.TenantID, position)
+		if err != nil {
+			return err
+		}
+	}
+
+	for position, childID := range household.ChildIDs {
+		_, err := conn(ctx, r.pool).Exec(ctx, `
+			INSERT INTO household_children (child_id, household_id, tenant_id, position)
+			VALUES ($1, $2, $3, $4)
+		`, childID, household.ID, household.TenantID, position)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// queryMembers runs a query selecting the member IDs of a household
+func (r *HouseholdRepository) queryMembers(ctx context.Context, query string, householdID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, query, householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// Ensure HouseholdRepository implements ports.HouseholdRepository
+var _ ports.HouseholdRepository = (*HouseholdRepository)(nil)
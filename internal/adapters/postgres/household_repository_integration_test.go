@@ -0,0 +1,90 @@
+package postgres_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/postgres"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHouseholdRepositoryIntegration tests the PostgreSQL household repository with a real PostgreSQL database
+func TestHouseholdRepositoryIntegration(t *testing.T) {
+	// Skip if short flag is set
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	// Set up test repositories using the helper
+	factory, ctx, cleanup := postgres.SetupTestRepositories(t)
+	defer cleanup()
+
+	parentRepo := factory.NewParentRepository()
+	childRepo := factory.NewChildRepository()
+	households := factory.NewHouseholdRepository()
+	tenantCtx := ports.WithTenantID(ctx, "tenant-a")
+
+	// Two parents who live apart and share a child
+	father := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	mother := domain.NewParent("Mary", "Doe", "mary.doe@example.com", time.Now().AddDate(-29, 0, 0))
+	require.NoError(t, parentRepo.Create(tenantCtx, father))
+	require.NoError(t, parentRepo.Create(tenantCtx, mother))
+	child := domain.NewChild("Jimmy", "Doe", time.Now().AddDate(-5, 0, 0), father.ID)
+	require.NoError(t, childRepo.Create(tenantCtx, child))
+
+	address := domain.Address{Street: "1 Main St", City: "Springfield", State: "IL", PostalCode: "62701", Country: "US"}
+	fathers := domain.NewHousehold("Dad's", address, []string{"+12175550100"}, []uuid.UUID{father.ID}, []uuid.UUID{child.ID})
+	mothers := domain.NewHousehold("Mum's", address, nil, []uuid.UUID{mother.ID}, nil)
+	require.NoError(t, households.Create(tenantCtx, fathers))
+	require.NoError(t, households.Create(tenantCtx, mothers))
+
+	// Test that a household is read back with its address and members
+	t.Run("GetByID", func(t *testing.T) {
+		household, err := households.GetByID(tenantCtx, fathers.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Dad's", household.Name)
+		assert.Equal(t, address, household.Address)
+		assert.Equal(t, []string{"+12175550100"}, household.PhoneNumbers)
+		assert.Equal(t, []uuid.UUID{father.ID}, household.ParentIDs)
+		assert.Equal(t, []uuid.UUID{child.ID}, household.ChildIDs)
+
+		household, err = households.GetByChildID(tenantCtx, child.ID)
+		require.NoError(t, err)
+		assert.Equal(t, fathers.ID, household.ID)
+	})
+
+	// Test that a child cannot belong to two households
+	t.Run("SingleHouseholdPerChild", func(t *testing.T) {
+		mothers.AddChild(child.ID)
+		assert.Error(t, households.Update(tenantCtx, mothers))
+		mothers.RemoveChild(child.ID)
+	})
+
+	// Test that a child moves between households
+	t.Run("Move", func(t *testing.T) {
+		fathers.RemoveChild(child.ID)
+		require.NoError(t, households.Update(tenantCtx, fathers))
+		mothers.AddChild(child.ID)
+		require.NoError(t, households.Update(tenantCtx, mothers))
+
+		household, err := households.GetByChildID(tenantCtx, child.ID)
+		require.NoError(t, err)
+		assert.Equal(t, mothers.ID, household.ID)
+	})
+
+	// Test that households are only visible to their tenant
+	t.Run("OtherTenant", func(t *testing.T) {
+		otherCtx := ports.WithTenantID(ctx, "tenant-b")
+
+		_, err := households.GetByID(otherCtx, fathers.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+
+		_, err = households.GetByChildID(otherCtx, child.ID)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+	})
+}
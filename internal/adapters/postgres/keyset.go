@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+)
+
+// appendWindow appends the pagination window to a list query whose WHERE clause has already been built.
+// Cursor requests are served with a keyset seek on (sort column, id) and fetch one extra row to detect
+// further pages; page/pageSize requests keep using LIMIT/OFFSET. The id column is always added to the
+// ORDER BY clause as a tie-breaker so that the ordering is total and cursors stay stable.
+// It returns the query, its parameters, the page size and the offset.
+func appendWindow(query string, params []interface{}, sortColumn, idColumn string, desc bool, options ports.QueryOptions) (string, []interface{}, int, int) {
+	cursor := options.Cursor
+
+	if !cursor.IsSet() {
+		limit := options.Pagination.PageSize
+		if limit <= 0 {
+			limit = 10 // Default page size
+		}
+
+		offset := options.Pagination.Page * limit
+		if offset < 0 {
+			offset = 0
+		}
+
+		direction := "ASC"
+		if desc {
+			direction = "DESC"
+		}
+
+		query += fmt.Sprintf(" ORDER BY %s %s, %s %s", sortColumn, direction, idColumn, direction)
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(params)+1, len(params)+2)
+		params = append(params, limit, offset)
+
+		return query, params, limit, offset
+	}
+
+	// Rows after the cursor come later in the requested order, rows before it come earlier
+	if cursor.After != nil {
+		operator := ">"
+		if desc {
+			operator = "<"
+		}
+		query += fmt.Sprintf(" AND (%s, %s) %s ($%d, $%d)", sortColumn, idColumn, operator, len(params)+1, len(params)+2)
+		params = append(params, cursor.After.SortValue, cursor.After.ID)
+	}
+
+	if cursor.Before != nil {
+		operator := "<"
+		if desc {
+			operator = ">"
+		}
+		query += fmt.Sprintf(" AND (%s, %s) %s ($%d, $%d)", sortColumn, idColumn, operator, len(params)+1, len(params)+2)
+		params = append(params, cursor.Before.SortValue, cursor.Before.ID)
+	}
+
+	// Reading backwards scans in the opposite direction; ports.KeysetPage restores the order
+	direction := "ASC"
+	if desc != cursor.IsBackward() {
+		direction = "DESC"
+	}
+
+	limit := cursor.Limit(options.Pagination.PageSize)
+
+	query += fmt.Sprintf(" ORDER BY %s %s, %s %s", sortColumn, direction, idColumn, direction)
+	query += fmt.Sprintf(" LIMIT $%d", len(params)+1)
+	params = append(params, limit+1)
+
+	return query, params, limit, 0
+}
+
+// windowResult builds the paged result for rows fetched with appendWindow
+func windowResult[T any](items []T, options ports.QueryOptions, limit, offset int, totalCount int64) ([]T, *ports.PagedResult) {
+	pagedResult := &ports.PagedResult{
+		TotalCount: totalCount,
+		Page:       options.Pagination.Page,
+		PageSize:   limit,
+	}
+
+	if !options.Cursor.IsSet() {
+		pagedResult.HasNext = int64(offset+len(items)) < totalCount
+		pagedResult.HasPrevious = offset > 0
+		return items, pagedResult
+	}
+
+	items, pagedResult.HasNext, pagedResult.HasPrevious = ports.KeysetPage(options.Cursor, items, limit)
+	return items, pagedResult
+}
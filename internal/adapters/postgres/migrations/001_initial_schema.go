@@ -24,6 +24,43 @@ func NewInitialSchemaMigration(pool *pgxpool.Pool, logger *zap.Logger) *InitialS
 	}
 }
 
+// The SQL that applies and reverts the migration
+const (
+	initialSchemaUpSQL = `
+		CREATE TABLE IF NOT EXISTS parents (
+			id UUID PRIMARY KEY,
+			first_name TEXT NOT NULL,
+			last_name TEXT NOT NULL,
+			email TEXT NOT NULL,
+			birth_date TIMESTAMP NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL,
+			deleted_at TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS children (
+			id UUID PRIMARY KEY,
+			first_name TEXT NOT NULL,
+			last_name TEXT NOT NULL,
+			birth_date TIMESTAMP NOT NULL,
+			parent_id UUID NOT NULL REFERENCES parents(id),
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL,
+			deleted_at TIMESTAMP
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_parents_deleted_at ON parents(deleted_at);
+		CREATE INDEX IF NOT EXISTS idx_parents_email ON parents(email);
+		CREATE INDEX IF NOT EXISTS idx_children_deleted_at ON children(deleted_at);
+		CREATE INDEX IF NOT EXISTS idx_children_parent_id ON children(parent_id);
+	`
+
+	initialSchemaDownSQL = `
+		DROP TABLE IF EXISTS children;
+		DROP TABLE IF EXISTS parents;
+	`
+)
+
 // Up runs the migration
 func (m *InitialSchemaMigration) Up(ctx context.Context) error {
 	m.logger.Info("Running initial schema migration for PostgreSQL")
@@ -50,13 +87,7 @@ func (m *InitialSchemaMigration) Up(ctx context.Context) error {
 func (m *InitialSchemaMigration) Down(ctx context.Context) error {
 	m.logger.Info("Rolling back initial schema migration for PostgreSQL")
 
-	// Drop tables
-	dropTablesSQL := `
-		DROP TABLE IF EXISTS children;
-		DROP TABLE IF EXISTS parents;
-	`
-
-	_, err := m.pool.Exec(ctx, dropTablesSQL)
+	_, err := m.pool.Exec(ctx, initialSchemaDownSQL)
 	if err != nil {
 		m.logger.Error("Failed to drop tables", zap.Error(err))
 		return err
@@ -66,39 +97,14 @@ func (m *InitialSchemaMigration) Down(ctx context.Context) error {
 	return nil
 }
 
+// Definition returns the SQL that applies and reverts the migration
+func (m *InitialSchemaMigration) Definition() string {
+	return initialSchemaUpSQL + initialSchemaDownSQL
+}
+
 // createTables creates the database tables
 func (m *InitialSchemaMigration) createTables(ctx context.Context) error {
-	// Create tables
-	createTablesSQL := `
-		CREATE TABLE IF NOT EXISTS parents (
-			id UUID PRIMARY KEY,
-			first_name TEXT NOT NULL,
-			last_name TEXT NOT NULL,
-			email TEXT NOT NULL,
-			birth_date TIMESTAMP NOT NULL,
-			created_at TIMESTAMP NOT NULL,
-			updated_at TIMESTAMP NOT NULL,
-			deleted_at TIMESTAMP
-		);
-
-		CREATE TABLE IF NOT EXISTS children (
-			id UUID PRIMARY KEY,
-			first_name TEXT NOT NULL,
-			last_name TEXT NOT NULL,
-			birth_date TIMESTAMP NOT NULL,
-			parent_id UUID NOT NULL REFERENCES parents(id),
-			created_at TIMESTAMP NOT NULL,
-			updated_at TIMESTAMP NOT NULL,
-			deleted_at TIMESTAMP
-		);
-
-		CREATE INDEX IF NOT EXISTS idx_parents_deleted_at ON parents(deleted_at);
-		CREATE INDEX IF NOT EXISTS idx_parents_email ON parents(email);
-		CREATE INDEX IF NOT EXISTS idx_children_deleted_at ON children(deleted_at);
-		CREATE INDEX IF NOT EXISTS idx_children_parent_id ON children(parent_id);
-	`
-
-	_, err := m.pool.Exec(ctx, createTablesSQL)
+	_, err := m.pool.Exec(ctx, initialSchemaUpSQL)
 	if err != nil {
 		m.logger.Error("Failed to create tables", zap.Error(err))
 		return err
@@ -0,0 +1,72 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// ParentUserLinkMigration adds the link between a parent and the user account that may access its family
+type ParentUserLinkMigration struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewParentUserLinkMigration creates a new parent user link migration
+func NewParentUserLinkMigration(pool *pgxpool.Pool, logger *zap.Logger) *ParentUserLinkMigration {
+	return &ParentUserLinkMigration{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// The SQL that applies and reverts the migration
+const (
+	// A user account can be linked to at most one parent
+	parentUserLinkUpSQL = `
+		ALTER TABLE parents ADD COLUMN IF NOT EXISTS user_id TEXT;
+
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_parents_user_id
+			ON parents(user_id)
+			WHERE user_id IS NOT NULL AND deleted_at IS NULL;
+	`
+
+	parentUserLinkDownSQL = `
+		DROP INDEX IF EXISTS idx_parents_user_id;
+		ALTER TABLE parents DROP COLUMN IF EXISTS user_id;
+	`
+)
+
+// Up runs the migration
+func (m *ParentUserLinkMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running parent user link migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, parentUserLinkUpSQL)
+	if err != nil {
+		m.logger.Error("Failed to add user_id column to parents", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Parent user link migration for PostgreSQL completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *ParentUserLinkMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back parent user link migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, parentUserLinkDownSQL)
+	if err != nil {
+		m.logger.Error("Failed to drop user_id column from parents", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Parent user link migration for PostgreSQL rolled back successfully")
+	return nil
+}
+
+// Definition returns the SQL that applies and reverts the migration
+func (m *ParentUserLinkMigration) Definition() string {
+	return parentUserLinkUpSQL + parentUserLinkDownSQL
+}
@@ -0,0 +1,110 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// TenantIsolationMigration assigns parents and children to tenants and isolates the tenants
+// with row-level security
+type TenantIsolationMigration struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewTenantIsolationMigration creates a new tenant isolation migration
+func NewTenantIsolationMigration(pool *pgxpool.Pool, logger *zap.Logger) *TenantIsolationMigration {
+	return &TenantIsolationMigration{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// The SQL that applies and reverts the migration
+const (
+	// Existing rows belong to the default tenant, whose ID is empty.
+	// The policies limit every statement to the tenant set by the transaction manager in
+	// app.tenant_id; statements run without it rely on the tenant predicate of the repositories.
+	// FORCE makes the policies apply to the owner of the tables too.
+	tenantIsolationUpSQL = `
+		ALTER TABLE parents ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT '';
+		ALTER TABLE children ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT '';
+
+		CREATE INDEX IF NOT EXISTS idx_parents_tenant_id ON parents(tenant_id);
+		CREATE INDEX IF NOT EXISTS idx_children_tenant_id ON children(tenant_id);
+
+		DROP INDEX IF EXISTS idx_parents_user_id;
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_parents_user_id
+			ON parents(tenant_id, user_id)
+			WHERE user_id IS NOT NULL AND deleted_at IS NULL;
+
+		ALTER TABLE parents ENABLE ROW LEVEL SECURITY;
+		ALTER TABLE parents FORCE ROW LEVEL SECURITY;
+		ALTER TABLE children ENABLE ROW LEVEL SECURITY;
+		ALTER TABLE children FORCE ROW LEVEL SECURITY;
+
+		DROP POLICY IF EXISTS tenant_isolation ON parents;
+		CREATE POLICY tenant_isolation ON parents
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+
+		DROP POLICY IF EXISTS tenant_isolation ON children;
+		CREATE POLICY tenant_isolation ON children
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+	`
+
+	tenantIsolationDownSQL = `
+		DROP POLICY IF EXISTS tenant_isolation ON children;
+		DROP POLICY IF EXISTS tenant_isolation ON parents;
+		ALTER TABLE children NO FORCE ROW LEVEL SECURITY;
+		ALTER TABLE children DISABLE ROW LEVEL SECURITY;
+		ALTER TABLE parents NO FORCE ROW LEVEL SECURITY;
+		ALTER TABLE parents DISABLE ROW LEVEL SECURITY;
+
+		DROP INDEX IF EXISTS idx_parents_user_id;
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_parents_user_id
+			ON parents(user_id)
+			WHERE user_id IS NOT NULL AND deleted_at IS NULL;
+
+		DROP INDEX IF EXISTS idx_children_tenant_id;
+		DROP INDEX IF EXISTS idx_parents_tenant_id;
+		ALTER TABLE children DROP COLUMN IF EXISTS tenant_id;
+		ALTER TABLE parents DROP COLUMN IF EXISTS tenant_id;
+	`
+)
+
+// Up runs the migration
+func (m *TenantIsolationMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running tenant isolation migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, tenantIsolationUpSQL)
+	if err != nil {
+		m.logger.Error("Failed to isolate tenants", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Tenant isolation migration for PostgreSQL completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *TenantIsolationMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back tenant isolation migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, tenantIsolationDownSQL)
+	if err != nil {
+		m.logger.Error("Failed to remove tenant isolation", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Tenant isolation migration for PostgreSQL rolled back successfully")
+	return nil
+}
+
+// Definition returns the SQL that applies and reverts the migration
+func (m *TenantIsolationMigration) Definition() string {
+	return tenantIsolationUpSQL + tenantIsolationDownSQL
+}
@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// EntityVersionsMigration adds the version used for optimistic concurrency control to parents and children
+type EntityVersionsMigration struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewEntityVersionsMigration creates a new entity versions migration
+func NewEntityVersionsMigration(pool *pgxpool.Pool, logger *zap.Logger) *EntityVersionsMigration {
+	return &EntityVersionsMigration{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// The SQL that applies and reverts the migration
+const (
+	// Existing rows start at the first version, like new entities
+	entityVersionsUpSQL = `
+		ALTER TABLE parents ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+		ALTER TABLE children ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+	`
+
+	entityVersionsDownSQL = `
+		ALTER TABLE children DROP COLUMN IF EXISTS version;
+		ALTER TABLE parents DROP COLUMN IF EXISTS version;
+	`
+)
+
+// Up runs the migration
+func (m *EntityVersionsMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running entity versions migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, entityVersionsUpSQL)
+	if err != nil {
+		m.logger.Error("Failed to add entity versions", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Entity versions migration for PostgreSQL completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *EntityVersionsMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back entity versions migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, entityVersionsDownSQL)
+	if err != nil {
+		m.logger.Error("Failed to remove entity versions", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Entity versions migration for PostgreSQL rolled back successfully")
+	return nil
+}
+
+// Definition returns the SQL that applies and reverts the migration
+func (m *EntityVersionsMigration) Definition() string {
+	return entityVersionsUpSQL + entityVersionsDownSQL
+}
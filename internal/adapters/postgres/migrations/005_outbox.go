@@ -0,0 +1,87 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// OutboxMigration adds the transactional outbox of domain events
+type OutboxMigration struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewOutboxMigration creates a new outbox migration
+func NewOutboxMigration(pool *pgxpool.Pool, logger *zap.Logger) *OutboxMigration {
+	return &OutboxMigration{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// The SQL that applies and reverts the migration
+const (
+	// Messages are appended within the transactions of the tenants, and relayed by a reader
+	// that sets no tenant, which the tenant isolation policy admits to every tenant.
+	outboxUpSQL = `
+		CREATE TABLE IF NOT EXISTS outbox (
+			sequence BIGSERIAL PRIMARY KEY,
+			event_id UUID NOT NULL UNIQUE,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			aggregate_id UUID NOT NULL,
+			event_type TEXT NOT NULL,
+			payload JSONB NOT NULL,
+			occurred_at TIMESTAMP NOT NULL,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			next_attempt_at TIMESTAMP NOT NULL,
+			last_error TEXT
+		);
+
+		ALTER TABLE outbox ENABLE ROW LEVEL SECURITY;
+		ALTER TABLE outbox FORCE ROW LEVEL SECURITY;
+
+		DROP POLICY IF EXISTS tenant_isolation ON outbox;
+		CREATE POLICY tenant_isolation ON outbox
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+	`
+
+	outboxDownSQL = `
+		DROP TABLE IF EXISTS outbox;
+	`
+)
+
+// Up runs the migration
+func (m *OutboxMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running outbox migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, outboxUpSQL)
+	if err != nil {
+		m.logger.Error("Failed to create outbox", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Outbox migration for PostgreSQL completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *OutboxMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back outbox migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, outboxDownSQL)
+	if err != nil {
+		m.logger.Error("Failed to drop outbox", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Outbox migration for PostgreSQL rolled back successfully")
+	return nil
+}
+
+// Definition returns the SQL that applies and reverts the migration
+func (m *OutboxMigration) Definition() string {
+	return outboxUpSQL + outboxDownSQL
+}
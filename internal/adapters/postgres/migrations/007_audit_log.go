@@ -0,0 +1,100 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// AuditLogMigration adds the append-only log of changes to parents and children
+type AuditLogMigration struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewAuditLogMigration creates a new audit log migration
+func NewAuditLogMigration(pool *pgxpool.Pool, logger *zap.Logger) *AuditLogMigration {
+	return &AuditLogMigration{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// The SQL that applies and reverts the migration
+const (
+	// The trigger makes the log append-only for every role the service may connect as,
+	// so that a record cannot be altered or removed once its change has committed.
+	auditLogUpSQL = `
+		CREATE TABLE IF NOT EXISTS audit_log (
+			id UUID PRIMARY KEY,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			actor TEXT NOT NULL DEFAULT '',
+			operation TEXT NOT NULL,
+			entity_type TEXT NOT NULL,
+			entity_id UUID NOT NULL,
+			changes JSONB NOT NULL,
+			trace_id TEXT NOT NULL DEFAULT '',
+			occurred_at TIMESTAMP NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log(tenant_id, entity_id, occurred_at);
+
+		CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit_log is append-only';
+		END;
+		$$ LANGUAGE plpgsql;
+
+		DROP TRIGGER IF EXISTS audit_log_append_only ON audit_log;
+		CREATE TRIGGER audit_log_append_only
+			BEFORE UPDATE OR DELETE ON audit_log
+			FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+		ALTER TABLE audit_log ENABLE ROW LEVEL SECURITY;
+		ALTER TABLE audit_log FORCE ROW LEVEL SECURITY;
+
+		DROP POLICY IF EXISTS tenant_isolation ON audit_log;
+		CREATE POLICY tenant_isolation ON audit_log
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+	`
+
+	auditLogDownSQL = `
+		DROP TABLE IF EXISTS audit_log;
+		DROP FUNCTION IF EXISTS audit_log_append_only();
+	`
+)
+
+// Up runs the migration
+func (m *AuditLogMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running audit log migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, auditLogUpSQL)
+	if err != nil {
+		m.logger.Error("Failed to create audit log table", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Audit log migration for PostgreSQL completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *AuditLogMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back audit log migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, auditLogDownSQL)
+	if err != nil {
+		m.logger.Error("Failed to drop audit log table", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Audit log migration for PostgreSQL rolled back successfully")
+	return nil
+}
+
+// Definition returns the SQL that applies and reverts the migration
+func (m *AuditLogMigration) Definition() string {
+	return auditLogUpSQL + auditLogDownSQL
+}
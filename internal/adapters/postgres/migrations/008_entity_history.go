@@ -0,0 +1,167 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// EntityHistoryMigration adds the versioned history of parents and children
+type EntityHistoryMigration struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewEntityHistoryMigration creates a new entity history migration
+func NewEntityHistoryMigration(pool *pgxpool.Pool, logger *zap.Logger) *EntityHistoryMigration {
+	return &EntityHistoryMigration{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// The SQL that applies and reverts the migration
+const (
+	// The triggers record a revision of every row written to parents and children, whichever
+	// repository or statement writes it, and remove the revisions of the rows that are purged.
+	// The rows that already exist are recorded as of their last update, as their earlier
+	// versions are not known.
+	entityHistoryUpSQL = `
+		CREATE TABLE IF NOT EXISTS parent_history (
+			history_id BIGSERIAL PRIMARY KEY,
+			id UUID NOT NULL,
+			first_name TEXT NOT NULL,
+			last_name TEXT NOT NULL,
+			email TEXT NOT NULL,
+			birth_date TIMESTAMP NOT NULL,
+			user_id TEXT,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			version INTEGER NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL,
+			deleted_at TIMESTAMP,
+			recorded_at TIMESTAMP NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS child_history (
+			history_id BIGSERIAL PRIMARY KEY,
+			id UUID NOT NULL,
+			first_name TEXT NOT NULL,
+			last_name TEXT NOT NULL,
+			birth_date TIMESTAMP NOT NULL,
+			parent_id UUID NOT NULL,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			version INTEGER NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL,
+			deleted_at TIMESTAMP,
+			recorded_at TIMESTAMP NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_parent_history_id ON parent_history(tenant_id, id, recorded_at);
+		CREATE INDEX IF NOT EXISTS idx_child_history_id ON child_history(tenant_id, id, recorded_at);
+		CREATE INDEX IF NOT EXISTS idx_child_history_parent_id ON child_history(tenant_id, parent_id);
+
+		INSERT INTO parent_history (id, first_name, last_name, email, birth_date, user_id, tenant_id, version, created_at, updated_at, deleted_at, recorded_at)
+		SELECT p.id, p.first_name, p.last_name, p.email, p.birth_date, p.user_id, p.tenant_id, p.version, p.created_at, p.updated_at, p.deleted_at, p.updated_at
+		FROM parents p
+		WHERE NOT EXISTS (SELECT 1 FROM parent_history h WHERE h.id = p.id);
+
+		INSERT INTO child_history (id, first_name, last_name, birth_date, parent_id, tenant_id, version, created_at, updated_at, deleted_at, recorded_at)
+		SELECT c.id, c.first_name, c.last_name, c.birth_date, c.parent_id, c.tenant_id, c.version, c.created_at, c.updated_at, c.deleted_at, c.updated_at
+		FROM children c
+		WHERE NOT EXISTS (SELECT 1 FROM child_history h WHERE h.id = c.id);
+
+		CREATE OR REPLACE FUNCTION record_parent_history() RETURNS trigger AS $$
+		BEGIN
+			IF TG_OP = 'DELETE' THEN
+				DELETE FROM parent_history WHERE id = OLD.id;
+				RETURN NULL;
+			END IF;
+			INSERT INTO parent_history (id, first_name, last_name, email, birth_date, user_id, tenant_id, version, created_at, updated_at, deleted_at, recorded_at)
+			VALUES (NEW.id, NEW.first_name, NEW.last_name, NEW.email, NEW.birth_date, NEW.user_id, NEW.tenant_id, NEW.version, NEW.created_at, NEW.updated_at, NEW.deleted_at, clock_timestamp() AT TIME ZONE 'UTC');
+			RETURN NULL;
+		END;
+		$$ LANGUAGE plpgsql;
+
+		CREATE OR REPLACE FUNCTION record_child_history() RETURNS trigger AS $$
+		BEGIN
+			IF TG_OP = 'DELETE' THEN
+				DELETE FROM child_history WHERE id = OLD.id;
+				RETURN NULL;
+			END IF;
+			INSERT INTO child_history (id, first_name, last_name, birth_date, parent_id, tenant_id, version, created_at, updated_at, deleted_at, recorded_at)
+			VALUES (NEW.id, NEW.first_name, NEW.last_name, NEW.birth_date, NEW.parent_id, NEW.tenant_id, NEW.version, NEW.created_at, NEW.updated_at, NEW.deleted_at, clock_timestamp() AT TIME ZONE 'UTC');
+			RETURN NULL;
+		END;
+		$$ LANGUAGE plpgsql;
+
+		DROP TRIGGER IF EXISTS record_parent_history ON parents;
+		CREATE TRIGGER record_parent_history
+			AFTER INSERT OR UPDATE OR DELETE ON parents
+			FOR EACH ROW EXECUTE FUNCTION record_parent_history();
+
+		DROP TRIGGER IF EXISTS record_child_history ON children;
+		CREATE TRIGGER record_child_history
+			AFTER INSERT OR UPDATE OR DELETE ON children
+			FOR EACH ROW EXECUTE FUNCTION record_child_history();
+
+		ALTER TABLE parent_history ENABLE ROW LEVEL SECURITY;
+		ALTER TABLE parent_history FORCE ROW LEVEL SECURITY;
+		ALTER TABLE child_history ENABLE ROW LEVEL SECURITY;
+		ALTER TABLE child_history FORCE ROW LEVEL SECURITY;
+
+		DROP POLICY IF EXISTS tenant_isolation ON parent_history;
+		CREATE POLICY tenant_isolation ON parent_history
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+
+		DROP POLICY IF EXISTS tenant_isolation ON child_history;
+		CREATE POLICY tenant_isolation ON child_history
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+	`
+
+	entityHistoryDownSQL = `
+		DROP TRIGGER IF EXISTS record_child_history ON children;
+		DROP TRIGGER IF EXISTS record_parent_history ON parents;
+		DROP FUNCTION IF EXISTS record_child_history();
+		DROP FUNCTION IF EXISTS record_parent_history();
+		DROP TABLE IF EXISTS child_history;
+		DROP TABLE IF EXISTS parent_history;
+	`
+)
+
+// Up runs the migration
+func (m *EntityHistoryMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running entity history migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, entityHistoryUpSQL)
+	if err != nil {
+		m.logger.Error("Failed to create entity history tables", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Entity history migration for PostgreSQL completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *EntityHistoryMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back entity history migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, entityHistoryDownSQL)
+	if err != nil {
+		m.logger.Error("Failed to drop entity history tables", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Entity history migration for PostgreSQL rolled back successfully")
+	return nil
+}
+
+// Definition returns the SQL that applies and reverts the migration
+func (m *EntityHistoryMigration) Definition() string {
+	return entityHistoryUpSQL + entityHistoryDownSQL
+}
@@ -0,0 +1,104 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// GuardianshipsMigration relates parents and children through guardianships
+type GuardianshipsMigration struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewGuardianshipsMigration creates a new guardianships migration
+func NewGuardianshipsMigration(pool *pgxpool.Pool, logger *zap.Logger) *GuardianshipsMigration {
+	return &GuardianshipsMigration{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// The SQL that applies and reverts the migration
+const (
+	// The guardianships replace the foreign key of children to their parent; children.parent_id
+	// remains as the primary contact of the child, which the service keeps in step with the
+	// guardianships. Every existing child becomes the ward of its parent, as its primary contact,
+	// since it was created.
+	guardianshipsUpSQL = `
+		CREATE TABLE IF NOT EXISTS guardianships (
+			id UUID PRIMARY KEY,
+			parent_id UUID NOT NULL REFERENCES parents(id) ON DELETE CASCADE,
+			child_id UUID NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			type TEXT NOT NULL,
+			start_date TIMESTAMP NOT NULL,
+			end_date TIMESTAMP,
+			primary_contact BOOLEAN NOT NULL DEFAULT FALSE,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_guardianships_child_id ON guardianships(tenant_id, child_id);
+		CREATE INDEX IF NOT EXISTS idx_guardianships_parent_id ON guardianships(tenant_id, parent_id);
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_guardianships_active ON guardianships(parent_id, child_id) WHERE end_date IS NULL;
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_guardianships_primary_contact ON guardianships(child_id) WHERE primary_contact AND end_date IS NULL;
+
+		INSERT INTO guardianships (id, parent_id, child_id, tenant_id, type, start_date, primary_contact, created_at, updated_at)
+		SELECT gen_random_uuid(), c.parent_id, c.id, c.tenant_id, 'GUARDIAN', c.created_at, TRUE, c.created_at, c.created_at
+		FROM children c
+		WHERE NOT EXISTS (SELECT 1 FROM guardianships g WHERE g.child_id = c.id);
+
+		ALTER TABLE children DROP CONSTRAINT IF EXISTS children_parent_id_fkey;
+
+		ALTER TABLE guardianships ENABLE ROW LEVEL SECURITY;
+		ALTER TABLE guardianships FORCE ROW LEVEL SECURITY;
+
+		DROP POLICY IF EXISTS tenant_isolation ON guardianships;
+		CREATE POLICY tenant_isolation ON guardianships
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+	`
+
+	guardianshipsDownSQL = `
+		DROP TABLE IF EXISTS guardianships;
+
+		ALTER TABLE children DROP CONSTRAINT IF EXISTS children_parent_id_fkey;
+		ALTER TABLE children ADD CONSTRAINT children_parent_id_fkey FOREIGN KEY (parent_id) REFERENCES parents(id);
+	`
+)
+
+// Up runs the migration
+func (m *GuardianshipsMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running guardianships migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, guardianshipsUpSQL)
+	if err != nil {
+		m.logger.Error("Failed to create guardianships table", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Guardianships migration for PostgreSQL completed successfully")
+	return nil
+}
+
+// Down rolls back the migration; the children keep the parent that was their primary contact
+func (m *GuardianshipsMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back guardianships migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, guardianshipsDownSQL)
+	if err != nil {
+		m.logger.Error("Failed to drop guardianships table", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Guardianships migration for PostgreSQL rolled back successfully")
+	return nil
+}
+
+// Definition returns the SQL that applies and reverts the migration
+func (m *GuardianshipsMigration) Definition() string {
+	return guardianshipsUpSQL + guardianshipsDownSQL
+}
@@ -0,0 +1,123 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// HouseholdsMigration groups parents and children in households with a shared address
+type HouseholdsMigration struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewHouseholdsMigration creates a new households migration
+func NewHouseholdsMigration(pool *pgxpool.Pool, logger *zap.Logger) *HouseholdsMigration {
+	return &HouseholdsMigration{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// The SQL that applies and reverts the migration
+const (
+	// The members of a household are removed with the parent or child when it is purged.
+	// A child is a member of at most one household, so child_id is the key of household_children.
+	householdsUpSQL = `
+		CREATE TABLE IF NOT EXISTS households (
+			id UUID PRIMARY KEY,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			name TEXT NOT NULL,
+			street TEXT NOT NULL,
+			city TEXT NOT NULL,
+			state TEXT NOT NULL DEFAULT '',
+			postal_code TEXT NOT NULL,
+			country TEXT NOT NULL,
+			phone_numbers TEXT[] NOT NULL DEFAULT '{}',
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS household_parents (
+			household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+			parent_id UUID NOT NULL REFERENCES parents(id) ON DELETE CASCADE,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			position INTEGER NOT NULL,
+			PRIMARY KEY (household_id, parent_id)
+		);
+
+		CREATE TABLE IF NOT EXISTS household_children (
+			child_id UUID PRIMARY KEY REFERENCES children(id) ON DELETE CASCADE,
+			household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			position INTEGER NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_households_tenant_id ON households(tenant_id);
+		CREATE INDEX IF NOT EXISTS idx_household_parents_parent_id ON household_parents(tenant_id, parent_id);
+		CREATE INDEX IF NOT EXISTS idx_household_children_household_id ON household_children(household_id);
+
+		ALTER TABLE households ENABLE ROW LEVEL SECURITY;
+		ALTER TABLE households FORCE ROW LEVEL SECURITY;
+		ALTER TABLE household_parents ENABLE ROW LEVEL SECURITY;
+		ALTER TABLE household_parents FORCE ROW LEVEL SECURITY;
+		ALTER TABLE household_children ENABLE ROW LEVEL SECURITY;
+		ALTER TABLE household_children FORCE ROW LEVEL SECURITY;
+
+		DROP POLICY IF EXISTS tenant_isolation ON households;
+		CREATE POLICY tenant_isolation ON households
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+
+		DROP POLICY IF EXISTS tenant_isolation ON household_parents;
+		CREATE POLICY tenant_isolation ON household_parents
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+
+		DROP POLICY IF EXISTS tenant_isolation ON household_children;
+		CREATE POLICY tenant_isolation ON household_children
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+	`
+
+	householdsDownSQL = `
+		DROP TABLE IF EXISTS household_children;
+		DROP TABLE IF EXISTS household_parents;
+		DROP TABLE IF EXISTS households;
+	`
+)
+
+// Up runs the migration
+func (m *HouseholdsMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running households migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, householdsUpSQL)
+	if err != nil {
+		m.logger.Error("Failed to create households tables", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Households migration for PostgreSQL completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *HouseholdsMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back households migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, householdsDownSQL)
+	if err != nil {
+		m.logger.Error("Failed to drop households tables", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Households migration for PostgreSQL rolled back successfully")
+	return nil
+}
+
+// Definition returns the SQL that applies and reverts the migration
+func (m *HouseholdsMigration) Definition() string {
+	return householdsUpSQL + householdsDownSQL
+}
@@ -0,0 +1,99 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// ParentContactDetailsMigration adds the phone numbers, postal address and emergency contacts of parents
+type ParentContactDetailsMigration struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewParentContactDetailsMigration creates a new parent contact details migration
+func NewParentContactDetailsMigration(pool *pgxpool.Pool, logger *zap.Logger) *ParentContactDetailsMigration {
+	return &ParentContactDetailsMigration{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// The SQL that applies and reverts the migration
+const (
+	// The contact details are stored as a single JSONB document, which the history records as well.
+	// The parents are filtered by the postal code of their address and by their phone numbers.
+	parentContactDetailsUpSQL = `
+		ALTER TABLE parents ADD COLUMN IF NOT EXISTS contact_details JSONB NOT NULL DEFAULT '{}';
+		ALTER TABLE parent_history ADD COLUMN IF NOT EXISTS contact_details JSONB NOT NULL DEFAULT '{}';
+
+		CREATE INDEX IF NOT EXISTS idx_parents_postal_code ON parents(tenant_id, (contact_details->'address'->>'postalCode'));
+		CREATE INDEX IF NOT EXISTS idx_parents_phones ON parents USING GIN ((contact_details->'phones') jsonb_path_ops);
+
+		CREATE OR REPLACE FUNCTION record_parent_history() RETURNS trigger AS $$
+		BEGIN
+			IF TG_OP = 'DELETE' THEN
+				DELETE FROM parent_history WHERE id = OLD.id;
+				RETURN NULL;
+			END IF;
+			INSERT INTO parent_history (id, first_name, last_name, email, birth_date, user_id, contact_details, tenant_id, version, created_at, updated_at, deleted_at, recorded_at)
+			VALUES (NEW.id, NEW.first_name, NEW.last_name, NEW.email, NEW.birth_date, NEW.user_id, NEW.contact_details, NEW.tenant_id, NEW.version, NEW.created_at, NEW.updated_at, NEW.deleted_at, clock_timestamp() AT TIME ZONE 'UTC');
+			RETURN NULL;
+		END;
+		$$ LANGUAGE plpgsql;
+	`
+
+	parentContactDetailsDownSQL = `
+		CREATE OR REPLACE FUNCTION record_parent_history() RETURNS trigger AS $$
+		BEGIN
+			IF TG_OP = 'DELETE' THEN
+				DELETE FROM parent_history WHERE id = OLD.id;
+				RETURN NULL;
+			END IF;
+			INSERT INTO parent_history (id, first_name, last_name, email, birth_date, user_id, tenant_id, version, created_at, updated_at, deleted_at, recorded_at)
+			VALUES (NEW.id, NEW.first_name, NEW.last_name, NEW.email, NEW.birth_date, NEW.user_id, NEW.tenant_id, NEW.version, NEW.created_at, NEW.updated_at, NEW.deleted_at, clock_timestamp() AT TIME ZONE 'UTC');
+			RETURN NULL;
+		END;
+		$$ LANGUAGE plpgsql;
+
+		DROP INDEX IF EXISTS idx_parents_phones;
+		DROP INDEX IF EXISTS idx_parents_postal_code;
+		ALTER TABLE parent_history DROP COLUMN IF EXISTS contact_details;
+		ALTER TABLE parents DROP COLUMN IF EXISTS contact_details;
+	`
+)
+
+// Up runs the migration
+func (m *ParentContactDetailsMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running parent contact details migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, parentContactDetailsUpSQL)
+	if err != nil {
+		m.logger.Error("Failed to add parent contact details", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Parent contact details migration for PostgreSQL completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *ParentContactDetailsMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back parent contact details migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, parentContactDetailsDownSQL)
+	if err != nil {
+		m.logger.Error("Failed to drop parent contact details", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Parent contact details migration for PostgreSQL rolled back successfully")
+	return nil
+}
+
+// Definition returns the SQL that applies and reverts the migration
+func (m *ParentContactDetailsMigration) Definition() string {
+	return parentContactDetailsUpSQL + parentContactDetailsDownSQL
+}
@@ -0,0 +1,89 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// UniqueParentEmailMigration makes the email of a parent unique among the non-deleted parents of a tenant
+type UniqueParentEmailMigration struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewUniqueParentEmailMigration creates a new unique parent email migration
+func NewUniqueParentEmailMigration(pool *pgxpool.Pool, logger *zap.Logger) *UniqueParentEmailMigration {
+	return &UniqueParentEmailMigration{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// The SQL that applies and reverts the migration
+const (
+	// Emails that differ only in case are the same, and deleted parents release their email
+	uniqueParentEmailUpSQL = `
+		DROP INDEX IF EXISTS idx_parents_email;
+		CREATE UNIQUE INDEX idx_parents_email ON parents(tenant_id, lower(email)) WHERE deleted_at IS NULL;
+	`
+
+	uniqueParentEmailDownSQL = `
+		DROP INDEX IF EXISTS idx_parents_email;
+		CREATE INDEX IF NOT EXISTS idx_parents_email ON parents(email);
+	`
+)
+
+// Up runs the migration
+func (m *UniqueParentEmailMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running unique parent email migration for PostgreSQL")
+
+	// The index cannot be created while parents share an email, so they are reported first
+	var duplicates int
+	countSQL := `
+		SELECT COUNT(*) FROM (
+			SELECT 1 FROM parents
+			WHERE deleted_at IS NULL
+			GROUP BY tenant_id, lower(email)
+			HAVING COUNT(*) > 1
+		) AS duplicates
+	`
+	if err := m.pool.QueryRow(ctx, countSQL).Scan(&duplicates); err != nil {
+		m.logger.Error("Failed to look for duplicate parent emails", zap.Error(err))
+		return err
+	}
+	if duplicates > 0 {
+		m.logger.Error("Parents share an email", zap.Int("emails", duplicates))
+		return fmt.Errorf("%d emails are shared by several parents; merge or delete them before migrating", duplicates)
+	}
+
+	_, err := m.pool.Exec(ctx, uniqueParentEmailUpSQL)
+	if err != nil {
+		m.logger.Error("Failed to create unique email index for parents table", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Unique parent email migration for PostgreSQL completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *UniqueParentEmailMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back unique parent email migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, uniqueParentEmailDownSQL)
+	if err != nil {
+		m.logger.Error("Failed to restore email index for parents table", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Unique parent email migration for PostgreSQL rolled back successfully")
+	return nil
+}
+
+// Definition returns the SQL that applies and reverts the migration
+func (m *UniqueParentEmailMigration) Definition() string {
+	return uniqueParentEmailUpSQL + uniqueParentEmailDownSQL
+}
@@ -0,0 +1,93 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// ParentMergesMigration records the parent a duplicate parent was merged into
+type ParentMergesMigration struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewParentMergesMigration creates a new parent merges migration
+func NewParentMergesMigration(pool *pgxpool.Pool, logger *zap.Logger) *ParentMergesMigration {
+	return &ParentMergesMigration{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// The SQL that applies and reverts the migration
+const (
+	// The history records the merge with the version that marked the duplicate as deleted
+	parentMergesUpSQL = `
+		ALTER TABLE parents ADD COLUMN IF NOT EXISTS merged_into UUID;
+		ALTER TABLE parent_history ADD COLUMN IF NOT EXISTS merged_into UUID;
+
+		CREATE OR REPLACE FUNCTION record_parent_history() RETURNS trigger AS $$
+		BEGIN
+			IF TG_OP = 'DELETE' THEN
+				DELETE FROM parent_history WHERE id = OLD.id;
+				RETURN NULL;
+			END IF;
+			INSERT INTO parent_history (id, first_name, last_name, email, birth_date, user_id, contact_details, merged_into, tenant_id, version, created_at, updated_at, deleted_at, recorded_at)
+			VALUES (NEW.id, NEW.first_name, NEW.last_name, NEW.email, NEW.birth_date, NEW.user_id, NEW.contact_details, NEW.merged_into, NEW.tenant_id, NEW.version, NEW.created_at, NEW.updated_at, NEW.deleted_at, clock_timestamp() AT TIME ZONE 'UTC');
+			RETURN NULL;
+		END;
+		$$ LANGUAGE plpgsql;
+	`
+
+	parentMergesDownSQL = `
+		CREATE OR REPLACE FUNCTION record_parent_history() RETURNS trigger AS $$
+		BEGIN
+			IF TG_OP = 'DELETE' THEN
+				DELETE FROM parent_history WHERE id = OLD.id;
+				RETURN NULL;
+			END IF;
+			INSERT INTO parent_history (id, first_name, last_name, email, birth_date, user_id, contact_details, tenant_id, version, created_at, updated_at, deleted_at, recorded_at)
+			VALUES (NEW.id, NEW.first_name, NEW.last_name, NEW.email, NEW.birth_date, NEW.user_id, NEW.contact_details, NEW.tenant_id, NEW.version, NEW.created_at, NEW.updated_at, NEW.deleted_at, clock_timestamp() AT TIME ZONE 'UTC');
+			RETURN NULL;
+		END;
+		$$ LANGUAGE plpgsql;
+
+		ALTER TABLE parent_history DROP COLUMN IF EXISTS merged_into;
+		ALTER TABLE parents DROP COLUMN IF EXISTS merged_into;
+	`
+)
+
+// Up runs the migration
+func (m *ParentMergesMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running parent merges migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, parentMergesUpSQL)
+	if err != nil {
+		m.logger.Error("Failed to add parent merges", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Parent merges migration for PostgreSQL completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *ParentMergesMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back parent merges migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, parentMergesDownSQL)
+	if err != nil {
+		m.logger.Error("Failed to drop parent merges", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Parent merges migration for PostgreSQL rolled back successfully")
+	return nil
+}
+
+// Definition returns the SQL that applies and reverts the migration
+func (m *ParentMergesMigration) Definition() string {
+	return parentMergesUpSQL + parentMergesDownSQL
+}
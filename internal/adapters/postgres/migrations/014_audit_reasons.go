@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// AuditReasonsMigration records the reason given for a change, such as the transfer of a child, in the audit log
+type AuditReasonsMigration struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewAuditReasonsMigration creates a new audit reasons migration
+func NewAuditReasonsMigration(pool *pgxpool.Pool, logger *zap.Logger) *AuditReasonsMigration {
+	return &AuditReasonsMigration{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// The SQL that applies and reverts the migration
+const (
+	// Adding a column does not fire the trigger that rejects updates of the audit log
+	auditReasonsUpSQL = `
+		ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS reason TEXT NOT NULL DEFAULT '';
+	`
+
+	auditReasonsDownSQL = `
+		ALTER TABLE audit_log DROP COLUMN IF EXISTS reason;
+	`
+)
+
+// Up runs the migration
+func (m *AuditReasonsMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running audit reasons migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, auditReasonsUpSQL)
+	if err != nil {
+		m.logger.Error("Failed to add audit reasons", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Audit reasons migration for PostgreSQL completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *AuditReasonsMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back audit reasons migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, auditReasonsDownSQL)
+	if err != nil {
+		m.logger.Error("Failed to drop audit reasons", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Audit reasons migration for PostgreSQL rolled back successfully")
+	return nil
+}
+
+// Definition returns the SQL that applies and reverts the migration
+func (m *AuditReasonsMigration) Definition() string {
+	return auditReasonsUpSQL + auditReasonsDownSQL
+}
@@ -0,0 +1,96 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// OutboxLeasesMigration adds the leases of the messages of the outbox, which let several relays deliver
+// them, and the dead letters of the outbox, where the messages that cannot be delivered are quarantined
+type OutboxLeasesMigration struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewOutboxLeasesMigration creates a new outbox leases migration
+func NewOutboxLeasesMigration(pool *pgxpool.Pool, logger *zap.Logger) *OutboxLeasesMigration {
+	return &OutboxLeasesMigration{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// The SQL that applies and reverts the migration
+const (
+	// The relays look up the earliest message of every family, and the due messages
+	outboxLeasesUpSQL = `
+		ALTER TABLE outbox ADD COLUMN IF NOT EXISTS leased_until TIMESTAMP;
+
+		CREATE INDEX IF NOT EXISTS idx_outbox_aggregate_id ON outbox(aggregate_id, sequence);
+		CREATE INDEX IF NOT EXISTS idx_outbox_next_attempt_at ON outbox(next_attempt_at);
+
+		CREATE TABLE IF NOT EXISTS outbox_dead_letters (
+			sequence BIGINT PRIMARY KEY,
+			event_id UUID NOT NULL,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			aggregate_id UUID NOT NULL,
+			event_type TEXT NOT NULL,
+			payload JSONB NOT NULL,
+			occurred_at TIMESTAMP NOT NULL,
+			attempts INTEGER NOT NULL,
+			last_error TEXT,
+			reason TEXT NOT NULL,
+			quarantined_at TIMESTAMP NOT NULL
+		);
+
+		ALTER TABLE outbox_dead_letters ENABLE ROW LEVEL SECURITY;
+		ALTER TABLE outbox_dead_letters FORCE ROW LEVEL SECURITY;
+
+		DROP POLICY IF EXISTS tenant_isolation ON outbox_dead_letters;
+		CREATE POLICY tenant_isolation ON outbox_dead_letters
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+	`
+
+	outboxLeasesDownSQL = `
+		DROP TABLE IF EXISTS outbox_dead_letters;
+		DROP INDEX IF EXISTS idx_outbox_next_attempt_at;
+		DROP INDEX IF EXISTS idx_outbox_aggregate_id;
+		ALTER TABLE outbox DROP COLUMN IF EXISTS leased_until;
+	`
+)
+
+// Up runs the migration
+func (m *OutboxLeasesMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running outbox leases migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, outboxLeasesUpSQL)
+	if err != nil {
+		m.logger.Error("Failed to add outbox leases and dead letters", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Outbox leases migration for PostgreSQL completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *OutboxLeasesMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back outbox leases migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, outboxLeasesDownSQL)
+	if err != nil {
+		m.logger.Error("Failed to drop outbox leases and dead letters", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Outbox leases migration for PostgreSQL rolled back successfully")
+	return nil
+}
+
+// Definition returns the SQL that applies and reverts the migration
+func (m *OutboxLeasesMigration) Definition() string {
+	return outboxLeasesUpSQL + outboxLeasesDownSQL
+}
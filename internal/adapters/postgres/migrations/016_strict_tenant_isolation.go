@@ -0,0 +1,196 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// StrictTenantIsolationMigration makes the tenant isolation policies admit the rows of the tenant the
+// connection is scoped to only. The policies used to admit every row to a connection scoped to no tenant,
+// or to the default tenant, whose ID is empty; a connection must now ask for every tenant explicitly,
+// with app.all_tenants, as the outbox relay, the webhook dispatcher and the migrations do.
+type StrictTenantIsolationMigration struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewStrictTenantIsolationMigration creates a new strict tenant isolation migration
+func NewStrictTenantIsolationMigration(pool *pgxpool.Pool, logger *zap.Logger) *StrictTenantIsolationMigration {
+	return &StrictTenantIsolationMigration{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// The SQL that applies and reverts the migration
+const (
+	// A setting that is not set reads as NULL, which matches no tenant
+	strictTenantIsolationUpSQL = `
+		DROP POLICY IF EXISTS tenant_isolation ON parents;
+		CREATE POLICY tenant_isolation ON parents
+			USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on')
+			WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on');
+
+		DROP POLICY IF EXISTS tenant_isolation ON children;
+		CREATE POLICY tenant_isolation ON children
+			USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on')
+			WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on');
+
+		DROP POLICY IF EXISTS tenant_isolation ON outbox;
+		CREATE POLICY tenant_isolation ON outbox
+			USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on')
+			WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on');
+
+		DROP POLICY IF EXISTS tenant_isolation ON outbox_dead_letters;
+		CREATE POLICY tenant_isolation ON outbox_dead_letters
+			USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on')
+			WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on');
+
+		DROP POLICY IF EXISTS tenant_isolation ON webhook_subscriptions;
+		CREATE POLICY tenant_isolation ON webhook_subscriptions
+			USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on')
+			WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on');
+
+		DROP POLICY IF EXISTS tenant_isolation ON webhook_deliveries;
+		CREATE POLICY tenant_isolation ON webhook_deliveries
+			USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on')
+			WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on');
+
+		DROP POLICY IF EXISTS tenant_isolation ON audit_log;
+		CREATE POLICY tenant_isolation ON audit_log
+			USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on')
+			WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on');
+
+		DROP POLICY IF EXISTS tenant_isolation ON parent_history;
+		CREATE POLICY tenant_isolation ON parent_history
+			USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on')
+			WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on');
+
+		DROP POLICY IF EXISTS tenant_isolation ON child_history;
+		CREATE POLICY tenant_isolation ON child_history
+			USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on')
+			WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on');
+
+		DROP POLICY IF EXISTS tenant_isolation ON guardianships;
+		CREATE POLICY tenant_isolation ON guardianships
+			USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on')
+			WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on');
+
+		DROP POLICY IF EXISTS tenant_isolation ON households;
+		CREATE POLICY tenant_isolation ON households
+			USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on')
+			WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on');
+
+		DROP POLICY IF EXISTS tenant_isolation ON household_parents;
+		CREATE POLICY tenant_isolation ON household_parents
+			USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on')
+			WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on');
+
+		DROP POLICY IF EXISTS tenant_isolation ON household_children;
+		CREATE POLICY tenant_isolation ON household_children
+			USING (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on')
+			WITH CHECK (tenant_id = current_setting('app.tenant_id', true) OR current_setting('app.all_tenants', true) = 'on');
+	`
+
+	strictTenantIsolationDownSQL = `
+		DROP POLICY IF EXISTS tenant_isolation ON parents;
+		CREATE POLICY tenant_isolation ON parents
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+
+		DROP POLICY IF EXISTS tenant_isolation ON children;
+		CREATE POLICY tenant_isolation ON children
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+
+		DROP POLICY IF EXISTS tenant_isolation ON outbox;
+		CREATE POLICY tenant_isolation ON outbox
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+
+		DROP POLICY IF EXISTS tenant_isolation ON outbox_dead_letters;
+		CREATE POLICY tenant_isolation ON outbox_dead_letters
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+
+		DROP POLICY IF EXISTS tenant_isolation ON webhook_subscriptions;
+		CREATE POLICY tenant_isolation ON webhook_subscriptions
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+
+		DROP POLICY IF EXISTS tenant_isolation ON webhook_deliveries;
+		CREATE POLICY tenant_isolation ON webhook_deliveries
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+
+		DROP POLICY IF EXISTS tenant_isolation ON audit_log;
+		CREATE POLICY tenant_isolation ON audit_log
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+
+		DROP POLICY IF EXISTS tenant_isolation ON parent_history;
+		CREATE POLICY tenant_isolation ON parent_history
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+
+		DROP POLICY IF EXISTS tenant_isolation ON child_history;
+		CREATE POLICY tenant_isolation ON child_history
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+
+		DROP POLICY IF EXISTS tenant_isolation ON guardianships;
+		CREATE POLICY tenant_isolation ON guardianships
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+
+		DROP POLICY IF EXISTS tenant_isolation ON households;
+		CREATE POLICY tenant_isolation ON households
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+
+		DROP POLICY IF EXISTS tenant_isolation ON household_parents;
+		CREATE POLICY tenant_isolation ON household_parents
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+
+		DROP POLICY IF EXISTS tenant_isolation ON household_children;
+		CREATE POLICY tenant_isolation ON household_children
+			USING (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id))
+			WITH CHECK (tenant_id = COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), tenant_id));
+	`
+)
+
+// Up runs the migration
+func (m *StrictTenantIsolationMigration) Up(ctx context.Context) error {
+	m.logger.Info("Running strict tenant isolation migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, strictTenantIsolationUpSQL)
+	if err != nil {
+		m.logger.Error("Failed to make the tenant isolation policies strict", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Strict tenant isolation migration for PostgreSQL completed successfully")
+	return nil
+}
+
+// Down rolls back the migration
+func (m *StrictTenantIsolationMigration) Down(ctx context.Context) error {
+	m.logger.Info("Rolling back strict tenant isolation migration for PostgreSQL")
+
+	_, err := m.pool.Exec(ctx, strictTenantIsolationDownSQL)
+	if err != nil {
+		m.logger.Error("Failed to restore the tenant isolation policies", zap.Error(err))
+		return err
+	}
+
+	m.logger.Info("Strict tenant isolation migration for PostgreSQL rolled back successfully")
+	return nil
+}
+
+// Definition returns the SQL that applies and reverts the migration
+func (m *StrictTenantIsolationMigration) Definition() string {
+	return strictTenantIsolationUpSQL + strictTenantIsolationDownSQL
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -12,21 +14,67 @@ import (
 
 // Migration represents a database migration
 type Migration struct {
-	Version     int       `json:"version"`
-	Description string    `json:"description"`
-	AppliedAt   time.Time `json:"applied_at"`
+	Version     int           `json:"version"`
+	Description string        `json:"description"`
+	AppliedAt   time.Time     `json:"applied_at"`
+	Duration    time.Duration `json:"duration"`
+
+	// Checksum is the checksum of the definition of the migration when it was applied, or empty for
+	// migrations applied before checksums were recorded
+	Checksum string `json:"checksum"`
 }
 
 // MigrationFunc is a function that performs a migration
 type MigrationFunc func(ctx context.Context, pool *pgxpool.Pool) error
 
-// MigrationDefinition defines a migration with its version, description, and function
+// MigrationDefinition defines a migration with its version, description, the checksum of its definition,
+// and the functions that apply and revert it
 type MigrationDefinition struct {
 	Version     int
 	Description string
-	Migrate     MigrationFunc
+	Checksum    string
+	Up          MigrationFunc
+	Down        MigrationFunc
+}
+
+// MigrationStatus is the state of a migration that is registered, applied, or both
+type MigrationStatus struct {
+	Version     int
+	Description string
+
+	// Applied reports whether the migration is applied, in which case AppliedAt and Duration tell when
+	// it was applied and how long it took
+	Applied   bool
+	AppliedAt time.Time
+	Duration  time.Duration
+
+	// Registered is false for an applied migration this version of the service does not know, such as
+	// one applied by a newer version
+	Registered bool
+
+	// Drifted reports whether the definition of an applied migration changed after it was applied
+	Drifted bool
+}
+
+// DriftError is returned when the definitions of applied migrations changed after they were applied,
+// so that the database may not have the schema they now define
+type DriftError struct {
+	Versions []int
+}
+
+// Error returns the message of the drift error
+func (e *DriftError) Error() string {
+	versions := make([]string, len(e.Versions))
+	for i, version := range e.Versions {
+		versions[i] = strconv.Itoa(version)
+	}
+	return fmt.Sprintf("migrations %s changed after they were applied; restore them, or force the current version to accept their definitions",
+		strings.Join(versions, ", "))
 }
 
+// migrationLockKey is the key of the advisory lock that keeps processes from running migrations at the same time
+const migrationLockKey int64 = 0x66616d696c79
+
 // MigrationManager manages database migrations
 type MigrationManager struct {
 	pool       *pgxpool.Pool
@@ -43,24 +91,31 @@ func NewMigrationManager(pool *pgxpool.Pool, logger *zap.Logger) *MigrationManag
 	}
 }
 
-// RegisterMigration registers a migration
-func (m *MigrationManager) RegisterMigration(version int, description string, migrate MigrationFunc) {
+// RegisterMigration registers a migration with the checksum of its definition, and the functions that apply
+// and revert it. An empty checksum is never reported as drifted.
+func (m *MigrationManager) RegisterMigration(version int, description, checksum string, up, down MigrationFunc) {
 	m.migrations[version] = MigrationDefinition{
 		Version:     version,
 		Description: description,
-		Migrate:     migrate,
+		Checksum:    checksum,
+		Up:          up,
+		Down:        down,
 	}
 }
 
 // EnsureMigrationsTable ensures that the migrations table exists
 func (m *MigrationManager) EnsureMigrationsTable(ctx context.Context) error {
-	// Create migrations table if it doesn't exist
+	// Create migrations table if it doesn't exist; tables created before durations and checksums were recorded
+	// gain the columns
 	createTableSQL := `
 		CREATE TABLE IF NOT EXISTS migrations (
 			version INT PRIMARY KEY,
 			description TEXT NOT NULL,
 			applied_at TIMESTAMP NOT NULL
 		);
+
+		ALTER TABLE migrations ADD COLUMN IF NOT EXISTS duration_ms BIGINT NOT NULL DEFAULT 0;
+		ALTER TABLE migrations ADD COLUMN IF NOT EXISTS checksum TEXT NOT NULL DEFAULT '';
 	`
 
 	_, err := m.pool.Exec(ctx, createTableSQL)
@@ -71,10 +126,10 @@ func (m *MigrationManager) EnsureMigrationsTable(ctx context.Context) error {
 	return nil
 }
 
-// GetAppliedMigrations gets all applied migrations
+// GetAppliedMigrations gets all applied migrations, with when they were applied and how long they took
 func (m *MigrationManager) GetAppliedMigrations(ctx context.Context) ([]Migration, error) {
 	// Get all migrations from the migrations table
-	rows, err := m.pool.Query(ctx, "SELECT version, description, applied_at FROM migrations ORDER BY version")
+	rows, err := m.pool.Query(ctx, "SELECT version, description, applied_at, duration_ms, checksum FROM migrations ORDER BY version")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
 	}
@@ -84,9 +139,11 @@ func (m *MigrationManager) GetAppliedMigrations(ctx context.Context) ([]Migratio
 	var migrations []Migration
 	for rows.Next() {
 		var migration Migration
-		if err := rows.Scan(&migration.Version, &migration.Description, &migration.AppliedAt); err != nil {
+		var durationMs int64
+		if err := rows.Scan(&migration.Version, &migration.Description, &migration.AppliedAt, &durationMs, &migration.Checksum); err != nil {
 			return nil, fmt.Errorf("failed to decode migration: %w", err)
 		}
+		migration.Duration = time.Duration(durationMs) * time.Millisecond
 		migrations = append(migrations, migration)
 	}
 
@@ -97,80 +154,403 @@ func (m *MigrationManager) GetAppliedMigrations(ctx context.Context) ([]Migratio
 	return migrations, nil
 }
 
-// MigrateUp applies all pending migrations
-func (m *MigrationManager) MigrateUp(ctx context.Context) error {
+// Status gets the state of every registered or applied migration, by version
+func (m *MigrationManager) Status(ctx context.Context) ([]MigrationStatus, error) {
 	// Ensure migrations table exists
 	if err := m.EnsureMigrationsTable(ctx); err != nil {
-		return err
+		return nil, err
 	}
 
-	// Get applied migrations
 	appliedMigrations, err := m.GetAppliedMigrations(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Create a map of applied migrations for quick lookup
-	appliedMap := make(map[int]bool)
+	drifted := make(map[int]bool)
+	for _, version := range m.drift(appliedMigrations) {
+		drifted[version] = true
+	}
+
+	statuses := make(map[int]*MigrationStatus)
+	for _, version := range m.versions() {
+		migration := m.migrations[version]
+		statuses[version] = &MigrationStatus{Version: version, Description: migration.Description, Registered: true}
+	}
 	for _, migration := range appliedMigrations {
-		appliedMap[migration.Version] = true
+		status, ok := statuses[migration.Version]
+		if !ok {
+			status = &MigrationStatus{Version: migration.Version, Description: migration.Description}
+			statuses[migration.Version] = status
+		}
+		status.Applied = true
+		status.AppliedAt = migration.AppliedAt
+		status.Duration = migration.Duration
+		status.Drifted = drifted[migration.Version]
 	}
 
-	// Get all migration versions
-	var versions []int
-	for version := range m.migrations {
-		versions = append(versions, version)
+	result := make([]MigrationStatus, 0, len(statuses))
+	for _, status := range statuses {
+		result = append(result, *status)
 	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result, nil
+}
 
-	// Sort versions
-	sort.Ints(versions)
+// MigrateUp applies all pending migrations
+func (m *MigrationManager) MigrateUp(ctx context.Context) error {
+	return m.Up(ctx, 0)
+}
 
-	// Apply pending migrations
-	for _, version := range versions {
+// Up applies the first n pending migrations, or all of them if n is 0, in the order of their versions
+func (m *MigrationManager) Up(ctx context.Context, n int) error {
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	appliedMigrations, err := m.prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	appliedMap := make(map[int]bool, len(appliedMigrations))
+	for _, migration := range appliedMigrations {
+		appliedMap[migration.Version] = true
+	}
+
+	applied := 0
+	for _, version := range m.versions() {
 		// Skip if already applied
 		if appliedMap[version] {
 			m.logger.Info("Migration already applied", zap.Int("version", version))
 			continue
 		}
+		if n > 0 && applied == n {
+			break
+		}
 
-		migration := m.migrations[version]
-		m.logger.Info("Applying migration", zap.Int("version", version), zap.String("description", migration.Description))
+		if err := m.apply(ctx, m.migrations[version]); err != nil {
+			return err
+		}
+		applied++
+	}
 
-		// Begin transaction
-		tx, err := m.pool.Begin(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to begin transaction for migration %d: %w", version, err)
+	if applied == 0 {
+		m.logger.Info("No pending migrations")
+	}
+	return nil
+}
+
+// Down reverts the n latest applied migrations, latest first
+func (m *MigrationManager) Down(ctx context.Context, n int) error {
+	if n <= 0 {
+		return fmt.Errorf("the number of migrations to revert must be positive")
+	}
+
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	appliedMigrations, err := m.prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := len(appliedMigrations) - 1; i >= 0 && n > 0; i-- {
+		if err := m.revert(ctx, appliedMigrations[i].Version); err != nil {
+			return err
+		}
+		n--
+	}
+
+	return nil
+}
+
+// Goto applies or reverts migrations until the applied migrations are those up to the given version:
+// the pending migrations up to it are applied, and the applied migrations after it are reverted.
+// Version 0 reverts all migrations.
+func (m *MigrationManager) Goto(ctx context.Context, version int) error {
+	if _, ok := m.migrations[version]; !ok && version != 0 {
+		return fmt.Errorf("migration %d does not exist", version)
+	}
+
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	appliedMigrations, err := m.prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Revert the later migrations first, latest first
+	appliedMap := make(map[int]bool)
+	for i := len(appliedMigrations) - 1; i >= 0; i-- {
+		applied := appliedMigrations[i].Version
+		if applied <= version {
+			appliedMap[applied] = true
+			continue
 		}
+		if err := m.revert(ctx, applied); err != nil {
+			return err
+		}
+	}
 
-		// Apply migration
-		if err := migration.Migrate(ctx, m.pool); err != nil {
-			// Rollback transaction
-			if rbErr := tx.Rollback(ctx); rbErr != nil {
-				m.logger.Error("Failed to rollback transaction", zap.Error(rbErr))
-			}
-			return fmt.Errorf("failed to apply migration %d: %w", version, err)
+	for _, pending := range m.versions() {
+		if pending > version {
+			break
+		}
+		if appliedMap[pending] {
+			continue
+		}
+		if err := m.apply(ctx, m.migrations[pending]); err != nil {
+			return err
 		}
+	}
 
-		// Record migration
-		_, err = tx.Exec(ctx, `
-			INSERT INTO migrations (version, description, applied_at)
-			VALUES ($1, $2, $3)
-		`, version, migration.Description, time.Now().UTC())
+	return nil
+}
+
+// Redo reverts the latest applied migration and applies it again
+func (m *MigrationManager) Redo(ctx context.Context) error {
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	appliedMigrations, err := m.prepare(ctx)
+	if err != nil {
+		return err
+	}
+	if len(appliedMigrations) == 0 {
+		return fmt.Errorf("no migration is applied")
+	}
+
+	version := appliedMigrations[len(appliedMigrations)-1].Version
+	if err := m.revert(ctx, version); err != nil {
+		return err
+	}
+	return m.apply(ctx, m.migrations[version])
+}
+
+// Force records the migrations up to the given version as applied, with the checksums of their current
+// definitions, and those after it as not applied, without applying or reverting any of them. It repairs
+// the migrations table after a migration failed halfway and the database was fixed by hand, and accepts
+// the definitions of drifted migrations once the database matches them.
+func (m *MigrationManager) Force(ctx context.Context, version int) error {
+	if _, ok := m.migrations[version]; !ok && version != 0 {
+		return fmt.Errorf("migration %d does not exist", version)
+	}
+
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Ensure migrations table exists
+	if err := m.EnsureMigrationsTable(ctx); err != nil {
+		return err
+	}
+
+	// Begin transaction
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction to force version %d: %w", version, err)
+	}
+	defer func() {
+		// Rolling back a committed transaction does nothing
+		_ = tx.Rollback(ctx)
+	}()
+
+	if _, err := tx.Exec(ctx, "DELETE FROM migrations WHERE version > $1", version); err != nil {
+		return fmt.Errorf("failed to force version %d: %w", version, err)
+	}
+	for _, registered := range m.versions() {
+		if registered > version {
+			break
+		}
+		_, err := tx.Exec(ctx, `
+			INSERT INTO migrations (version, description, applied_at, duration_ms, checksum)
+			VALUES ($1, $2, $3, 0, $4)
+			ON CONFLICT (version) DO UPDATE SET checksum = EXCLUDED.checksum
+		`, registered, m.migrations[registered].Description, time.Now().UTC(), m.migrations[registered].Checksum)
 		if err != nil {
-			// Rollback transaction
-			if rbErr := tx.Rollback(ctx); rbErr != nil {
-				m.logger.Error("Failed to rollback transaction", zap.Error(rbErr))
-			}
-			return fmt.Errorf("failed to record migration %d: %w", version, err)
+			return fmt.Errorf("failed to force version %d: %w", version, err)
+		}
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction to force version %d: %w", version, err)
+	}
+
+	m.logger.Warn("Forced migration version", zap.Int("version", version))
+	return nil
+}
+
+// versions returns the versions of the registered migrations in ascending order
+func (m *MigrationManager) versions() []int {
+	versions := make([]int, 0, len(m.migrations))
+	for version := range m.migrations {
+		versions = append(versions, version)
+	}
+	sort.Ints(versions)
+	return versions
+}
+
+// lock takes the advisory lock of migrations, waiting for another process that holds it to release it,
+// and returns the function that releases it. The lock belongs to the session of a connection, which is
+// kept out of the pool until the lock is released.
+func (m *MigrationManager) lock(ctx context.Context) (func(), error) {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire a connection for the migration lock: %w", err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", migrationLockKey).Scan(&acquired); err != nil {
+		conn.Release()
+		return nil, fmt.Errorf("failed to acquire the migration lock: %w", err)
+	}
+	if !acquired {
+		m.logger.Info("Waiting for another process to finish its migrations")
+		if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+			conn.Release()
+			return nil, fmt.Errorf("failed to acquire the migration lock: %w", err)
+		}
+	}
+
+	return func() {
+		// The lock is released even when the migrations were interrupted
+		unlockCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+
+		if _, err := conn.Exec(unlockCtx, "SELECT pg_advisory_unlock($1)", migrationLockKey); err != nil {
+			// Closing the connection ends its session, and so releases the lock
+			m.logger.Error("Failed to release the migration lock", zap.Error(err))
+			_ = conn.Conn().Close(unlockCtx)
+		}
+		conn.Release()
+	}, nil
+}
+
+// prepare ensures that the migrations table exists, records the checksums of the migrations applied before
+// checksums were recorded, and returns the applied migrations, or a DriftError if the definitions of some
+// of them changed after they were applied
+func (m *MigrationManager) prepare(ctx context.Context) ([]Migration, error) {
+	// Ensure migrations table exists
+	if err := m.EnsureMigrationsTable(ctx); err != nil {
+		return nil, err
+	}
+
+	appliedMigrations, err := m.GetAppliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, migration := range appliedMigrations {
+		definition, ok := m.migrations[migration.Version]
+		if migration.Checksum != "" || !ok || definition.Checksum == "" {
+			continue
+		}
+		if _, err := m.pool.Exec(ctx, "UPDATE migrations SET checksum = $2 WHERE version = $1", migration.Version, definition.Checksum); err != nil {
+			return nil, fmt.Errorf("failed to record the checksum of migration %d: %w", migration.Version, err)
+		}
+		appliedMigrations[i].Checksum = definition.Checksum
+		m.logger.Info("Recorded the checksum of migration", zap.Int("version", migration.Version))
+	}
+
+	if drifted := m.drift(appliedMigrations); len(drifted) > 0 {
+		return nil, &DriftError{Versions: drifted}
+	}
+	return appliedMigrations, nil
+}
+
+// drift returns the versions of the applied migrations whose definitions changed after they were applied
+func (m *MigrationManager) drift(appliedMigrations []Migration) []int {
+	var drifted []int
+	for _, migration := range appliedMigrations {
+		definition, ok := m.migrations[migration.Version]
+		if !ok || migration.Checksum == "" || definition.Checksum == "" {
+			continue
+		}
+		if migration.Checksum != definition.Checksum {
+			drifted = append(drifted, migration.Version)
 		}
+	}
+	return drifted
+}
+
+// apply applies a migration and records it, with how long it took
+func (m *MigrationManager) apply(ctx context.Context, migration MigrationDefinition) error {
+	version := migration.Version
+	m.logger.Info("Applying migration", zap.Int("version", version), zap.String("description", migration.Description))
+
+	// Begin transaction
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for migration %d: %w", version, err)
+	}
 
-		// Commit transaction
-		if err := tx.Commit(ctx); err != nil {
-			return fmt.Errorf("failed to commit transaction for migration %d: %w", version, err)
+	// Apply migration
+	start := time.Now()
+	if err := migration.Up(ctx, m.pool); err != nil {
+		// Rollback transaction
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			m.logger.Error("Failed to rollback transaction", zap.Error(rbErr))
 		}
+		return fmt.Errorf("failed to apply migration %d: %w", version, err)
+	}
+	duration := time.Since(start)
 
-		m.logger.Info("Migration applied successfully", zap.Int("version", version))
+	// Record migration
+	_, err = tx.Exec(ctx, `
+		INSERT INTO migrations (version, description, applied_at, duration_ms, checksum)
+		VALUES ($1, $2, $3, $4, $5)
+	`, version, migration.Description, time.Now().UTC(), duration.Milliseconds(), migration.Checksum)
+	if err != nil {
+		// Rollback transaction
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			m.logger.Error("Failed to rollback transaction", zap.Error(rbErr))
+		}
+		return fmt.Errorf("failed to record migration %d: %w", version, err)
 	}
 
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction for migration %d: %w", version, err)
+	}
+
+	m.logger.Info("Migration applied successfully", zap.Int("version", version), zap.Duration("duration", duration))
 	return nil
-}
\ No newline at end of file
+}
+
+// revert reverts an applied migration and removes its record
+func (m *MigrationManager) revert(ctx context.Context, version int) error {
+	migration, ok := m.migrations[version]
+	if !ok {
+		return fmt.Errorf("migration %d is applied but unknown to this version of the service, so it cannot be reverted", version)
+	}
+	m.logger.Info("Reverting migration", zap.Int("version", version), zap.String("description", migration.Description))
+
+	// Revert migration
+	start := time.Now()
+	if err := migration.Down(ctx, m.pool); err != nil {
+		return fmt.Errorf("failed to revert migration %d: %w", version, err)
+	}
+
+	// Remove the record of the migration
+	if _, err := m.pool.Exec(ctx, "DELETE FROM migrations WHERE version = $1", version); err != nil {
+		return fmt.Errorf("failed to remove the record of migration %d: %w", version, err)
+	}
+
+	m.logger.Info("Migration reverted successfully", zap.Int("version", version), zap.Duration("duration", time.Since(start)))
+	return nil
+}
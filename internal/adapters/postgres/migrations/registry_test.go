@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRegistry_Checksums(t *testing.T) {
+	manager := NewRegistry(nil, zap.NewNop()).Manager()
+
+	checksums := make(map[string]int)
+	for _, version := range manager.versions() {
+		migration := manager.migrations[version]
+		assert.Len(t, migration.Checksum, 64, "migration %d has no checksum", version)
+		assert.NotContains(t, checksums, migration.Checksum, "migrations %d and %d have the same checksum", checksums[migration.Checksum], version)
+		checksums[migration.Checksum] = version
+	}
+	assert.Equal(t, checksum(NewInitialSchemaMigration(nil, zap.NewNop()).Definition()), manager.migrations[1].Checksum)
+}
+
+func TestChecksum(t *testing.T) {
+	assert.Len(t, checksum("up"), 64)
+	assert.NotEqual(t, checksum("up"), checksum("down"))
+	assert.Equal(t, checksum("\n\t\tup one\r\n\t\tdown\n"), checksum("up  one down"), "white space is no change")
+}
+
+func TestMigrationManager_Drift(t *testing.T) {
+	// Arrange
+	manager := NewMigrationManager(nil, zap.NewNop())
+	for version, sum := range map[int]string{1: "one", 2: "two", 3: "three", 4: ""} {
+		manager.RegisterMigration(version, "", sum, nil, nil)
+	}
+	applied := []Migration{
+		{Version: 1, Checksum: "one"},
+		{Version: 2, Checksum: "edited"},
+		{Version: 3},                    // applied before checksums were recorded
+		{Version: 4, Checksum: "four"},  // registered without a checksum
+		{Version: 5, Checksum: "newer"}, // applied by a newer version of the service
+	}
+
+	// Act
+	drifted := manager.drift(applied)
+
+	// Assert
+	assert.Equal(t, []int{2}, drifted)
+	assert.EqualError(t, &DriftError{Versions: []int{2, 7}},
+		"migrations 2, 7 changed after they were applied; restore them, or force the current version to accept their definitions")
+}
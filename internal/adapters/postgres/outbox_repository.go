@@ -0,0 +1,247 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// OutboxRepository implements the ports.OutboxRepository interface for PostgreSQL
+type OutboxRepository struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+	tracer trace.Tracer
+}
+
+// NewOutboxRepository creates a new PostgreSQL outbox repository
+func NewOutboxRepository(pool *pgxpool.Pool, logger *zap.Logger) *OutboxRepository {
+	return &OutboxRepository{
+		pool:   pool,
+		logger: logger,
+		tracer: otel.Tracer("postgres.outbox_repository"),
+	}
+}
+
+// Append records an event within the transaction carried by ctx.
+// The sequence of a message is taken when it is inserted, but becomes visible when the transaction
+// commits, so the transactions that record events of the same family are serialized with an advisory
+// lock to make the sequence follow the commit order within the family.
+func (r *OutboxRepository) Append(ctx context.Context, event domain.Event) error {
+	ctx, span := r.tracer.Start(ctx, "OutboxRepository.Append")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("event.id", event.ID.String()),
+		attribute.String("event.type", string(event.Type)),
+	)
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	q := conn(ctx, r.pool)
+
+	_, err = q.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, event.ParentID.String())
+	if err != nil {
+		r.logger.Error("Failed to lock the outbox of the family", zap.Error(err), zap.String("parent_id", event.ParentID.String()))
+		return fmt.Errorf("failed to lock outbox: %w", err)
+	}
+
+	query := `
+		INSERT INTO outbox (event_id, tenant_id, aggregate_id, event_type, payload, occurred_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+	`
+
+	_, err = q.Exec(ctx, query, event.ID, event.TenantID, event.ParentID, string(event.Type), payload, event.OccurredAt)
+	if err != nil {
+		r.logger.Error("Failed to append event to outbox", zap.Error(err), zap.String("event_id", event.ID.String()))
+		return fmt.Errorf("failed to append event to outbox: %w", err)
+	}
+
+	return nil
+}
+
+// LeasePending leases up to limit undelivered messages of all tenants until leaseUntil, ordered by sequence.
+// The earliest message of every family that is due and not leased is locked, skipping those that another
+// relay is leasing, and the messages of the locked families are leased in the same transaction, so that
+// a family is relayed by one relay at a time. The later messages of a family are never leased on their own,
+// as an earlier message of their family is either leased or waiting for its next attempt.
+// It works across tenants, as do the other methods the relay calls.
+func (r *OutboxRepository) LeasePending(ctx context.Context, limit int, now, leaseUntil time.Time) ([]ports.OutboxMessage, error) {
+	ctx, span := r.tracer.Start(withAllTenants(ctx), "OutboxRepository.LeasePending")
+	defer span.End()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		r.logger.Error("Failed to begin transaction to lease outbox messages", zap.Error(err))
+		return nil, fmt.Errorf("failed to begin transaction to lease outbox messages: %w", err)
+	}
+	defer func() {
+		// Rolling back a committed transaction does nothing
+		_ = tx.Rollback(ctx)
+	}()
+
+	headsQuery := `
+		SELECT o.aggregate_id
+		FROM outbox o
+		WHERE o.next_attempt_at <= $1
+			AND (o.leased_until IS NULL OR o.leased_until <= $1)
+			AND NOT EXISTS (SELECT 1 FROM outbox e WHERE e.aggregate_id = o.aggregate_id AND e.sequence < o.sequence)
+		ORDER BY o.sequence
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := tx.Query(ctx, headsQuery, now.UTC(), limit)
+	if err != nil {
+		r.logger.Error("Failed to lock pending outbox messages", zap.Error(err))
+		return nil, fmt.Errorf("failed to lock pending outbox messages: %w", err)
+	}
+	families, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		r.logger.Error("Failed to scan pending outbox messages", zap.Error(err))
+		return nil, fmt.Errorf("failed to scan pending outbox messages: %w", err)
+	}
+	if len(families) == 0 {
+		return nil, nil
+	}
+
+	leaseQuery := `
+		UPDATE outbox
+		SET leased_until = $3
+		WHERE sequence IN (
+			SELECT sequence FROM outbox WHERE aggregate_id = ANY($1) ORDER BY sequence LIMIT $2
+		)
+		RETURNING sequence, payload, attempts, next_attempt_at, COALESCE(last_error, '')
+	`
+
+	rows, err = tx.Query(ctx, leaseQuery, families, limit, leaseUntil.UTC())
+	if err != nil {
+		r.logger.Error("Failed to lease pending outbox messages", zap.Error(err))
+		return nil, fmt.Errorf("failed to lease pending outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []ports.OutboxMessage
+	var undecodable []int64
+	for rows.Next() {
+		var message ports.OutboxMessage
+		var payload []byte
+		if err := rows.Scan(&message.Sequence, &payload, &message.Attempts, &message.NextAttemptAt, &message.LastError); err != nil {
+			r.logger.Error("Failed to scan outbox message", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+		if err := json.Unmarshal(payload, &message.Event); err != nil {
+			r.logger.Error("Failed to decode outbox message, quarantining it", zap.Error(err), zap.Int64("sequence", message.Sequence))
+			undecodable = append(undecodable, message.Sequence)
+			continue
+		}
+		message.NextAttemptAt = message.NextAttemptAt.UTC()
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating outbox messages", zap.Error(err))
+		return nil, fmt.Errorf("error iterating outbox messages: %w", err)
+	}
+
+	for _, sequence := range undecodable {
+		if err := r.quarantine(ctx, tx, sequence, "payload cannot be decoded"); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.logger.Error("Failed to commit the lease of outbox messages", zap.Error(err))
+		return nil, fmt.Errorf("failed to commit the lease of outbox messages: %w", err)
+	}
+
+	sort.Slice(messages, func(i, j int) bool { return messages[i].Sequence < messages[j].Sequence })
+	return messages, nil
+}
+
+// Acknowledge removes a delivered message from the outbox
+func (r *OutboxRepository) Acknowledge(ctx context.Context, sequence int64) error {
+	ctx, span := r.tracer.Start(withAllTenants(ctx), "OutboxRepository.Acknowledge")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("outbox.sequence", sequence))
+
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM outbox WHERE sequence = $1`, sequence)
+	if err != nil {
+		r.logger.Error("Failed to acknowledge outbox message", zap.Error(err), zap.Int64("sequence", sequence))
+		return fmt.Errorf("failed to acknowledge outbox message %d: %w", sequence, err)
+	}
+
+	return nil
+}
+
+// MarkFailed records a failed attempt to deliver a message and when to attempt it again, and releases its lease
+func (r *OutboxRepository) MarkFailed(ctx context.Context, sequence int64, nextAttemptAt time.Time, lastError string) error {
+	ctx, span := r.tracer.Start(withAllTenants(ctx), "OutboxRepository.MarkFailed")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("outbox.sequence", sequence))
+
+	query := `
+		UPDATE outbox
+		SET attempts = attempts + 1, next_attempt_at = $1, last_error = $2, leased_until = NULL
+		WHERE sequence = $3
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, nextAttemptAt.UTC(), lastError, sequence)
+	if err != nil {
+		r.logger.Error("Failed to mark outbox message as failed", zap.Error(err), zap.Int64("sequence", sequence))
+		return fmt.Errorf("failed to mark outbox message %d as failed: %w", sequence, err)
+	}
+
+	return nil
+}
+
+// Quarantine moves a message that cannot be delivered from the outbox to its dead letters, with the reason
+func (r *OutboxRepository) Quarantine(ctx context.Context, sequence int64, reason string) error {
+	ctx, span := r.tracer.Start(withAllTenants(ctx), "OutboxRepository.Quarantine")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("outbox.sequence", sequence))
+
+	return r.quarantine(ctx, conn(ctx, r.pool), sequence, reason)
+}
+
+// quarantine moves a message to the dead letters of the outbox in a single statement
+func (r *OutboxRepository) quarantine(ctx context.Context, q querier, sequence int64, reason string) error {
+	query := `
+		WITH moved AS (
+			DELETE FROM outbox WHERE sequence = $1
+			RETURNING sequence, event_id, tenant_id, aggregate_id, event_type, payload, occurred_at, attempts, last_error
+		)
+		INSERT INTO outbox_dead_letters (sequence, event_id, tenant_id, aggregate_id, event_type, payload, occurred_at, attempts, last_error, reason, quarantined_at)
+		SELECT sequence, event_id, tenant_id, aggregate_id, event_type, payload, occurred_at, attempts, last_error, $2, $3
+		FROM moved
+		ON CONFLICT (sequence) DO NOTHING
+	`
+
+	_, err := q.Exec(ctx, query, sequence, reason, time.Now().UTC())
+	if err != nil {
+		r.logger.Error("Failed to quarantine outbox message", zap.Error(err), zap.Int64("sequence", sequence))
+		return fmt.Errorf("failed to quarantine outbox message %d: %w", sequence, err)
+	}
+
+	return nil
+}
+
+// Ensure OutboxRepository implements ports.OutboxRepository
+var _ ports.OutboxRepository = (*OutboxRepository)(nil)
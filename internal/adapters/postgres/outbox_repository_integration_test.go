@@ -0,0 +1,136 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/postgres"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOutboxRepositoryIntegration tests the PostgreSQL outbox repository with a real PostgreSQL database
+func TestOutboxRepositoryIntegration(t *testing.T) {
+	// Skip if short flag is set
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	// Set up test repositories using the helper
+	factory, ctx, cleanup := postgres.SetupTestRepositories(t)
+	defer cleanup()
+
+	outbox := factory.NewOutboxRepository()
+	txManager := factory.GetTransactionManager()
+
+	lease := func(now time.Time) []ports.OutboxMessage {
+		messages, err := outbox.LeasePending(ctx, 100, now, now.Add(time.Minute))
+		require.NoError(t, err)
+		return messages
+	}
+
+	// Test that an event is recorded with the transaction that commits
+	t.Run("AppendCommitted", func(t *testing.T) {
+		event := domain.NewEvent(domain.EventParentCreated, uuid.New(), uuid.Nil)
+
+		txCtx, err := txManager.BeginTx(ctx)
+		require.NoError(t, err)
+		require.NoError(t, outbox.Append(txCtx, event))
+		require.NoError(t, txManager.CommitTx(txCtx))
+
+		messages := lease(time.Now())
+		require.NotEmpty(t, messages)
+		last := messages[len(messages)-1]
+		assert.Equal(t, event.ID, last.Event.ID)
+		assert.Equal(t, event.Type, last.Event.Type)
+		assert.Equal(t, event.ParentID, last.Event.ParentID)
+
+		require.NoError(t, outbox.Acknowledge(ctx, last.Sequence))
+	})
+
+	// Test that an event is discarded with the transaction that rolls back
+	t.Run("AppendRolledBack", func(t *testing.T) {
+		event := domain.NewEvent(domain.EventParentDeleted, uuid.New(), uuid.Nil)
+
+		txCtx, err := txManager.BeginTx(ctx)
+		require.NoError(t, err)
+		require.NoError(t, outbox.Append(txCtx, event))
+		require.NoError(t, txManager.RollbackTx(txCtx))
+
+		for _, message := range lease(time.Now()) {
+			assert.NotEqual(t, event.ID, message.Event.ID)
+		}
+	})
+
+	// Test recording a failed delivery and acknowledging a delivered message
+	t.Run("MarkFailedAndAcknowledge", func(t *testing.T) {
+		first := domain.NewEvent(domain.EventChildCreated, uuid.New(), uuid.New())
+		second := domain.NewEvent(domain.EventChildUpdated, first.ParentID, first.ChildID)
+		require.NoError(t, outbox.Append(ctx, first))
+		require.NoError(t, outbox.Append(ctx, second))
+
+		now := time.Now()
+		messages := lease(now)
+		require.Len(t, messages, 2)
+		assert.Equal(t, first.ID, messages[0].Event.ID)
+		assert.Less(t, messages[0].Sequence, messages[1].Sequence)
+
+		nextAttemptAt := now.Add(time.Minute).UTC()
+		require.NoError(t, outbox.MarkFailed(ctx, messages[0].Sequence, nextAttemptAt, "unavailable"))
+		require.NoError(t, outbox.Acknowledge(ctx, messages[1].Sequence))
+
+		// The message is not leased until it is due
+		assert.Empty(t, lease(now))
+		messages = lease(now.Add(2 * time.Minute))
+		require.Len(t, messages, 1)
+		assert.Equal(t, 1, messages[0].Attempts)
+		assert.Equal(t, "unavailable", messages[0].LastError)
+		assert.WithinDuration(t, nextAttemptAt, messages[0].NextAttemptAt, time.Second)
+
+		require.NoError(t, outbox.Acknowledge(ctx, messages[0].Sequence))
+	})
+
+	// Test that the messages of a family are leased to one relay at a time
+	t.Run("Lease", func(t *testing.T) {
+		first := domain.NewEvent(domain.EventParentCreated, uuid.New(), uuid.Nil)
+		second := domain.NewEvent(domain.EventParentUpdated, first.ParentID, uuid.Nil)
+		require.NoError(t, outbox.Append(ctx, first))
+		require.NoError(t, outbox.Append(ctx, second))
+
+		now := time.Now()
+		messages := lease(now)
+		require.Len(t, messages, 2)
+		assert.Equal(t, first.ID, messages[0].Event.ID)
+		assert.Equal(t, second.ID, messages[1].Event.ID)
+
+		// Another relay finds nothing until the lease ends
+		assert.Empty(t, lease(now))
+		messages = lease(now.Add(2 * time.Minute))
+		require.Len(t, messages, 2)
+
+		require.NoError(t, outbox.Acknowledge(ctx, messages[0].Sequence))
+		require.NoError(t, outbox.Acknowledge(ctx, messages[1].Sequence))
+	})
+
+	// Test that a quarantined message is no longer leased, and releases the later messages of its family
+	t.Run("Quarantine", func(t *testing.T) {
+		first := domain.NewEvent(domain.EventChildCreated, uuid.New(), uuid.New())
+		second := domain.NewEvent(domain.EventChildUpdated, first.ParentID, first.ChildID)
+		require.NoError(t, outbox.Append(ctx, first))
+		require.NoError(t, outbox.Append(ctx, second))
+
+		now := time.Now()
+		messages := lease(now)
+		require.Len(t, messages, 2)
+		require.NoError(t, outbox.MarkFailed(ctx, messages[0].Sequence, now.Add(time.Hour), "rejected"))
+		require.NoError(t, outbox.Quarantine(ctx, messages[0].Sequence, "delivery failed 25 times: rejected"))
+
+		messages = lease(now.Add(2 * time.Minute))
+		require.Len(t, messages, 1)
+		assert.Equal(t, second.ID, messages[0].Event.ID)
+		require.NoError(t, outbox.Acknowledge(ctx, messages[0].Sequence))
+	})
+}
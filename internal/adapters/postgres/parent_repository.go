@@ -35,19 +35,21 @@ func NewParentRepository(pool *pgxpool.Pool, logger *zap.Logger) *ParentReposito
 	}
 }
 
-// Create creates a new parent in the database
+// Create creates a new parent of the caller's tenant in the database
 func (r *ParentRepository) Create(ctx context.Context, parent *domain.Parent) error {
 	ctx, span := r.tracer.Start(ctx, "ParentRepository.Create")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("parent.id", parent.ID.String()))
 
+	parent.TenantID = ports.TenantIDFromContext(ctx)
+
 	query := `
-		INSERT INTO parents (id, first_name, last_name, email, birth_date, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO parents (id, first_name, last_name, email, birth_date, created_at, updated_at, user_id, contact_details, tenant_id, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
 		parent.ID,
 		parent.FirstName,
 		parent.LastName,
@@ -55,9 +57,17 @@ func (r *ParentRepository) Create(ctx context.Context, parent *domain.Parent) er
 		parent.BirthDate,
 		parent.CreatedAt,
 		parent.UpdatedAt,
+		nullString(parent.UserID),
+		parent.ContactDetails,
+		parent.TenantID,
+		parent.Version,
 	)
 
 	if err != nil {
+		if isUniqueViolation(err, parentEmailIndex) {
+			r.logger.Debug("Parent email already exists", zap.String("parent_id", parent.ID.String()))
+			return domain.NewDuplicateError("Parent", "email", parent.Email)
+		}
 		r.logger.Error("Failed to create parent", zap.Error(err), zap.String("parent_id", parent.ID.String()))
 		return fmt.Errorf("failed to create parent: %w", err)
 	}
@@ -65,7 +75,27 @@ func (r *ParentRepository) Create(ctx context.Context, parent *domain.Parent) er
 	return nil
 }
 
-// GetByID retrieves a parent by ID from the database
+// CreateMany creates many parents of the caller's tenant in the database in a single round trip
+func (r *ParentRepository) CreateMany(ctx context.Context, parents []*domain.Parent) error {
+	ctx, span := r.tracer.Start(ctx, "ParentRepository.CreateMany")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("parents.count", len(parents)))
+
+	if err := insertParents(ctx, conn(ctx, r.pool), parents); err != nil {
+		var batchErr *domain.BatchError
+		if errors.As(err, &batchErr) && errors.Is(batchErr.Err, domain.ErrDuplicate) {
+			r.logger.Debug("Parent email already exists", zap.String("parent_id", parents[batchErr.Index].ID.String()))
+			return err
+		}
+		r.logger.Error("Failed to create parents", zap.Error(err), zap.Int("count", len(parents)))
+		return fmt.Errorf("failed to create parents: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a parent of the caller's tenant by ID from the database
 func (r *ParentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Parent, error) {
 	ctx, span := r.tracer.Start(ctx, "ParentRepository.GetByID")
 	defer span.End()
@@ -73,15 +103,16 @@ func (r *ParentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.P
 	span.SetAttributes(attribute.String("parent.id", id.String()))
 
 	query := `
-		SELECT p.id, p.first_name, p.last_name, p.email, p.birth_date, p.created_at, p.updated_at, p.deleted_at
+		SELECT p.id, p.first_name, p.last_name, p.email, p.birth_date, p.created_at, p.updated_at, p.deleted_at, p.user_id, p.contact_details, p.tenant_id, p.version, p.merged_into
 		FROM parents p
-		WHERE p.id = $1 AND p.deleted_at IS NULL
+		WHERE p.id = $1 AND p.tenant_id = $2 AND p.deleted_at IS NULL
 	`
 
-	row := r.pool.QueryRow(ctx, query, id)
+	row := conn(ctx, r.pool).QueryRow(ctx, query, id, ports.TenantIDFromContext(ctx))
 
 	var parent domain.Parent
 	var deletedAt sql.NullTime
+	var userID sql.NullString
 
 	err := row.Scan(
 		&parent.ID,
@@ -92,11 +123,17 @@ func (r *ParentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.P
 		&parent.CreatedAt,
 		&parent.UpdatedAt,
 		&deletedAt,
+		&userID,
+		&parent.ContactDetails,
+		&parent.TenantID,
+		&parent.Version,
+		&parent.MergedInto,
 	)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			r.logger.Debug("Parent not found", zap.String("parent_id", id.String()))
+			reportCrossTenantAccess(ctx, r.pool, r.logger, "parents", "Parent", id)
 			return nil, fmt.Errorf("parent not found: %w", err)
 		}
 		r.logger.Error("Failed to get parent", zap.Error(err), zap.String("parent_id", id.String()))
@@ -106,15 +143,16 @@ func (r *ParentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.P
 	if deletedAt.Valid {
 		parent.DeletedAt = &deletedAt.Time
 	}
+	parent.UserID = userID.String
 
 	// Get children for this parent
 	childrenQuery := `
-		SELECT c.id, c.first_name, c.last_name, c.birth_date, c.parent_id, c.created_at, c.updated_at, c.deleted_at
+		SELECT c.id, c.first_name, c.last_name, c.birth_date, c.parent_id, c.created_at, c.updated_at, c.deleted_at, c.tenant_id, c.version
 		FROM children c
-		WHERE c.parent_id = $1 AND c.deleted_at IS NULL
+		WHERE c.parent_id = $1 AND c.tenant_id = $2 AND c.deleted_at IS NULL
 	`
 
-	rows, err := r.pool.Query(ctx, childrenQuery, id)
+	rows, err := conn(ctx, r.pool).Query(ctx, childrenQuery, id, parent.TenantID)
 	if err != nil {
 		r.logger.Error("Failed to get children for parent", zap.Error(err), zap.String("parent_id", id.String()))
 		return nil, fmt.Errorf("failed to get children for parent: %w", err)
@@ -136,6 +174,8 @@ func (r *ParentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.P
 			&child.CreatedAt,
 			&child.UpdatedAt,
 			&childDeletedAt,
+			&child.TenantID,
+			&child.Version,
 		)
 
 		if err != nil {
@@ -158,7 +198,167 @@ func (r *ParentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.P
 	return &parent, nil
 }
 
-// Update updates an existing parent in the database
+// GetByIDs retrieves the parents of the caller's tenant with the given IDs from the database in a single query.
+// Unlike GetByID, it does not load the children of each parent; use
+// ChildRepository.ListByParentIDs to load them in one batch.
+func (r *ParentRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Parent, error) {
+	ctx, span := r.tracer.Start(ctx, "ParentRepository.GetByIDs")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("parent.count", len(ids)))
+
+	query := `
+		SELECT p.id, p.first_name, p.last_name, p.email, p.birth_date, p.created_at, p.updated_at, p.deleted_at, p.user_id, p.contact_details, p.tenant_id, p.version, p.merged_into
+		FROM parents p
+		WHERE p.id = ANY($1) AND p.tenant_id = $2 AND p.deleted_at IS NULL
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, ids, ports.TenantIDFromContext(ctx))
+	if err != nil {
+		r.logger.Error("Failed to get parents by IDs", zap.Error(err), zap.Int("count", len(ids)))
+		return nil, fmt.Errorf("failed to get parents by IDs: %w", err)
+	}
+	defer rows.Close()
+
+	parents := make([]*domain.Parent, 0, len(ids))
+
+	for rows.Next() {
+		var parent domain.Parent
+		var deletedAt sql.NullTime
+		var userID sql.NullString
+
+		err := rows.Scan(
+			&parent.ID,
+			&parent.FirstName,
+			&parent.LastName,
+			&parent.Email,
+			&parent.BirthDate,
+			&parent.CreatedAt,
+			&parent.UpdatedAt,
+			&deletedAt,
+			&userID,
+			&parent.ContactDetails,
+			&parent.TenantID,
+			&parent.Version,
+			&parent.MergedInto,
+		)
+
+		if err != nil {
+			r.logger.Error("Failed to scan parent row", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan parent row: %w", err)
+		}
+
+		if deletedAt.Valid {
+			parent.DeletedAt = &deletedAt.Time
+		}
+		parent.UserID = userID.String
+
+		parents = append(parents, &parent)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating parent rows", zap.Error(err))
+		return nil, fmt.Errorf("error iterating parent rows: %w", err)
+	}
+
+	return parents, nil
+}
+
+// GetByUserID retrieves the parent of the caller's tenant linked to the given user account from the database.
+// Like GetByIDs, it does not load the children of the parent.
+func (r *ParentRepository) GetByUserID(ctx context.Context, userID string) (*domain.Parent, error) {
+	ctx, span := r.tracer.Start(ctx, "ParentRepository.GetByUserID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID))
+
+	query := `
+		SELECT p.id, p.first_name, p.last_name, p.email, p.birth_date, p.created_at, p.updated_at, p.deleted_at, p.user_id, p.contact_details, p.tenant_id, p.version, p.merged_into
+		FROM parents p
+		WHERE p.user_id = $1 AND p.tenant_id = $2 AND p.deleted_at IS NULL
+	`
+
+	row := conn(ctx, r.pool).QueryRow(ctx, query, userID, ports.TenantIDFromContext(ctx))
+
+	var parent domain.Parent
+	var deletedAt sql.NullTime
+	var linkedUserID sql.NullString
+
+	err := row.Scan(
+		&parent.ID,
+		&parent.FirstName,
+		&parent.LastName,
+		&parent.Email,
+		&parent.BirthDate,
+		&parent.CreatedAt,
+		&parent.UpdatedAt,
+		&deletedAt,
+		&linkedUserID,
+		&parent.ContactDetails,
+		&parent.TenantID,
+		&parent.Version,
+		&parent.MergedInto,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			r.logger.Debug("No parent linked to user", zap.String("user_id", userID))
+			return nil, fmt.Errorf("parent not found for user: %w", domain.ErrNotFound)
+		}
+		r.logger.Error("Failed to get parent by user ID", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get parent by user ID: %w", err)
+	}
+
+	if deletedAt.Valid {
+		parent.DeletedAt = &deletedAt.Time
+	}
+	parent.UserID = linkedUserID.String
+
+	return &parent, nil
+}
+
+// FindTakenEmails returns those of the given emails that non-deleted parents of the caller's tenant already have,
+// ignoring case
+func (r *ParentRepository) FindTakenEmails(ctx context.Context, emails []string) ([]string, error) {
+	ctx, span := r.tracer.Start(ctx, "ParentRepository.FindTakenEmails")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("emails.count", len(emails)))
+
+	// The unique index of the emails is on their lower-cased values
+	lowered := make([]string, 0, len(emails))
+	for _, email := range emails {
+		lowered = append(lowered, strings.ToLower(email))
+	}
+
+	rows, err := conn(ctx, r.pool).Query(ctx, `
+		SELECT email FROM parents WHERE tenant_id = $1 AND lower(email) = ANY($2) AND deleted_at IS NULL
+	`, ports.TenantIDFromContext(ctx), lowered)
+	if err != nil {
+		r.logger.Error("Failed to find taken emails", zap.Error(err), zap.Int("count", len(emails)))
+		return nil, fmt.Errorf("failed to find taken emails: %w", err)
+	}
+	defer rows.Close()
+
+	taken := []string{}
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			r.logger.Error("Failed to scan email", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan email: %w", err)
+		}
+		taken = append(taken, email)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating over emails", zap.Error(err))
+		return nil, fmt.Errorf("error iterating over emails: %w", err)
+	}
+
+	return taken, nil
+}
+
+// Update updates an existing parent of the caller's tenant in the database, provided that it is still
+// at the version of the given parent, and increments the version
 func (r *ParentRepository) Update(ctx context.Context, parent *domain.Parent) error {
 	ctx, span := r.tracer.Start(ctx, "ParentRepository.Update")
 	defer span.End()
@@ -167,97 +367,157 @@ func (r *ParentRepository) Update(ctx context.Context, parent *domain.Parent) er
 
 	query := `
 		UPDATE parents
-		SET first_name = $1, last_name = $2, email = $3, birth_date = $4, updated_at = $5
-		WHERE id = $6 AND deleted_at IS NULL
+		SET first_name = $1, last_name = $2, email = $3, birth_date = $4, updated_at = $5, user_id = $6,
+			contact_details = $7, merged_into = $8, version = version + 1
+		WHERE id = $9 AND tenant_id = $10 AND version = $11 AND deleted_at IS NULL
 	`
 
-	result, err := r.pool.Exec(ctx, query,
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
 		parent.FirstName,
 		parent.LastName,
 		parent.Email,
 		parent.BirthDate,
 		time.Now().UTC(),
+		nullString(parent.UserID),
+		parent.ContactDetails,
+		parent.MergedInto,
 		parent.ID,
+		ports.TenantIDFromContext(ctx),
+		parent.Version,
 	)
 
 	if err != nil {
+		if isUniqueViolation(err, parentEmailIndex) {
+			r.logger.Debug("Parent email already exists", zap.String("parent_id", parent.ID.String()))
+			return domain.NewDuplicateError("Parent", "email", parent.Email)
+		}
 		r.logger.Error("Failed to update parent", zap.Error(err), zap.String("parent_id", parent.ID.String()))
 		return fmt.Errorf("failed to update parent: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
+		if isVersionConflict(ctx, conn(ctx, r.pool), "parents", parent.ID) {
+			r.logger.Debug("Parent version conflict", zap.String("parent_id", parent.ID.String()), zap.Int("version", parent.Version))
+			return domain.NewConflictError("Parent", parent.ID.String(), parent.Version)
+		}
 		r.logger.Debug("Parent not found for update", zap.String("parent_id", parent.ID.String()))
+		reportCrossTenantAccess(ctx, r.pool, r.logger, "parents", "Parent", parent.ID)
 		return fmt.Errorf("parent not found for update")
 	}
 
+	parent.Version++
 	return nil
 }
 
-// Delete marks a parent as deleted in the database
+// Delete marks a parent of the caller's tenant and its children as deleted in the database
 func (r *ParentRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	ctx, span := r.tracer.Start(ctx, "ParentRepository.Delete")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("parent.id", id.String()))
 
-	tx, err := r.pool.Begin(ctx)
-	if err != nil {
-		r.logger.Error("Failed to begin transaction", zap.Error(err))
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback(ctx)
+	return inTx(ctx, r.pool, func(tx querier) error {
+		now := time.Now().UTC()
 
-	now := time.Now().UTC()
+		// Mark parent as deleted
+		parentQuery := `
+			UPDATE parents
+			SET deleted_at = $1, updated_at = $1, version = version + 1
+			WHERE id = $2 AND tenant_id = $3 AND deleted_at IS NULL
+		`
 
-	// Mark parent as deleted
-	parentQuery := `
-		UPDATE parents
-		SET deleted_at = $1, updated_at = $1
-		WHERE id = $2 AND deleted_at IS NULL
-	`
+		result, err := tx.Exec(ctx, parentQuery, now, id, ports.TenantIDFromContext(ctx))
+		if err != nil {
+			r.logger.Error("Failed to delete parent", zap.Error(err), zap.String("parent_id", id.String()))
+			return fmt.Errorf("failed to delete parent: %w", err)
+		}
 
-	result, err := tx.Exec(ctx, parentQuery, now, id)
-	if err != nil {
-		r.logger.Error("Failed to delete parent", zap.Error(err), zap.String("parent_id", id.String()))
-		return fmt.Errorf("failed to delete parent: %w", err)
-	}
+		if result.RowsAffected() == 0 {
+			r.logger.Debug("Parent not found for deletion", zap.String("parent_id", id.String()))
+			reportCrossTenantAccess(ctx, r.pool, r.logger, "parents", "Parent", id)
+			return fmt.Errorf("parent not found for deletion")
+		}
 
-	if result.RowsAffected() == 0 {
-		r.logger.Debug("Parent not found for deletion", zap.String("parent_id", id.String()))
-		return fmt.Errorf("parent not found for deletion")
-	}
+		// Mark all children as deleted
+		childrenQuery := `
+			UPDATE children
+			SET deleted_at = $1, updated_at = $1, version = version + 1
+			WHERE parent_id = $2 AND tenant_id = $3 AND deleted_at IS NULL
+		`
 
-	// Mark all children as deleted
-	childrenQuery := `
-		UPDATE children
-		SET deleted_at = $1, updated_at = $1
-		WHERE parent_id = $2 AND deleted_at IS NULL
-	`
+		_, err = tx.Exec(ctx, childrenQuery, now, id, ports.TenantIDFromContext(ctx))
+		if err != nil {
+			r.logger.Error("Failed to delete children", zap.Error(err), zap.String("parent_id", id.String()))
+			return fmt.Errorf("failed to delete children: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Restore unmarks a parent of the caller's tenant that was marked as deleted in the database,
+// together with the children that were marked as deleted with it
+func (r *ParentRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "ParentRepository.Restore")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("parent.id", id.String()))
+
+	return inTx(ctx, r.pool, func(tx querier) error {
+		// The children are restored first, while the deletion time of the parent is still known
+		if err := restoreChildrenDeletedWithParent(ctx, tx, id); err != nil {
+			r.logger.Error("Failed to restore children", zap.Error(err), zap.String("parent_id", id.String()))
+			return fmt.Errorf("failed to restore children: %w", err)
+		}
 
-	_, err = tx.Exec(ctx, childrenQuery, now, id)
+		found, err := restoreRow(ctx, tx, "parents", id)
+		if err != nil {
+			// Another parent may have taken the email since the parent was deleted
+			if isUniqueViolation(err, parentEmailIndex) {
+				r.logger.Debug("Parent email already exists", zap.String("parent_id", id.String()))
+				return domain.NewDuplicateError("Parent", "email", "")
+			}
+			r.logger.Error("Failed to restore parent", zap.Error(err), zap.String("parent_id", id.String()))
+			return fmt.Errorf("failed to restore parent: %w", err)
+		}
+
+		if !found {
+			r.logger.Debug("Parent not found for restore", zap.String("parent_id", id.String()))
+			reportCrossTenantAccess(ctx, r.pool, r.logger, "parents", "Parent", id)
+			return fmt.Errorf("parent not found for restore: %w", domain.ErrNotFound)
+		}
+
+		return nil
+	})
+}
+
+// Purge permanently removes the parents of the caller's tenant that were marked as deleted before
+// the given time from the database, and returns how many were removed. Parents that still have children are kept.
+func (r *ParentRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, span := r.tracer.Start(ctx, "ParentRepository.Purge")
+	defer span.End()
+
+	purged, err := purgeRows(ctx, conn(ctx, r.pool), "parents", deletedBefore)
 	if err != nil {
-		r.logger.Error("Failed to delete children", zap.Error(err), zap.String("parent_id", id.String()))
-		return fmt.Errorf("failed to delete children: %w", err)
+		r.logger.Error("Failed to purge parents", zap.Error(err))
+		return 0, fmt.Errorf("failed to purge parents: %w", err)
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		r.logger.Error("Failed to commit transaction", zap.Error(err))
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
+	span.SetAttributes(attribute.Int64("parent.purged", purged))
 
-	return nil
+	return purged, nil
 }
 
-// buildListQuery builds a query for listing parents with filtering, pagination, and sorting
-func (r *ParentRepository) buildListQuery(filter ports.FilterOptions, sort ports.SortOptions) (string, []interface{}) {
+// buildListQuery builds a query for listing the parents of the caller's tenant with filtering
+func (r *ParentRepository) buildListQuery(ctx context.Context, filter ports.FilterOptions) (string, []interface{}) {
 	query := `
-		SELECT p.id, p.first_name, p.last_name, p.email, p.birth_date, p.created_at, p.updated_at, p.deleted_at
+		SELECT p.id, p.first_name, p.last_name, p.email, p.birth_date, p.created_at, p.updated_at, p.deleted_at, p.user_id, p.contact_details, p.tenant_id, p.version, p.merged_into
 		FROM parents p
-		WHERE p.deleted_at IS NULL
+		WHERE ` + deletedCondition("p.deleted_at", filter) + ` AND p.tenant_id = $1
 	`
 
-	params := []interface{}{}
-	paramIndex := 1
+	params := []interface{}{ports.TenantIDFromContext(ctx)}
+	paramIndex := 2
 	whereConditions := []string{}
 
 	if filter.FirstName != "" {
@@ -290,63 +550,69 @@ func (r *ParentRepository) buildListQuery(filter ports.FilterOptions, sort ports
 		paramIndex++
 	}
 
-	if len(whereConditions) > 0 {
-		query += " AND " + strings.Join(whereConditions, " AND ")
+	if filter.PostalCode != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("p.contact_details->'address'->>'postalCode' = $%d", paramIndex))
+		params = append(params, filter.PostalCode)
+		paramIndex++
 	}
 
-	// Add sorting
-	if sort.Field != "" {
-		direction := "ASC"
-		if strings.ToLower(sort.Direction) == "desc" {
-			direction = "DESC"
-		}
+	if filter.Phone != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("p.contact_details->'phones' @> $%d::jsonb", paramIndex))
+		params = append(params, []domain.Phone{{Number: filter.Phone}})
+		paramIndex++
+	}
 
-		var sortField string
-		switch strings.ToLower(sort.Field) {
-		case "firstname", "first_name":
-			sortField = "p.first_name"
-		case "lastname", "last_name":
-			sortField = "p.last_name"
-		case "email":
-			sortField = "p.email"
-		case "birthdate", "birth_date":
-			sortField = "p.birth_date"
-		case "createdat", "created_at":
-			sortField = "p.created_at"
-		case "updatedat", "updated_at":
-			sortField = "p.updated_at"
-		default:
-			sortField = "p.created_at"
-		}
+	if len(filter.ParentIDs) > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf("p.id = ANY($%d)", paramIndex))
+		params = append(params, filter.ParentIDs)
+		paramIndex++
+	}
 
-		query += fmt.Sprintf(" ORDER BY %s %s", sortField, direction)
-	} else {
-		query += " ORDER BY p.created_at DESC"
+	if len(whereConditions) > 0 {
+		query += " AND " + strings.Join(whereConditions, " AND ")
 	}
 
 	return query, params
 }
 
+// sortColumn maps the sort options to a column and reports whether the order is descending
+func (r *ParentRepository) sortColumn(sort ports.SortOptions) (string, bool) {
+	if sort.Field == "" {
+		return "p.created_at", true
+	}
+
+	var sortField string
+	switch strings.ToLower(sort.Field) {
+	case "firstname", "first_name":
+		sortField = "p.first_name"
+	case "lastname", "last_name":
+		sortField = "p.last_name"
+	case "email":
+		sortField = "p.email"
+	case "birthdate", "birth_date":
+		sortField = "p.birth_date"
+	case "createdat", "created_at":
+		sortField = "p.created_at"
+	case "updatedat", "updated_at":
+		sortField = "p.updated_at"
+	default:
+		sortField = "p.created_at"
+	}
+
+	return sortField, strings.ToLower(sort.Direction) == "desc"
+}
+
 // List retrieves a list of parents with pagination, filtering, and sorting
 func (r *ParentRepository) List(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
 	ctx, span := r.tracer.Start(ctx, "ParentRepository.List")
 	defer span.End()
 
-	baseQuery, params := r.buildListQuery(options.Filter, options.Sort)
+	baseQuery, params := r.buildListQuery(ctx, options.Filter)
 
-	// Add pagination
-	limit := options.Pagination.PageSize
-	if limit <= 0 {
-		limit = 10 // Default page size
-	}
+	sortColumn, desc := r.sortColumn(options.Sort)
 
-	offset := options.Pagination.Page * limit
-	if offset < 0 {
-		offset = 0
-	}
-
-	query := baseQuery + fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(params)+1, len(params)+2)
-	params = append(params, limit, offset)
+	// Add ordering and pagination
+	query, params, limit, offset := appendWindow(baseQuery, params, sortColumn, "p.id", desc, options)
 
 	// Create channels for concurrent operations
 	type queryResult struct {
@@ -362,7 +628,7 @@ func (r *ParentRepository) List(ctx context.Context, options ports.QueryOptions)
 
 	// Execute query operation concurrently
 	go func() {
-		rows, err := r.pool.Query(ctx, query, params...)
+		rows, err := conn(ctx, r.pool).Query(ctx, query, params...)
 		if err != nil {
 			queryCh <- queryResult{nil, fmt.Errorf("failed to list parents: %w", err)}
 			return
@@ -374,6 +640,7 @@ func (r *ParentRepository) List(ctx context.Context, options ports.QueryOptions)
 		for rows.Next() {
 			var parent domain.Parent
 			var deletedAt sql.NullTime
+			var userID sql.NullString
 
 			err := rows.Scan(
 				&parent.ID,
@@ -384,6 +651,11 @@ func (r *ParentRepository) List(ctx context.Context, options ports.QueryOptions)
 				&parent.CreatedAt,
 				&parent.UpdatedAt,
 				&deletedAt,
+				&userID,
+				&parent.ContactDetails,
+				&parent.TenantID,
+				&parent.Version,
+				&parent.MergedInto,
 			)
 
 			if err != nil {
@@ -394,6 +666,7 @@ func (r *ParentRepository) List(ctx context.Context, options ports.QueryOptions)
 			if deletedAt.Valid {
 				parent.DeletedAt = &deletedAt.Time
 			}
+			parent.UserID = userID.String
 
 			parents = append(parents, &parent)
 		}
@@ -430,17 +703,12 @@ func (r *ParentRepository) List(ctx context.Context, options ports.QueryOptions)
 		return nil, nil, countRes.err
 	}
 
-	pagedResult := &ports.PagedResult{
-		TotalCount: countRes.count,
-		Page:       options.Pagination.Page,
-		PageSize:   limit,
-		HasNext:    int64(offset+len(queryRes.parents)) < countRes.count,
-	}
+	parents, pagedResult := windowResult(queryRes.parents, options, limit, offset, countRes.count)
 
-	return queryRes.parents, pagedResult, nil
+	return parents, pagedResult, nil
 }
 
-// Count returns the total count of parents matching the filter
+// Count returns the total count of the parents of the caller's tenant matching the filter
 func (r *ParentRepository) Count(ctx context.Context, filter ports.FilterOptions) (int64, error) {
 	ctx, span := r.tracer.Start(ctx, "ParentRepository.Count")
 	defer span.End()
@@ -448,11 +716,11 @@ func (r *ParentRepository) Count(ctx context.Context, filter ports.FilterOptions
 	query := `
 		SELECT COUNT(*)
 		FROM parents p
-		WHERE p.deleted_at IS NULL
+		WHERE ` + deletedCondition("p.deleted_at", filter) + ` AND p.tenant_id = $1
 	`
 
-	params := []interface{}{}
-	paramIndex := 1
+	params := []interface{}{ports.TenantIDFromContext(ctx)}
+	paramIndex := 2
 	whereConditions := []string{}
 
 	if filter.FirstName != "" {
@@ -485,12 +753,30 @@ func (r *ParentRepository) Count(ctx context.Context, filter ports.FilterOptions
 		paramIndex++
 	}
 
+	if filter.PostalCode != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("p.contact_details->'address'->>'postalCode' = $%d", paramIndex))
+		params = append(params, filter.PostalCode)
+		paramIndex++
+	}
+
+	if filter.Phone != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("p.contact_details->'phones' @> $%d::jsonb", paramIndex))
+		params = append(params, []domain.Phone{{Number: filter.Phone}})
+		paramIndex++
+	}
+
+	if len(filter.ParentIDs) > 0 {
+		whereConditions = append(whereConditions, fmt.Sprintf("p.id = ANY($%d)", paramIndex))
+		params = append(params, filter.ParentIDs)
+		paramIndex++
+	}
+
 	if len(whereConditions) > 0 {
 		query += " AND " + strings.Join(whereConditions, " AND ")
 	}
 
 	var count int64
-	err := r.pool.QueryRow(ctx, query, params...).Scan(&count)
+	err := conn(ctx, r.pool).QueryRow(ctx, query, params...).Scan(&count)
 	if err != nil {
 		r.logger.Error("Failed to count parents", zap.Error(err))
 		return 0, fmt.Errorf("failed to count parents: %w", err)
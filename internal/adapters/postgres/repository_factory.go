@@ -11,11 +11,17 @@ import (
 
 // RepositoryFactory implements the ports.RepositoryFactory interface for PostgreSQL
 type RepositoryFactory struct {
-	pool               *pgxpool.Pool
-	logger             *zap.Logger
-	transactionManager *TransactionManager
-	parentRepository   *ParentRepository
-	childRepository    *ChildRepository
+	pool                   *pgxpool.Pool
+	logger                 *zap.Logger
+	transactionManager     *TransactionManager
+	parentRepository       *ParentRepository
+	childRepository        *ChildRepository
+	outboxRepository       *OutboxRepository
+	webhookRepository      *WebhookRepository
+	auditLogRepository     *AuditLogRepository
+	historyRepository      *HistoryRepository
+	guardianshipRepository *GuardianshipRepository
+	householdRepository    *HouseholdRepository
 }
 
 // NewRepositoryFactory creates a new PostgreSQL repository factory
@@ -26,15 +32,10 @@ func NewRepositoryFactory(ctx context.Context, connString string, logger *zap.Lo
 		logger.Warn("Nil context provided to NewRepositoryFactory, using background context")
 	}
 
-	// Create connection pool
-	config, err := pgxpool.ParseConfig(connString)
+	// Create connection pool, scoped to the tenant of each statement
+	pool, err := newPool(ctx, connString)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse connection string: %w", err)
-	}
-
-	pool, err := pgxpool.NewWithConfig(ctx, config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create connection pool: %w", err)
+		return nil, err
 	}
 
 	// Ping database to verify connection
@@ -48,13 +49,25 @@ func NewRepositoryFactory(ctx context.Context, connString string, logger *zap.Lo
 	// Create repositories
 	parentRepository := NewParentRepository(pool, logger)
 	childRepository := NewChildRepository(pool, logger)
+	outboxRepository := NewOutboxRepository(pool, logger)
+	webhookRepository := NewWebhookRepository(pool, logger)
+	auditLogRepository := NewAuditLogRepository(pool, logger)
+	historyRepository := NewHistoryRepository(pool, logger)
+	guardianshipRepository := NewGuardianshipRepository(pool, logger)
+	householdRepository := NewHouseholdRepository(pool, logger)
 
 	return &RepositoryFactory{
-		pool:               pool,
-		logger:             logger,
-		transactionManager: transactionManager,
-		parentRepository:   parentRepository,
-		childRepository:    childRepository,
+		pool:                   pool,
+		logger:                 logger,
+		transactionManager:     transactionManager,
+		parentRepository:       parentRepository,
+		childRepository:        childRepository,
+		outboxRepository:       outboxRepository,
+		webhookRepository:      webhookRepository,
+		auditLogRepository:     auditLogRepository,
+		historyRepository:      historyRepository,
+		guardianshipRepository: guardianshipRepository,
+		householdRepository:    householdRepository,
 	}, nil
 }
 
@@ -68,6 +81,36 @@ func (f *RepositoryFactory) NewChildRepository() ports.ChildRepository {
 	return f.childRepository
 }
 
+// NewOutboxRepository returns an outbox repository
+func (f *RepositoryFactory) NewOutboxRepository() ports.OutboxRepository {
+	return f.outboxRepository
+}
+
+// NewWebhookRepository returns a webhook repository
+func (f *RepositoryFactory) NewWebhookRepository() ports.WebhookRepository {
+	return f.webhookRepository
+}
+
+// NewAuditLogRepository returns an audit log repository
+func (f *RepositoryFactory) NewAuditLogRepository() ports.AuditLogRepository {
+	return f.auditLogRepository
+}
+
+// NewHistoryRepository returns a history repository
+func (f *RepositoryFactory) NewHistoryRepository() ports.HistoryRepository {
+	return f.historyRepository
+}
+
+// NewGuardianshipRepository returns a guardianship repository
+func (f *RepositoryFactory) NewGuardianshipRepository() ports.GuardianshipRepository {
+	return f.guardianshipRepository
+}
+
+// NewHouseholdRepository returns a household repository
+func (f *RepositoryFactory) NewHouseholdRepository() ports.HouseholdRepository {
+	return f.householdRepository
+}
+
 // GetTransactionManager returns the transaction manager
 func (f *RepositoryFactory) GetTransactionManager() ports.TransactionManager {
 	return f.transactionManager
@@ -107,15 +150,226 @@ func (f *RepositoryFactory) InitSchema(ctx context.Context) error {
 			first_name TEXT NOT NULL,
 			last_name TEXT NOT NULL,
 			birth_date TIMESTAMP NOT NULL,
-			parent_id UUID NOT NULL REFERENCES parents(id),
+			parent_id UUID NOT NULL,
 			created_at TIMESTAMP NOT NULL,
 			updated_at TIMESTAMP NOT NULL,
 			deleted_at TIMESTAMP
 		);
 
+		ALTER TABLE parents ADD COLUMN IF NOT EXISTS user_id TEXT;
+		ALTER TABLE parents ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT '';
+		ALTER TABLE children ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT '';
+		ALTER TABLE parents ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+		ALTER TABLE children ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+		ALTER TABLE parents ADD COLUMN IF NOT EXISTS contact_details JSONB NOT NULL DEFAULT '{}';
+		ALTER TABLE parents ADD COLUMN IF NOT EXISTS merged_into UUID;
+
 		CREATE INDEX IF NOT EXISTS idx_parents_deleted_at ON parents(deleted_at);
+		CREATE INDEX IF NOT EXISTS idx_parents_tenant_id ON parents(tenant_id);
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_parents_user_id ON parents(tenant_id, user_id) WHERE user_id IS NOT NULL AND deleted_at IS NULL;
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_parents_email ON parents(tenant_id, lower(email)) WHERE deleted_at IS NULL;
+		CREATE INDEX IF NOT EXISTS idx_parents_postal_code ON parents(tenant_id, (contact_details->'address'->>'postalCode'));
+		CREATE INDEX IF NOT EXISTS idx_parents_phones ON parents USING GIN ((contact_details->'phones') jsonb_path_ops);
 		CREATE INDEX IF NOT EXISTS idx_children_deleted_at ON children(deleted_at);
 		CREATE INDEX IF NOT EXISTS idx_children_parent_id ON children(parent_id);
+		CREATE INDEX IF NOT EXISTS idx_children_tenant_id ON children(tenant_id);
+
+		CREATE TABLE IF NOT EXISTS guardianships (
+			id UUID PRIMARY KEY,
+			parent_id UUID NOT NULL REFERENCES parents(id) ON DELETE CASCADE,
+			child_id UUID NOT NULL REFERENCES children(id) ON DELETE CASCADE,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			type TEXT NOT NULL,
+			start_date TIMESTAMP NOT NULL,
+			end_date TIMESTAMP,
+			primary_contact BOOLEAN NOT NULL DEFAULT FALSE,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		);
+
+		ALTER TABLE children DROP CONSTRAINT IF EXISTS children_parent_id_fkey;
+
+		CREATE INDEX IF NOT EXISTS idx_guardianships_child_id ON guardianships(tenant_id, child_id);
+		CREATE INDEX IF NOT EXISTS idx_guardianships_parent_id ON guardianships(tenant_id, parent_id);
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_guardianships_active ON guardianships(parent_id, child_id) WHERE end_date IS NULL;
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_guardianships_primary_contact ON guardianships(child_id) WHERE primary_contact AND end_date IS NULL;
+
+		CREATE TABLE IF NOT EXISTS households (
+			id UUID PRIMARY KEY,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			name TEXT NOT NULL,
+			street TEXT NOT NULL,
+			city TEXT NOT NULL,
+			state TEXT NOT NULL DEFAULT '',
+			postal_code TEXT NOT NULL,
+			country TEXT NOT NULL,
+			phone_numbers TEXT[] NOT NULL DEFAULT '{}',
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS household_parents (
+			household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+			parent_id UUID NOT NULL REFERENCES parents(id) ON DELETE CASCADE,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			position INTEGER NOT NULL,
+			PRIMARY KEY (household_id, parent_id)
+		);
+
+		CREATE TABLE IF NOT EXISTS household_children (
+			child_id UUID PRIMARY KEY REFERENCES children(id) ON DELETE CASCADE,
+			household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			position INTEGER NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_households_tenant_id ON households(tenant_id);
+		CREATE INDEX IF NOT EXISTS idx_household_parents_parent_id ON household_parents(tenant_id, parent_id);
+		CREATE INDEX IF NOT EXISTS idx_household_children_household_id ON household_children(household_id);
+
+		CREATE TABLE IF NOT EXISTS outbox (
+			sequence BIGSERIAL PRIMARY KEY,
+			event_id UUID NOT NULL UNIQUE,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			aggregate_id UUID NOT NULL,
+			event_type TEXT NOT NULL,
+			payload JSONB NOT NULL,
+			occurred_at TIMESTAMP NOT NULL,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			next_attempt_at TIMESTAMP NOT NULL,
+			last_error TEXT
+		);
+
+		ALTER TABLE outbox ADD COLUMN IF NOT EXISTS leased_until TIMESTAMP;
+		CREATE INDEX IF NOT EXISTS idx_outbox_aggregate_id ON outbox(aggregate_id, sequence);
+		CREATE INDEX IF NOT EXISTS idx_outbox_next_attempt_at ON outbox(next_attempt_at);
+
+		CREATE TABLE IF NOT EXISTS outbox_dead_letters (
+			sequence BIGINT PRIMARY KEY,
+			event_id UUID NOT NULL,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			aggregate_id UUID NOT NULL,
+			event_type TEXT NOT NULL,
+			payload JSONB NOT NULL,
+			occurred_at TIMESTAMP NOT NULL,
+			attempts INTEGER NOT NULL,
+			last_error TEXT,
+			reason TEXT NOT NULL,
+			quarantined_at TIMESTAMP NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+			id UUID PRIMARY KEY,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			url TEXT NOT NULL,
+			event_types TEXT[] NOT NULL,
+			secret TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS webhook_deliveries (
+			id UUID PRIMARY KEY,
+			subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			event_id UUID NOT NULL,
+			event_type TEXT NOT NULL,
+			payload TEXT NOT NULL,
+			status TEXT NOT NULL,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			next_attempt_at TIMESTAMP NOT NULL,
+			last_error TEXT,
+			response_status INTEGER,
+			created_at TIMESTAMP NOT NULL,
+			delivered_at TIMESTAMP,
+			UNIQUE (subscription_id, event_id)
+		);
+
+		CREATE TABLE IF NOT EXISTS audit_log (
+			id UUID PRIMARY KEY,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			actor TEXT NOT NULL DEFAULT '',
+			operation TEXT NOT NULL,
+			entity_type TEXT NOT NULL,
+			entity_id UUID NOT NULL,
+			changes JSONB NOT NULL,
+			trace_id TEXT NOT NULL DEFAULT '',
+			occurred_at TIMESTAMP NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log(tenant_id, entity_id, occurred_at);
+		ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS reason TEXT NOT NULL DEFAULT '';
+
+		CREATE TABLE IF NOT EXISTS parent_history (
+			history_id BIGSERIAL PRIMARY KEY,
+			id UUID NOT NULL,
+			first_name TEXT NOT NULL,
+			last_name TEXT NOT NULL,
+			email TEXT NOT NULL,
+			birth_date TIMESTAMP NOT NULL,
+			user_id TEXT,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			version INTEGER NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL,
+			deleted_at TIMESTAMP,
+			recorded_at TIMESTAMP NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS child_history (
+			history_id BIGSERIAL PRIMARY KEY,
+			id UUID NOT NULL,
+			first_name TEXT NOT NULL,
+			last_name TEXT NOT NULL,
+			birth_date TIMESTAMP NOT NULL,
+			parent_id UUID NOT NULL,
+			tenant_id TEXT NOT NULL DEFAULT '',
+			version INTEGER NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL,
+			deleted_at TIMESTAMP,
+			recorded_at TIMESTAMP NOT NULL
+		);
+
+		ALTER TABLE parent_history ADD COLUMN IF NOT EXISTS contact_details JSONB NOT NULL DEFAULT '{}';
+		ALTER TABLE parent_history ADD COLUMN IF NOT EXISTS merged_into UUID;
+
+		CREATE INDEX IF NOT EXISTS idx_parent_history_id ON parent_history(tenant_id, id, recorded_at);
+		CREATE INDEX IF NOT EXISTS idx_child_history_id ON child_history(tenant_id, id, recorded_at);
+		CREATE INDEX IF NOT EXISTS idx_child_history_parent_id ON child_history(tenant_id, parent_id);
+
+		CREATE OR REPLACE FUNCTION record_parent_history() RETURNS trigger AS $$
+		BEGIN
+			IF TG_OP = 'DELETE' THEN
+				DELETE FROM parent_history WHERE id = OLD.id;
+				RETURN NULL;
+			END IF;
+			INSERT INTO parent_history (id, first_name, last_name, email, birth_date, user_id, contact_details, merged_into, tenant_id, version, created_at, updated_at, deleted_at, recorded_at)
+			VALUES (NEW.id, NEW.first_name, NEW.last_name, NEW.email, NEW.birth_date, NEW.user_id, NEW.contact_details, NEW.merged_into, NEW.tenant_id, NEW.version, NEW.created_at, NEW.updated_at, NEW.deleted_at, clock_timestamp() AT TIME ZONE 'UTC');
+			RETURN NULL;
+		END;
+		$$ LANGUAGE plpgsql;
+
+		CREATE OR REPLACE FUNCTION record_child_history() RETURNS trigger AS $$
+		BEGIN
+			IF TG_OP = 'DELETE' THEN
+				DELETE FROM child_history WHERE id = OLD.id;
+				RETURN NULL;
+			END IF;
+			INSERT INTO child_history (id, first_name, last_name, birth_date, parent_id, tenant_id, version, created_at, updated_at, deleted_at, recorded_at)
+			VALUES (NEW.id, NEW.first_name, NEW.last_name, NEW.birth_date, NEW.parent_id, NEW.tenant_id, NEW.version, NEW.created_at, NEW.updated_at, NEW.deleted_at, clock_timestamp() AT TIME ZONE 'UTC');
+			RETURN NULL;
+		END;
+		$$ LANGUAGE plpgsql;
+
+		DROP TRIGGER IF EXISTS record_parent_history ON parents;
+		CREATE TRIGGER record_parent_history
+			AFTER INSERT OR UPDATE OR DELETE ON parents
+			FOR EACH ROW EXECUTE FUNCTION record_parent_history();
+
+		DROP TRIGGER IF EXISTS record_child_history ON children;
+		CREATE TRIGGER record_child_history
+			AFTER INSERT OR UPDATE OR DELETE ON children
+			FOR EACH ROW EXECUTE FUNCTION record_child_history();
 	`
 
 	_, err := f.pool.Exec(ctx, schema)
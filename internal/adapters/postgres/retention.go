@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+)
+
+// deletedCondition returns the condition on a deleted_at column that selects the rows a filter asks for:
+// the rows marked as deleted when filter.Deleted is set, and the other rows otherwise
+func deletedCondition(column string, filter ports.FilterOptions) string {
+	if filter.Deleted {
+		return column + " IS NOT NULL"
+	}
+	return column + " IS NULL"
+}
+
+// restoreRow unmarks a row of the caller's tenant that is marked as deleted, and increments its version.
+// It reports whether a deleted row with the given ID was found.
+func restoreRow(ctx context.Context, q querier, tableName string, id uuid.UUID) (bool, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET deleted_at = NULL, updated_at = $1, version = version + 1
+		WHERE id = $2 AND tenant_id = $3 AND deleted_at IS NOT NULL
+	`, tableName)
+
+	result, err := q.Exec(ctx, query, time.Now().UTC(), id, ports.TenantIDFromContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// restoreChildrenDeletedWithParent unmarks the children of the caller's tenant that were marked as
+// deleted together with a deleted parent, which is when they share its deletion time.
+// It must run before the parent is restored, while the parent's deletion time is still known.
+func restoreChildrenDeletedWithParent(ctx context.Context, q querier, parentID uuid.UUID) error {
+	query := `
+		UPDATE children
+		SET deleted_at = NULL, updated_at = $1, version = version + 1
+		WHERE parent_id = $2 AND tenant_id = $3 AND deleted_at = (
+			SELECT deleted_at FROM parents WHERE id = $2 AND tenant_id = $3 AND deleted_at IS NOT NULL
+		)
+	`
+
+	_, err := q.Exec(ctx, query, time.Now().UTC(), parentID, ports.TenantIDFromContext(ctx))
+	return err
+}
+
+// purgeRows permanently removes the rows of the caller's tenant that were marked as deleted before
+// the given time, and returns how many were removed. Parents that still have children, whether they
+// are deleted or not, are kept, since the children refer to them, and so are parents that are still
+// a guardian of a child.
+func purgeRows(ctx context.Context, q querier, tableName string, deletedBefore time.Time) (int64, error) {
+	query := fmt.Sprintf(`
+		DELETE FROM %s
+		WHERE tenant_id = $1 AND deleted_at < $2
+	`, tableName)
+	if tableName == "parents" {
+		query += " AND NOT EXISTS (SELECT 1 FROM children WHERE children.parent_id = parents.id)"
+		query += " AND NOT EXISTS (SELECT 1 FROM guardianships WHERE guardianships.parent_id = parents.id AND guardianships.end_date IS NULL)"
+	}
+
+	result, err := q.Exec(ctx, query, ports.TenantIDFromContext(ctx), deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// withAllTenants returns a copy of ctx whose statements the row-level security policies admit to the rows
+// of every tenant, for the system processes that work across tenants, such as the outbox relay
+func withAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allTenantsKey, true)
+}
+
+// scopeToTenant is called before a connection is acquired from a pool. It scopes the row-level security
+// policies of the connection to the tenant of the context it is acquired with, or to every tenant for the
+// contexts of withAllTenants, so that the statements that run outside of a transaction are scoped like
+// those within one. The policies admit no row to a connection that was not scoped.
+// A connection that cannot be scoped is not used.
+func scopeToTenant(ctx context.Context, conn *pgx.Conn) bool {
+	allTenants := "off"
+	if all, _ := ctx.Value(allTenantsKey).(bool); all {
+		allTenants = "on"
+	}
+
+	_, err := conn.Exec(ctx,
+		"SELECT set_config('app.tenant_id', $1, false), set_config('app.all_tenants', $2, false)",
+		ports.TenantIDFromContext(ctx), allTenants)
+	return err == nil
+}
+
+// newPool creates a connection pool whose connections are scoped to the tenant of the context they are acquired with
+func newPool(ctx context.Context, connString string) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse connection string: %w", err)
+	}
+	config.BeforeAcquire = scopeToTenant
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection pool: %w", err)
+	}
+	return pool, nil
+}
+
+// reportCrossTenantAccess logs a security event when an entity that was not found for the
+// caller's tenant exists in another tenant. The caller still gets a not-found error, so that
+// the existence of the entity is not disclosed.
+// The lookup runs outside the caller's transaction, across tenants, so that the tenant policies do not hide the entity.
+func reportCrossTenantAccess(ctx context.Context, pool *pgxpool.Pool, logger *zap.Logger, tableName, entityType string, id uuid.UUID) {
+	tenantID := ports.TenantIDFromContext(ctx)
+
+	var ownerTenantID string
+	query := fmt.Sprintf("SELECT tenant_id FROM %s WHERE id = $1", tableName)
+	if err := pool.QueryRow(withAllTenants(ctx), query, id).Scan(&ownerTenantID); err != nil || ownerTenantID == tenantID {
+		return
+	}
+
+	logger.Warn("Cross-tenant access denied",
+		zap.String("security_event", "cross_tenant_access"),
+		zap.String("entity_type", entityType),
+		zap.String("id", id.String()),
+		zap.String("tenant_id", tenantID),
+		zap.String("owner_tenant_id", ownerTenantID))
+}
@@ -82,7 +82,37 @@ func SetupTestDatabase(t *testing.T) (*pgxpool.Pool, context.Context, *zap.Logge
 	// Return cleanup function
 	cleanup := func() {
 		// Drop tables to clean up
-		_, err := pool.Exec(ctx, `DROP TABLE IF EXISTS children`)
+		_, err := pool.Exec(ctx, `DROP TABLE IF EXISTS child_history, parent_history`)
+		if err != nil {
+			t.Logf("Failed to drop history tables: %v", err)
+		}
+
+		_, err = pool.Exec(ctx, `DROP TABLE IF EXISTS audit_log`)
+		if err != nil {
+			t.Logf("Failed to drop audit log table: %v", err)
+		}
+
+		_, err = pool.Exec(ctx, `DROP TABLE IF EXISTS webhook_deliveries, webhook_subscriptions`)
+		if err != nil {
+			t.Logf("Failed to drop webhook tables: %v", err)
+		}
+
+		_, err = pool.Exec(ctx, `DROP TABLE IF EXISTS outbox_dead_letters, outbox`)
+		if err != nil {
+			t.Logf("Failed to drop outbox table: %v", err)
+		}
+
+		_, err = pool.Exec(ctx, `DROP TABLE IF EXISTS household_children, household_parents, households`)
+		if err != nil {
+			t.Logf("Failed to drop household tables: %v", err)
+		}
+
+		_, err = pool.Exec(ctx, `DROP TABLE IF EXISTS guardianships`)
+		if err != nil {
+			t.Logf("Failed to drop guardianships table: %v", err)
+		}
+
+		_, err = pool.Exec(ctx, `DROP TABLE IF EXISTS children`)
 		if err != nil {
 			t.Logf("Failed to drop children table: %v", err)
 		}
@@ -110,11 +140,17 @@ func SetupTestRepositories(t *testing.T) (ports.RepositoryFactory, context.Conte
 
 	// Create a new repository factory using the existing pool
 	factory := &RepositoryFactory{
-		pool:               pool,
-		logger:             logger,
-		transactionManager: NewTransactionManager(pool, logger),
-		parentRepository:   NewParentRepository(pool, logger),
-		childRepository:    NewChildRepository(pool, logger),
+		pool:                   pool,
+		logger:                 logger,
+		transactionManager:     NewTransactionManager(pool, logger),
+		parentRepository:       NewParentRepository(pool, logger),
+		childRepository:        NewChildRepository(pool, logger),
+		outboxRepository:       NewOutboxRepository(pool, logger),
+		webhookRepository:      NewWebhookRepository(pool, logger),
+		auditLogRepository:     NewAuditLogRepository(pool, logger),
+		historyRepository:      NewHistoryRepository(pool, logger),
+		guardianshipRepository: NewGuardianshipRepository(pool, logger),
+		householdRepository:    NewHouseholdRepository(pool, logger),
 	}
 
 	return factory, ctx, cleanup
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
@@ -19,6 +21,8 @@ const (
 	txKey contextKey = iota
 	// originalCtxKey is the key for the original context
 	originalCtxKey
+	// allTenantsKey is the key that marks a context whose statements work across tenants
+	allTenantsKey
 )
 
 // TransactionManager implements the ports.TransactionManager interface for PostgreSQL
@@ -60,6 +64,13 @@ func (tm *TransactionManager) BeginTx(ctx context.Context) (context.Context, err
 		return ctx, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
+	// Scope the row-level security policies to the caller's tenant until the transaction ends
+	if _, err := tx.Exec(ctx, "SELECT set_config('app.tenant_id', $1, true)", ports.TenantIDFromContext(ctx)); err != nil {
+		tm.logger.Error("Failed to set transaction tenant", zap.Error(err))
+		_ = tx.Rollback(ctx)
+		return ctx, fmt.Errorf("failed to set transaction tenant: %w", err)
+	}
+
 	tm.logger.Debug("Transaction started")
 	return context.WithValue(ctx, txKey, tx), nil
 }
@@ -118,6 +129,49 @@ func GetTx(ctx context.Context) pgx.Tx {
 	return getTx(ctx)
 }
 
+// querier is implemented by both the connection pool and a transaction
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+// conn returns the transaction stored in the context, so that statements join it and its
+// row-level security scope, or the pool when there is no transaction
+func conn(ctx context.Context, pool *pgxpool.Pool) querier {
+	if tx := getTx(ctx); tx != nil {
+		return tx
+	}
+	return pool
+}
+
+// inTx runs fn within the transaction stored in the context, so that its statements commit or roll back
+// with the caller's, or within a transaction of its own, which it commits when fn succeeds, when there is none
+func inTx(ctx context.Context, pool *pgxpool.Pool, fn func(tx querier) error) error {
+	if tx := getTx(ctx); tx != nil {
+		return fn(tx)
+	}
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		// Rolling back a committed transaction does nothing
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
 // WithTx is a helper function to execute a function within a transaction
 // If the function returns an error, the transaction is rolled back
 // Otherwise, the transaction is committed
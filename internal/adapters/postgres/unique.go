@@ -0,0 +1,20 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolation is the SQLSTATE of a statement that would have stored a duplicate value in a unique index
+const uniqueViolation = "23505"
+
+// parentEmailIndex is the unique index of the emails of the non-deleted parents of a tenant, regardless of case
+const parentEmailIndex = "idx_parents_email"
+
+// isUniqueViolation reports whether a statement failed because it would have stored a value that
+// another row already has in the given unique index
+func isUniqueViolation(err error, indexName string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == indexName
+}
@@ -0,0 +1,21 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+)
+
+// isVersionConflict reports whether an update that matched no row failed because the entity of the
+// caller's tenant is at another version than the one that was read, rather than because it does not exist
+func isVersionConflict(ctx context.Context, q querier, tableName string, id uuid.UUID) bool {
+	var exists bool
+	query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL)", tableName)
+	if err := q.QueryRow(ctx, query, id, ports.TenantIDFromContext(ctx)).Scan(&exists); err != nil {
+		return false
+	}
+
+	return exists
+}
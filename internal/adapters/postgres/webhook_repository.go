@@ -0,0 +1,355 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// webhookDeliveryColumns are the columns read by scanWebhookDelivery, in order
+const webhookDeliveryColumns = `id, subscription_id, tenant_id, event_id, event_type, payload, status, attempts,
+	next_attempt_at, COALESCE(last_error, ''), COALESCE(response_status, 0), created_at, delivered_at`
+
+// WebhookRepository implements the ports.WebhookRepository interface for PostgreSQL
+type WebhookRepository struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+	tracer trace.Tracer
+}
+
+// NewWebhookRepository creates a new PostgreSQL webhook repository
+func NewWebhookRepository(pool *pgxpool.Pool, logger *zap.Logger) *WebhookRepository {
+	return &WebhookRepository{
+		pool:   pool,
+		logger: logger,
+		tracer: otel.Tracer("postgres.webhook_repository"),
+	}
+}
+
+// CreateSubscription stores a new subscription of the caller's tenant
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	ctx, span := r.tracer.Start(ctx, "WebhookRepository.CreateSubscription")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("webhook.subscription_id", subscription.ID.String()))
+
+	subscription.TenantID = ports.TenantIDFromContext(ctx)
+
+	eventTypes := make([]string, len(subscription.EventTypes))
+	for i, eventType := range subscription.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+
+	query := `
+		INSERT INTO webhook_subscriptions (id, tenant_id, url, event_types, secret, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		subscription.ID,
+		subscription.TenantID,
+		subscription.URL,
+		eventTypes,
+		subscription.Secret,
+		subscription.CreatedAt,
+	)
+	if err != nil {
+		r.logger.Error("Failed to create webhook subscription", zap.Error(err), zap.String("subscription_id", subscription.ID.String()))
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// GetSubscription retrieves a subscription of the caller's tenant by ID
+func (r *WebhookRepository) GetSubscription(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	ctx, span := r.tracer.Start(ctx, "WebhookRepository.GetSubscription")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("webhook.subscription_id", id.String()))
+
+	query := `
+		SELECT id, tenant_id, url, event_types, secret, created_at
+		FROM webhook_subscriptions
+		WHERE id = $1 AND tenant_id = $2
+	`
+
+	subscription, err := scanWebhookSubscription(conn(ctx, r.pool).QueryRow(ctx, query, id, ports.TenantIDFromContext(ctx)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			r.logger.Debug("Webhook subscription not found", zap.String("subscription_id", id.String()))
+			reportCrossTenantAccess(ctx, r.pool, r.logger, "webhook_subscriptions", "WebhookSubscription", id)
+			return nil, fmt.Errorf("webhook subscription not found: %w", err)
+		}
+		r.logger.Error("Failed to get webhook subscription", zap.Error(err), zap.String("subscription_id", id.String()))
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// ListSubscriptions retrieves the subscriptions of the caller's tenant, oldest first
+func (r *WebhookRepository) ListSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	ctx, span := r.tracer.Start(ctx, "WebhookRepository.ListSubscriptions")
+	defer span.End()
+
+	query := `
+		SELECT id, tenant_id, url, event_types, secret, created_at
+		FROM webhook_subscriptions
+		WHERE tenant_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, ports.TenantIDFromContext(ctx))
+	if err != nil {
+		r.logger.Error("Failed to list webhook subscriptions", zap.Error(err))
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := []*domain.WebhookSubscription{}
+	for rows.Next() {
+		subscription, err := scanWebhookSubscription(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan webhook subscription", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan webhook subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating webhook subscriptions", zap.Error(err))
+		return nil, fmt.Errorf("error iterating webhook subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// DeleteSubscription removes a subscription of the caller's tenant; its deliveries are removed with it
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "WebhookRepository.DeleteSubscription")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("webhook.subscription_id", id.String()))
+
+	result, err := conn(ctx, r.pool).Exec(ctx,
+		`DELETE FROM webhook_subscriptions WHERE id = $1 AND tenant_id = $2`,
+		id, ports.TenantIDFromContext(ctx))
+	if err != nil {
+		r.logger.Error("Failed to delete webhook subscription", zap.Error(err), zap.String("subscription_id", id.String()))
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		r.logger.Debug("Webhook subscription not found for deletion", zap.String("subscription_id", id.String()))
+		return fmt.Errorf("webhook subscription not found for deletion")
+	}
+
+	return nil
+}
+
+// EnqueueDelivery stores a new delivery, unless the event has already been enqueued for the subscription
+func (r *WebhookRepository) EnqueueDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	ctx, span := r.tracer.Start(ctx, "WebhookRepository.EnqueueDelivery")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("webhook.subscription_id", delivery.SubscriptionID.String()),
+		attribute.String("event.id", delivery.EventID.String()),
+	)
+
+	query := `
+		INSERT INTO webhook_deliveries (id, subscription_id, tenant_id, event_id, event_type, payload, status, attempts, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		delivery.ID,
+		delivery.SubscriptionID,
+		delivery.TenantID,
+		delivery.EventID,
+		string(delivery.EventType),
+		delivery.Payload,
+		string(delivery.Status),
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.CreatedAt,
+	)
+	if err != nil {
+		r.logger.Error("Failed to enqueue webhook delivery", zap.Error(err), zap.String("delivery_id", delivery.ID.String()))
+		return fmt.Errorf("failed to enqueue webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// FetchDueDeliveries retrieves up to limit pending deliveries of all tenants that are due at now, oldest first.
+// It works across tenants.
+func (r *WebhookRepository) FetchDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	ctx, span := r.tracer.Start(withAllTenants(ctx), "WebhookRepository.FetchDueDeliveries")
+	defer span.End()
+
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE status = $1 AND next_attempt_at <= $2
+		ORDER BY next_attempt_at, created_at
+		LIMIT $3
+	`
+
+	return r.queryDeliveries(ctx, query, string(domain.DeliveryPending), now.UTC(), limit)
+}
+
+// UpdateDelivery stores the outcome of an attempt to deliver
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	ctx, span := r.tracer.Start(ctx, "WebhookRepository.UpdateDelivery")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("webhook.delivery_id", delivery.ID.String()))
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = $2, next_attempt_at = $3, last_error = $4, response_status = $5, delivered_at = $6
+		WHERE id = $7 AND tenant_id = $8
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		string(delivery.Status),
+		delivery.Attempts,
+		delivery.NextAttemptAt.UTC(),
+		nullString(delivery.LastError),
+		sql.NullInt32{Int32: int32(delivery.ResponseStatus), Valid: delivery.ResponseStatus != 0},
+		delivery.DeliveredAt,
+		delivery.ID,
+		delivery.TenantID,
+	)
+	if err != nil {
+		r.logger.Error("Failed to update webhook delivery", zap.Error(err), zap.String("delivery_id", delivery.ID.String()))
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// ListDeliveries retrieves the deliveries of the caller's tenant selected by the filter, newest first
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, filter ports.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	ctx, span := r.tracer.Start(ctx, "WebhookRepository.ListDeliveries")
+	defer span.End()
+
+	conditions := []string{"tenant_id = $1"}
+	args := []any{ports.TenantIDFromContext(ctx)}
+
+	if filter.SubscriptionID != nil {
+		args = append(args, *filter.SubscriptionID)
+		conditions = append(conditions, fmt.Sprintf("subscription_id = $%d", len(args)))
+	}
+	if filter.Status != nil {
+		args = append(args, string(*filter.Status))
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	args = append(args, filter.Limit)
+
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY created_at DESC, id
+		LIMIT ` + fmt.Sprintf("$%d", len(args))
+
+	return r.queryDeliveries(ctx, query, args...)
+}
+
+// queryDeliveries runs a query selecting webhookDeliveryColumns and scans the deliveries
+func (r *WebhookRepository) queryDeliveries(ctx context.Context, query string, args ...any) ([]*domain.WebhookDelivery, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Failed to list webhook deliveries", zap.Error(err))
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []*domain.WebhookDelivery{}
+	for rows.Next() {
+		var delivery domain.WebhookDelivery
+		var eventType, status string
+		var deliveredAt sql.NullTime
+
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.SubscriptionID,
+			&delivery.TenantID,
+			&delivery.EventID,
+			&eventType,
+			&delivery.Payload,
+			&status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.LastError,
+			&delivery.ResponseStatus,
+			&delivery.CreatedAt,
+			&deliveredAt,
+		)
+		if err != nil {
+			r.logger.Error("Failed to scan webhook delivery", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+
+		delivery.EventType = domain.EventType(eventType)
+		delivery.Status = domain.DeliveryStatus(status)
+		delivery.NextAttemptAt = delivery.NextAttemptAt.UTC()
+		if deliveredAt.Valid {
+			delivered := deliveredAt.Time.UTC()
+			delivery.DeliveredAt = &delivered
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating webhook deliveries", zap.Error(err))
+		return nil, fmt.Errorf("error iterating webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// scanWebhookSubscription scans a row of id, tenant_id, url, event_types, secret and created_at
+func scanWebhookSubscription(row pgx.Row) (*domain.WebhookSubscription, error) {
+	var subscription domain.WebhookSubscription
+	var eventTypes []string
+
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.TenantID,
+		&subscription.URL,
+		&eventTypes,
+		&subscription.Secret,
+		&subscription.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.EventTypes = make([]domain.EventType, len(eventTypes))
+	for i, eventType := range eventTypes {
+		subscription.EventTypes[i] = domain.EventType(eventType)
+	}
+
+	return &subscription, nil
+}
+
+// Ensure WebhookRepository implements ports.WebhookRepository
+var _ ports.WebhookRepository = (*WebhookRepository)(nil)
@@ -0,0 +1,89 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/postgres"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWebhookRepositoryIntegration tests the PostgreSQL webhook repository with a real PostgreSQL database
+func TestWebhookRepositoryIntegration(t *testing.T) {
+	// Skip if short flag is set
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	// Set up test repositories using the helper
+	factory, ctx, cleanup := postgres.SetupTestRepositories(t)
+	defer cleanup()
+
+	webhooks := factory.NewWebhookRepository()
+	tenantCtx := ports.WithTenantID(ctx, "tenant-a")
+
+	subscription := domain.NewWebhookSubscription("https://example.com/hooks",
+		[]domain.EventType{domain.EventChildCreated, domain.EventChildDeleted}, "0123456789abcdef")
+	require.NoError(t, webhooks.CreateSubscription(tenantCtx, subscription))
+
+	// Test that subscriptions are only visible to their tenant
+	t.Run("SubscriptionsOfTenant", func(t *testing.T) {
+		got, err := webhooks.GetSubscription(tenantCtx, subscription.ID)
+		require.NoError(t, err)
+		assert.Equal(t, subscription.URL, got.URL)
+		assert.Equal(t, subscription.EventTypes, got.EventTypes)
+		assert.Equal(t, subscription.Secret, got.Secret)
+		assert.Equal(t, "tenant-a", got.TenantID)
+
+		_, err = webhooks.GetSubscription(ports.WithTenantID(ctx, "tenant-b"), subscription.ID)
+		assert.Error(t, err)
+
+		subscriptions, err := webhooks.ListSubscriptions(ports.WithTenantID(ctx, "tenant-b"))
+		require.NoError(t, err)
+		assert.Empty(t, subscriptions)
+	})
+
+	// Test that an event is enqueued once per subscription, and that the outcome of an attempt is stored
+	t.Run("EnqueueFetchAndUpdate", func(t *testing.T) {
+		event := domain.NewEvent(domain.EventChildCreated, uuid.New(), uuid.New())
+		delivery := domain.NewWebhookDelivery(subscription, event, `{"type":"CHILD_CREATED"}`)
+		require.NoError(t, webhooks.EnqueueDelivery(tenantCtx, delivery))
+		require.NoError(t, webhooks.EnqueueDelivery(tenantCtx, domain.NewWebhookDelivery(subscription, event, `{}`)))
+
+		due, err := webhooks.FetchDueDeliveries(ctx, time.Now().Add(time.Second), 100)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		assert.Equal(t, delivery.ID, due[0].ID)
+		assert.Equal(t, delivery.Payload, due[0].Payload)
+
+		due[0].MarkFailed(503, "unavailable", time.Now().Add(time.Hour).UTC())
+		require.NoError(t, webhooks.UpdateDelivery(ctx, due[0]))
+
+		due, err = webhooks.FetchDueDeliveries(ctx, time.Now().Add(time.Second), 100)
+		require.NoError(t, err)
+		assert.Empty(t, due)
+
+		pending := domain.DeliveryPending
+		deliveries, err := webhooks.ListDeliveries(tenantCtx, ports.WebhookDeliveryFilter{SubscriptionID: &subscription.ID, Status: &pending, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, 1, deliveries[0].Attempts)
+		assert.Equal(t, 503, deliveries[0].ResponseStatus)
+		assert.Equal(t, "unavailable", deliveries[0].LastError)
+	})
+
+	// Test that deleting a subscription deletes its deliveries
+	t.Run("DeleteSubscription", func(t *testing.T) {
+		require.NoError(t, webhooks.DeleteSubscription(tenantCtx, subscription.ID))
+
+		deliveries, err := webhooks.ListDeliveries(tenantCtx, ports.WebhookDeliveryFilter{Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, deliveries)
+
+		assert.Error(t, webhooks.DeleteSubscription(tenantCtx, subscription.ID))
+	})
+}
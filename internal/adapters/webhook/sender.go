@@ -0,0 +1,210 @@
+// Package webhook provides the HTTP adapter that delivers webhook payloads to their subscribers.
+// Every request carries an HMAC-SHA256 signature of its timestamp and body, computed with the
+// subscription's secret, so that receivers can check where it comes from and reject replays.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Headers of a webhook request
+const (
+	// SignatureHeader holds "sha256=" followed by the hex encoded signature, see Sign
+	SignatureHeader = "X-Family-Signature"
+	// TimestampHeader holds the Unix time at which the request was signed
+	TimestampHeader = "X-Family-Timestamp"
+	// EventHeader holds the type of the delivered event, such as CHILD_CREATED
+	EventHeader = "X-Family-Event"
+	// DeliveryHeader holds the ID of the delivery, which is the same for every attempt
+	DeliveryHeader = "X-Family-Delivery"
+)
+
+// DefaultTimeout is how long a receiver has to respond, unless the sender is configured otherwise
+const DefaultTimeout = 10 * time.Second
+
+// ErrForbiddenAddress is returned when the host of a subscription's URL resolves to an address that is not
+// public, see domain.IsPublicAddress
+var ErrForbiddenAddress = errors.New("webhook receiver address is not public")
+
+// maxResponseBody is how much of a response is read so that the connection can be reused
+const maxResponseBody = 64 << 10
+
+// HTTPSender implements the ports.WebhookSender interface by posting the payload to the subscription's URL.
+// A delivery succeeds when the receiver responds with a 2xx status; redirects are not followed.
+// The sender only connects to public addresses, which it checks once the host of the URL is resolved,
+// and connects directly rather than through the proxies of the environment, which would resolve it.
+type HTTPSender struct {
+	client *http.Client
+	logger *zap.Logger
+	tracer trace.Tracer
+	now    func() time.Time
+}
+
+// NewHTTPSender creates a new HTTP webhook sender.
+// Parameters:
+//   - timeout: How long a receiver has to respond; DefaultTimeout is used if it is not positive
+//   - logger: Logger for logging sender operations
+//
+// Returns:
+//   - *HTTPSender: A new HTTP webhook sender
+func NewHTTPSender(timeout time.Duration, logger *zap.Logger) *HTTPSender {
+	return NewHTTPSenderForAddresses(timeout, logger, domain.IsPublicAddress)
+}
+
+// NewHTTPSenderForAddresses creates a new HTTP webhook sender that only connects to the addresses allowed
+// accepts, such as the loopback addresses of the receivers of tests.
+// Parameters:
+//   - timeout: How long a receiver has to respond; DefaultTimeout is used if it is not positive
+//   - logger: Logger for logging sender operations
+//   - allowed: Checks if the sender may connect to an address
+//
+// Returns:
+//   - *HTTPSender: A new HTTP webhook sender
+func NewHTTPSenderForAddresses(timeout time.Duration, logger *zap.Logger, allowed func(netip.Addr) bool) *HTTPSender {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	// The addresses are checked as they are dialed, so that a host that resolves to another address than
+	// when the subscription was created, or to several ones, is never reached at a forbidden one
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !allowed(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: otelhttp.NewTransport(transport),
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		logger: logger,
+		tracer: otel.Tracer("webhook.http_sender"),
+		now:    time.Now,
+	}
+}
+
+// Send posts the payload of the delivery to the subscription's URL, signed with its secret.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - subscription: The subscription the delivery is made to
+//   - delivery: The delivery to make
+//
+// Returns:
+//   - int: The HTTP status code of the response, or 0 if there was no response
+//   - error: An error if there was no response or the response status is not 2xx
+func (s *HTTPSender) Send(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "HTTPSender.Send")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("webhook.delivery_id", delivery.ID.String()),
+		attribute.String("webhook.subscription_id", subscription.ID.String()),
+		attribute.String("event.type", string(delivery.EventType)),
+	)
+
+	payload := []byte(delivery.Payload)
+	timestamp := s.now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "family-service-webhooks")
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, timestamp, payload))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(EventHeader, string(delivery.EventType))
+	req.Header.Set(DeliveryHeader, delivery.ID.String())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.CopyN(io.Discard, resp.Body, maxResponseBody)
+
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook receiver responded with status %d", resp.StatusCode)
+	}
+
+	s.logger.Debug("Webhook delivered",
+		zap.String("delivery_id", delivery.ID.String()),
+		zap.Int("status", resp.StatusCode))
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns the value of the signature header for a payload sent at the given Unix time:
+// "sha256=" followed by the hex encoded HMAC-SHA256 of the timestamp, a dot and the payload.
+// Parameters:
+//   - secret: The secret of the subscription
+//   - timestamp: The Unix time sent in the timestamp header
+//   - payload: The request body
+//
+// Returns:
+//   - string: The signature
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a webhook request in constant time, as a receiver would.
+// Receivers should also reject requests whose timestamp is too old, to prevent replays.
+// Parameters:
+//   - secret: The secret of the subscription
+//   - timestamp: The value of the timestamp header
+//   - payload: The request body
+//   - signature: The value of the signature header
+//
+// Returns:
+//   - bool: true if the signature matches, false otherwise
+func Verify(secret, timestamp string, payload []byte, signature string) bool {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, unix, payload)), []byte(signature))
+}
+
+// Ensure HTTPSender implements ports.WebhookSender
+var _ ports.WebhookSender = (*HTTPSender)(nil)
@@ -0,0 +1,174 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/webhook"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+const testSecret = "0123456789abcdef"
+
+// anyAddress allows the sender to connect to the loopback addresses of the test receivers
+func anyAddress(netip.Addr) bool { return true }
+
+// newDelivery creates a subscription to the URL and a delivery of a child event to it
+func newDelivery(url string) (*domain.WebhookSubscription, *domain.WebhookDelivery) {
+	subscription := domain.NewWebhookSubscription(url, []domain.EventType{domain.EventChildCreated}, testSecret)
+	event := domain.NewEvent(domain.EventChildCreated, uuid.New(), uuid.New())
+	return subscription, domain.NewWebhookDelivery(subscription, event, `{"type":"CHILD_CREATED"}`)
+}
+
+// TestHTTPSender_SignsPayload tests that the receiver gets the payload with a signature it can verify
+func TestHTTPSender_SignsPayload(t *testing.T) {
+	// Arrange
+	var received *http.Request
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	sender := webhook.NewHTTPSenderForAddresses(0, zaptest.NewLogger(t), anyAddress)
+	subscription, delivery := newDelivery(receiver.URL)
+
+	// Act
+	status, err := sender.Send(context.Background(), subscription, delivery)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, status)
+	require.NotNil(t, received)
+	assert.Equal(t, http.MethodPost, received.Method)
+	assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+	assert.Equal(t, "CHILD_CREATED", received.Header.Get(webhook.EventHeader))
+	assert.Equal(t, delivery.ID.String(), received.Header.Get(webhook.DeliveryHeader))
+	assert.Equal(t, delivery.Payload, string(body))
+	assert.True(t, webhook.Verify(testSecret, received.Header.Get(webhook.TimestampHeader), body, received.Header.Get(webhook.SignatureHeader)))
+	assert.False(t, webhook.Verify("another secret!!", received.Header.Get(webhook.TimestampHeader), body, received.Header.Get(webhook.SignatureHeader)))
+}
+
+// TestHTTPSender_FailsOnErrorStatus tests that a response other than 2xx fails the delivery
+func TestHTTPSender_FailsOnErrorStatus(t *testing.T) {
+	testCases := []struct {
+		name   string
+		status int
+	}{
+		{"server error", http.StatusServiceUnavailable},
+		{"client error", http.StatusGone},
+		{"redirect", http.StatusFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tc.status == http.StatusFound {
+					w.Header().Set("Location", "/elsewhere")
+				}
+				w.WriteHeader(tc.status)
+			}))
+			defer receiver.Close()
+
+			sender := webhook.NewHTTPSenderForAddresses(0, zaptest.NewLogger(t), anyAddress)
+			subscription, delivery := newDelivery(receiver.URL)
+
+			// Act
+			status, err := sender.Send(context.Background(), subscription, delivery)
+
+			// Assert
+			assert.Error(t, err)
+			assert.Equal(t, tc.status, status)
+		})
+	}
+}
+
+// TestHTTPSender_FailsWithoutResponse tests that an unreachable receiver fails the delivery without a status
+func TestHTTPSender_FailsWithoutResponse(t *testing.T) {
+	// Arrange
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	url := receiver.URL
+	receiver.Close()
+
+	sender := webhook.NewHTTPSenderForAddresses(0, zaptest.NewLogger(t), anyAddress)
+	subscription, delivery := newDelivery(url)
+
+	// Act
+	status, err := sender.Send(context.Background(), subscription, delivery)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Zero(t, status)
+}
+
+// TestHTTPSender_RefusesLocalAddresses tests that the sender does not connect to a receiver on a loopback address
+func TestHTTPSender_RefusesLocalAddresses(t *testing.T) {
+	// Arrange
+	called := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	sender := webhook.NewHTTPSender(0, zaptest.NewLogger(t))
+	subscription, delivery := newDelivery(receiver.URL)
+
+	// Act
+	status, err := sender.Send(context.Background(), subscription, delivery)
+
+	// Assert
+	assert.True(t, errors.Is(err, webhook.ErrForbiddenAddress), "unexpected error %v", err)
+	assert.Zero(t, status)
+	assert.False(t, called)
+}
+
+// TestHTTPSender_RefusesInternalAddresses tests that the sender does not connect to the shared address space of
+// carrier-grade NAT, where clouds run internal services, nor to "this network"
+func TestHTTPSender_RefusesInternalAddresses(t *testing.T) {
+	for _, url := range []string{
+		"https://100.100.100.200/latest/meta-data",
+		"https://100.64.0.1/hooks",
+		"https://[::ffff:100.100.100.200]/hooks",
+		"https://0.1.2.3/hooks",
+	} {
+		t.Run(url, func(t *testing.T) {
+			// Arrange
+			sender := webhook.NewHTTPSender(time.Second, zaptest.NewLogger(t))
+			subscription, delivery := newDelivery(url)
+
+			// Act
+			status, err := sender.Send(context.Background(), subscription, delivery)
+
+			// Assert
+			assert.True(t, errors.Is(err, webhook.ErrForbiddenAddress), "unexpected error %v", err)
+			assert.Zero(t, status)
+		})
+	}
+}
+
+// TestSign tests that the signature covers the timestamp as well as the payload
+func TestSign(t *testing.T) {
+	payload := []byte(`{"type":"CHILD_DELETED"}`)
+
+	signature := webhook.Sign(testSecret, 1700000000, payload)
+
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, signature)
+	assert.True(t, webhook.Verify(testSecret, "1700000000", payload, signature))
+	assert.False(t, webhook.Verify(testSecret, "1700000001", payload, signature))
+	assert.False(t, webhook.Verify(testSecret, "not a number", payload, signature))
+	assert.False(t, webhook.Verify(testSecret, "1700000000", []byte(`{}`), signature))
+}
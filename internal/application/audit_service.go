@@ -0,0 +1,79 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// MaxAuditLogRecords is the maximum number of audit records retrieved at a time
+const MaxAuditLogRecords = 1000
+
+// AuditService implements the ports.AuditService interface.
+// It reads the audit log; the records are written by the FamilyService as part of each change.
+type AuditService struct {
+	auditLog ports.AuditLogRepository // Repository for audit records
+	logger   *zap.Logger              // Logs service operations
+	tracer   trace.Tracer             // Provides distributed tracing
+}
+
+// NewAuditService creates a new audit service.
+// Parameters:
+//   - auditLog: Repository for audit records
+//   - logger: Logger for logging service operations
+//
+// Returns:
+//   - *AuditService: A new instance of the audit service
+func NewAuditService(auditLog ports.AuditLogRepository, logger *zap.Logger) *AuditService {
+	return &AuditService{
+		auditLog: auditLog,
+		logger:   logger,
+		tracer:   otel.Tracer("application.audit_service"),
+	}
+}
+
+// GetAuditLog retrieves the audit records of an entity of the caller's tenant.
+// At most MaxAuditLogRecords records are retrieved; a narrower time range retrieves the rest.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - entityID: The unique identifier of the parent or child
+//   - from: The earliest time of the records to retrieve, or nil for no lower bound
+//   - to: The latest time of the records to retrieve, or nil for no upper bound
+//
+// Returns:
+//   - []*domain.AuditRecord: The records, oldest first
+//   - error: A ValidationError if the time range ends before it starts, or a database error
+func (s *AuditService) GetAuditLog(ctx context.Context, entityID uuid.UUID, from, to *time.Time) ([]*domain.AuditRecord, error) {
+	ctx, span := s.tracer.Start(ctx, "AuditService.GetAuditLog")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("audit.entity_id", entityID.String()))
+
+	if from != nil && to != nil && to.Before(*from) {
+		return nil, domain.NewValidationError("AuditRecord", "to", "must not be before from")
+	}
+
+	records, err := s.auditLog.List(ctx, ports.AuditLogFilter{
+		EntityID: entityID,
+		From:     from,
+		To:       to,
+		Limit:    MaxAuditLogRecords,
+	})
+	if err != nil {
+		s.logger.Error("Failed to list audit records", zap.Error(err), zap.String("entity_id", entityID.String()))
+		return nil, domain.NewDatabaseError("list", "AuditRecord", err)
+	}
+
+	span.SetAttributes(attribute.Int("audit.records", len(records)))
+	return records, nil
+}
+
+// Ensure AuditService implements ports.AuditService
+var _ ports.AuditService = (*AuditService)(nil)
@@ -0,0 +1,103 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/application"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/mocks"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// appendAuditRecord appends a record of an entity that occurred at the given time
+func appendAuditRecord(t *testing.T, ctx context.Context, auditLog *mocks.MockAuditLogRepository, entityID uuid.UUID, occurredAt time.Time) *domain.AuditRecord {
+	record := domain.NewAuditRecord("UpdateChild", domain.AuditEntityChild, entityID, nil, domain.AuditSnapshot{"firstName": "Jane"})
+	record.TenantID = ports.TenantIDFromContext(ctx)
+	record.OccurredAt = occurredAt
+	require.NoError(t, auditLog.Append(ctx, record))
+	return record
+}
+
+func TestAuditService_GetAuditLog(t *testing.T) {
+	// Arrange
+	auditLog := mocks.NewMockAuditLogRepository()
+	service := application.NewAuditService(auditLog, zaptest.NewLogger(t))
+	ctx := ports.WithTenantID(context.Background(), "tenant-a")
+
+	entityID := uuid.New()
+	now := time.Now().UTC()
+	older := appendAuditRecord(t, ctx, auditLog, entityID, now.Add(-2*time.Hour))
+	newer := appendAuditRecord(t, ctx, auditLog, entityID, now.Add(-time.Hour))
+	appendAuditRecord(t, ctx, auditLog, uuid.New(), now)
+	appendAuditRecord(t, ports.WithTenantID(context.Background(), "tenant-b"), auditLog, entityID, now)
+
+	// Act
+	records, err := service.GetAuditLog(ctx, entityID, nil, nil)
+
+	// Assert only the records of the entity and tenant are listed, oldest first
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, older.ID, records[0].ID)
+	assert.Equal(t, newer.ID, records[1].ID)
+
+	// Act and assert the time range selects the records
+	from := now.Add(-90 * time.Minute)
+	records, err = service.GetAuditLog(ctx, entityID, &from, nil)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, newer.ID, records[0].ID)
+}
+
+func TestAuditService_GetAuditLog_InvalidRange(t *testing.T) {
+	// Arrange
+	service := application.NewAuditService(mocks.NewMockAuditLogRepository(), zaptest.NewLogger(t))
+	from := time.Now()
+	to := from.Add(-time.Hour)
+
+	// Act
+	_, err := service.GetAuditLog(context.Background(), uuid.New(), &from, &to)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestAuditService_GetAuditLog_CapsRecords(t *testing.T) {
+	// Arrange
+	auditLog := mocks.NewMockAuditLogRepository()
+	var filter ports.AuditLogFilter
+	auditLog.ListFunc = func(ctx context.Context, f ports.AuditLogFilter) ([]*domain.AuditRecord, error) {
+		filter = f
+		return []*domain.AuditRecord{}, nil
+	}
+	service := application.NewAuditService(auditLog, zaptest.NewLogger(t))
+
+	// Act
+	_, err := service.GetAuditLog(context.Background(), uuid.New(), nil, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, application.MaxAuditLogRecords, filter.Limit)
+}
+
+func TestAuditService_GetAuditLog_DatabaseError(t *testing.T) {
+	// Arrange
+	auditLog := mocks.NewMockAuditLogRepository()
+	auditLog.ListFunc = func(ctx context.Context, filter ports.AuditLogFilter) ([]*domain.AuditRecord, error) {
+		return nil, errors.New("connection refused")
+	}
+	service := application.NewAuditService(auditLog, zaptest.NewLogger(t))
+
+	// Act
+	_, err := service.GetAuditLog(context.Background(), uuid.New(), nil, nil)
+
+	// Assert
+	var dbErr *domain.DatabaseError
+	assert.ErrorAs(t, err, &dbErr)
+}
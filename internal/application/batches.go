@@ -0,0 +1,449 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// CreateParents creates many parents at once. Every input gets a result at its position, holding the created
+// parent or the error that kept it from being created.
+// In atomic mode, every parent is validated before any is stored, and the parents are stored in a single
+// transaction with a batch insert, so that either all or none of them are created. When one of them fails,
+// the others fail with an error wrapping domain.ErrAborted. Otherwise, every parent is created on its own,
+// as with CreateParent.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - inputs: The information of the parents to create, at most ports.MaxBatchSize of them
+//   - atomic: Whether to create all of the parents or none of them
+//
+// Returns:
+//   - []ports.ParentResult: The results of the inputs, in the same order
+//   - error: A ValidationError if there are too many inputs, a TransactionError if the transaction fails,
+//     or a database error that is not about a single parent
+func (s *FamilyService) CreateParents(ctx context.Context, inputs []ports.ParentInput, atomic bool) ([]ports.ParentResult, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.CreateParents")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("batch.size", len(inputs)),
+		attribute.Bool("batch.atomic", atomic),
+	)
+
+	// Validate input
+	if len(inputs) > ports.MaxBatchSize {
+		return nil, batchSizeError("Parent", "inputs")
+	}
+
+	results := make([]ports.ParentResult, len(inputs))
+
+	// Without atomic mode, the parents are independent of each other
+	if !atomic {
+		for i, input := range inputs {
+			results[i].Parent, results[i].Err = s.CreateParent(ctx, input.FirstName, input.LastName, input.Email, input.BirthDate, input.Contact)
+		}
+		return results, nil
+	}
+
+	// Validate every parent before any is stored
+	parents := make([]*domain.Parent, len(inputs))
+	errs := make([]error, len(inputs))
+	failed := false
+	for i, input := range inputs {
+		parents[i], errs[i] = s.newParent(input.FirstName, input.LastName, input.Email, input.BirthDate, input.Contact)
+		failed = failed || errs[i] != nil
+	}
+	if failed {
+		for i, err := range abortBatch(errs) {
+			results[i].Err = err
+		}
+		return results, nil
+	}
+
+	// Begin transaction
+	ctx, err := s.transactionManager.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("begin", err)
+	}
+
+	// Save parents
+	err = s.parentRepo.CreateMany(ctx, parents)
+	if err != nil {
+		s.rollback(ctx)
+		s.logger.Error("Failed to create parents", zap.Error(err))
+
+		var batchErr *domain.BatchError
+		if !errors.As(err, &batchErr) {
+			return nil, domain.NewDatabaseError("create", "Parent", err)
+		}
+		errs[batchErr.Index] = domain.NewDatabaseError("create", "Parent", batchErr.Err)
+		for i, err := range abortBatch(errs) {
+			results[i].Err = err
+		}
+		return results, nil
+	}
+
+	// Record the events and the audit records with the changes
+	events := make([]domain.Event, 0, len(parents))
+	for _, parent := range parents {
+		event := domain.NewParentEvent(domain.EventParentCreated, parent)
+		change := domain.NewAuditRecord("CreateParents", domain.AuditEntityParent, parent.ID, nil, parent.AuditSnapshot())
+		if err := s.record(ctx, event, change); err != nil {
+			s.rollback(ctx)
+			s.logger.Error("Failed to record event", zap.Error(err), zap.String("event_type", string(event.Type)))
+			return nil, domain.NewDatabaseError("record", "Event", err)
+		}
+		events = append(events, event)
+	}
+
+	// Commit transaction
+	err = s.transactionManager.CommitTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("commit", err)
+	}
+
+	for _, event := range events {
+		s.publish(ctx, event)
+	}
+
+	for i, parent := range parents {
+		results[i].Parent = parent
+	}
+
+	return results, nil
+}
+
+// CreateChildren creates many children at once, each with its parent as its first guardian and primary contact.
+// Every input gets a result at its position, holding the created child or the error that kept it from being created.
+// In atomic mode, every child is validated before any is stored, and the children are stored in a single
+// transaction with a batch insert, so that either all or none of them are created. When one of them fails,
+// the others fail with an error wrapping domain.ErrAborted. Otherwise, every child is created on its own,
+// as with CreateChild.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - inputs: The information of the children to create, at most ports.MaxBatchSize of them
+//   - atomic: Whether to create all of the children or none of them
+//
+// Returns:
+//   - []ports.ChildResult: The results of the inputs, in the same order
+//   - error: A ValidationError if there are too many inputs, a TransactionError if the transaction fails,
+//     or a database error that is not about a single child
+func (s *FamilyService) CreateChildren(ctx context.Context, inputs []ports.ChildInput, atomic bool) ([]ports.ChildResult, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.CreateChildren")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("batch.size", len(inputs)),
+		attribute.Bool("batch.atomic", atomic),
+	)
+
+	// Validate input
+	if len(inputs) > ports.MaxBatchSize {
+		return nil, batchSizeError("Child", "inputs")
+	}
+
+	results := make([]ports.ChildResult, len(inputs))
+
+	// Without atomic mode, the children are independent of each other
+	if !atomic {
+		for i, input := range inputs {
+			results[i].Child, results[i].Err = s.CreateChild(ctx, input.FirstName, input.LastName, input.BirthDate, input.ParentID)
+		}
+		return results, nil
+	}
+
+	// Validate every child before any is stored
+	children := make([]*domain.Child, len(inputs))
+	errs := make([]error, len(inputs))
+	failed := false
+	for i, input := range inputs {
+		children[i], errs[i] = s.newChild(input.FirstName, input.LastName, input.BirthDate, input.ParentID)
+		failed = failed || errs[i] != nil
+	}
+	if failed {
+		for i, err := range abortBatch(errs) {
+			results[i].Err = err
+		}
+		return results, nil
+	}
+
+	// Begin transaction
+	ctx, err := s.transactionManager.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("begin", err)
+	}
+
+	// Get the parents to update their children arrays
+	parentIDs := parentIDsOf(children)
+	parents, err := s.parentRepo.GetByIDs(ctx, parentIDs)
+	if err != nil {
+		s.rollback(ctx)
+		s.logger.Error("Failed to get parents", zap.Error(err), zap.Int("count", len(parentIDs)))
+		return nil, domain.NewDatabaseError("get", "Parent", err)
+	}
+
+	parentsByID := make(map[uuid.UUID]*domain.Parent, len(parents))
+	for _, parent := range parents {
+		parentsByID[parent.ID] = parent
+	}
+	for i, child := range children {
+		if parentsByID[child.ParentID] == nil {
+			errs[i] = domain.NewNotFoundError("Parent", child.ParentID.String())
+			failed = true
+		}
+	}
+	if failed {
+		s.rollback(ctx)
+		for i, err := range abortBatch(errs) {
+			results[i].Err = err
+		}
+		return results, nil
+	}
+
+	// Save children
+	err = s.childRepo.CreateMany(ctx, children)
+	if err != nil {
+		s.rollback(ctx)
+		s.logger.Error("Failed to create children", zap.Error(err))
+
+		var batchErr *domain.BatchError
+		if !errors.As(err, &batchErr) {
+			return nil, domain.NewDatabaseError("create", "Child", err)
+		}
+		errs[batchErr.Index] = domain.NewDatabaseError("create", "Child", batchErr.Err)
+		for i, err := range abortBatch(errs) {
+			results[i].Err = err
+		}
+		return results, nil
+	}
+
+	// The parents are the first guardians of their children, and their primary contacts
+	for _, child := range children {
+		guardianship := domain.NewGuardianship(child.ParentID, child.ID, domain.GuardianshipGuardian, child.CreatedAt, true)
+		err = s.guardianshipRepo.Create(ctx, guardianship)
+		if err != nil {
+			s.rollback(ctx)
+			s.logger.Error("Failed to create guardianship", zap.Error(err), zap.String("child_id", child.ID.String()))
+			return nil, domain.NewDatabaseError("create", "Guardianship", err)
+		}
+
+		parentsByID[child.ParentID].AddChild(*child)
+	}
+
+	// Add the children to their parents' children arrays, updating every parent once
+	for _, parent := range parents {
+		err = s.parentRepo.Update(ctx, parent)
+		if err != nil {
+			s.rollback(ctx)
+			s.logger.Error("Failed to update parent with children", zap.Error(err), zap.String("parent_id", parent.ID.String()))
+			return nil, domain.NewDatabaseError("update", "Parent", err)
+		}
+	}
+
+	// Record the events and the audit records with the changes
+	events := make([]domain.Event, 0, len(children))
+	for _, child := range children {
+		event := domain.NewChildEvent(domain.EventChildCreated, child)
+		change := domain.NewAuditRecord("CreateChildren", domain.AuditEntityChild, child.ID, nil, child.AuditSnapshot())
+		if err := s.record(ctx, event, change); err != nil {
+			s.rollback(ctx)
+			s.logger.Error("Failed to record event", zap.Error(err), zap.String("event_type", string(event.Type)))
+			return nil, domain.NewDatabaseError("record", "Event", err)
+		}
+		events = append(events, event)
+	}
+
+	// Commit transaction
+	err = s.transactionManager.CommitTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("commit", err)
+	}
+
+	for _, event := range events {
+		s.publish(ctx, event)
+	}
+
+	for i, child := range children {
+		results[i].Child = child
+	}
+
+	return results, nil
+}
+
+// DeleteChildren marks many children as deleted at once and removes them from their parents' children.
+// Every ID gets a result at its position, holding the error that kept the child from being deleted, if any.
+// In atomic mode, the children are deleted in a single transaction, so that either all or none of them are.
+// When one of them fails, the others fail with an error wrapping domain.ErrAborted. Otherwise, every child
+// is deleted on its own, as with DeleteChild.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - ids: The unique identifiers of the children to delete, at most ports.MaxBatchSize of them
+//   - atomic: Whether to delete all of the children or none of them
+//
+// Returns:
+//   - []ports.DeleteResult: The results of the IDs, in the same order
+//   - error: A ValidationError if there are too many IDs, a TransactionError if the transaction fails,
+//     or a database error that is not about a single child
+func (s *FamilyService) DeleteChildren(ctx context.Context, ids []uuid.UUID, atomic bool) ([]ports.DeleteResult, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.DeleteChildren")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("batch.size", len(ids)),
+		attribute.Bool("batch.atomic", atomic),
+	)
+
+	// Validate input
+	if len(ids) > ports.MaxBatchSize {
+		return nil, batchSizeError("Child", "ids")
+	}
+
+	results := make([]ports.DeleteResult, len(ids))
+	for i, id := range ids {
+		results[i].ID = id
+	}
+
+	// Without atomic mode, the children are independent of each other
+	if !atomic {
+		for i, id := range ids {
+			results[i].Err = s.DeleteChild(ctx, id)
+		}
+		return results, nil
+	}
+
+	// Begin transaction
+	ctx, err := s.transactionManager.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("begin", err)
+	}
+
+	// Get the children to find their parents, and delete them
+	children := make([]*domain.Child, len(ids))
+	errs := make([]error, len(ids))
+	failed := false
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for i, id := range ids {
+		if seen[id] {
+			errs[i] = domain.NewValidationError("Child", "ids", "must not hold the same child twice")
+			failed = true
+			continue
+		}
+		seen[id] = true
+
+		children[i], err = s.childRepo.GetByID(ctx, id)
+		if err == nil {
+			err = s.childRepo.Delete(ctx, id)
+		}
+		if err != nil {
+			s.logger.Error("Failed to delete child", zap.Error(err), zap.String("child_id", id.String()))
+
+			failed = true
+
+			// Check if this is a "not found" error; after another error, the transaction cannot go on
+			if !strings.Contains(err.Error(), "not found") {
+				errs[i] = domain.NewDatabaseError("delete", "Child", err)
+				break
+			}
+			errs[i] = domain.NewNotFoundError("Child", id.String())
+		}
+	}
+	if failed {
+		s.rollback(ctx)
+		for i, err := range abortBatch(errs) {
+			results[i].Err = err
+		}
+		return results, nil
+	}
+
+	// Remove the children from their parents' children arrays, updating every parent once.
+	// Parents that are not found are skipped, as with DeleteChild.
+	parentIDs := parentIDsOf(children)
+	parents, err := s.parentRepo.GetByIDs(ctx, parentIDs)
+	if err != nil {
+		s.rollback(ctx)
+		s.logger.Error("Failed to get parents for child deletion", zap.Error(err), zap.Int("count", len(parentIDs)))
+		return nil, domain.NewDatabaseError("get", "Parent", err)
+	}
+
+	for _, parent := range parents {
+		for _, child := range children {
+			if child.ParentID == parent.ID {
+				parent.RemoveChild(child.ID)
+			}
+		}
+
+		err = s.parentRepo.Update(ctx, parent)
+		if err != nil {
+			s.rollback(ctx)
+			s.logger.Error("Failed to update parent after child deletion", zap.Error(err), zap.String("parent_id", parent.ID.String()))
+			return nil, domain.NewDatabaseError("update", "Parent", err)
+		}
+	}
+
+	// Record the events and the audit records with the changes
+	events := make([]domain.Event, 0, len(children))
+	for _, child := range children {
+		event := domain.NewChildEvent(domain.EventChildDeleted, child)
+		change := domain.NewAuditRecord("DeleteChildren", domain.AuditEntityChild, child.ID, domain.DeletionSnapshot(false), domain.DeletionSnapshot(true))
+		if err := s.record(ctx, event, change); err != nil {
+			s.rollback(ctx)
+			s.logger.Error("Failed to record event", zap.Error(err), zap.String("event_type", string(event.Type)))
+			return nil, domain.NewDatabaseError("record", "Event", err)
+		}
+		events = append(events, event)
+	}
+
+	// Commit transaction
+	err = s.transactionManager.CommitTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("commit", err)
+	}
+
+	for _, event := range events {
+		s.publish(ctx, event)
+	}
+
+	return results, nil
+}
+
+// batchSizeError returns the ValidationError of a batch that holds more than ports.MaxBatchSize items
+func batchSizeError(entityType, field string) error {
+	return domain.NewValidationError(entityType, field, "must hold at most "+strconv.Itoa(ports.MaxBatchSize)+" items")
+}
+
+// parentIDsOf returns the IDs of the parents of children, each once
+func parentIDsOf(children []*domain.Child) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(children))
+	parentIDs := make([]uuid.UUID, 0, len(children))
+	for _, child := range children {
+		if !seen[child.ParentID] {
+			seen[child.ParentID] = true
+			parentIDs = append(parentIDs, child.ParentID)
+		}
+	}
+	return parentIDs
+}
+
+// abortBatch returns the errors of the items of an atomic batch that failed, which was rolled back:
+// the items that failed keep their errors, and the others get domain.ErrAborted
+func abortBatch(errs []error) []error {
+	for i, err := range errs {
+		if err == nil {
+			errs[i] = domain.ErrAborted
+		}
+	}
+	return errs
+}
@@ -0,0 +1,319 @@
+package application
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/agnivade/levenshtein"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// Weights of the similarities of the names, email addresses and birth dates of two parents in their score
+const (
+	nameWeight      = 0.5
+	emailWeight     = 0.3
+	birthDateWeight = 0.2
+)
+
+// DefaultDuplicateScore is the score from which two parents are possible duplicates,
+// unless FindDuplicateParents is asked for another minimum
+const DefaultDuplicateScore = 0.75
+
+// DefaultDuplicateLimit is how many possible duplicates FindDuplicateParents lists unless it is asked for
+// another number, and MaxDuplicateLimit how many it lists at most
+const (
+	DefaultDuplicateLimit = 20
+	MaxDuplicateLimit     = 100
+)
+
+// matchParents scores how similar two parents are, by their normalized names, email addresses and birth dates.
+// Names are also compared the other way around, as first and last names are sometimes swapped.
+// Parameters:
+//   - parent: The parent the other parent is compared with
+//   - other: The parent that may be a duplicate
+//
+// Returns:
+//   - *domain.ParentDuplicate: The two parents with their scores
+func matchParents(parent, other *domain.Parent) *domain.ParentDuplicate {
+	name := normalizeName(parent.FirstName) + " " + normalizeName(parent.LastName)
+	otherName := normalizeName(other.FirstName) + " " + normalizeName(other.LastName)
+	swappedName := normalizeName(other.LastName) + " " + normalizeName(other.FirstName)
+
+	nameScore := math.Max(similarity(name, otherName), similarity(name, swappedName))
+	emailScore := similarity(normalizeEmail(parent.Email), normalizeEmail(other.Email))
+	birthDateScore := similarity(parent.BirthDate.UTC().Format("20060102"), other.BirthDate.UTC().Format("20060102"))
+
+	return &domain.ParentDuplicate{
+		Parent:         parent,
+		Duplicate:      other,
+		Score:          round(nameWeight*nameScore + emailWeight*emailScore + birthDateWeight*birthDateScore),
+		NameScore:      round(nameScore),
+		EmailScore:     round(emailScore),
+		BirthDateScore: round(birthDateScore),
+	}
+}
+
+// findDuplicatesOf lists the parents that may be duplicates of a parent, most similar first.
+// Parameters:
+//   - parent: The parent whose duplicates to find
+//   - candidates: The parents to compare it with; the parent itself is skipped
+//   - minScore: The score from which a candidate is a possible duplicate
+//
+// Returns:
+//   - []*domain.ParentDuplicate: The possible duplicates of the parent
+func findDuplicatesOf(parent *domain.Parent, candidates []*domain.Parent, minScore float64) []*domain.ParentDuplicate {
+	duplicates := []*domain.ParentDuplicate{}
+	for _, candidate := range candidates {
+		if candidate.ID == parent.ID {
+			continue
+		}
+		if duplicate := matchParents(parent, candidate); duplicate.Score >= minScore {
+			duplicates = append(duplicates, duplicate)
+		}
+	}
+
+	sortDuplicates(duplicates)
+	return duplicates
+}
+
+// findDuplicates lists the pairs of parents that may be duplicates of each other, most similar first.
+// To avoid comparing every parent with every other, only the parents that share a birth date, the local part
+// of their email address, or the beginning of one of their names are compared; duplicates differ in a few
+// characters, so they almost always share one of them. The older parent of a pair is its Parent.
+// Parameters:
+//   - parents: The parents to compare with each other
+//   - minScore: The score from which a pair is a possible duplicate
+//
+// Returns:
+//   - []*domain.ParentDuplicate: The pairs of possible duplicates
+func findDuplicates(parents []*domain.Parent, minScore float64) []*domain.ParentDuplicate {
+	blocks := make(map[string][]int)
+	for i, parent := range parents {
+		for _, key := range blockingKeys(parent) {
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	duplicates := []*domain.ParentDuplicate{}
+	compared := make(map[[2]int]bool)
+	for _, block := range blocks {
+		for i := 0; i < len(block); i++ {
+			for j := i + 1; j < len(block); j++ {
+				pair := [2]int{block[i], block[j]}
+				if compared[pair] {
+					continue
+				}
+				compared[pair] = true
+
+				older, newer := parents[pair[0]], parents[pair[1]]
+				if newer.CreatedAt.Before(older.CreatedAt) {
+					older, newer = newer, older
+				}
+				if duplicate := matchParents(older, newer); duplicate.Score >= minScore {
+					duplicates = append(duplicates, duplicate)
+				}
+			}
+		}
+	}
+
+	sortDuplicates(duplicates)
+	return duplicates
+}
+
+// blockingKeys returns the keys of the blocks of parents findDuplicates compares a parent with
+func blockingKeys(parent *domain.Parent) []string {
+	keys := []string{"birthDate:" + parent.BirthDate.UTC().Format("20060102")}
+	if email := normalizeEmail(parent.Email); email != "" {
+		keys = append(keys, "email:"+strings.SplitN(email, "@", 2)[0])
+	}
+	for _, name := range []string{parent.FirstName, parent.LastName} {
+		if start := prefix(normalizeName(name), 3); start != "" {
+			keys = append(keys, "name:"+start)
+		}
+	}
+	return keys
+}
+
+// sortDuplicates orders possible duplicates from the most to the least similar, and by ID when they are as similar
+func sortDuplicates(duplicates []*domain.ParentDuplicate) {
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		if duplicates[i].Score != duplicates[j].Score {
+			return duplicates[i].Score > duplicates[j].Score
+		}
+		if duplicates[i].Parent.ID != duplicates[j].Parent.ID {
+			return duplicates[i].Parent.ID.String() < duplicates[j].Parent.ID.String()
+		}
+		return duplicates[i].Duplicate.ID.String() < duplicates[j].Duplicate.ID.String()
+	})
+}
+
+// normalizeName lower-cases a name and removes everything but its letters, so that "Mary-Jane" and
+// "mary jane" are the same name
+func normalizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsLetter(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, name)
+}
+
+// normalizeEmail lower-cases an email address and removes the tag from its local part, so that
+// "John.Doe+school@example.com" and "john.doe@example.com" are the same address
+func normalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+	local, host := email[:at], email[at:]
+	if plus := strings.Index(local, "+"); plus >= 0 {
+		local = local[:plus]
+	}
+	return local + host
+}
+
+// similarity returns how similar two strings are, from 0 when every character differs to 1 when they are equal,
+// as one minus their Levenshtein distance divided by the length of the longest
+func similarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	longest := utf8.RuneCountInString(a)
+	if length := utf8.RuneCountInString(b); length > longest {
+		longest = length
+	}
+	return 1 - float64(levenshtein.ComputeDistance(a, b))/float64(longest)
+}
+
+// prefix returns the first n characters of s, or s if it is shorter
+func prefix(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) > n {
+		runes = runes[:n]
+	}
+	return string(runes)
+}
+
+// round rounds a score to three decimals
+func round(score float64) float64 {
+	return math.Round(score*1000) / 1000
+}
+
+// duplicateScanPageSize is how many parents listAllParents reads at a time
+const duplicateScanPageSize = 500
+
+// FindDuplicateParents lists the parents that may be the same person recorded twice, by the similarity
+// of their normalized names, email addresses and birth dates, most similar first. When a parent is given,
+// the parents that may be duplicates of it are listed; otherwise every pair of possible duplicates is,
+// with the older parent of each pair first. A caller restricted to their own family has no duplicates to find.
+// The method uses OpenTelemetry for tracing and logs relevant information during the operation.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - parentID: The unique identifier of the parent whose duplicates to find, or nil to find every pair
+//   - minScore: The score from which two parents are possible duplicates, between 0 and 1, or 0 for DefaultDuplicateScore
+//   - limit: How many possible duplicates to list, or 0 for DefaultDuplicateLimit; at most MaxDuplicateLimit are listed
+//
+// Returns:
+//   - []*domain.ParentDuplicate: The possible duplicates
+//   - error: A ValidationError if the minimum score or limit is out of range, a NotFoundError if the parent
+//     doesn't exist, or a database error
+func (s *FamilyService) FindDuplicateParents(ctx context.Context, parentID *uuid.UUID, minScore float64, limit int) ([]*domain.ParentDuplicate, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.FindDuplicateParents")
+	defer span.End()
+
+	// Validate input
+	if minScore < 0 || minScore > 1 {
+		return nil, domain.NewValidationError("Parent", "minScore", "must be between 0 and 1")
+	}
+	if limit < 0 {
+		return nil, domain.NewValidationError("Parent", "limit", "must not be negative")
+	}
+	if minScore == 0 {
+		minScore = DefaultDuplicateScore
+	}
+	if limit == 0 {
+		limit = DefaultDuplicateLimit
+	}
+	if limit > MaxDuplicateLimit {
+		limit = MaxDuplicateLimit
+	}
+	span.SetAttributes(attribute.Float64("duplicates.min_score", minScore), attribute.Int("duplicates.limit", limit))
+
+	// Get the parent whose duplicates to find, if any
+	var parent *domain.Parent
+	if parentID != nil {
+		span.SetAttributes(attribute.String("parent.id", parentID.String()))
+
+		var err error
+		parent, err = s.parentRepo.GetByID(ctx, *parentID)
+		if err != nil {
+			s.logger.Error("Failed to get parent", zap.Error(err), zap.String("parent_id", parentID.String()))
+			return nil, domain.NewNotFoundError("Parent", parentID.String())
+		}
+		if err := s.authorizeFamily(ctx, parent.ID, "Parent", parent.ID.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	// Get the parents to compare
+	filter, ok, err := s.scopeFilter(ctx, ports.FilterOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return []*domain.ParentDuplicate{}, nil
+	}
+	parents, err := s.listAllParents(ctx, filter)
+	if err != nil {
+		s.logger.Error("Failed to list parents", zap.Error(err))
+		return nil, domain.NewDatabaseError("list", "Parent", err)
+	}
+
+	var duplicates []*domain.ParentDuplicate
+	if parent != nil {
+		duplicates = findDuplicatesOf(parent, parents, minScore)
+	} else {
+		duplicates = findDuplicates(parents, minScore)
+	}
+	if len(duplicates) > limit {
+		duplicates = duplicates[:limit]
+	}
+
+	span.SetAttributes(attribute.Int("duplicates.count", len(duplicates)))
+	return duplicates, nil
+}
+
+// listAllParents reads every parent matching a filter, a page at a time.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - filter: The filter of the parents to read
+//
+// Returns:
+//   - []*domain.Parent: The parents matching the filter
+//   - error: An error if a page cannot be read
+func (s *FamilyService) listAllParents(ctx context.Context, filter ports.FilterOptions) ([]*domain.Parent, error) {
+	parents := []*domain.Parent{}
+	for page := 0; ; page++ {
+		options := ports.QueryOptions{
+			Filter:     filter,
+			Pagination: ports.PaginationOptions{Page: page, PageSize: duplicateScanPageSize},
+		}
+		batch, pagedResult, err := s.parentRepo.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		parents = append(parents, batch...)
+		if pagedResult == nil || !pagedResult.HasNext || len(batch) == 0 {
+			return parents, nil
+		}
+	}
+}
@@ -6,6 +6,8 @@ package application
 
 import (
 	"context"
+	"errors"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,56 +25,231 @@ import (
 // It provides methods for managing parents and children in the family service,
 // including CRUD operations and relationship management between parents and children.
 // The service uses repositories for data access, a transaction manager for ensuring
-// data consistency, a validator for input validation, a logger for logging,
+// data consistency, an event publisher for announcing changes, an audit log for recording who changed what,
+// a validator for input validation, a logger for logging,
 // a tracer for distributed tracing, and a localizer for error message localization.
 type FamilyService struct {
-	parentRepo         ports.ParentRepository   // Repository for parent entities
-	childRepo          ports.ChildRepository    // Repository for child entities
-	transactionManager ports.TransactionManager // Manages database transactions
-	validator          *validator.Validate      // Validates input data
-	logger             *zap.Logger              // Logs service operations
-	tracer             trace.Tracer             // Provides distributed tracing
+	parentRepo         ports.ParentRepository       // Repository for parent entities
+	childRepo          ports.ChildRepository        // Repository for child entities
+	transactionManager ports.TransactionManager     // Manages database transactions
+	eventPublisher     ports.EventPublisher         // Publishes domain events for committed changes
+	outbox             ports.OutboxRepository       // Records domain events with the changes, for the outbox relay
+	validator          *validator.Validate          // Validates input data
+	logger             *zap.Logger                  // Logs service operations
+	tracer             trace.Tracer                 // Provides distributed tracing
+	deletedRetention   time.Duration                // How long deleted parents and children are kept before they are purged
+	auditLog           ports.AuditLogRepository     // Records who changed what, with the changes
+	authService        ports.AuthorizationService   // Identifies the actor of the audited changes
+	historyRepo        ports.HistoryRepository      // Reads the recorded versions of parents and children
+	guardianshipRepo   ports.GuardianshipRepository // Stores the guardianships between parents and children
+	householdRepo      ports.HouseholdRepository    // Stores the households that group parents and children
 }
 
+// DefaultDeletedRetention is how long deleted parents and children are kept before they are purged,
+// unless the service is configured otherwise with WithDeletedRetention
+const DefaultDeletedRetention = 90 * 24 * time.Hour
+
 // NewFamilyService creates a new family service with the necessary dependencies.
 // Parameters:
 //   - repoFactory: Factory for creating repositories and transaction manager
-//   - validator: Validator for input validation
+//   - eventPublisher: Publisher for the domain events raised by committed changes
+//   - validator: Validator for input validation, with which the rules of RegisterValidations are registered
 //   - logger: Logger for logging service operations
 //
 // Returns:
 //   - *FamilyService: A new instance of the family service
 func NewFamilyService(
 	repoFactory ports.RepositoryFactory,
+	eventPublisher ports.EventPublisher,
 	validator *validator.Validate,
 	logger *zap.Logger,
 ) *FamilyService {
+	if validator != nil {
+		if err := RegisterValidations(validator); err != nil {
+			logger.Error("Failed to register validation rules", zap.Error(err))
+		}
+	}
+
 	return &FamilyService{
 		parentRepo:         repoFactory.NewParentRepository(),
 		childRepo:          repoFactory.NewChildRepository(),
+		historyRepo:        repoFactory.NewHistoryRepository(),
+		guardianshipRepo:   repoFactory.NewGuardianshipRepository(),
+		householdRepo:      repoFactory.NewHouseholdRepository(),
 		transactionManager: repoFactory.GetTransactionManager(),
+		eventPublisher:     eventPublisher,
 		validator:          validator,
 		logger:             logger,
 		tracer:             otel.Tracer("application.family_service"),
+		deletedRetention:   DefaultDeletedRetention,
 	}
 }
 
+// WithDeletedRetention sets how long deleted parents and children are kept before PurgeDeleted removes them.
+// A retention that is not positive keeps the default, so that an unset value cannot purge everything.
+func (s *FamilyService) WithDeletedRetention(retention time.Duration) *FamilyService {
+	if retention > 0 {
+		s.deletedRetention = retention
+	}
+	return s
+}
+
+// WithOutbox records the domain event of every change in the given outbox, within the transaction
+// of the change, so that an outbox relay can deliver the events of the committed changes to other systems.
+// A nil outbox records nothing.
+func (s *FamilyService) WithOutbox(outbox ports.OutboxRepository) *FamilyService {
+	s.outbox = outbox
+	return s
+}
+
+// WithAuditLog records an audit record of every change in the given audit log, within the transaction
+// of the change, attributed to the user identified by the authorization service.
+// A nil audit log records nothing.
+func (s *FamilyService) WithAuditLog(auditLog ports.AuditLogRepository, authService ports.AuthorizationService) *FamilyService {
+	s.auditLog = auditLog
+	s.authService = authService
+	return s
+}
+
 // CreateParent creates a new parent in the system.
-// It validates the input data, creates a new Parent entity, and persists it to the database.
+// It validates the input data, creates a new Parent entity, and persists it to the database
+// within a transaction.
 // Parameters:
 //   - ctx: The context for the operation, used for tracing and cancellation
 //   - firstName: The parent's first name
 //   - lastName: The parent's last name
 //   - email: The parent's email address
 //   - birthDateStr: The parent's birth date as a string in RFC3339 format (e.g., "2006-01-02T15:04:05Z")
+//   - contact: The parent's phone numbers, postal address and emergency contacts, or nil if none are known
 //
 // Returns:
 //   - *domain.Parent: The newly created parent entity if successful
-//   - error: An error if validation fails or if there's a database error
-func (s *FamilyService) CreateParent(ctx context.Context, firstName, lastName, email, birthDateStr string) (*domain.Parent, error) {
+//   - error: An error if validation fails, a TransactionError if the transaction fails,
+//     or a database error
+func (s *FamilyService) CreateParent(ctx context.Context, firstName, lastName, email, birthDateStr string, contact *domain.ContactDetails) (*domain.Parent, error) {
 	ctx, span := s.tracer.Start(ctx, "FamilyService.CreateParent")
 	defer span.End()
 
+	// Validate input and create parent
+	parent, err := s.newParent(firstName, lastName, email, birthDateStr, contact)
+	if err != nil {
+		return nil, err
+	}
+
+	// Begin transaction
+	ctx, err = s.transactionManager.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("begin", err)
+	}
+
+	// Save parent
+	err = s.parentRepo.Create(ctx, parent)
+	if err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to create parent", zap.Error(err))
+		return nil, domain.NewDatabaseError("create", "Parent", err)
+	}
+
+	// Record the event and the audit record with the change
+	event := domain.NewParentEvent(domain.EventParentCreated, parent)
+	change := domain.NewAuditRecord("CreateParent", domain.AuditEntityParent, parent.ID, nil, parent.AuditSnapshot())
+	if err := s.record(ctx, event, change); err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to record event", zap.Error(err), zap.String("event_type", string(event.Type)))
+		return nil, domain.NewDatabaseError("record", "Event", err)
+	}
+
+	// Commit transaction
+	err = s.transactionManager.CommitTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("commit", err)
+	}
+
+	s.publish(ctx, event)
+
+	return parent, nil
+}
+
+// ValidateParents checks whether parents could be created, without creating them. An input is invalid when
+// CreateParent would reject it, when a non-deleted parent already has its email, or when an earlier input
+// has the same email, ignoring case.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - inputs: The information of the parents to check, at most ports.MaxBatchSize of them
+//
+// Returns:
+//   - []error: The error of every input at its position, or nil for the valid ones
+//   - error: A ValidationError if there are too many inputs, or a database error
+func (s *FamilyService) ValidateParents(ctx context.Context, inputs []ports.ParentInput) ([]error, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.ValidateParents")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("batch.size", len(inputs)))
+
+	// Validate input
+	if len(inputs) > ports.MaxBatchSize {
+		return nil, batchSizeError("Parent", "inputs")
+	}
+
+	errs := make([]error, len(inputs))
+	emails := make([]string, 0, len(inputs))
+	for i, input := range inputs {
+		if _, errs[i] = s.newParent(input.FirstName, input.LastName, input.Email, input.BirthDate, input.Contact); errs[i] == nil {
+			emails = append(emails, input.Email)
+		}
+	}
+
+	// No two parents of a tenant can have the same email, ignoring case
+	taken, err := s.parentRepo.FindTakenEmails(ctx, emails)
+	if err != nil {
+		s.logger.Error("Failed to find taken emails", zap.Error(err), zap.Int("count", len(emails)))
+		return nil, domain.NewDatabaseError("get", "Parent", err)
+	}
+
+	seen := make(map[string]bool, len(inputs))
+	for _, email := range taken {
+		seen[strings.ToLower(email)] = true
+	}
+	for i, input := range inputs {
+		if errs[i] != nil {
+			continue
+		}
+		email := strings.ToLower(input.Email)
+		if seen[email] {
+			errs[i] = domain.NewDuplicateError("Parent", "email", input.Email)
+		}
+		seen[email] = true
+	}
+
+	return errs, nil
+}
+
+// newParent validates the information of a new parent and creates the Parent entity, without storing it.
+// Parameters:
+//   - firstName: The parent's first name
+//   - lastName: The parent's last name
+//   - email: The parent's email address
+//   - birthDateStr: The parent's birth date as a string in RFC3339 format
+//   - contact: The parent's phone numbers, postal address and emergency contacts, or nil if none are known
+//
+// Returns:
+//   - *domain.Parent: The new parent entity if the information is valid
+//   - error: A ValidationError naming the invalid field otherwise
+func (s *FamilyService) newParent(firstName, lastName, email, birthDateStr string, contact *domain.ContactDetails) (*domain.Parent, error) {
 	// Validate input
 	if firstName == "" {
 		return nil, domain.NewValidationError("Parent", "firstName", "is required")
@@ -96,12 +273,19 @@ func (s *FamilyService) CreateParent(ctx context.Context, firstName, lastName, e
 
 	// Create parent
 	parent := domain.NewParent(firstName, lastName, email, birthDate)
+	if contact != nil {
+		parent.ContactDetails = *contact
+		parent.ContactDetails.Normalize()
+	}
 
 	// Validate parent
 	if err := s.validator.Struct(parent); err != nil {
 		s.logger.Error("Parent validation failed", zap.Error(err))
 
 		// Check for specific validation errors
+		if contactErr := contactValidationError("Parent", err); contactErr != nil {
+			return nil, contactErr
+		}
 		if strings.Contains(err.Error(), "Email") {
 			return nil, domain.NewValidationError("Parent", "email", "invalid format")
 		}
@@ -109,13 +293,6 @@ func (s *FamilyService) CreateParent(ctx context.Context, firstName, lastName, e
 		return nil, domain.NewValidationError("Parent", "", err.Error())
 	}
 
-	// Save parent
-	err = s.parentRepo.Create(ctx, parent)
-	if err != nil {
-		s.logger.Error("Failed to create parent", zap.Error(err))
-		return nil, domain.NewDatabaseError("create", "Parent", err)
-	}
-
 	return parent, nil
 }
 
@@ -128,13 +305,18 @@ func (s *FamilyService) CreateParent(ctx context.Context, firstName, lastName, e
 //
 // Returns:
 //   - *domain.Parent: The retrieved parent entity if found
-//   - error: A NotFoundError if the parent doesn't exist, or a database error
+//   - error: A ForbiddenError if the parent is outside the caller's family, a NotFoundError
+//     if the parent doesn't exist, or a database error
 func (s *FamilyService) GetParentByID(ctx context.Context, id uuid.UUID) (*domain.Parent, error) {
 	ctx, span := s.tracer.Start(ctx, "FamilyService.GetParentByID")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("parent.id", id.String()))
 
+	if err := s.authorizeFamily(ctx, id, "Parent", id.String()); err != nil {
+		return nil, err
+	}
+
 	parent, err := s.parentRepo.GetByID(ctx, id)
 	if err != nil {
 		s.logger.Error("Failed to get parent", zap.Error(err), zap.String("parent_id", id.String()))
@@ -149,9 +331,158 @@ func (s *FamilyService) GetParentByID(ctx context.Context, id uuid.UUID) (*domai
 	return parent, nil
 }
 
+// GetParentsByIDs retrieves the parents with the given identifiers in a single batch.
+// Identifiers that do not match a parent, or that are outside the caller's family, are skipped,
+// so the result may be shorter than ids.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - ids: The unique identifiers of the parents to retrieve
+//
+// Returns:
+//   - []*domain.Parent: The parents that were found, in no particular order
+//   - error: A database error if the lookup fails
+func (s *FamilyService) GetParentsByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Parent, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.GetParentsByIDs")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("parent.count", len(ids)))
+
+	ids, err := s.scopeParentIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []*domain.Parent{}, nil
+	}
+
+	parents, err := s.parentRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("Failed to get parents by IDs", zap.Error(err), zap.Int("count", len(ids)))
+		return nil, domain.NewDatabaseError("getByIDs", "Parent", err)
+	}
+
+	return parents, nil
+}
+
+// GetParentByUserID retrieves the parent linked to a user account.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - userID: The ID of the user account
+//
+// Returns:
+//   - *domain.Parent: The parent linked to the user
+//   - error: A NotFoundError if no parent is linked to the user, or a database error
+func (s *FamilyService) GetParentByUserID(ctx context.Context, userID string) (*domain.Parent, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.GetParentByUserID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", userID))
+
+	parent, err := s.parentRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.NewNotFoundError("Parent", "user:"+userID)
+		}
+		s.logger.Error("Failed to get parent by user ID", zap.Error(err), zap.String("user_id", userID))
+		return nil, domain.NewDatabaseError("getByUserID", "Parent", err)
+	}
+
+	return parent, nil
+}
+
+// LinkParentUser links a parent to a user account, so that the user can access the parent's family.
+// A user account can be linked to at most one parent; an empty user ID removes the link.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - id: The unique identifier of the parent
+//   - userID: The ID of the user account, as found in the subject of its tokens
+//
+// Returns:
+//   - *domain.Parent: The updated parent entity if successful
+//   - error: A NotFoundError if the parent doesn't exist, a ValidationError if the user
+//     is already linked to another parent, a TransactionError if the transaction fails, or a database error
+func (s *FamilyService) LinkParentUser(ctx context.Context, id uuid.UUID, userID string) (*domain.Parent, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.LinkParentUser")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("parent.id", id.String()),
+		attribute.String("user.id", userID),
+	)
+
+	// Get existing parent
+	parent, err := s.parentRepo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to get parent for linking", zap.Error(err), zap.String("parent_id", id.String()))
+		return nil, domain.NewNotFoundError("Parent", id.String())
+	}
+
+	// A user account may access a single family
+	if userID != "" {
+		linked, err := s.parentRepo.GetByUserID(ctx, userID)
+		switch {
+		case err == nil && linked.ID != id:
+			return nil, domain.NewValidationError("Parent", "userId", "is already linked to another parent")
+		case err != nil && !errors.Is(err, domain.ErrNotFound):
+			s.logger.Error("Failed to get parent by user ID", zap.Error(err), zap.String("user_id", userID))
+			return nil, domain.NewDatabaseError("getByUserID", "Parent", err)
+		}
+	}
+
+	before := parent.AuditSnapshot()
+	parent.LinkUser(userID)
+
+	// Begin transaction
+	ctx, err = s.transactionManager.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("begin", err)
+	}
+
+	// Save parent
+	err = s.parentRepo.Update(ctx, parent)
+	if err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to link parent to user", zap.Error(err), zap.String("parent_id", id.String()))
+		return nil, domain.NewDatabaseError("update", "Parent", err)
+	}
+
+	// Record the event and the audit record with the change
+	event := domain.NewParentEvent(domain.EventParentUpdated, parent)
+	change := domain.NewAuditRecord("LinkParentUser", domain.AuditEntityParent, parent.ID, before, parent.AuditSnapshot())
+	if err := s.record(ctx, event, change); err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to record event", zap.Error(err), zap.String("event_type", string(event.Type)))
+		return nil, domain.NewDatabaseError("record", "Event", err)
+	}
+
+	// Commit transaction
+	err = s.transactionManager.CommitTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("commit", err)
+	}
+
+	s.publish(ctx, event)
+
+	return parent, nil
+}
+
 // UpdateParent updates an existing parent with new information.
 // It retrieves the parent, updates its attributes, validates the updated entity,
-// and persists the changes to the database. The method handles validation of input data,
+// and persists the changes to the database within a transaction. The method handles validation of input data,
 // including parsing the birth date string.
 // The method uses OpenTelemetry for tracing and logs relevant information during the operation.
 // Parameters:
@@ -161,17 +492,25 @@ func (s *FamilyService) GetParentByID(ctx context.Context, id uuid.UUID) (*domai
 //   - lastName: The new last name for the parent
 //   - email: The new email address for the parent
 //   - birthDateStr: The new birth date as a string in RFC3339 format (e.g., "2006-01-02T15:04:05Z")
+//   - contact: The new phone numbers, postal address and emergency contacts, or nil to keep the current ones
+//   - expectedVersion: The version the caller last read, or nil to update whatever version is current
 //
 // Returns:
 //   - *domain.Parent: The updated parent entity if successful
-//   - error: A NotFoundError if the parent doesn't exist, a ValidationError if validation fails,
-//     or a database error
-func (s *FamilyService) UpdateParent(ctx context.Context, id uuid.UUID, firstName, lastName, email, birthDateStr string) (*domain.Parent, error) {
+//   - error: A ForbiddenError if the parent is outside the caller's family, a NotFoundError
+//     if the parent doesn't exist, a ConflictError if the parent is no longer at the expected
+//     version or is modified concurrently, a ValidationError if validation fails, a TransactionError
+//     if the transaction fails, or a database error
+func (s *FamilyService) UpdateParent(ctx context.Context, id uuid.UUID, firstName, lastName, email, birthDateStr string, contact *domain.ContactDetails, expectedVersion *int) (*domain.Parent, error) {
 	ctx, span := s.tracer.Start(ctx, "FamilyService.UpdateParent")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("parent.id", id.String()))
 
+	if err := s.authorizeFamily(ctx, id, "Parent", id.String()); err != nil {
+		return nil, err
+	}
+
 	// Get existing parent
 	parent, err := s.parentRepo.GetByID(ctx, id)
 	if err != nil {
@@ -179,6 +518,12 @@ func (s *FamilyService) UpdateParent(ctx context.Context, id uuid.UUID, firstNam
 		return nil, domain.NewNotFoundError("Parent", id.String())
 	}
 
+	if expectedVersion != nil && parent.Version != *expectedVersion {
+		s.logger.Info("Parent version conflict", zap.String("parent_id", id.String()),
+			zap.Int("expected_version", *expectedVersion), zap.Int("version", parent.Version))
+		return nil, domain.NewConflictError("Parent", id.String(), *expectedVersion)
+	}
+
 	// Parse birth date
 	birthDate, err := time.Parse(time.RFC3339, birthDateStr)
 	if err != nil {
@@ -187,13 +532,21 @@ func (s *FamilyService) UpdateParent(ctx context.Context, id uuid.UUID, firstNam
 	}
 
 	// Update parent
+	before := parent.AuditSnapshot()
 	parent.Update(firstName, lastName, email, birthDate)
+	if contact != nil {
+		parent.UpdateContactDetails(*contact)
+		parent.ContactDetails.Normalize()
+	}
 
 	// Validate parent
 	if err := s.validator.Struct(parent); err != nil {
 		s.logger.Error("Parent validation failed", zap.Error(err))
 
 		// Check for specific validation errors
+		if contactErr := contactValidationError("Parent", err); contactErr != nil {
+			return nil, contactErr
+		}
 		if strings.Contains(err.Error(), "Email") {
 			return nil, domain.NewValidationError("Parent", "email", "invalid format")
 		}
@@ -201,13 +554,55 @@ func (s *FamilyService) UpdateParent(ctx context.Context, id uuid.UUID, firstNam
 		return nil, domain.NewValidationError("Parent", "", err.Error())
 	}
 
-	// Save parent
+	// Begin transaction
+	ctx, err = s.transactionManager.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("begin", err)
+	}
+
+	// Save parent; the repository rejects the write if the parent was modified since it was read
 	err = s.parentRepo.Update(ctx, parent)
 	if err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
 		s.logger.Error("Failed to update parent", zap.Error(err), zap.String("parent_id", id.String()))
+		var conflictErr *domain.ConflictError
+		if errors.As(err, &conflictErr) {
+			return nil, conflictErr
+		}
 		return nil, domain.NewDatabaseError("update", "Parent", err)
 	}
 
+	// Record the event and the audit record with the change
+	event := domain.NewParentEvent(domain.EventParentUpdated, parent)
+	change := domain.NewAuditRecord("UpdateParent", domain.AuditEntityParent, parent.ID, before, parent.AuditSnapshot())
+	if err := s.record(ctx, event, change); err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to record event", zap.Error(err), zap.String("event_type", string(event.Type)))
+		return nil, domain.NewDatabaseError("record", "Event", err)
+	}
+
+	// Commit transaction
+	err = s.transactionManager.CommitTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("commit", err)
+	}
+
+	s.publish(ctx, event)
+
 	return parent, nil
 }
 
@@ -256,6 +651,21 @@ func (s *FamilyService) DeleteParent(ctx context.Context, id uuid.UUID) error {
 		return domain.NewDatabaseError("delete", "Parent", err)
 	}
 
+	// Record the event and the audit record with the change
+	event := domain.NewEvent(domain.EventParentDeleted, id, uuid.Nil)
+	change := domain.NewAuditRecord("DeleteParent", domain.AuditEntityParent, id, domain.DeletionSnapshot(false), domain.DeletionSnapshot(true))
+	if err := s.record(ctx, event, change); err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to record event", zap.Error(err), zap.String("event_type", string(event.Type)))
+		return domain.NewDatabaseError("record", "Event", err)
+	}
+
 	// Commit transaction
 	err = s.transactionManager.CommitTx(ctx)
 	if err != nil {
@@ -263,33 +673,130 @@ func (s *FamilyService) DeleteParent(ctx context.Context, id uuid.UUID) error {
 		return domain.NewTransactionError("commit", err)
 	}
 
+	s.publish(ctx, event)
+
 	return nil
 }
 
-// ListParents retrieves a list of parents with pagination, filtering, and sorting.
-// It delegates to the parent repository to fetch the data and handles any errors.
-// The method supports filtering by various criteria, sorting by different fields,
-// and pagination with page size and page number.
+// RestoreParent unmarks a parent that was marked as deleted, together with the children
+// that were deleted with it. The operation is performed within a transaction.
 // The method uses OpenTelemetry for tracing and logs relevant information during the operation.
 // Parameters:
 //   - ctx: The context for the operation, used for tracing and cancellation
-//   - options: Options for filtering, sorting, and pagination
+//   - id: The unique identifier of the deleted parent to restore
 //
 // Returns:
-//   - []*domain.Parent: A slice of parent entities matching the criteria
-//   - *ports.PagedResult: Pagination information including total count and whether there are more pages
-//   - error: A database error if the operation fails
-func (s *FamilyService) ListParents(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
-	ctx, span := s.tracer.Start(ctx, "FamilyService.ListParents")
+//   - *domain.Parent: The restored parent entity if successful
+//   - error: A NotFoundError if no deleted parent has the ID, a TransactionError if the transaction
+//     fails, or a database error
+func (s *FamilyService) RestoreParent(ctx context.Context, id uuid.UUID) (*domain.Parent, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.RestoreParent")
 	defer span.End()
 
-	parents, pagedResult, err := s.parentRepo.List(ctx, options)
+	span.SetAttributes(attribute.String("parent.id", id.String()))
+
+	// Begin transaction
+	ctx, err := s.transactionManager.BeginTx(ctx)
 	if err != nil {
-		s.logger.Error("Failed to list parents", zap.Error(err))
-		return nil, nil, domain.NewDatabaseError("list", "Parent", err)
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("begin", err)
 	}
 
-	return parents, pagedResult, nil
+	// Restore parent
+	err = s.parentRepo.Restore(ctx, id)
+	if err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to restore parent", zap.Error(err), zap.String("parent_id", id.String()))
+
+		// Check if this is a "not found" error
+		if strings.Contains(err.Error(), "not found") {
+			return nil, domain.NewNotFoundError("Parent", id.String())
+		}
+
+		return nil, domain.NewDatabaseError("restore", "Parent", err)
+	}
+
+	// Get the restored parent
+	parent, err := s.parentRepo.GetByID(ctx, id)
+	if err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to get restored parent", zap.Error(err), zap.String("parent_id", id.String()))
+		return nil, domain.NewDatabaseError("get", "Parent", err)
+	}
+
+	// Record the event and the audit record with the change
+	event := domain.NewParentEvent(domain.EventParentRestored, parent)
+	change := domain.NewAuditRecord("RestoreParent", domain.AuditEntityParent, id, domain.DeletionSnapshot(true), domain.DeletionSnapshot(false))
+	if err := s.record(ctx, event, change); err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to record event", zap.Error(err), zap.String("event_type", string(event.Type)))
+		return nil, domain.NewDatabaseError("record", "Event", err)
+	}
+
+	// Commit transaction
+	err = s.transactionManager.CommitTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("commit", err)
+	}
+
+	s.publish(ctx, event)
+
+	return parent, nil
+}
+
+// ListParents retrieves a list of parents with pagination, filtering, and sorting.
+// It delegates to the parent repository to fetch the data and handles any errors.
+// The method supports filtering by various criteria, sorting by different fields,
+// and pagination with page size and page number. A caller restricted to their own family
+// only sees its parent.
+// The method uses OpenTelemetry for tracing and logs relevant information during the operation.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - options: Options for filtering, sorting, and pagination
+//
+// Returns:
+//   - []*domain.Parent: A slice of parent entities matching the criteria
+//   - *ports.PagedResult: Pagination information including total count and whether there are more pages
+//   - error: A database error if the operation fails
+func (s *FamilyService) ListParents(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.ListParents")
+	defer span.End()
+
+	filter, ok, err := s.scopeFilter(ctx, options.Filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return []*domain.Parent{}, emptyPagedResult(options), nil
+	}
+	options.Filter = filter
+
+	parents, pagedResult, err := s.parentRepo.List(ctx, options)
+	if err != nil {
+		s.logger.Error("Failed to list parents", zap.Error(err))
+		return nil, nil, domain.NewDatabaseError("list", "Parent", err)
+	}
+
+	return parents, pagedResult, nil
 }
 
 // CountParents returns the total count of parents matching the filter.
@@ -308,6 +815,14 @@ func (s *FamilyService) CountParents(ctx context.Context, filter ports.FilterOpt
 	ctx, span := s.tracer.Start(ctx, "FamilyService.CountParents")
 	defer span.End()
 
+	filter, ok, err := s.scopeFilter(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, nil
+	}
+
 	count, err := s.parentRepo.Count(ctx, filter)
 	if err != nil {
 		s.logger.Error("Failed to count parents", zap.Error(err))
@@ -336,31 +851,10 @@ func (s *FamilyService) CreateChild(ctx context.Context, firstName, lastName, bi
 
 	span.SetAttributes(attribute.String("parent.id", parentID.String()))
 
-	// Validate input
-	if firstName == "" {
-		return nil, domain.NewValidationError("Child", "firstName", "is required")
-	}
-	if lastName == "" {
-		return nil, domain.NewValidationError("Child", "lastName", "is required")
-	}
-	if birthDateStr == "" {
-		return nil, domain.NewValidationError("Child", "birthDate", "is required")
-	}
-
-	// Parse birth date
-	birthDate, err := time.Parse(time.RFC3339, birthDateStr)
+	// Validate input and create child
+	child, err := s.newChild(firstName, lastName, birthDateStr, parentID)
 	if err != nil {
-		s.logger.Error("Failed to parse birth date", zap.Error(err), zap.String("birthDate", birthDateStr))
-		return nil, domain.NewValidationError("Child", "birthDate", "invalid format, expected RFC3339")
-	}
-
-	// Create child
-	child := domain.NewChild(firstName, lastName, birthDate, parentID)
-
-	// Validate child
-	if err := s.validator.Struct(child); err != nil {
-		s.logger.Error("Child validation failed", zap.Error(err))
-		return nil, domain.NewValidationError("Child", "", err.Error())
+		return nil, err
 	}
 
 	// Begin transaction
@@ -398,6 +892,21 @@ func (s *FamilyService) CreateChild(ctx context.Context, firstName, lastName, bi
 		return nil, domain.NewDatabaseError("create", "Child", err)
 	}
 
+	// The parent is the child's first guardian, and its primary contact
+	guardianship := domain.NewGuardianship(parentID, child.ID, domain.GuardianshipGuardian, child.CreatedAt, true)
+	err = s.guardianshipRepo.Create(ctx, guardianship)
+	if err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to create guardianship", zap.Error(err), zap.String("child_id", child.ID.String()))
+		return nil, domain.NewDatabaseError("create", "Guardianship", err)
+	}
+
 	// Add child to parent's children array
 	s.logger.Debug("Before adding child to parent",
 		zap.String("parent_id", parentID.String()),
@@ -435,6 +944,21 @@ func (s *FamilyService) CreateChild(ctx context.Context, firstName, lastName, bi
 			zap.Any("parent", updatedParent))
 	}
 
+	// Record the event and the audit record with the change
+	event := domain.NewChildEvent(domain.EventChildCreated, child)
+	change := domain.NewAuditRecord("CreateChild", domain.AuditEntityChild, child.ID, nil, child.AuditSnapshot())
+	if err := s.record(ctx, event, change); err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to record event", zap.Error(err), zap.String("event_type", string(event.Type)))
+		return nil, domain.NewDatabaseError("record", "Event", err)
+	}
+
 	// Commit transaction
 	err = s.transactionManager.CommitTx(ctx)
 	if err != nil {
@@ -442,6 +966,100 @@ func (s *FamilyService) CreateChild(ctx context.Context, firstName, lastName, bi
 		return nil, domain.NewTransactionError("commit", err)
 	}
 
+	s.publish(ctx, event)
+
+	return child, nil
+}
+
+// ValidateChildren checks whether children could be created, without creating them. An input is invalid when
+// CreateChild would reject it, such as when its parent does not exist.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - inputs: The information of the children to check, at most ports.MaxBatchSize of them
+//
+// Returns:
+//   - []error: The error of every input at its position, or nil for the valid ones
+//   - error: A ValidationError if there are too many inputs, or a database error
+func (s *FamilyService) ValidateChildren(ctx context.Context, inputs []ports.ChildInput) ([]error, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.ValidateChildren")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("batch.size", len(inputs)))
+
+	// Validate input
+	if len(inputs) > ports.MaxBatchSize {
+		return nil, batchSizeError("Child", "inputs")
+	}
+
+	errs := make([]error, len(inputs))
+	children := make([]*domain.Child, 0, len(inputs))
+	for i, input := range inputs {
+		child, err := s.newChild(input.FirstName, input.LastName, input.BirthDate, input.ParentID)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		children = append(children, child)
+	}
+
+	// The parents must exist
+	parents, err := s.parentRepo.GetByIDs(ctx, parentIDsOf(children))
+	if err != nil {
+		s.logger.Error("Failed to get parents", zap.Error(err), zap.Int("count", len(children)))
+		return nil, domain.NewDatabaseError("get", "Parent", err)
+	}
+
+	found := make(map[uuid.UUID]bool, len(parents))
+	for _, parent := range parents {
+		found[parent.ID] = true
+	}
+	for i, input := range inputs {
+		if errs[i] == nil && !found[input.ParentID] {
+			errs[i] = domain.NewNotFoundError("Parent", input.ParentID.String())
+		}
+	}
+
+	return errs, nil
+}
+
+// newChild validates the information of a new child and creates the Child entity, without storing it.
+// Parameters:
+//   - firstName: The child's first name
+//   - lastName: The child's last name
+//   - birthDateStr: The child's birth date as a string in RFC3339 format
+//   - parentID: The UUID of the parent to associate with this child
+//
+// Returns:
+//   - *domain.Child: The new child entity if the information is valid
+//   - error: A ValidationError naming the invalid field otherwise
+func (s *FamilyService) newChild(firstName, lastName, birthDateStr string, parentID uuid.UUID) (*domain.Child, error) {
+	// Validate input
+	if firstName == "" {
+		return nil, domain.NewValidationError("Child", "firstName", "is required")
+	}
+	if lastName == "" {
+		return nil, domain.NewValidationError("Child", "lastName", "is required")
+	}
+	if birthDateStr == "" {
+		return nil, domain.NewValidationError("Child", "birthDate", "is required")
+	}
+
+	// Parse birth date
+	birthDate, err := time.Parse(time.RFC3339, birthDateStr)
+	if err != nil {
+		s.logger.Error("Failed to parse birth date", zap.Error(err), zap.String("birthDate", birthDateStr))
+		return nil, domain.NewValidationError("Child", "birthDate", "invalid format, expected RFC3339")
+	}
+
+	// Create child
+	child := domain.NewChild(firstName, lastName, birthDate, parentID)
+
+	// Validate child
+	if err := s.validator.Struct(child); err != nil {
+		s.logger.Error("Child validation failed", zap.Error(err))
+		return nil, domain.NewValidationError("Child", "", err.Error())
+	}
+
 	return child, nil
 }
 
@@ -463,11 +1081,17 @@ func (s *FamilyService) GetChildByID(ctx context.Context, id uuid.UUID) (*domain
 		return nil, domain.NewNotFoundError("Child", id.String())
 	}
 
+	if err := s.authorizeChild(ctx, child); err != nil {
+		return nil, err
+	}
+
 	return child, nil
 }
 
-// UpdateChild updates an existing child
-func (s *FamilyService) UpdateChild(ctx context.Context, id uuid.UUID, firstName, lastName, birthDateStr string) (*domain.Child, error) {
+// UpdateChild updates an existing child.
+// When expectedVersion is set, the update fails with a ConflictError unless the child is still at that version;
+// it also fails with a ConflictError if the child is modified concurrently.
+func (s *FamilyService) UpdateChild(ctx context.Context, id uuid.UUID, firstName, lastName, birthDateStr string, expectedVersion *int) (*domain.Child, error) {
 	ctx, span := s.tracer.Start(ctx, "FamilyService.UpdateChild")
 	defer span.End()
 
@@ -480,6 +1104,16 @@ func (s *FamilyService) UpdateChild(ctx context.Context, id uuid.UUID, firstName
 		return nil, domain.NewNotFoundError("Child", id.String())
 	}
 
+	if err := s.authorizeChild(ctx, child); err != nil {
+		return nil, err
+	}
+
+	if expectedVersion != nil && child.Version != *expectedVersion {
+		s.logger.Info("Child version conflict", zap.String("child_id", id.String()),
+			zap.Int("expected_version", *expectedVersion), zap.Int("version", child.Version))
+		return nil, domain.NewConflictError("Child", id.String(), *expectedVersion)
+	}
+
 	// Parse birth date
 	birthDate, err := time.Parse(time.RFC3339, birthDateStr)
 	if err != nil {
@@ -488,6 +1122,7 @@ func (s *FamilyService) UpdateChild(ctx context.Context, id uuid.UUID, firstName
 	}
 
 	// Update child
+	before := child.AuditSnapshot()
 	child.Update(firstName, lastName, birthDate)
 
 	// Validate child
@@ -496,13 +1131,55 @@ func (s *FamilyService) UpdateChild(ctx context.Context, id uuid.UUID, firstName
 		return nil, domain.NewValidationError("Child", "", err.Error())
 	}
 
-	// Save child
+	// Begin transaction
+	ctx, err = s.transactionManager.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("begin", err)
+	}
+
+	// Save child; the repository rejects the write if the child was modified since it was read
 	err = s.childRepo.Update(ctx, child)
 	if err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
 		s.logger.Error("Failed to update child", zap.Error(err), zap.String("child_id", id.String()))
+		var conflictErr *domain.ConflictError
+		if errors.As(err, &conflictErr) {
+			return nil, conflictErr
+		}
 		return nil, domain.NewDatabaseError("update", "Child", err)
 	}
 
+	// Record the event and the audit record with the change
+	event := domain.NewChildEvent(domain.EventChildUpdated, child)
+	change := domain.NewAuditRecord("UpdateChild", domain.AuditEntityChild, child.ID, before, child.AuditSnapshot())
+	if err := s.record(ctx, event, change); err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to record event", zap.Error(err), zap.String("event_type", string(event.Type)))
+		return nil, domain.NewDatabaseError("record", "Event", err)
+	}
+
+	// Commit transaction
+	err = s.transactionManager.CommitTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("commit", err)
+	}
+
+	s.publish(ctx, event)
+
 	return child, nil
 }
 
@@ -606,6 +1283,21 @@ func (s *FamilyService) DeleteChild(ctx context.Context, id uuid.UUID) error {
 		}
 	}
 
+	// Record the event and the audit record with the change
+	event := domain.NewChildEvent(domain.EventChildDeleted, child)
+	change := domain.NewAuditRecord("DeleteChild", domain.AuditEntityChild, id, domain.DeletionSnapshot(false), domain.DeletionSnapshot(true))
+	if err := s.record(ctx, event, change); err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to record event", zap.Error(err), zap.String("event_type", string(event.Type)))
+		return domain.NewDatabaseError("record", "Event", err)
+	}
+
 	// Commit transaction
 	err = s.transactionManager.CommitTx(ctx)
 	if err != nil {
@@ -613,35 +1305,217 @@ func (s *FamilyService) DeleteChild(ctx context.Context, id uuid.UUID) error {
 		return domain.NewTransactionError("commit", err)
 	}
 
+	s.publish(ctx, event)
+
 	return nil
 }
 
-// ListChildrenByParentID retrieves children for a specific parent with pagination, filtering, and sorting
-func (s *FamilyService) ListChildrenByParentID(ctx context.Context, parentID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
-	ctx, span := s.tracer.Start(ctx, "FamilyService.ListChildrenByParentID")
+// RestoreChild unmarks a child that was marked as deleted and links it to its parent again,
+// adding it back to the parent's children. The parent must not be deleted itself; it has to be
+// restored first. The operation is performed within a transaction.
+// The method uses OpenTelemetry for tracing and logs relevant information during the operation.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - id: The unique identifier of the deleted child to restore
+//
+// Returns:
+//   - *domain.Child: The restored child entity if successful
+//   - error: A NotFoundError if no deleted child has the ID, a ValidationError if the parent of the
+//     child is deleted, a TransactionError if the transaction fails, or a database error
+func (s *FamilyService) RestoreChild(ctx context.Context, id uuid.UUID) (*domain.Child, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.RestoreChild")
 	defer span.End()
 
-	span.SetAttributes(attribute.String("parent.id", parentID.String()))
+	span.SetAttributes(attribute.String("child.id", id.String()))
 
-	children, pagedResult, err := s.childRepo.ListByParentID(ctx, parentID, options)
+	// Begin transaction
+	ctx, err := s.transactionManager.BeginTx(ctx)
 	if err != nil {
-		s.logger.Error("Failed to list children by parent", zap.Error(err), zap.String("parent_id", parentID.String()))
-		return nil, nil, domain.NewDatabaseError("listByParentID", "Child", err)
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("begin", err)
 	}
 
-	return children, pagedResult, nil
-}
-
-// ListChildren retrieves a list of children with pagination, filtering, and sorting
-func (s *FamilyService) ListChildren(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
-	ctx, span := s.tracer.Start(ctx, "FamilyService.ListChildren")
-	defer span.End()
-
-	children, pagedResult, err := s.childRepo.List(ctx, options)
+	// Restore child
+	err = s.childRepo.Restore(ctx, id)
 	if err != nil {
-		s.logger.Error("Failed to list children", zap.Error(err))
-		return nil, nil, domain.NewDatabaseError("list", "Child", err)
-	}
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to restore child", zap.Error(err), zap.String("child_id", id.String()))
+
+		// Check if this is a "not found" error
+		if strings.Contains(err.Error(), "not found") {
+			return nil, domain.NewNotFoundError("Child", id.String())
+		}
+
+		return nil, domain.NewDatabaseError("restore", "Child", err)
+	}
+
+	// Get the restored child to find its parent
+	child, err := s.childRepo.GetByID(ctx, id)
+	if err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to get restored child", zap.Error(err), zap.String("child_id", id.String()))
+		return nil, domain.NewDatabaseError("get", "Child", err)
+	}
+
+	// Get the parent to update its children array
+	parent, err := s.parentRepo.GetByID(ctx, child.ParentID)
+	if err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to get parent for child restore", zap.Error(err), zap.String("parent_id", child.ParentID.String()))
+
+		// A child cannot be restored into a deleted family
+		if strings.Contains(err.Error(), "not found") {
+			return nil, domain.NewValidationError("Child", "parentId", "parent is deleted; restore the parent first")
+		}
+
+		return nil, domain.NewDatabaseError("get", "Parent", err)
+	}
+
+	// Link the child to the parent again, replacing any stale copy
+	parent.RemoveChild(child.ID)
+	parent.AddChild(*child)
+
+	err = s.parentRepo.Update(ctx, parent)
+	if err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to update parent after child restore", zap.Error(err), zap.String("parent_id", parent.ID.String()))
+		return nil, domain.NewDatabaseError("update", "Parent", err)
+	}
+
+	// Record the event and the audit record with the change
+	event := domain.NewChildEvent(domain.EventChildRestored, child)
+	change := domain.NewAuditRecord("RestoreChild", domain.AuditEntityChild, child.ID, domain.DeletionSnapshot(true), domain.DeletionSnapshot(false))
+	if err := s.record(ctx, event, change); err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to record event", zap.Error(err), zap.String("event_type", string(event.Type)))
+		return nil, domain.NewDatabaseError("record", "Event", err)
+	}
+
+	// Commit transaction
+	err = s.transactionManager.CommitTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("commit", err)
+	}
+
+	s.publish(ctx, event)
+
+	return child, nil
+}
+
+// ListChildrenByParentID retrieves children for a specific parent with pagination, filtering, and sorting
+func (s *FamilyService) ListChildrenByParentID(ctx context.Context, parentID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.ListChildrenByParentID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("parent.id", parentID.String()))
+
+	if err := s.authorizeFamily(ctx, parentID, "Parent", parentID.String()); err != nil {
+		return nil, nil, err
+	}
+
+	// The children of a parent are the wards of its active guardianships, not only those it is the primary contact of
+	childIDs, err := s.wardIDs(ctx, []uuid.UUID{parentID})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(childIDs) == 0 {
+		return []*domain.Child{}, emptyPagedResult(options), nil
+	}
+	options.Filter.ParentIDs = nil
+	options.Filter.ChildIDs = childIDs
+
+	children, pagedResult, err := s.childRepo.List(ctx, options)
+	if err != nil {
+		s.logger.Error("Failed to list children by parent", zap.Error(err), zap.String("parent_id", parentID.String()))
+		return nil, nil, domain.NewDatabaseError("listByParentID", "Child", err)
+	}
+
+	return children, pagedResult, nil
+}
+
+// ListChildrenByParentIDs retrieves all children of the given parents in a single batch.
+// Parents outside the caller's family are skipped.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - parentIDs: The unique identifiers of the parents whose children to retrieve
+//
+// Returns:
+//   - []*domain.Child: The children of all the given parents, ordered by parent and creation time
+//   - error: A database error if the lookup fails
+func (s *FamilyService) ListChildrenByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Child, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.ListChildrenByParentIDs")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("parent.count", len(parentIDs)))
+
+	parentIDs, err := s.scopeParentIDs(ctx, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(parentIDs) == 0 {
+		return []*domain.Child{}, nil
+	}
+
+	children, err := s.childRepo.ListByParentIDs(ctx, parentIDs)
+	if err != nil {
+		s.logger.Error("Failed to list children by parent IDs", zap.Error(err), zap.Int("count", len(parentIDs)))
+		return nil, domain.NewDatabaseError("listByParentIDs", "Child", err)
+	}
+
+	return children, nil
+}
+
+// ListChildren retrieves a list of children with pagination, filtering, and sorting.
+// A caller restricted to their own family only sees the children they are an active guardian of.
+func (s *FamilyService) ListChildren(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.ListChildren")
+	defer span.End()
+
+	filter, ok, err := s.scopeChildFilter(ctx, options.Filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return []*domain.Child{}, emptyPagedResult(options), nil
+	}
+	options.Filter = filter
+
+	children, pagedResult, err := s.childRepo.List(ctx, options)
+	if err != nil {
+		s.logger.Error("Failed to list children", zap.Error(err))
+		return nil, nil, domain.NewDatabaseError("list", "Child", err)
+	}
 
 	return children, pagedResult, nil
 }
@@ -651,6 +1525,14 @@ func (s *FamilyService) CountChildren(ctx context.Context, filter ports.FilterOp
 	ctx, span := s.tracer.Start(ctx, "FamilyService.CountChildren")
 	defer span.End()
 
+	filter, ok, err := s.scopeChildFilter(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, nil
+	}
+
 	count, err := s.childRepo.Count(ctx, filter)
 	if err != nil {
 		s.logger.Error("Failed to count children", zap.Error(err))
@@ -660,16 +1542,25 @@ func (s *FamilyService) CountChildren(ctx context.Context, filter ports.FilterOp
 	return count, nil
 }
 
-// AddChildToParent adds a child to a parent
-func (s *FamilyService) AddChildToParent(ctx context.Context, parentID, childID uuid.UUID) error {
+// AddChildToParent makes a parent a guardian of a child, or changes the type and primary contact mark
+// of the parent's active guardianship of the child. The parent becomes the child's primary contact,
+// and its ParentID, when asked to or when the child has no other active guardian.
+func (s *FamilyService) AddChildToParent(ctx context.Context, parentID, childID uuid.UUID, guardianshipType domain.GuardianshipType, primaryContact bool, startDate *time.Time) error {
 	ctx, span := s.tracer.Start(ctx, "FamilyService.AddChildToParent")
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("parent.id", parentID.String()),
 		attribute.String("child.id", childID.String()),
+		attribute.String("guardianship.type", string(guardianshipType)),
+		attribute.Bool("guardianship.primary_contact", primaryContact),
 	)
 
+	// Validate input
+	if !guardianshipType.IsValid() {
+		return domain.NewValidationError("Guardianship", "type", "must be one of MOTHER, FATHER, GUARDIAN or FOSTER")
+	}
+
 	// Begin transaction
 	ctx, err := s.transactionManager.BeginTx(ctx)
 	if err != nil {
@@ -686,7 +1577,6 @@ func (s *FamilyService) AddChildToParent(ctx context.Context, parentID, childID
 			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
 			// We don't return the rollback error as the original error is more important
 		}
-
 		s.logger.Error("Failed to get parent", zap.Error(err), zap.String("parent_id", parentID.String()))
 		return domain.NewNotFoundError("Parent", parentID.String())
 	}
@@ -700,14 +1590,12 @@ func (s *FamilyService) AddChildToParent(ctx context.Context, parentID, childID
 			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
 			// We don't return the rollback error as the original error is more important
 		}
-
 		s.logger.Error("Failed to get child", zap.Error(err), zap.String("child_id", childID.String()))
 		return domain.NewNotFoundError("Child", childID.String())
 	}
 
-	// Update child's parent ID
-	child.ParentID = parentID
-	err = s.childRepo.Update(ctx, child)
+	// Get the guardianships of the child
+	guardianships, err := s.guardianshipRepo.ListByChildIDs(ctx, []uuid.UUID{childID})
 	if err != nil {
 		// Rollback transaction
 		rollbackErr := s.transactionManager.RollbackTx(ctx)
@@ -715,14 +1603,58 @@ func (s *FamilyService) AddChildToParent(ctx context.Context, parentID, childID
 			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
 			// We don't return the rollback error as the original error is more important
 		}
+		s.logger.Error("Failed to list guardianships", zap.Error(err), zap.String("child_id", childID.String()))
+		return domain.NewDatabaseError("list", "Guardianship", err)
+	}
+	before := child.GuardiansSnapshot(guardianships)
 
-		s.logger.Error("Failed to update child", zap.Error(err), zap.String("child_id", childID.String()))
-		return domain.NewDatabaseError("update", "Child", err)
+	// Find the parent's active guardianship of the child, and the child's primary contact
+	var guardianship, primary *domain.Guardianship
+	for _, active := range domain.ActiveGuardianships(guardianships) {
+		if active.ParentID == parentID {
+			guardianship = active
+		}
+		if active.PrimaryContact {
+			primary = active
+		}
 	}
 
-	// Add child to parent
-	parent.AddChild(*child)
-	err = s.parentRepo.Update(ctx, parent)
+	// A child always has a primary contact, so the parent stays or becomes one unless another guardian is
+	makePrimary := primaryContact || primary == nil || primary == guardianship
+
+	// The previous primary contact steps down first, as a child has a single primary contact at a time
+	if makePrimary && primary != nil && primary != guardianship {
+		primary.SetPrimaryContact(false)
+		err = s.guardianshipRepo.Update(ctx, primary)
+		if err != nil {
+			// Rollback transaction
+			rollbackErr := s.transactionManager.RollbackTx(ctx)
+			if rollbackErr != nil {
+				s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+				// We don't return the rollback error as the original error is more important
+			}
+			s.logger.Error("Failed to update guardianship", zap.Error(err), zap.String("guardianship_id", primary.ID.String()))
+			return domain.NewDatabaseError("update", "Guardianship", err)
+		}
+	}
+
+	// Create or update the parent's guardianship
+	if guardianship == nil {
+		start := time.Now().UTC()
+		if startDate != nil {
+			start = *startDate
+		}
+		guardianship = domain.NewGuardianship(parentID, childID, guardianshipType, start, makePrimary)
+		guardianships = append(guardianships, guardianship)
+		err = s.guardianshipRepo.Create(ctx, guardianship)
+	} else {
+		guardianship.Type = guardianshipType
+		if startDate != nil {
+			guardianship.StartDate = startDate.UTC()
+		}
+		guardianship.SetPrimaryContact(makePrimary)
+		err = s.guardianshipRepo.Update(ctx, guardianship)
+	}
 	if err != nil {
 		// Rollback transaction
 		rollbackErr := s.transactionManager.RollbackTx(ctx)
@@ -730,9 +1662,72 @@ func (s *FamilyService) AddChildToParent(ctx context.Context, parentID, childID
 			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
 			// We don't return the rollback error as the original error is more important
 		}
+		s.logger.Error("Failed to save guardianship", zap.Error(err), zap.String("parent_id", parentID.String()), zap.String("child_id", childID.String()))
+		return domain.NewDatabaseError("save", "Guardianship", err)
+	}
+
+	// The child's parent is its primary contact
+	if makePrimary && child.ParentID != parentID {
+		previousParentID := child.ParentID
+		child.ParentID = parentID
+		err = s.childRepo.Update(ctx, child)
+		if err != nil {
+			// Rollback transaction
+			rollbackErr := s.transactionManager.RollbackTx(ctx)
+			if rollbackErr != nil {
+				s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+				// We don't return the rollback error as the original error is more important
+			}
+			s.logger.Error("Failed to update child", zap.Error(err), zap.String("child_id", childID.String()))
+			return domain.NewDatabaseError("update", "Child", err)
+		}
+
+		// Add child to parent
+		parent.AddChild(*child)
+		err = s.parentRepo.Update(ctx, parent)
+		if err != nil {
+			// Rollback transaction
+			rollbackErr := s.transactionManager.RollbackTx(ctx)
+			if rollbackErr != nil {
+				s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+				// We don't return the rollback error as the original error is more important
+			}
+			s.logger.Error("Failed to update parent", zap.Error(err), zap.String("parent_id", parentID.String()))
+			return domain.NewDatabaseError("update", "Parent", err)
+		}
+
+		// Remove child from the previous primary contact, so that it is no longer one of its children;
+		// a previous parent that no longer exists has no children to remove it from
+		previous, err := s.parentRepo.GetByID(ctx, previousParentID)
+		if err == nil && previous.RemoveChild(childID) {
+			err = s.parentRepo.Update(ctx, previous)
+			if err != nil {
+				// Rollback transaction
+				rollbackErr := s.transactionManager.RollbackTx(ctx)
+				if rollbackErr != nil {
+					s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+					// We don't return the rollback error as the original error is more important
+				}
+				s.logger.Error("Failed to update parent", zap.Error(err), zap.String("parent_id", previousParentID.String()))
+				return domain.NewDatabaseError("update", "Parent", err)
+			}
+		}
+	}
 
-		s.logger.Error("Failed to update parent", zap.Error(err), zap.String("parent_id", parentID.String()))
-		return domain.NewDatabaseError("update", "Parent", err)
+	// Record the event and the audit record with the change; the event belongs to the family of
+	// the new guardian, who may not be the primary contact
+	event := domain.NewChildEvent(domain.EventChildAddedToParent, child)
+	event.ParentID = parentID
+	change := domain.NewAuditRecord("AddChildToParent", domain.AuditEntityChild, child.ID, before, child.GuardiansSnapshot(guardianships))
+	if err := s.record(ctx, event, change); err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+		s.logger.Error("Failed to record event", zap.Error(err), zap.String("event_type", string(event.Type)))
+		return domain.NewDatabaseError("record", "Event", err)
 	}
 
 	// Commit transaction
@@ -742,10 +1737,14 @@ func (s *FamilyService) AddChildToParent(ctx context.Context, parentID, childID
 		return domain.NewTransactionError("commit", err)
 	}
 
+	s.publish(ctx, event)
+
 	return nil
 }
 
-// RemoveChildFromParent removes a child from a parent
+// RemoveChildFromParent ends the active guardianship of a parent over a child.
+// When the parent was the primary contact, the child's longest-standing other guardian becomes
+// the primary contact and the child's parent; a child cannot lose its only guardian.
 func (s *FamilyService) RemoveChildFromParent(ctx context.Context, parentID, childID uuid.UUID) error {
 	ctx, span := s.tracer.Start(ctx, "FamilyService.RemoveChildFromParent")
 	defer span.End()
@@ -771,26 +1770,71 @@ func (s *FamilyService) RemoveChildFromParent(ctx context.Context, parentID, chi
 			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
 			// We don't return the rollback error as the original error is more important
 		}
-
 		s.logger.Error("Failed to get parent", zap.Error(err), zap.String("parent_id", parentID.String()))
 		return domain.NewNotFoundError("Parent", parentID.String())
 	}
 
-	// Remove child from parent
-	if !parent.RemoveChild(childID) {
+	// Get child
+	child, err := s.childRepo.GetByID(ctx, childID)
+	if err != nil {
 		// Rollback transaction
 		rollbackErr := s.transactionManager.RollbackTx(ctx)
 		if rollbackErr != nil {
 			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
 			// We don't return the rollback error as the original error is more important
 		}
+		s.logger.Error("Failed to get child", zap.Error(err), zap.String("child_id", childID.String()))
+		return domain.NewNotFoundError("Child", childID.String())
+	}
 
+	// Get the guardianships of the child
+	guardianships, err := s.guardianshipRepo.ListByChildIDs(ctx, []uuid.UUID{childID})
+	if err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+		s.logger.Error("Failed to list guardianships", zap.Error(err), zap.String("child_id", childID.String()))
+		return domain.NewDatabaseError("list", "Guardianship", err)
+	}
+	before := child.GuardiansSnapshot(guardianships)
+
+	// Find the parent's active guardianship of the child, and the guardian who would succeed
+	// the parent as primary contact; the guardianships are ordered by start date
+	var guardianship, successor *domain.Guardianship
+	for _, active := range domain.ActiveGuardianships(guardianships) {
+		if active.ParentID == parentID {
+			guardianship = active
+		} else if successor == nil {
+			successor = active
+		}
+	}
+	if guardianship == nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
 		s.logger.Error("Child not found in parent", zap.String("parent_id", parentID.String()), zap.String("child_id", childID.String()))
 		return domain.NewNotFoundError("Child", childID.String())
 	}
+	if successor == nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+		return domain.NewValidationError("Child", "parentId", "is the only guardian of the child; add another guardian first")
+	}
 
-	// Update parent
-	err = s.parentRepo.Update(ctx, parent)
+	// End the guardianship; it steps down as primary contact before the successor takes over
+	wasPrimary := guardianship.PrimaryContact
+	guardianship.End()
+	err = s.guardianshipRepo.Update(ctx, guardianship)
 	if err != nil {
 		// Rollback transaction
 		rollbackErr := s.transactionManager.RollbackTx(ctx)
@@ -798,17 +1842,1028 @@ func (s *FamilyService) RemoveChildFromParent(ctx context.Context, parentID, chi
 			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
 			// We don't return the rollback error as the original error is more important
 		}
+		s.logger.Error("Failed to update guardianship", zap.Error(err), zap.String("guardianship_id", guardianship.ID.String()))
+		return domain.NewDatabaseError("update", "Guardianship", err)
+	}
+
+	if wasPrimary {
+		successor.SetPrimaryContact(true)
+		err = s.guardianshipRepo.Update(ctx, successor)
+		if err != nil {
+			// Rollback transaction
+			rollbackErr := s.transactionManager.RollbackTx(ctx)
+			if rollbackErr != nil {
+				s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+				// We don't return the rollback error as the original error is more important
+			}
+			s.logger.Error("Failed to update guardianship", zap.Error(err), zap.String("guardianship_id", successor.ID.String()))
+			return domain.NewDatabaseError("update", "Guardianship", err)
+		}
 
-		s.logger.Error("Failed to update parent", zap.Error(err), zap.String("parent_id", parentID.String()))
-		return domain.NewDatabaseError("update", "Parent", err)
+		// The child's parent is its primary contact
+		child.ParentID = successor.ParentID
+		err = s.childRepo.Update(ctx, child)
+		if err != nil {
+			// Rollback transaction
+			rollbackErr := s.transactionManager.RollbackTx(ctx)
+			if rollbackErr != nil {
+				s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+				// We don't return the rollback error as the original error is more important
+			}
+			s.logger.Error("Failed to update child", zap.Error(err), zap.String("child_id", childID.String()))
+			return domain.NewDatabaseError("update", "Child", err)
+		}
 	}
 
-	// Commit transaction
-	err = s.transactionManager.CommitTx(ctx)
-	if err != nil {
-		s.logger.Error("Failed to commit transaction", zap.Error(err))
-		return domain.NewTransactionError("commit", err)
+	// Remove child from parent
+	if parent.RemoveChild(childID) {
+		err = s.parentRepo.Update(ctx, parent)
+		if err != nil {
+			// Rollback transaction
+			rollbackErr := s.transactionManager.RollbackTx(ctx)
+			if rollbackErr != nil {
+				s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+				// We don't return the rollback error as the original error is more important
+			}
+			s.logger.Error("Failed to update parent", zap.Error(err), zap.String("parent_id", parentID.String()))
+			return domain.NewDatabaseError("update", "Parent", err)
+		}
 	}
 
-	return nil
+	// Record the event and the audit record with the change
+	event := domain.NewEvent(domain.EventChildRemovedFromParent, parentID, childID)
+	change := domain.NewAuditRecord("RemoveChildFromParent", domain.AuditEntityChild, childID, before, child.GuardiansSnapshot(guardianships))
+	if err := s.record(ctx, event, change); err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+		s.logger.Error("Failed to record event", zap.Error(err), zap.String("event_type", string(event.Type)))
+		return domain.NewDatabaseError("record", "Event", err)
+	}
+
+	// Commit transaction
+	err = s.transactionManager.CommitTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return domain.NewTransactionError("commit", err)
+	}
+
+	s.publish(ctx, event)
+
+	return nil
+}
+
+// ListGuardianshipsByChildIDs retrieves the guardianships of the given children in a single batch.
+// A caller restricted to their own family only sees the guardianships of the children they are a guardian of.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - childIDs: The unique identifiers of the children whose guardianships to retrieve
+//
+// Returns:
+//   - []*domain.Guardianship: The guardianships, including those that ended, ordered by child and start date
+//   - error: A database error if the lookup fails
+func (s *FamilyService) ListGuardianshipsByChildIDs(ctx context.Context, childIDs []uuid.UUID) ([]*domain.Guardianship, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.ListGuardianshipsByChildIDs")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("child.count", len(childIDs)))
+
+	scopeParentID, restricted, err := s.familyScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	guardianships, err := s.guardianshipRepo.ListByChildIDs(ctx, childIDs)
+	if err != nil {
+		s.logger.Error("Failed to list guardianships by child IDs", zap.Error(err), zap.Int("count", len(childIDs)))
+		return nil, domain.NewDatabaseError("listByChildIDs", "Guardianship", err)
+	}
+	if !restricted {
+		return guardianships, nil
+	}
+
+	// Keep the children the caller is an active guardian of
+	inFamily := make(map[uuid.UUID]bool)
+	for _, guardianship := range domain.ActiveGuardianships(guardianships) {
+		if guardianship.ParentID == scopeParentID && scopeParentID != uuid.Nil {
+			inFamily[guardianship.ChildID] = true
+		}
+	}
+	scoped := make([]*domain.Guardianship, 0, len(guardianships))
+	for _, guardianship := range guardianships {
+		if inFamily[guardianship.ChildID] {
+			scoped = append(scoped, guardianship)
+		}
+	}
+
+	return scoped, nil
+}
+
+// ListGuardianshipsByParentIDs retrieves the guardianships of the given parents in a single batch.
+// Parents outside the caller's family are skipped.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - parentIDs: The unique identifiers of the parents whose guardianships to retrieve
+//
+// Returns:
+//   - []*domain.Guardianship: The guardianships, including those that ended, ordered by parent and start date
+//   - error: A database error if the lookup fails
+func (s *FamilyService) ListGuardianshipsByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Guardianship, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.ListGuardianshipsByParentIDs")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("parent.count", len(parentIDs)))
+
+	parentIDs, err := s.scopeParentIDs(ctx, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(parentIDs) == 0 {
+		return []*domain.Guardianship{}, nil
+	}
+
+	guardianships, err := s.guardianshipRepo.ListByParentIDs(ctx, parentIDs)
+	if err != nil {
+		s.logger.Error("Failed to list guardianships by parent IDs", zap.Error(err), zap.Int("count", len(parentIDs)))
+		return nil, domain.NewDatabaseError("listByParentIDs", "Guardianship", err)
+	}
+
+	return guardianships, nil
+}
+
+// IsEventInFamily reports whether an event concerns the family the caller may access.
+// A caller restricted to their own family sees the events of their parent, including the transfers
+// of children away from it, and those of the children their parent is an active guardian of.
+// Parameters:
+//   - ctx: The context for the operation, carrying the caller's access scope
+//   - event: The event to check
+//
+// Returns:
+//   - bool: Whether the caller may see the event
+//   - error: A database error if the caller's family or the guardianships cannot be looked up
+func (s *FamilyService) IsEventInFamily(ctx context.Context, event domain.Event) (bool, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.IsEventInFamily")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event.type", string(event.Type)))
+
+	scopeParentID, restricted, err := s.familyScope(ctx)
+	if err != nil || !restricted {
+		return err == nil, err
+	}
+	if scopeParentID == uuid.Nil {
+		return false, nil
+	}
+	if event.ParentID == scopeParentID || (event.PreviousParentID != nil && *event.PreviousParentID == scopeParentID) {
+		return true, nil
+	}
+	if event.ChildID == uuid.Nil {
+		return false, nil
+	}
+
+	guardianships, err := s.guardianshipRepo.ListByChildIDs(ctx, []uuid.UUID{event.ChildID})
+	if err != nil {
+		s.logger.Error("Failed to list guardianships", zap.Error(err), zap.String("child_id", event.ChildID.String()))
+		return false, domain.NewDatabaseError("list", "Guardianship", err)
+	}
+	for _, guardianship := range domain.ActiveGuardianships(guardianships) {
+		if guardianship.ParentID == scopeParentID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// CreateHousehold creates a household of parents and children who share an address.
+// Every member must exist, and a child that already belongs to a household must be moved with
+// MoveChildToHousehold instead. The household is created within a transaction, with its audit record.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - name: The name of the household
+//   - address: The address the members share
+//   - phoneNumbers: The phone numbers of the household
+//   - parentIDs: The unique identifiers of the parents who live in the household
+//   - childIDs: The unique identifiers of the children who live in the household
+//
+// Returns:
+//   - *domain.Household: The newly created household
+//   - error: A validation error if the input is invalid or a child already belongs to a household,
+//     a NotFoundError if a member doesn't exist, a TransactionError if the transaction fails, or a database error
+func (s *FamilyService) CreateHousehold(ctx context.Context, name string, address domain.Address, phoneNumbers []string, parentIDs, childIDs []uuid.UUID) (*domain.Household, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.CreateHousehold")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("parent.count", len(parentIDs)),
+		attribute.Int("child.count", len(childIDs)),
+	)
+
+	// Validate input
+	if name == "" {
+		return nil, domain.NewValidationError("Household", "name", "is required")
+	}
+
+	// Create household
+	household := domain.NewHousehold(name, address, phoneNumbers, parentIDs, childIDs)
+	for i, phoneNumber := range household.PhoneNumbers {
+		household.PhoneNumbers[i] = domain.NormalizePhoneNumber(phoneNumber)
+	}
+
+	// Validate household
+	if len(household.ParentIDs) == 0 {
+		return nil, domain.NewValidationError("Household", "parentIds", "must name at least one parent")
+	}
+	if err := s.validator.Struct(household); err != nil {
+		s.logger.Error("Household validation failed", zap.Error(err))
+		if contactErr := contactValidationError("Household", err); contactErr != nil {
+			return nil, contactErr
+		}
+		return nil, domain.NewValidationError("Household", "", err.Error())
+	}
+
+	// Begin transaction
+	ctx, err := s.transactionManager.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("begin", err)
+	}
+
+	// Check that the parents exist
+	for _, parentID := range household.ParentIDs {
+		if _, err := s.parentRepo.GetByID(ctx, parentID); err != nil {
+			// Rollback transaction
+			rollbackErr := s.transactionManager.RollbackTx(ctx)
+			if rollbackErr != nil {
+				s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+				// We don't return the rollback error as the original error is more important
+			}
+			s.logger.Error("Failed to get parent", zap.Error(err), zap.String("parent_id", parentID.String()))
+			return nil, domain.NewNotFoundError("Parent", parentID.String())
+		}
+	}
+
+	// Check that the children exist and do not belong to a household yet
+	for _, childID := range household.ChildIDs {
+		if _, err := s.childRepo.GetByID(ctx, childID); err != nil {
+			// Rollback transaction
+			rollbackErr := s.transactionManager.RollbackTx(ctx)
+			if rollbackErr != nil {
+				s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+				// We don't return the rollback error as the original error is more important
+			}
+			s.logger.Error("Failed to get child", zap.Error(err), zap.String("child_id", childID.String()))
+			return nil, domain.NewNotFoundError("Child", childID.String())
+		}
+
+		current, err := s.householdRepo.GetByChildID(ctx, childID)
+		if err == nil {
+			// Rollback transaction
+			rollbackErr := s.transactionManager.RollbackTx(ctx)
+			if rollbackErr != nil {
+				s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+				// We don't return the rollback error as the original error is more important
+			}
+			return nil, domain.NewValidationError("Household", "childIds",
+				"child "+childID.String()+" already belongs to household "+current.ID.String()+"; move it instead")
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			// Rollback transaction
+			rollbackErr := s.transactionManager.RollbackTx(ctx)
+			if rollbackErr != nil {
+				s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+				// We don't return the rollback error as the original error is more important
+			}
+			s.logger.Error("Failed to get household of child", zap.Error(err), zap.String("child_id", childID.String()))
+			return nil, domain.NewDatabaseError("getByChildID", "Household", err)
+		}
+	}
+
+	// Save household
+	err = s.householdRepo.Create(ctx, household)
+	if err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+		s.logger.Error("Failed to create household", zap.Error(err))
+		return nil, domain.NewDatabaseError("create", "Household", err)
+	}
+
+	// Record the audit record with the change; households are not part of the family events
+	change := domain.NewAuditRecord("CreateHousehold", domain.AuditEntityHousehold, household.ID, nil, household.AuditSnapshot())
+	if err := s.audit(ctx, change); err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+		s.logger.Error("Failed to record audit record", zap.Error(err))
+		return nil, domain.NewDatabaseError("record", "AuditRecord", err)
+	}
+
+	// Commit transaction
+	err = s.transactionManager.CommitTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("commit", err)
+	}
+
+	return household, nil
+}
+
+// GetHouseholdByID retrieves a household by ID.
+// A caller restricted to their own family may only retrieve the households they live in.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - id: The unique identifier of the household
+//
+// Returns:
+//   - *domain.Household: The household
+//   - error: A ForbiddenError if the household is outside the caller's family, a NotFoundError
+//     if the household doesn't exist, or a database error
+func (s *FamilyService) GetHouseholdByID(ctx context.Context, id uuid.UUID) (*domain.Household, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.GetHouseholdByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("household.id", id.String()))
+
+	household, err := s.householdRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.NewNotFoundError("Household", id.String())
+		}
+		s.logger.Error("Failed to get household", zap.Error(err), zap.String("household_id", id.String()))
+		return nil, domain.NewDatabaseError("get", "Household", err)
+	}
+
+	if err := s.authorizeHousehold(ctx, household); err != nil {
+		return nil, err
+	}
+
+	return household, nil
+}
+
+// MoveChildToHousehold makes a child a member of a household. A child belongs to at most one
+// household, so it is removed from the household it belonged to within the same transaction,
+// and the move is recorded in the audit records of both households.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - childID: The unique identifier of the child
+//   - householdID: The unique identifier of the household the child moves to
+//
+// Returns:
+//   - *domain.Household: The household the child moved to
+//   - error: A ForbiddenError if the household is outside the caller's family, a NotFoundError if
+//     either the child or household doesn't exist, a TransactionError if the transaction fails, or a database error
+func (s *FamilyService) MoveChildToHousehold(ctx context.Context, childID, householdID uuid.UUID) (*domain.Household, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.MoveChildToHousehold")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("child.id", childID.String()),
+		attribute.String("household.id", householdID.String()),
+	)
+
+	// Begin transaction
+	ctx, err := s.transactionManager.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("begin", err)
+	}
+
+	// Get child
+	if _, err := s.childRepo.GetByID(ctx, childID); err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+		s.logger.Error("Failed to get child", zap.Error(err), zap.String("child_id", childID.String()))
+		return nil, domain.NewNotFoundError("Child", childID.String())
+	}
+
+	// Get household
+	household, err := s.householdRepo.GetByID(ctx, householdID)
+	if err == nil {
+		err = s.authorizeHousehold(ctx, household)
+	} else if errors.Is(err, domain.ErrNotFound) {
+		err = domain.NewNotFoundError("Household", householdID.String())
+	} else {
+		s.logger.Error("Failed to get household", zap.Error(err), zap.String("household_id", householdID.String()))
+		err = domain.NewDatabaseError("get", "Household", err)
+	}
+	if err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+		return nil, err
+	}
+
+	// Get the household the child belongs to, if any
+	previous, err := s.householdRepo.GetByChildID(ctx, childID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+		s.logger.Error("Failed to get household of child", zap.Error(err), zap.String("child_id", childID.String()))
+		return nil, domain.NewDatabaseError("getByChildID", "Household", err)
+	}
+
+	// Moving a child to the household it already belongs to changes nothing
+	if previous != nil && previous.ID == household.ID {
+		err = s.transactionManager.CommitTx(ctx)
+		if err != nil {
+			s.logger.Error("Failed to commit transaction", zap.Error(err))
+			return nil, domain.NewTransactionError("commit", err)
+		}
+		return household, nil
+	}
+
+	// The child leaves its previous household first, as a child belongs to a single household at a time
+	changes := make([]*domain.AuditRecord, 0, 2)
+	if previous != nil {
+		before := previous.AuditSnapshot()
+		previous.RemoveChild(childID)
+		err = s.householdRepo.Update(ctx, previous)
+		if err != nil {
+			// Rollback transaction
+			rollbackErr := s.transactionManager.RollbackTx(ctx)
+			if rollbackErr != nil {
+				s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+				// We don't return the rollback error as the original error is more important
+			}
+			s.logger.Error("Failed to update household", zap.Error(err), zap.String("household_id", previous.ID.String()))
+			return nil, domain.NewDatabaseError("update", "Household", err)
+		}
+		changes = append(changes, domain.NewAuditRecord("MoveChildToHousehold", domain.AuditEntityHousehold, previous.ID, before, previous.AuditSnapshot()))
+	}
+
+	// Add the child to the household
+	before := household.AuditSnapshot()
+	household.AddChild(childID)
+	err = s.householdRepo.Update(ctx, household)
+	if err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+		s.logger.Error("Failed to update household", zap.Error(err), zap.String("household_id", household.ID.String()))
+		return nil, domain.NewDatabaseError("update", "Household", err)
+	}
+	changes = append(changes, domain.NewAuditRecord("MoveChildToHousehold", domain.AuditEntityHousehold, household.ID, before, household.AuditSnapshot()))
+
+	// Record the audit records with the change
+	for _, change := range changes {
+		if err := s.audit(ctx, change); err != nil {
+			// Rollback transaction
+			rollbackErr := s.transactionManager.RollbackTx(ctx)
+			if rollbackErr != nil {
+				s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+				// We don't return the rollback error as the original error is more important
+			}
+			s.logger.Error("Failed to record audit record", zap.Error(err))
+			return nil, domain.NewDatabaseError("record", "AuditRecord", err)
+		}
+	}
+
+	// Commit transaction
+	err = s.transactionManager.CommitTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("commit", err)
+	}
+
+	return household, nil
+}
+
+// PurgeDeleted permanently removes the parents and children of the caller's tenant that were
+// marked as deleted longer ago than the configured retention. Children are purged before
+// parents, and parents that still have children are kept. The operation is performed within a transaction.
+// The method uses OpenTelemetry for tracing and logs relevant information during the operation.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//
+// Returns:
+//   - *ports.PurgeResult: The numbers of parents and children removed
+//   - error: A TransactionError if the transaction fails, or a database error
+func (s *FamilyService) PurgeDeleted(ctx context.Context) (*ports.PurgeResult, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.PurgeDeleted")
+	defer span.End()
+
+	deletedBefore := time.Now().UTC().Add(-s.deletedRetention)
+	span.SetAttributes(attribute.String("purge.deleted_before", deletedBefore.Format(time.RFC3339)))
+
+	// Begin transaction
+	ctx, err := s.transactionManager.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("begin", err)
+	}
+
+	// Purge children first, so that their parents can be purged as well
+	children, err := s.childRepo.Purge(ctx, deletedBefore)
+	if err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to purge children", zap.Error(err))
+		return nil, domain.NewDatabaseError("purge", "Child", err)
+	}
+
+	parents, err := s.parentRepo.Purge(ctx, deletedBefore)
+	if err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to purge parents", zap.Error(err))
+		return nil, domain.NewDatabaseError("purge", "Parent", err)
+	}
+
+	// Audit the purge with the numbers of records removed, as the records themselves are gone
+	change := domain.NewAuditRecord("PurgeDeleted", domain.AuditEntityDeletedRecords, uuid.Nil, nil, domain.AuditSnapshot{
+		"parents":  strconv.FormatInt(parents, 10),
+		"children": strconv.FormatInt(children, 10),
+	})
+	if err := s.audit(ctx, change); err != nil {
+		// Rollback transaction
+		rollbackErr := s.transactionManager.RollbackTx(ctx)
+		if rollbackErr != nil {
+			s.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+			// We don't return the rollback error as the original error is more important
+		}
+
+		s.logger.Error("Failed to record audit record", zap.Error(err), zap.String("operation", change.Operation))
+		return nil, domain.NewDatabaseError("record", "AuditRecord", err)
+	}
+
+	// Commit transaction
+	err = s.transactionManager.CommitTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("commit", err)
+	}
+
+	s.logger.Info("Purged deleted records",
+		zap.Int64("parents", parents),
+		zap.Int64("children", children),
+		zap.Time("deleted_before", deletedBefore))
+
+	return &ports.PurgeResult{Parents: parents, Children: children}, nil
+}
+
+// GetParentAsOf retrieves a parent as it was at a point in time, from its recorded history.
+// The parent's AsOf is set to the point in time, so that its children at that time can be listed
+// with ListChildrenByParentIDAsOf.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - id: The unique identifier of the parent to retrieve
+//   - asOf: The point in time
+//
+// Returns:
+//   - *domain.Parent: The parent as it was at that time
+//   - error: A ForbiddenError if the parent is outside the caller's family, a NotFoundError
+//     if the parent did not exist or was deleted at that time, or a database error
+func (s *FamilyService) GetParentAsOf(ctx context.Context, id uuid.UUID, asOf time.Time) (*domain.Parent, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.GetParentAsOf")
+	defer span.End()
+
+	asOf = asOf.UTC()
+	span.SetAttributes(
+		attribute.String("parent.id", id.String()),
+		attribute.String("history.as_of", asOf.Format(time.RFC3339)),
+	)
+
+	if err := s.authorizeFamily(ctx, id, "Parent", id.String()); err != nil {
+		return nil, err
+	}
+
+	parent, err := s.historyRepo.GetParentAsOf(ctx, id, asOf)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.NewNotFoundError("Parent", id.String())
+		}
+		s.logger.Error("Failed to get parent as of time", zap.Error(err), zap.String("parent_id", id.String()))
+		return nil, domain.NewDatabaseError("getAsOf", "Parent", err)
+	}
+
+	parent.AsOf = &asOf
+	return parent, nil
+}
+
+// ListChildrenByParentIDAsOf retrieves the children a parent had at a point in time, from their recorded history.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - parentID: The unique identifier of the parent whose children to retrieve
+//   - asOf: The point in time
+//
+// Returns:
+//   - []*domain.Child: The children as they were at that time, ordered by creation time
+//   - error: A ForbiddenError if the parent is outside the caller's family, or a database error
+func (s *FamilyService) ListChildrenByParentIDAsOf(ctx context.Context, parentID uuid.UUID, asOf time.Time) ([]*domain.Child, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.ListChildrenByParentIDAsOf")
+	defer span.End()
+
+	asOf = asOf.UTC()
+	span.SetAttributes(
+		attribute.String("parent.id", parentID.String()),
+		attribute.String("history.as_of", asOf.Format(time.RFC3339)),
+	)
+
+	if err := s.authorizeFamily(ctx, parentID, "Parent", parentID.String()); err != nil {
+		return nil, err
+	}
+
+	children, err := s.historyRepo.ListChildrenAsOf(ctx, parentID, asOf)
+	if err != nil {
+		s.logger.Error("Failed to list children as of time", zap.Error(err), zap.String("parent_id", parentID.String()))
+		return nil, domain.NewDatabaseError("listAsOf", "Child", err)
+	}
+
+	return children, nil
+}
+
+// GetHistory retrieves every recorded version of a parent or child, including the versions
+// that marked it as deleted or restored it. A caller restricted to their own family may only
+// retrieve the history of a parent whose latest version belongs to that family, or of a child
+// they are an active guardian of.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - id: The unique identifier of the parent or child
+//
+// Returns:
+//   - []*domain.Revision: The revisions, oldest first
+//   - error: A ForbiddenError if the entity is outside the caller's family, a NotFoundError
+//     if no parent or child has a history with the ID, or a database error
+func (s *FamilyService) GetHistory(ctx context.Context, id uuid.UUID) ([]*domain.Revision, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.GetHistory")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("history.entity_id", id.String()))
+
+	// The ID is looked up among the parents first, as parents and children never share an ID
+	entityType := "Parent"
+	revisions, err := s.historyRepo.ListParentRevisions(ctx, id)
+	if err == nil && len(revisions) == 0 {
+		entityType = "Child"
+		revisions, err = s.historyRepo.ListChildRevisions(ctx, id)
+	}
+	if err != nil {
+		s.logger.Error("Failed to list revisions", zap.Error(err), zap.String("entity_id", id.String()))
+		return nil, domain.NewDatabaseError("listRevisions", entityType, err)
+	}
+	if len(revisions) == 0 {
+		return nil, domain.NewNotFoundError("Revision", id.String())
+	}
+
+	// The family of the entity is the one of its latest version, and a child belongs to the families of its guardians
+	latest := revisions[len(revisions)-1]
+	if latest.Child != nil {
+		err = s.authorizeChild(ctx, latest.Child)
+	} else {
+		err = s.authorizeFamily(ctx, latest.EntityID(), entityType, id.String())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("history.revisions", len(revisions)))
+
+	return revisions, nil
+}
+
+// familyScope resolves the access scope carried by ctx.
+// For a caller restricted to their own family, it returns the ID of the parent linked to the
+// caller, or uuid.Nil when the caller is not linked to a parent.
+// Parameters:
+//   - ctx: The context for the operation, carrying the caller's access scope
+//
+// Returns:
+//   - uuid.UUID: The ID of the only parent whose family the caller may access
+//   - bool: true if the caller is restricted to their own family, false if they may access every family
+//   - error: A database error if the linked parent cannot be looked up
+func (s *FamilyService) familyScope(ctx context.Context) (uuid.UUID, bool, error) {
+	scope := ports.AccessScopeFromContext(ctx)
+	if !scope.Restricted {
+		return uuid.Nil, false, nil
+	}
+	if scope.UserID == "" {
+		return uuid.Nil, true, nil
+	}
+
+	parent, err := s.parentRepo.GetByUserID(ctx, scope.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return uuid.Nil, true, nil
+		}
+		s.logger.Error("Failed to get parent by user ID", zap.Error(err), zap.String("user_id", scope.UserID))
+		return uuid.Nil, true, domain.NewDatabaseError("getByUserID", "Parent", err)
+	}
+
+	return parent.ID, true, nil
+}
+
+// authorizeFamily checks that the caller may access the family of a parent.
+// Parameters:
+//   - ctx: The context for the operation, carrying the caller's access scope
+//   - parentID: The ID of the parent whose family is accessed
+//   - entityType: The type of the entity being accessed, for the error
+//   - id: The ID of the entity being accessed, for the error
+//
+// Returns:
+//   - error: A ForbiddenError if the family is outside the caller's scope, or a database error
+func (s *FamilyService) authorizeFamily(ctx context.Context, parentID uuid.UUID, entityType, id string) error {
+	scopeParentID, restricted, err := s.familyScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	if restricted && parentID != scopeParentID {
+		s.logger.Warn("Access outside the caller's family denied",
+			zap.String("entity_type", entityType),
+			zap.String("id", id),
+			zap.String("user_id", ports.AccessScopeFromContext(ctx).UserID))
+		return domain.NewForbiddenError(entityType, id)
+	}
+
+	return nil
+}
+
+// authorizeChild checks that the caller may access a child, which belongs to the families of
+// its active guardians, its primary contact among them.
+// Parameters:
+//   - ctx: The context for the operation, carrying the caller's access scope
+//   - child: The child being accessed
+//
+// Returns:
+//   - error: A ForbiddenError if the child is outside the caller's family, or a database error
+func (s *FamilyService) authorizeChild(ctx context.Context, child *domain.Child) error {
+	scopeParentID, restricted, err := s.familyScope(ctx)
+	if err != nil {
+		return err
+	}
+	if !restricted {
+		return nil
+	}
+
+	if scopeParentID != uuid.Nil {
+		guardianships, err := s.guardianshipRepo.ListByChildIDs(ctx, []uuid.UUID{child.ID})
+		if err != nil {
+			s.logger.Error("Failed to list guardianships", zap.Error(err), zap.String("child_id", child.ID.String()))
+			return domain.NewDatabaseError("list", "Guardianship", err)
+		}
+		for _, guardianship := range domain.ActiveGuardianships(guardianships) {
+			if guardianship.ParentID == scopeParentID {
+				return nil
+			}
+		}
+	}
+
+	s.logger.Warn("Access outside the caller's family denied",
+		zap.String("entity_type", "Child"),
+		zap.String("id", child.ID.String()),
+		zap.String("user_id", ports.AccessScopeFromContext(ctx).UserID))
+	return domain.NewForbiddenError("Child", child.ID.String())
+}
+
+// authorizeHousehold checks that the caller may access a household, which belongs to the
+// families of the parents who live in it.
+// Parameters:
+//   - ctx: The context for the operation, carrying the caller's access scope
+//   - household: The household being accessed
+//
+// Returns:
+//   - error: A ForbiddenError if the household is outside the caller's family, or a database error
+func (s *FamilyService) authorizeHousehold(ctx context.Context, household *domain.Household) error {
+	scopeParentID, restricted, err := s.familyScope(ctx)
+	if err != nil {
+		return err
+	}
+	if !restricted || (scopeParentID != uuid.Nil && household.HasParent(scopeParentID)) {
+		return nil
+	}
+
+	s.logger.Warn("Access outside the caller's family denied",
+		zap.String("entity_type", "Household"),
+		zap.String("id", household.ID.String()),
+		zap.String("user_id", ports.AccessScopeFromContext(ctx).UserID))
+	return domain.NewForbiddenError("Household", household.ID.String())
+}
+
+// scopeFilter restricts a list filter to the family the caller may access.
+// Parameters:
+//   - ctx: The context for the operation, carrying the caller's access scope
+//   - filter: The filter requested by the caller
+//
+// Returns:
+//   - ports.FilterOptions: The filter, restricted to the caller's family if needed
+//   - bool: false if the caller may not access any family, so the result is empty
+//   - error: A database error if the caller's family cannot be looked up
+func (s *FamilyService) scopeFilter(ctx context.Context, filter ports.FilterOptions) (ports.FilterOptions, bool, error) {
+	scopeParentID, restricted, err := s.familyScope(ctx)
+	if err != nil || !restricted {
+		return filter, err == nil, err
+	}
+	if scopeParentID == uuid.Nil {
+		return filter, false, nil
+	}
+
+	filter.ParentIDs = []uuid.UUID{scopeParentID}
+	return filter, true, nil
+}
+
+// scopeChildFilter restricts a list filter of children to the family the caller may access.
+// The children of the parents the filter names, including the caller's own parent, are the
+// wards of their active guardianships.
+// Parameters:
+//   - ctx: The context for the operation, carrying the caller's access scope
+//   - filter: The filter requested by the caller
+//
+// Returns:
+//   - ports.FilterOptions: The filter, restricted to the caller's wards if needed
+//   - bool: false if the filter matches no child, so the result is empty
+//   - error: A database error if the caller's family or the guardianships cannot be looked up
+func (s *FamilyService) scopeChildFilter(ctx context.Context, filter ports.FilterOptions) (ports.FilterOptions, bool, error) {
+	filter, ok, err := s.scopeFilter(ctx, filter)
+	if err != nil || !ok || len(filter.ParentIDs) == 0 {
+		return filter, ok, err
+	}
+
+	childIDs, err := s.wardIDs(ctx, filter.ParentIDs)
+	if err != nil {
+		return filter, false, err
+	}
+
+	filter.ParentIDs = nil
+	filter.ChildIDs = childIDs
+	return filter, len(childIDs) > 0, nil
+}
+
+// wardIDs returns the IDs of the children the given parents are active guardians of.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - parentIDs: The unique identifiers of the parents
+//
+// Returns:
+//   - []uuid.UUID: The IDs of the children, each listed once
+//   - error: A database error if the guardianships cannot be looked up
+func (s *FamilyService) wardIDs(ctx context.Context, parentIDs []uuid.UUID) ([]uuid.UUID, error) {
+	guardianships, err := s.guardianshipRepo.ListByParentIDs(ctx, parentIDs)
+	if err != nil {
+		s.logger.Error("Failed to list guardianships", zap.Error(err), zap.Int("count", len(parentIDs)))
+		return nil, domain.NewDatabaseError("list", "Guardianship", err)
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	childIDs := []uuid.UUID{}
+	for _, guardianship := range domain.ActiveGuardianships(guardianships) {
+		if !seen[guardianship.ChildID] {
+			seen[guardianship.ChildID] = true
+			childIDs = append(childIDs, guardianship.ChildID)
+		}
+	}
+
+	return childIDs, nil
+}
+
+// scopeParentIDs removes the parents outside the caller's family from a list of parent IDs.
+// Parameters:
+//   - ctx: The context for the operation, carrying the caller's access scope
+//   - parentIDs: The requested parent IDs
+//
+// Returns:
+//   - []uuid.UUID: The parent IDs the caller may access
+//   - error: A database error if the caller's family cannot be looked up
+func (s *FamilyService) scopeParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]uuid.UUID, error) {
+	scopeParentID, restricted, err := s.familyScope(ctx)
+	if err != nil || !restricted {
+		return parentIDs, err
+	}
+
+	for _, id := range parentIDs {
+		if id == scopeParentID && id != uuid.Nil {
+			return []uuid.UUID{id}, nil
+		}
+	}
+
+	return []uuid.UUID{}, nil
+}
+
+// emptyPagedResult returns the pagination information of a list without results
+func emptyPagedResult(options ports.QueryOptions) *ports.PagedResult {
+	return &ports.PagedResult{
+		Page:     options.Pagination.Page,
+		PageSize: options.Pagination.PageSize,
+	}
+}
+
+// rollback rolls back the transaction carried by ctx after an operation failed. A failure to roll back is
+// only logged, as the error that made the operation fail is more important.
+// Parameters:
+//   - ctx: The context for the operation, carrying the transaction
+func (s *FamilyService) rollback(ctx context.Context) {
+	if err := s.transactionManager.RollbackTx(ctx); err != nil {
+		s.logger.Error("Failed to rollback transaction", zap.Error(err))
+	}
+}
+
+// record appends a domain event to the outbox and the audit record of the change to the audit log,
+// within the transaction carried by ctx, so that both are kept if and only if the transaction commits.
+// The event is not recorded when the service has no outbox.
+// Parameters:
+//   - ctx: The context for the operation, carrying the transaction and the caller's tenant
+//   - event: The domain event to record
+//   - change: The audit record of the change
+//
+// Returns:
+//   - error: An error if the event or the audit record could not be recorded, in which case the
+//     transaction must be rolled back
+func (s *FamilyService) record(ctx context.Context, event domain.Event, change *domain.AuditRecord) error {
+	if s.outbox != nil {
+		// Downstream systems route the events by tenant
+		if event.TenantID == "" {
+			event.TenantID = ports.TenantIDFromContext(ctx)
+		}
+
+		if err := s.outbox.Append(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return s.audit(ctx, change)
+}
+
+// audit appends the audit record of a change to the audit log within the transaction carried by ctx,
+// attributing it to the caller and to the trace of the request. It does nothing when the service has no audit log.
+// Parameters:
+//   - ctx: The context for the operation, carrying the transaction, the caller's identity and tenant, and the trace
+//   - change: The audit record of the change
+//
+// Returns:
+//   - error: An error if the audit record could not be recorded, in which case the transaction must be rolled back
+func (s *FamilyService) audit(ctx context.Context, change *domain.AuditRecord) error {
+	if s.auditLog == nil {
+		return nil
+	}
+
+	// Internal callers, such as scheduled purges, have no user; their changes are recorded without an actor
+	if s.authService != nil {
+		if userID, err := s.authService.GetUserID(ctx); err == nil {
+			change.Actor = userID
+		}
+	}
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.HasTraceID() {
+		change.TraceID = spanCtx.TraceID().String()
+	}
+	change.TenantID = ports.TenantIDFromContext(ctx)
+
+	return s.auditLog.Append(ctx, change)
+}
+
+// publish publishes a domain event for a change that has already been persisted.
+// Publishing is best effort: a failure is logged but does not fail the operation,
+// because the change itself cannot be undone at this point. Systems that must not miss
+// an event receive it from the outbox relay instead.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - event: The domain event to publish
+func (s *FamilyService) publish(ctx context.Context, event domain.Event) {
+	if s.eventPublisher == nil {
+		return
+	}
+
+	// Subscribers only receive the events of their own tenant
+	if event.TenantID == "" {
+		event.TenantID = ports.TenantIDFromContext(ctx)
+	}
+
+	if err := s.eventPublisher.Publish(ctx, event); err != nil {
+		s.logger.Error("Failed to publish event",
+			zap.Error(err),
+			zap.String("event_type", string(event.Type)),
+			zap.String("parent_id", event.ParentID.String()))
+	}
 }
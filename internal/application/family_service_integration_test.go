@@ -114,6 +114,8 @@ func setupFamilyServiceIntegration(t *testing.T) (*application.FamilyService, co
 	// Create repositories
 	parentRepo := mongodb.NewParentRepository(ctx, db, logger, mongoConfig)
 	childRepo := mongodb.NewChildRepository(ctx, db, logger, mongoConfig)
+	guardianshipRepo := mongodb.NewGuardianshipRepository(db, logger)
+	householdRepo := mongodb.NewHouseholdRepository(db, logger)
 
 	// Create transaction manager
 	txManager := mongodb.NewTransactionManager(db.Client(), logger)
@@ -122,6 +124,8 @@ func setupFamilyServiceIntegration(t *testing.T) (*application.FamilyService, co
 	repoFactory := &mongoRepositoryFactory{
 		parentRepo: parentRepo,
 		childRepo:  childRepo,
+		guardians:  guardianshipRepo,
+		households: householdRepo,
 		txManager:  txManager,
 	}
 
@@ -131,6 +135,7 @@ func setupFamilyServiceIntegration(t *testing.T) (*application.FamilyService, co
 	// Create family service
 	service := application.NewFamilyService(
 		repoFactory,
+		nil,
 		validate,
 		logger,
 	)
@@ -172,6 +177,8 @@ func (c *KoanfMongoDBConfig) GetURI() string {
 type mongoRepositoryFactory struct {
 	parentRepo ports.ParentRepository
 	childRepo  ports.ChildRepository
+	guardians  ports.GuardianshipRepository
+	households ports.HouseholdRepository
 	txManager  ports.TransactionManager
 }
 
@@ -183,6 +190,30 @@ func (f *mongoRepositoryFactory) NewChildRepository() ports.ChildRepository {
 	return f.childRepo
 }
 
+func (f *mongoRepositoryFactory) NewOutboxRepository() ports.OutboxRepository {
+	return nil
+}
+
+func (f *mongoRepositoryFactory) NewWebhookRepository() ports.WebhookRepository {
+	return nil
+}
+
+func (f *mongoRepositoryFactory) NewAuditLogRepository() ports.AuditLogRepository {
+	return nil
+}
+
+func (f *mongoRepositoryFactory) NewHistoryRepository() ports.HistoryRepository {
+	return nil
+}
+
+func (f *mongoRepositoryFactory) NewGuardianshipRepository() ports.GuardianshipRepository {
+	return f.guardians
+}
+
+func (f *mongoRepositoryFactory) NewHouseholdRepository() ports.HouseholdRepository {
+	return f.households
+}
+
 func (f *mongoRepositoryFactory) GetTransactionManager() ports.TransactionManager {
 	return f.txManager
 }
@@ -223,7 +254,7 @@ func TestFamilyServiceIntegration(t *testing.T) {
 		email := "john.doe@example.com"
 		birthDate := time.Now().AddDate(-30, 0, 0).Format(time.RFC3339)
 
-		parent, err := service.CreateParent(ctx, firstName, lastName, email, birthDate)
+		parent, err := service.CreateParent(ctx, firstName, lastName, email, birthDate, nil)
 		require.NoError(t, err, "Failed to create parent")
 		assert.NotNil(t, parent, "Parent should not be nil")
 		assert.Equal(t, firstName, parent.FirstName, "First name should match")
@@ -248,6 +279,7 @@ func TestFamilyServiceIntegration(t *testing.T) {
 			"Smith",
 			"jane.smith@example.com",
 			time.Now().AddDate(-25, 0, 0).Format(time.RFC3339),
+			nil,
 		)
 		require.NoError(t, err, "Failed to create parent")
 
@@ -259,6 +291,8 @@ func TestFamilyServiceIntegration(t *testing.T) {
 			"Johnson",
 			"janet.johnson@example.com",
 			time.Now().AddDate(-26, 0, 0).Format(time.RFC3339),
+			nil,
+			nil,
 		)
 		require.NoError(t, err, "Failed to update parent")
 		assert.Equal(t, parent.ID, updatedParent.ID, "Parent ID should match")
@@ -283,6 +317,7 @@ func TestFamilyServiceIntegration(t *testing.T) {
 			"Brown",
 			"bob.brown@example.com",
 			time.Now().AddDate(-40, 0, 0).Format(time.RFC3339),
+			nil,
 		)
 		require.NoError(t, err, "Failed to create parent")
 
@@ -323,6 +358,7 @@ func TestFamilyServiceIntegration(t *testing.T) {
 				"Parent"+string(rune('A'+i)),
 				"list.test"+string(rune('a'+i))+"@example.com",
 				time.Now().AddDate(-30-i, 0, 0).Format(time.RFC3339),
+				nil,
 			)
 			require.NoError(t, err, "Failed to create parent")
 		}
@@ -358,6 +394,7 @@ func TestFamilyServiceIntegration(t *testing.T) {
 			"Parent",
 			"childlist.parent@example.com",
 			time.Now().AddDate(-35, 0, 0).Format(time.RFC3339),
+			nil,
 		)
 		require.NoError(t, err, "Failed to create parent")
 
@@ -402,6 +439,7 @@ func TestFamilyServiceIntegration(t *testing.T) {
 			"Parent",
 			"delete.parent@example.com",
 			time.Now().AddDate(-45, 0, 0).Format(time.RFC3339),
+			nil,
 		)
 		require.NoError(t, err, "Failed to create parent")
 
@@ -444,6 +482,7 @@ func TestFamilyServiceIntegration(t *testing.T) {
 			"Parent",
 			"deletechild.parent@example.com",
 			time.Now().AddDate(-50, 0, 0).Format(time.RFC3339),
+			nil,
 		)
 		require.NoError(t, err, "Failed to create parent")
 
@@ -485,6 +524,7 @@ func TestFamilyServiceIntegration(t *testing.T) {
 			"Email",
 			"not-an-email",
 			time.Now().AddDate(-30, 0, 0).Format(time.RFC3339),
+			nil,
 		)
 		require.Error(t, err, "Should fail with invalid email")
 		assert.Contains(t, strings.ToLower(err.Error()), "email", "Error should mention email")
@@ -496,6 +536,7 @@ func TestFamilyServiceIntegration(t *testing.T) {
 			"LastName",
 			"email@example.com",
 			time.Now().AddDate(-30, 0, 0).Format(time.RFC3339),
+			nil,
 		)
 		require.Error(t, err, "Should fail with missing first name")
 		assert.Contains(t, strings.ToLower(err.Error()), "first name", "Error should mention first name")
@@ -508,6 +549,7 @@ func TestFamilyServiceIntegration(t *testing.T) {
 			"Parent",
 			"valid.parent@example.com",
 			time.Now().AddDate(-30, 0, 0).Format(time.RFC3339),
+			nil,
 		)
 		require.NoError(t, err, "Failed to create parent")
 
@@ -542,6 +584,8 @@ func TestFamilyServiceIntegration(t *testing.T) {
 			"Parent",
 			"nonexistent.parent@example.com",
 			time.Now().AddDate(-30, 0, 0).Format(time.RFC3339),
+			nil,
+			nil,
 		)
 		require.Error(t, err, "Should fail with parent not found")
 		assert.Contains(t, err.Error(), "not found", "Error should indicate parent not found")
@@ -553,6 +597,7 @@ func TestFamilyServiceIntegration(t *testing.T) {
 			"NonExistent",
 			"Child",
 			time.Now().AddDate(-10, 0, 0).Format(time.RFC3339),
+			nil,
 		)
 		require.Error(t, err, "Should fail with child not found")
 		assert.Contains(t, err.Error(), "not found", "Error should indicate child not found")
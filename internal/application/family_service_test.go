@@ -3,6 +3,8 @@ package application_test
 import (
 	"context"
 	"errors"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap/zaptest"
 )
 
@@ -33,6 +36,7 @@ func setupFamilyServiceTest(t *testing.T) (
 	// Create service
 	service := application.NewFamilyService(
 		repoFactory,
+		mocks.NewMockEventBroker(),
 		validate,
 		logger,
 	)
@@ -53,7 +57,7 @@ func TestCreateParent_Success(t *testing.T) {
 	birthDate := time.Now().AddDate(-30, 0, 0).Format(time.RFC3339)
 
 	// Act
-	parent, err := service.CreateParent(ctx, firstName, lastName, email, birthDate)
+	parent, err := service.CreateParent(ctx, firstName, lastName, email, birthDate, nil)
 
 	// Assert
 	require.NoError(t, err)
@@ -78,6 +82,7 @@ func TestCreateParent_ValidationFailure(t *testing.T) {
 	// Create a new service with the custom validator
 	customService := application.NewFamilyService(
 		repoFactory,
+		mocks.NewMockEventBroker(),
 		customValidator,
 		zaptest.NewLogger(t),
 	)
@@ -91,13 +96,32 @@ func TestCreateParent_ValidationFailure(t *testing.T) {
 	birthDate := time.Now().AddDate(-30, 0, 0).Format(time.RFC3339)
 
 	// Act
-	parent, err := customService.CreateParent(ctx, firstName, lastName, email, birthDate)
+	parent, err := customService.CreateParent(ctx, firstName, lastName, email, birthDate, nil)
 
 	// Assert
 	require.Error(t, err)
 	assert.Nil(t, parent)
 }
 
+func TestCreateParent_DuplicateEmail(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	birthDate := time.Now().AddDate(-30, 0, 0).Format(time.RFC3339)
+	_, err := service.CreateParent(ctx, "John", "Doe", "john.doe@example.com", birthDate, nil)
+	require.NoError(t, err)
+
+	// Act: the email differs only in case
+	parent, err := service.CreateParent(ctx, "Johnny", "Doe", "JOHN.DOE@example.com", birthDate, nil)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, parent)
+	var duplicateErr *domain.DuplicateError
+	require.ErrorAs(t, err, &duplicateErr)
+	assert.Equal(t, "email", duplicateErr.Field)
+	assert.True(t, repoFactory.GetMockTransactionManager().RollbackTxCalled)
+}
+
 func TestCreateParent_MissingRequiredFields(t *testing.T) {
 	// Arrange
 	service, _, _, _, ctx := setupFamilyServiceTest(t)
@@ -155,7 +179,7 @@ func TestCreateParent_MissingRequiredFields(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// Act
-			parent, err := service.CreateParent(ctx, tc.firstName, tc.lastName, tc.email, tc.birthDate)
+			parent, err := service.CreateParent(ctx, tc.firstName, tc.lastName, tc.email, tc.birthDate, nil)
 
 			// Assert
 			require.Error(t, err)
@@ -213,7 +237,7 @@ func TestUpdateParent_Success(t *testing.T) {
 	newBirthDate := time.Now().AddDate(-25, 0, 0).Format(time.RFC3339)
 
 	// Act
-	updatedParent, err := service.UpdateParent(ctx, testParent.ID, newFirstName, newLastName, newEmail, newBirthDate)
+	updatedParent, err := service.UpdateParent(ctx, testParent.ID, newFirstName, newLastName, newEmail, newBirthDate, nil, nil)
 
 	// Assert
 	require.NoError(t, err)
@@ -231,6 +255,27 @@ func TestUpdateParent_Success(t *testing.T) {
 	assert.Equal(t, newEmail, savedParent.Email)
 }
 
+func TestUpdateParent_DuplicateEmail(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	john := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	jane := domain.NewParent("Jane", "Smith", "jane.smith@example.com", time.Now().AddDate(-25, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(john)
+	repoFactory.GetMockParentRepository().AddTestParent(jane)
+
+	// Act
+	updatedParent, err := service.UpdateParent(ctx, jane.ID, "Jane", "Smith", "john.doe@example.com", jane.BirthDate.Format(time.RFC3339), nil, nil)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, updatedParent)
+	assert.ErrorIs(t, err, domain.ErrDuplicate)
+
+	// A parent keeps its own email, whatever its case
+	_, err = service.UpdateParent(ctx, john.ID, "John", "Doe", "John.Doe@example.com", john.BirthDate.Format(time.RFC3339), nil, nil)
+	require.NoError(t, err)
+}
+
 func TestUpdateParent_NotFound(t *testing.T) {
 	// Arrange
 	service, _, _, _, ctx := setupFamilyServiceTest(t)
@@ -243,6 +288,8 @@ func TestUpdateParent_NotFound(t *testing.T) {
 		"Smith",
 		"jane.smith@example.com",
 		time.Now().AddDate(-25, 0, 0).Format(time.RFC3339),
+		nil,
+		nil,
 	)
 
 	// Assert
@@ -251,6 +298,53 @@ func TestUpdateParent_NotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "Parent with ID")
 }
 
+func TestUpdateParent_ExpectedVersion(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+
+	testParent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(testParent)
+	birthDate := testParent.BirthDate.Format(time.RFC3339)
+	version := testParent.Version
+
+	// Act
+	updatedParent, err := service.UpdateParent(ctx, testParent.ID, "Jane", "Doe", "john.doe@example.com", birthDate, nil, &version)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, version+1, updatedParent.Version)
+
+	// Act: a second update based on the version that was read first
+	_, err = service.UpdateParent(ctx, testParent.ID, "Janet", "Doe", "john.doe@example.com", birthDate, nil, &version)
+
+	// Assert
+	var conflictErr *domain.ConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, version, conflictErr.Version)
+	savedParent, err := repoFactory.GetMockParentRepository().GetByID(ctx, testParent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Jane", savedParent.FirstName)
+}
+
+func TestUpdateParent_ConcurrentModification(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+
+	testParent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(testParent)
+
+	// Another writer updates the parent between the read and the write of the service
+	repoFactory.GetMockParentRepository().UpdateFunc = func(ctx context.Context, parent *domain.Parent) error {
+		return domain.NewConflictError("Parent", parent.ID.String(), parent.Version)
+	}
+
+	// Act
+	_, err := service.UpdateParent(ctx, testParent.ID, "Jane", "Doe", "john.doe@example.com", testParent.BirthDate.Format(time.RFC3339), nil, nil)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrConflict)
+}
+
 func TestDeleteParent_Success(t *testing.T) {
 	// Arrange
 	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
@@ -283,6 +377,83 @@ func TestDeleteParent_NotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "Parent with ID")
 }
 
+func TestRestoreParent_Success(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	testParent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(testParent)
+	require.NoError(t, service.DeleteParent(ctx, testParent.ID))
+
+	// Act
+	restored, err := service.RestoreParent(ctx, testParent.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, testParent.ID, restored.ID)
+	assert.Nil(t, restored.DeletedAt)
+
+	// Verify the parent is found again
+	_, err = service.GetParentByID(ctx, testParent.ID)
+	require.NoError(t, err)
+}
+
+func TestRestoreParent_EmailTaken(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	testParent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(testParent)
+	require.NoError(t, service.DeleteParent(ctx, testParent.ID))
+
+	// A deleted parent releases its email
+	_, err := service.CreateParent(ctx, "Johnny", "Doe", "John.Doe@example.com", time.Now().AddDate(-31, 0, 0).Format(time.RFC3339), nil)
+	require.NoError(t, err)
+
+	// Act
+	restored, err := service.RestoreParent(ctx, testParent.ID)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, restored)
+	assert.ErrorIs(t, err, domain.ErrDuplicate)
+}
+
+func TestRestoreParent_NotDeleted(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	testParent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(testParent)
+
+	// Act
+	restored, err := service.RestoreParent(ctx, testParent.ID)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, restored)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestListParents_Deleted(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	deletedParent := domain.NewParent("Jane", "Smith", "jane.smith@example.com", time.Now().AddDate(-25, 0, 0))
+	deletedParent.MarkAsDeleted()
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+	repoFactory.GetMockParentRepository().AddTestParent(deletedParent)
+
+	// Act
+	parents, pagedResult, err := service.ListParents(ctx, ports.QueryOptions{
+		Filter:     ports.FilterOptions{Deleted: true},
+		Pagination: ports.PaginationOptions{Page: 0, PageSize: 10},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, parents, 1)
+	assert.Equal(t, deletedParent.ID, parents[0].ID)
+	assert.Equal(t, int64(1), pagedResult.TotalCount)
+}
+
 func TestListParents_Success(t *testing.T) {
 	// Arrange
 	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
@@ -399,6 +570,7 @@ func TestCreateChild_ValidationFailure(t *testing.T) {
 	// Create a new service with the custom validator
 	customService := application.NewFamilyService(
 		repoFactory,
+		mocks.NewMockEventBroker(),
 		customValidator,
 		zaptest.NewLogger(t),
 	)
@@ -535,7 +707,7 @@ func TestUpdateChild_Success(t *testing.T) {
 	newBirthDate := time.Now().AddDate(-6, 0, 0).Format(time.RFC3339)
 
 	// Act
-	updatedChild, err := service.UpdateChild(ctx, testChild.ID, newFirstName, newLastName, newBirthDate)
+	updatedChild, err := service.UpdateChild(ctx, testChild.ID, newFirstName, newLastName, newBirthDate, nil)
 
 	// Assert
 	require.NoError(t, err)
@@ -562,6 +734,7 @@ func TestUpdateChild_NotFound(t *testing.T) {
 		"John",
 		"Smith",
 		time.Now().AddDate(-6, 0, 0).Format(time.RFC3339),
+		nil,
 	)
 
 	// Assert
@@ -570,6 +743,23 @@ func TestUpdateChild_NotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "Child with ID")
 }
 
+func TestUpdateChild_ExpectedVersion(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+
+	testChild := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), uuid.New())
+	repoFactory.GetMockChildRepository().AddTestChild(testChild)
+	staleVersion := testChild.Version - 1
+
+	// Act
+	updatedChild, err := service.UpdateChild(ctx, testChild.ID, "Janet", "Doe", testChild.BirthDate.Format(time.RFC3339), &staleVersion)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, updatedChild)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+}
+
 func TestDeleteChild_Success(t *testing.T) {
 	// Arrange
 	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
@@ -606,6 +796,53 @@ func TestDeleteChild_NotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "Child with ID")
 }
 
+func TestRestoreChild_Success(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+	testChild := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parent.ID)
+	repoFactory.GetMockChildRepository().AddTestChild(testChild)
+	require.NoError(t, service.DeleteChild(ctx, testChild.ID))
+
+	// Act
+	restored, err := service.RestoreChild(ctx, testChild.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, testChild.ID, restored.ID)
+	assert.Nil(t, restored.DeletedAt)
+
+	// Verify the child is linked to its parent again
+	updatedParent, err := repoFactory.GetMockParentRepository().GetByID(ctx, parent.ID)
+	require.NoError(t, err)
+	require.Len(t, updatedParent.Children, 1)
+	assert.Equal(t, testChild.ID, updatedParent.Children[0].ID)
+}
+
+func TestRestoreChild_ParentDeleted(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	parent.MarkAsDeleted()
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+	testChild := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parent.ID)
+	testChild.MarkAsDeleted()
+	repoFactory.GetMockChildRepository().AddTestChild(testChild)
+
+	// Act
+	restored, err := service.RestoreChild(ctx, testChild.ID)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, restored)
+	assert.ErrorIs(t, err, domain.ErrValidation)
+
+	// Verify the restore was rolled back
+	assert.True(t, repoFactory.GetMockTransactionManager().RollbackTxCalled)
+	assert.False(t, repoFactory.GetMockTransactionManager().CommitTxCalled)
+}
+
 func TestListChildrenByParentID_Success(t *testing.T) {
 	// Arrange
 	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
@@ -619,9 +856,11 @@ func TestListChildrenByParentID_Success(t *testing.T) {
 	child2 := domain.NewChild("Jack", "Doe", time.Now().AddDate(-3, 0, 0), parent.ID)
 	child3 := domain.NewChild("Jill", "Doe", time.Now().AddDate(-1, 0, 0), parent.ID)
 
-	repoFactory.GetMockChildRepository().AddTestChild(child1)
-	repoFactory.GetMockChildRepository().AddTestChild(child2)
-	repoFactory.GetMockChildRepository().AddTestChild(child3)
+	for _, child := range []*domain.Child{child1, child2, child3} {
+		repoFactory.GetMockChildRepository().AddTestChild(child)
+		require.NoError(t, repoFactory.GetMockGuardianshipRepository().Create(ctx,
+			domain.NewGuardianship(parent.ID, child.ID, domain.GuardianshipFather, child.CreatedAt, true)))
+	}
 
 	// Create query options
 	options := ports.QueryOptions{
@@ -807,7 +1046,7 @@ func TestAddChildToParent_Success(t *testing.T) {
 	repoFactory.GetMockChildRepository().AddTestChild(child)
 
 	// Act
-	err := service.AddChildToParent(ctx, parent.ID, child.ID)
+	err := service.AddChildToParent(ctx, parent.ID, child.ID, domain.GuardianshipGuardian, false, nil)
 
 	// Assert
 	require.NoError(t, err)
@@ -833,7 +1072,7 @@ func TestAddChildToParent_ParentNotFound(t *testing.T) {
 	repoFactory.GetMockChildRepository().AddTestChild(child)
 
 	// Act
-	err := service.AddChildToParent(ctx, uuid.New(), child.ID)
+	err := service.AddChildToParent(ctx, uuid.New(), child.ID, domain.GuardianshipGuardian, false, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -849,7 +1088,7 @@ func TestAddChildToParent_ChildNotFound(t *testing.T) {
 	repoFactory.GetMockParentRepository().AddTestParent(parent)
 
 	// Act
-	err := service.AddChildToParent(ctx, parent.ID, uuid.New())
+	err := service.AddChildToParent(ctx, parent.ID, uuid.New(), domain.GuardianshipGuardian, false, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -872,6 +1111,11 @@ func TestRemoveChildFromParent_Success(t *testing.T) {
 	// Add to repositories
 	repoFactory.GetMockParentRepository().AddTestParent(parent)
 	repoFactory.GetMockChildRepository().AddTestChild(child)
+	guardians := repoFactory.GetMockGuardianshipRepository()
+	require.NoError(t, guardians.Create(ctx, domain.NewGuardianship(parent.ID, child.ID, domain.GuardianshipFather, child.CreatedAt, true)))
+	mother := domain.NewParent("Mary", "Doe", "mary.doe@example.com", time.Now().AddDate(-29, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(mother)
+	require.NoError(t, guardians.Create(ctx, domain.NewGuardianship(mother.ID, child.ID, domain.GuardianshipMother, time.Now(), false)))
 
 	// Act
 	err := service.RemoveChildFromParent(ctx, parent.ID, child.ID)
@@ -883,6 +1127,17 @@ func TestRemoveChildFromParent_Success(t *testing.T) {
 	updatedParent, err := repoFactory.GetMockParentRepository().GetByID(ctx, parent.ID)
 	require.NoError(t, err)
 	assert.Len(t, updatedParent.Children, 0)
+
+	// Verify the other guardian became the child's primary contact
+	updatedChild, err := repoFactory.GetMockChildRepository().GetByID(ctx, child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, mother.ID, updatedChild.ParentID)
+	guardianships, err := guardians.ListByChildIDs(ctx, []uuid.UUID{child.ID})
+	require.NoError(t, err)
+	active := domain.ActiveGuardianships(guardianships)
+	require.Len(t, active, 1)
+	assert.Equal(t, mother.ID, active[0].ParentID)
+	assert.True(t, active[0].PrimaryContact)
 }
 
 func TestRemoveChildFromParent_ParentNotFound(t *testing.T) {
@@ -916,3 +1171,1804 @@ func TestRemoveChildFromParent_ChildNotFoundInParent(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "Child with ID")
 }
+
+func TestCreateParent_PublishesEvent(t *testing.T) {
+	// Arrange
+	repoFactory := mocks.NewMockRepositoryFactory()
+	broker := mocks.NewMockEventBroker()
+	service := application.NewFamilyService(repoFactory, broker, validator.New(), zaptest.NewLogger(t))
+	ctx := context.Background()
+
+	// Act
+	parent, err := service.CreateParent(ctx, "John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0).Format(time.RFC3339), nil)
+
+	// Assert
+	require.NoError(t, err)
+	events := broker.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, domain.EventParentCreated, events[0].Type)
+	assert.Equal(t, parent.ID, events[0].ParentID)
+	assert.Equal(t, parent, events[0].Parent)
+}
+
+func TestAddChildToParent_PublishesEvent(t *testing.T) {
+	// Arrange
+	repoFactory := mocks.NewMockRepositoryFactory()
+	broker := mocks.NewMockEventBroker()
+	service := application.NewFamilyService(repoFactory, broker, validator.New(), zaptest.NewLogger(t))
+	ctx := context.Background()
+
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+	child := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), uuid.New())
+	repoFactory.GetMockChildRepository().AddTestChild(child)
+
+	// Act
+	err := service.AddChildToParent(ctx, parent.ID, child.ID, domain.GuardianshipGuardian, false, nil)
+
+	// Assert
+	require.NoError(t, err)
+	events := broker.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, domain.EventChildAddedToParent, events[0].Type)
+	assert.Equal(t, parent.ID, events[0].ParentID)
+	assert.Equal(t, child.ID, events[0].ChildID)
+}
+
+func TestCreateParent_PublishesEventForTenant(t *testing.T) {
+	// Arrange
+	repoFactory := mocks.NewMockRepositoryFactory()
+	broker := mocks.NewMockEventBroker()
+	service := application.NewFamilyService(repoFactory, broker, validator.New(), zaptest.NewLogger(t))
+	ctx := ports.WithTenantID(context.Background(), "tenant-1")
+
+	// Act
+	_, err := service.CreateParent(ctx, "John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0).Format(time.RFC3339), nil)
+
+	// Assert
+	require.NoError(t, err)
+	events := broker.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, "tenant-1", events[0].TenantID)
+}
+
+func TestDeleteParent_PublishFailureDoesNotFailOperation(t *testing.T) {
+	// Arrange
+	repoFactory := mocks.NewMockRepositoryFactory()
+	broker := mocks.NewMockEventBroker()
+	broker.PublishFunc = func(ctx context.Context, event domain.Event) error {
+		return errors.New("broker unavailable")
+	}
+	service := application.NewFamilyService(repoFactory, broker, validator.New(), zaptest.NewLogger(t))
+	ctx := context.Background()
+
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+
+	// Act
+	err := service.DeleteParent(ctx, parent.ID)
+
+	// Assert
+	require.NoError(t, err)
+	events := broker.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, domain.EventParentDeleted, events[0].Type)
+	assert.True(t, events[0].IsParentEvent())
+}
+
+func TestCreateParent_ValidationFailure_PublishesNoEvent(t *testing.T) {
+	// Arrange
+	repoFactory := mocks.NewMockRepositoryFactory()
+	broker := mocks.NewMockEventBroker()
+	service := application.NewFamilyService(repoFactory, broker, validator.New(), zaptest.NewLogger(t))
+
+	// Act
+	_, err := service.CreateParent(context.Background(), "John", "Doe", "invalid-email", time.Now().AddDate(-30, 0, 0).Format(time.RFC3339), nil)
+
+	// Assert
+	require.Error(t, err)
+	assert.Empty(t, broker.Events())
+}
+
+func TestDeleteChild_RecordsEventInOutbox(t *testing.T) {
+	// Arrange
+	repoFactory := mocks.NewMockRepositoryFactory()
+	broker := mocks.NewMockEventBroker()
+	outbox := repoFactory.GetMockOutboxRepository()
+	service := application.NewFamilyService(repoFactory, broker, validator.New(), zaptest.NewLogger(t)).
+		WithOutbox(outbox)
+	ctx := ports.WithTenantID(context.Background(), "tenant-1")
+
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+	child := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parent.ID)
+	repoFactory.GetMockChildRepository().AddTestChild(child)
+
+	// Act
+	err := service.DeleteChild(ctx, child.ID)
+
+	// Assert
+	require.NoError(t, err)
+	messages := outbox.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, domain.EventChildDeleted, messages[0].Event.Type)
+	assert.Equal(t, parent.ID, messages[0].Event.ParentID)
+	assert.Equal(t, child.ID, messages[0].Event.ChildID)
+	assert.Equal(t, "tenant-1", messages[0].Event.TenantID)
+
+	// The subscribers receive the same event
+	events := broker.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, messages[0].Event.ID, events[0].ID)
+}
+
+func TestUpdateParent_RecordsEventInOutbox(t *testing.T) {
+	// Arrange
+	repoFactory := mocks.NewMockRepositoryFactory()
+	outbox := repoFactory.GetMockOutboxRepository()
+	service := application.NewFamilyService(repoFactory, mocks.NewMockEventBroker(), validator.New(), zaptest.NewLogger(t)).
+		WithOutbox(outbox)
+	ctx := context.Background()
+
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+
+	// Act
+	_, err := service.UpdateParent(ctx, parent.ID, "Johnny", "Doe", "john.doe@example.com", parent.BirthDate.Format(time.RFC3339), nil, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, repoFactory.GetMockTransactionManager().CommitTxCalled)
+	messages := outbox.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, domain.EventParentUpdated, messages[0].Event.Type)
+	assert.Equal(t, "Johnny", messages[0].Event.Parent.FirstName)
+}
+
+func TestAddChildToParent_OutboxFailureRollsBack(t *testing.T) {
+	// Arrange
+	repoFactory := mocks.NewMockRepositoryFactory()
+	broker := mocks.NewMockEventBroker()
+	outbox := repoFactory.GetMockOutboxRepository()
+	outbox.AppendFunc = func(ctx context.Context, event domain.Event) error {
+		return errors.New("outbox unavailable")
+	}
+	service := application.NewFamilyService(repoFactory, broker, validator.New(), zaptest.NewLogger(t)).
+		WithOutbox(outbox)
+	ctx := context.Background()
+
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+	child := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), uuid.New())
+	repoFactory.GetMockChildRepository().AddTestChild(child)
+
+	// Act
+	err := service.AddChildToParent(ctx, parent.ID, child.ID, domain.GuardianshipGuardian, false, nil)
+
+	// Assert
+	require.Error(t, err)
+	var dbErr *domain.DatabaseError
+	assert.ErrorAs(t, err, &dbErr)
+	txManager := repoFactory.GetMockTransactionManager()
+	assert.True(t, txManager.RollbackTxCalled)
+	assert.False(t, txManager.CommitTxCalled)
+	assert.Empty(t, broker.Events())
+}
+
+func TestCreateParent_WithoutOutbox_RecordsNothing(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+
+	// Act
+	_, err := service.CreateParent(ctx, "John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0).Format(time.RFC3339), nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, repoFactory.GetMockOutboxRepository().Messages())
+}
+
+// setupFamilyScopeTest adds two families and returns a context restricted to the first one
+func setupFamilyScopeTest(t *testing.T, repoFactory *mocks.MockRepositoryFactory) (context.Context, *domain.Parent, *domain.Child, *domain.Parent, *domain.Child) {
+	t.Helper()
+
+	ownParent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	ownParent.LinkUser("user-1")
+	otherParent := domain.NewParent("Jane", "Smith", "jane.smith@example.com", time.Now().AddDate(-25, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(ownParent)
+	repoFactory.GetMockParentRepository().AddTestParent(otherParent)
+
+	ownChild := domain.NewChild("Jimmy", "Doe", time.Now().AddDate(-5, 0, 0), ownParent.ID)
+	otherChild := domain.NewChild("Jenny", "Smith", time.Now().AddDate(-6, 0, 0), otherParent.ID)
+	repoFactory.GetMockChildRepository().AddTestChild(ownChild)
+	repoFactory.GetMockChildRepository().AddTestChild(otherChild)
+	guardianships := repoFactory.GetMockGuardianshipRepository()
+	require.NoError(t, guardianships.Create(context.Background(),
+		domain.NewGuardianship(ownParent.ID, ownChild.ID, domain.GuardianshipFather, ownChild.CreatedAt, true)))
+	require.NoError(t, guardianships.Create(context.Background(),
+		domain.NewGuardianship(otherParent.ID, otherChild.ID, domain.GuardianshipMother, otherChild.CreatedAt, true)))
+
+	ctx := ports.WithAccessScope(context.Background(), ports.AccessScope{Restricted: true, UserID: "user-1"})
+
+	return ctx, ownParent, ownChild, otherParent, otherChild
+}
+
+func TestGetParentByID_FamilyScope(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, _ := setupFamilyServiceTest(t)
+	ctx, ownParent, _, otherParent, _ := setupFamilyScopeTest(t, repoFactory)
+
+	// Act
+	parent, err := service.GetParentByID(ctx, ownParent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ownParent.ID, parent.ID)
+
+	parent, err = service.GetParentByID(ctx, otherParent.ID)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, parent)
+	assert.True(t, errors.Is(err, domain.ErrForbidden))
+}
+
+func TestUpdateChild_FamilyScope(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, _ := setupFamilyServiceTest(t)
+	ctx, _, _, _, otherChild := setupFamilyScopeTest(t, repoFactory)
+
+	// Act
+	child, err := service.UpdateChild(ctx, otherChild.ID, "Jenny", "Smith", time.Now().AddDate(-6, 0, 0).Format(time.RFC3339), nil)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, child)
+	assert.True(t, errors.Is(err, domain.ErrForbidden))
+}
+
+func TestListParentsAndChildren_FamilyScope(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, _ := setupFamilyServiceTest(t)
+	ctx, ownParent, ownChild, _, _ := setupFamilyScopeTest(t, repoFactory)
+	options := ports.QueryOptions{Pagination: ports.PaginationOptions{Page: 0, PageSize: 10}}
+
+	// Act
+	parents, parentPage, err := service.ListParents(ctx, options)
+	require.NoError(t, err)
+	children, childPage, err := service.ListChildren(ctx, options)
+	require.NoError(t, err)
+	count, err := service.CountChildren(ctx, ports.FilterOptions{})
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, parents, 1)
+	assert.Equal(t, ownParent.ID, parents[0].ID)
+	assert.Equal(t, int64(1), parentPage.TotalCount)
+	require.Len(t, children, 1)
+	assert.Equal(t, ownChild.ID, children[0].ID)
+	assert.Equal(t, int64(1), childPage.TotalCount)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestListChildren_FamilyScopeFollowsGuardianships(t *testing.T) {
+	// Arrange: the caller's parent becomes a guardian of a child whose primary contact is another parent
+	service, repoFactory, _, _, _ := setupFamilyServiceTest(t)
+	ctx, ownParent, ownChild, _, otherChild := setupFamilyScopeTest(t, repoFactory)
+	require.NoError(t, repoFactory.GetMockGuardianshipRepository().Create(ctx,
+		domain.NewGuardianship(ownParent.ID, otherChild.ID, domain.GuardianshipGuardian, time.Now(), false)))
+	options := ports.QueryOptions{Pagination: ports.PaginationOptions{Page: 0, PageSize: 10}}
+
+	// Act
+	children, _, err := service.ListChildren(ctx, options)
+	require.NoError(t, err)
+	count, err := service.CountChildren(ctx, ports.FilterOptions{})
+	require.NoError(t, err)
+	byParent, _, err := service.ListChildrenByParentID(ctx, ownParent.ID, options)
+	require.NoError(t, err)
+
+	// Assert
+	assert.ElementsMatch(t, []uuid.UUID{ownChild.ID, otherChild.ID}, childIDs(children))
+	assert.Equal(t, int64(2), count)
+	assert.ElementsMatch(t, []uuid.UUID{ownChild.ID, otherChild.ID}, childIDs(byParent))
+}
+
+func TestListChildren_FamilyScopeSkipsEndedGuardianships(t *testing.T) {
+	// Arrange: the caller's parent is no longer a guardian of its former primary child
+	service, repoFactory, _, _, _ := setupFamilyServiceTest(t)
+	ctx, ownParent, ownChild, _, _ := setupFamilyScopeTest(t, repoFactory)
+	guardianships, err := repoFactory.GetMockGuardianshipRepository().ListByParentIDs(ctx, []uuid.UUID{ownParent.ID})
+	require.NoError(t, err)
+	require.Len(t, guardianships, 1)
+	guardianships[0].End()
+	require.NoError(t, repoFactory.GetMockGuardianshipRepository().Update(ctx, guardianships[0]))
+
+	// Act
+	children, _, err := service.ListChildren(ctx, ports.QueryOptions{Pagination: ports.PaginationOptions{Page: 0, PageSize: 10}})
+	require.NoError(t, err)
+	_, err = service.GetChildByID(ctx, ownChild.ID)
+
+	// Assert: the child still names the parent as its primary contact, but is out of the caller's family
+	assert.Empty(t, children)
+	assert.True(t, errors.Is(err, domain.ErrForbidden))
+}
+
+// childIDs returns the IDs of children
+func childIDs(children []*domain.Child) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(children))
+	for _, child := range children {
+		ids = append(ids, child.ID)
+	}
+	return ids
+}
+
+func TestIsEventInFamily(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, _ := setupFamilyServiceTest(t)
+	ctx, ownParent, ownChild, otherParent, otherChild := setupFamilyScopeTest(t, repoFactory)
+	coGuardedChild := domain.NewChild("Jill", "Smith", time.Now().AddDate(-2, 0, 0), otherParent.ID)
+	repoFactory.GetMockChildRepository().AddTestChild(coGuardedChild)
+	require.NoError(t, repoFactory.GetMockGuardianshipRepository().Create(ctx,
+		domain.NewGuardianship(otherParent.ID, coGuardedChild.ID, domain.GuardianshipMother, time.Now(), true)))
+	require.NoError(t, repoFactory.GetMockGuardianshipRepository().Create(ctx,
+		domain.NewGuardianship(ownParent.ID, coGuardedChild.ID, domain.GuardianshipFather, time.Now(), false)))
+
+	transfer := domain.NewEvent(domain.EventChildTransferred, otherParent.ID, uuid.New())
+	transfer.PreviousParentID = &ownParent.ID
+
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		event    domain.Event
+		expected bool
+	}{
+		{"own parent", ctx, domain.NewEvent(domain.EventParentUpdated, ownParent.ID, uuid.Nil), true},
+		{"other parent", ctx, domain.NewEvent(domain.EventParentUpdated, otherParent.ID, uuid.Nil), false},
+		{"own child", ctx, domain.NewEvent(domain.EventChildUpdated, ownParent.ID, ownChild.ID), true},
+		{"ward of another primary contact", ctx, domain.NewEvent(domain.EventChildUpdated, otherParent.ID, coGuardedChild.ID), true},
+		{"other child", ctx, domain.NewEvent(domain.EventChildUpdated, otherParent.ID, otherChild.ID), false},
+		{"child transferred away", ctx, transfer, true},
+		{"unrestricted caller", context.Background(), domain.NewEvent(domain.EventChildUpdated, otherParent.ID, otherChild.ID), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			inFamily, err := service.IsEventInFamily(tc.ctx, tc.event)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, inFamily)
+		})
+	}
+}
+
+func TestListParents_UnlinkedFamilyScope(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, _ := setupFamilyServiceTest(t)
+	setupFamilyScopeTest(t, repoFactory)
+	ctx := ports.WithAccessScope(context.Background(), ports.AccessScope{Restricted: true, UserID: "user-2"})
+
+	// Act
+	parents, pagedResult, err := service.ListParents(ctx, ports.QueryOptions{Pagination: ports.PaginationOptions{Page: 0, PageSize: 10}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, parents)
+	assert.Equal(t, int64(0), pagedResult.TotalCount)
+}
+
+func TestLinkParentUser_Success(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+
+	// Act
+	linked, err := service.LinkParentUser(ctx, parent.ID, "user-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", linked.UserID)
+
+	found, err := service.GetParentByUserID(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, parent.ID, found.ID)
+}
+
+func TestLinkParentUser_AlreadyLinked(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	linkedParent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	linkedParent.LinkUser("user-1")
+	parent := domain.NewParent("Jane", "Smith", "jane.smith@example.com", time.Now().AddDate(-25, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(linkedParent)
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+
+	// Act
+	linked, err := service.LinkParentUser(ctx, parent.ID, "user-1")
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, linked)
+	assert.True(t, errors.Is(err, domain.ErrValidation))
+}
+
+func TestPurgeDeleted_Success(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	service.WithDeletedRetention(24 * time.Hour)
+
+	expired := time.Now().Add(-48 * time.Hour)
+	expiredParent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	expiredParent.DeletedAt = &expired
+	recentParent := domain.NewParent("Jane", "Smith", "jane.smith@example.com", time.Now().AddDate(-25, 0, 0))
+	recentParent.MarkAsDeleted()
+	repoFactory.GetMockParentRepository().AddTestParent(expiredParent)
+	repoFactory.GetMockParentRepository().AddTestParent(recentParent)
+
+	expiredChild := domain.NewChild("Jimmy", "Doe", time.Now().AddDate(-5, 0, 0), expiredParent.ID)
+	expiredChild.DeletedAt = &expired
+	repoFactory.GetMockChildRepository().AddTestChild(expiredChild)
+
+	// Act
+	result, err := service.PurgeDeleted(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Parents)
+	assert.Equal(t, int64(1), result.Children)
+
+	// Verify the recently deleted parent can still be restored
+	_, err = service.RestoreParent(ctx, recentParent.ID)
+	require.NoError(t, err)
+	_, err = service.RestoreParent(ctx, expiredParent.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+// setupAuditedFamilyServiceTest creates a family service that audits its changes as the user "auditor-1"
+func setupAuditedFamilyServiceTest(t *testing.T) (*application.FamilyService, *mocks.MockRepositoryFactory, *mocks.MockAuditLogRepository) {
+	repoFactory := mocks.NewMockRepositoryFactory()
+	authService := mocks.NewMockAuthorizationService()
+	authService.DefaultUserID = "auditor-1"
+	auditLog := repoFactory.GetMockAuditLogRepository()
+	service := application.NewFamilyService(repoFactory, mocks.NewMockEventBroker(), validator.New(), zaptest.NewLogger(t)).
+		WithAuditLog(auditLog, authService)
+
+	return service, repoFactory, auditLog
+}
+
+func TestUpdateChild_RecordsAuditRecord(t *testing.T) {
+	// Arrange
+	service, repoFactory, auditLog := setupAuditedFamilyServiceTest(t)
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(ports.WithTenantID(context.Background(), "tenant-1"),
+		trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+	birthDate := time.Date(2015, 1, 2, 0, 0, 0, 0, time.UTC)
+	child := domain.NewChild("Jane", "Doe", birthDate, parent.ID)
+	repoFactory.GetMockChildRepository().AddTestChild(child)
+
+	// Act
+	_, err := service.UpdateChild(ctx, child.ID, "Jane", "Doe", "2015-02-01T00:00:00Z", nil)
+
+	// Assert the record names the actor, the change and the trace
+	require.NoError(t, err)
+	records := auditLog.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, "auditor-1", records[0].Actor)
+	assert.Equal(t, "UpdateChild", records[0].Operation)
+	assert.Equal(t, domain.AuditEntityChild, records[0].EntityType)
+	assert.Equal(t, child.ID, records[0].EntityID)
+	assert.Equal(t, "tenant-1", records[0].TenantID)
+	assert.Equal(t, traceID.String(), records[0].TraceID)
+	assert.Equal(t, []domain.FieldChange{
+		{Field: "birthDate", Before: "2015-01-02T00:00:00Z", After: "2015-02-01T00:00:00Z"},
+	}, records[0].Changes)
+}
+
+func TestFamilyService_AuditsEveryMutation(t *testing.T) {
+	// Arrange
+	service, repoFactory, auditLog := setupAuditedFamilyServiceTest(t)
+	ctx := context.Background()
+
+	// Act
+	parent, err := service.CreateParent(ctx, "John", "Doe", "john.doe@example.com", "1985-03-04T00:00:00Z", nil)
+	require.NoError(t, err)
+	child, err := service.CreateChild(ctx, "Jane", "Doe", "2015-01-02T00:00:00Z", parent.ID)
+	require.NoError(t, err)
+	_, err = service.LinkParentUser(ctx, parent.ID, "user-1")
+	require.NoError(t, err)
+	mother := domain.NewParent("Mary", "Doe", "mary.doe@example.com", time.Now().AddDate(-29, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(mother)
+	require.NoError(t, service.AddChildToParent(ctx, mother.ID, child.ID, domain.GuardianshipMother, false, nil))
+	require.NoError(t, service.RemoveChildFromParent(ctx, parent.ID, child.ID))
+	require.NoError(t, service.AddChildToParent(ctx, parent.ID, child.ID, domain.GuardianshipFather, true, nil))
+	require.NoError(t, service.DeleteChild(ctx, child.ID))
+	_, err = service.RestoreChild(ctx, child.ID)
+	require.NoError(t, err)
+	require.NoError(t, service.DeleteParent(ctx, parent.ID))
+	_, err = service.RestoreParent(ctx, parent.ID)
+	require.NoError(t, err)
+	_, err = service.PurgeDeleted(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	operations := []string{}
+	for _, record := range auditLog.Records() {
+		operations = append(operations, record.Operation)
+		assert.Equal(t, "auditor-1", record.Actor)
+	}
+	assert.Equal(t, []string{
+		"CreateParent", "CreateChild", "LinkParentUser", "AddChildToParent", "RemoveChildFromParent", "AddChildToParent",
+		"DeleteChild", "RestoreChild", "DeleteParent", "RestoreParent", "PurgeDeleted",
+	}, operations)
+
+	records := auditLog.Records()
+	assert.Contains(t, records[0].Changes, domain.FieldChange{Field: "email", After: "john.doe@example.com"})
+	assert.Equal(t, []domain.FieldChange{{Field: "userId", After: "user-1"}}, records[2].Changes)
+	assert.Equal(t, []domain.FieldChange{{
+		Field:  "guardians",
+		Before: parent.ID.String() + ":GUARDIAN:primary",
+		After:  guardiansOf(parent.ID.String()+":GUARDIAN:primary", mother.ID.String()+":MOTHER"),
+	}}, records[3].Changes)
+	assert.Contains(t, records[4].Changes, domain.FieldChange{Field: "parentId", Before: parent.ID.String(), After: mother.ID.String()})
+	assert.Equal(t, []domain.FieldChange{{Field: "deleted", Before: "false", After: "true"}}, records[6].Changes)
+	assert.Equal(t, []domain.FieldChange{{Field: "deleted", Before: "true", After: "false"}}, records[9].Changes)
+	assert.Equal(t, uuid.Nil, records[10].EntityID)
+}
+
+// guardiansOf returns the audited guardians field listing the given guardians
+func guardiansOf(guardians ...string) string {
+	sort.Strings(guardians)
+	return strings.Join(guardians, ",")
+}
+
+func TestUpdateParent_AuditFailureRollsBack(t *testing.T) {
+	// Arrange
+	service, repoFactory, auditLog := setupAuditedFamilyServiceTest(t)
+	auditLog.AppendFunc = func(ctx context.Context, record *domain.AuditRecord) error {
+		return errors.New("audit log unavailable")
+	}
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+
+	// Act
+	_, err := service.UpdateParent(context.Background(), parent.ID, "Johnny", "Doe", "john.doe@example.com", parent.BirthDate.Format(time.RFC3339), nil, nil)
+
+	// Assert
+	var dbErr *domain.DatabaseError
+	assert.ErrorAs(t, err, &dbErr)
+	txManager := repoFactory.GetMockTransactionManager()
+	assert.True(t, txManager.RollbackTxCalled)
+	assert.False(t, txManager.CommitTxCalled)
+}
+
+func TestCreateParent_AuditsWithoutActorForAnonymousCaller(t *testing.T) {
+	// Arrange
+	repoFactory := mocks.NewMockRepositoryFactory()
+	authService := mocks.NewMockAuthorizationService()
+	authService.GetUserIDFunc = func(ctx context.Context) (string, error) {
+		return "", errors.New("user ID not found in context")
+	}
+	auditLog := repoFactory.GetMockAuditLogRepository()
+	service := application.NewFamilyService(repoFactory, mocks.NewMockEventBroker(), validator.New(), zaptest.NewLogger(t)).
+		WithAuditLog(auditLog, authService)
+
+	// Act
+	_, err := service.CreateParent(context.Background(), "John", "Doe", "john.doe@example.com", "1985-03-04T00:00:00Z", nil)
+
+	// Assert
+	require.NoError(t, err)
+	records := auditLog.Records()
+	require.Len(t, records, 1)
+	assert.Empty(t, records[0].Actor)
+	assert.Empty(t, records[0].TraceID)
+}
+
+// setupHistoryTest records a parent that was renamed and then deleted, and a child that moved
+// from another parent to it, and returns the times of the changes
+func setupHistoryTest(t *testing.T, repoFactory *mocks.MockRepositoryFactory) (*domain.Parent, *domain.Child, []time.Time) {
+	t.Helper()
+
+	times := []time.Time{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	history := repoFactory.GetMockHistoryRepository()
+
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	created := *parent
+	history.AddRevision(domain.NewParentRevision(&created, times[0]))
+
+	child := domain.NewChild("Jimmy", "Doe", time.Now().AddDate(-5, 0, 0), uuid.New())
+	adopted := *child
+	history.AddRevision(domain.NewChildRevision(&adopted, times[0]))
+
+	renamed := created
+	renamed.FirstName = "Johnny"
+	renamed.Version++
+	history.AddRevision(domain.NewParentRevision(&renamed, times[1]))
+
+	moved := adopted
+	moved.ParentID = parent.ID
+	moved.Version++
+	history.AddRevision(domain.NewChildRevision(&moved, times[1]))
+
+	deleted := renamed
+	deleted.MarkAsDeleted()
+	deleted.Version++
+	history.AddRevision(domain.NewParentRevision(&deleted, times[2]))
+
+	return parent, child, times
+}
+
+func TestGetParentAsOf_Success(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	parent, _, times := setupHistoryTest(t, repoFactory)
+
+	// Act
+	original, err := service.GetParentAsOf(ctx, parent.ID, times[0].Add(time.Hour))
+	require.NoError(t, err)
+	renamed, err := service.GetParentAsOf(ctx, parent.ID, times[1])
+	require.NoError(t, err)
+
+	// Assert the parent is as it was, and marked as of the requested time
+	assert.Equal(t, "John", original.FirstName)
+	require.NotNil(t, original.AsOf)
+	assert.Equal(t, times[0].Add(time.Hour), *original.AsOf)
+	assert.Equal(t, "Johnny", renamed.FirstName)
+}
+
+func TestGetParentAsOf_NotFound(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	parent, _, times := setupHistoryTest(t, repoFactory)
+
+	// Act: before the parent was created, and after it was deleted
+	_, errBefore := service.GetParentAsOf(ctx, parent.ID, times[0].Add(-time.Hour))
+	_, errAfter := service.GetParentAsOf(ctx, parent.ID, times[2])
+
+	// Assert
+	assert.True(t, errors.Is(errBefore, domain.ErrNotFound))
+	assert.True(t, errors.Is(errAfter, domain.ErrNotFound))
+}
+
+func TestListChildrenByParentIDAsOf_Success(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	parent, child, times := setupHistoryTest(t, repoFactory)
+
+	// Act
+	before, err := service.ListChildrenByParentIDAsOf(ctx, parent.ID, times[0])
+	require.NoError(t, err)
+	after, err := service.ListChildrenByParentIDAsOf(ctx, parent.ID, times[1])
+	require.NoError(t, err)
+
+	// Assert the child belongs to the parent only once it moved
+	assert.Empty(t, before)
+	require.Len(t, after, 1)
+	assert.Equal(t, child.ID, after[0].ID)
+	assert.Equal(t, parent.ID, after[0].ParentID)
+}
+
+func TestGetHistory_Success(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	parent, child, times := setupHistoryTest(t, repoFactory)
+
+	// Act
+	parentRevisions, err := service.GetHistory(ctx, parent.ID)
+	require.NoError(t, err)
+	childRevisions, err := service.GetHistory(ctx, child.ID)
+	require.NoError(t, err)
+
+	// Assert the revisions are listed oldest first
+	require.Len(t, parentRevisions, 3)
+	for i, revision := range parentRevisions {
+		assert.Equal(t, times[i], revision.RecordedAt)
+		assert.Equal(t, i+1, revision.Version())
+	}
+	assert.True(t, parentRevisions[2].Parent.IsDeleted())
+
+	require.Len(t, childRevisions, 2)
+	assert.NotEqual(t, parent.ID, childRevisions[0].Child.ParentID)
+	assert.Equal(t, parent.ID, childRevisions[1].Child.ParentID)
+}
+
+func TestGetHistory_NotFound(t *testing.T) {
+	// Arrange
+	service, _, _, _, ctx := setupFamilyServiceTest(t)
+
+	// Act
+	revisions, err := service.GetHistory(ctx, uuid.New())
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, revisions)
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+}
+
+func TestGetHistory_FamilyScope(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, _ := setupFamilyServiceTest(t)
+	ctx, ownParent, _, otherParent, _ := setupFamilyScopeTest(t, repoFactory)
+	history := repoFactory.GetMockHistoryRepository()
+	history.AddRevision(domain.NewParentRevision(ownParent, time.Now()))
+	history.AddRevision(domain.NewParentRevision(otherParent, time.Now()))
+
+	// Act
+	revisions, err := service.GetHistory(ctx, ownParent.ID)
+	require.NoError(t, err)
+	assert.Len(t, revisions, 1)
+
+	revisions, err = service.GetHistory(ctx, otherParent.ID)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, revisions)
+	assert.True(t, errors.Is(err, domain.ErrForbidden))
+}
+
+func TestGetHistory_FamilyScopeFollowsGuardianships(t *testing.T) {
+	// Arrange: the caller's parent is a guardian, but not the primary contact, of one of two children of another parent
+	service, repoFactory, _, _, _ := setupFamilyServiceTest(t)
+	ctx, ownParent, _, otherParent, otherChild := setupFamilyScopeTest(t, repoFactory)
+	wardChild := domain.NewChild("Jill", "Smith", time.Now().AddDate(-2, 0, 0), otherParent.ID)
+	repoFactory.GetMockChildRepository().AddTestChild(wardChild)
+	require.NoError(t, repoFactory.GetMockGuardianshipRepository().Create(ctx,
+		domain.NewGuardianship(otherParent.ID, wardChild.ID, domain.GuardianshipMother, time.Now(), true)))
+	require.NoError(t, repoFactory.GetMockGuardianshipRepository().Create(ctx,
+		domain.NewGuardianship(ownParent.ID, wardChild.ID, domain.GuardianshipFather, time.Now(), false)))
+	history := repoFactory.GetMockHistoryRepository()
+	history.AddRevision(domain.NewChildRevision(wardChild, time.Now()))
+	history.AddRevision(domain.NewChildRevision(otherChild, time.Now()))
+
+	// Act
+	revisions, err := service.GetHistory(ctx, wardChild.ID)
+	require.NoError(t, err)
+	assert.Len(t, revisions, 1)
+
+	revisions, err = service.GetHistory(ctx, otherChild.ID)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, revisions)
+	assert.True(t, errors.Is(err, domain.ErrForbidden))
+}
+
+func TestCreateChild_MakesParentPrimaryGuardian(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+
+	// Act
+	child, err := service.CreateChild(ctx, "Jane", "Doe", time.Now().AddDate(-5, 0, 0).Format(time.RFC3339), parent.ID)
+
+	// Assert
+	require.NoError(t, err)
+	guardianships, err := service.ListGuardianshipsByChildIDs(ctx, []uuid.UUID{child.ID})
+	require.NoError(t, err)
+	require.Len(t, guardianships, 1)
+	assert.Equal(t, parent.ID, guardianships[0].ParentID)
+	assert.Equal(t, domain.GuardianshipGuardian, guardianships[0].Type)
+	assert.True(t, guardianships[0].PrimaryContact)
+	assert.True(t, guardianships[0].IsActive())
+}
+
+// setupGuardianshipTest creates a child whose father is its primary contact, and a mother
+// who is not yet one of its guardians
+func setupGuardianshipTest(t *testing.T) (*application.FamilyService, *mocks.MockRepositoryFactory, context.Context, *domain.Parent, *domain.Parent, *domain.Child) {
+	t.Helper()
+
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	father := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	mother := domain.NewParent("Mary", "Doe", "mary.doe@example.com", time.Now().AddDate(-29, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(father)
+	repoFactory.GetMockParentRepository().AddTestParent(mother)
+
+	child := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), father.ID)
+	repoFactory.GetMockChildRepository().AddTestChild(child)
+	guardianship := domain.NewGuardianship(father.ID, child.ID, domain.GuardianshipFather, child.CreatedAt, true)
+	require.NoError(t, repoFactory.GetMockGuardianshipRepository().Create(ctx, guardianship))
+
+	return service, repoFactory, ctx, father, mother, child
+}
+
+func TestAddChildToParent_AddsGuardian(t *testing.T) {
+	// Arrange
+	service, repoFactory, ctx, father, mother, child := setupGuardianshipTest(t)
+	startDate := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	err := service.AddChildToParent(ctx, mother.ID, child.ID, domain.GuardianshipMother, false, &startDate)
+
+	// Assert the mother is a guardian, while the father remains the primary contact
+	require.NoError(t, err)
+	guardianships, err := service.ListGuardianshipsByChildIDs(ctx, []uuid.UUID{child.ID})
+	require.NoError(t, err)
+	require.Len(t, guardianships, 2)
+	assert.Equal(t, mother.ID, guardianships[0].ParentID)
+	assert.Equal(t, domain.GuardianshipMother, guardianships[0].Type)
+	assert.Equal(t, startDate, guardianships[0].StartDate)
+	assert.False(t, guardianships[0].PrimaryContact)
+	assert.Equal(t, father.ID, guardianships[1].ParentID)
+	assert.True(t, guardianships[1].PrimaryContact)
+
+	updatedChild, err := repoFactory.GetMockChildRepository().GetByID(ctx, child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, father.ID, updatedChild.ParentID)
+
+	wards, err := service.ListGuardianshipsByParentIDs(ctx, []uuid.UUID{mother.ID})
+	require.NoError(t, err)
+	require.Len(t, wards, 1)
+	assert.Equal(t, child.ID, wards[0].ChildID)
+}
+
+func TestAddChildToParent_PrimaryContactTakesOver(t *testing.T) {
+	// Arrange
+	service, repoFactory, ctx, father, mother, child := setupGuardianshipTest(t)
+
+	// Act
+	err := service.AddChildToParent(ctx, mother.ID, child.ID, domain.GuardianshipMother, true, nil)
+
+	// Assert the mother is the single primary contact and the child's parent
+	require.NoError(t, err)
+	guardianships, err := service.ListGuardianshipsByChildIDs(ctx, []uuid.UUID{child.ID})
+	require.NoError(t, err)
+	primary := map[uuid.UUID]bool{}
+	for _, guardianship := range domain.ActiveGuardianships(guardianships) {
+		primary[guardianship.ParentID] = guardianship.PrimaryContact
+	}
+	assert.Equal(t, map[uuid.UUID]bool{father.ID: false, mother.ID: true}, primary)
+
+	updatedChild, err := repoFactory.GetMockChildRepository().GetByID(ctx, child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, mother.ID, updatedChild.ParentID)
+}
+
+func TestAddChildToParent_UpdatesGuardianship(t *testing.T) {
+	// Arrange
+	service, _, ctx, father, _, child := setupGuardianshipTest(t)
+
+	// Act
+	err := service.AddChildToParent(ctx, father.ID, child.ID, domain.GuardianshipFoster, false, nil)
+
+	// Assert the guardianship changed type and, as the only one, stays the primary contact
+	require.NoError(t, err)
+	guardianships, err := service.ListGuardianshipsByChildIDs(ctx, []uuid.UUID{child.ID})
+	require.NoError(t, err)
+	require.Len(t, guardianships, 1)
+	assert.Equal(t, domain.GuardianshipFoster, guardianships[0].Type)
+	assert.True(t, guardianships[0].PrimaryContact)
+}
+
+func TestAddChildToParent_InvalidType(t *testing.T) {
+	// Arrange
+	service, _, ctx, _, mother, child := setupGuardianshipTest(t)
+
+	// Act
+	err := service.AddChildToParent(ctx, mother.ID, child.ID, domain.GuardianshipType("UNCLE"), false, nil)
+
+	// Assert
+	require.Error(t, err)
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestRemoveChildFromParent_OnlyGuardian(t *testing.T) {
+	// Arrange
+	service, repoFactory, ctx, father, _, child := setupGuardianshipTest(t)
+
+	// Act
+	err := service.RemoveChildFromParent(ctx, father.ID, child.ID)
+
+	// Assert
+	require.Error(t, err)
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.True(t, repoFactory.GetMockTransactionManager().RollbackTxCalled)
+}
+
+func TestRemoveChildFromParent_NotGuardian(t *testing.T) {
+	// Arrange
+	service, _, ctx, _, mother, child := setupGuardianshipTest(t)
+
+	// Act
+	err := service.RemoveChildFromParent(ctx, mother.ID, child.ID)
+
+	// Assert
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+}
+
+func TestRemoveChildFromParent_KeepsEndedGuardianship(t *testing.T) {
+	// Arrange
+	service, _, ctx, father, mother, child := setupGuardianshipTest(t)
+	require.NoError(t, service.AddChildToParent(ctx, mother.ID, child.ID, domain.GuardianshipMother, true, nil))
+
+	// Act
+	err := service.RemoveChildFromParent(ctx, mother.ID, child.ID)
+
+	// Assert the mother's guardianship ended and the father is the primary contact again
+	require.NoError(t, err)
+	guardianships, err := service.ListGuardianshipsByChildIDs(ctx, []uuid.UUID{child.ID})
+	require.NoError(t, err)
+	require.Len(t, guardianships, 2)
+	for _, guardianship := range guardianships {
+		if guardianship.ParentID == mother.ID {
+			assert.False(t, guardianship.IsActive())
+			assert.False(t, guardianship.PrimaryContact)
+		} else {
+			assert.Equal(t, father.ID, guardianship.ParentID)
+			assert.True(t, guardianship.PrimaryContact)
+		}
+	}
+}
+
+func TestGetChildByID_GuardianFamilyScope(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, _ := setupFamilyServiceTest(t)
+	ctx, ownParent, _, _, otherChild := setupFamilyScopeTest(t, repoFactory)
+	guardians := repoFactory.GetMockGuardianshipRepository()
+
+	// Act
+	_, err := service.GetChildByID(ctx, otherChild.ID)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrForbidden))
+	guardianships, err := service.ListGuardianshipsByChildIDs(ctx, []uuid.UUID{otherChild.ID})
+	require.NoError(t, err)
+	assert.Empty(t, guardianships)
+
+	// Assert that the caller may access the child once they are one of its guardians
+	require.NoError(t, guardians.Create(ctx, domain.NewGuardianship(ownParent.ID, otherChild.ID, domain.GuardianshipFoster, time.Now(), false)))
+	child, err := service.GetChildByID(ctx, otherChild.ID)
+	require.NoError(t, err)
+	assert.Equal(t, otherChild.ID, child.ID)
+	guardianships, err = service.ListGuardianshipsByChildIDs(ctx, []uuid.UUID{otherChild.ID})
+	require.NoError(t, err)
+	assert.Len(t, guardianships, 2)
+}
+
+// testAddress is the address of the households created by the tests
+var testAddress = domain.Address{Street: "1 Main St", City: "Springfield", State: "IL", PostalCode: "62701", Country: "US"}
+
+func TestCreateHousehold_Success(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+	child := domain.NewChild("Jimmy", "Doe", time.Now().AddDate(-5, 0, 0), parent.ID)
+	repoFactory.GetMockChildRepository().AddTestChild(child)
+
+	// Act
+	household, err := service.CreateHousehold(ctx, "The Doe family", testAddress, []string{"+12175550100"},
+		[]uuid.UUID{parent.ID, parent.ID}, []uuid.UUID{child.ID})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{parent.ID}, household.ParentIDs)
+	assert.Equal(t, []uuid.UUID{child.ID}, household.ChildIDs)
+
+	saved, err := repoFactory.GetMockHouseholdRepository().GetByChildID(ctx, child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, household.ID, saved.ID)
+}
+
+func TestCreateHousehold_Invalid(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+
+	// Act and assert that a household needs a name, a parent and a complete address
+	_, err := service.CreateHousehold(ctx, "", testAddress, nil, []uuid.UUID{parent.ID}, nil)
+	assert.ErrorIs(t, err, domain.ErrValidation)
+	_, err = service.CreateHousehold(ctx, "The Doe family", testAddress, nil, nil, nil)
+	assert.ErrorIs(t, err, domain.ErrValidation)
+	_, err = service.CreateHousehold(ctx, "The Doe family", domain.Address{Street: "1 Main St"}, nil, []uuid.UUID{parent.ID}, nil)
+	assert.ErrorIs(t, err, domain.ErrValidation)
+
+	// Members must exist
+	_, err = service.CreateHousehold(ctx, "The Doe family", testAddress, nil, []uuid.UUID{uuid.New()}, nil)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = service.CreateHousehold(ctx, "The Doe family", testAddress, nil, []uuid.UUID{parent.ID}, []uuid.UUID{uuid.New()})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestCreateHousehold_ChildInAnotherHousehold(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+	child := domain.NewChild("Jimmy", "Doe", time.Now().AddDate(-5, 0, 0), parent.ID)
+	repoFactory.GetMockChildRepository().AddTestChild(child)
+	_, err := service.CreateHousehold(ctx, "Dad's", testAddress, nil, []uuid.UUID{parent.ID}, []uuid.UUID{child.ID})
+	require.NoError(t, err)
+
+	// Act
+	_, err = service.CreateHousehold(ctx, "Mum's", testAddress, nil, []uuid.UUID{parent.ID}, []uuid.UUID{child.ID})
+
+	// Assert that the child has to be moved instead
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrValidation)
+	assert.Contains(t, err.Error(), "move it instead")
+}
+
+func TestMoveChildToHousehold_LeavesPreviousHousehold(t *testing.T) {
+	// Arrange
+	service, repoFactory, auditLog := setupAuditedFamilyServiceTest(t)
+	ctx := context.Background()
+	father := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	mother := domain.NewParent("Mary", "Doe", "mary.doe@example.com", time.Now().AddDate(-29, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(father)
+	repoFactory.GetMockParentRepository().AddTestParent(mother)
+	child := domain.NewChild("Jimmy", "Doe", time.Now().AddDate(-5, 0, 0), father.ID)
+	repoFactory.GetMockChildRepository().AddTestChild(child)
+
+	fathers, err := service.CreateHousehold(ctx, "Dad's", testAddress, nil, []uuid.UUID{father.ID}, []uuid.UUID{child.ID})
+	require.NoError(t, err)
+	mothers, err := service.CreateHousehold(ctx, "Mum's", testAddress, nil, []uuid.UUID{mother.ID}, nil)
+	require.NoError(t, err)
+
+	// Act
+	household, err := service.MoveChildToHousehold(ctx, child.ID, mothers.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{child.ID}, household.ChildIDs)
+
+	previous, err := service.GetHouseholdByID(ctx, fathers.ID)
+	require.NoError(t, err)
+	assert.Empty(t, previous.ChildIDs)
+
+	// Both households record the move
+	records := auditLog.Records()
+	require.Len(t, records, 4)
+	for _, record := range records[2:] {
+		assert.Equal(t, "MoveChildToHousehold", record.Operation)
+		assert.Equal(t, domain.AuditEntityHousehold, record.EntityType)
+	}
+	assert.ElementsMatch(t, []uuid.UUID{fathers.ID, mothers.ID}, []uuid.UUID{records[2].EntityID, records[3].EntityID})
+
+	// Moving the child again changes nothing
+	_, err = service.MoveChildToHousehold(ctx, child.ID, mothers.ID)
+	require.NoError(t, err)
+	assert.Len(t, auditLog.Records(), 4)
+
+	// An unknown child or household is not found
+	_, err = service.MoveChildToHousehold(ctx, uuid.New(), mothers.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = service.MoveChildToHousehold(ctx, child.ID, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestGetHouseholdByID_FamilyScope(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, _ := setupFamilyServiceTest(t)
+	ctx, ownParent, ownChild, otherParent, otherChild := setupFamilyScopeTest(t, repoFactory)
+	own, err := service.CreateHousehold(context.Background(), "Own", testAddress, nil, []uuid.UUID{ownParent.ID}, []uuid.UUID{ownChild.ID})
+	require.NoError(t, err)
+	other, err := service.CreateHousehold(context.Background(), "Other", testAddress, nil, []uuid.UUID{otherParent.ID}, []uuid.UUID{otherChild.ID})
+	require.NoError(t, err)
+
+	// Act
+	household, err := service.GetHouseholdByID(ctx, own.ID)
+	require.NoError(t, err)
+	assert.Equal(t, own.ID, household.ID)
+
+	_, err = service.GetHouseholdByID(ctx, other.ID)
+
+	// Assert that a restricted caller only sees the households they live in
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrForbidden))
+
+	_, err = service.MoveChildToHousehold(ctx, ownChild.ID, other.ID)
+	assert.True(t, errors.Is(err, domain.ErrForbidden))
+}
+
+func TestCreateParent_WithContactDetails(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	contact := &domain.ContactDetails{
+		Phones:            []domain.Phone{{Number: "+1 (217) 555-0100", Type: domain.PhoneMobile}},
+		Address:           &domain.Address{Street: "1 Main St", City: "Springfield", PostalCode: "62701", Country: "us"},
+		EmergencyContacts: []domain.EmergencyContact{{Name: "Mary Doe", Relationship: "grandmother", Phone: domain.Phone{Number: "+12175550199"}}},
+	}
+
+	// Act
+	parent, err := service.CreateParent(ctx, "John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0).Format(time.RFC3339), contact)
+
+	// Assert that the phone numbers and country are stored normalized
+	require.NoError(t, err)
+	savedParent, err := repoFactory.GetMockParentRepository().GetByID(ctx, parent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "+12175550100", savedParent.Phones[0].Number)
+	assert.Equal(t, "US", savedParent.Address.Country)
+	assert.Equal(t, "Mary Doe", savedParent.EmergencyContacts[0].Name)
+}
+
+func TestCreateParent_InvalidContactDetails(t *testing.T) {
+	// Arrange
+	service, _, _, _, ctx := setupFamilyServiceTest(t)
+	birthDate := time.Now().AddDate(-30, 0, 0).Format(time.RFC3339)
+
+	testCases := []struct {
+		name    string
+		contact domain.ContactDetails
+		field   string
+	}{
+		{
+			name:    "phone number not in E.164 format",
+			contact: domain.ContactDetails{Phones: []domain.Phone{{Number: "217-555-0100"}}},
+			field:   "phones",
+		},
+		{
+			name:    "unknown phone type",
+			contact: domain.ContactDetails{Phones: []domain.Phone{{Number: "+12175550100", Type: "FAX"}}},
+			field:   "phones",
+		},
+		{
+			name:    "unknown country",
+			contact: domain.ContactDetails{Address: &domain.Address{Street: "1 Main St", City: "Springfield", PostalCode: "62701", Country: "XX"}},
+			field:   "address",
+		},
+		{
+			name: "emergency contact at the parent's own number",
+			contact: domain.ContactDetails{
+				Phones:            []domain.Phone{{Number: "+12175550100"}},
+				EmergencyContacts: []domain.EmergencyContact{{Name: "Mary Doe", Phone: domain.Phone{Number: "+1 217 555 0100"}}},
+			},
+			field: "emergencyContacts",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			contact := tc.contact
+			parent, err := service.CreateParent(ctx, "John", "Doe", "john.doe@example.com", birthDate, &contact)
+
+			// Assert
+			require.Error(t, err)
+			assert.Nil(t, parent)
+			var validationErr *domain.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tc.field, validationErr.Field)
+		})
+	}
+}
+
+func TestUpdateParent_ContactDetails(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	testParent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	testParent.Phones = []domain.Phone{{Number: "+12175550100"}}
+	repoFactory.GetMockParentRepository().AddTestParent(testParent)
+	birthDate := testParent.BirthDate.Format(time.RFC3339)
+
+	// Act: without contact details, the current ones are kept
+	updatedParent, err := service.UpdateParent(ctx, testParent.ID, "John", "Doe", "john.doe@example.com", birthDate, nil, nil)
+	require.NoError(t, err)
+	assert.True(t, updatedParent.HasPhone("+12175550100"))
+
+	// Act: with contact details, they replace the current ones
+	updatedParent, err = service.UpdateParent(ctx, testParent.ID, "John", "Doe", "john.doe@example.com", birthDate,
+		&domain.ContactDetails{Phones: []domain.Phone{{Number: "+1 217 555 0111", Type: domain.PhoneWork}}}, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Phone{{Number: "+12175550111", Type: domain.PhoneWork}}, updatedParent.Phones)
+}
+
+func TestListParents_FilterByContactDetails(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	springfield := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	springfield.Address = &domain.Address{Street: "1 Main St", City: "Springfield", PostalCode: "62701", Country: "US"}
+	springfield.Phones = []domain.Phone{{Number: "+12175550100"}}
+	shelbyville := domain.NewParent("Jane", "Smith", "jane.smith@example.com", time.Now().AddDate(-25, 0, 0))
+	shelbyville.Address = &domain.Address{Street: "2 Oak St", City: "Shelbyville", PostalCode: "62565", Country: "US"}
+	withoutAddress := domain.NewParent("Jim", "Brown", "jim.brown@example.com", time.Now().AddDate(-35, 0, 0))
+	for _, parent := range []*domain.Parent{springfield, shelbyville, withoutAddress} {
+		repoFactory.GetMockParentRepository().AddTestParent(parent)
+	}
+	pagination := ports.PaginationOptions{Page: 0, PageSize: 10}
+
+	// Act
+	byPostalCode, _, err := service.ListParents(ctx, ports.QueryOptions{Filter: ports.FilterOptions{PostalCode: "62701"}, Pagination: pagination})
+	require.NoError(t, err)
+	byPhone, _, err := service.ListParents(ctx, ports.QueryOptions{Filter: ports.FilterOptions{Phone: "+12175550100"}, Pagination: pagination})
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, byPostalCode, 1)
+	assert.Equal(t, springfield.ID, byPostalCode[0].ID)
+	require.Len(t, byPhone, 1)
+	assert.Equal(t, springfield.ID, byPhone[0].ID)
+}
+
+func TestFindDuplicateParents_Success(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	birthDate := time.Date(1990, 4, 12, 0, 0, 0, 0, time.UTC)
+	john := domain.NewParent("John", "Doe", "john.doe@example.com", birthDate)
+	typo := domain.NewParent("Jon", "Doe", "John.Doe+school@example.com", birthDate)
+	swapped := domain.NewParent("Doe", "John", "doe.john@example.org", birthDate)
+	other := domain.NewParent("Mary", "Smith", "mary.smith@example.com", time.Date(1985, 1, 3, 0, 0, 0, 0, time.UTC))
+	for _, parent := range []*domain.Parent{john, typo, swapped, other} {
+		repoFactory.GetMockParentRepository().AddTestParent(parent)
+	}
+
+	// Act
+	duplicates, err := service.FindDuplicateParents(ctx, &john.ID, 0, 0)
+
+	// Assert the typo is the most similar, and the unrelated parent is not listed
+	require.NoError(t, err)
+	require.Len(t, duplicates, 2)
+	assert.Equal(t, john.ID, duplicates[0].Parent.ID)
+	assert.Equal(t, typo.ID, duplicates[0].Duplicate.ID)
+	assert.Equal(t, 1.0, duplicates[0].EmailScore)
+	assert.Equal(t, 1.0, duplicates[0].BirthDateScore)
+	assert.Equal(t, swapped.ID, duplicates[1].Duplicate.ID)
+	assert.Equal(t, 1.0, duplicates[1].NameScore)
+	assert.Greater(t, duplicates[0].Score, duplicates[1].Score)
+}
+
+func TestFindDuplicateParents_AllPairs(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	birthDate := time.Date(1990, 4, 12, 0, 0, 0, 0, time.UTC)
+	john := domain.NewParent("John", "Doe", "john.doe@example.com", birthDate)
+	typo := domain.NewParent("John", "Doee", "john.doe@example.org", birthDate)
+	typo.CreatedAt = john.CreatedAt.Add(time.Hour)
+	other := domain.NewParent("Mary", "Smith", "mary.smith@example.com", time.Date(1985, 1, 3, 0, 0, 0, 0, time.UTC))
+	for _, parent := range []*domain.Parent{john, typo, other} {
+		repoFactory.GetMockParentRepository().AddTestParent(parent)
+	}
+
+	// Act
+	duplicates, err := service.FindDuplicateParents(ctx, nil, 0, 0)
+	require.NoError(t, err)
+	limited, err := service.FindDuplicateParents(ctx, nil, 0.99, 0)
+	require.NoError(t, err)
+
+	// Assert the older parent of the pair comes first
+	require.Len(t, duplicates, 1)
+	assert.Equal(t, john.ID, duplicates[0].Parent.ID)
+	assert.Equal(t, typo.ID, duplicates[0].Duplicate.ID)
+	assert.Empty(t, limited)
+}
+
+func TestFindDuplicateParents_InvalidOptions(t *testing.T) {
+	// Arrange
+	service, _, _, _, ctx := setupFamilyServiceTest(t)
+
+	for _, tc := range []struct {
+		name     string
+		minScore float64
+		limit    int
+	}{
+		{name: "negative score", minScore: -0.1},
+		{name: "score above one", minScore: 1.5},
+		{name: "negative limit", limit: -1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			_, err := service.FindDuplicateParents(ctx, nil, tc.minScore, tc.limit)
+
+			// Assert
+			var validationErr *domain.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+		})
+	}
+}
+
+func TestMergeParents_Success(t *testing.T) {
+	// Arrange
+	service, repoFactory, ctx, father, _, child := setupGuardianshipTest(t)
+	duplicate := domain.NewParent("Jon", "Doe", "jon.doe@example.com", father.BirthDate)
+	repoFactory.GetMockParentRepository().AddTestParent(duplicate)
+	duplicateChild := domain.NewChild("Jim", "Doe", time.Now().AddDate(-3, 0, 0), duplicate.ID)
+	repoFactory.GetMockChildRepository().AddTestChild(duplicateChild)
+	require.NoError(t, repoFactory.GetMockGuardianshipRepository().Create(ctx,
+		domain.NewGuardianship(duplicate.ID, duplicateChild.ID, domain.GuardianshipFather, duplicateChild.CreatedAt, true)))
+	require.NoError(t, repoFactory.GetMockGuardianshipRepository().Create(ctx,
+		domain.NewGuardianship(duplicate.ID, child.ID, domain.GuardianshipFather, child.CreatedAt, false)))
+
+	// Act
+	survivor, err := service.MergeParents(ctx, father.ID, duplicate.ID)
+
+	// Assert the children of the duplicate belong to the survivor
+	require.NoError(t, err)
+	assert.Equal(t, father.ID, survivor.ID)
+	movedChild, err := repoFactory.GetMockChildRepository().GetByID(ctx, duplicateChild.ID)
+	require.NoError(t, err)
+	assert.Equal(t, father.ID, movedChild.ParentID)
+
+	// Assert the guardianships of the duplicate ended, and the survivor guards both children once
+	wards, err := service.ListGuardianshipsByParentIDs(ctx, []uuid.UUID{father.ID})
+	require.NoError(t, err)
+	active := domain.ActiveGuardianships(wards)
+	require.Len(t, active, 2)
+	for _, guardianship := range active {
+		assert.True(t, guardianship.PrimaryContact)
+	}
+	duplicateWards, err := service.ListGuardianshipsByParentIDs(ctx, []uuid.UUID{duplicate.ID})
+	require.NoError(t, err)
+	assert.Empty(t, domain.ActiveGuardianships(duplicateWards))
+
+	// Assert the duplicate is deleted and records the survivor
+	deleted, _, err := repoFactory.GetMockParentRepository().List(ctx, ports.QueryOptions{
+		Filter:     ports.FilterOptions{Deleted: true, ParentIDs: []uuid.UUID{duplicate.ID}},
+		Pagination: ports.PaginationOptions{Page: 0, PageSize: 10},
+	})
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	require.NotNil(t, deleted[0].MergedInto)
+	assert.Equal(t, father.ID, *deleted[0].MergedInto)
+	assert.True(t, repoFactory.GetMockTransactionManager().CommitTxCalled)
+}
+
+func TestMergeParents_SameParent(t *testing.T) {
+	// Arrange
+	service, _, ctx, father, _, _ := setupGuardianshipTest(t)
+
+	// Act
+	_, err := service.MergeParents(ctx, father.ID, father.ID)
+
+	// Assert
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestMergeParents_NotFound(t *testing.T) {
+	// Arrange
+	service, repoFactory, ctx, father, _, _ := setupGuardianshipTest(t)
+
+	// Act
+	_, err := service.MergeParents(ctx, father.ID, uuid.New())
+
+	// Assert
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+	assert.True(t, repoFactory.GetMockTransactionManager().RollbackTxCalled)
+}
+
+func TestTransferChild_Success(t *testing.T) {
+	// Arrange
+	service, repoFactory, ctx, father, mother, child := setupGuardianshipTest(t)
+	father.AddChild(*child)
+	require.NoError(t, repoFactory.GetMockParentRepository().Update(ctx, father))
+
+	// Act
+	transferred, err := service.TransferChild(ctx, child.ID, father.ID, mother.ID, " Custody order ")
+
+	// Assert the mother took over the father's guardianship and the child
+	require.NoError(t, err)
+	assert.Equal(t, mother.ID, transferred.ParentID)
+	guardianships, err := service.ListGuardianshipsByChildIDs(ctx, []uuid.UUID{child.ID})
+	require.NoError(t, err)
+	active := domain.ActiveGuardianships(guardianships)
+	require.Len(t, active, 1)
+	assert.Equal(t, mother.ID, active[0].ParentID)
+	assert.Equal(t, domain.GuardianshipFather, active[0].Type)
+	assert.True(t, active[0].PrimaryContact)
+
+	// Assert the child moved from the father's children to the mother's
+	updatedFather, err := repoFactory.GetMockParentRepository().GetByID(ctx, father.ID)
+	require.NoError(t, err)
+	assert.Empty(t, updatedFather.Children)
+	updatedMother, err := repoFactory.GetMockParentRepository().GetByID(ctx, mother.ID)
+	require.NoError(t, err)
+	require.Len(t, updatedMother.Children, 1)
+	assert.Equal(t, child.ID, updatedMother.Children[0].ID)
+	assert.True(t, repoFactory.GetMockTransactionManager().CommitTxCalled)
+}
+
+func TestTransferChild_RecordsEventAndReason(t *testing.T) {
+	// Arrange
+	service, repoFactory, auditLog := setupAuditedFamilyServiceTest(t)
+	outbox := repoFactory.GetMockOutboxRepository()
+	service.WithOutbox(outbox)
+	ctx := context.Background()
+	father := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	mother := domain.NewParent("Mary", "Doe", "mary.doe@example.com", time.Now().AddDate(-29, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(father)
+	repoFactory.GetMockParentRepository().AddTestParent(mother)
+	child := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), father.ID)
+	repoFactory.GetMockChildRepository().AddTestChild(child)
+	require.NoError(t, repoFactory.GetMockGuardianshipRepository().Create(ctx,
+		domain.NewGuardianship(father.ID, child.ID, domain.GuardianshipFather, child.CreatedAt, true)))
+
+	// Act
+	_, err := service.TransferChild(ctx, child.ID, father.ID, mother.ID, "Custody order")
+
+	// Assert
+	require.NoError(t, err)
+	records, err := auditLog.List(ctx, ports.AuditLogFilter{EntityID: child.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "TransferChild", records[0].Operation)
+	assert.Equal(t, "Custody order", records[0].Reason)
+
+	messages := outbox.Messages()
+	require.Len(t, messages, 1)
+	event := messages[0].Event
+	assert.Equal(t, domain.EventChildTransferred, event.Type)
+	assert.Equal(t, mother.ID, event.ParentID)
+	require.NotNil(t, event.PreviousParentID)
+	assert.Equal(t, father.ID, *event.PreviousParentID)
+	assert.Equal(t, "Custody order", event.Reason)
+	assert.True(t, event.BelongsToFamily(father.ID))
+}
+
+func TestTransferChild_NotGuardian(t *testing.T) {
+	// Arrange
+	service, repoFactory, ctx, father, mother, child := setupGuardianshipTest(t)
+
+	// Act
+	_, err := service.TransferChild(ctx, child.ID, mother.ID, father.ID, "Custody order")
+
+	// Assert
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "fromParentId", validationErr.Field)
+	assert.True(t, repoFactory.GetMockTransactionManager().RollbackTxCalled)
+}
+
+func TestTransferChild_InvalidInput(t *testing.T) {
+	// Arrange
+	service, _, ctx, father, mother, child := setupGuardianshipTest(t)
+
+	for _, tc := range []struct {
+		name   string
+		to     uuid.UUID
+		reason string
+		field  string
+	}{
+		{name: "same parent", to: father.ID, reason: "Custody order", field: "toParentId"},
+		{name: "blank reason", to: mother.ID, reason: "  ", field: "reason"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			_, err := service.TransferChild(ctx, child.ID, father.ID, tc.to, tc.reason)
+
+			// Assert
+			var validationErr *domain.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tc.field, validationErr.Field)
+		})
+	}
+}
+
+func TestAddChildToParent_RemovesChildFromPreviousPrimaryContact(t *testing.T) {
+	// Arrange
+	service, repoFactory, ctx, father, mother, child := setupGuardianshipTest(t)
+	father.AddChild(*child)
+	require.NoError(t, repoFactory.GetMockParentRepository().Update(ctx, father))
+
+	// Act
+	err := service.AddChildToParent(ctx, mother.ID, child.ID, domain.GuardianshipMother, true, nil)
+
+	// Assert the child is only one of the mother's children
+	require.NoError(t, err)
+	updatedFather, err := repoFactory.GetMockParentRepository().GetByID(ctx, father.ID)
+	require.NoError(t, err)
+	assert.Empty(t, updatedFather.Children)
+	updatedMother, err := repoFactory.GetMockParentRepository().GetByID(ctx, mother.ID)
+	require.NoError(t, err)
+	require.Len(t, updatedMother.Children, 1)
+}
+
+func TestCreateParents_Atomic(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	outbox := repoFactory.GetMockOutboxRepository()
+	service.WithOutbox(outbox)
+	birthDate := time.Now().AddDate(-30, 0, 0).Format(time.RFC3339)
+	inputs := []ports.ParentInput{
+		{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com", BirthDate: birthDate},
+		{FirstName: "Mary", LastName: "Doe", Email: "mary.doe@example.com", BirthDate: birthDate},
+	}
+
+	// Act
+	results, err := service.CreateParents(ctx, inputs, true)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for i, result := range results {
+		require.NoError(t, result.Err)
+		assert.Equal(t, inputs[i].Email, result.Parent.Email)
+		_, err := repoFactory.GetMockParentRepository().GetByID(ctx, result.Parent.ID)
+		require.NoError(t, err)
+	}
+	messages := outbox.Messages()
+	require.Len(t, messages, 2)
+	assert.Equal(t, domain.EventParentCreated, messages[0].Event.Type)
+	assert.Equal(t, results[1].Parent.ID, messages[1].Event.ParentID)
+	assert.True(t, repoFactory.GetMockTransactionManager().CommitTxCalled)
+}
+
+func TestCreateParents_AtomicDuplicateEmail(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	birthDate := time.Now().AddDate(-30, 0, 0).Format(time.RFC3339)
+	inputs := []ports.ParentInput{
+		{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com", BirthDate: birthDate},
+		{FirstName: "Johnny", LastName: "Doe", Email: "JOHN.DOE@example.com", BirthDate: birthDate},
+		{FirstName: "Mary", LastName: "Doe", Email: "mary.doe@example.com", BirthDate: birthDate},
+	}
+
+	// Act
+	results, err := service.CreateParents(ctx, inputs, true)
+
+	// Assert the duplicate fails and the other parents are aborted
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	var duplicateErr *domain.DuplicateError
+	require.ErrorAs(t, results[1].Err, &duplicateErr)
+	assert.Equal(t, "email", duplicateErr.Field)
+	assert.ErrorIs(t, results[0].Err, domain.ErrAborted)
+	assert.ErrorIs(t, results[2].Err, domain.ErrAborted)
+	for _, result := range results {
+		assert.Nil(t, result.Parent)
+	}
+	assert.True(t, repoFactory.GetMockTransactionManager().RollbackTxCalled)
+
+	// Assert no parent was stored
+	count, err := service.CountParents(ctx, ports.FilterOptions{})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestCreateParents_AtomicValidationFailure(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	birthDate := time.Now().AddDate(-30, 0, 0).Format(time.RFC3339)
+	inputs := []ports.ParentInput{
+		{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com", BirthDate: birthDate},
+		{FirstName: "Mary", LastName: "Doe", BirthDate: birthDate},
+	}
+
+	// Act
+	results, err := service.CreateParents(ctx, inputs, true)
+
+	// Assert nothing is stored when an input is invalid
+	require.NoError(t, err)
+	assert.ErrorIs(t, results[0].Err, domain.ErrAborted)
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, results[1].Err, &validationErr)
+	assert.Equal(t, "email", validationErr.Field)
+	assert.False(t, repoFactory.GetMockTransactionManager().BeginTxCalled)
+}
+
+func TestCreateParents_NonAtomic(t *testing.T) {
+	// Arrange
+	service, _, _, _, ctx := setupFamilyServiceTest(t)
+	birthDate := time.Now().AddDate(-30, 0, 0).Format(time.RFC3339)
+	inputs := []ports.ParentInput{
+		{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com", BirthDate: birthDate},
+		{FirstName: "Johnny", LastName: "Doe", Email: "john.doe@example.com", BirthDate: birthDate},
+		{FirstName: "Mary", LastName: "Doe", Email: "mary.doe@example.com", BirthDate: birthDate},
+	}
+
+	// Act
+	results, err := service.CreateParents(ctx, inputs, false)
+
+	// Assert only the duplicate fails
+	require.NoError(t, err)
+	require.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, domain.ErrDuplicate)
+	require.NoError(t, results[2].Err)
+	count, err := service.CountParents(ctx, ports.FilterOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestCreateParents_TooMany(t *testing.T) {
+	// Arrange
+	service, _, _, _, ctx := setupFamilyServiceTest(t)
+
+	// Act
+	results, err := service.CreateParents(ctx, make([]ports.ParentInput, ports.MaxBatchSize+1), true)
+
+	// Assert
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "inputs", validationErr.Field)
+	assert.Nil(t, results)
+}
+
+func TestCreateChildren_Atomic(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+	birthDate := time.Now().AddDate(-5, 0, 0).Format(time.RFC3339)
+	inputs := []ports.ChildInput{
+		{FirstName: "Jane", LastName: "Doe", BirthDate: birthDate, ParentID: parent.ID},
+		{FirstName: "Jim", LastName: "Doe", BirthDate: birthDate, ParentID: parent.ID},
+	}
+
+	// Act
+	results, err := service.CreateChildren(ctx, inputs, true)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	childIDs := []uuid.UUID{}
+	for _, result := range results {
+		require.NoError(t, result.Err)
+		childIDs = append(childIDs, result.Child.ID)
+	}
+
+	// Assert the parent became the primary guardian of both children
+	updatedParent, err := repoFactory.GetMockParentRepository().GetByID(ctx, parent.ID)
+	require.NoError(t, err)
+	assert.Len(t, updatedParent.Children, 2)
+	guardianships, err := service.ListGuardianshipsByChildIDs(ctx, childIDs)
+	require.NoError(t, err)
+	require.Len(t, guardianships, 2)
+	for _, guardianship := range guardianships {
+		assert.Equal(t, parent.ID, guardianship.ParentID)
+		assert.True(t, guardianship.PrimaryContact)
+	}
+}
+
+func TestCreateChildren_AtomicParentNotFound(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+	birthDate := time.Now().AddDate(-5, 0, 0).Format(time.RFC3339)
+	inputs := []ports.ChildInput{
+		{FirstName: "Jane", LastName: "Doe", BirthDate: birthDate, ParentID: parent.ID},
+		{FirstName: "Jim", LastName: "Doe", BirthDate: birthDate, ParentID: uuid.New()},
+	}
+
+	// Act
+	results, err := service.CreateChildren(ctx, inputs, true)
+
+	// Assert
+	require.NoError(t, err)
+	assert.ErrorIs(t, results[0].Err, domain.ErrAborted)
+	assert.ErrorIs(t, results[1].Err, domain.ErrNotFound)
+	assert.True(t, repoFactory.GetMockTransactionManager().RollbackTxCalled)
+	count, err := service.CountChildren(ctx, ports.FilterOptions{})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestDeleteChildren_Atomic(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	outbox := repoFactory.GetMockOutboxRepository()
+	service.WithOutbox(outbox)
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+	first := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parent.ID)
+	second := domain.NewChild("Jim", "Doe", time.Now().AddDate(-3, 0, 0), parent.ID)
+	repoFactory.GetMockChildRepository().AddTestChild(first)
+	repoFactory.GetMockChildRepository().AddTestChild(second)
+
+	// Act
+	results, err := service.DeleteChildren(ctx, []uuid.UUID{first.ID, second.ID}, true)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		require.NoError(t, result.Err)
+		_, err := repoFactory.GetMockChildRepository().GetByID(ctx, result.ID)
+		assert.Error(t, err)
+	}
+	messages := outbox.Messages()
+	require.Len(t, messages, 2)
+	assert.Equal(t, domain.EventChildDeleted, messages[0].Event.Type)
+	assert.Equal(t, second.ID, messages[1].Event.ChildID)
+}
+
+func TestDeleteChildren_AtomicNotFound(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+	child := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parent.ID)
+	repoFactory.GetMockChildRepository().AddTestChild(child)
+	missingID := uuid.New()
+
+	// Act
+	results, err := service.DeleteChildren(ctx, []uuid.UUID{child.ID, missingID}, true)
+
+	// Assert the child is kept, as the other ID was not found
+	require.NoError(t, err)
+	assert.Equal(t, child.ID, results[0].ID)
+	assert.ErrorIs(t, results[0].Err, domain.ErrAborted)
+	assert.Equal(t, missingID, results[1].ID)
+	assert.ErrorIs(t, results[1].Err, domain.ErrNotFound)
+	assert.True(t, repoFactory.GetMockTransactionManager().RollbackTxCalled)
+}
+
+func TestDeleteChildren_NonAtomic(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+	child := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parent.ID)
+	repoFactory.GetMockChildRepository().AddTestChild(child)
+
+	// Act
+	results, err := service.DeleteChildren(ctx, []uuid.UUID{child.ID, uuid.New()}, false)
+
+	// Assert only the missing child fails
+	require.NoError(t, err)
+	require.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, domain.ErrNotFound)
+	_, err = repoFactory.GetMockChildRepository().GetByID(ctx, child.ID)
+	assert.Error(t, err)
+}
+
+func TestValidateParents(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	birthDate := time.Now().AddDate(-30, 0, 0).Format(time.RFC3339)
+	repoFactory.GetMockParentRepository().AddTestParent(
+		domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0)))
+	inputs := []ports.ParentInput{
+		{FirstName: "Mary", LastName: "Doe", Email: "mary.doe@example.com", BirthDate: birthDate},
+		{FirstName: "Johnny", LastName: "Doe", Email: "JOHN.DOE@example.com", BirthDate: birthDate},
+		{FirstName: "Maria", LastName: "Doe", Email: "Mary.Doe@example.com", BirthDate: birthDate},
+		{FirstName: "Jim", LastName: "Doe", Email: "jim.doe@example.com", BirthDate: "yesterday"},
+	}
+
+	// Act
+	errs, err := service.ValidateParents(ctx, inputs)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, errs, 4)
+	assert.NoError(t, errs[0])
+	assert.ErrorIs(t, errs[1], domain.ErrDuplicate)
+	assert.ErrorIs(t, errs[2], domain.ErrDuplicate)
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, errs[3], &validationErr)
+	assert.Equal(t, "birthDate", validationErr.Field)
+
+	// Assert nothing was stored
+	count, err := service.CountParents(ctx, ports.FilterOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestValidateChildren(t *testing.T) {
+	// Arrange
+	service, repoFactory, _, _, ctx := setupFamilyServiceTest(t)
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	repoFactory.GetMockParentRepository().AddTestParent(parent)
+	birthDate := time.Now().AddDate(-5, 0, 0).Format(time.RFC3339)
+	inputs := []ports.ChildInput{
+		{FirstName: "Jane", LastName: "Doe", BirthDate: birthDate, ParentID: parent.ID},
+		{FirstName: "Jim", LastName: "Doe", BirthDate: birthDate, ParentID: uuid.New()},
+		{LastName: "Doe", BirthDate: birthDate, ParentID: parent.ID},
+	}
+
+	// Act
+	errs, err := service.ValidateChildren(ctx, inputs)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, errs, 3)
+	assert.NoError(t, errs[0])
+	assert.ErrorIs(t, errs[1], domain.ErrNotFound)
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, errs[2], &validationErr)
+	assert.Equal(t, "firstName", validationErr.Field)
+	count, err := service.CountChildren(ctx, ports.FilterOptions{})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
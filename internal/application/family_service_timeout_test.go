@@ -35,6 +35,7 @@ func setupTimeoutTest(t *testing.T) (
 	// Create service
 	service := application.NewFamilyService(
 		repoFactory,
+		mocks.NewMockEventBroker(),
 		validate,
 		logger,
 	)
@@ -105,7 +106,7 @@ func TestCreateParentTimeout(t *testing.T) {
 	email := "john.doe@example.com"
 	birthDate := time.Now().AddDate(-30, 0, 0).Format(time.RFC3339)
 
-	parent, err := service.CreateParent(ctx, firstName, lastName, email, birthDate)
+	parent, err := service.CreateParent(ctx, firstName, lastName, email, birthDate, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -0,0 +1,190 @@
+package application
+
+import (
+	"context"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// MergeParents merges a parent recorded twice into the parent that is kept, the survivor. The active
+// guardianships of the duplicate are moved to the survivor, who becomes the primary contact, and the parent,
+// of the children the duplicate was the primary contact of. The duplicate then records the survivor it was
+// merged into and is marked as deleted, so that its history shows the merge. The operation is performed within a transaction.
+// The method uses OpenTelemetry for tracing and logs relevant information during the operation.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - survivorID: The unique identifier of the parent that is kept
+//   - duplicateID: The unique identifier of the parent that is merged into the survivor
+//
+// Returns:
+//   - *domain.Parent: The survivor, with the children it took over
+//   - error: A ValidationError if both IDs are the same, a NotFoundError if either parent doesn't exist,
+//     a TransactionError if the transaction fails, or a database error
+func (s *FamilyService) MergeParents(ctx context.Context, survivorID, duplicateID uuid.UUID) (*domain.Parent, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.MergeParents")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("parent.survivor_id", survivorID.String()),
+		attribute.String("parent.duplicate_id", duplicateID.String()),
+	)
+
+	// Validate input
+	if survivorID == duplicateID {
+		return nil, domain.NewValidationError("Parent", "duplicateId", "must be another parent than the survivor")
+	}
+
+	// Begin transaction
+	ctx, err := s.transactionManager.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("begin", err)
+	}
+
+	// Get the survivor
+	survivor, err := s.parentRepo.GetByID(ctx, survivorID)
+	if err != nil {
+		s.rollback(ctx)
+		s.logger.Error("Failed to get parent", zap.Error(err), zap.String("parent_id", survivorID.String()))
+		return nil, domain.NewNotFoundError("Parent", survivorID.String())
+	}
+
+	// Get the duplicate
+	duplicate, err := s.parentRepo.GetByID(ctx, duplicateID)
+	if err != nil {
+		s.rollback(ctx)
+		s.logger.Error("Failed to get parent", zap.Error(err), zap.String("parent_id", duplicateID.String()))
+		return nil, domain.NewNotFoundError("Parent", duplicateID.String())
+	}
+	survivorBefore := survivor.AuditSnapshot()
+	duplicateBefore := duplicate.AuditSnapshot()
+
+	// Get the guardianships of the duplicate, and those of the survivor over the same children
+	guardianships, err := s.guardianshipRepo.ListByParentIDs(ctx, []uuid.UUID{duplicateID, survivorID})
+	if err != nil {
+		s.rollback(ctx)
+		s.logger.Error("Failed to list guardianships", zap.Error(err), zap.String("parent_id", duplicateID.String()))
+		return nil, domain.NewDatabaseError("list", "Guardianship", err)
+	}
+	survivorGuardianships := make(map[uuid.UUID]*domain.Guardianship)
+	for _, guardianship := range domain.ActiveGuardianships(guardianships) {
+		if guardianship.ParentID == survivorID {
+			survivorGuardianships[guardianship.ChildID] = guardianship
+		}
+	}
+
+	// Move the active guardianships of the duplicate to the survivor. Guardianships keep their parent, so the
+	// duplicate's guardianship ends, which also frees the primary contact mark, and the survivor's is created;
+	// when the survivor already is a guardian of the child, its guardianship takes over the primary contact mark.
+	for _, guardianship := range domain.ActiveGuardianships(guardianships) {
+		if guardianship.ParentID != duplicateID {
+			continue
+		}
+
+		primaryContact := guardianship.PrimaryContact
+		guardianship.End()
+		err = s.guardianshipRepo.Update(ctx, guardianship)
+		if err == nil {
+			if existing, ok := survivorGuardianships[guardianship.ChildID]; ok {
+				if primaryContact {
+					existing.SetPrimaryContact(true)
+					err = s.guardianshipRepo.Update(ctx, existing)
+				}
+			} else {
+				err = s.guardianshipRepo.Create(ctx, domain.NewGuardianship(survivorID, guardianship.ChildID, guardianship.Type, guardianship.StartDate, primaryContact))
+			}
+		}
+		if err != nil {
+			s.rollback(ctx)
+			s.logger.Error("Failed to move guardianship", zap.Error(err), zap.String("guardianship_id", guardianship.ID.String()))
+			return nil, domain.NewDatabaseError("save", "Guardianship", err)
+		}
+	}
+
+	// Get the children the duplicate is the primary contact of
+	children, err := s.childRepo.ListByParentIDs(ctx, []uuid.UUID{duplicateID})
+	if err != nil {
+		s.rollback(ctx)
+		s.logger.Error("Failed to list children", zap.Error(err), zap.String("parent_id", duplicateID.String()))
+		return nil, domain.NewDatabaseError("list", "Child", err)
+	}
+
+	// The survivor becomes their parent
+	changes := make([]*domain.AuditRecord, 0, len(children))
+	for _, child := range children {
+		before := child.AuditSnapshot()
+		child.ParentID = survivorID
+		err = s.childRepo.Update(ctx, child)
+		if err != nil {
+			s.rollback(ctx)
+			s.logger.Error("Failed to update child", zap.Error(err), zap.String("child_id", child.ID.String()))
+			return nil, domain.NewDatabaseError("update", "Child", err)
+		}
+		changes = append(changes, domain.NewAuditRecord("MergeParents", domain.AuditEntityChild, child.ID, before, child.AuditSnapshot()))
+
+		duplicate.RemoveChild(child.ID)
+		survivor.AddChild(*child)
+	}
+
+	// Update the survivor's children
+	err = s.parentRepo.Update(ctx, survivor)
+	if err != nil {
+		s.rollback(ctx)
+		s.logger.Error("Failed to update parent", zap.Error(err), zap.String("parent_id", survivorID.String()))
+		return nil, domain.NewDatabaseError("update", "Parent", err)
+	}
+
+	// Record the survivor the duplicate was merged into, then mark the duplicate as deleted
+	duplicate.MergeInto(survivorID)
+	err = s.parentRepo.Update(ctx, duplicate)
+	if err == nil {
+		err = s.parentRepo.Delete(ctx, duplicateID)
+	}
+	if err != nil {
+		s.rollback(ctx)
+		s.logger.Error("Failed to merge parent", zap.Error(err), zap.String("parent_id", duplicateID.String()))
+		return nil, domain.NewDatabaseError("merge", "Parent", err)
+	}
+	duplicate.MarkAsDeleted()
+
+	// Record the events and the audit records with the changes; the merge is announced to the family
+	// of the duplicate, and the children it took over to the family of the survivor
+	events := []domain.Event{
+		domain.NewParentEvent(domain.EventParentMerged, duplicate),
+		domain.NewParentEvent(domain.EventParentUpdated, survivor),
+	}
+	eventChanges := []*domain.AuditRecord{
+		domain.NewAuditRecord("MergeParents", domain.AuditEntityParent, duplicateID, duplicateBefore, duplicate.AuditSnapshot()),
+		domain.NewAuditRecord("MergeParents", domain.AuditEntityParent, survivorID, survivorBefore, survivor.AuditSnapshot()),
+	}
+	for i, event := range events {
+		if err := s.record(ctx, event, eventChanges[i]); err != nil {
+			s.rollback(ctx)
+			s.logger.Error("Failed to record event", zap.Error(err), zap.String("event_type", string(event.Type)))
+			return nil, domain.NewDatabaseError("record", "Event", err)
+		}
+	}
+	for _, change := range changes {
+		if err := s.audit(ctx, change); err != nil {
+			s.rollback(ctx)
+			s.logger.Error("Failed to record audit record", zap.Error(err))
+			return nil, domain.NewDatabaseError("record", "AuditRecord", err)
+		}
+	}
+
+	// Commit transaction
+	err = s.transactionManager.CommitTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("commit", err)
+	}
+
+	for _, event := range events {
+		s.publish(ctx, event)
+	}
+
+	return survivor, nil
+}
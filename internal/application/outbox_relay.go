@@ -0,0 +1,230 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Default settings of the outbox relay, used for the options that are not set
+const (
+	DefaultOutboxPollInterval   = time.Second
+	DefaultOutboxBatchSize      = 100
+	DefaultOutboxInitialBackoff = time.Second
+	DefaultOutboxMaxBackoff     = 5 * time.Minute
+	DefaultOutboxMaxAttempts    = 25
+	DefaultOutboxLeaseDuration  = time.Minute
+)
+
+// OutboxRelayOptions configures an OutboxRelay
+type OutboxRelayOptions struct {
+	// PollInterval is how long the relay waits for new messages when the outbox is drained
+	PollInterval time.Duration
+
+	// BatchSize is the maximum number of messages read from the outbox at a time
+	BatchSize int
+
+	// InitialBackoff is how long the relay waits before attempting a failed delivery again;
+	// the wait doubles with every further failure, up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// MaxAttempts is the number of failed attempts after which a message is quarantined,
+	// so that the later messages of its family are delivered
+	MaxAttempts int
+
+	// LeaseDuration is how long the messages read from the outbox are reserved to the relay;
+	// it must exceed the time it takes to deliver a batch
+	LeaseDuration time.Duration
+}
+
+// OutboxRelay delivers the domain events recorded in the outbox to a publisher.
+// Delivery is at least once: a message is removed from the outbox only after it has been
+// published, so a message may be published again if the relay stops in between.
+// The events of a family are delivered in the order in which they were recorded: when a
+// message cannot be delivered, the later messages of its family wait until it is, or until it
+// is quarantined after MaxAttempts failed attempts.
+// Several relays may run against an outbox: each leases the families it delivers, so that the
+// messages of a family are delivered by one relay at a time.
+type OutboxRelay struct {
+	outbox    ports.OutboxRepository
+	publisher ports.EventPublisher
+	options   OutboxRelayOptions
+	logger    *zap.Logger
+	tracer    trace.Tracer
+	now       func() time.Time
+}
+
+// NewOutboxRelay creates a new outbox relay.
+// Parameters:
+//   - outbox: The outbox to read the messages from
+//   - publisher: The publisher to deliver the events to
+//   - options: The relay settings; the defaults are used for the settings that are not positive
+//   - logger: Logger for logging relay operations
+//
+// Returns:
+//   - *OutboxRelay: A new outbox relay
+func NewOutboxRelay(outbox ports.OutboxRepository, publisher ports.EventPublisher, options OutboxRelayOptions, logger *zap.Logger) *OutboxRelay {
+	if options.PollInterval <= 0 {
+		options.PollInterval = DefaultOutboxPollInterval
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = DefaultOutboxBatchSize
+	}
+	if options.InitialBackoff <= 0 {
+		options.InitialBackoff = DefaultOutboxInitialBackoff
+	}
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = DefaultOutboxMaxBackoff
+	}
+	if options.MaxBackoff < options.InitialBackoff {
+		options.MaxBackoff = options.InitialBackoff
+	}
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = DefaultOutboxMaxAttempts
+	}
+	if options.LeaseDuration <= 0 {
+		options.LeaseDuration = DefaultOutboxLeaseDuration
+	}
+
+	return &OutboxRelay{
+		outbox:    outbox,
+		publisher: publisher,
+		options:   options,
+		logger:    logger,
+		tracer:    otel.Tracer("application.outbox_relay"),
+		now:       func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Run delivers the messages of the outbox until ctx is done.
+// It relays batches back to back while messages are delivered, and waits for the poll interval otherwise.
+// Parameters:
+//   - ctx: The context that stops the relay when it is done
+func (r *OutboxRelay) Run(ctx context.Context) {
+	r.logger.Info("Starting outbox relay",
+		zap.Duration("poll_interval", r.options.PollInterval),
+		zap.Int("batch_size", r.options.BatchSize))
+
+	for {
+		delivered, err := r.RelayPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			r.logger.Error("Failed to relay outbox messages", zap.Error(err))
+		}
+
+		if delivered > 0 && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			r.logger.Info("Stopping outbox relay")
+			return
+		case <-time.After(r.options.PollInterval):
+		}
+	}
+}
+
+// RelayPending makes a single attempt to deliver a batch of messages of the outbox, which it leases first.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//
+// Returns:
+//   - int: The number of messages that were delivered
+//   - error: An error if the outbox could not be read or updated; the delivery of a message
+//     failing is not an error, but is recorded for a later attempt, or quarantines the message
+//     once it has failed MaxAttempts times
+func (r *OutboxRelay) RelayPending(ctx context.Context) (int, error) {
+	ctx, span := r.tracer.Start(ctx, "OutboxRelay.RelayPending")
+	defer span.End()
+
+	now := r.now()
+	messages, err := r.outbox.LeasePending(ctx, r.options.BatchSize, now, now.Add(r.options.LeaseDuration))
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+
+	// The families with a message that is not delivered in this batch
+	blocked := make(map[uuid.UUID]bool)
+
+	for _, message := range messages {
+		if err := ctx.Err(); err != nil {
+			return delivered, err
+		}
+
+		family := message.Event.ParentID
+		if blocked[family] {
+			continue
+		}
+
+		if message.NextAttemptAt.After(now) {
+			blocked[family] = true
+			continue
+		}
+
+		if err := r.publisher.Publish(ctx, message.Event); err != nil {
+			if message.Attempts+1 >= r.options.MaxAttempts {
+				r.logger.Error("Failed to deliver outbox message, quarantining it",
+					zap.Error(err),
+					zap.Int64("sequence", message.Sequence),
+					zap.String("event_type", string(message.Event.Type)),
+					zap.Int("attempts", message.Attempts+1))
+
+				reason := fmt.Sprintf("delivery failed %d times: %s", message.Attempts+1, err.Error())
+				if err := r.outbox.Quarantine(ctx, message.Sequence, reason); err != nil {
+					return delivered, err
+				}
+				continue
+			}
+
+			blocked[family] = true
+
+			backoff := r.backoff(message.Attempts + 1)
+			r.logger.Warn("Failed to deliver outbox message, will retry",
+				zap.Error(err),
+				zap.Int64("sequence", message.Sequence),
+				zap.String("event_type", string(message.Event.Type)),
+				zap.Int("attempts", message.Attempts+1),
+				zap.Duration("backoff", backoff))
+
+			if err := r.outbox.MarkFailed(ctx, message.Sequence, now.Add(backoff), err.Error()); err != nil {
+				return delivered, err
+			}
+			continue
+		}
+
+		// The message is delivered again if it cannot be removed
+		if err := r.outbox.Acknowledge(ctx, message.Sequence); err != nil {
+			return delivered, err
+		}
+		delivered++
+	}
+
+	span.SetAttributes(
+		attribute.Int("outbox.fetched", len(messages)),
+		attribute.Int("outbox.delivered", delivered),
+	)
+
+	return delivered, nil
+}
+
+// backoff returns how long to wait before the next attempt to deliver a message that failed the given number of times
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	backoff := r.options.InitialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= r.options.MaxBackoff {
+			return r.options.MaxBackoff
+		}
+	}
+	return backoff
+}
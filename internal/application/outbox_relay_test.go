@@ -0,0 +1,227 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/application"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/mocks"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// setupOutboxRelayTest creates a relay between a mock outbox and a mock broker
+func setupOutboxRelayTest(t *testing.T) (*application.OutboxRelay, *mocks.MockOutboxRepository, *mocks.MockEventBroker) {
+	t.Helper()
+
+	outbox := mocks.NewMockOutboxRepository()
+	broker := mocks.NewMockEventBroker()
+	relay := application.NewOutboxRelay(outbox, broker, application.OutboxRelayOptions{
+		PollInterval:   10 * time.Millisecond,
+		BatchSize:      10,
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Hour,
+		MaxAttempts:    100,
+	}, zaptest.NewLogger(t))
+
+	return relay, outbox, broker
+}
+
+func TestOutboxRelay_DeliversInOrder(t *testing.T) {
+	// Arrange
+	relay, outbox, broker := setupOutboxRelayTest(t)
+	ctx := context.Background()
+
+	parentID := uuid.New()
+	first := domain.NewEvent(domain.EventParentCreated, parentID, uuid.Nil)
+	second := domain.NewEvent(domain.EventChildCreated, parentID, uuid.New())
+	require.NoError(t, outbox.Append(ctx, first))
+	require.NoError(t, outbox.Append(ctx, second))
+
+	// Act
+	delivered, err := relay.RelayPending(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, delivered)
+	events := broker.Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, first.ID, events[0].ID)
+	assert.Equal(t, second.ID, events[1].ID)
+	assert.Empty(t, outbox.Messages())
+}
+
+func TestOutboxRelay_FailureHoldsBackFamily(t *testing.T) {
+	// Arrange
+	relay, outbox, broker := setupOutboxRelayTest(t)
+	ctx := context.Background()
+
+	failingFamily := uuid.New()
+	otherFamily := uuid.New()
+	failing := domain.NewEvent(domain.EventParentCreated, failingFamily, uuid.Nil)
+	later := domain.NewEvent(domain.EventParentUpdated, failingFamily, uuid.Nil)
+	other := domain.NewEvent(domain.EventParentCreated, otherFamily, uuid.Nil)
+	require.NoError(t, outbox.Append(ctx, failing))
+	require.NoError(t, outbox.Append(ctx, later))
+	require.NoError(t, outbox.Append(ctx, other))
+
+	broker.PublishFunc = func(ctx context.Context, event domain.Event) error {
+		if event.ID == failing.ID {
+			return errors.New("broker unavailable")
+		}
+		return nil
+	}
+
+	// Act
+	delivered, err := relay.RelayPending(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+
+	messages := outbox.Messages()
+	require.Len(t, messages, 2)
+	assert.Equal(t, failing.ID, messages[0].Event.ID)
+	assert.Equal(t, 1, messages[0].Attempts)
+	assert.Equal(t, "broker unavailable", messages[0].LastError)
+	assert.True(t, messages[0].NextAttemptAt.After(time.Now().Add(59*time.Second)))
+	assert.Equal(t, later.ID, messages[1].Event.ID)
+	assert.Equal(t, 0, messages[1].Attempts)
+
+	// The later event of the failing family waits while its earlier event is not due
+	broker.PublishFunc = nil
+	delivered, err = relay.RelayPending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.Len(t, outbox.Messages(), 2)
+}
+
+func TestOutboxRelay_WaitingFamilyDoesNotStallOthers(t *testing.T) {
+	// Arrange: a family waiting for its next attempt has more messages than a batch holds
+	relay, outbox, broker := setupOutboxRelayTest(t)
+	ctx := context.Background()
+
+	waitingFamily := uuid.New()
+	failing := domain.NewEvent(domain.EventParentCreated, waitingFamily, uuid.Nil)
+	require.NoError(t, outbox.Append(ctx, failing))
+	for i := 0; i < 10; i++ {
+		require.NoError(t, outbox.Append(ctx, domain.NewEvent(domain.EventParentUpdated, waitingFamily, uuid.Nil)))
+	}
+	other := domain.NewEvent(domain.EventParentCreated, uuid.New(), uuid.Nil)
+	require.NoError(t, outbox.Append(ctx, other))
+	require.NoError(t, outbox.MarkFailed(ctx, 1, time.Now().Add(time.Hour), "broker unavailable"))
+
+	// Act
+	delivered, err := relay.RelayPending(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	events := broker.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, other.ID, events[0].ID)
+	assert.Len(t, outbox.Messages(), 11)
+}
+
+func TestOutboxRelay_QuarantinesAfterMaxAttempts(t *testing.T) {
+	// Arrange
+	relay, outbox, broker := setupOutboxRelayTest(t)
+	ctx := context.Background()
+
+	parentID := uuid.New()
+	failing := domain.NewEvent(domain.EventParentCreated, parentID, uuid.Nil)
+	later := domain.NewEvent(domain.EventParentUpdated, parentID, uuid.Nil)
+	require.NoError(t, outbox.Append(ctx, failing))
+	require.NoError(t, outbox.Append(ctx, later))
+	for i := 0; i < 99; i++ {
+		require.NoError(t, outbox.MarkFailed(ctx, 1, time.Now().Add(-time.Second), "broker unavailable"))
+	}
+
+	broker.PublishFunc = func(ctx context.Context, event domain.Event) error {
+		if event.ID == failing.ID {
+			return errors.New("event rejected")
+		}
+		return nil
+	}
+
+	// Act
+	delivered, err := relay.RelayPending(ctx)
+
+	// Assert: the message is quarantined on its last attempt, and the later message of its family is delivered
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	events := broker.Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, later.ID, events[1].ID)
+	assert.Empty(t, outbox.Messages())
+	deadLetters := outbox.DeadLetters()
+	require.Contains(t, deadLetters, int64(1))
+	assert.Contains(t, deadLetters[1], "event rejected")
+}
+
+func TestOutboxRelay_BackoffGrowsUpToMaximum(t *testing.T) {
+	// Arrange
+	relay, outbox, broker := setupOutboxRelayTest(t)
+	ctx := context.Background()
+
+	broker.PublishFunc = func(ctx context.Context, event domain.Event) error {
+		return errors.New("broker unavailable")
+	}
+	event := domain.NewEvent(domain.EventParentCreated, uuid.New(), uuid.Nil)
+
+	// Act: fail a message that has already failed a given number of times
+	backoffAfter := func(attempts int) time.Duration {
+		outbox.LeasePendingFunc = func(ctx context.Context, limit int, now, leaseUntil time.Time) ([]ports.OutboxMessage, error) {
+			return []ports.OutboxMessage{{Sequence: 1, Event: event, Attempts: attempts}}, nil
+		}
+		var nextAttemptAt time.Time
+		outbox.MarkFailedFunc = func(ctx context.Context, sequence int64, next time.Time, lastError string) error {
+			nextAttemptAt = next
+			return nil
+		}
+
+		before := time.Now()
+		_, err := relay.RelayPending(ctx)
+		require.NoError(t, err)
+		return nextAttemptAt.Sub(before).Round(time.Minute)
+	}
+
+	// Assert
+	assert.Equal(t, time.Minute, backoffAfter(0))
+	assert.Equal(t, 2*time.Minute, backoffAfter(1))
+	assert.Equal(t, 32*time.Minute, backoffAfter(5))
+	assert.Equal(t, time.Hour, backoffAfter(6))
+	assert.Equal(t, time.Hour, backoffAfter(50))
+}
+
+func TestOutboxRelay_RunStopsWhenContextIsDone(t *testing.T) {
+	// Arrange
+	relay, outbox, broker := setupOutboxRelayTest(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	require.NoError(t, outbox.Append(ctx, domain.NewEvent(domain.EventParentCreated, uuid.New(), uuid.Nil)))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		relay.Run(ctx)
+	}()
+
+	// Act
+	require.Eventually(t, func() bool { return len(broker.Events()) == 1 }, time.Second, 10*time.Millisecond)
+	cancel()
+
+	// Assert
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("relay did not stop")
+	}
+	assert.Empty(t, outbox.Messages())
+}
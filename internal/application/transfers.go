@@ -0,0 +1,183 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// TransferChild moves a child from one parent to another in a single transaction: the guardianship of
+// the parent the child leaves ends, and the parent the child joins becomes a guardian of the same type,
+// taking over as primary contact, and as the child's parent, when the parent it replaces was.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - childID: The unique identifier of the child to transfer
+//   - fromParentID: The unique identifier of the parent the child leaves, which must be an active guardian of the child
+//   - toParentID: The unique identifier of the parent the child joins
+//   - reason: Why the child is transferred, recorded with the event and the audit record
+//
+// Returns:
+//   - *domain.Child: The transferred child
+//   - error: A validation error if the parents are the same, the reason is missing or the child does not leave
+//     one of its guardians, a not found error if the child or a parent doesn't exist, or a database error
+func (s *FamilyService) TransferChild(ctx context.Context, childID, fromParentID, toParentID uuid.UUID, reason string) (*domain.Child, error) {
+	ctx, span := s.tracer.Start(ctx, "FamilyService.TransferChild")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("child.id", childID.String()),
+		attribute.String("parent.from_id", fromParentID.String()),
+		attribute.String("parent.to_id", toParentID.String()),
+	)
+
+	// Validate input
+	if fromParentID == toParentID {
+		return nil, domain.NewValidationError("Child", "toParentId", "must be another parent than the parent the child leaves")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, domain.NewValidationError("Child", "reason", "is required")
+	}
+
+	// Begin transaction
+	ctx, err := s.transactionManager.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("begin", err)
+	}
+
+	// Get the parent the child leaves
+	from, err := s.parentRepo.GetByID(ctx, fromParentID)
+	if err != nil {
+		s.rollback(ctx)
+		s.logger.Error("Failed to get parent", zap.Error(err), zap.String("parent_id", fromParentID.String()))
+		return nil, domain.NewNotFoundError("Parent", fromParentID.String())
+	}
+
+	// Get the parent the child joins
+	to, err := s.parentRepo.GetByID(ctx, toParentID)
+	if err != nil {
+		s.rollback(ctx)
+		s.logger.Error("Failed to get parent", zap.Error(err), zap.String("parent_id", toParentID.String()))
+		return nil, domain.NewNotFoundError("Parent", toParentID.String())
+	}
+
+	// Get child
+	child, err := s.childRepo.GetByID(ctx, childID)
+	if err != nil {
+		s.rollback(ctx)
+		s.logger.Error("Failed to get child", zap.Error(err), zap.String("child_id", childID.String()))
+		return nil, domain.NewNotFoundError("Child", childID.String())
+	}
+
+	// Get the guardianships of the child
+	guardianships, err := s.guardianshipRepo.ListByChildIDs(ctx, []uuid.UUID{childID})
+	if err != nil {
+		s.rollback(ctx)
+		s.logger.Error("Failed to list guardianships", zap.Error(err), zap.String("child_id", childID.String()))
+		return nil, domain.NewDatabaseError("list", "Guardianship", err)
+	}
+	before := child.GuardiansSnapshot(guardianships)
+
+	// Find the active guardianships of both parents; the child must leave one of its guardians
+	var source, target *domain.Guardianship
+	for _, active := range domain.ActiveGuardianships(guardianships) {
+		switch active.ParentID {
+		case fromParentID:
+			source = active
+		case toParentID:
+			target = active
+		}
+	}
+	if source == nil {
+		s.rollback(ctx)
+		s.logger.Error("Child not found in parent", zap.String("parent_id", fromParentID.String()), zap.String("child_id", childID.String()))
+		return nil, domain.NewValidationError("Child", "fromParentId", "is not a guardian of the child")
+	}
+
+	// End the guardianship of the parent the child leaves; it steps down as primary contact
+	// before the parent the child joins takes over
+	wasPrimary := source.PrimaryContact
+	source.End()
+	err = s.guardianshipRepo.Update(ctx, source)
+	if err != nil {
+		s.rollback(ctx)
+		s.logger.Error("Failed to update guardianship", zap.Error(err), zap.String("guardianship_id", source.ID.String()))
+		return nil, domain.NewDatabaseError("update", "Guardianship", err)
+	}
+
+	// The parent the child joins becomes a guardian of the same type, unless it already is one
+	if target == nil {
+		target = domain.NewGuardianship(toParentID, childID, source.Type, time.Now().UTC(), wasPrimary)
+		guardianships = append(guardianships, target)
+		err = s.guardianshipRepo.Create(ctx, target)
+	} else if wasPrimary {
+		target.SetPrimaryContact(true)
+		err = s.guardianshipRepo.Update(ctx, target)
+	}
+	if err != nil {
+		s.rollback(ctx)
+		s.logger.Error("Failed to save guardianship", zap.Error(err), zap.String("parent_id", toParentID.String()), zap.String("child_id", childID.String()))
+		return nil, domain.NewDatabaseError("save", "Guardianship", err)
+	}
+
+	// The child's parent is its primary contact
+	if wasPrimary {
+		child.ParentID = toParentID
+		err = s.childRepo.Update(ctx, child)
+		if err != nil {
+			s.rollback(ctx)
+			s.logger.Error("Failed to update child", zap.Error(err), zap.String("child_id", childID.String()))
+			return nil, domain.NewDatabaseError("update", "Child", err)
+		}
+
+		// Add child to the parent it joins
+		to.AddChild(*child)
+		err = s.parentRepo.Update(ctx, to)
+		if err != nil {
+			s.rollback(ctx)
+			s.logger.Error("Failed to update parent", zap.Error(err), zap.String("parent_id", toParentID.String()))
+			return nil, domain.NewDatabaseError("update", "Parent", err)
+		}
+	}
+
+	// Remove child from the parent it leaves
+	if from.RemoveChild(childID) {
+		err = s.parentRepo.Update(ctx, from)
+		if err != nil {
+			s.rollback(ctx)
+			s.logger.Error("Failed to update parent", zap.Error(err), zap.String("parent_id", fromParentID.String()))
+			return nil, domain.NewDatabaseError("update", "Parent", err)
+		}
+	}
+
+	// Record the event and the audit record with the change and its reason; the event belongs to the family
+	// the child joins, and names the family it left
+	event := domain.NewChildEvent(domain.EventChildTransferred, child)
+	event.ParentID = toParentID
+	event.PreviousParentID = &fromParentID
+	event.Reason = reason
+	change := domain.NewAuditRecord("TransferChild", domain.AuditEntityChild, child.ID, before, child.GuardiansSnapshot(guardianships))
+	change.Reason = reason
+	if err := s.record(ctx, event, change); err != nil {
+		s.rollback(ctx)
+		s.logger.Error("Failed to record event", zap.Error(err), zap.String("event_type", string(event.Type)))
+		return nil, domain.NewDatabaseError("record", "Event", err)
+	}
+
+	// Commit transaction
+	err = s.transactionManager.CommitTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, domain.NewTransactionError("commit", err)
+	}
+
+	s.publish(ctx, event)
+
+	return child, nil
+}
@@ -0,0 +1,92 @@
+package application
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/go-playground/validator/v10"
+)
+
+// e164 matches a phone number in E.164 format: a plus sign and up to fifteen digits, the first of
+// which is a country code and therefore not zero
+var e164 = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// RegisterValidations registers the validation rules of the domain entities with the given validator:
+//   - phone: the field is a phone number in E.164 format
+//   - phone_type: the field is a known domain.PhoneType
+//
+// and checks that the emergency contacts of contact details are reachable at another number than the
+// phones of the contact details themselves. Registering the rules again replaces them.
+// Parameters:
+//   - validate: The validator to register the rules with
+//
+// Returns:
+//   - error: An error if a rule cannot be registered
+func RegisterValidations(validate *validator.Validate) error {
+	if err := validate.RegisterValidation("phone", func(fl validator.FieldLevel) bool {
+		return e164.MatchString(fl.Field().String())
+	}); err != nil {
+		return err
+	}
+
+	if err := validate.RegisterValidation("phone_type", func(fl validator.FieldLevel) bool {
+		return domain.PhoneType(fl.Field().String()).IsValid()
+	}); err != nil {
+		return err
+	}
+
+	validate.RegisterStructValidation(func(sl validator.StructLevel) {
+		contact := sl.Current().Interface().(domain.ContactDetails)
+		for _, emergencyContact := range contact.EmergencyContacts {
+			if contact.HasPhone(emergencyContact.Phone.Number) {
+				sl.ReportError(emergencyContact.Phone.Number, "EmergencyContacts", "emergencyContacts", "other_phone", "")
+			}
+		}
+	}, domain.ContactDetails{})
+
+	return nil
+}
+
+// contactValidationError returns a ValidationError of the given entity for the first invalid phone number,
+// address or emergency contact reported in err, naming the field the way the API does, or nil if err
+// reports none of them.
+func contactValidationError(entity string, err error) error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil
+	}
+
+	for _, fieldError := range validationErrors {
+		namespace := fieldError.Namespace()
+		field := ""
+		switch {
+		case strings.Contains(namespace, ".EmergencyContacts"):
+			field = "emergencyContacts"
+		case strings.Contains(namespace, ".Phones"):
+			field = "phones"
+		case strings.Contains(namespace, ".PhoneNumbers"):
+			field = "phoneNumbers"
+		case strings.Contains(namespace, ".Address"):
+			field = "address"
+		default:
+			continue
+		}
+
+		switch fieldError.Tag() {
+		case "phone":
+			return domain.NewValidationError(entity, field, "must be a phone number in E.164 format, such as +12175550100")
+		case "phone_type":
+			return domain.NewValidationError(entity, field, "has an unknown phone type")
+		case "other_phone":
+			return domain.NewValidationError(entity, field, "must be reachable at another number than the parent")
+		case "iso3166_1_alpha2":
+			return domain.NewValidationError(entity, field, "must have a two-letter ISO 3166 country code")
+		default:
+			return domain.NewValidationError(entity, field, fieldError.Field()+" is "+fieldError.Tag())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,339 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// WebhookEnqueuer implements the ports.EventPublisher interface by enqueuing a delivery of every
+// event to the webhook subscriptions of its tenant that subscribe to its type.
+// Enqueuing an event twice enqueues a single delivery per subscription, so the enqueuer can be fed
+// by an at-least-once source such as the outbox relay.
+type WebhookEnqueuer struct {
+	webhooks ports.WebhookRepository
+	logger   *zap.Logger
+	tracer   trace.Tracer
+}
+
+// NewWebhookEnqueuer creates a new webhook enqueuer.
+// Parameters:
+//   - webhooks: Repository for webhook subscriptions and deliveries
+//   - logger: Logger for logging enqueuer operations
+//
+// Returns:
+//   - *WebhookEnqueuer: A new webhook enqueuer
+func NewWebhookEnqueuer(webhooks ports.WebhookRepository, logger *zap.Logger) *WebhookEnqueuer {
+	return &WebhookEnqueuer{
+		webhooks: webhooks,
+		logger:   logger,
+		tracer:   otel.Tracer("application.webhook_enqueuer"),
+	}
+}
+
+// Publish enqueues a delivery of the event to each matching subscription of the event's tenant.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - event: The event to deliver
+//
+// Returns:
+//   - error: An error if the subscriptions could not be read or a delivery could not be enqueued
+func (e *WebhookEnqueuer) Publish(ctx context.Context, event domain.Event) error {
+	ctx, span := e.tracer.Start(ctx, "WebhookEnqueuer.Publish")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("event.id", event.ID.String()),
+		attribute.String("event.type", string(event.Type)),
+	)
+
+	// The event is delivered to the subscriptions of its own tenant
+	ctx = ports.WithTenantID(ctx, event.TenantID)
+
+	subscriptions, err := e.webhooks.ListSubscriptions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	var payload []byte
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(event.Type) {
+			continue
+		}
+
+		if payload == nil {
+			if payload, err = json.Marshal(event); err != nil {
+				return fmt.Errorf("failed to encode event: %w", err)
+			}
+		}
+
+		delivery := domain.NewWebhookDelivery(subscription, event, string(payload))
+		if err := e.webhooks.EnqueueDelivery(ctx, delivery); err != nil {
+			return fmt.Errorf("failed to enqueue webhook delivery: %w", err)
+		}
+
+		e.logger.Debug("Webhook delivery enqueued",
+			zap.String("subscription_id", subscription.ID.String()),
+			zap.String("event_id", event.ID.String()),
+			zap.String("event_type", string(event.Type)))
+	}
+
+	return nil
+}
+
+// Consume enqueues the events received from the subscriber until ctx is done.
+// It is used when the events are not recorded in an outbox, in which case an event published
+// while the service is stopping may be missed.
+// Parameters:
+//   - ctx: The context that stops consuming when it is done
+//   - subscriber: The source of the events
+//
+// Returns:
+//   - error: An error if the subscription could not be made
+func (e *WebhookEnqueuer) Consume(ctx context.Context, subscriber ports.EventSubscriber) error {
+	events, err := subscriber.Subscribe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to events: %w", err)
+	}
+
+	for event := range events {
+		if err := e.Publish(ctx, event); err != nil && ctx.Err() == nil {
+			e.logger.Error("Failed to enqueue webhook deliveries",
+				zap.Error(err),
+				zap.String("event_id", event.ID.String()),
+				zap.String("event_type", string(event.Type)))
+		}
+	}
+
+	return nil
+}
+
+// Ensure WebhookEnqueuer implements ports.EventPublisher
+var _ ports.EventPublisher = (*WebhookEnqueuer)(nil)
+
+// Default settings of the webhook dispatcher, used for the options that are not set
+const (
+	DefaultWebhookPollInterval   = time.Second
+	DefaultWebhookBatchSize      = 100
+	DefaultWebhookConcurrency    = 8
+	DefaultWebhookInitialBackoff = 10 * time.Second
+	DefaultWebhookMaxBackoff     = time.Hour
+	DefaultWebhookMaxAttempts    = 10
+)
+
+// WebhookDispatcherOptions configures a WebhookDispatcher
+type WebhookDispatcherOptions struct {
+	// PollInterval is how long the dispatcher waits for new deliveries when none are due
+	PollInterval time.Duration
+
+	// BatchSize is the maximum number of deliveries read at a time
+	BatchSize int
+
+	// Concurrency is the maximum number of deliveries attempted at the same time
+	Concurrency int
+
+	// InitialBackoff is how long the dispatcher waits before attempting a failed delivery again;
+	// the wait doubles with every further failure, up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// MaxAttempts is the number of failed attempts after which a delivery is moved to the dead letters
+	MaxAttempts int
+}
+
+// WebhookDispatcher makes the pending webhook deliveries that are due.
+// A failed delivery is attempted again with exponential backoff until it succeeds or has failed
+// MaxAttempts times, when it is moved to the dead letters.
+// Delivery is at least once: a delivery whose outcome cannot be stored is made again.
+// A single dispatcher should run against a database; several dispatchers would make deliveries twice.
+type WebhookDispatcher struct {
+	webhooks ports.WebhookRepository
+	sender   ports.WebhookSender
+	options  WebhookDispatcherOptions
+	logger   *zap.Logger
+	tracer   trace.Tracer
+	now      func() time.Time
+}
+
+// NewWebhookDispatcher creates a new webhook dispatcher.
+// Parameters:
+//   - webhooks: Repository for webhook subscriptions and deliveries
+//   - sender: The sender that makes the deliveries
+//   - options: The dispatcher settings; the defaults are used for the settings that are not positive
+//   - logger: Logger for logging dispatcher operations
+//
+// Returns:
+//   - *WebhookDispatcher: A new webhook dispatcher
+func NewWebhookDispatcher(webhooks ports.WebhookRepository, sender ports.WebhookSender, options WebhookDispatcherOptions, logger *zap.Logger) *WebhookDispatcher {
+	if options.PollInterval <= 0 {
+		options.PollInterval = DefaultWebhookPollInterval
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = DefaultWebhookBatchSize
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = DefaultWebhookConcurrency
+	}
+	if options.InitialBackoff <= 0 {
+		options.InitialBackoff = DefaultWebhookInitialBackoff
+	}
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = DefaultWebhookMaxBackoff
+	}
+	if options.MaxBackoff < options.InitialBackoff {
+		options.MaxBackoff = options.InitialBackoff
+	}
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = DefaultWebhookMaxAttempts
+	}
+
+	return &WebhookDispatcher{
+		webhooks: webhooks,
+		sender:   sender,
+		options:  options,
+		logger:   logger,
+		tracer:   otel.Tracer("application.webhook_dispatcher"),
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Run makes the due deliveries until ctx is done.
+// It dispatches batches back to back while a batch is full, and waits for the poll interval otherwise.
+// Parameters:
+//   - ctx: The context that stops the dispatcher when it is done
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	d.logger.Info("Starting webhook dispatcher",
+		zap.Duration("poll_interval", d.options.PollInterval),
+		zap.Int("batch_size", d.options.BatchSize),
+		zap.Int("max_attempts", d.options.MaxAttempts))
+
+	for {
+		attempted, err := d.DispatchDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			d.logger.Error("Failed to dispatch webhook deliveries", zap.Error(err))
+		}
+
+		if attempted == d.options.BatchSize && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			d.logger.Info("Stopping webhook dispatcher")
+			return
+		case <-time.After(d.options.PollInterval):
+		}
+	}
+}
+
+// DispatchDue makes a single attempt at a batch of the deliveries that are due.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//
+// Returns:
+//   - int: The number of deliveries attempted
+//   - error: An error if the deliveries could not be read, or the outcome of an attempt could not
+//     be stored; a delivery failing is not an error, but is recorded for a later attempt
+func (d *WebhookDispatcher) DispatchDue(ctx context.Context) (int, error) {
+	ctx, span := d.tracer.Start(ctx, "WebhookDispatcher.DispatchDue")
+	defer span.End()
+
+	deliveries, err := d.webhooks.FetchDueDeliveries(ctx, d.now(), d.options.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	slots := make(chan struct{}, d.options.Concurrency)
+
+	for _, delivery := range deliveries {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			if err := d.dispatch(ctx, delivery); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	span.SetAttributes(attribute.Int("webhook.attempted", len(deliveries)))
+
+	return len(deliveries), firstErr
+}
+
+// dispatch makes an attempt at a delivery and stores its outcome
+func (d *WebhookDispatcher) dispatch(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	// The subscription and the delivery belong to the delivery's tenant
+	ctx = ports.WithTenantID(ctx, delivery.TenantID)
+
+	subscription, err := d.webhooks.GetSubscription(ctx, delivery.SubscriptionID)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook subscription %s: %w", delivery.SubscriptionID, err)
+	}
+
+	status, err := d.sender.Send(ctx, subscription, delivery)
+	if err == nil {
+		delivery.MarkDelivered(status)
+	} else {
+		backoff := d.backoff(delivery.Attempts + 1)
+		delivery.MarkFailed(status, err.Error(), d.now().Add(backoff))
+
+		if delivery.Attempts >= d.options.MaxAttempts {
+			delivery.MarkDeadLetter("")
+			d.logger.Warn("Webhook delivery failed too many times, moved to dead letters",
+				zap.Error(err),
+				zap.String("delivery_id", delivery.ID.String()),
+				zap.String("subscription_id", subscription.ID.String()),
+				zap.Int("attempts", delivery.Attempts))
+		} else {
+			d.logger.Warn("Webhook delivery failed, will retry",
+				zap.Error(err),
+				zap.String("delivery_id", delivery.ID.String()),
+				zap.String("subscription_id", subscription.ID.String()),
+				zap.Int("attempts", delivery.Attempts),
+				zap.Duration("backoff", backoff))
+		}
+	}
+
+	// The delivery is made again if its outcome cannot be stored
+	if err := d.webhooks.UpdateDelivery(ctx, delivery); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// backoff returns how long to wait before the next attempt at a delivery that failed the given number of times
+func (d *WebhookDispatcher) backoff(attempts int) time.Duration {
+	backoff := d.options.InitialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= d.options.MaxBackoff {
+			return d.options.MaxBackoff
+		}
+	}
+	return backoff
+}
@@ -0,0 +1,276 @@
+package application_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/webhook"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/application"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/mocks"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+const testWebhookSecret = "0123456789abcdef"
+
+// stubSender is a webhook sender that responds with the given status, failing unless it is 2xx
+type stubSender struct {
+	mu     sync.Mutex
+	status int
+	sent   []*domain.WebhookDelivery
+}
+
+func (s *stubSender) Send(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sent = append(s.sent, delivery)
+	if s.status < 200 || s.status > 299 {
+		return s.status, errors.New("webhook receiver responded with an error")
+	}
+	return s.status, nil
+}
+
+// subscribe creates a subscription of the tenant to the event types
+func subscribe(t *testing.T, webhooks ports.WebhookRepository, tenantID, url string, eventTypes ...domain.EventType) *domain.WebhookSubscription {
+	t.Helper()
+
+	subscription := domain.NewWebhookSubscription(url, eventTypes, testWebhookSecret)
+	require.NoError(t, webhooks.CreateSubscription(ports.WithTenantID(context.Background(), tenantID), subscription))
+	return subscription
+}
+
+// tenantEvent creates an event of the type in the tenant
+func tenantEvent(eventType domain.EventType, tenantID string) domain.Event {
+	event := domain.NewEvent(eventType, uuid.New(), uuid.New())
+	event.TenantID = tenantID
+	return event
+}
+
+func TestWebhookEnqueuer_EnqueuesMatchingSubscriptions(t *testing.T) {
+	// Arrange
+	webhooks := mocks.NewMockWebhookRepository()
+	enqueuer := application.NewWebhookEnqueuer(webhooks, zaptest.NewLogger(t))
+
+	created := subscribe(t, webhooks, "tenant-a", "https://a.example.com/created", domain.EventChildCreated)
+	subscribe(t, webhooks, "tenant-a", "https://a.example.com/deleted", domain.EventChildDeleted)
+	subscribe(t, webhooks, "tenant-b", "https://b.example.com/created", domain.EventChildCreated)
+
+	event := tenantEvent(domain.EventChildCreated, "tenant-a")
+
+	// Act; enqueuing the event again, as a retrying relay would, enqueues nothing more
+	require.NoError(t, enqueuer.Publish(context.Background(), event))
+	require.NoError(t, enqueuer.Publish(context.Background(), event))
+
+	// Assert
+	deliveries := webhooks.Deliveries()
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, created.ID, deliveries[0].SubscriptionID)
+	assert.Equal(t, "tenant-a", deliveries[0].TenantID)
+	assert.Equal(t, event.ID, deliveries[0].EventID)
+	assert.Equal(t, domain.DeliveryPending, deliveries[0].Status)
+
+	var payload domain.Event
+	require.NoError(t, json.Unmarshal([]byte(deliveries[0].Payload), &payload))
+	assert.Equal(t, event.ID, payload.ID)
+	assert.Equal(t, event.ChildID, payload.ChildID)
+}
+
+func TestWebhookEnqueuer_Consume(t *testing.T) {
+	// Arrange
+	webhooks := mocks.NewMockWebhookRepository()
+	enqueuer := application.NewWebhookEnqueuer(webhooks, zaptest.NewLogger(t))
+	subscribe(t, webhooks, "tenant-a", "https://a.example.com/hooks", domain.EventChildCreated)
+
+	events := make(chan domain.Event, 1)
+	broker := mocks.NewMockEventBroker()
+	broker.SubscribeFunc = func(ctx context.Context) (<-chan domain.Event, error) {
+		return events, nil
+	}
+
+	// Act
+	events <- tenantEvent(domain.EventChildCreated, "tenant-a")
+	close(events)
+	err := enqueuer.Consume(context.Background(), broker)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, webhooks.Deliveries(), 1)
+}
+
+// setupWebhookDispatcherTest creates a dispatcher on a mock repository holding a delivery
+// of an event to a subscription
+func setupWebhookDispatcherTest(t *testing.T, sender ports.WebhookSender, options application.WebhookDispatcherOptions) (*application.WebhookDispatcher, *mocks.MockWebhookRepository) {
+	t.Helper()
+
+	webhooks := mocks.NewMockWebhookRepository()
+	subscription := subscribe(t, webhooks, "tenant-a", "https://a.example.com/hooks", domain.EventChildCreated)
+	enqueuer := application.NewWebhookEnqueuer(webhooks, zaptest.NewLogger(t))
+	require.NoError(t, enqueuer.Publish(context.Background(), tenantEvent(subscription.EventTypes[0], "tenant-a")))
+
+	return application.NewWebhookDispatcher(webhooks, sender, options, zaptest.NewLogger(t)), webhooks
+}
+
+func TestWebhookDispatcher_Delivers(t *testing.T) {
+	// Arrange
+	sender := &stubSender{status: http.StatusOK}
+	dispatcher, webhooks := setupWebhookDispatcherTest(t, sender, application.WebhookDispatcherOptions{})
+
+	// Act
+	attempted, err := dispatcher.DispatchDue(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+	deliveries := webhooks.Deliveries()
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, domain.DeliveryDelivered, deliveries[0].Status)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusOK, deliveries[0].ResponseStatus)
+	assert.NotNil(t, deliveries[0].DeliveredAt)
+
+	// A delivered delivery is not attempted again
+	attempted, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, attempted)
+}
+
+func TestWebhookDispatcher_RetriesWithBackoff(t *testing.T) {
+	// Arrange
+	sender := &stubSender{status: http.StatusServiceUnavailable}
+	dispatcher, webhooks := setupWebhookDispatcherTest(t, sender, application.WebhookDispatcherOptions{
+		InitialBackoff: time.Minute,
+		MaxBackoff:     3 * time.Minute,
+	})
+
+	// Act
+	before := time.Now()
+	_, err := dispatcher.DispatchDue(context.Background())
+
+	// Assert the delivery waits for the initial backoff
+	require.NoError(t, err)
+	delivery := webhooks.Deliveries()[0]
+	assert.Equal(t, domain.DeliveryPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, delivery.ResponseStatus)
+	assert.NotEmpty(t, delivery.LastError)
+	assert.WithinDuration(t, before.Add(time.Minute), delivery.NextAttemptAt, 5*time.Second)
+
+	// A delivery that is not due is not attempted
+	attempted, err := dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, attempted)
+
+	// Act; make the delivery due again after a second failure
+	delivery.Attempts = 2
+	delivery.NextAttemptAt = time.Now().Add(-time.Second)
+	require.NoError(t, webhooks.UpdateDelivery(context.Background(), delivery))
+	before = time.Now()
+	_, err = dispatcher.DispatchDue(context.Background())
+
+	// Assert the backoff doubles up to the maximum
+	require.NoError(t, err)
+	delivery = webhooks.Deliveries()[0]
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.WithinDuration(t, before.Add(3*time.Minute), delivery.NextAttemptAt, 5*time.Second)
+}
+
+func TestWebhookDispatcher_DeadLetterAfterMaxAttempts(t *testing.T) {
+	// Arrange
+	sender := &stubSender{status: http.StatusInternalServerError}
+	dispatcher, webhooks := setupWebhookDispatcherTest(t, sender, application.WebhookDispatcherOptions{
+		InitialBackoff: time.Nanosecond,
+		MaxAttempts:    3,
+	})
+
+	// Act
+	for i := 0; i < 5; i++ {
+		_, err := dispatcher.DispatchDue(context.Background())
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond)
+	}
+
+	// Assert
+	delivery := webhooks.Deliveries()[0]
+	assert.Equal(t, domain.DeliveryDeadLetter, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Equal(t, http.StatusInternalServerError, delivery.ResponseStatus)
+	assert.Len(t, sender.sent, 3)
+}
+
+func TestWebhookDispatcher_StoreFailureIsReported(t *testing.T) {
+	// Arrange
+	sender := &stubSender{status: http.StatusOK}
+	dispatcher, webhooks := setupWebhookDispatcherTest(t, sender, application.WebhookDispatcherOptions{})
+	webhooks.UpdateDeliveryFunc = func(ctx context.Context, delivery *domain.WebhookDelivery) error {
+		return errors.New("database unavailable")
+	}
+
+	// Act
+	_, err := dispatcher.DispatchDue(context.Background())
+
+	// Assert the delivery stays pending, to be made again
+	assert.Error(t, err)
+	assert.Equal(t, domain.DeliveryPending, webhooks.Deliveries()[0].Status)
+}
+
+// TestWebhookDispatcher_DeliversToReceiver tests the path from an event to a signed callback
+// received by an HTTP server
+func TestWebhookDispatcher_DeliversToReceiver(t *testing.T) {
+	// Arrange
+	type callback struct {
+		header http.Header
+		body   []byte
+	}
+	callbacks := make(chan callback, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		callbacks <- callback{header: r.Header, body: body}
+	}))
+	defer receiver.Close()
+
+	webhooks := mocks.NewMockWebhookRepository()
+	subscribe(t, webhooks, "tenant-a", receiver.URL, domain.EventChildDeleted)
+	enqueuer := application.NewWebhookEnqueuer(webhooks, zaptest.NewLogger(t))
+	dispatcher := application.NewWebhookDispatcher(webhooks, webhook.NewHTTPSenderForAddresses(time.Second, zaptest.NewLogger(t), func(netip.Addr) bool { return true }),
+		application.WebhookDispatcherOptions{PollInterval: 10 * time.Millisecond}, zaptest.NewLogger(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go dispatcher.Run(ctx)
+
+	event := tenantEvent(domain.EventChildDeleted, "tenant-a")
+
+	// Act
+	require.NoError(t, enqueuer.Publish(context.Background(), event))
+
+	// Assert
+	select {
+	case received := <-callbacks:
+		assert.Equal(t, string(domain.EventChildDeleted), received.header.Get(webhook.EventHeader))
+		assert.True(t, webhook.Verify(testWebhookSecret, received.header.Get(webhook.TimestampHeader), received.body, received.header.Get(webhook.SignatureHeader)))
+
+		var payload domain.Event
+		require.NoError(t, json.Unmarshal(received.body, &payload))
+		assert.Equal(t, event.ID, payload.ID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook callback not received")
+	}
+
+	assert.Eventually(t, func() bool {
+		deliveries := webhooks.Deliveries()
+		return len(deliveries) == 1 && deliveries[0].Status == domain.DeliveryDelivered
+	}, 5*time.Second, 10*time.Millisecond)
+}
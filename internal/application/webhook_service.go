@@ -0,0 +1,192 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Limits of the number of webhook deliveries listed at a time
+const (
+	DefaultWebhookDeliveryLimit = 50
+	MaxWebhookDeliveryLimit     = 500
+)
+
+// MinWebhookSecretLength is the minimum length of the secret webhook payloads are signed with
+const MinWebhookSecretLength = 16
+
+// WebhookService implements the ports.WebhookService interface.
+// It manages the webhook subscriptions of the caller's tenant; the deliveries to them are
+// enqueued by a WebhookEnqueuer and made by a WebhookDispatcher.
+type WebhookService struct {
+	webhooks  ports.WebhookRepository // Repository for webhook subscriptions and deliveries
+	validator *validator.Validate     // Validates input data
+	logger    *zap.Logger             // Logs service operations
+	tracer    trace.Tracer            // Provides distributed tracing
+}
+
+// NewWebhookService creates a new webhook service.
+// Parameters:
+//   - webhooks: Repository for webhook subscriptions and deliveries
+//   - validator: Validator for input validation
+//   - logger: Logger for logging service operations
+//
+// Returns:
+//   - *WebhookService: A new instance of the webhook service
+func NewWebhookService(webhooks ports.WebhookRepository, validator *validator.Validate, logger *zap.Logger) *WebhookService {
+	return &WebhookService{
+		webhooks:  webhooks,
+		validator: validator,
+		logger:    logger,
+		tracer:    otel.Tracer("application.webhook_service"),
+	}
+}
+
+// CreateWebhookSubscription subscribes a URL to the events of the given types.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - rawURL: The https URL that receives the callbacks, which must not be local, see domain.ValidateWebhookURL
+//   - eventTypes: The types of the events delivered to the URL; duplicates are ignored
+//   - secret: The secret the payloads are signed with, of at least MinWebhookSecretLength characters
+//
+// Returns:
+//   - *domain.WebhookSubscription: The newly created subscription if successful
+//   - error: A ValidationError if the input is invalid, or a database error
+func (s *WebhookService) CreateWebhookSubscription(ctx context.Context, rawURL string, eventTypes []domain.EventType, secret string) (*domain.WebhookSubscription, error) {
+	ctx, span := s.tracer.Start(ctx, "WebhookService.CreateWebhookSubscription")
+	defer span.End()
+
+	// Validate input
+	if err := domain.ValidateWebhookURL(rawURL); err != nil {
+		return nil, err
+	}
+	if len(eventTypes) == 0 {
+		return nil, domain.NewValidationError("WebhookSubscription", "eventTypes", "is required")
+	}
+	for _, eventType := range eventTypes {
+		if !eventType.IsValid() {
+			return nil, domain.NewValidationError("WebhookSubscription", "eventTypes", "contains unknown event type "+string(eventType))
+		}
+	}
+	if len(secret) < MinWebhookSecretLength {
+		return nil, domain.NewValidationError("WebhookSubscription", "secret", fmt.Sprintf("must have at least %d characters", MinWebhookSecretLength))
+	}
+
+	// Create subscription
+	eventTypes = slices.Clone(eventTypes)
+	slices.Sort(eventTypes)
+	subscription := domain.NewWebhookSubscription(rawURL, slices.Compact(eventTypes), secret)
+
+	// Validate subscription
+	if err := s.validator.Struct(subscription); err != nil {
+		s.logger.Error("Webhook subscription validation failed", zap.Error(err))
+		return nil, domain.NewValidationError("WebhookSubscription", "", err.Error())
+	}
+
+	// Save subscription
+	if err := s.webhooks.CreateSubscription(ctx, subscription); err != nil {
+		s.logger.Error("Failed to create webhook subscription", zap.Error(err))
+		return nil, domain.NewDatabaseError("create", "WebhookSubscription", err)
+	}
+
+	span.SetAttributes(attribute.String("webhook.subscription_id", subscription.ID.String()))
+	s.logger.Info("Webhook subscription created",
+		zap.String("subscription_id", subscription.ID.String()),
+		zap.String("url", subscription.URL))
+
+	return subscription, nil
+}
+
+// GetWebhookSubscriptions retrieves the webhook subscriptions of the caller's tenant.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//
+// Returns:
+//   - []*domain.WebhookSubscription: The subscriptions, oldest first
+//   - error: A database error if the subscriptions could not be retrieved
+func (s *WebhookService) GetWebhookSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	ctx, span := s.tracer.Start(ctx, "WebhookService.GetWebhookSubscriptions")
+	defer span.End()
+
+	subscriptions, err := s.webhooks.ListSubscriptions(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list webhook subscriptions", zap.Error(err))
+		return nil, domain.NewDatabaseError("list", "WebhookSubscription", err)
+	}
+
+	return subscriptions, nil
+}
+
+// DeleteWebhookSubscription removes a webhook subscription and its deliveries.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - id: The unique identifier of the subscription to remove
+//
+// Returns:
+//   - error: A NotFoundError if the subscription doesn't exist, or a database error
+func (s *WebhookService) DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "WebhookService.DeleteWebhookSubscription")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("webhook.subscription_id", id.String()))
+
+	// Check if subscription exists
+	if _, err := s.webhooks.GetSubscription(ctx, id); err != nil {
+		s.logger.Error("Failed to get webhook subscription", zap.Error(err), zap.String("subscription_id", id.String()))
+		if err == context.DeadlineExceeded {
+			return err
+		}
+		return domain.NewNotFoundError("WebhookSubscription", id.String())
+	}
+
+	if err := s.webhooks.DeleteSubscription(ctx, id); err != nil {
+		s.logger.Error("Failed to delete webhook subscription", zap.Error(err), zap.String("subscription_id", id.String()))
+		return domain.NewDatabaseError("delete", "WebhookSubscription", err)
+	}
+
+	s.logger.Info("Webhook subscription deleted", zap.String("subscription_id", id.String()))
+	return nil
+}
+
+// GetWebhookDeliveries retrieves the webhook deliveries of the caller's tenant, for debugging receivers.
+// Parameters:
+//   - ctx: The context for the operation, used for tracing and cancellation
+//   - filter: Selects the deliveries by subscription and status; a limit that is not positive
+//     lists DefaultWebhookDeliveryLimit deliveries, and the limit is capped at MaxWebhookDeliveryLimit
+//
+// Returns:
+//   - []*domain.WebhookDelivery: The deliveries, newest first
+//   - error: A ValidationError if the status is unknown, or a database error
+func (s *WebhookService) GetWebhookDeliveries(ctx context.Context, filter ports.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	ctx, span := s.tracer.Start(ctx, "WebhookService.GetWebhookDeliveries")
+	defer span.End()
+
+	if filter.Status != nil && !filter.Status.IsValid() {
+		return nil, domain.NewValidationError("WebhookDelivery", "status", "is unknown")
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultWebhookDeliveryLimit
+	}
+	filter.Limit = min(filter.Limit, MaxWebhookDeliveryLimit)
+
+	deliveries, err := s.webhooks.ListDeliveries(ctx, filter)
+	if err != nil {
+		s.logger.Error("Failed to list webhook deliveries", zap.Error(err))
+		return nil, domain.NewDatabaseError("list", "WebhookDelivery", err)
+	}
+
+	return deliveries, nil
+}
+
+// Ensure WebhookService implements ports.WebhookService
+var _ ports.WebhookService = (*WebhookService)(nil)
@@ -0,0 +1,145 @@
+package application_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/application"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/mocks"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// setupWebhookServiceTest creates a webhook service on a mock repository
+func setupWebhookServiceTest(t *testing.T) (*application.WebhookService, *mocks.MockWebhookRepository) {
+	t.Helper()
+
+	webhooks := mocks.NewMockWebhookRepository()
+	return application.NewWebhookService(webhooks, validator.New(), zaptest.NewLogger(t)), webhooks
+}
+
+func TestWebhookService_CreateWebhookSubscription(t *testing.T) {
+	// Arrange
+	service, webhooks := setupWebhookServiceTest(t)
+	ctx := ports.WithTenantID(context.Background(), "tenant-a")
+
+	// Act
+	subscription, err := service.CreateWebhookSubscription(ctx, "https://example.com/hooks",
+		[]domain.EventType{domain.EventChildDeleted, domain.EventChildCreated, domain.EventChildDeleted}, "0123456789abcdef")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []domain.EventType{domain.EventChildCreated, domain.EventChildDeleted}, subscription.EventTypes)
+
+	subscriptions, err := webhooks.ListSubscriptions(ctx)
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 1)
+	assert.Equal(t, subscription.ID, subscriptions[0].ID)
+	assert.Equal(t, "tenant-a", subscriptions[0].TenantID)
+}
+
+func TestWebhookService_CreateWebhookSubscription_Validation(t *testing.T) {
+	testCases := []struct {
+		name       string
+		url        string
+		eventTypes []domain.EventType
+		secret     string
+		errorMsg   string
+	}{
+		{"relative URL", "/hooks", []domain.EventType{domain.EventChildCreated}, "0123456789abcdef", "url"},
+		{"unsupported scheme", "ftp://example.com/hooks", []domain.EventType{domain.EventChildCreated}, "0123456789abcdef", "url"},
+		{"http URL", "http://example.com/hooks", []domain.EventType{domain.EventChildCreated}, "0123456789abcdef", "https"},
+		{"private address", "https://10.0.0.8/hooks", []domain.EventType{domain.EventChildCreated}, "0123456789abcdef", "private"},
+		{"no event types", "https://example.com/hooks", nil, "0123456789abcdef", "event types"},
+		{"unknown event type", "https://example.com/hooks", []domain.EventType{"CHILD_RENAMED"}, "0123456789abcdef", "CHILD_RENAMED"},
+		{"short secret", "https://example.com/hooks", []domain.EventType{domain.EventChildCreated}, "secret", "secret"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			service, webhooks := setupWebhookServiceTest(t)
+			ctx := context.Background()
+
+			// Act
+			subscription, err := service.CreateWebhookSubscription(ctx, tc.url, tc.eventTypes, tc.secret)
+
+			// Assert
+			assert.Nil(t, subscription)
+			assert.ErrorIs(t, err, domain.ErrValidation)
+			assert.Contains(t, err.Error(), tc.errorMsg)
+
+			subscriptions, err := webhooks.ListSubscriptions(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, subscriptions)
+		})
+	}
+}
+
+func TestWebhookService_DeleteWebhookSubscription(t *testing.T) {
+	// Arrange
+	service, _ := setupWebhookServiceTest(t)
+	ctx := ports.WithTenantID(context.Background(), "tenant-a")
+
+	subscription, err := service.CreateWebhookSubscription(ctx, "https://example.com/hooks",
+		[]domain.EventType{domain.EventChildCreated}, "0123456789abcdef")
+	require.NoError(t, err)
+
+	// Act and assert another tenant cannot delete the subscription
+	err = service.DeleteWebhookSubscription(ports.WithTenantID(context.Background(), "tenant-b"), subscription.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Act and assert the tenant can
+	require.NoError(t, service.DeleteWebhookSubscription(ctx, subscription.ID))
+	assert.ErrorIs(t, service.DeleteWebhookSubscription(ctx, subscription.ID), domain.ErrNotFound)
+	assert.ErrorIs(t, service.DeleteWebhookSubscription(ctx, uuid.New()), domain.ErrNotFound)
+}
+
+func TestWebhookService_GetWebhookDeliveries_Limit(t *testing.T) {
+	testCases := []struct {
+		name     string
+		limit    int
+		expected int
+	}{
+		{"default", 0, application.DefaultWebhookDeliveryLimit},
+		{"given", 10, 10},
+		{"capped", 10000, application.MaxWebhookDeliveryLimit},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			service, webhooks := setupWebhookServiceTest(t)
+			var got ports.WebhookDeliveryFilter
+			webhooks.ListDeliveriesFunc = func(ctx context.Context, filter ports.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+				got = filter
+				return nil, nil
+			}
+
+			// Act
+			_, err := service.GetWebhookDeliveries(context.Background(), ports.WebhookDeliveryFilter{Limit: tc.limit})
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got.Limit)
+		})
+	}
+}
+
+func TestWebhookService_GetWebhookDeliveries_UnknownStatus(t *testing.T) {
+	// Arrange
+	service, _ := setupWebhookServiceTest(t)
+	status := domain.DeliveryStatus(strings.ToLower(string(domain.DeliveryPending)))
+
+	// Act
+	_, err := service.GetWebhookDeliveries(context.Background(), ports.WebhookDeliveryFilter{Status: &status})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrValidation)
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "strings"
+
+// Address is a postal address, such as the address of a household.
+type Address struct {
+	Street     string `json:"street" bson:"street" validate:"required"`
+	City       string `json:"city" bson:"city" validate:"required"`
+	State      string `json:"state,omitempty" bson:"state,omitempty"`
+	PostalCode string `json:"postalCode" bson:"postalCode" validate:"required"`
+	Country    string `json:"country" bson:"country" validate:"required,iso3166_1_alpha2"`
+}
+
+// String returns the address on a single line, leaving out the parts that are not set.
+// Returns:
+//   - string: The address, such as "1 Main St, Springfield, IL, 62701, US"
+func (a Address) String() string {
+	parts := make([]string, 0, 5)
+	for _, part := range []string{a.Street, a.City, a.State, a.PostalCode, a.Country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
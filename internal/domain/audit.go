@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audited entity types
+const (
+	AuditEntityParent    = "Parent"
+	AuditEntityChild     = "Child"
+	AuditEntityHousehold = "Household"
+
+	// AuditEntityDeletedRecords is the entity type of a purge of deleted records,
+	// which is recorded under the nil entity ID because it changes many entities at once
+	AuditEntityDeletedRecords = "DeletedRecords"
+)
+
+// AuditSnapshot holds the audited fields of an entity as text, keyed by field name.
+// Fields without a value are left out, so that a field that is set or cleared shows up
+// in a diff with no value on the other side.
+type AuditSnapshot map[string]string
+
+// FieldChange is the change of a single field of an entity
+type FieldChange struct {
+	Field  string `json:"field" bson:"field"`
+	Before string `json:"before,omitempty" bson:"before,omitempty"`
+	After  string `json:"after,omitempty" bson:"after,omitempty"`
+}
+
+// AuditRecord records who changed which entity, when, how, and, for the operations that ask for one, why.
+// Audit records are only ever appended; they are kept when the entity is purged.
+type AuditRecord struct {
+	ID         uuid.UUID     `json:"id" bson:"_id"`
+	TenantID   string        `json:"tenantId,omitempty" bson:"tenantId"`
+	Actor      string        `json:"actor,omitempty" bson:"actor,omitempty"`
+	Operation  string        `json:"operation" bson:"operation"`
+	EntityType string        `json:"entityType" bson:"entityType"`
+	EntityID   uuid.UUID     `json:"entityId" bson:"entityId"`
+	Changes    []FieldChange `json:"changes" bson:"changes"`
+	Reason     string        `json:"reason,omitempty" bson:"reason,omitempty"`
+	TraceID    string        `json:"traceId,omitempty" bson:"traceId,omitempty"`
+	OccurredAt time.Time     `json:"occurredAt" bson:"occurredAt"`
+}
+
+// NewAuditRecord creates a new AuditRecord with a generated UUID and the current UTC time.
+// The actor, tenant and trace are left to the caller, which knows the context of the change.
+// Parameters:
+//   - operation: The operation that made the change, such as "UpdateChild"
+//   - entityType: The type of the changed entity, such as AuditEntityChild
+//   - entityID: The ID of the changed entity
+//   - before: The audited fields before the change, or nil for a new entity
+//   - after: The audited fields after the change
+//
+// Returns:
+//   - *AuditRecord: A pointer to the newly created audit record
+func NewAuditRecord(operation, entityType string, entityID uuid.UUID, before, after AuditSnapshot) *AuditRecord {
+	return &AuditRecord{
+		ID:         uuid.New(),
+		Operation:  operation,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    DiffSnapshots(before, after),
+		OccurredAt: time.Now().UTC(),
+	}
+}
+
+// DiffSnapshots returns the fields whose values differ between two snapshots, ordered by field name.
+// Parameters:
+//   - before: The audited fields before the change
+//   - after: The audited fields after the change
+//
+// Returns:
+//   - []FieldChange: The changed fields
+func DiffSnapshots(before, after AuditSnapshot) []FieldChange {
+	changes := []FieldChange{}
+	for field, value := range after {
+		if before[field] != value {
+			changes = append(changes, FieldChange{Field: field, Before: before[field], After: value})
+		}
+	}
+	for field, value := range before {
+		if _, ok := after[field]; !ok {
+			changes = append(changes, FieldChange{Field: field, Before: value})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+// DeletionSnapshot returns the snapshot of the deleted field alone, for changes that only mark
+// an entity as deleted or restore it.
+// Parameters:
+//   - deleted: Whether the entity is marked as deleted
+//
+// Returns:
+//   - AuditSnapshot: The snapshot of the deleted field
+func DeletionSnapshot(deleted bool) AuditSnapshot {
+	return AuditSnapshot{"deleted": strconv.FormatBool(deleted)}
+}
+
+// AuditSnapshot returns the audited fields of the parent.
+// Returns:
+//   - AuditSnapshot: The parent's personal information, linked user, children, deletion mark and the
+//     parent it was merged into
+func (p *Parent) AuditSnapshot() AuditSnapshot {
+	snapshot := DeletionSnapshot(p.IsDeleted())
+	snapshot.set("firstName", p.FirstName)
+	snapshot.set("lastName", p.LastName)
+	snapshot.set("email", p.Email)
+	snapshot.set("birthDate", p.BirthDate.Format(time.RFC3339))
+	snapshot.set("userId", p.UserID)
+
+	phones := make([]string, 0, len(p.Phones))
+	for _, phone := range p.Phones {
+		phones = append(phones, phone.String())
+	}
+	snapshot.set("phones", strings.Join(phones, ","))
+	if p.Address != nil {
+		snapshot.set("address", p.Address.String())
+	}
+	emergencyContacts := make([]string, 0, len(p.EmergencyContacts))
+	for _, contact := range p.EmergencyContacts {
+		emergencyContacts = append(emergencyContacts, contact.String())
+	}
+	snapshot.set("emergencyContacts", strings.Join(emergencyContacts, ","))
+
+	childIDs := make([]string, 0, len(p.Children))
+	for _, child := range p.Children {
+		childIDs = append(childIDs, child.ID.String())
+	}
+	snapshot.set("children", strings.Join(childIDs, ","))
+
+	if p.MergedInto != nil {
+		snapshot.set("mergedInto", p.MergedInto.String())
+	}
+
+	return snapshot
+}
+
+// AuditSnapshot returns the audited fields of the child.
+// Returns:
+//   - AuditSnapshot: The child's personal information, parent and deletion mark
+func (c *Child) AuditSnapshot() AuditSnapshot {
+	snapshot := DeletionSnapshot(c.IsDeleted())
+	snapshot.set("firstName", c.FirstName)
+	snapshot.set("lastName", c.LastName)
+	snapshot.set("birthDate", c.BirthDate.Format(time.RFC3339))
+	if c.ParentID != uuid.Nil {
+		snapshot.set("parentId", c.ParentID.String())
+	}
+	return snapshot
+}
+
+// GuardiansSnapshot returns the audited fields of the child together with its active guardians,
+// so that the changes of its guardianships show up in its audit records.
+// Parameters:
+//   - guardianships: The guardianships of the child
+//
+// Returns:
+//   - AuditSnapshot: The child's audited fields and a guardians field listing the parent, type
+//     and primary contact mark of each active guardianship
+func (c *Child) GuardiansSnapshot(guardianships []*Guardianship) AuditSnapshot {
+	snapshot := c.AuditSnapshot()
+
+	guardians := make([]string, 0, len(guardianships))
+	for _, guardianship := range ActiveGuardianships(guardianships) {
+		guardian := guardianship.ParentID.String() + ":" + string(guardianship.Type)
+		if guardianship.PrimaryContact {
+			guardian += ":primary"
+		}
+		guardians = append(guardians, guardian)
+	}
+	sort.Strings(guardians)
+	snapshot.set("guardians", strings.Join(guardians, ","))
+
+	return snapshot
+}
+
+// AuditSnapshot returns the audited fields of the household.
+// Returns:
+//   - AuditSnapshot: The household's name, address, phone numbers and members
+func (h *Household) AuditSnapshot() AuditSnapshot {
+	snapshot := AuditSnapshot{}
+	snapshot.set("name", h.Name)
+	snapshot.set("address", h.Address.String())
+	snapshot.set("phoneNumbers", strings.Join(h.PhoneNumbers, ","))
+	snapshot.set("parents", joinIDs(h.ParentIDs))
+	snapshot.set("children", joinIDs(h.ChildIDs))
+	return snapshot
+}
+
+// joinIDs returns the given IDs as a sorted, comma-separated list, so that the order in which
+// members were added does not show up as a change
+func joinIDs(ids []uuid.UUID) string {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, id.String())
+	}
+	sort.Strings(values)
+	return strings.Join(values, ",")
+}
+
+// set stores a field of the snapshot, leaving out empty values
+func (s AuditSnapshot) set(field, value string) {
+	if value != "" {
+		s[field] = value
+	}
+}
@@ -0,0 +1,84 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAuditRecord_DiffsSnapshots(t *testing.T) {
+	// Arrange
+	entityID := uuid.New()
+	before := domain.AuditSnapshot{"firstName": "Jane", "birthDate": "2015-01-02T00:00:00Z", "userId": "user-1"}
+	after := domain.AuditSnapshot{"firstName": "Jane", "birthDate": "2015-02-01T00:00:00Z", "email": "jane@example.com"}
+
+	// Act
+	record := domain.NewAuditRecord("UpdateChild", domain.AuditEntityChild, entityID, before, after)
+
+	// Assert the changed, added and removed fields are listed by name
+	assert.NotEqual(t, uuid.Nil, record.ID)
+	assert.Equal(t, "UpdateChild", record.Operation)
+	assert.Equal(t, domain.AuditEntityChild, record.EntityType)
+	assert.Equal(t, entityID, record.EntityID)
+	assert.WithinDuration(t, time.Now(), record.OccurredAt, time.Second)
+	assert.Equal(t, []domain.FieldChange{
+		{Field: "birthDate", Before: "2015-01-02T00:00:00Z", After: "2015-02-01T00:00:00Z"},
+		{Field: "email", After: "jane@example.com"},
+		{Field: "userId", Before: "user-1"},
+	}, record.Changes)
+}
+
+func TestNewAuditRecord_NoChanges(t *testing.T) {
+	// Act
+	record := domain.NewAuditRecord("UpdateParent", domain.AuditEntityParent, uuid.New(),
+		domain.AuditSnapshot{"firstName": "John"}, domain.AuditSnapshot{"firstName": "John"})
+
+	// Assert
+	require.NotNil(t, record.Changes)
+	assert.Empty(t, record.Changes)
+}
+
+func TestParent_AuditSnapshot(t *testing.T) {
+	// Arrange
+	birthDate := time.Date(1985, 3, 4, 0, 0, 0, 0, time.UTC)
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", birthDate)
+	child := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parent.ID)
+	parent.AddChild(*child)
+
+	// Act
+	snapshot := parent.AuditSnapshot()
+
+	// Assert
+	assert.Equal(t, domain.AuditSnapshot{
+		"firstName": "John",
+		"lastName":  "Doe",
+		"email":     "john.doe@example.com",
+		"birthDate": "1985-03-04T00:00:00Z",
+		"children":  child.ID.String(),
+		"deleted":   "false",
+	}, snapshot)
+}
+
+func TestChild_AuditSnapshot(t *testing.T) {
+	// Arrange
+	parentID := uuid.New()
+	birthDate := time.Date(2015, 1, 2, 0, 0, 0, 0, time.UTC)
+	child := domain.NewChild("Jane", "Doe", birthDate, parentID)
+	child.MarkAsDeleted()
+
+	// Act
+	snapshot := child.AuditSnapshot()
+
+	// Assert
+	assert.Equal(t, domain.AuditSnapshot{
+		"firstName": "Jane",
+		"lastName":  "Doe",
+		"birthDate": "2015-01-02T00:00:00Z",
+		"parentId":  parentID.String(),
+		"deleted":   "true",
+	}, snapshot)
+}
@@ -10,6 +10,8 @@ import (
 
 // Child represents a child entity in the family service.
 // It contains personal information about the child and a reference to their parent.
+// ParentID is the child's primary contact only; the guardianships of the child name every
+// parent and guardian it has, and decide which families may read it.
 // This entity implements the Entity interface for common CRUD operations.
 type Child struct {
 	ID        uuid.UUID  `json:"id" bson:"_id"`
@@ -17,8 +19,10 @@ type Child struct {
 	LastName  string     `json:"lastName" bson:"lastName" validate:"required"`
 	BirthDate time.Time  `json:"birthDate" bson:"birthDate" validate:"required"`
 	ParentID  uuid.UUID  `json:"parentId" bson:"parentId" validate:"required"`
+	TenantID  string     `json:"tenantId,omitempty" bson:"tenantId"`
 	CreatedAt time.Time  `json:"createdAt" bson:"createdAt"`
 	UpdatedAt time.Time  `json:"updatedAt" bson:"updatedAt"`
+	Version   int        `json:"version" bson:"version"`
 	DeletedAt *time.Time `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
 }
 
@@ -49,6 +53,14 @@ func (c *Child) GetUpdatedAt() time.Time {
 	return c.UpdatedAt
 }
 
+// GetVersion returns the child's version.
+// This method implements the Entity interface.
+// Returns:
+//   - int: The version of the child, incremented on every write
+func (c *Child) GetVersion() int {
+	return c.Version
+}
+
 // GetDeletedAt returns the child's deletion timestamp, if any.
 // This method implements the Entity interface.
 // Returns:
@@ -78,6 +90,7 @@ func NewChild(firstName, lastName string, birthDate time.Time, parentID uuid.UUI
 		ParentID:  parentID,
 		CreatedAt: now,
 		UpdatedAt: now,
+		Version:   1,
 	}
 }
 
@@ -89,6 +102,13 @@ func (c *Child) MarkAsDeleted() {
 	c.UpdatedAt = now
 }
 
+// Restore unmarks the child as deleted by clearing the DeletedAt timestamp.
+// It undoes MarkAsDeleted, as long as the child has not been purged.
+func (c *Child) Restore() {
+	c.DeletedAt = nil
+	c.UpdatedAt = time.Now().UTC()
+}
+
 // IsDeleted checks if the child is marked as deleted.
 // Returns:
 //   - bool: true if the child has been marked as deleted, false otherwise
@@ -28,6 +28,7 @@ func TestNewChild(t *testing.T) {
 	assert.Equal(t, parentID, child.ParentID)
 	assert.False(t, child.CreatedAt.IsZero())
 	assert.False(t, child.UpdatedAt.IsZero())
+	assert.Equal(t, 1, child.Version)
 	assert.Nil(t, child.DeletedAt)
 }
 
@@ -48,6 +49,24 @@ func TestChild_MarkAsDeleted(t *testing.T) {
 	assert.True(t, child.IsDeleted())
 }
 
+func TestChild_Restore(t *testing.T) {
+	// Arrange
+	child := domain.NewChild("Jane", "Doe", time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC), uuid.New())
+	child.MarkAsDeleted()
+	deletedUpdatedAt := child.UpdatedAt
+
+	// Wait a moment to ensure UpdatedAt will be different
+	time.Sleep(1 * time.Millisecond)
+
+	// Act
+	child.Restore()
+
+	// Assert
+	assert.Nil(t, child.DeletedAt)
+	assert.True(t, child.UpdatedAt.After(deletedUpdatedAt))
+	assert.False(t, child.IsDeleted())
+}
+
 func TestChild_Update(t *testing.T) {
 	// Arrange
 	child := domain.NewChild("Jane", "Doe", time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC), uuid.New())
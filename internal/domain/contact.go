@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"strings"
+)
+
+// PhoneType is what a phone number is used for.
+type PhoneType string
+
+// Types of phone numbers
+const (
+	// PhoneMobile is a mobile phone number
+	PhoneMobile PhoneType = "MOBILE"
+	// PhoneHome is a landline at home
+	PhoneHome PhoneType = "HOME"
+	// PhoneWork is a phone number at work
+	PhoneWork PhoneType = "WORK"
+)
+
+// IsValid checks if the phone type is one of the known types.
+// Returns:
+//   - bool: true if the type is known, false otherwise
+func (t PhoneType) IsValid() bool {
+	switch t {
+	case PhoneMobile, PhoneHome, PhoneWork:
+		return true
+	}
+	return false
+}
+
+// Phone is a phone number in E.164 format, such as "+12175550100", with what it is used for.
+type Phone struct {
+	Number string    `json:"number" bson:"number" validate:"required,phone"`
+	Type   PhoneType `json:"type,omitempty" bson:"type,omitempty" validate:"omitempty,phone_type"`
+}
+
+// String returns the phone number, followed by its type if it is set.
+// Returns:
+//   - string: The phone number, such as "+12175550100 (MOBILE)"
+func (p Phone) String() string {
+	if p.Type == "" {
+		return p.Number
+	}
+	return p.Number + " (" + string(p.Type) + ")"
+}
+
+// NormalizePhoneNumber removes the spaces, dots, dashes and parentheses that are commonly used to
+// format a phone number, so that "+1 (217) 555-0100" becomes "+12175550100".
+// Parameters:
+//   - number: The phone number as entered
+//
+// Returns:
+//   - string: The phone number without formatting characters
+func NormalizePhoneNumber(number string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '.', '-', '(', ')':
+			return -1
+		}
+		return r
+	}, number)
+}
+
+// EmergencyContact is a person to call when a parent cannot be reached, such as a grandparent.
+type EmergencyContact struct {
+	Name         string `json:"name" bson:"name" validate:"required"`
+	Relationship string `json:"relationship,omitempty" bson:"relationship,omitempty"`
+	Phone        Phone  `json:"phone" bson:"phone"`
+}
+
+// String returns the name of the emergency contact, followed by their relationship if it is set, and their phone.
+// Returns:
+//   - string: The emergency contact, such as "Jane Doe (grandmother): +12175550100"
+func (e EmergencyContact) String() string {
+	name := e.Name
+	if e.Relationship != "" {
+		name += " (" + e.Relationship + ")"
+	}
+	return name + ": " + e.Phone.Number
+}
+
+// ContactDetails are the ways to reach a parent besides their email address: their phone numbers,
+// their postal address and the people to call in an emergency. None of them is required.
+type ContactDetails struct {
+	Phones            []Phone            `json:"phones,omitempty" bson:"phones,omitempty" validate:"dive"`
+	Address           *Address           `json:"address,omitempty" bson:"address,omitempty"`
+	EmergencyContacts []EmergencyContact `json:"emergencyContacts,omitempty" bson:"emergencyContacts,omitempty" validate:"dive"`
+}
+
+// Normalize normalizes the phone numbers of the contact details, and upper-cases the country of the address,
+// so that the same number or country is stored, and found by filters, the same way however it was entered.
+func (c *ContactDetails) Normalize() {
+	for i := range c.Phones {
+		c.Phones[i].Number = NormalizePhoneNumber(c.Phones[i].Number)
+	}
+	for i := range c.EmergencyContacts {
+		c.EmergencyContacts[i].Phone.Number = NormalizePhoneNumber(c.EmergencyContacts[i].Phone.Number)
+	}
+	if c.Address != nil {
+		c.Address.Country = strings.ToUpper(c.Address.Country)
+	}
+}
+
+// HasPhone checks if one of the phone numbers of the contact details is the given number.
+// Parameters:
+//   - number: The phone number in E.164 format
+//
+// Returns:
+//   - bool: true if the number is one of the phone numbers, false otherwise
+func (c ContactDetails) HasPhone(number string) bool {
+	for _, phone := range c.Phones {
+		if phone.Number == number {
+			return true
+		}
+	}
+	return false
+}
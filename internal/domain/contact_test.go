@@ -0,0 +1,46 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	assert.Equal(t, "+12175550100", domain.NormalizePhoneNumber("+1 (217) 555-0100"))
+	assert.Equal(t, "+442071838750", domain.NormalizePhoneNumber("+44 20.7183.8750"))
+	assert.Equal(t, "+12175550100", domain.NormalizePhoneNumber("+12175550100"))
+}
+
+func TestPhoneType_IsValid(t *testing.T) {
+	assert.True(t, domain.PhoneMobile.IsValid())
+	assert.True(t, domain.PhoneWork.IsValid())
+	assert.False(t, domain.PhoneType("FAX").IsValid())
+}
+
+func TestContactDetails_Normalize(t *testing.T) {
+	// Arrange
+	contact := domain.ContactDetails{
+		Phones:            []domain.Phone{{Number: "+1 217 555 0100", Type: domain.PhoneMobile}},
+		Address:           &domain.Address{Street: "1 Main St", City: "Springfield", PostalCode: "62701", Country: "us"},
+		EmergencyContacts: []domain.EmergencyContact{{Name: "Mary Doe", Relationship: "grandmother", Phone: domain.Phone{Number: "+1-217-555-0199"}}},
+	}
+
+	// Act
+	contact.Normalize()
+
+	// Assert
+	assert.Equal(t, "+12175550100", contact.Phones[0].Number)
+	assert.Equal(t, "US", contact.Address.Country)
+	assert.Equal(t, "+12175550199", contact.EmergencyContacts[0].Phone.Number)
+	assert.True(t, contact.HasPhone("+12175550100"))
+	assert.False(t, contact.HasPhone("+12175550199"))
+}
+
+func TestContactDetails_String(t *testing.T) {
+	assert.Equal(t, "+12175550100 (MOBILE)", domain.Phone{Number: "+12175550100", Type: domain.PhoneMobile}.String())
+	assert.Equal(t, "+12175550100", domain.Phone{Number: "+12175550100"}.String())
+	assert.Equal(t, "Mary Doe (grandmother): +12175550199",
+		domain.EmergencyContact{Name: "Mary Doe", Relationship: "grandmother", Phone: domain.Phone{Number: "+12175550199"}}.String())
+}
@@ -0,0 +1,16 @@
+package domain
+
+// ParentDuplicate is a pair of parents that may be the same person recorded twice, with how similar
+// they are. The scores range from 0, when nothing matches, to 1, when everything does.
+type ParentDuplicate struct {
+	// Parent is the parent the duplicate was compared with, or the older of the two parents
+	Parent *Parent `json:"parent"`
+	// Duplicate is the parent that may be a duplicate of Parent
+	Duplicate *Parent `json:"duplicate"`
+
+	// Score is the weighted average of the scores of the names, email addresses and birth dates
+	Score          float64 `json:"score"`
+	NameScore      float64 `json:"nameScore"`
+	EmailScore     float64 `json:"emailScore"`
+	BirthDateScore float64 `json:"birthDateScore"`
+}
@@ -36,6 +36,11 @@ type Entity interface {
 	//   - time.Time: The UTC timestamp when the entity was last updated
 	GetUpdatedAt() time.Time
 
+	// GetVersion returns the entity's version, which is incremented on every write.
+	// Returns:
+	//   - int: The version of the entity, starting at 1 when it is created
+	GetVersion() int
+
 	// GetDeletedAt returns the entity's deletion timestamp, if any.
 	// Returns:
 	//   - *time.Time: The UTC timestamp when the entity was marked as deleted, or nil if not deleted
@@ -28,8 +28,14 @@ var (
 	// ErrForbidden is returned when a user is forbidden from performing an action
 	ErrForbidden = errors.New("forbidden")
 
+	// ErrConflict is returned when an entity was modified since the caller read it
+	ErrConflict = errors.New("conflict")
+
 	// ErrInternal is returned when an internal error occurs
 	ErrInternal = errors.New("internal error")
+
+	// ErrAborted is returned for the items of an atomic batch that were rolled back because another item failed
+	ErrAborted = errors.New("aborted, as another item of the batch failed")
 )
 
 // NotFoundError represents an error when an entity is not found
@@ -158,3 +164,166 @@ func NewDatabaseError(operation, entityType string, err error) *DatabaseError {
 		Err:        err,
 	}
 }
+
+// ForbiddenError represents an error when the caller may not access an entity
+type ForbiddenError struct {
+	EntityType string
+	ID         string
+	Err        error
+}
+
+// Error returns the error message
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("access to %s with ID %s is forbidden", e.EntityType, e.ID)
+}
+
+// Unwrap returns the underlying error
+func (e *ForbiddenError) Unwrap() error {
+	return e.Err
+}
+
+// Is checks if the target error is of the same type
+func (e *ForbiddenError) Is(target error) bool {
+	return target == ErrForbidden
+}
+
+// NewForbiddenError creates a new ForbiddenError
+func NewForbiddenError(entityType, id string) *ForbiddenError {
+	return &ForbiddenError{
+		EntityType: entityType,
+		ID:         id,
+		Err:        ErrForbidden,
+	}
+}
+
+// AuthorizationError represents an error when the caller may not perform an operation.
+// It is an ErrForbidden for authenticated callers, and an ErrUnauthorized for the others,
+// who might be allowed to perform the operation once they authenticate.
+type AuthorizationError struct {
+	Operation     string
+	Authenticated bool
+	Err           error
+}
+
+// Error returns the error message
+func (e *AuthorizationError) Error() string {
+	return fmt.Sprintf("not authorized to %s", e.Operation)
+}
+
+// Unwrap returns the underlying error
+func (e *AuthorizationError) Unwrap() error {
+	return e.Err
+}
+
+// Is checks if the target error is of the same type
+func (e *AuthorizationError) Is(target error) bool {
+	return target == e.Err
+}
+
+// NewAuthorizationError creates a new AuthorizationError for an operation, such as "update parent"
+func NewAuthorizationError(operation string, authenticated bool) *AuthorizationError {
+	err := ErrUnauthorized
+	if authenticated {
+		err = ErrForbidden
+	}
+
+	return &AuthorizationError{
+		Operation:     operation,
+		Authenticated: authenticated,
+		Err:           err,
+	}
+}
+
+// ConflictError represents an error when an entity was modified since the caller read it
+type ConflictError struct {
+	EntityType string
+	ID         string
+	Version    int
+	Err        error
+}
+
+// Error returns the error message
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s with ID %s has been modified since version %d", e.EntityType, e.ID, e.Version)
+}
+
+// Unwrap returns the underlying error
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
+// Is checks if the target error is of the same type
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// NewConflictError creates a new ConflictError for an entity that is no longer at the given version
+func NewConflictError(entityType, id string, version int) *ConflictError {
+	return &ConflictError{
+		EntityType: entityType,
+		ID:         id,
+		Version:    version,
+		Err:        ErrConflict,
+	}
+}
+
+// DuplicateError represents an error when an entity has the same value of a unique field as another entity
+type DuplicateError struct {
+	EntityType string
+	Field      string
+	Value      string
+	Err        error
+}
+
+// Error returns the error message
+func (e *DuplicateError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("%s with the same %s already exists", e.EntityType, e.Field)
+	}
+	return fmt.Sprintf("%s with %s %s already exists", e.EntityType, e.Field, e.Value)
+}
+
+// Unwrap returns the underlying error
+func (e *DuplicateError) Unwrap() error {
+	return e.Err
+}
+
+// Is checks if the target error is of the same type
+func (e *DuplicateError) Is(target error) bool {
+	return target == ErrDuplicate
+}
+
+// NewDuplicateError creates a new DuplicateError for an entity whose field has a value that is already taken.
+// The value may be empty when it is not known, such as when restoring an entity.
+func NewDuplicateError(entityType, field, value string) *DuplicateError {
+	return &DuplicateError{
+		EntityType: entityType,
+		Field:      field,
+		Value:      value,
+		Err:        ErrDuplicate,
+	}
+}
+
+// BatchError represents the failure of one item of a batch, at the given position in the batch
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+// Error returns the error message
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// NewBatchError creates a new BatchError for the item at the given position of a batch
+func NewBatchError(index int, err error) *BatchError {
+	return &BatchError{
+		Index: index,
+		Err:   err,
+	}
+}
@@ -27,6 +27,93 @@ func TestNotFoundError(t *testing.T) {
 	assert.False(t, errors.Is(err, domain.ErrValidation))
 }
 
+func TestForbiddenError(t *testing.T) {
+	// Test constructor
+	err := domain.NewForbiddenError("Parent", "123")
+	assert.NotNil(t, err)
+	assert.Equal(t, "Parent", err.EntityType)
+	assert.Equal(t, "123", err.ID)
+
+	// Test Error method
+	assert.Equal(t, "access to Parent with ID 123 is forbidden", err.Error())
+
+	// Test Unwrap and Is methods
+	assert.Equal(t, domain.ErrForbidden, errors.Unwrap(err))
+	assert.True(t, errors.Is(err, domain.ErrForbidden))
+	assert.False(t, errors.Is(err, domain.ErrNotFound))
+}
+
+func TestAuthorizationError(t *testing.T) {
+	// Test constructor
+	err := domain.NewAuthorizationError("update parent", true)
+	assert.NotNil(t, err)
+	assert.Equal(t, "update parent", err.Operation)
+	assert.True(t, err.Authenticated)
+
+	// Test Error method
+	assert.Equal(t, "not authorized to update parent", err.Error())
+
+	// Test Unwrap and Is methods
+	assert.Equal(t, domain.ErrForbidden, errors.Unwrap(err))
+	assert.True(t, errors.Is(err, domain.ErrForbidden))
+	assert.False(t, errors.Is(err, domain.ErrUnauthorized))
+
+	// An unauthenticated caller is unauthorized rather than forbidden
+	err = domain.NewAuthorizationError("update parent", false)
+	assert.True(t, errors.Is(err, domain.ErrUnauthorized))
+	assert.False(t, errors.Is(err, domain.ErrForbidden))
+}
+
+func TestConflictError(t *testing.T) {
+	// Test constructor
+	err := domain.NewConflictError("Parent", "123", 2)
+	assert.NotNil(t, err)
+	assert.Equal(t, "Parent", err.EntityType)
+	assert.Equal(t, "123", err.ID)
+	assert.Equal(t, 2, err.Version)
+
+	// Test Error method
+	assert.Equal(t, "Parent with ID 123 has been modified since version 2", err.Error())
+
+	// Test Unwrap and Is methods
+	assert.Equal(t, domain.ErrConflict, errors.Unwrap(err))
+	assert.True(t, errors.Is(err, domain.ErrConflict))
+	assert.False(t, errors.Is(err, domain.ErrNotFound))
+}
+
+func TestDuplicateError(t *testing.T) {
+	// Test constructor
+	err := domain.NewDuplicateError("Parent", "email", "john.doe@example.com")
+	assert.NotNil(t, err)
+	assert.Equal(t, "Parent", err.EntityType)
+	assert.Equal(t, "email", err.Field)
+	assert.Equal(t, "john.doe@example.com", err.Value)
+
+	// Test Error method, with and without the value
+	assert.Equal(t, "Parent with email john.doe@example.com already exists", err.Error())
+	assert.Equal(t, "Parent with the same email already exists", domain.NewDuplicateError("Parent", "email", "").Error())
+
+	// Test Unwrap and Is methods
+	assert.Equal(t, domain.ErrDuplicate, errors.Unwrap(err))
+	assert.True(t, errors.Is(err, domain.ErrDuplicate))
+	assert.False(t, errors.Is(err, domain.ErrConflict))
+}
+
+func TestBatchError(t *testing.T) {
+	// Test constructor
+	duplicateErr := domain.NewDuplicateError("Parent", "email", "john.doe@example.com")
+	err := domain.NewBatchError(2, duplicateErr)
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, err.Index)
+
+	// Test Error method
+	assert.Equal(t, "item 2: Parent with email john.doe@example.com already exists", err.Error())
+
+	// Test Unwrap method, through which the error of the item is found
+	assert.Equal(t, duplicateErr, errors.Unwrap(err))
+	assert.True(t, errors.Is(err, domain.ErrDuplicate))
+}
+
 func TestValidationError(t *testing.T) {
 	// Test constructor with field
 	err := domain.NewValidationError("Parent", "firstName", "is required")
@@ -104,7 +191,9 @@ func TestCommonErrorTypes(t *testing.T) {
 	assert.NotNil(t, domain.ErrInvalidInput)
 	assert.NotNil(t, domain.ErrUnauthorized)
 	assert.NotNil(t, domain.ErrForbidden)
+	assert.NotNil(t, domain.ErrConflict)
 	assert.NotNil(t, domain.ErrInternal)
+	assert.NotNil(t, domain.ErrAborted)
 
 	// Test error messages
 	assert.Equal(t, "entity not found", domain.ErrNotFound.Error())
@@ -113,5 +202,7 @@ func TestCommonErrorTypes(t *testing.T) {
 	assert.Equal(t, "invalid input", domain.ErrInvalidInput.Error())
 	assert.Equal(t, "unauthorized", domain.ErrUnauthorized.Error())
 	assert.Equal(t, "forbidden", domain.ErrForbidden.Error())
+	assert.Equal(t, "conflict", domain.ErrConflict.Error())
 	assert.Equal(t, "internal error", domain.ErrInternal.Error())
+	assert.Equal(t, "aborted, as another item of the batch failed", domain.ErrAborted.Error())
 }
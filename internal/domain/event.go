@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType identifies the kind of change described by a domain event.
+type EventType string
+
+// Event types raised by the family service
+const (
+	EventParentCreated          EventType = "PARENT_CREATED"
+	EventParentUpdated          EventType = "PARENT_UPDATED"
+	EventParentDeleted          EventType = "PARENT_DELETED"
+	EventParentRestored         EventType = "PARENT_RESTORED"
+	EventParentMerged           EventType = "PARENT_MERGED"
+	EventChildCreated           EventType = "CHILD_CREATED"
+	EventChildUpdated           EventType = "CHILD_UPDATED"
+	EventChildDeleted           EventType = "CHILD_DELETED"
+	EventChildRestored          EventType = "CHILD_RESTORED"
+	EventChildAddedToParent     EventType = "CHILD_ADDED_TO_PARENT"
+	EventChildRemovedFromParent EventType = "CHILD_REMOVED_FROM_PARENT"
+	EventChildTransferred       EventType = "CHILD_TRANSFERRED"
+)
+
+// IsValid checks if the event type is one of the event types raised by the family service.
+// Returns:
+//   - bool: true if the event type is known, false otherwise
+func (t EventType) IsValid() bool {
+	switch t {
+	case EventParentCreated, EventParentUpdated, EventParentDeleted, EventParentRestored, EventParentMerged,
+		EventChildCreated, EventChildUpdated, EventChildDeleted, EventChildRestored,
+		EventChildAddedToParent, EventChildRemovedFromParent, EventChildTransferred:
+		return true
+	}
+	return false
+}
+
+// Event represents a change to a parent or child that has been persisted.
+// Every event belongs to the family of the parent identified by ParentID, within the
+// tenant identified by TenantID. Child events also carry the ChildID. Parent and Child
+// hold a snapshot of the entity after the change, when one is available.
+// A child transferred between parents also names the parent it left, in PreviousParentID,
+// and the reason given for the transfer.
+type Event struct {
+	ID               uuid.UUID  `json:"id"`
+	Type             EventType  `json:"type"`
+	TenantID         string     `json:"tenantId,omitempty"`
+	ParentID         uuid.UUID  `json:"parentId"`
+	ChildID          uuid.UUID  `json:"childId"`
+	PreviousParentID *uuid.UUID `json:"previousParentId,omitempty"`
+	Reason           string     `json:"reason,omitempty"`
+	Parent           *Parent    `json:"parent,omitempty"`
+	Child            *Child     `json:"child,omitempty"`
+	OccurredAt       time.Time  `json:"occurredAt"`
+}
+
+// NewEvent creates a new Event with a generated UUID and the current UTC time.
+// Parameters:
+//   - eventType: The kind of change
+//   - parentID: The UUID of the parent whose family changed
+//   - childID: The UUID of the child that changed, or uuid.Nil for parent events
+//
+// Returns:
+//   - Event: The newly created event
+func NewEvent(eventType EventType, parentID, childID uuid.UUID) Event {
+	return Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		ParentID:   parentID,
+		ChildID:    childID,
+		OccurredAt: time.Now().UTC(),
+	}
+}
+
+// NewParentEvent creates a new Event carrying a snapshot of the parent.
+// Parameters:
+//   - eventType: The kind of change
+//   - parent: The parent after the change
+//
+// Returns:
+//   - Event: The newly created event
+func NewParentEvent(eventType EventType, parent *Parent) Event {
+	event := NewEvent(eventType, parent.ID, uuid.Nil)
+	event.Parent = parent
+	return event
+}
+
+// NewChildEvent creates a new Event carrying a snapshot of the child.
+// The event belongs to the family of the child's parent.
+// Parameters:
+//   - eventType: The kind of change
+//   - child: The child after the change
+//
+// Returns:
+//   - Event: The newly created event
+func NewChildEvent(eventType EventType, child *Child) Event {
+	event := NewEvent(eventType, child.ParentID, child.ID)
+	event.Child = child
+	return event
+}
+
+// BelongsToFamily checks if the event concerns the family of a parent: the family the event belongs to,
+// or the family a transferred child left.
+// Parameters:
+//   - parentID: The UUID of the parent whose family to check
+//
+// Returns:
+//   - bool: true if the event concerns the parent's family, false otherwise
+func (e Event) BelongsToFamily(parentID uuid.UUID) bool {
+	return e.ParentID == parentID || (e.PreviousParentID != nil && *e.PreviousParentID == parentID)
+}
+
+// IsParentEvent checks if the event describes a change to a parent.
+// Returns:
+//   - bool: true for parent events, false for child events
+func (e Event) IsParentEvent() bool {
+	return e.ChildID == uuid.Nil
+}
+
+// IsChildEvent checks if the event describes a change to a child.
+// Returns:
+//   - bool: true for child events, false for parent events
+func (e Event) IsChildEvent() bool {
+	return e.ChildID != uuid.Nil
+}
@@ -0,0 +1,89 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewParentEvent(t *testing.T) {
+	// Arrange
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	// Act
+	event := domain.NewParentEvent(domain.EventParentCreated, parent)
+
+	// Assert
+	assert.NotEqual(t, uuid.Nil, event.ID)
+	assert.Equal(t, domain.EventParentCreated, event.Type)
+	assert.Equal(t, parent.ID, event.ParentID)
+	assert.Equal(t, uuid.Nil, event.ChildID)
+	assert.Equal(t, parent, event.Parent)
+	assert.Nil(t, event.Child)
+	assert.False(t, event.OccurredAt.IsZero())
+	assert.True(t, event.IsParentEvent())
+	assert.False(t, event.IsChildEvent())
+}
+
+func TestNewChildEvent(t *testing.T) {
+	// Arrange
+	parentID := uuid.New()
+	child := domain.NewChild("Jane", "Doe", time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC), parentID)
+
+	// Act
+	event := domain.NewChildEvent(domain.EventChildUpdated, child)
+
+	// Assert
+	assert.Equal(t, domain.EventChildUpdated, event.Type)
+	assert.Equal(t, parentID, event.ParentID)
+	assert.Equal(t, child.ID, event.ChildID)
+	assert.Equal(t, child, event.Child)
+	assert.Nil(t, event.Parent)
+	assert.True(t, event.IsChildEvent())
+	assert.False(t, event.IsParentEvent())
+}
+
+func TestNewEvent(t *testing.T) {
+	// Arrange
+	parentID := uuid.New()
+	childID := uuid.New()
+
+	// Act
+	event := domain.NewEvent(domain.EventChildRemovedFromParent, parentID, childID)
+
+	// Assert
+	assert.Equal(t, domain.EventChildRemovedFromParent, event.Type)
+	assert.Equal(t, parentID, event.ParentID)
+	assert.Equal(t, childID, event.ChildID)
+	assert.Nil(t, event.Parent)
+	assert.Nil(t, event.Child)
+}
+
+func TestEventType_IsValid(t *testing.T) {
+	assert.True(t, domain.EventChildCreated.IsValid())
+	assert.True(t, domain.EventChildRemovedFromParent.IsValid())
+	assert.True(t, domain.EventParentRestored.IsValid())
+	assert.True(t, domain.EventParentMerged.IsValid())
+	assert.True(t, domain.EventChildTransferred.IsValid())
+	assert.False(t, domain.EventType("CHILD_RENAMED").IsValid())
+	assert.False(t, domain.EventType("").IsValid())
+}
+
+func TestEvent_BelongsToFamily(t *testing.T) {
+	// Arrange
+	fromParentID := uuid.New()
+	toParentID := uuid.New()
+	event := domain.NewEvent(domain.EventChildTransferred, toParentID, uuid.New())
+
+	// Assert the event belongs to the family the child joins
+	assert.True(t, event.BelongsToFamily(toParentID))
+	assert.False(t, event.BelongsToFamily(fromParentID))
+
+	// Assert a transfer also concerns the family the child left
+	event.PreviousParentID = &fromParentID
+	assert.True(t, event.BelongsToFamily(fromParentID))
+	assert.False(t, event.BelongsToFamily(uuid.New()))
+}
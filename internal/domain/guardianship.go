@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GuardianshipType is how a parent or guardian is related to a child.
+type GuardianshipType string
+
+// Types of guardianships
+const (
+	// GuardianshipMother is the child's mother
+	GuardianshipMother GuardianshipType = "MOTHER"
+	// GuardianshipFather is the child's father
+	GuardianshipFather GuardianshipType = "FATHER"
+	// GuardianshipGuardian is a legal guardian of the child, such as a step-parent or relative
+	GuardianshipGuardian GuardianshipType = "GUARDIAN"
+	// GuardianshipFoster is a foster parent of the child
+	GuardianshipFoster GuardianshipType = "FOSTER"
+)
+
+// IsValid checks if the guardianship type is one of the known types.
+// Returns:
+//   - bool: true if the type is known, false otherwise
+func (t GuardianshipType) IsValid() bool {
+	switch t {
+	case GuardianshipMother, GuardianshipFather, GuardianshipGuardian, GuardianshipFoster:
+		return true
+	}
+	return false
+}
+
+// Guardianship relates a parent or guardian to a child. A child may have several guardianships
+// at once, such as two parents and a step-parent, and exactly one of its active guardianships
+// is the primary contact; the child's ParentID is the parent of that guardianship.
+// A guardianship that ends is kept with its end date, so that past guardians remain known.
+type Guardianship struct {
+	ID             uuid.UUID        `json:"id" bson:"_id"`
+	ParentID       uuid.UUID        `json:"parentId" bson:"parentId" validate:"required"`
+	ChildID        uuid.UUID        `json:"childId" bson:"childId" validate:"required"`
+	TenantID       string           `json:"tenantId,omitempty" bson:"tenantId"`
+	Type           GuardianshipType `json:"type" bson:"type" validate:"required"`
+	StartDate      time.Time        `json:"startDate" bson:"startDate" validate:"required"`
+	EndDate        *time.Time       `json:"endDate,omitempty" bson:"endDate,omitempty"`
+	PrimaryContact bool             `json:"primaryContact" bson:"primaryContact"`
+	CreatedAt      time.Time        `json:"createdAt" bson:"createdAt"`
+	UpdatedAt      time.Time        `json:"updatedAt" bson:"updatedAt"`
+}
+
+// NewGuardianship creates a new active Guardianship with a generated UUID and timestamps.
+// Parameters:
+//   - parentID: The UUID of the parent or guardian
+//   - childID: The UUID of the child
+//   - guardianshipType: How the parent is related to the child
+//   - startDate: The date the guardianship started
+//   - primaryContact: Whether the parent is the primary contact of the child
+//
+// Returns:
+//   - *Guardianship: A pointer to the newly created guardianship
+func NewGuardianship(parentID, childID uuid.UUID, guardianshipType GuardianshipType, startDate time.Time, primaryContact bool) *Guardianship {
+	now := time.Now().UTC()
+	return &Guardianship{
+		ID:             uuid.New(),
+		ParentID:       parentID,
+		ChildID:        childID,
+		Type:           guardianshipType,
+		StartDate:      startDate.UTC(),
+		PrimaryContact: primaryContact,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// IsActive checks if the guardianship has not ended.
+// Returns:
+//   - bool: true if the guardianship has no end date, false otherwise
+func (g *Guardianship) IsActive() bool {
+	return g.EndDate == nil
+}
+
+// End ends the guardianship now. An ended guardianship is no longer the primary contact.
+func (g *Guardianship) End() {
+	now := time.Now().UTC()
+	g.EndDate = &now
+	g.PrimaryContact = false
+	g.UpdatedAt = now
+}
+
+// SetPrimaryContact sets whether the parent is the primary contact of the child.
+// Parameters:
+//   - primaryContact: Whether the parent is the primary contact
+func (g *Guardianship) SetPrimaryContact(primaryContact bool) {
+	g.PrimaryContact = primaryContact
+	g.UpdatedAt = time.Now().UTC()
+}
+
+// ActiveGuardianships returns the guardianships that have not ended, in the given order.
+// Parameters:
+//   - guardianships: The guardianships of a child or parent
+//
+// Returns:
+//   - []*Guardianship: The active guardianships
+func ActiveGuardianships(guardianships []*Guardianship) []*Guardianship {
+	active := make([]*Guardianship, 0, len(guardianships))
+	for _, guardianship := range guardianships {
+		if guardianship.IsActive() {
+			active = append(active, guardianship)
+		}
+	}
+	return active
+}
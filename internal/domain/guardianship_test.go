@@ -0,0 +1,76 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuardianshipType_IsValid(t *testing.T) {
+	for _, guardianshipType := range []domain.GuardianshipType{
+		domain.GuardianshipMother, domain.GuardianshipFather, domain.GuardianshipGuardian, domain.GuardianshipFoster,
+	} {
+		assert.True(t, guardianshipType.IsValid(), guardianshipType)
+	}
+	assert.False(t, domain.GuardianshipType("UNCLE").IsValid())
+	assert.False(t, domain.GuardianshipType("").IsValid())
+}
+
+func TestNewGuardianship(t *testing.T) {
+	// Arrange
+	parentID := uuid.New()
+	childID := uuid.New()
+	startDate := time.Date(2020, 6, 1, 2, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	// Act
+	guardianship := domain.NewGuardianship(parentID, childID, domain.GuardianshipFoster, startDate, true)
+
+	// Assert
+	assert.NotEqual(t, uuid.Nil, guardianship.ID)
+	assert.Equal(t, parentID, guardianship.ParentID)
+	assert.Equal(t, childID, guardianship.ChildID)
+	assert.Equal(t, domain.GuardianshipFoster, guardianship.Type)
+	assert.Equal(t, time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), guardianship.StartDate)
+	assert.True(t, guardianship.PrimaryContact)
+	assert.True(t, guardianship.IsActive())
+}
+
+func TestGuardianship_End(t *testing.T) {
+	// Arrange
+	guardianship := domain.NewGuardianship(uuid.New(), uuid.New(), domain.GuardianshipMother, time.Now(), true)
+	other := domain.NewGuardianship(uuid.New(), guardianship.ChildID, domain.GuardianshipFather, time.Now(), false)
+
+	// Act
+	guardianship.End()
+
+	// Assert that an ended guardianship is no longer the primary contact
+	require.NotNil(t, guardianship.EndDate)
+	assert.False(t, guardianship.IsActive())
+	assert.False(t, guardianship.PrimaryContact)
+	assert.Equal(t, []*domain.Guardianship{other}, domain.ActiveGuardianships([]*domain.Guardianship{guardianship, other}))
+}
+
+func TestChild_GuardiansSnapshot(t *testing.T) {
+	// Arrange
+	father := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	mother := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	child := domain.NewChild("Jane", "Doe", time.Date(2015, 1, 2, 0, 0, 0, 0, time.UTC), father)
+	ended := domain.NewGuardianship(uuid.New(), child.ID, domain.GuardianshipFoster, time.Now(), false)
+	ended.End()
+	guardianships := []*domain.Guardianship{
+		domain.NewGuardianship(mother, child.ID, domain.GuardianshipMother, time.Now(), false),
+		domain.NewGuardianship(father, child.ID, domain.GuardianshipFather, time.Now(), true),
+		ended,
+	}
+
+	// Act
+	snapshot := child.GuardiansSnapshot(guardianships)
+
+	// Assert the active guardians are listed in a stable order, with the primary contact marked
+	assert.Equal(t, father.String()+":FATHER:primary,"+mother.String()+":MOTHER", snapshot["guardians"])
+	assert.Equal(t, father.String(), snapshot["parentId"])
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Revision is a version of a parent or child as it was recorded by a change.
+// Every write of an entity, including marking it as deleted and restoring it, records a revision,
+// so the revisions of an entity show what it looked like at any point in time.
+// Exactly one of Parent and Child is set.
+type Revision struct {
+	RecordedAt time.Time `json:"recordedAt"`
+	Parent     *Parent   `json:"parent,omitempty"`
+	Child      *Child    `json:"child,omitempty"`
+}
+
+// NewParentRevision creates a revision of a parent.
+// The parent is marked as being as of the time the revision was recorded, so that its children
+// are those it had at that time.
+// Parameters:
+//   - parent: The parent as it was recorded
+//   - recordedAt: The time the revision was recorded
+//
+// Returns:
+//   - *Revision: A pointer to the new revision
+func NewParentRevision(parent *Parent, recordedAt time.Time) *Revision {
+	parent.AsOf = &recordedAt
+	return &Revision{RecordedAt: recordedAt, Parent: parent}
+}
+
+// NewChildRevision creates a revision of a child.
+// Parameters:
+//   - child: The child as it was recorded
+//   - recordedAt: The time the revision was recorded
+//
+// Returns:
+//   - *Revision: A pointer to the new revision
+func NewChildRevision(child *Child, recordedAt time.Time) *Revision {
+	return &Revision{RecordedAt: recordedAt, Child: child}
+}
+
+// EntityID returns the ID of the parent or child of the revision.
+// Returns:
+//   - uuid.UUID: The ID of the revised entity
+func (r *Revision) EntityID() uuid.UUID {
+	if r.Parent != nil {
+		return r.Parent.ID
+	}
+	return r.Child.ID
+}
+
+// Version returns the version of the parent or child of the revision.
+// Returns:
+//   - int: The version the entity had after the change that recorded the revision
+func (r *Revision) Version() int {
+	if r.Parent != nil {
+		return r.Parent.Version
+	}
+	return r.Child.Version
+}
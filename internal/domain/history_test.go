@@ -0,0 +1,47 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewParentRevision(t *testing.T) {
+	// Arrange
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	parent.Version = 3
+	recordedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	revision := domain.NewParentRevision(parent, recordedAt)
+
+	// Assert the parent is marked as being as of the time of the revision
+	assert.Equal(t, recordedAt, revision.RecordedAt)
+	assert.Same(t, parent, revision.Parent)
+	assert.Nil(t, revision.Child)
+	require.NotNil(t, parent.AsOf)
+	assert.Equal(t, recordedAt, *parent.AsOf)
+	assert.Equal(t, parent.ID, revision.EntityID())
+	assert.Equal(t, 3, revision.Version())
+}
+
+func TestNewChildRevision(t *testing.T) {
+	// Arrange
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Now().AddDate(-30, 0, 0))
+	child := domain.NewChild("Jane", "Doe", time.Now().AddDate(-5, 0, 0), parent.ID)
+	child.Version = 2
+	recordedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	revision := domain.NewChildRevision(child, recordedAt)
+
+	// Assert
+	assert.Equal(t, recordedAt, revision.RecordedAt)
+	assert.Same(t, child, revision.Child)
+	assert.Nil(t, revision.Parent)
+	assert.Equal(t, child.ID, revision.EntityID())
+	assert.Equal(t, 2, revision.Version())
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Household groups the parents and children who live together, with their shared address and
+// phone numbers. A child belongs to at most one household at a time; a parent may belong to
+// several, such as the households of separated parents who share custody.
+type Household struct {
+	ID           uuid.UUID   `json:"id" bson:"_id"`
+	TenantID     string      `json:"tenantId,omitempty" bson:"tenantId"`
+	Name         string      `json:"name" bson:"name" validate:"required"`
+	Address      Address     `json:"address" bson:"address" validate:"required"`
+	PhoneNumbers []string    `json:"phoneNumbers" bson:"phoneNumbers" validate:"dive,required,phone"`
+	ParentIDs    []uuid.UUID `json:"parentIds" bson:"parentIds"`
+	ChildIDs     []uuid.UUID `json:"childIds" bson:"childIds"`
+	CreatedAt    time.Time   `json:"createdAt" bson:"createdAt"`
+	UpdatedAt    time.Time   `json:"updatedAt" bson:"updatedAt"`
+}
+
+// NewHousehold creates a new Household with a generated UUID and timestamps.
+// Parameters:
+//   - name: The name of the household, such as "The Doe family"
+//   - address: The address the members share
+//   - phoneNumbers: The phone numbers of the household
+//   - parentIDs: The UUIDs of the parents who live in the household
+//   - childIDs: The UUIDs of the children who live in the household
+//
+// Returns:
+//   - *Household: A pointer to the newly created household
+func NewHousehold(name string, address Address, phoneNumbers []string, parentIDs, childIDs []uuid.UUID) *Household {
+	now := time.Now().UTC()
+	household := &Household{
+		ID:           uuid.New(),
+		Name:         name,
+		Address:      address,
+		PhoneNumbers: append([]string{}, phoneNumbers...),
+		ParentIDs:    []uuid.UUID{},
+		ChildIDs:     []uuid.UUID{},
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	// Members listed twice are only added once
+	for _, parentID := range parentIDs {
+		if !household.HasParent(parentID) {
+			household.ParentIDs = append(household.ParentIDs, parentID)
+		}
+	}
+	for _, childID := range childIDs {
+		if !household.HasChild(childID) {
+			household.ChildIDs = append(household.ChildIDs, childID)
+		}
+	}
+
+	return household
+}
+
+// HasParent checks if a parent lives in the household.
+// Parameters:
+//   - parentID: The UUID of the parent
+//
+// Returns:
+//   - bool: true if the parent is a member of the household, false otherwise
+func (h *Household) HasParent(parentID uuid.UUID) bool {
+	for _, id := range h.ParentIDs {
+		if id == parentID {
+			return true
+		}
+	}
+	return false
+}
+
+// HasChild checks if a child lives in the household.
+// Parameters:
+//   - childID: The UUID of the child
+//
+// Returns:
+//   - bool: true if the child is a member of the household, false otherwise
+func (h *Household) HasChild(childID uuid.UUID) bool {
+	for _, id := range h.ChildIDs {
+		if id == childID {
+			return true
+		}
+	}
+	return false
+}
+
+// AddChild makes a child a member of the household, unless it already is.
+// Parameters:
+//   - childID: The UUID of the child to add
+//
+// Returns:
+//   - bool: true if the child was added, false if it was already a member
+func (h *Household) AddChild(childID uuid.UUID) bool {
+	if h.HasChild(childID) {
+		return false
+	}
+
+	h.ChildIDs = append(h.ChildIDs, childID)
+	h.UpdatedAt = time.Now().UTC()
+	return true
+}
+
+// RemoveChild removes a child from the members of the household.
+// Parameters:
+//   - childID: The UUID of the child to remove
+//
+// Returns:
+//   - bool: true if the child was found and removed, false otherwise
+func (h *Household) RemoveChild(childID uuid.UUID) bool {
+	for i, id := range h.ChildIDs {
+		if id == childID {
+			h.ChildIDs = append(h.ChildIDs[:i], h.ChildIDs[i+1:]...)
+			h.UpdatedAt = time.Now().UTC()
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHousehold(t *testing.T) {
+	// Arrange
+	parentID := uuid.New()
+	childID := uuid.New()
+	address := domain.Address{Street: "1 Main St", City: "Springfield", PostalCode: "62701", Country: "US"}
+
+	// Act
+	household := domain.NewHousehold("The Doe family", address, []string{"+12175550100"},
+		[]uuid.UUID{parentID, parentID}, []uuid.UUID{childID, childID})
+
+	// Assert that members listed twice are added once
+	assert.NotEqual(t, uuid.Nil, household.ID)
+	assert.Equal(t, "The Doe family", household.Name)
+	assert.Equal(t, []uuid.UUID{parentID}, household.ParentIDs)
+	assert.Equal(t, []uuid.UUID{childID}, household.ChildIDs)
+	assert.True(t, household.HasParent(parentID))
+	assert.False(t, household.HasParent(childID))
+	assert.Equal(t, "1 Main St, Springfield, 62701, US", household.Address.String())
+}
+
+func TestHousehold_AddRemoveChild(t *testing.T) {
+	// Arrange
+	household := domain.NewHousehold("The Doe family", domain.Address{}, nil, []uuid.UUID{uuid.New()}, nil)
+	childID := uuid.New()
+
+	// Act and assert
+	assert.True(t, household.AddChild(childID))
+	assert.False(t, household.AddChild(childID))
+	assert.True(t, household.HasChild(childID))
+	assert.True(t, household.RemoveChild(childID))
+	assert.False(t, household.RemoveChild(childID))
+	assert.Empty(t, household.ChildIDs)
+}
+
+func TestHousehold_AuditSnapshot(t *testing.T) {
+	// Arrange
+	first := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	second := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	household := domain.NewHousehold("The Doe family", domain.Address{Street: "1 Main St", City: "Springfield", PostalCode: "62701", Country: "US"},
+		[]string{"+12175550100"}, []uuid.UUID{second, first}, nil)
+
+	// Act
+	snapshot := household.AuditSnapshot()
+
+	// Assert the members are listed in a stable order
+	assert.Equal(t, "The Doe family", snapshot["name"])
+	assert.Equal(t, "1 Main St, Springfield, 62701, US", snapshot["address"])
+	assert.Equal(t, first.String()+","+second.String(), snapshot["parents"])
+}
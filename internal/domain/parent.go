@@ -17,10 +17,25 @@ type Parent struct {
 	LastName  string     `json:"lastName" bson:"lastName" validate:"required"`
 	Email     string     `json:"email" bson:"email" validate:"required,email"`
 	BirthDate time.Time  `json:"birthDate" bson:"birthDate" validate:"required"`
+	UserID    string     `json:"userId,omitempty" bson:"userId,omitempty"`
+	TenantID  string     `json:"tenantId,omitempty" bson:"tenantId"`
 	Children  []Child    `json:"children,omitempty" bson:"children,omitempty"`
 	CreatedAt time.Time  `json:"createdAt" bson:"createdAt"`
 	UpdatedAt time.Time  `json:"updatedAt" bson:"updatedAt"`
+	Version   int        `json:"version" bson:"version"`
 	DeletedAt *time.Time `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+
+	// ContactDetails are the phone numbers, postal address and emergency contacts of the parent.
+	// They are stored with the parent, as subdocuments or a JSONB column.
+	ContactDetails `bson:",inline"`
+
+	// MergedInto is the ID of the parent this parent was merged into as a duplicate, if any.
+	// A merged parent is marked as deleted, and keeps the ID when it is restored.
+	MergedInto *uuid.UUID `json:"mergedInto,omitempty" bson:"mergedInto,omitempty"`
+
+	// AsOf is set on a parent reconstructed from its history, to the time it is as of.
+	// Its children are then the children it had at that time. It is never stored.
+	AsOf *time.Time `json:"-" bson:"-"`
 }
 
 // Ensure Parent implements Entity interface
@@ -50,6 +65,14 @@ func (p *Parent) GetUpdatedAt() time.Time {
 	return p.UpdatedAt
 }
 
+// GetVersion returns the parent's version.
+// This method implements the Entity interface.
+// Returns:
+//   - int: The version of the parent, incremented on every write
+func (p *Parent) GetVersion() int {
+	return p.Version
+}
+
 // GetDeletedAt returns the parent's deletion timestamp, if any.
 // This method implements the Entity interface.
 // Returns:
@@ -80,6 +103,7 @@ func NewParent(firstName, lastName, email string, birthDate time.Time) *Parent {
 		Children:  []Child{},
 		CreatedAt: now,
 		UpdatedAt: now,
+		Version:   1,
 	}
 }
 
@@ -119,6 +143,13 @@ func (p *Parent) MarkAsDeleted() {
 	p.UpdatedAt = now
 }
 
+// Restore unmarks the parent as deleted by clearing the DeletedAt timestamp.
+// It undoes MarkAsDeleted, as long as the parent has not been purged.
+func (p *Parent) Restore() {
+	p.DeletedAt = nil
+	p.UpdatedAt = time.Now().UTC()
+}
+
 // IsDeleted checks if the parent is marked as deleted.
 // Returns:
 //   - bool: true if the parent has been marked as deleted, false otherwise
@@ -141,6 +172,31 @@ func (p *Parent) Update(firstName, lastName, email string, birthDate time.Time)
 	p.UpdatedAt = time.Now().UTC()
 }
 
+// UpdateContactDetails replaces the parent's phone numbers, postal address and emergency contacts.
+// Parameters:
+//   - contact: The new contact details
+func (p *Parent) UpdateContactDetails(contact ContactDetails) {
+	p.ContactDetails = contact
+	p.UpdatedAt = time.Now().UTC()
+}
+
+// MergeInto records that the parent is a duplicate of another parent, into which it was merged.
+// Parameters:
+//   - survivorID: The UUID of the parent that was kept
+func (p *Parent) MergeInto(survivorID uuid.UUID) {
+	p.MergedInto = &survivorID
+	p.UpdatedAt = time.Now().UTC()
+}
+
+// LinkUser links the parent to the user account with the given ID, so that the user
+// can access the parent's family. An empty user ID removes the link.
+// Parameters:
+//   - userID: The ID of the user account, as found in the subject of its tokens
+func (p *Parent) LinkUser(userID string) {
+	p.UserID = userID
+	p.UpdatedAt = time.Now().UTC()
+}
+
 // FullName returns the full name of the parent by concatenating the first and last names.
 // Returns:
 //   - string: The full name in the format "FirstName LastName"
@@ -7,6 +7,7 @@ import (
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewParent(t *testing.T) {
@@ -29,6 +30,7 @@ func TestNewParent(t *testing.T) {
 	assert.Empty(t, parent.Children)
 	assert.False(t, parent.CreatedAt.IsZero())
 	assert.False(t, parent.UpdatedAt.IsZero())
+	assert.Equal(t, 1, parent.Version)
 	assert.Nil(t, parent.DeletedAt)
 }
 
@@ -98,6 +100,24 @@ func TestParent_MarkAsDeleted(t *testing.T) {
 	assert.True(t, parent.IsDeleted())
 }
 
+func TestParent_Restore(t *testing.T) {
+	// Arrange
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC))
+	parent.MarkAsDeleted()
+	deletedUpdatedAt := parent.UpdatedAt
+
+	// Wait a moment to ensure UpdatedAt will be different
+	time.Sleep(1 * time.Millisecond)
+
+	// Act
+	parent.Restore()
+
+	// Assert
+	assert.Nil(t, parent.DeletedAt)
+	assert.True(t, parent.UpdatedAt.After(deletedUpdatedAt))
+	assert.False(t, parent.IsDeleted())
+}
+
 func TestParent_Update(t *testing.T) {
 	// Arrange
 	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC))
@@ -122,6 +142,68 @@ func TestParent_Update(t *testing.T) {
 	assert.True(t, parent.UpdatedAt.After(initialUpdatedAt))
 }
 
+func TestParent_LinkUser(t *testing.T) {
+	// Arrange
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC))
+	initialUpdatedAt := parent.UpdatedAt
+
+	// Wait a moment to ensure UpdatedAt will be different
+	time.Sleep(1 * time.Millisecond)
+
+	// Act
+	parent.LinkUser("user-123")
+
+	// Assert
+	assert.Equal(t, "user-123", parent.UserID)
+	assert.True(t, parent.UpdatedAt.After(initialUpdatedAt))
+
+	// Act: an empty user ID removes the link
+	parent.LinkUser("")
+
+	// Assert
+	assert.Empty(t, parent.UserID)
+}
+
+func TestParent_UpdateContactDetails(t *testing.T) {
+	// Arrange
+	parent := domain.NewParent("John", "Doe", "john.doe@example.com", time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC))
+	initialUpdatedAt := parent.UpdatedAt
+
+	// Wait a moment to ensure UpdatedAt will be different
+	time.Sleep(1 * time.Millisecond)
+
+	// Act
+	parent.UpdateContactDetails(domain.ContactDetails{
+		Phones:  []domain.Phone{{Number: "+12175550100", Type: domain.PhoneHome}},
+		Address: &domain.Address{Street: "1 Main St", City: "Springfield", PostalCode: "62701", Country: "US"},
+	})
+
+	// Assert
+	assert.True(t, parent.HasPhone("+12175550100"))
+	assert.Equal(t, "62701", parent.Address.PostalCode)
+	assert.True(t, parent.UpdatedAt.After(initialUpdatedAt))
+	assert.Equal(t, "+12175550100 (HOME)", parent.AuditSnapshot()["phones"])
+}
+
+func TestParent_MergeInto(t *testing.T) {
+	// Arrange
+	parent := domain.NewParent("Jon", "Doe", "jon.doe@example.com", time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC))
+	survivorID := uuid.New()
+	initialUpdatedAt := parent.UpdatedAt
+
+	// Wait a moment to ensure UpdatedAt will be different
+	time.Sleep(1 * time.Millisecond)
+
+	// Act
+	parent.MergeInto(survivorID)
+
+	// Assert
+	require.NotNil(t, parent.MergedInto)
+	assert.Equal(t, survivorID, *parent.MergedInto)
+	assert.True(t, parent.UpdatedAt.After(initialUpdatedAt))
+	assert.Equal(t, survivorID.String(), parent.AuditSnapshot()["mergedInto"])
+}
+
 func TestParent_FullName(t *testing.T) {
 	// Arrange
 	firstName := "John"
@@ -0,0 +1,210 @@
+package domain
+
+import (
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookSubscription registers an https URL that receives an HTTP callback for every event of the
+// subscribed types within a tenant. The payloads are signed with the subscription's secret,
+// so that the receiver can check that they come from the family service.
+type WebhookSubscription struct {
+	ID         uuid.UUID   `json:"id" bson:"_id"`
+	TenantID   string      `json:"tenantId,omitempty" bson:"tenantId"`
+	URL        string      `json:"url" bson:"url" validate:"required,url"`
+	EventTypes []EventType `json:"eventTypes" bson:"eventTypes" validate:"required,min=1"`
+	Secret     string      `json:"-" bson:"secret" validate:"required,min=16"`
+	CreatedAt  time.Time   `json:"createdAt" bson:"createdAt"`
+}
+
+// NewWebhookSubscription creates a new WebhookSubscription with a generated UUID and the current UTC time.
+// Parameters:
+//   - url: The URL that receives the callbacks, see ValidateWebhookURL
+//   - eventTypes: The types of the events delivered to the URL
+//   - secret: The secret the payloads are signed with
+//
+// Returns:
+//   - *WebhookSubscription: A pointer to the newly created subscription
+func NewWebhookSubscription(url string, eventTypes []EventType, secret string) *WebhookSubscription {
+	return &WebhookSubscription{
+		ID:         uuid.New(),
+		URL:        url,
+		EventTypes: eventTypes,
+		Secret:     secret,
+		CreatedAt:  time.Now().UTC(),
+	}
+}
+
+// Matches checks if events of the given type are delivered to the subscription.
+// Parameters:
+//   - eventType: The type of an event
+//
+// Returns:
+//   - bool: true if the subscription subscribes to the event type, false otherwise
+func (s *WebhookSubscription) Matches(eventType EventType) bool {
+	return slices.Contains(s.EventTypes, eventType)
+}
+
+// ValidateWebhookURL checks that callbacks can be delivered to a URL: it must be an absolute https URL
+// whose host is not local, nor a loopback, link-local, private or unspecified address, so that
+// subscribers cannot make the service call itself or the network it runs in. Host names are
+// checked when the callbacks are delivered, once they are resolved.
+// Parameters:
+//   - rawURL: The URL that receives the callbacks
+//
+// Returns:
+//   - error: A ValidationError if callbacks cannot be delivered to the URL, nil otherwise
+func ValidateWebhookURL(rawURL string) error {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || parsedURL.Scheme != "https" || parsedURL.Hostname() == "" {
+		return NewValidationError("WebhookSubscription", "url", "must be an absolute https URL")
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(parsedURL.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return NewValidationError("WebhookSubscription", "url", "must not be a local address")
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !IsPublicAddress(addr) {
+		return NewValidationError("WebhookSubscription", "url", "must not be a loopback, link-local, private or shared address")
+	}
+	return nil
+}
+
+// nonPublicPrefixes are the ranges that are not reachable from the internet, beyond the loopback, link-local,
+// private, multicast and unspecified addresses netip.Addr tells apart: "this network" (RFC 791), which
+// reaches the host itself on several systems, and the shared address space of carrier-grade NAT (RFC 6598),
+// in which several clouds run internal services, such as a metadata endpoint at 100.100.100.200
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// IsPublicAddress checks if webhook callbacks may be delivered to an IP address.
+// Parameters:
+//   - addr: The IP address of a webhook receiver
+//
+// Returns:
+//   - bool: false if the address is a loopback, link-local, private, shared, multicast or unspecified one,
+//     or in 0.0.0.0/8, true otherwise
+func IsPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsMulticast() ||
+		addr.IsPrivate() ||
+		addr.IsUnspecified() {
+		return false
+	}
+
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// DeliveryStatus is the state of the delivery of an event to a webhook subscription.
+type DeliveryStatus string
+
+// Delivery statuses of webhook deliveries
+const (
+	// DeliveryPending is a delivery that has not succeeded yet and will be attempted again
+	DeliveryPending DeliveryStatus = "PENDING"
+	// DeliveryDelivered is a delivery that the receiver acknowledged with a 2xx response
+	DeliveryDelivered DeliveryStatus = "DELIVERED"
+	// DeliveryDeadLetter is a delivery that failed too many times and will not be attempted again
+	DeliveryDeadLetter DeliveryStatus = "DEAD_LETTER"
+)
+
+// IsValid checks if the delivery status is one of the known statuses.
+// Returns:
+//   - bool: true if the status is known, false otherwise
+func (s DeliveryStatus) IsValid() bool {
+	switch s {
+	case DeliveryPending, DeliveryDelivered, DeliveryDeadLetter:
+		return true
+	}
+	return false
+}
+
+// WebhookDelivery tracks the delivery of an event to a webhook subscription.
+// The payload is fixed when the delivery is created, so every attempt sends the same body.
+type WebhookDelivery struct {
+	ID             uuid.UUID      `json:"id" bson:"_id"`
+	SubscriptionID uuid.UUID      `json:"subscriptionId" bson:"subscriptionId"`
+	TenantID       string         `json:"tenantId,omitempty" bson:"tenantId"`
+	EventID        uuid.UUID      `json:"eventId" bson:"eventId"`
+	EventType      EventType      `json:"eventType" bson:"eventType"`
+	Payload        string         `json:"payload" bson:"payload"`
+	Status         DeliveryStatus `json:"status" bson:"status"`
+	Attempts       int            `json:"attempts" bson:"attempts"`
+	NextAttemptAt  time.Time      `json:"nextAttemptAt" bson:"nextAttemptAt"`
+	LastError      string         `json:"lastError,omitempty" bson:"lastError,omitempty"`
+	ResponseStatus int            `json:"responseStatus,omitempty" bson:"responseStatus,omitempty"`
+	CreatedAt      time.Time      `json:"createdAt" bson:"createdAt"`
+	DeliveredAt    *time.Time     `json:"deliveredAt,omitempty" bson:"deliveredAt,omitempty"`
+}
+
+// NewWebhookDelivery creates a new pending delivery of an event to a subscription, due immediately.
+// Parameters:
+//   - subscription: The subscription the event is delivered to
+//   - event: The event to deliver
+//   - payload: The body sent to the subscription's URL
+//
+// Returns:
+//   - *WebhookDelivery: A pointer to the newly created delivery
+func NewWebhookDelivery(subscription *WebhookSubscription, event Event, payload string) *WebhookDelivery {
+	now := time.Now().UTC()
+	return &WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscription.ID,
+		TenantID:       subscription.TenantID,
+		EventID:        event.ID,
+		EventType:      event.Type,
+		Payload:        payload,
+		Status:         DeliveryPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+	}
+}
+
+// MarkDelivered records a successful attempt.
+// Parameters:
+//   - responseStatus: The HTTP status code of the receiver's response
+func (d *WebhookDelivery) MarkDelivered(responseStatus int) {
+	now := time.Now().UTC()
+	d.Attempts++
+	d.Status = DeliveryDelivered
+	d.ResponseStatus = responseStatus
+	d.LastError = ""
+	d.DeliveredAt = &now
+}
+
+// MarkFailed records a failed attempt and when to attempt the delivery again.
+// Parameters:
+//   - responseStatus: The HTTP status code of the receiver's response, or 0 if there was none
+//   - lastError: Why the attempt failed
+//   - nextAttemptAt: The earliest time of the next attempt
+func (d *WebhookDelivery) MarkFailed(responseStatus int, lastError string, nextAttemptAt time.Time) {
+	d.Attempts++
+	d.ResponseStatus = responseStatus
+	d.LastError = lastError
+	d.NextAttemptAt = nextAttemptAt
+}
+
+// MarkDeadLetter gives up on the delivery, which keeps the outcome of its last attempt for debugging.
+// Parameters:
+//   - reason: Why the delivery is given up, which replaces the last error when not empty
+func (d *WebhookDelivery) MarkDeadLetter(reason string) {
+	d.Status = DeliveryDeadLetter
+	if reason != "" {
+		d.LastError = reason
+	}
+}
@@ -0,0 +1,124 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSubscription_Matches(t *testing.T) {
+	// Arrange
+	subscription := domain.NewWebhookSubscription("https://example.com/hooks",
+		[]domain.EventType{domain.EventChildCreated, domain.EventChildDeleted}, "0123456789abcdef")
+
+	// Assert
+	assert.True(t, subscription.Matches(domain.EventChildCreated))
+	assert.True(t, subscription.Matches(domain.EventChildDeleted))
+	assert.False(t, subscription.Matches(domain.EventChildUpdated))
+	assert.False(t, subscription.Matches(domain.EventParentCreated))
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	for _, rawURL := range []string{
+		"https://example.com/hooks",
+		"https://93.184.216.34:8443/hooks",
+		"https://100.128.0.1/hooks",
+		"https://[2606:2800:220:1:248:1893:25c8:1946]/hooks",
+	} {
+		assert.NoError(t, domain.ValidateWebhookURL(rawURL), rawURL)
+	}
+
+	for _, rawURL := range []string{
+		"/hooks",
+		"http://example.com/hooks",
+		"ftp://example.com/hooks",
+		"https:///hooks",
+		"https://localhost/hooks",
+		"https://api.localhost./hooks",
+		"https://127.0.0.1/hooks",
+		"https://[::1]/hooks",
+		"https://[::ffff:127.0.0.1]/hooks",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[fe80::1]/hooks",
+		"https://10.1.2.3/hooks",
+		"https://172.16.0.1/hooks",
+		"https://192.168.1.1/hooks",
+		"https://[fd00::1]/hooks",
+		"https://0.0.0.0/hooks",
+		"https://0.1.2.3/hooks",
+		"https://100.64.0.1/hooks",
+		"https://100.100.100.200/latest/meta-data",
+		"https://100.127.255.254/hooks",
+		"https://[::ffff:100.100.100.200]/hooks",
+	} {
+		err := domain.ValidateWebhookURL(rawURL)
+		assert.ErrorIs(t, err, domain.ErrValidation, rawURL)
+	}
+}
+
+func TestWebhookDelivery_Lifecycle(t *testing.T) {
+	// Arrange
+	subscription := domain.NewWebhookSubscription("https://example.com/hooks",
+		[]domain.EventType{domain.EventChildCreated}, "0123456789abcdef")
+	subscription.TenantID = "tenant-a"
+	event := domain.NewEvent(domain.EventChildCreated, uuid.New(), uuid.New())
+
+	// Act
+	delivery := domain.NewWebhookDelivery(subscription, event, `{}`)
+
+	// Assert a new delivery is pending and due
+	assert.Equal(t, subscription.ID, delivery.SubscriptionID)
+	assert.Equal(t, "tenant-a", delivery.TenantID)
+	assert.Equal(t, event.ID, delivery.EventID)
+	assert.Equal(t, domain.EventChildCreated, delivery.EventType)
+	assert.Equal(t, domain.DeliveryPending, delivery.Status)
+	assert.Zero(t, delivery.Attempts)
+	assert.False(t, delivery.NextAttemptAt.After(time.Now()))
+
+	// Act and assert a failed attempt is recorded
+	nextAttemptAt := time.Now().Add(time.Minute)
+	delivery.MarkFailed(503, "unavailable", nextAttemptAt)
+	assert.Equal(t, domain.DeliveryPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, 503, delivery.ResponseStatus)
+	assert.Equal(t, "unavailable", delivery.LastError)
+	assert.Equal(t, nextAttemptAt, delivery.NextAttemptAt)
+
+	// Act and assert a successful attempt is recorded
+	delivery.MarkDelivered(200)
+	assert.Equal(t, domain.DeliveryDelivered, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.Equal(t, 200, delivery.ResponseStatus)
+	assert.Empty(t, delivery.LastError)
+	require.NotNil(t, delivery.DeliveredAt)
+}
+
+func TestWebhookDelivery_MarkDeadLetter(t *testing.T) {
+	// Arrange
+	subscription := domain.NewWebhookSubscription("https://example.com/hooks",
+		[]domain.EventType{domain.EventChildCreated}, "0123456789abcdef")
+	delivery := domain.NewWebhookDelivery(subscription, domain.NewEvent(domain.EventChildCreated, uuid.New(), uuid.New()), `{}`)
+	delivery.MarkFailed(500, "internal server error", time.Now())
+
+	// Act
+	delivery.MarkDeadLetter("")
+
+	// Assert the outcome of the last attempt is kept
+	assert.Equal(t, domain.DeliveryDeadLetter, delivery.Status)
+	assert.Equal(t, "internal server error", delivery.LastError)
+
+	// Act and assert a reason replaces the last error
+	delivery.MarkDeadLetter("subscription deleted")
+	assert.Equal(t, "subscription deleted", delivery.LastError)
+}
+
+func TestDeliveryStatus_IsValid(t *testing.T) {
+	assert.True(t, domain.DeliveryPending.IsValid())
+	assert.True(t, domain.DeliveryDelivered.IsValid())
+	assert.True(t, domain.DeliveryDeadLetter.IsValid())
+	assert.False(t, domain.DeliveryStatus("LOST").IsValid())
+}
@@ -58,6 +58,9 @@ type Claims struct {
 	// Roles contains the user's assigned roles for authorization
 	Roles []string `json:"roles"`
 
+	// TenantID identifies the tenant whose data the user may access; empty for the default tenant
+	TenantID string `json:"tenant_id,omitempty"`
+
 	// RegisteredClaims contains the standard JWT claims like expiration time
 	jwt.RegisteredClaims
 }
@@ -73,12 +76,26 @@ type Claims struct {
 //   - string: The signed JWT token string if successful
 //   - error: An error if token generation fails
 func (s *JWTService) GenerateToken(userID string, roles []string) (string, error) {
+	return s.GenerateTenantToken(userID, "", roles)
+}
+
+// GenerateTenantToken generates a new JWT token for a user of a tenant with the specified roles.
+// Parameters:
+//   - userID: The unique identifier of the user
+//   - tenantID: The tenant of the user, or an empty string for the default tenant
+//   - roles: The roles assigned to the user for authorization purposes
+//
+// Returns:
+//   - string: The signed JWT token string if successful
+//   - error: An error if token generation fails
+func (s *JWTService) GenerateTenantToken(userID, tenantID string, roles []string) (string, error) {
 	now := time.Now()
 	expiresAt := now.Add(s.config.TokenDuration)
 
 	claims := Claims{
-		UserID: userID,
-		Roles:  roles,
+		UserID:   userID,
+		Roles:    roles,
+		TenantID: tenantID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(now),
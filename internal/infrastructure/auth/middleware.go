@@ -1,10 +1,12 @@
 package auth
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
 
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -47,9 +49,9 @@ func (m *AuthMiddleware) Middleware(next http.Handler) http.Handler {
 		// Extract token from Authorization header
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
-			// No token provided, continue as unauthenticated
+			// Anonymous callers are not linked to any family, so they could not do anything
 			m.logger.Debug("No Authorization header provided")
-			next.ServeHTTP(w, r.WithContext(ctx))
+			http.Error(w, "Authentication required", http.StatusUnauthorized)
 			return
 		}
 
@@ -64,39 +66,47 @@ func (m *AuthMiddleware) Middleware(next http.Handler) http.Handler {
 		tokenString := parts[1]
 		span.SetAttributes(attribute.String("token.length", fmt.Sprintf("%d", len(tokenString))))
 
-		var claims *Claims
-		var err error
-
-		// Try OIDC validation first if available
-		if m.oidcService != nil {
-			claims, err = m.oidcService.ValidateToken(ctx, tokenString)
-			if err != nil {
-				m.logger.Debug("OIDC validation failed, trying JWT", zap.Error(err))
-				// Fall back to JWT validation
-				claims, err = m.jwtService.ValidateToken(tokenString)
-				if err != nil {
-					m.logger.Debug("Invalid token", zap.Error(err))
-					http.Error(w, "Invalid token", http.StatusUnauthorized)
-					return
-				}
-			}
-		} else {
-			// Use JWT validation
-			claims, err = m.jwtService.ValidateToken(tokenString)
-			if err != nil {
-				m.logger.Debug("Invalid token", zap.Error(err))
-				http.Error(w, "Invalid token", http.StatusUnauthorized)
-				return
-			}
+		claims, err := m.validateToken(ctx, tokenString)
+		if err != nil {
+			m.logger.Debug("Invalid token", zap.Error(err))
+			http.Error(w, "Invalid token", http.StatusUnauthorized)
+			return
 		}
 
-		// Add user info to context
+		// Add user info to context; the tenant limits the data every repository can reach
 		ctx = WithUserID(ctx, claims.UserID)
 		ctx = WithUserRoles(ctx, claims.Roles)
+		ctx = ports.WithTenantID(ctx, claims.TenantID)
 
-		span.SetAttributes(attribute.String("user.id", claims.UserID))
+		span.SetAttributes(
+			attribute.String("user.id", claims.UserID),
+			attribute.String("tenant.id", claims.TenantID),
+		)
 
 		// Continue with the request
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// validateToken validates the token with OIDC first, if available, and falls back to JWT
+func (m *AuthMiddleware) validateToken(ctx context.Context, tokenString string) (*Claims, error) {
+	var err error
+
+	if m.oidcService != nil {
+		var claims *Claims
+		claims, err = m.oidcService.ValidateToken(ctx, tokenString)
+		if err == nil {
+			return claims, nil
+		}
+		m.logger.Debug("OIDC validation failed, trying JWT", zap.Error(err))
+	}
+
+	if m.jwtService != nil {
+		return m.jwtService.ValidateToken(tokenString)
+	}
+
+	if err == nil {
+		err = fmt.Errorf("no token validator configured")
+	}
+	return nil, err
+}
@@ -103,20 +103,28 @@ func (s *OIDCService) ValidateToken(ctx context.Context, tokenString string) (*C
 
 	// Extract claims from the ID token
 	var claims struct {
-		Subject string   `json:"sub"`
-		Email   string   `json:"email"`
-		Name    string   `json:"name"`
-		Roles   []string `json:"roles"`
+		Subject  string   `json:"sub"`
+		Email    string   `json:"email"`
+		Name     string   `json:"name"`
+		Roles    []string `json:"roles"`
+		TenantID string   `json:"tenant_id"`
 	}
 	if err := idToken.Claims(&claims); err != nil {
 		s.logger.Debug("Failed to extract claims from ID token", zap.Error(err))
 		return nil, fmt.Errorf("invalid token claims: %w", err)
 	}
 
+	// The authorization service knows the admin role as "admin", whatever the provider calls it
+	roles := claims.Roles
+	if s.config.AdminRoleName != "" && s.config.AdminRoleName != "admin" && s.IsAdmin(roles) {
+		roles = append(roles, "admin")
+	}
+
 	// Create JWT claims
 	jwtClaims := &Claims{
-		UserID: claims.Subject,
-		Roles:  claims.Roles,
+		UserID:   claims.Subject,
+		Roles:    roles,
+		TenantID: claims.TenantID,
 	}
 
 	return jwtClaims, nil
@@ -0,0 +1,331 @@
+package auth
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/file"
+	"github.com/knadh/koanf/v2"
+	"go.uber.org/zap"
+)
+
+// policyReloadDelay is how long the policy file must stay unchanged before it is reloaded
+const policyReloadDelay = 100 * time.Millisecond
+
+const (
+	// AnonymousRole is held by every caller that is not authenticated
+	AnonymousRole = "anonymous"
+
+	// AuthenticatedRole is held by every authenticated caller, in addition to the roles of their token
+	AuthenticatedRole = "authenticated"
+)
+
+// OwnSuffix marks the sub-operation that performs an operation on the caller's own family only,
+// as in "parent:read:own". A caller allowed the operation itself is not limited to their family.
+const OwnSuffix = ":own"
+
+// Operations lists the operations checked by the application.
+// Wildcard permissions are expanded against it when listing the permissions of a caller.
+var Operations = []string{
+	"parent:create",
+	"parent:read",
+	"parent:list",
+	"parent:update",
+	"parent:delete",
+	"parent:restore",
+	"parent:purge",
+	"parent:list-deleted",
+	"parent:link",
+	"parent:list-duplicates",
+	"parent:merge",
+	"parent:read:own",
+	"parent:list:own",
+	"parent:update:own",
+	"child:create",
+	"child:read",
+	"child:list",
+	"child:update",
+	"child:delete",
+	"child:restore",
+	"child:purge",
+	"child:list-deleted",
+	"child:transfer",
+	"child:read:own",
+	"child:list:own",
+	"child:update:own",
+	"household:create",
+	"household:read",
+	"household:update",
+	"household:read:own",
+	"webhook:create",
+	"webhook:delete",
+	"webhook:list",
+	"webhook:list-deliveries",
+	"audit:read",
+}
+
+// RolePolicy holds the permissions granted to and denied to a role
+type RolePolicy struct {
+	Allow []string `mapstructure:"allow"`
+	Deny  []string `mapstructure:"deny"`
+}
+
+// Policy maps roles to their permissions.
+// A permission has the form "resource:action", and either part may use the wildcards
+// of path.Match, so "child:*" grants every child operation and "*" grants everything.
+// A deny rule of any of the caller's roles takes precedence over every allow rule.
+type Policy struct {
+	Roles map[string]RolePolicy `mapstructure:"roles"`
+}
+
+// DefaultPolicy returns the policy used when no policy file is configured:
+// admins can do anything, staff can manage every family but not delete or link
+// and not manage webhooks or read the audit log, guardians can manage their own family,
+// auditors can read every family and its audit log, other authenticated callers can only read
+// their own family, and anonymous callers can do nothing.
+func DefaultPolicy() *Policy {
+	readOnly := []string{"parent:read", "parent:list", "child:read", "child:list"}
+
+	return &Policy{
+		Roles: map[string]RolePolicy{
+			"admin": {Allow: []string{"*"}},
+			"staff": {
+				Allow: []string{"*:read", "*:list", "*:create", "*:update"},
+				Deny:  []string{"webhook:*", "audit:*"},
+			},
+			"auditor": {Allow: append([]string{"audit:read"}, readOnly...)},
+			"guardian": {Allow: []string{
+				"parent:read:own", "parent:list:own", "parent:update:own",
+				"child:read:own", "child:list:own", "child:update:own",
+				"household:read:own",
+			}},
+			AnonymousRole:     {Deny: []string{"*"}},
+			AuthenticatedRole: {Allow: []string{"*:read:own", "*:list:own"}},
+		},
+	}
+}
+
+// Validate checks that the policy defines roles and that every permission is a valid pattern
+func (p *Policy) Validate() error {
+	// A policy without roles denies everything, which is more likely a truncated file than intended
+	if len(p.Roles) == 0 {
+		return fmt.Errorf("policy defines no roles")
+	}
+
+	for role, rolePolicy := range p.Roles {
+		for _, permission := range append(append([]string{}, rolePolicy.Allow...), rolePolicy.Deny...) {
+			if permission == "" {
+				return fmt.Errorf("role %s has an empty permission", role)
+			}
+			if _, err := path.Match(permission, ""); err != nil {
+				return fmt.Errorf("role %s has an invalid permission %q: %w", role, permission, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadPolicyFile loads and validates a YAML policy file
+func LoadPolicyFile(policyPath string) (*Policy, error) {
+	k := koanf.New(".")
+	if err := k.Load(file.Provider(policyPath), yaml.Parser()); err != nil {
+		return nil, fmt.Errorf("error reading policy file %s: %w", policyPath, err)
+	}
+
+	var policy Policy
+	if err := k.UnmarshalWithConf("", &policy, koanf.UnmarshalConf{
+		Tag: "mapstructure",
+	}); err != nil {
+		return nil, fmt.Errorf("unable to decode policy file %s: %w", policyPath, err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", policyPath, err)
+	}
+
+	return &policy, nil
+}
+
+// PolicyEngine evaluates permissions against a policy.
+// The policy can be replaced at any time, for example when its file changes.
+type PolicyEngine struct {
+	mu     sync.RWMutex
+	policy *Policy
+
+	watchMu     sync.Mutex
+	provider    *file.File
+	path        string
+	reloadTimer *time.Timer
+
+	logger *zap.Logger
+}
+
+// NewPolicyEngine creates a policy engine for the given policy
+func NewPolicyEngine(policy *Policy, logger *zap.Logger) (*PolicyEngine, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy: %w", err)
+	}
+
+	return &PolicyEngine{
+		policy: policy,
+		logger: logger,
+	}, nil
+}
+
+// NewPolicyEngineFromFile creates a policy engine for the policy held in a YAML file
+func NewPolicyEngineFromFile(policyPath string, logger *zap.Logger) (*PolicyEngine, error) {
+	policy, err := LoadPolicyFile(policyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Authorization policy loaded", zap.String("path", policyPath), zap.Int("roles", len(policy.Roles)))
+
+	return &PolicyEngine{
+		policy: policy,
+		path:   policyPath,
+		logger: logger,
+	}, nil
+}
+
+// Watch reloads the policy whenever its file changes.
+// A policy that fails to load or validate is logged and ignored, so the last valid policy stays in force.
+func (e *PolicyEngine) Watch() error {
+	if e.path == "" {
+		return fmt.Errorf("policy engine has no policy file to watch")
+	}
+
+	e.watchMu.Lock()
+	defer e.watchMu.Unlock()
+
+	if e.provider != nil {
+		return nil
+	}
+
+	provider := file.Provider(e.path)
+	err := provider.Watch(func(event interface{}, err error) {
+		if err != nil {
+			e.logger.Error("Failed to watch policy file", zap.Error(err), zap.String("path", e.path))
+			return
+		}
+
+		// Editors often write a file in several steps, so wait for the writes to settle
+		e.watchMu.Lock()
+		defer e.watchMu.Unlock()
+		if e.reloadTimer != nil {
+			e.reloadTimer.Stop()
+		}
+		e.reloadTimer = time.AfterFunc(policyReloadDelay, e.reload)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch policy file %s: %w", e.path, err)
+	}
+
+	e.provider = provider
+	return nil
+}
+
+// reload loads the policy file again and replaces the policy if it is valid
+func (e *PolicyEngine) reload() {
+	policy, err := LoadPolicyFile(e.path)
+	if err != nil {
+		e.logger.Error("Failed to reload policy file, keeping the current policy", zap.Error(err), zap.String("path", e.path))
+		return
+	}
+
+	e.SetPolicy(policy)
+	e.logger.Info("Authorization policy reloaded", zap.String("path", e.path), zap.Int("roles", len(policy.Roles)))
+}
+
+// Close stops watching the policy file
+func (e *PolicyEngine) Close() error {
+	e.watchMu.Lock()
+	defer e.watchMu.Unlock()
+
+	if e.provider == nil {
+		return nil
+	}
+
+	if e.reloadTimer != nil {
+		e.reloadTimer.Stop()
+		e.reloadTimer = nil
+	}
+
+	err := e.provider.Unwatch()
+	e.provider = nil
+	return err
+}
+
+// SetPolicy replaces the policy; the policy must be valid
+func (e *PolicyEngine) SetPolicy(policy *Policy) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.policy = policy
+}
+
+// IsAllowed reports whether the given roles are allowed to perform the operation
+func (e *PolicyEngine) IsAllowed(roles []string, operation string) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	allowed := false
+	for _, role := range roles {
+		rolePolicy, ok := e.policy.Roles[role]
+		if !ok {
+			continue
+		}
+
+		if matchesAny(rolePolicy.Deny, operation) {
+			return false
+		}
+		if matchesAny(rolePolicy.Allow, operation) {
+			allowed = true
+		}
+	}
+
+	return allowed
+}
+
+// Permissions returns the known operations that the given roles are allowed to perform, sorted.
+// An ":own" operation is only listed when the operation itself is not allowed.
+func (e *PolicyEngine) Permissions(roles []string) []string {
+	permissions := make([]string, 0, len(Operations))
+	for _, operation := range Operations {
+		if base, ok := strings.CutSuffix(operation, OwnSuffix); ok && e.IsAllowed(roles, base) {
+			continue
+		}
+		if e.IsAllowed(roles, operation) {
+			permissions = append(permissions, operation)
+		}
+	}
+
+	sort.Strings(permissions)
+	return permissions
+}
+
+// matchesAny reports whether the operation matches one of the permission patterns.
+// A pattern also matches the sub-operations of the operation it names, so "*:read" matches "parent:read:own".
+func matchesAny(patterns []string, operation string) bool {
+	for _, pattern := range patterns {
+		for candidate := operation; candidate != ""; {
+			if ok, _ := path.Match(pattern, candidate); ok {
+				return true
+			}
+
+			i := strings.LastIndex(candidate, ":")
+			if i < 0 {
+				break
+			}
+			candidate = candidate[:i]
+		}
+	}
+
+	return false
+}
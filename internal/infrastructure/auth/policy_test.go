@@ -0,0 +1,276 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testPolicyYAML = `
+roles:
+  admin:
+    allow: ["*"]
+  caseworker:
+    allow: ["parent:*", "child:*"]
+    deny: ["parent:delete", "*:purge"]
+  auditor:
+    allow: ["*:read", "*:list"]
+  suspended:
+    deny: ["*"]
+`
+
+// writePolicyFile writes a policy file into a temporary directory and returns its path
+func writePolicyFile(t *testing.T, content string) string {
+	t.Helper()
+
+	policyPath := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(policyPath, []byte(content), 0o600))
+
+	return policyPath
+}
+
+func TestPolicyEngine_DefaultPolicy(t *testing.T) {
+	// Setup
+	logger, _ := zap.NewDevelopment()
+	engine, err := NewPolicyEngine(DefaultPolicy(), logger)
+	require.NoError(t, err)
+
+	// Verify
+	assert.True(t, engine.IsAllowed([]string{"admin"}, "parent:delete"))
+	assert.False(t, engine.IsAllowed([]string{AnonymousRole}, "parent:read"))
+	assert.False(t, engine.IsAllowed([]string{AnonymousRole}, "parent:read:own"))
+	assert.False(t, engine.IsAllowed([]string{AnonymousRole}, "parent:create"))
+	assert.False(t, engine.IsAllowed([]string{"user", AuthenticatedRole}, "child:list"))
+	assert.True(t, engine.IsAllowed([]string{"user", AuthenticatedRole}, "child:list:own"))
+	assert.False(t, engine.IsAllowed([]string{"user", AuthenticatedRole}, "child:update"))
+	assert.False(t, engine.IsAllowed([]string{"user"}, "child:read"))
+}
+
+func TestPolicyEngine_Wildcards(t *testing.T) {
+	// Setup
+	logger, _ := zap.NewDevelopment()
+	policy, err := LoadPolicyFile(writePolicyFile(t, testPolicyYAML))
+	require.NoError(t, err)
+	engine, err := NewPolicyEngine(policy, logger)
+	require.NoError(t, err)
+
+	// Verify
+	testCases := []struct {
+		name      string
+		roles     []string
+		operation string
+		expected  bool
+	}{
+		{"resource wildcard", []string{"caseworker"}, "child:create", true},
+		{"resource wildcard other resource", []string{"caseworker"}, "audit:read", false},
+		{"action wildcard", []string{"auditor"}, "child:read", true},
+		{"action wildcard other action", []string{"auditor"}, "child:update", false},
+		{"deny overrides allow of the same role", []string{"caseworker"}, "parent:delete", false},
+		{"deny overrides allow of another role", []string{"admin", "suspended"}, "parent:read", false},
+		{"unknown role", []string{"visitor"}, "parent:read", false},
+		{"sub-operation", []string{"auditor"}, "parent:read:own", true},
+		{"deleted records are not listed", []string{"auditor"}, "parent:list-deleted", false},
+		{"duplicates are not listed", []string{"auditor"}, "parent:list-duplicates", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, engine.IsAllowed(tc.roles, tc.operation))
+		})
+	}
+}
+
+func TestPolicyEngine_Permissions(t *testing.T) {
+	// Setup
+	logger, _ := zap.NewDevelopment()
+	policy, err := LoadPolicyFile(writePolicyFile(t, testPolicyYAML))
+	require.NoError(t, err)
+	engine, err := NewPolicyEngine(policy, logger)
+	require.NoError(t, err)
+
+	// Execute
+	permissions := engine.Permissions([]string{"caseworker"})
+
+	// Verify
+	assert.Equal(t, []string{
+		"child:create",
+		"child:delete",
+		"child:list",
+		"child:list-deleted",
+		"child:read",
+		"child:restore",
+		"child:transfer",
+		"child:update",
+		"parent:create",
+		"parent:link",
+		"parent:list",
+		"parent:list-deleted",
+		"parent:list-duplicates",
+		"parent:merge",
+		"parent:read",
+		"parent:restore",
+		"parent:update",
+	}, permissions)
+	assert.Empty(t, engine.Permissions([]string{"suspended"}))
+}
+
+func TestLoadPolicyFile_InvalidPermission(t *testing.T) {
+	// Execute
+	_, err := LoadPolicyFile(writePolicyFile(t, "roles:\n  broken:\n    allow: [\"parent:[\"]\n"))
+
+	// Verify
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid permission")
+}
+
+func TestLoadPolicyFile_NoRoles(t *testing.T) {
+	// Execute
+	_, err := LoadPolicyFile(writePolicyFile(t, "roles: {}\n"))
+
+	// Verify
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "policy defines no roles")
+}
+
+func TestLoadPolicyFile_Missing(t *testing.T) {
+	// Execute
+	_, err := LoadPolicyFile(filepath.Join(t.TempDir(), "missing.yaml"))
+
+	// Verify
+	assert.Error(t, err)
+}
+
+func TestPolicyEngine_Watch(t *testing.T) {
+	// Setup
+	logger, _ := zap.NewDevelopment()
+	policyPath := writePolicyFile(t, testPolicyYAML)
+	engine, err := NewPolicyEngineFromFile(policyPath, logger)
+	require.NoError(t, err)
+	require.NoError(t, engine.Watch())
+	defer engine.Close()
+
+	require.False(t, engine.IsAllowed([]string{"caseworker"}, "parent:delete"))
+
+	// Execute: an invalid policy is ignored
+	require.NoError(t, os.WriteFile(policyPath, []byte("roles:\n  caseworker:\n    allow: [\"[\"]\n"), 0o600))
+	time.Sleep(3 * policyReloadDelay)
+	assert.True(t, engine.IsAllowed([]string{"caseworker"}, "child:create"))
+
+	// Execute: a valid policy replaces the current one
+	require.NoError(t, os.WriteFile(policyPath, []byte("roles:\n  caseworker:\n    allow: [\"*\"]\n"), 0o600))
+
+	// Verify
+	assert.Eventually(t, func() bool {
+		return engine.IsAllowed([]string{"caseworker"}, "parent:delete")
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+func TestAuthorizationService_GetPermissions(t *testing.T) {
+	// Setup
+	logger, _ := zap.NewDevelopment()
+	service := NewAuthorizationService(logger)
+
+	// Anonymous callers get the permissions of the anonymous role, which are none
+	permissions, err := service.GetPermissions(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, permissions)
+
+	// Admins get every known operation, without the ":own" operations their permissions supersede
+	ctx := WithUserRoles(WithUserID(context.Background(), "admin-user"), []string{"admin"})
+	permissions, err = service.GetPermissions(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, permissions, "parent:link")
+	for _, permission := range permissions {
+		assert.NotContains(t, permission, OwnSuffix)
+	}
+
+	// Guardians only get their own family's operations
+	ctx = WithUserRoles(WithUserID(context.Background(), "guardian-user"), []string{"guardian"})
+	permissions, err = service.GetPermissions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"child:list:own",
+		"child:read:own",
+		"child:update:own",
+		"household:read:own",
+		"parent:list:own",
+		"parent:read:own",
+		"parent:update:own",
+	}, permissions)
+}
+
+func TestPolicyEngine_DefaultPolicyRoles(t *testing.T) {
+	// Setup
+	logger, _ := zap.NewDevelopment()
+	engine, err := NewPolicyEngine(DefaultPolicy(), logger)
+	require.NoError(t, err)
+
+	// Verify
+	testCases := []struct {
+		name      string
+		roles     []string
+		operation string
+		expected  bool
+	}{
+		{"staff reads every family", []string{"staff"}, "parent:read", true},
+		{"staff updates every family", []string{"staff"}, "child:update", true},
+		{"staff cannot delete", []string{"staff"}, "parent:delete", false},
+		{"staff cannot link", []string{"staff"}, "parent:link", false},
+		{"staff cannot create webhooks", []string{"staff"}, "webhook:create", false},
+		{"staff cannot list webhooks", []string{"staff"}, "webhook:list", false},
+		{"admin lists webhook deliveries", []string{"admin"}, "webhook:list-deliveries", true},
+		{"staff cannot read the audit log", []string{"staff"}, "audit:read", false},
+		{"auditor reads the audit log", []string{"auditor"}, "audit:read", true},
+		{"auditor reads parents", []string{"auditor"}, "parent:read", true},
+		{"auditor cannot update", []string{"auditor"}, "child:update", false},
+		{"anonymous cannot read the audit log", []string{AnonymousRole}, "audit:read", false},
+		{"anonymous cannot list children", []string{AnonymousRole}, "child:list", false},
+		{"guardian updates own family", []string{"guardian"}, "parent:update:own", true},
+		{"guardian cannot update every family", []string{"guardian"}, "parent:update", false},
+		{"guardian cannot delete own family", []string{"guardian"}, "parent:delete:own", false},
+		{"staff creates households", []string{"staff"}, "household:create", true},
+		{"guardian reads own household", []string{"guardian"}, "household:read:own", true},
+		{"guardian cannot move children between households", []string{"guardian"}, "household:update", false},
+		{"staff cannot merge parents", []string{"staff"}, "parent:merge", false},
+		{"staff cannot list duplicates", []string{"staff"}, "parent:list-duplicates", false},
+		{"admin transfers children", []string{"admin"}, "child:transfer", true},
+		{"staff cannot transfer children", []string{"staff"}, "child:transfer", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, engine.IsAllowed(tc.roles, tc.operation))
+		})
+	}
+}
+
+func TestPolicyFile_AnonymousCannotReadFamilies(t *testing.T) {
+	// Setup: the policy the service ships with
+	logger, _ := zap.NewDevelopment()
+	policy, err := LoadPolicyFile(filepath.Join("..", "..", "..", "config", "policy.yaml"))
+	require.NoError(t, err)
+	engine, err := NewPolicyEngine(policy, logger)
+	require.NoError(t, err)
+	service := NewAuthorizationServiceWithPolicy(engine, logger)
+
+	// Verify: a caller without a token is linked to no family, so it may not read any, not even as its own
+	for _, operation := range []string{
+		"parent:read", "parent:read:own", "parent:list", "parent:list:own",
+		"child:read", "child:read:own", "child:list", "child:list:own",
+		"household:read", "household:read:own",
+	} {
+		authorized, err := service.IsAuthorized(context.Background(), operation)
+		require.NoError(t, err)
+		assert.False(t, authorized, operation)
+	}
+
+	permissions, err := service.GetPermissions(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, permissions)
+}
@@ -3,7 +3,6 @@ package auth
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -19,15 +18,26 @@ const (
 	userRolesKey
 )
 
-// AuthorizationService implements the ports.AuthorizationService interface
+// AuthorizationService implements the ports.AuthorizationService interface.
+// It evaluates the roles of the caller against the policy of its policy engine.
 type AuthorizationService struct {
+	engine *PolicyEngine
 	logger *zap.Logger
 	tracer trace.Tracer
 }
 
-// NewAuthorizationService creates a new authorization service
+// NewAuthorizationService creates a new authorization service that enforces the default policy
 func NewAuthorizationService(logger *zap.Logger) *AuthorizationService {
+	// The default policy is always valid
+	engine, _ := NewPolicyEngine(DefaultPolicy(), logger)
+
+	return NewAuthorizationServiceWithPolicy(engine, logger)
+}
+
+// NewAuthorizationServiceWithPolicy creates a new authorization service that enforces the policy of the engine
+func NewAuthorizationServiceWithPolicy(engine *PolicyEngine, logger *zap.Logger) *AuthorizationService {
 	return &AuthorizationService{
+		engine: engine,
 		logger: logger,
 		tracer: otel.Tracer("infrastructure.auth.service"),
 	}
@@ -40,27 +50,46 @@ func (s *AuthorizationService) IsAuthorized(ctx context.Context, operation strin
 
 	span.SetAttributes(attribute.String("operation", operation))
 
-	// Check if user is admin
-	isAdmin, err := s.IsAdmin(ctx)
+	roles, err := s.callerRoles(ctx)
 	if err != nil {
 		return false, err
 	}
 
-	if isAdmin {
-		// Admins can do anything
-		return true, nil
+	authorized := s.engine.IsAllowed(roles, operation)
+	span.SetAttributes(attribute.Bool("authorized", authorized))
+
+	return authorized, nil
+}
+
+// GetPermissions returns the operations the user is authorized to perform
+func (s *AuthorizationService) GetPermissions(ctx context.Context) ([]string, error) {
+	ctx, span := s.tracer.Start(ctx, "AuthorizationService.GetPermissions")
+	defer span.End()
+
+	roles, err := s.callerRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.engine.Permissions(roles), nil
+}
+
+// callerRoles returns the roles of the user, together with the anonymous or authenticated role
+func (s *AuthorizationService) callerRoles(ctx context.Context) ([]string, error) {
+	roles, err := s.GetUserRoles(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// Non-admin users can only perform query operations
-	if strings.HasPrefix(operation, "parent:read") ||
-		strings.HasPrefix(operation, "parent:list") ||
-		strings.HasPrefix(operation, "child:read") ||
-		strings.HasPrefix(operation, "child:list") {
-		return true, nil
+	callerRoles := make([]string, 0, len(roles)+1)
+	callerRoles = append(callerRoles, roles...)
+	if _, err := s.GetUserID(ctx); err != nil {
+		callerRoles = append(callerRoles, AnonymousRole)
+	} else {
+		callerRoles = append(callerRoles, AuthenticatedRole)
 	}
 
-	// If we get here, the user is not authorized
-	return false, nil
+	return callerRoles, nil
 }
 
 // IsAdmin checks if the user has admin role
@@ -49,7 +49,7 @@ func TestIsAuthorized_NonAdmin_ReadOperation(t *testing.T) {
 	ctx = WithUserID(ctx, "regular-user")
 	ctx = WithUserRoles(ctx, []string{"user"})
 	
-	// Test cases for read operations
+	// Test cases for read operations, which non-admins may only perform on their own family
 	readOperations := []string{
 		"parent:read",
 		"parent:list",
@@ -61,10 +61,13 @@ func TestIsAuthorized_NonAdmin_ReadOperation(t *testing.T) {
 		t.Run(operation, func(t *testing.T) {
 			// Execute
 			authorized, err := service.IsAuthorized(ctx, operation)
+			assert.NoError(t, err)
+			authorizedOwn, err := service.IsAuthorized(ctx, operation+OwnSuffix)
 			
 			// Verify
 			assert.NoError(t, err)
-			assert.True(t, authorized)
+			assert.False(t, authorized)
+			assert.True(t, authorizedOwn)
 		})
 	}
 }
@@ -42,6 +42,49 @@ func TestLoadConfig(t *testing.T) {
 
 	// Verify feature flags
 	assert.Equal(t, true, config.Features.UseGenerics)
+
+	// Verify authentication is disabled unless configured
+	assert.Equal(t, "disabled", config.Auth.Mode)
+	assert.Equal(t, time.Hour, config.Auth.JWT.TokenDuration)
+}
+
+// TestProcessConfigJWTModeRequiresSecret tests that the JWT mode cannot be enabled without a secret key
+func TestProcessConfigJWTModeRequiresSecret(t *testing.T) {
+	os.Unsetenv("JWT_SECRET_KEY")
+	defer os.Unsetenv("JWT_SECRET_KEY")
+
+	// Without a secret key the configuration is rejected
+	k := koanf.New(".")
+	assert.NoError(t, loadDefaults(k))
+	k.Set("auth.mode", "jwt")
+	processEnvVarsInAuthSecrets(k)
+
+	_, err := processConfig(k)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "auth.jwt.secret_key is required")
+
+	// The secret key placeholder is resolved from the environment
+	os.Setenv("JWT_SECRET_KEY", "test-secret")
+	k = koanf.New(".")
+	assert.NoError(t, loadDefaults(k))
+	k.Set("auth.mode", "jwt")
+	processEnvVarsInAuthSecrets(k)
+
+	config, err := processConfig(k)
+	assert.NoError(t, err)
+	assert.Equal(t, "jwt", config.Auth.Mode)
+	assert.Equal(t, "test-secret", config.Auth.JWT.SecretKey)
+}
+
+// TestProcessConfigOIDCModeRequiresIssuer tests that the OIDC mode cannot be enabled without a provider
+func TestProcessConfigOIDCModeRequiresIssuer(t *testing.T) {
+	k := koanf.New(".")
+	assert.NoError(t, loadDefaults(k))
+	k.Set("auth.mode", "oidc")
+
+	_, err := processConfig(k)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "auth.oidc.issuer_url is required")
 }
 
 // TestLoadConfigWithEnvironmentVariables tests loading config with environment variables
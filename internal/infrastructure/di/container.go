@@ -3,15 +3,20 @@ package di
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/eventbus"
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/mongodb"
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/postgres"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/adapters/webhook"
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/application"
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/infrastructure/auth"
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/infrastructure/config"
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/infrastructure/logging"
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
 	"github.com/go-playground/validator/v10"
+	"github.com/knadh/koanf/v2"
 	"go.uber.org/zap"
 )
 
@@ -22,13 +27,46 @@ type Container struct {
 	contextLogger        *logging.ContextLogger
 	validator            *validator.Validate
 	repositoryFactory    ports.RepositoryFactory
+	eventBroker          ports.EventBroker
+	outboxPublisher      ports.EventPublisher
+	stopOutboxRelay      context.CancelFunc
+	outboxRelayDone      chan struct{}
+	stopWebhooks         context.CancelFunc
+	webhooksDone         sync.WaitGroup
 	familyService        ports.FamilyService
+	webhookService       ports.WebhookService
+	auditService         ports.AuditService
 	authorizationService ports.AuthorizationService
+	policyEngine         *auth.PolicyEngine
+	jwtService           *auth.JWTService
+	oidcService          *auth.OIDCService
 	config               *config.Config
 }
 
+// Option configures how NewContainer builds a container
+type Option func(*containerOptions)
+
+// containerOptions are the settings of NewContainer that do not come from the configuration
+type containerOptions struct {
+	backgroundWorkers bool
+}
+
+// WithoutBackgroundWorkers builds a container that does not run the outbox relay, the webhook enqueuer
+// and the webhook dispatcher, for short-lived processes such as familyctl that share the database with
+// the server. The events of the changes are still recorded in the outbox, for the server to deliver.
+func WithoutBackgroundWorkers() Option {
+	return func(o *containerOptions) {
+		o.backgroundWorkers = false
+	}
+}
+
 // NewContainer creates a new dependency injection container
-func NewContainer(ctx context.Context, logger *zap.Logger, cfg *config.Config) (*Container, error) {
+func NewContainer(ctx context.Context, logger *zap.Logger, cfg *config.Config, opts ...Option) (*Container, error) {
+	options := containerOptions{backgroundWorkers: true}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	container := &Container{
 		ctx:    ctx,
 		logger: logger,
@@ -71,16 +109,195 @@ func NewContainer(ctx context.Context, logger *zap.Logger, cfg *config.Config) (
 		return nil, fmt.Errorf("unsupported database type: %s", dbType)
 	}
 
+	// Initialize event broker based on broker type
+	brokerType := cfg.Events.Broker
+	switch brokerType {
+	case "", "memory":
+		container.eventBroker = eventbus.NewInMemoryBroker(cfg.Events.BufferSize, logger)
+	case "redis":
+		redisBroker, err := eventbus.NewRedisBroker(ctx, eventbus.RedisOptions{
+			Address:    cfg.Events.Redis.Address,
+			Password:   cfg.Events.Redis.Password,
+			DB:         cfg.Events.Redis.DB,
+			Channel:    cfg.Events.Redis.Channel,
+			BufferSize: cfg.Events.BufferSize,
+		}, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize Redis event broker: %w", err)
+		}
+		container.eventBroker = redisBroker
+	default:
+		return nil, fmt.Errorf("unsupported event broker type: %s", brokerType)
+	}
+
+	// Initialize the authorization policy, reloading it on change if configured
+	policyFile := cfg.Auth.Policy.File
+	if policyFile == "" {
+		logger.Info("No authorization policy file configured, using the default policy")
+		policyEngine, err := auth.NewPolicyEngine(auth.DefaultPolicy(), logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize authorization policy: %w", err)
+		}
+		container.policyEngine = policyEngine
+	} else {
+		policyEngine, err := auth.NewPolicyEngineFromFile(policyFile, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize authorization policy: %w", err)
+		}
+		if cfg.Auth.Policy.Watch {
+			if err := policyEngine.Watch(); err != nil {
+				return nil, fmt.Errorf("failed to watch authorization policy: %w", err)
+			}
+		}
+		container.policyEngine = policyEngine
+	}
+
 	// Initialize authorization service
-	authService := auth.NewAuthorizationService(logger)
+	authService := auth.NewAuthorizationServiceWithPolicy(container.policyEngine, logger)
 	container.authorizationService = authService
 
+	// Initialize the token services required by the auth mode
+	authMode := cfg.Auth.Mode
+	switch authMode {
+	case "", "disabled":
+		logger.Warn("Authentication is disabled, no bearer token can be validated")
+	case "jwt", "oidc", "both":
+		if authMode == "jwt" || authMode == "both" {
+			container.jwtService = auth.NewJWTService(auth.JWTConfig{
+				SecretKey:     cfg.Auth.JWT.SecretKey,
+				TokenDuration: cfg.Auth.JWT.TokenDuration,
+				Issuer:        cfg.Auth.JWT.Issuer,
+			}, logger)
+		}
+		if authMode == "oidc" || authMode == "both" {
+			// The OIDC service reads its timeout from koanf
+			k := koanf.New(".")
+			if err := k.Set("auth.oidc_timeout", int(cfg.Auth.OIDCTimeout/time.Second)); err != nil {
+				return nil, fmt.Errorf("failed to configure OIDC timeout: %w", err)
+			}
+
+			oidcService, err := auth.InitOIDCServiceFromConfig(
+				ctx,
+				cfg.Auth.OIDC.IssuerURL,
+				cfg.Auth.OIDC.ClientID,
+				cfg.Auth.OIDC.ClientSecret,
+				cfg.Auth.OIDC.RedirectURL,
+				cfg.Auth.OIDC.Scopes,
+				cfg.Auth.OIDC.AdminRoleName,
+				logger,
+				k,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to initialize OIDC service: %w", err)
+			}
+			if oidcService == nil {
+				return nil, fmt.Errorf("OIDC issuer URL and client ID are required for auth mode %s", authMode)
+			}
+			container.oidcService = oidcService
+		}
+	default:
+		return nil, fmt.Errorf("unsupported auth mode: %s", authMode)
+	}
+
 	// Initialize family service
-	container.familyService = application.NewFamilyService(
+	familyService := application.NewFamilyService(
 		container.repositoryFactory,
+		container.eventBroker,
 		container.validator,
 		container.logger,
-	)
+	).WithDeletedRetention(cfg.Retention.DeletedRecords)
+	container.familyService = familyService
+
+	// Audit every change, attributed to the user who made it
+	auditLogRepository := container.repositoryFactory.NewAuditLogRepository()
+	familyService.WithAuditLog(auditLogRepository, authService)
+	container.auditService = application.NewAuditService(auditLogRepository, logger)
+
+	// Record the events in the outbox, if enabled
+	if cfg.Events.Outbox.Enabled {
+		familyService.WithOutbox(container.repositoryFactory.NewOutboxRepository())
+	}
+
+	// Initialize webhook service
+	webhookRepository := container.repositoryFactory.NewWebhookRepository()
+	container.webhookService = application.NewWebhookService(webhookRepository, container.validator, logger)
+
+	// Enqueue the webhook deliveries of the events: from the outbox relay when the events are recorded
+	// in the outbox, so that no event is missed, and from the event broker otherwise
+	var webhookEnqueuer *application.WebhookEnqueuer
+	if cfg.Events.Webhooks.Enabled {
+		webhookEnqueuer = application.NewWebhookEnqueuer(webhookRepository, logger)
+	}
+
+	// Relay the recorded events to the outbox publisher
+	if cfg.Events.Outbox.Enabled && cfg.Events.Outbox.Relay && options.backgroundWorkers {
+		switch cfg.Events.Outbox.Publisher {
+		case "stdout":
+			container.outboxPublisher = eventbus.NewStdoutPublisher(logger)
+		case "file":
+			filePublisher, err := eventbus.NewFilePublisher(cfg.Events.Outbox.File, logger)
+			if err != nil {
+				return nil, fmt.Errorf("failed to initialize outbox publisher: %w", err)
+			}
+			container.outboxPublisher = filePublisher
+		default:
+			return nil, fmt.Errorf("unsupported outbox publisher: %s", cfg.Events.Outbox.Publisher)
+		}
+
+		// The relay also enqueues the webhook deliveries
+		if webhookEnqueuer != nil {
+			container.outboxPublisher = eventbus.NewFanOutPublisher(container.outboxPublisher, webhookEnqueuer)
+		}
+
+		relay := application.NewOutboxRelay(container.repositoryFactory.NewOutboxRepository(), container.outboxPublisher, application.OutboxRelayOptions{
+			PollInterval:   cfg.Events.Outbox.PollInterval,
+			BatchSize:      cfg.Events.Outbox.BatchSize,
+			InitialBackoff: cfg.Events.Outbox.InitialBackoff,
+			MaxBackoff:     cfg.Events.Outbox.MaxBackoff,
+			MaxAttempts:    cfg.Events.Outbox.MaxAttempts,
+			LeaseDuration:  cfg.Events.Outbox.LeaseDuration,
+		}, logger)
+
+		relayCtx, stopRelay := context.WithCancel(ctx)
+		container.stopOutboxRelay = stopRelay
+		container.outboxRelayDone = make(chan struct{})
+		go func() {
+			defer close(container.outboxRelayDone)
+			relay.Run(relayCtx)
+		}()
+	}
+
+	// The webhook goroutines stop before the resources they use are closed
+	webhooksCtx, stopWebhooks := context.WithCancel(ctx)
+	container.stopWebhooks = stopWebhooks
+
+	if webhookEnqueuer != nil && !cfg.Events.Outbox.Enabled && options.backgroundWorkers {
+		container.webhooksDone.Add(1)
+		go func() {
+			defer container.webhooksDone.Done()
+			if err := webhookEnqueuer.Consume(webhooksCtx, container.eventBroker); err != nil {
+				logger.Error("Failed to enqueue webhook deliveries", zap.Error(err))
+			}
+		}()
+	}
+
+	// Deliver the enqueued webhook callbacks
+	if cfg.Events.Webhooks.Enabled && cfg.Events.Webhooks.Dispatch && options.backgroundWorkers {
+		dispatcher := application.NewWebhookDispatcher(webhookRepository, webhook.NewHTTPSender(cfg.Events.Webhooks.Timeout, logger), application.WebhookDispatcherOptions{
+			PollInterval:   cfg.Events.Webhooks.PollInterval,
+			BatchSize:      cfg.Events.Webhooks.BatchSize,
+			Concurrency:    cfg.Events.Webhooks.Concurrency,
+			InitialBackoff: cfg.Events.Webhooks.InitialBackoff,
+			MaxBackoff:     cfg.Events.Webhooks.MaxBackoff,
+			MaxAttempts:    cfg.Events.Webhooks.MaxAttempts,
+		}, logger)
+
+		container.webhooksDone.Add(1)
+		go func() {
+			defer container.webhooksDone.Done()
+			dispatcher.Run(webhooksCtx)
+		}()
+	}
 
 	return container, nil
 }
@@ -110,20 +327,81 @@ func (c *Container) GetRepositoryFactory() ports.RepositoryFactory {
 	return c.repositoryFactory
 }
 
+// GetEventBroker returns the event broker
+func (c *Container) GetEventBroker() ports.EventBroker {
+	return c.eventBroker
+}
+
 // GetFamilyService returns the family service
 func (c *Container) GetFamilyService() ports.FamilyService {
 	return c.familyService
 }
 
+// GetWebhookService returns the webhook service
+func (c *Container) GetWebhookService() ports.WebhookService {
+	return c.webhookService
+}
+
+// GetAuditService returns the audit service
+func (c *Container) GetAuditService() ports.AuditService {
+	return c.auditService
+}
+
 // GetAuthorizationService returns the authorization service
 func (c *Container) GetAuthorizationService() ports.AuthorizationService {
 	return c.authorizationService
 }
 
+// GetJWTService returns the JWT service, or nil when the auth mode does not accept JWT tokens
+func (c *Container) GetJWTService() *auth.JWTService {
+	return c.jwtService
+}
+
+// GetOIDCService returns the OIDC service, or nil when the auth mode does not accept OIDC tokens
+func (c *Container) GetOIDCService() *auth.OIDCService {
+	return c.oidcService
+}
+
 // Close closes all resources
 func (c *Container) Close() error {
 	var errs []error
 
+	// Stop the outbox relay before the resources it uses are closed
+	if c.stopOutboxRelay != nil {
+		c.stopOutboxRelay()
+		<-c.outboxRelayDone
+	}
+
+	// Stop enqueuing and delivering webhook callbacks
+	if c.stopWebhooks != nil {
+		c.stopWebhooks()
+		c.webhooksDone.Wait()
+	}
+
+	// Close the outbox publisher
+	if closer, ok := c.outboxPublisher.(interface{ Close() error }); ok {
+		if err := closer.Close(); err != nil {
+			c.logger.Error("Failed to close outbox publisher", zap.Error(err))
+			errs = append(errs, err)
+		}
+	}
+
+	// Close event broker, ending all subscriptions
+	if c.eventBroker != nil {
+		if err := c.eventBroker.Close(); err != nil {
+			c.logger.Error("Failed to close event broker", zap.Error(err))
+			errs = append(errs, err)
+		}
+	}
+
+	// Stop reloading the authorization policy
+	if c.policyEngine != nil {
+		if err := c.policyEngine.Close(); err != nil {
+			c.logger.Error("Failed to stop watching the authorization policy", zap.Error(err))
+			errs = append(errs, err)
+		}
+	}
+
 	// Close MongoDB repository factory
 	if mongoFactory, ok := c.repositoryFactory.(*mongodb.RepositoryFactory); ok {
 		if err := mongoFactory.Close(c.ctx, c.config); err != nil {
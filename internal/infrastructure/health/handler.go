@@ -40,37 +40,9 @@ func NewHandler(provider HealthCheckProvider, logger *zap.Logger, cfg *config.Co
 		r = r.WithContext(ctx)
 
 		// Check the health of dependencies
-		services := make(map[string]string)
-
-		// Check database connectivity through the repository factory
-		repoFactory := provider.GetRepositoryFactory()
-		if repoFactory != nil {
-			services["database"] = ServiceUp
-		} else {
-			services["database"] = ServiceDown
-		}
-
-		// Overall status is healthy if all dependencies are healthy
-		status := StatusHealthy
-		for _, s := range services {
-			if s != ServiceUp {
-				status = StatusDegraded
-				break
-			}
-		}
-
-		// Create the health response
-		healthResponse := HealthStatus{
-			Status:    status, // Use the status constant directly
-			Timestamp: time.Now().UTC().Format(time.RFC3339),
-			Version:   cfg.App.Version,
-			Services:  make(map[string]string),
-		}
-
-		// Use service statuses directly
-		for service, serviceStatus := range services {
-			healthResponse.Services[service] = serviceStatus
-		}
+		healthResponse := Check(provider, cfg.App.Version)
+		status := healthResponse.Status
+		services := healthResponse.Services
 
 		// Set content type
 		w.Header().Set("Content-Type", "application/json")
@@ -96,3 +68,38 @@ func NewHandler(provider HealthCheckProvider, logger *zap.Logger, cfg *config.Co
 		)
 	}
 }
+
+// Check checks the health of the dependencies of the service, as the health check endpoint reports it.
+// Parameters:
+//   - provider: The provider of the dependencies to check
+//   - version: The version of the service
+//
+// Returns:
+//   - HealthStatus: The health of the service, which is healthy if all its dependencies are up
+func Check(provider HealthCheckProvider, version string) HealthStatus {
+	services := make(map[string]string)
+
+	// Check database connectivity through the repository factory
+	repoFactory := provider.GetRepositoryFactory()
+	if repoFactory != nil {
+		services["database"] = ServiceUp
+	} else {
+		services["database"] = ServiceDown
+	}
+
+	// Overall status is healthy if all dependencies are healthy
+	status := StatusHealthy
+	for _, s := range services {
+		if s != ServiceUp {
+			status = StatusDegraded
+			break
+		}
+	}
+
+	return HealthStatus{
+		Status:    status,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Version:   version,
+		Services:  services,
+	}
+}
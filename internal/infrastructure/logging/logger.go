@@ -6,6 +6,7 @@ package logging
 
 import (
 	"context"
+	"io"
 	"os"
 
 	"go.opentelemetry.io/otel/trace"
@@ -23,6 +24,20 @@ import (
 //   - *zap.Logger: A configured zap logger instance
 //   - error: An error if logger creation fails
 func NewLogger(level string, development bool) (*zap.Logger, error) {
+	return NewLoggerWithOutput(level, development, os.Stdout)
+}
+
+// NewLoggerWithOutput creates a new zap logger like NewLogger, writing to the given output instead of standard output,
+// such as to standard error for command line tools that write their results to standard output.
+// Parameters:
+//   - level: The minimum log level as a string (e.g., "debug", "info", "warn", "error")
+//   - development: Whether to use development mode with console output (true) or production mode with JSON output (false)
+//   - output: Where to write the log entries
+//
+// Returns:
+//   - *zap.Logger: A configured zap logger instance
+//   - error: An error if logger creation fails
+func NewLoggerWithOutput(level string, development bool, output io.Writer) (*zap.Logger, error) {
 	// Parse log level
 	var zapLevel zapcore.Level
 	err := zapLevel.UnmarshalText([]byte(level))
@@ -52,11 +67,11 @@ func NewLogger(level string, development bool) (*zap.Logger, error) {
 	if development {
 		// In development mode, log to console with colored output
 		consoleEncoder := zapcore.NewConsoleEncoder(encoderConfig)
-		core = zapcore.NewCore(consoleEncoder, zapcore.AddSync(os.Stdout), zapLevel)
+		core = zapcore.NewCore(consoleEncoder, zapcore.AddSync(output), zapLevel)
 	} else {
 		// In production mode, log as JSON
 		jsonEncoder := zapcore.NewJSONEncoder(encoderConfig)
-		core = zapcore.NewCore(jsonEncoder, zapcore.AddSync(os.Stdout), zapLevel)
+		core = zapcore.NewCore(jsonEncoder, zapcore.AddSync(output), zapLevel)
 	}
 
 	// Create logger
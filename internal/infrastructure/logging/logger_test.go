@@ -1,6 +1,7 @@
 package logging
 
 import (
+	"bytes"
 	"context"
 	"testing"
 
@@ -58,6 +59,21 @@ func TestNewLogger(t *testing.T) {
 	}
 }
 
+func TestNewLoggerWithOutput(t *testing.T) {
+	// Setup
+	var output bytes.Buffer
+
+	// Execute
+	logger, err := NewLoggerWithOutput("info", false, &output)
+	require.NoError(t, err)
+	logger.Info("test message")
+	logger.Debug("hidden message")
+
+	// Verify
+	assert.Contains(t, output.String(), `"msg":"test message"`)
+	assert.NotContains(t, output.String(), "hidden message")
+}
+
 func TestWithTraceID(t *testing.T) {
 	// Setup
 	core, recorded := observer.New(zapcore.InfoLevel)
@@ -0,0 +1,80 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+)
+
+// MockAuditLogRepository is a mock implementation of the ports.AuditLogRepository interface
+type MockAuditLogRepository struct {
+	// Function mocks for testing specific scenarios
+	AppendFunc func(ctx context.Context, record *domain.AuditRecord) error
+	ListFunc   func(ctx context.Context, filter ports.AuditLogFilter) ([]*domain.AuditRecord, error)
+
+	// In-memory storage for testing, in the order the records were appended
+	mu      sync.Mutex
+	records []domain.AuditRecord
+}
+
+// NewMockAuditLogRepository creates a new mock audit log repository
+func NewMockAuditLogRepository() *MockAuditLogRepository {
+	return &MockAuditLogRepository{}
+}
+
+// Append stores a new record
+func (r *MockAuditLogRepository) Append(ctx context.Context, record *domain.AuditRecord) error {
+	if r.AppendFunc != nil {
+		return r.AppendFunc(ctx, record)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.records = append(r.records, *record)
+	return nil
+}
+
+// List retrieves the records of the caller's tenant selected by the filter, oldest first
+func (r *MockAuditLogRepository) List(ctx context.Context, filter ports.AuditLogFilter) ([]*domain.AuditRecord, error) {
+	if r.ListFunc != nil {
+		return r.ListFunc(ctx, filter)
+	}
+
+	tenantID := ports.TenantIDFromContext(ctx)
+	records := []*domain.AuditRecord{}
+	for _, record := range r.Records() {
+		if record.TenantID != tenantID || record.EntityID != filter.EntityID ||
+			(filter.From != nil && record.OccurredAt.Before(*filter.From)) ||
+			(filter.To != nil && record.OccurredAt.After(*filter.To)) {
+			continue
+		}
+		records = append(records, record)
+	}
+	if len(records) > filter.Limit {
+		records = records[:filter.Limit]
+	}
+	return records, nil
+}
+
+// Records returns copies of all records in the order they were appended, for test assertions
+func (r *MockAuditLogRepository) Records() []*domain.AuditRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	records := make([]*domain.AuditRecord, 0, len(r.records))
+	for _, record := range r.records {
+		records = append(records, &record)
+	}
+	return records
+}
+
+// Reset clears the records
+func (r *MockAuditLogRepository) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.records = nil
+}
@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+)
+
+// MockAuditService is a mock implementation of the ports.AuditService interface
+type MockAuditService struct {
+	GetAuditLogFunc func(ctx context.Context, entityID uuid.UUID, from, to *time.Time) ([]*domain.AuditRecord, error)
+}
+
+// NewMockAuditService creates a new mock audit service
+func NewMockAuditService() *MockAuditService {
+	return &MockAuditService{}
+}
+
+// GetAuditLog implements ports.AuditService
+func (m *MockAuditService) GetAuditLog(ctx context.Context, entityID uuid.UUID, from, to *time.Time) ([]*domain.AuditRecord, error) {
+	if m.GetAuditLogFunc != nil {
+		return m.GetAuditLogFunc(ctx, entityID, from, to)
+	}
+	return []*domain.AuditRecord{}, nil
+}
+
+// Ensure MockAuditService implements ports.AuditService
+var _ ports.AuditService = (*MockAuditService)(nil)
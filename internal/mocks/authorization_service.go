@@ -2,27 +2,32 @@ package mocks
 
 import (
 	"context"
+	"sync"
 )
 
 // MockAuthorizationService is a mock implementation of the ports.AuthorizationService interface
 type MockAuthorizationService struct {
 	// Function mocks for testing specific scenarios
-	IsAuthorizedFunc func(ctx context.Context, operation string) (bool, error)
-	IsAdminFunc      func(ctx context.Context) (bool, error)
-	GetUserIDFunc    func(ctx context.Context) (string, error)
-	GetUserRolesFunc func(ctx context.Context) ([]string, error)
+	IsAuthorizedFunc   func(ctx context.Context, operation string) (bool, error)
+	IsAdminFunc        func(ctx context.Context) (bool, error)
+	GetUserIDFunc      func(ctx context.Context) (string, error)
+	GetUserRolesFunc   func(ctx context.Context) ([]string, error)
+	GetPermissionsFunc func(ctx context.Context) ([]string, error)
 
 	// Default return values
 	DefaultIsAuthorized bool
 	DefaultIsAdmin      bool
 	DefaultUserID       string
 	DefaultUserRoles    []string
-
-	// Call tracking for assertions
-	IsAuthorizedCalls  []string
-	IsAdminCalled      bool
-	GetUserIDCalled    bool
-	GetUserRolesCalled bool
+	DefaultPermissions  []string
+
+	// Call tracking for assertions; mu guards it because resolvers check authorization concurrently
+	mu                   sync.Mutex
+	IsAuthorizedCalls    []string
+	IsAdminCalled        bool
+	GetUserIDCalled      bool
+	GetUserRolesCalled   bool
+	GetPermissionsCalled bool
 }
 
 // NewMockAuthorizationService creates a new mock authorization service
@@ -32,6 +37,7 @@ func NewMockAuthorizationService() *MockAuthorizationService {
 		DefaultIsAdmin:      false,
 		DefaultUserID:       "test-user-id",
 		DefaultUserRoles:    []string{"user"},
+		DefaultPermissions:  []string{"child:list", "child:read", "parent:list", "parent:read"},
 		IsAuthorizedCalls:   make([]string, 0),
 	}
 }
@@ -39,7 +45,9 @@ func NewMockAuthorizationService() *MockAuthorizationService {
 // IsAuthorized checks if the user is authorized to perform the operation
 func (s *MockAuthorizationService) IsAuthorized(ctx context.Context, operation string) (bool, error) {
 	// Track the call
+	s.mu.Lock()
 	s.IsAuthorizedCalls = append(s.IsAuthorizedCalls, operation)
+	s.mu.Unlock()
 
 	if s.IsAuthorizedFunc != nil {
 		return s.IsAuthorizedFunc(ctx, operation)
@@ -81,14 +89,27 @@ func (s *MockAuthorizationService) GetUserRoles(ctx context.Context) ([]string,
 	return s.DefaultUserRoles, nil
 }
 
+// GetPermissions retrieves the operations the user is authorized to perform
+func (s *MockAuthorizationService) GetPermissions(ctx context.Context) ([]string, error) {
+	s.GetPermissionsCalled = true
+
+	if s.GetPermissionsFunc != nil {
+		return s.GetPermissionsFunc(ctx)
+	}
+
+	return s.DefaultPermissions, nil
+}
+
 // Reset resets the state of the mock authorization service
 func (s *MockAuthorizationService) Reset() {
 	s.DefaultIsAuthorized = true
 	s.DefaultIsAdmin = false
 	s.DefaultUserID = "test-user-id"
 	s.DefaultUserRoles = []string{"user"}
+	s.DefaultPermissions = []string{"child:list", "child:read", "parent:list", "parent:read"}
 	s.IsAuthorizedCalls = make([]string, 0)
 	s.IsAdminCalled = false
 	s.GetUserIDCalled = false
 	s.GetUserRolesCalled = false
+	s.GetPermissionsCalled = false
 }
@@ -3,7 +3,10 @@ package mocks
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
@@ -16,13 +19,17 @@ type MockChildRepository struct {
 	children map[uuid.UUID]*domain.Child
 
 	// Function mocks for testing specific scenarios
-	CreateFunc         func(ctx context.Context, child *domain.Child) error
-	GetByIDFunc        func(ctx context.Context, id uuid.UUID) (*domain.Child, error)
-	UpdateFunc         func(ctx context.Context, child *domain.Child) error
-	DeleteFunc         func(ctx context.Context, id uuid.UUID) error
-	ListByParentIDFunc func(ctx context.Context, parentID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error)
-	ListFunc           func(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error)
-	CountFunc          func(ctx context.Context, filter ports.FilterOptions) (int64, error)
+	CreateFunc          func(ctx context.Context, child *domain.Child) error
+	CreateManyFunc      func(ctx context.Context, children []*domain.Child) error
+	GetByIDFunc         func(ctx context.Context, id uuid.UUID) (*domain.Child, error)
+	UpdateFunc          func(ctx context.Context, child *domain.Child) error
+	DeleteFunc          func(ctx context.Context, id uuid.UUID) error
+	RestoreFunc         func(ctx context.Context, id uuid.UUID) error
+	PurgeFunc           func(ctx context.Context, deletedBefore time.Time) (int64, error)
+	ListByParentIDFunc  func(ctx context.Context, parentID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error)
+	ListByParentIDsFunc func(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Child, error)
+	ListFunc            func(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error)
+	CountFunc           func(ctx context.Context, filter ports.FilterOptions) (int64, error)
 }
 
 // NewMockChildRepository creates a new mock child repository
@@ -53,6 +60,30 @@ func (r *MockChildRepository) Create(ctx context.Context, child *domain.Child) e
 	return nil
 }
 
+// CreateMany adds many children to the mock repository. Like a database transaction, it adds either
+// all of the children or, when one of them cannot be added, none of them.
+func (r *MockChildRepository) CreateMany(ctx context.Context, children []*domain.Child) error {
+	if r.CreateManyFunc != nil {
+		return r.CreateManyFunc(ctx, children)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, child := range children {
+		if _, exists := r.children[child.ID]; exists {
+			return domain.NewBatchError(i, errors.New("child already exists"))
+		}
+	}
+
+	for _, child := range children {
+		childCopy := *child
+		r.children[child.ID] = &childCopy
+	}
+
+	return nil
+}
+
 // GetByID retrieves a child by ID from the mock repository
 func (r *MockChildRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Child, error) {
 	if r.GetByIDFunc != nil {
@@ -82,12 +113,18 @@ func (r *MockChildRepository) Update(ctx context.Context, child *domain.Child) e
 	defer r.mu.Unlock()
 
 	// Check if child exists
-	_, exists := r.children[child.ID]
-	if !exists || r.children[child.ID].DeletedAt != nil {
+	existing, exists := r.children[child.ID]
+	if !exists || existing.DeletedAt != nil {
 		return errors.New("child not found")
 	}
 
+	// Like the database repositories, only update the version that was read
+	if existing.Version != child.Version {
+		return domain.NewConflictError("Child", child.ID.String(), child.Version)
+	}
+
 	// Update the child
+	child.Version++
 	childCopy := *child
 	r.children[child.ID] = &childCopy
 
@@ -111,10 +148,53 @@ func (r *MockChildRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 	// Mark child as deleted
 	child.MarkAsDeleted()
+	child.Version++
 
 	return nil
 }
 
+// Restore unmarks a child that was marked as deleted in the mock repository
+func (r *MockChildRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	if r.RestoreFunc != nil {
+		return r.RestoreFunc(ctx, id)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Check if a deleted child exists
+	child, exists := r.children[id]
+	if !exists || child.DeletedAt == nil {
+		return fmt.Errorf("child not found for restore: %w", domain.ErrNotFound)
+	}
+
+	// Unmark child as deleted
+	child.Restore()
+	child.Version++
+
+	return nil
+}
+
+// Purge removes the children that were marked as deleted before the given time from the mock repository
+func (r *MockChildRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	if r.PurgeFunc != nil {
+		return r.PurgeFunc(ctx, deletedBefore)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged int64
+	for id, child := range r.children {
+		if child.DeletedAt != nil && child.DeletedAt.Before(deletedBefore) {
+			delete(r.children, id)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
 // ListByParentID retrieves children for a specific parent with pagination, filtering, and sorting
 func (r *MockChildRepository) ListByParentID(ctx context.Context, parentID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
 	if r.ListByParentIDFunc != nil {
@@ -127,7 +207,7 @@ func (r *MockChildRepository) ListByParentID(ctx context.Context, parentID uuid.
 	// Filter children by parent ID
 	var filteredChildren []*domain.Child
 	for _, child := range r.children {
-		if child.DeletedAt != nil {
+		if (child.DeletedAt != nil) != options.Filter.Deleted {
 			continue
 		}
 
@@ -174,6 +254,41 @@ func (r *MockChildRepository) ListByParentID(ctx context.Context, parentID uuid.
 	return paginatedChildren, pagedResult, nil
 }
 
+// ListByParentIDs retrieves all children of the given parents from the mock repository
+func (r *MockChildRepository) ListByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Child, error) {
+	if r.ListByParentIDsFunc != nil {
+		return r.ListByParentIDsFunc(ctx, parentIDs)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[uuid.UUID]bool, len(parentIDs))
+	for _, id := range parentIDs {
+		wanted[id] = true
+	}
+
+	children := make([]*domain.Child, 0)
+	for _, child := range r.children {
+		if child.DeletedAt != nil || !wanted[child.ParentID] {
+			continue
+		}
+
+		childCopy := *child
+		children = append(children, &childCopy)
+	}
+
+	// Order by parent and creation time, as the database adapters do
+	sort.Slice(children, func(i, j int) bool {
+		if children[i].ParentID != children[j].ParentID {
+			return children[i].ParentID.String() < children[j].ParentID.String()
+		}
+		return children[i].CreatedAt.Before(children[j].CreatedAt)
+	})
+
+	return children, nil
+}
+
 // List retrieves a list of children with pagination, filtering, and sorting
 func (r *MockChildRepository) List(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
 	if r.ListFunc != nil {
@@ -186,7 +301,7 @@ func (r *MockChildRepository) List(ctx context.Context, options ports.QueryOptio
 	// Filter children
 	var filteredChildren []*domain.Child
 	for _, child := range r.children {
-		if child.DeletedAt != nil {
+		if (child.DeletedAt != nil) != options.Filter.Deleted {
 			continue
 		}
 
@@ -197,6 +312,12 @@ func (r *MockChildRepository) List(ctx context.Context, options ports.QueryOptio
 		if options.Filter.LastName != "" && child.LastName != options.Filter.LastName {
 			continue
 		}
+		if len(options.Filter.ParentIDs) > 0 && !containsID(options.Filter.ParentIDs, child.ParentID) {
+			continue
+		}
+		if len(options.Filter.ChildIDs) > 0 && !containsID(options.Filter.ChildIDs, child.ID) {
+			continue
+		}
 
 		// Add child to filtered list
 		childCopy := *child
@@ -241,7 +362,7 @@ func (r *MockChildRepository) Count(ctx context.Context, filter ports.FilterOpti
 	// Filter children
 	var count int64
 	for _, child := range r.children {
-		if child.DeletedAt != nil {
+		if (child.DeletedAt != nil) != filter.Deleted {
 			continue
 		}
 
@@ -252,6 +373,12 @@ func (r *MockChildRepository) Count(ctx context.Context, filter ports.FilterOpti
 		if filter.LastName != "" && child.LastName != filter.LastName {
 			continue
 		}
+		if len(filter.ParentIDs) > 0 && !containsID(filter.ParentIDs, child.ParentID) {
+			continue
+		}
+		if len(filter.ChildIDs) > 0 && !containsID(filter.ChildIDs, child.ID) {
+			continue
+		}
 
 		count++
 	}
@@ -0,0 +1,84 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+)
+
+// MockEventBroker is a mock implementation of the ports.EventBroker interface
+type MockEventBroker struct {
+	// Function mocks for testing specific scenarios
+	PublishFunc   func(ctx context.Context, event domain.Event) error
+	SubscribeFunc func(ctx context.Context) (<-chan domain.Event, error)
+	CloseFunc     func() error
+
+	// State tracking for assertions
+	mu              sync.Mutex
+	PublishedEvents []domain.Event
+	SubscribeCalled bool
+	CloseCalled     bool
+}
+
+// NewMockEventBroker creates a new mock event broker
+func NewMockEventBroker() *MockEventBroker {
+	return &MockEventBroker{
+		PublishedEvents: make([]domain.Event, 0),
+	}
+}
+
+// Publish records the event and delivers it to the mock function if one is set
+func (b *MockEventBroker) Publish(ctx context.Context, event domain.Event) error {
+	b.mu.Lock()
+	b.PublishedEvents = append(b.PublishedEvents, event)
+	b.mu.Unlock()
+
+	if b.PublishFunc != nil {
+		return b.PublishFunc(ctx, event)
+	}
+
+	return nil
+}
+
+// Subscribe returns the channel from the mock function, or a channel that is closed when ctx is done
+func (b *MockEventBroker) Subscribe(ctx context.Context) (<-chan domain.Event, error) {
+	b.mu.Lock()
+	b.SubscribeCalled = true
+	b.mu.Unlock()
+
+	if b.SubscribeFunc != nil {
+		return b.SubscribeFunc(ctx)
+	}
+
+	ch := make(chan domain.Event)
+	go func() {
+		<-ctx.Done()
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+// Close closes the mock event broker
+func (b *MockEventBroker) Close() error {
+	b.mu.Lock()
+	b.CloseCalled = true
+	b.mu.Unlock()
+
+	if b.CloseFunc != nil {
+		return b.CloseFunc()
+	}
+
+	return nil
+}
+
+// Events returns a copy of the events published so far
+func (b *MockEventBroker) Events() []domain.Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	events := make([]domain.Event, len(b.PublishedEvents))
+	copy(events, b.PublishedEvents)
+	return events
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
@@ -11,25 +12,57 @@ import (
 // MockFamilyService is a mock implementation of the ports.FamilyService interface
 type MockFamilyService struct {
 	// Function mocks for ParentService methods
-	CreateParentFunc  func(ctx context.Context, firstName, lastName, email string, birthDate string) (*domain.Parent, error)
-	GetParentByIDFunc func(ctx context.Context, id uuid.UUID) (*domain.Parent, error)
-	UpdateParentFunc  func(ctx context.Context, id uuid.UUID, firstName, lastName, email string, birthDate string) (*domain.Parent, error)
-	DeleteParentFunc  func(ctx context.Context, id uuid.UUID) error
-	ListParentsFunc   func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error)
-	CountParentsFunc  func(ctx context.Context, filter ports.FilterOptions) (int64, error)
+	CreateParentFunc         func(ctx context.Context, firstName, lastName, email string, birthDate string, contact *domain.ContactDetails) (*domain.Parent, error)
+	CreateParentsFunc        func(ctx context.Context, inputs []ports.ParentInput, atomic bool) ([]ports.ParentResult, error)
+	ValidateParentsFunc      func(ctx context.Context, inputs []ports.ParentInput) ([]error, error)
+	GetParentByIDFunc        func(ctx context.Context, id uuid.UUID) (*domain.Parent, error)
+	GetParentsByIDsFunc      func(ctx context.Context, ids []uuid.UUID) ([]*domain.Parent, error)
+	GetParentByUserIDFunc    func(ctx context.Context, userID string) (*domain.Parent, error)
+	LinkParentUserFunc       func(ctx context.Context, id uuid.UUID, userID string) (*domain.Parent, error)
+	UpdateParentFunc         func(ctx context.Context, id uuid.UUID, firstName, lastName, email string, birthDate string, contact *domain.ContactDetails, expectedVersion *int) (*domain.Parent, error)
+	DeleteParentFunc         func(ctx context.Context, id uuid.UUID) error
+	RestoreParentFunc        func(ctx context.Context, id uuid.UUID) (*domain.Parent, error)
+	ListParentsFunc          func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error)
+	CountParentsFunc         func(ctx context.Context, filter ports.FilterOptions) (int64, error)
+	FindDuplicateParentsFunc func(ctx context.Context, parentID *uuid.UUID, minScore float64, limit int) ([]*domain.ParentDuplicate, error)
 
 	// Function mocks for ChildService methods
-	CreateChildFunc            func(ctx context.Context, firstName, lastName string, birthDate string, parentID uuid.UUID) (*domain.Child, error)
-	GetChildByIDFunc           func(ctx context.Context, id uuid.UUID) (*domain.Child, error)
-	UpdateChildFunc            func(ctx context.Context, id uuid.UUID, firstName, lastName string, birthDate string) (*domain.Child, error)
-	DeleteChildFunc            func(ctx context.Context, id uuid.UUID) error
-	ListChildrenByParentIDFunc func(ctx context.Context, parentID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error)
-	ListChildrenFunc           func(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error)
-	CountChildrenFunc          func(ctx context.Context, filter ports.FilterOptions) (int64, error)
+	CreateChildFunc             func(ctx context.Context, firstName, lastName string, birthDate string, parentID uuid.UUID) (*domain.Child, error)
+	CreateChildrenFunc          func(ctx context.Context, inputs []ports.ChildInput, atomic bool) ([]ports.ChildResult, error)
+	ValidateChildrenFunc        func(ctx context.Context, inputs []ports.ChildInput) ([]error, error)
+	GetChildByIDFunc            func(ctx context.Context, id uuid.UUID) (*domain.Child, error)
+	UpdateChildFunc             func(ctx context.Context, id uuid.UUID, firstName, lastName string, birthDate string, expectedVersion *int) (*domain.Child, error)
+	DeleteChildFunc             func(ctx context.Context, id uuid.UUID) error
+	DeleteChildrenFunc          func(ctx context.Context, ids []uuid.UUID, atomic bool) ([]ports.DeleteResult, error)
+	RestoreChildFunc            func(ctx context.Context, id uuid.UUID) (*domain.Child, error)
+	ListChildrenByParentIDFunc  func(ctx context.Context, parentID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error)
+	ListChildrenByParentIDsFunc func(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Child, error)
+	ListChildrenFunc            func(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error)
+	CountChildrenFunc           func(ctx context.Context, filter ports.FilterOptions) (int64, error)
 
 	// Function mocks for additional FamilyService methods
-	AddChildToParentFunc      func(ctx context.Context, parentID, childID uuid.UUID) error
+	AddChildToParentFunc      func(ctx context.Context, parentID, childID uuid.UUID, guardianshipType domain.GuardianshipType, primaryContact bool, startDate *time.Time) error
 	RemoveChildFromParentFunc func(ctx context.Context, parentID, childID uuid.UUID) error
+	TransferChildFunc         func(ctx context.Context, childID, fromParentID, toParentID uuid.UUID, reason string) (*domain.Child, error)
+	PurgeDeletedFunc          func(ctx context.Context) (*ports.PurgeResult, error)
+
+	// Function mocks for the guardianships between parents and children
+	ListGuardianshipsByChildIDsFunc  func(ctx context.Context, childIDs []uuid.UUID) ([]*domain.Guardianship, error)
+	ListGuardianshipsByParentIDsFunc func(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Guardianship, error)
+	IsEventInFamilyFunc              func(ctx context.Context, event domain.Event) (bool, error)
+
+	// Function mocks for the merges of duplicate parents
+	MergeParentsFunc func(ctx context.Context, survivorID, duplicateID uuid.UUID) (*domain.Parent, error)
+
+	// Function mocks for the households of parents and children
+	CreateHouseholdFunc      func(ctx context.Context, name string, address domain.Address, phoneNumbers []string, parentIDs, childIDs []uuid.UUID) (*domain.Household, error)
+	GetHouseholdByIDFunc     func(ctx context.Context, id uuid.UUID) (*domain.Household, error)
+	MoveChildToHouseholdFunc func(ctx context.Context, childID, householdID uuid.UUID) (*domain.Household, error)
+
+	// Function mocks for the history of parents and children
+	GetParentAsOfFunc              func(ctx context.Context, id uuid.UUID, asOf time.Time) (*domain.Parent, error)
+	ListChildrenByParentIDAsOfFunc func(ctx context.Context, parentID uuid.UUID, asOf time.Time) ([]*domain.Child, error)
+	GetHistoryFunc                 func(ctx context.Context, id uuid.UUID) ([]*domain.Revision, error)
 }
 
 // NewMockFamilyService creates a new mock family service
@@ -38,13 +71,29 @@ func NewMockFamilyService() *MockFamilyService {
 }
 
 // CreateParent implements ports.ParentService
-func (m *MockFamilyService) CreateParent(ctx context.Context, firstName, lastName, email string, birthDate string) (*domain.Parent, error) {
+func (m *MockFamilyService) CreateParent(ctx context.Context, firstName, lastName, email string, birthDate string, contact *domain.ContactDetails) (*domain.Parent, error) {
 	if m.CreateParentFunc != nil {
-		return m.CreateParentFunc(ctx, firstName, lastName, email, birthDate)
+		return m.CreateParentFunc(ctx, firstName, lastName, email, birthDate, contact)
+	}
+	return nil, nil
+}
+
+// CreateParents implements ports.ParentService
+func (m *MockFamilyService) CreateParents(ctx context.Context, inputs []ports.ParentInput, atomic bool) ([]ports.ParentResult, error) {
+	if m.CreateParentsFunc != nil {
+		return m.CreateParentsFunc(ctx, inputs, atomic)
 	}
 	return nil, nil
 }
 
+// ValidateParents implements ports.ParentService
+func (m *MockFamilyService) ValidateParents(ctx context.Context, inputs []ports.ParentInput) ([]error, error) {
+	if m.ValidateParentsFunc != nil {
+		return m.ValidateParentsFunc(ctx, inputs)
+	}
+	return make([]error, len(inputs)), nil
+}
+
 // GetParentByID implements ports.ParentService
 func (m *MockFamilyService) GetParentByID(ctx context.Context, id uuid.UUID) (*domain.Parent, error) {
 	if m.GetParentByIDFunc != nil {
@@ -53,10 +102,34 @@ func (m *MockFamilyService) GetParentByID(ctx context.Context, id uuid.UUID) (*d
 	return nil, nil
 }
 
+// GetParentsByIDs implements ports.ParentService
+func (m *MockFamilyService) GetParentsByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Parent, error) {
+	if m.GetParentsByIDsFunc != nil {
+		return m.GetParentsByIDsFunc(ctx, ids)
+	}
+	return []*domain.Parent{}, nil
+}
+
+// GetParentByUserID implements ports.ParentService
+func (m *MockFamilyService) GetParentByUserID(ctx context.Context, userID string) (*domain.Parent, error) {
+	if m.GetParentByUserIDFunc != nil {
+		return m.GetParentByUserIDFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+// LinkParentUser implements ports.ParentService
+func (m *MockFamilyService) LinkParentUser(ctx context.Context, id uuid.UUID, userID string) (*domain.Parent, error) {
+	if m.LinkParentUserFunc != nil {
+		return m.LinkParentUserFunc(ctx, id, userID)
+	}
+	return nil, nil
+}
+
 // UpdateParent implements ports.ParentService
-func (m *MockFamilyService) UpdateParent(ctx context.Context, id uuid.UUID, firstName, lastName, email string, birthDate string) (*domain.Parent, error) {
+func (m *MockFamilyService) UpdateParent(ctx context.Context, id uuid.UUID, firstName, lastName, email string, birthDate string, contact *domain.ContactDetails, expectedVersion *int) (*domain.Parent, error) {
 	if m.UpdateParentFunc != nil {
-		return m.UpdateParentFunc(ctx, id, firstName, lastName, email, birthDate)
+		return m.UpdateParentFunc(ctx, id, firstName, lastName, email, birthDate, contact, expectedVersion)
 	}
 	return nil, nil
 }
@@ -69,6 +142,14 @@ func (m *MockFamilyService) DeleteParent(ctx context.Context, id uuid.UUID) erro
 	return nil
 }
 
+// RestoreParent implements ports.ParentService
+func (m *MockFamilyService) RestoreParent(ctx context.Context, id uuid.UUID) (*domain.Parent, error) {
+	if m.RestoreParentFunc != nil {
+		return m.RestoreParentFunc(ctx, id)
+	}
+	return nil, nil
+}
+
 // ListParents implements ports.ParentService
 func (m *MockFamilyService) ListParents(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
 	if m.ListParentsFunc != nil {
@@ -85,6 +166,14 @@ func (m *MockFamilyService) CountParents(ctx context.Context, filter ports.Filte
 	return 0, nil
 }
 
+// FindDuplicateParents implements ports.ParentService
+func (m *MockFamilyService) FindDuplicateParents(ctx context.Context, parentID *uuid.UUID, minScore float64, limit int) ([]*domain.ParentDuplicate, error) {
+	if m.FindDuplicateParentsFunc != nil {
+		return m.FindDuplicateParentsFunc(ctx, parentID, minScore, limit)
+	}
+	return nil, nil
+}
+
 // CreateChild implements ports.ChildService
 func (m *MockFamilyService) CreateChild(ctx context.Context, firstName, lastName string, birthDate string, parentID uuid.UUID) (*domain.Child, error) {
 	if m.CreateChildFunc != nil {
@@ -93,6 +182,22 @@ func (m *MockFamilyService) CreateChild(ctx context.Context, firstName, lastName
 	return nil, nil
 }
 
+// CreateChildren implements ports.ChildService
+func (m *MockFamilyService) CreateChildren(ctx context.Context, inputs []ports.ChildInput, atomic bool) ([]ports.ChildResult, error) {
+	if m.CreateChildrenFunc != nil {
+		return m.CreateChildrenFunc(ctx, inputs, atomic)
+	}
+	return nil, nil
+}
+
+// ValidateChildren implements ports.ChildService
+func (m *MockFamilyService) ValidateChildren(ctx context.Context, inputs []ports.ChildInput) ([]error, error) {
+	if m.ValidateChildrenFunc != nil {
+		return m.ValidateChildrenFunc(ctx, inputs)
+	}
+	return make([]error, len(inputs)), nil
+}
+
 // GetChildByID implements ports.ChildService
 func (m *MockFamilyService) GetChildByID(ctx context.Context, id uuid.UUID) (*domain.Child, error) {
 	if m.GetChildByIDFunc != nil {
@@ -102,9 +207,9 @@ func (m *MockFamilyService) GetChildByID(ctx context.Context, id uuid.UUID) (*do
 }
 
 // UpdateChild implements ports.ChildService
-func (m *MockFamilyService) UpdateChild(ctx context.Context, id uuid.UUID, firstName, lastName string, birthDate string) (*domain.Child, error) {
+func (m *MockFamilyService) UpdateChild(ctx context.Context, id uuid.UUID, firstName, lastName string, birthDate string, expectedVersion *int) (*domain.Child, error) {
 	if m.UpdateChildFunc != nil {
-		return m.UpdateChildFunc(ctx, id, firstName, lastName, birthDate)
+		return m.UpdateChildFunc(ctx, id, firstName, lastName, birthDate, expectedVersion)
 	}
 	return nil, nil
 }
@@ -117,6 +222,22 @@ func (m *MockFamilyService) DeleteChild(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
+// DeleteChildren implements ports.ChildService
+func (m *MockFamilyService) DeleteChildren(ctx context.Context, ids []uuid.UUID, atomic bool) ([]ports.DeleteResult, error) {
+	if m.DeleteChildrenFunc != nil {
+		return m.DeleteChildrenFunc(ctx, ids, atomic)
+	}
+	return nil, nil
+}
+
+// RestoreChild implements ports.ChildService
+func (m *MockFamilyService) RestoreChild(ctx context.Context, id uuid.UUID) (*domain.Child, error) {
+	if m.RestoreChildFunc != nil {
+		return m.RestoreChildFunc(ctx, id)
+	}
+	return nil, nil
+}
+
 // ListChildrenByParentID implements ports.ChildService
 func (m *MockFamilyService) ListChildrenByParentID(ctx context.Context, parentID uuid.UUID, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
 	if m.ListChildrenByParentIDFunc != nil {
@@ -125,6 +246,14 @@ func (m *MockFamilyService) ListChildrenByParentID(ctx context.Context, parentID
 	return nil, nil, nil
 }
 
+// ListChildrenByParentIDs implements ports.ChildService
+func (m *MockFamilyService) ListChildrenByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Child, error) {
+	if m.ListChildrenByParentIDsFunc != nil {
+		return m.ListChildrenByParentIDsFunc(ctx, parentIDs)
+	}
+	return []*domain.Child{}, nil
+}
+
 // ListChildren implements ports.ChildService
 func (m *MockFamilyService) ListChildren(ctx context.Context, options ports.QueryOptions) ([]*domain.Child, *ports.PagedResult, error) {
 	if m.ListChildrenFunc != nil {
@@ -142,9 +271,9 @@ func (m *MockFamilyService) CountChildren(ctx context.Context, filter ports.Filt
 }
 
 // AddChildToParent implements ports.FamilyService
-func (m *MockFamilyService) AddChildToParent(ctx context.Context, parentID, childID uuid.UUID) error {
+func (m *MockFamilyService) AddChildToParent(ctx context.Context, parentID, childID uuid.UUID, guardianshipType domain.GuardianshipType, primaryContact bool, startDate *time.Time) error {
 	if m.AddChildToParentFunc != nil {
-		return m.AddChildToParentFunc(ctx, parentID, childID)
+		return m.AddChildToParentFunc(ctx, parentID, childID, guardianshipType, primaryContact, startDate)
 	}
 	return nil
 }
@@ -156,3 +285,99 @@ func (m *MockFamilyService) RemoveChildFromParent(ctx context.Context, parentID,
 	}
 	return nil
 }
+
+// TransferChild implements ports.FamilyService
+func (m *MockFamilyService) TransferChild(ctx context.Context, childID, fromParentID, toParentID uuid.UUID, reason string) (*domain.Child, error) {
+	if m.TransferChildFunc != nil {
+		return m.TransferChildFunc(ctx, childID, fromParentID, toParentID, reason)
+	}
+	return nil, nil
+}
+
+// ListGuardianshipsByChildIDs implements ports.FamilyService
+func (m *MockFamilyService) ListGuardianshipsByChildIDs(ctx context.Context, childIDs []uuid.UUID) ([]*domain.Guardianship, error) {
+	if m.ListGuardianshipsByChildIDsFunc != nil {
+		return m.ListGuardianshipsByChildIDsFunc(ctx, childIDs)
+	}
+	return []*domain.Guardianship{}, nil
+}
+
+// ListGuardianshipsByParentIDs implements ports.FamilyService
+func (m *MockFamilyService) ListGuardianshipsByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Guardianship, error) {
+	if m.ListGuardianshipsByParentIDsFunc != nil {
+		return m.ListGuardianshipsByParentIDsFunc(ctx, parentIDs)
+	}
+	return []*domain.Guardianship{}, nil
+}
+
+// IsEventInFamily implements ports.FamilyService
+func (m *MockFamilyService) IsEventInFamily(ctx context.Context, event domain.Event) (bool, error) {
+	if m.IsEventInFamilyFunc != nil {
+		return m.IsEventInFamilyFunc(ctx, event)
+	}
+	return true, nil
+}
+
+// MergeParents implements ports.FamilyService
+func (m *MockFamilyService) MergeParents(ctx context.Context, survivorID, duplicateID uuid.UUID) (*domain.Parent, error) {
+	if m.MergeParentsFunc != nil {
+		return m.MergeParentsFunc(ctx, survivorID, duplicateID)
+	}
+	return nil, nil
+}
+
+// CreateHousehold implements ports.FamilyService
+func (m *MockFamilyService) CreateHousehold(ctx context.Context, name string, address domain.Address, phoneNumbers []string, parentIDs, childIDs []uuid.UUID) (*domain.Household, error) {
+	if m.CreateHouseholdFunc != nil {
+		return m.CreateHouseholdFunc(ctx, name, address, phoneNumbers, parentIDs, childIDs)
+	}
+	return domain.NewHousehold(name, address, phoneNumbers, parentIDs, childIDs), nil
+}
+
+// GetHouseholdByID implements ports.FamilyService
+func (m *MockFamilyService) GetHouseholdByID(ctx context.Context, id uuid.UUID) (*domain.Household, error) {
+	if m.GetHouseholdByIDFunc != nil {
+		return m.GetHouseholdByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+// MoveChildToHousehold implements ports.FamilyService
+func (m *MockFamilyService) MoveChildToHousehold(ctx context.Context, childID, householdID uuid.UUID) (*domain.Household, error) {
+	if m.MoveChildToHouseholdFunc != nil {
+		return m.MoveChildToHouseholdFunc(ctx, childID, householdID)
+	}
+	return nil, nil
+}
+
+// PurgeDeleted implements ports.FamilyService
+func (m *MockFamilyService) PurgeDeleted(ctx context.Context) (*ports.PurgeResult, error) {
+	if m.PurgeDeletedFunc != nil {
+		return m.PurgeDeletedFunc(ctx)
+	}
+	return &ports.PurgeResult{}, nil
+}
+
+// GetParentAsOf implements ports.FamilyService
+func (m *MockFamilyService) GetParentAsOf(ctx context.Context, id uuid.UUID, asOf time.Time) (*domain.Parent, error) {
+	if m.GetParentAsOfFunc != nil {
+		return m.GetParentAsOfFunc(ctx, id, asOf)
+	}
+	return nil, nil
+}
+
+// ListChildrenByParentIDAsOf implements ports.FamilyService
+func (m *MockFamilyService) ListChildrenByParentIDAsOf(ctx context.Context, parentID uuid.UUID, asOf time.Time) ([]*domain.Child, error) {
+	if m.ListChildrenByParentIDAsOfFunc != nil {
+		return m.ListChildrenByParentIDAsOfFunc(ctx, parentID, asOf)
+	}
+	return []*domain.Child{}, nil
+}
+
+// GetHistory implements ports.FamilyService
+func (m *MockFamilyService) GetHistory(ctx context.Context, id uuid.UUID) ([]*domain.Revision, error) {
+	if m.GetHistoryFunc != nil {
+		return m.GetHistoryFunc(ctx, id)
+	}
+	return []*domain.Revision{}, nil
+}
@@ -0,0 +1,129 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+)
+
+// MockGuardianshipRepository is a mock implementation of the ports.GuardianshipRepository interface
+type MockGuardianshipRepository struct {
+	// Function mocks for testing specific scenarios
+	CreateFunc          func(ctx context.Context, guardianship *domain.Guardianship) error
+	UpdateFunc          func(ctx context.Context, guardianship *domain.Guardianship) error
+	ListByChildIDsFunc  func(ctx context.Context, childIDs []uuid.UUID) ([]*domain.Guardianship, error)
+	ListByParentIDsFunc func(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Guardianship, error)
+
+	// In-memory storage for testing
+	mu            sync.Mutex
+	guardianships map[uuid.UUID]domain.Guardianship
+}
+
+// NewMockGuardianshipRepository creates a new mock guardianship repository
+func NewMockGuardianshipRepository() *MockGuardianshipRepository {
+	return &MockGuardianshipRepository{
+		guardianships: make(map[uuid.UUID]domain.Guardianship),
+	}
+}
+
+// Create stores a new guardianship of the caller's tenant
+func (r *MockGuardianshipRepository) Create(ctx context.Context, guardianship *domain.Guardianship) error {
+	if r.CreateFunc != nil {
+		return r.CreateFunc(ctx, guardianship)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	guardianship.TenantID = ports.TenantIDFromContext(ctx)
+	r.guardianships[guardianship.ID] = *guardianship
+	return nil
+}
+
+// Update stores the type, dates and primary contact mark of a guardianship of the caller's tenant
+func (r *MockGuardianshipRepository) Update(ctx context.Context, guardianship *domain.Guardianship) error {
+	if r.UpdateFunc != nil {
+		return r.UpdateFunc(ctx, guardianship)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.guardianships[guardianship.ID]
+	if !ok || stored.TenantID != ports.TenantIDFromContext(ctx) {
+		return fmt.Errorf("guardianship not found: %w", domain.ErrNotFound)
+	}
+
+	r.guardianships[guardianship.ID] = *guardianship
+	return nil
+}
+
+// ListByChildIDs retrieves the guardianships of the given children of the caller's tenant, ordered by child and start date
+func (r *MockGuardianshipRepository) ListByChildIDs(ctx context.Context, childIDs []uuid.UUID) ([]*domain.Guardianship, error) {
+	if r.ListByChildIDsFunc != nil {
+		return r.ListByChildIDsFunc(ctx, childIDs)
+	}
+
+	return r.list(ctx, childIDs, func(guardianship *domain.Guardianship) uuid.UUID {
+		return guardianship.ChildID
+	}), nil
+}
+
+// ListByParentIDs retrieves the guardianships of the given parents of the caller's tenant, ordered by parent and start date
+func (r *MockGuardianshipRepository) ListByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Guardianship, error) {
+	if r.ListByParentIDsFunc != nil {
+		return r.ListByParentIDsFunc(ctx, parentIDs)
+	}
+
+	return r.list(ctx, parentIDs, func(guardianship *domain.Guardianship) uuid.UUID {
+		return guardianship.ParentID
+	}), nil
+}
+
+// list returns copies of the guardianships of the caller's tenant whose key is one of ids,
+// ordered by key and start date
+func (r *MockGuardianshipRepository) list(ctx context.Context, ids []uuid.UUID, key func(guardianship *domain.Guardianship) uuid.UUID) []*domain.Guardianship {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wanted := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	tenantID := ports.TenantIDFromContext(ctx)
+	guardianships := []*domain.Guardianship{}
+	for _, stored := range r.guardianships {
+		guardianship := stored
+		if guardianship.TenantID == tenantID && wanted[key(&guardianship)] {
+			guardianships = append(guardianships, &guardianship)
+		}
+	}
+	sort.Slice(guardianships, func(i, j int) bool {
+		ki, kj := key(guardianships[i]), key(guardianships[j])
+		if ki != kj {
+			return ki.String() < kj.String()
+		}
+		if !guardianships[i].StartDate.Equal(guardianships[j].StartDate) {
+			return guardianships[i].StartDate.Before(guardianships[j].StartDate)
+		}
+		return guardianships[i].CreatedAt.Before(guardianships[j].CreatedAt)
+	})
+	return guardianships
+}
+
+// Reset clears the guardianships
+func (r *MockGuardianshipRepository) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.guardianships = make(map[uuid.UUID]domain.Guardianship)
+}
+
+// Ensure MockGuardianshipRepository implements ports.GuardianshipRepository
+var _ ports.GuardianshipRepository = (*MockGuardianshipRepository)(nil)
@@ -0,0 +1,154 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+)
+
+// MockHistoryRepository is a mock implementation of the ports.HistoryRepository interface.
+// Unlike the databases, it does not record the writes of the other mock repositories;
+// tests add the revisions they need with AddRevision.
+type MockHistoryRepository struct {
+	// Function mocks for testing specific scenarios
+	GetParentAsOfFunc       func(ctx context.Context, id uuid.UUID, asOf time.Time) (*domain.Parent, error)
+	ListChildrenAsOfFunc    func(ctx context.Context, parentID uuid.UUID, asOf time.Time) ([]*domain.Child, error)
+	ListParentRevisionsFunc func(ctx context.Context, id uuid.UUID) ([]*domain.Revision, error)
+	ListChildRevisionsFunc  func(ctx context.Context, id uuid.UUID) ([]*domain.Revision, error)
+
+	// In-memory storage for testing, in the order the revisions were added
+	mu        sync.Mutex
+	revisions []domain.Revision
+}
+
+// NewMockHistoryRepository creates a new mock history repository
+func NewMockHistoryRepository() *MockHistoryRepository {
+	return &MockHistoryRepository{}
+}
+
+// AddRevision stores a revision, as the database does when a parent or child is written
+func (r *MockHistoryRepository) AddRevision(revision *domain.Revision) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.revisions = append(r.revisions, *revision)
+}
+
+// GetParentAsOf retrieves the latest revision of a parent of the caller's tenant recorded at or before asOf
+func (r *MockHistoryRepository) GetParentAsOf(ctx context.Context, id uuid.UUID, asOf time.Time) (*domain.Parent, error) {
+	if r.GetParentAsOfFunc != nil {
+		return r.GetParentAsOfFunc(ctx, id, asOf)
+	}
+
+	var latest *domain.Parent
+	for _, revision := range r.asOf(ctx, asOf) {
+		if revision.Parent != nil && revision.Parent.ID == id {
+			latest = revision.Parent
+		}
+	}
+	if latest == nil || latest.IsDeleted() {
+		return nil, fmt.Errorf("parent not found as of %s: %w", asOf.Format(time.RFC3339), domain.ErrNotFound)
+	}
+
+	parent := *latest
+	return &parent, nil
+}
+
+// ListChildrenAsOf retrieves the children of the caller's tenant whose latest revision recorded at or before asOf
+// belongs to the parent and is not deleted, ordered by creation time
+func (r *MockHistoryRepository) ListChildrenAsOf(ctx context.Context, parentID uuid.UUID, asOf time.Time) ([]*domain.Child, error) {
+	if r.ListChildrenAsOfFunc != nil {
+		return r.ListChildrenAsOfFunc(ctx, parentID, asOf)
+	}
+
+	latest := make(map[uuid.UUID]*domain.Child)
+	for _, revision := range r.asOf(ctx, asOf) {
+		if revision.Child != nil {
+			latest[revision.Child.ID] = revision.Child
+		}
+	}
+
+	children := []*domain.Child{}
+	for _, child := range latest {
+		if child.ParentID == parentID && !child.IsDeleted() {
+			copied := *child
+			children = append(children, &copied)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].CreatedAt.Before(children[j].CreatedAt)
+	})
+	return children, nil
+}
+
+// ListParentRevisions retrieves the revisions of a parent of the caller's tenant, oldest first
+func (r *MockHistoryRepository) ListParentRevisions(ctx context.Context, id uuid.UUID) ([]*domain.Revision, error) {
+	if r.ListParentRevisionsFunc != nil {
+		return r.ListParentRevisionsFunc(ctx, id)
+	}
+
+	return r.list(ctx, func(revision *domain.Revision) bool {
+		return revision.Parent != nil && revision.Parent.ID == id
+	}), nil
+}
+
+// ListChildRevisions retrieves the revisions of a child of the caller's tenant, oldest first
+func (r *MockHistoryRepository) ListChildRevisions(ctx context.Context, id uuid.UUID) ([]*domain.Revision, error) {
+	if r.ListChildRevisionsFunc != nil {
+		return r.ListChildRevisionsFunc(ctx, id)
+	}
+
+	return r.list(ctx, func(revision *domain.Revision) bool {
+		return revision.Child != nil && revision.Child.ID == id
+	}), nil
+}
+
+// asOf returns the revisions of the caller's tenant recorded at or before asOf, oldest first
+func (r *MockHistoryRepository) asOf(ctx context.Context, asOf time.Time) []*domain.Revision {
+	return r.list(ctx, func(revision *domain.Revision) bool {
+		return !revision.RecordedAt.After(asOf)
+	})
+}
+
+// list returns copies of the revisions of the caller's tenant selected by match, oldest first
+func (r *MockHistoryRepository) list(ctx context.Context, match func(revision *domain.Revision) bool) []*domain.Revision {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tenantID := ports.TenantIDFromContext(ctx)
+	revisions := []*domain.Revision{}
+	for _, revision := range r.revisions {
+		if revisionTenantID(&revision) == tenantID && match(&revision) {
+			revisions = append(revisions, &revision)
+		}
+	}
+	sort.SliceStable(revisions, func(i, j int) bool {
+		return revisions[i].RecordedAt.Before(revisions[j].RecordedAt)
+	})
+	return revisions
+}
+
+// Reset clears the revisions
+func (r *MockHistoryRepository) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.revisions = nil
+}
+
+// revisionTenantID returns the tenant of the entity of a revision
+func revisionTenantID(revision *domain.Revision) string {
+	if revision.Parent != nil {
+		return revision.Parent.TenantID
+	}
+	return revision.Child.TenantID
+}
+
+// Ensure MockHistoryRepository implements ports.HistoryRepository
+var _ ports.HistoryRepository = (*MockHistoryRepository)(nil)
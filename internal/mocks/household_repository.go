@@ -0,0 +1,145 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+)
+
+// MockHouseholdRepository is a mock implementation of the ports.HouseholdRepository interface
+type MockHouseholdRepository struct {
+	// Function mocks for testing specific scenarios
+	CreateFunc       func(ctx context.Context, household *domain.Household) error
+	GetByIDFunc      func(ctx context.Context, id uuid.UUID) (*domain.Household, error)
+	GetByChildIDFunc func(ctx context.Context, childID uuid.UUID) (*domain.Household, error)
+	UpdateFunc       func(ctx context.Context, household *domain.Household) error
+
+	// In-memory storage for testing
+	mu         sync.Mutex
+	households map[uuid.UUID]domain.Household
+}
+
+// NewMockHouseholdRepository creates a new mock household repository
+func NewMockHouseholdRepository() *MockHouseholdRepository {
+	return &MockHouseholdRepository{
+		households: make(map[uuid.UUID]domain.Household),
+	}
+}
+
+// Create stores a new household of the caller's tenant, failing like the unique index
+// of the databases when one of its children already belongs to a household
+func (r *MockHouseholdRepository) Create(ctx context.Context, household *domain.Household) error {
+	if r.CreateFunc != nil {
+		return r.CreateFunc(ctx, household)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	household.TenantID = ports.TenantIDFromContext(ctx)
+	if err := r.checkChildren(household); err != nil {
+		return err
+	}
+
+	r.households[household.ID] = copyHousehold(household)
+	return nil
+}
+
+// GetByID retrieves a household of the caller's tenant by ID
+func (r *MockHouseholdRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Household, error) {
+	if r.GetByIDFunc != nil {
+		return r.GetByIDFunc(ctx, id)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.households[id]
+	if !ok || stored.TenantID != ports.TenantIDFromContext(ctx) {
+		return nil, fmt.Errorf("household not found: %w", domain.ErrNotFound)
+	}
+
+	household := copyHousehold(&stored)
+	return &household, nil
+}
+
+// GetByChildID retrieves the household of the caller's tenant that a child belongs to
+func (r *MockHouseholdRepository) GetByChildID(ctx context.Context, childID uuid.UUID) (*domain.Household, error) {
+	if r.GetByChildIDFunc != nil {
+		return r.GetByChildIDFunc(ctx, childID)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tenantID := ports.TenantIDFromContext(ctx)
+	for _, stored := range r.households {
+		if stored.TenantID == tenantID && stored.HasChild(childID) {
+			household := copyHousehold(&stored)
+			return &household, nil
+		}
+	}
+
+	return nil, fmt.Errorf("household not found: %w", domain.ErrNotFound)
+}
+
+// Update stores the name, address, phone numbers and members of a household of the caller's tenant
+func (r *MockHouseholdRepository) Update(ctx context.Context, household *domain.Household) error {
+	if r.UpdateFunc != nil {
+		return r.UpdateFunc(ctx, household)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.households[household.ID]
+	if !ok || stored.TenantID != ports.TenantIDFromContext(ctx) {
+		return fmt.Errorf("household not found: %w", domain.ErrNotFound)
+	}
+	if err := r.checkChildren(household); err != nil {
+		return err
+	}
+
+	household.TenantID = stored.TenantID
+	r.households[household.ID] = copyHousehold(household)
+	return nil
+}
+
+// checkChildren fails when a child of the household belongs to another household of its tenant
+func (r *MockHouseholdRepository) checkChildren(household *domain.Household) error {
+	for _, stored := range r.households {
+		if stored.ID == household.ID || stored.TenantID != household.TenantID {
+			continue
+		}
+		for _, childID := range household.ChildIDs {
+			if stored.HasChild(childID) {
+				return fmt.Errorf("child %s already belongs to household %s", childID, stored.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// copyHousehold returns a copy of a household that shares no slices with it
+func copyHousehold(household *domain.Household) domain.Household {
+	copied := *household
+	copied.PhoneNumbers = append([]string{}, household.PhoneNumbers...)
+	copied.ParentIDs = append([]uuid.UUID{}, household.ParentIDs...)
+	copied.ChildIDs = append([]uuid.UUID{}, household.ChildIDs...)
+	return copied
+}
+
+// Reset clears the households
+func (r *MockHouseholdRepository) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.households = make(map[uuid.UUID]domain.Household)
+}
+
+// Ensure MockHouseholdRepository implements ports.HouseholdRepository
+var _ ports.HouseholdRepository = (*MockHouseholdRepository)(nil)
@@ -0,0 +1,159 @@
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+)
+
+// MockOutboxRepository is a mock implementation of the ports.OutboxRepository interface
+type MockOutboxRepository struct {
+	// Function mocks for testing specific scenarios
+	AppendFunc       func(ctx context.Context, event domain.Event) error
+	LeasePendingFunc func(ctx context.Context, limit int, now, leaseUntil time.Time) ([]ports.OutboxMessage, error)
+	AcknowledgeFunc  func(ctx context.Context, sequence int64) error
+	MarkFailedFunc   func(ctx context.Context, sequence int64, nextAttemptAt time.Time, lastError string) error
+	QuarantineFunc   func(ctx context.Context, sequence int64, reason string) error
+
+	// In-memory storage for testing
+	mu          sync.Mutex
+	messages    map[int64]ports.OutboxMessage
+	deadLetters map[int64]string
+	sequence    int64
+}
+
+// NewMockOutboxRepository creates a new mock outbox repository
+func NewMockOutboxRepository() *MockOutboxRepository {
+	return &MockOutboxRepository{
+		messages:    make(map[int64]ports.OutboxMessage),
+		deadLetters: make(map[int64]string),
+	}
+}
+
+// Append records an event in the outbox
+func (r *MockOutboxRepository) Append(ctx context.Context, event domain.Event) error {
+	if r.AppendFunc != nil {
+		return r.AppendFunc(ctx, event)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sequence++
+	r.messages[r.sequence] = ports.OutboxMessage{
+		Sequence:      r.sequence,
+		Event:         event,
+		NextAttemptAt: event.OccurredAt,
+	}
+	return nil
+}
+
+// LeasePending retrieves up to limit undelivered messages of the families whose earliest message is due,
+// ordered by sequence. Leases are not modelled, as a single relay runs against the mock.
+func (r *MockOutboxRepository) LeasePending(ctx context.Context, limit int, now, leaseUntil time.Time) ([]ports.OutboxMessage, error) {
+	if r.LeasePendingFunc != nil {
+		return r.LeasePendingFunc(ctx, limit, now, leaseUntil)
+	}
+
+	var messages []ports.OutboxMessage
+	due := make(map[uuid.UUID]bool)
+	for _, message := range r.Messages() {
+		family := message.Event.ParentID
+		if _, seen := due[family]; !seen {
+			due[family] = !message.NextAttemptAt.After(now)
+		}
+		if due[family] && len(messages) < limit {
+			messages = append(messages, message)
+		}
+	}
+	return messages, nil
+}
+
+// Acknowledge removes a delivered message from the outbox
+func (r *MockOutboxRepository) Acknowledge(ctx context.Context, sequence int64) error {
+	if r.AcknowledgeFunc != nil {
+		return r.AcknowledgeFunc(ctx, sequence)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.messages, sequence)
+	return nil
+}
+
+// MarkFailed records a failed attempt to deliver a message
+func (r *MockOutboxRepository) MarkFailed(ctx context.Context, sequence int64, nextAttemptAt time.Time, lastError string) error {
+	if r.MarkFailedFunc != nil {
+		return r.MarkFailedFunc(ctx, sequence, nextAttemptAt, lastError)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if message, ok := r.messages[sequence]; ok {
+		message.Attempts++
+		message.NextAttemptAt = nextAttemptAt
+		message.LastError = lastError
+		r.messages[sequence] = message
+	}
+	return nil
+}
+
+// Quarantine moves a message to the dead letters
+func (r *MockOutboxRepository) Quarantine(ctx context.Context, sequence int64, reason string) error {
+	if r.QuarantineFunc != nil {
+		return r.QuarantineFunc(ctx, sequence, reason)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.messages[sequence]; ok {
+		delete(r.messages, sequence)
+		r.deadLetters[sequence] = reason
+	}
+	return nil
+}
+
+// DeadLetters returns why each quarantined message was quarantined, by sequence, for test assertions
+func (r *MockOutboxRepository) DeadLetters() map[int64]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deadLetters := make(map[int64]string, len(r.deadLetters))
+	for sequence, reason := range r.deadLetters {
+		deadLetters[sequence] = reason
+	}
+	return deadLetters
+}
+
+// Messages returns the undelivered messages ordered by sequence, for test assertions
+func (r *MockOutboxRepository) Messages() []ports.OutboxMessage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	messages := make([]ports.OutboxMessage, 0, len(r.messages))
+	for _, message := range r.messages {
+		messages = append(messages, message)
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].Sequence < messages[j].Sequence
+	})
+	return messages
+}
+
+// Reset clears the outbox
+func (r *MockOutboxRepository) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.messages = make(map[int64]ports.OutboxMessage)
+	r.deadLetters = make(map[int64]string)
+	r.sequence = 0
+}
@@ -3,7 +3,10 @@ package mocks
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
@@ -16,12 +19,18 @@ type MockParentRepository struct {
 	parents map[uuid.UUID]*domain.Parent
 
 	// Function mocks for testing specific scenarios
-	CreateFunc  func(ctx context.Context, parent *domain.Parent) error
-	GetByIDFunc func(ctx context.Context, id uuid.UUID) (*domain.Parent, error)
-	UpdateFunc  func(ctx context.Context, parent *domain.Parent) error
-	DeleteFunc  func(ctx context.Context, id uuid.UUID) error
-	ListFunc    func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error)
-	CountFunc   func(ctx context.Context, filter ports.FilterOptions) (int64, error)
+	CreateFunc          func(ctx context.Context, parent *domain.Parent) error
+	CreateManyFunc      func(ctx context.Context, parents []*domain.Parent) error
+	GetByIDFunc         func(ctx context.Context, id uuid.UUID) (*domain.Parent, error)
+	GetByIDsFunc        func(ctx context.Context, ids []uuid.UUID) ([]*domain.Parent, error)
+	GetByUserIDFunc     func(ctx context.Context, userID string) (*domain.Parent, error)
+	FindTakenEmailsFunc func(ctx context.Context, emails []string) ([]string, error)
+	UpdateFunc          func(ctx context.Context, parent *domain.Parent) error
+	DeleteFunc          func(ctx context.Context, id uuid.UUID) error
+	RestoreFunc         func(ctx context.Context, id uuid.UUID) error
+	PurgeFunc           func(ctx context.Context, deletedBefore time.Time) (int64, error)
+	ListFunc            func(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error)
+	CountFunc           func(ctx context.Context, filter ports.FilterOptions) (int64, error)
 }
 
 // NewMockParentRepository creates a new mock parent repository
@@ -45,6 +54,11 @@ func (r *MockParentRepository) Create(ctx context.Context, parent *domain.Parent
 		return errors.New("parent already exists")
 	}
 
+	// Like the database repositories, the email of non-deleted parents is unique
+	if r.emailTaken(parent.ID, parent.Email) {
+		return domain.NewDuplicateError("Parent", "email", parent.Email)
+	}
+
 	// Create a deep copy to avoid reference issues
 	parentCopy := *parent
 	r.parents[parent.ID] = &parentCopy
@@ -52,6 +66,36 @@ func (r *MockParentRepository) Create(ctx context.Context, parent *domain.Parent
 	return nil
 }
 
+// CreateMany adds many parents to the mock repository. Like a database transaction, it adds either
+// all of the parents or, when one of them cannot be added, none of them.
+func (r *MockParentRepository) CreateMany(ctx context.Context, parents []*domain.Parent) error {
+	if r.CreateManyFunc != nil {
+		return r.CreateManyFunc(ctx, parents)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	emails := make(map[string]bool, len(parents))
+	for i, parent := range parents {
+		if _, exists := r.parents[parent.ID]; exists {
+			return domain.NewBatchError(i, errors.New("parent already exists"))
+		}
+		email := strings.ToLower(parent.Email)
+		if emails[email] || r.emailTaken(parent.ID, parent.Email) {
+			return domain.NewBatchError(i, domain.NewDuplicateError("Parent", "email", parent.Email))
+		}
+		emails[email] = true
+	}
+
+	for _, parent := range parents {
+		parentCopy := *parent
+		r.parents[parent.ID] = &parentCopy
+	}
+
+	return nil
+}
+
 // GetByID retrieves a parent by ID from the mock repository
 func (r *MockParentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Parent, error) {
 	if r.GetByIDFunc != nil {
@@ -71,6 +115,75 @@ func (r *MockParentRepository) GetByID(ctx context.Context, id uuid.UUID) (*doma
 	return &parentCopy, nil
 }
 
+// GetByIDs retrieves the parents with the given IDs from the mock repository
+func (r *MockParentRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Parent, error) {
+	if r.GetByIDsFunc != nil {
+		return r.GetByIDsFunc(ctx, ids)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	parents := make([]*domain.Parent, 0, len(ids))
+	for _, id := range ids {
+		parent, exists := r.parents[id]
+		if !exists || parent.DeletedAt != nil {
+			continue
+		}
+
+		// Return a copy to avoid reference issues
+		parentCopy := *parent
+		parents = append(parents, &parentCopy)
+	}
+
+	return parents, nil
+}
+
+// GetByUserID retrieves the parent linked to a user account from the mock repository
+func (r *MockParentRepository) GetByUserID(ctx context.Context, userID string) (*domain.Parent, error) {
+	if r.GetByUserIDFunc != nil {
+		return r.GetByUserIDFunc(ctx, userID)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, parent := range r.parents {
+		if parent.UserID == userID && parent.DeletedAt == nil {
+			// Return a copy to avoid reference issues
+			parentCopy := *parent
+			return &parentCopy, nil
+		}
+	}
+
+	return nil, fmt.Errorf("parent not found for user: %w", domain.ErrNotFound)
+}
+
+// FindTakenEmails returns the emails of the mock repository's non-deleted parents that are among the given emails
+func (r *MockParentRepository) FindTakenEmails(ctx context.Context, emails []string) ([]string, error) {
+	if r.FindTakenEmailsFunc != nil {
+		return r.FindTakenEmailsFunc(ctx, emails)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	taken := []string{}
+	for _, parent := range r.parents {
+		if parent.DeletedAt != nil {
+			continue
+		}
+		for _, email := range emails {
+			if strings.EqualFold(parent.Email, email) {
+				taken = append(taken, parent.Email)
+				break
+			}
+		}
+	}
+
+	return taken, nil
+}
+
 // Update updates a parent in the mock repository
 func (r *MockParentRepository) Update(ctx context.Context, parent *domain.Parent) error {
 	if r.UpdateFunc != nil {
@@ -81,12 +194,22 @@ func (r *MockParentRepository) Update(ctx context.Context, parent *domain.Parent
 	defer r.mu.Unlock()
 
 	// Check if parent exists
-	_, exists := r.parents[parent.ID]
-	if !exists || r.parents[parent.ID].DeletedAt != nil {
+	existing, exists := r.parents[parent.ID]
+	if !exists || existing.DeletedAt != nil {
 		return errors.New("parent not found")
 	}
 
+	// Like the database repositories, only update the version that was read
+	if existing.Version != parent.Version {
+		return domain.NewConflictError("Parent", parent.ID.String(), parent.Version)
+	}
+
+	if r.emailTaken(parent.ID, parent.Email) {
+		return domain.NewDuplicateError("Parent", "email", parent.Email)
+	}
+
 	// Update the parent
+	parent.Version++
 	parentCopy := *parent
 	r.parents[parent.ID] = &parentCopy
 
@@ -110,10 +233,58 @@ func (r *MockParentRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 	// Mark parent as deleted
 	parent.MarkAsDeleted()
+	parent.Version++
 
 	return nil
 }
 
+// Restore unmarks a parent that was marked as deleted in the mock repository
+func (r *MockParentRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	if r.RestoreFunc != nil {
+		return r.RestoreFunc(ctx, id)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Check if a deleted parent exists
+	parent, exists := r.parents[id]
+	if !exists || parent.DeletedAt == nil {
+		return fmt.Errorf("parent not found for restore: %w", domain.ErrNotFound)
+	}
+
+	if r.emailTaken(parent.ID, parent.Email) {
+		return domain.NewDuplicateError("Parent", "email", "")
+	}
+
+	// Unmark parent as deleted
+	parent.Restore()
+	parent.Version++
+
+	return nil
+}
+
+// Purge removes the parents that were marked as deleted before the given time from the mock repository.
+// Unlike the database repositories, the mock does not know the children, so it does not keep parents that still have children.
+func (r *MockParentRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	if r.PurgeFunc != nil {
+		return r.PurgeFunc(ctx, deletedBefore)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged int64
+	for id, parent := range r.parents {
+		if parent.DeletedAt != nil && parent.DeletedAt.Before(deletedBefore) {
+			delete(r.parents, id)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
 // List retrieves a list of parents with pagination, filtering, and sorting
 func (r *MockParentRepository) List(ctx context.Context, options ports.QueryOptions) ([]*domain.Parent, *ports.PagedResult, error) {
 	if r.ListFunc != nil {
@@ -126,7 +297,7 @@ func (r *MockParentRepository) List(ctx context.Context, options ports.QueryOpti
 	// Filter parents
 	var filteredParents []*domain.Parent
 	for _, parent := range r.parents {
-		if parent.DeletedAt != nil {
+		if (parent.DeletedAt != nil) != options.Filter.Deleted {
 			continue
 		}
 
@@ -140,6 +311,15 @@ func (r *MockParentRepository) List(ctx context.Context, options ports.QueryOpti
 		if options.Filter.Email != "" && parent.Email != options.Filter.Email {
 			continue
 		}
+		if len(options.Filter.ParentIDs) > 0 && !containsID(options.Filter.ParentIDs, parent.ID) {
+			continue
+		}
+		if options.Filter.PostalCode != "" && (parent.Address == nil || parent.Address.PostalCode != options.Filter.PostalCode) {
+			continue
+		}
+		if options.Filter.Phone != "" && !parent.HasPhone(options.Filter.Phone) {
+			continue
+		}
 
 		// Add parent to filtered list
 		parentCopy := *parent
@@ -184,7 +364,7 @@ func (r *MockParentRepository) Count(ctx context.Context, filter ports.FilterOpt
 	// Filter parents
 	var count int64
 	for _, parent := range r.parents {
-		if parent.DeletedAt != nil {
+		if (parent.DeletedAt != nil) != filter.Deleted {
 			continue
 		}
 
@@ -198,6 +378,15 @@ func (r *MockParentRepository) Count(ctx context.Context, filter ports.FilterOpt
 		if filter.Email != "" && parent.Email != filter.Email {
 			continue
 		}
+		if len(filter.ParentIDs) > 0 && !containsID(filter.ParentIDs, parent.ID) {
+			continue
+		}
+		if filter.PostalCode != "" && (parent.Address == nil || parent.Address.PostalCode != filter.PostalCode) {
+			continue
+		}
+		if filter.Phone != "" && !parent.HasPhone(filter.Phone) {
+			continue
+		}
 
 		count++
 	}
@@ -205,6 +394,17 @@ func (r *MockParentRepository) Count(ctx context.Context, filter ports.FilterOpt
 	return count, nil
 }
 
+// emailTaken reports whether a non-deleted parent other than the given one has the email, regardless of case.
+// The caller must hold the lock.
+func (r *MockParentRepository) emailTaken(id uuid.UUID, email string) bool {
+	for _, parent := range r.parents {
+		if parent.ID != id && parent.DeletedAt == nil && strings.EqualFold(parent.Email, email) {
+			return true
+		}
+	}
+	return false
+}
+
 // AddTestParent adds a test parent to the mock repository
 func (r *MockParentRepository) AddTestParent(parent *domain.Parent) {
 	r.mu.Lock()
@@ -221,3 +421,13 @@ func (r *MockParentRepository) Reset() {
 
 	r.parents = make(map[uuid.UUID]*domain.Parent)
 }
+
+// containsID reports whether ids contains id
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
@@ -10,6 +10,12 @@ import (
 type MockRepositoryFactory struct {
 	parentRepo *MockParentRepository
 	childRepo  *MockChildRepository
+	outbox     *MockOutboxRepository
+	webhooks   *MockWebhookRepository
+	auditLog   *MockAuditLogRepository
+	history    *MockHistoryRepository
+	guardians  *MockGuardianshipRepository
+	households *MockHouseholdRepository
 	txManager  *MockTransactionManager
 }
 
@@ -18,6 +24,12 @@ func NewMockRepositoryFactory() *MockRepositoryFactory {
 	return &MockRepositoryFactory{
 		parentRepo: NewMockParentRepository(),
 		childRepo:  NewMockChildRepository(),
+		outbox:     NewMockOutboxRepository(),
+		webhooks:   NewMockWebhookRepository(),
+		auditLog:   NewMockAuditLogRepository(),
+		history:    NewMockHistoryRepository(),
+		guardians:  NewMockGuardianshipRepository(),
+		households: NewMockHouseholdRepository(),
 		txManager:  NewMockTransactionManager(),
 	}
 }
@@ -32,6 +44,36 @@ func (f *MockRepositoryFactory) NewChildRepository() ports.ChildRepository {
 	return f.childRepo
 }
 
+// NewOutboxRepository returns an outbox repository
+func (f *MockRepositoryFactory) NewOutboxRepository() ports.OutboxRepository {
+	return f.outbox
+}
+
+// NewWebhookRepository returns a webhook repository
+func (f *MockRepositoryFactory) NewWebhookRepository() ports.WebhookRepository {
+	return f.webhooks
+}
+
+// NewAuditLogRepository returns an audit log repository
+func (f *MockRepositoryFactory) NewAuditLogRepository() ports.AuditLogRepository {
+	return f.auditLog
+}
+
+// NewHistoryRepository returns a history repository
+func (f *MockRepositoryFactory) NewHistoryRepository() ports.HistoryRepository {
+	return f.history
+}
+
+// NewGuardianshipRepository returns a guardianship repository
+func (f *MockRepositoryFactory) NewGuardianshipRepository() ports.GuardianshipRepository {
+	return f.guardians
+}
+
+// NewHouseholdRepository returns a household repository
+func (f *MockRepositoryFactory) NewHouseholdRepository() ports.HouseholdRepository {
+	return f.households
+}
+
 // GetTransactionManager returns the transaction manager
 func (f *MockRepositoryFactory) GetTransactionManager() ports.TransactionManager {
 	return f.txManager
@@ -47,6 +89,36 @@ func (f *MockRepositoryFactory) GetMockChildRepository() *MockChildRepository {
 	return f.childRepo
 }
 
+// GetMockOutboxRepository returns the mock outbox repository for test assertions
+func (f *MockRepositoryFactory) GetMockOutboxRepository() *MockOutboxRepository {
+	return f.outbox
+}
+
+// GetMockWebhookRepository returns the mock webhook repository for test assertions
+func (f *MockRepositoryFactory) GetMockWebhookRepository() *MockWebhookRepository {
+	return f.webhooks
+}
+
+// GetMockAuditLogRepository returns the mock audit log repository for test assertions
+func (f *MockRepositoryFactory) GetMockAuditLogRepository() *MockAuditLogRepository {
+	return f.auditLog
+}
+
+// GetMockHistoryRepository returns the mock history repository for test assertions
+func (f *MockRepositoryFactory) GetMockHistoryRepository() *MockHistoryRepository {
+	return f.history
+}
+
+// GetMockGuardianshipRepository returns the mock guardianship repository for test assertions
+func (f *MockRepositoryFactory) GetMockGuardianshipRepository() *MockGuardianshipRepository {
+	return f.guardians
+}
+
+// GetMockHouseholdRepository returns the mock household repository for test assertions
+func (f *MockRepositoryFactory) GetMockHouseholdRepository() *MockHouseholdRepository {
+	return f.households
+}
+
 // GetMockTransactionManager returns the mock transaction manager for test assertions
 func (f *MockRepositoryFactory) GetMockTransactionManager() *MockTransactionManager {
 	return f.txManager
@@ -56,6 +128,12 @@ func (f *MockRepositoryFactory) GetMockTransactionManager() *MockTransactionMana
 func (f *MockRepositoryFactory) Reset() {
 	f.parentRepo.Reset()
 	f.childRepo.Reset()
+	f.outbox.Reset()
+	f.webhooks.Reset()
+	f.auditLog.Reset()
+	f.history.Reset()
+	f.guardians.Reset()
+	f.households.Reset()
 	f.txManager.Reset()
 }
 
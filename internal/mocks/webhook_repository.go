@@ -0,0 +1,213 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+)
+
+// MockWebhookRepository is a mock implementation of the ports.WebhookRepository interface
+type MockWebhookRepository struct {
+	// Function mocks for testing specific scenarios
+	CreateSubscriptionFunc func(ctx context.Context, subscription *domain.WebhookSubscription) error
+	GetSubscriptionFunc    func(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error)
+	ListSubscriptionsFunc  func(ctx context.Context) ([]*domain.WebhookSubscription, error)
+	DeleteSubscriptionFunc func(ctx context.Context, id uuid.UUID) error
+	EnqueueDeliveryFunc    func(ctx context.Context, delivery *domain.WebhookDelivery) error
+	FetchDueDeliveriesFunc func(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error)
+	UpdateDeliveryFunc     func(ctx context.Context, delivery *domain.WebhookDelivery) error
+	ListDeliveriesFunc     func(ctx context.Context, filter ports.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error)
+
+	// In-memory storage for testing
+	mu            sync.Mutex
+	subscriptions map[uuid.UUID]domain.WebhookSubscription
+	deliveries    map[uuid.UUID]domain.WebhookDelivery
+}
+
+// NewMockWebhookRepository creates a new mock webhook repository
+func NewMockWebhookRepository() *MockWebhookRepository {
+	return &MockWebhookRepository{
+		subscriptions: make(map[uuid.UUID]domain.WebhookSubscription),
+		deliveries:    make(map[uuid.UUID]domain.WebhookDelivery),
+	}
+}
+
+// CreateSubscription stores a new subscription of the caller's tenant
+func (r *MockWebhookRepository) CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	if r.CreateSubscriptionFunc != nil {
+		return r.CreateSubscriptionFunc(ctx, subscription)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	subscription.TenantID = ports.TenantIDFromContext(ctx)
+	r.subscriptions[subscription.ID] = *subscription
+	return nil
+}
+
+// GetSubscription retrieves a subscription of the caller's tenant by ID
+func (r *MockWebhookRepository) GetSubscription(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	if r.GetSubscriptionFunc != nil {
+		return r.GetSubscriptionFunc(ctx, id)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	subscription, ok := r.subscriptions[id]
+	if !ok || subscription.TenantID != ports.TenantIDFromContext(ctx) {
+		return nil, errors.New("webhook subscription not found")
+	}
+	return &subscription, nil
+}
+
+// ListSubscriptions retrieves the subscriptions of the caller's tenant, oldest first
+func (r *MockWebhookRepository) ListSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	if r.ListSubscriptionsFunc != nil {
+		return r.ListSubscriptionsFunc(ctx)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tenantID := ports.TenantIDFromContext(ctx)
+	subscriptions := []*domain.WebhookSubscription{}
+	for _, subscription := range r.subscriptions {
+		if subscription.TenantID == tenantID {
+			subscriptions = append(subscriptions, &subscription)
+		}
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].CreatedAt.Before(subscriptions[j].CreatedAt)
+	})
+	return subscriptions, nil
+}
+
+// DeleteSubscription removes a subscription of the caller's tenant together with its deliveries
+func (r *MockWebhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	if r.DeleteSubscriptionFunc != nil {
+		return r.DeleteSubscriptionFunc(ctx, id)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	subscription, ok := r.subscriptions[id]
+	if !ok || subscription.TenantID != ports.TenantIDFromContext(ctx) {
+		return errors.New("webhook subscription not found for deletion")
+	}
+
+	delete(r.subscriptions, id)
+	for deliveryID, delivery := range r.deliveries {
+		if delivery.SubscriptionID == id {
+			delete(r.deliveries, deliveryID)
+		}
+	}
+	return nil
+}
+
+// EnqueueDelivery stores a new delivery, unless the event has already been enqueued for the subscription
+func (r *MockWebhookRepository) EnqueueDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	if r.EnqueueDeliveryFunc != nil {
+		return r.EnqueueDeliveryFunc(ctx, delivery)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.deliveries {
+		if existing.SubscriptionID == delivery.SubscriptionID && existing.EventID == delivery.EventID {
+			return nil
+		}
+	}
+	r.deliveries[delivery.ID] = *delivery
+	return nil
+}
+
+// FetchDueDeliveries retrieves up to limit pending deliveries of all tenants that are due at now, oldest first
+func (r *MockWebhookRepository) FetchDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	if r.FetchDueDeliveriesFunc != nil {
+		return r.FetchDueDeliveriesFunc(ctx, now, limit)
+	}
+
+	deliveries := []*domain.WebhookDelivery{}
+	for _, delivery := range r.Deliveries() {
+		if delivery.Status == domain.DeliveryPending && !delivery.NextAttemptAt.After(now) {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+// UpdateDelivery stores the outcome of an attempt to deliver
+func (r *MockWebhookRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	if r.UpdateDeliveryFunc != nil {
+		return r.UpdateDeliveryFunc(ctx, delivery)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.deliveries[delivery.ID]; ok {
+		r.deliveries[delivery.ID] = *delivery
+	}
+	return nil
+}
+
+// ListDeliveries retrieves the deliveries of the caller's tenant selected by the filter, newest first
+func (r *MockWebhookRepository) ListDeliveries(ctx context.Context, filter ports.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	if r.ListDeliveriesFunc != nil {
+		return r.ListDeliveriesFunc(ctx, filter)
+	}
+
+	tenantID := ports.TenantIDFromContext(ctx)
+	deliveries := []*domain.WebhookDelivery{}
+	all := r.Deliveries()
+	for i := len(all) - 1; i >= 0; i-- {
+		delivery := all[i]
+		if delivery.TenantID != tenantID ||
+			(filter.SubscriptionID != nil && delivery.SubscriptionID != *filter.SubscriptionID) ||
+			(filter.Status != nil && delivery.Status != *filter.Status) {
+			continue
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if len(deliveries) > filter.Limit {
+		deliveries = deliveries[:filter.Limit]
+	}
+	return deliveries, nil
+}
+
+// Deliveries returns copies of all deliveries ordered by creation time, for test assertions
+func (r *MockWebhookRepository) Deliveries() []*domain.WebhookDelivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveries := make([]*domain.WebhookDelivery, 0, len(r.deliveries))
+	for _, delivery := range r.deliveries {
+		deliveries = append(deliveries, &delivery)
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+	})
+	return deliveries
+}
+
+// Reset clears the subscriptions and deliveries
+func (r *MockWebhookRepository) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subscriptions = make(map[uuid.UUID]domain.WebhookSubscription)
+	r.deliveries = make(map[uuid.UUID]domain.WebhookDelivery)
+}
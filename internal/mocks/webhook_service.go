@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/ports"
+	"github.com/google/uuid"
+)
+
+// MockWebhookService is a mock implementation of the ports.WebhookService interface
+type MockWebhookService struct {
+	CreateWebhookSubscriptionFunc func(ctx context.Context, url string, eventTypes []domain.EventType, secret string) (*domain.WebhookSubscription, error)
+	GetWebhookSubscriptionsFunc   func(ctx context.Context) ([]*domain.WebhookSubscription, error)
+	DeleteWebhookSubscriptionFunc func(ctx context.Context, id uuid.UUID) error
+	GetWebhookDeliveriesFunc      func(ctx context.Context, filter ports.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error)
+}
+
+// NewMockWebhookService creates a new mock webhook service
+func NewMockWebhookService() *MockWebhookService {
+	return &MockWebhookService{}
+}
+
+// CreateWebhookSubscription implements ports.WebhookService
+func (m *MockWebhookService) CreateWebhookSubscription(ctx context.Context, url string, eventTypes []domain.EventType, secret string) (*domain.WebhookSubscription, error) {
+	if m.CreateWebhookSubscriptionFunc != nil {
+		return m.CreateWebhookSubscriptionFunc(ctx, url, eventTypes, secret)
+	}
+	return domain.NewWebhookSubscription(url, eventTypes, secret), nil
+}
+
+// GetWebhookSubscriptions implements ports.WebhookService
+func (m *MockWebhookService) GetWebhookSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	if m.GetWebhookSubscriptionsFunc != nil {
+		return m.GetWebhookSubscriptionsFunc(ctx)
+	}
+	return []*domain.WebhookSubscription{}, nil
+}
+
+// DeleteWebhookSubscription implements ports.WebhookService
+func (m *MockWebhookService) DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error {
+	if m.DeleteWebhookSubscriptionFunc != nil {
+		return m.DeleteWebhookSubscriptionFunc(ctx, id)
+	}
+	return nil
+}
+
+// GetWebhookDeliveries implements ports.WebhookService
+func (m *MockWebhookService) GetWebhookDeliveries(ctx context.Context, filter ports.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	if m.GetWebhookDeliveriesFunc != nil {
+		return m.GetWebhookDeliveriesFunc(ctx, filter)
+	}
+	return []*domain.WebhookDelivery{}, nil
+}
+
+// Ensure MockWebhookService implements ports.WebhookService
+var _ ports.WebhookService = (*MockWebhookService)(nil)
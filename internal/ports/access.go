@@ -0,0 +1,29 @@
+package ports
+
+import "context"
+
+// accessScopeKey is the context key for the AccessScope of a caller
+type accessScopeKey struct{}
+
+// AccessScope limits the families a caller can access.
+// The zero value grants access to every family.
+type AccessScope struct {
+	// Restricted limits access to the family of the parent linked to UserID.
+	// A restricted caller that is not linked to a parent cannot access any family.
+	Restricted bool
+
+	// UserID is the ID of the user account of the caller
+	UserID string
+}
+
+// WithAccessScope returns a copy of ctx that carries the given access scope
+func WithAccessScope(ctx context.Context, scope AccessScope) context.Context {
+	return context.WithValue(ctx, accessScopeKey{}, scope)
+}
+
+// AccessScopeFromContext returns the access scope carried by ctx.
+// A context without an access scope grants access to every family, as needed by internal callers.
+func AccessScopeFromContext(ctx context.Context) AccessScope {
+	scope, _ := ctx.Value(accessScopeKey{}).(AccessScope)
+	return scope
+}
@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/google/uuid"
+)
+
+// AuditLogFilter selects the audit records to list
+type AuditLogFilter struct {
+	// EntityID restricts the records to those of an entity
+	EntityID uuid.UUID
+
+	// From restricts the records to those that occurred at or after the given time, if set
+	From *time.Time
+
+	// To restricts the records to those that occurred at or before the given time, if set
+	To *time.Time
+
+	// Limit is the maximum number of records to list
+	Limit int
+}
+
+// AuditLogRepository defines the interface for the append-only log of changes to parents and children.
+// Records belong to the caller's tenant. They can be appended and listed, but never changed or removed.
+type AuditLogRepository interface {
+	// Append stores a new record of the caller's tenant, within the caller's transaction if there is one
+	Append(ctx context.Context, record *domain.AuditRecord) error
+
+	// List retrieves the records of the caller's tenant selected by the filter, oldest first
+	List(ctx context.Context, filter AuditLogFilter) ([]*domain.AuditRecord, error)
+}
@@ -0,0 +1,55 @@
+package ports
+
+import (
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/google/uuid"
+)
+
+// MaxBatchSize is the largest number of items a batch of changes may hold
+const MaxBatchSize = 100
+
+// ParentInput holds the information of a parent created by CreateParents
+type ParentInput struct {
+	FirstName string
+	LastName  string
+	Email     string
+
+	// BirthDate is the parent's birth date in RFC3339 format
+	BirthDate string
+
+	// Contact holds the parent's phone numbers, postal address and emergency contacts, or nil if none are known
+	Contact *domain.ContactDetails
+}
+
+// ChildInput holds the information of a child created by CreateChildren
+type ChildInput struct {
+	FirstName string
+	LastName  string
+
+	// BirthDate is the child's birth date in RFC3339 format
+	BirthDate string
+
+	// ParentID is the ID of the parent who becomes the child's first guardian
+	ParentID uuid.UUID
+}
+
+// ParentResult is the outcome of one input of CreateParents: the created parent, or the error
+// that kept it from being created
+type ParentResult struct {
+	Parent *domain.Parent
+	Err    error
+}
+
+// ChildResult is the outcome of one input of CreateChildren: the created child, or the error
+// that kept it from being created
+type ChildResult struct {
+	Child *domain.Child
+	Err   error
+}
+
+// DeleteResult is the outcome of one ID of a batch of deletions: the error that kept the
+// entity with the ID from being deleted, if any
+type DeleteResult struct {
+	ID  uuid.UUID
+	Err error
+}
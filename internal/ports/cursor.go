@@ -0,0 +1,47 @@
+package ports
+
+import "slices"
+
+// IsSet reports whether keyset pagination was requested
+func (c CursorOptions) IsSet() bool {
+	return c.First > 0 || c.Last > 0 || c.After != nil || c.Before != nil
+}
+
+// IsBackward reports whether the page is read backwards from the end of the window,
+// which is the case when "last" is given or when only a "before" cursor is present
+func (c CursorOptions) IsBackward() bool {
+	return c.Last > 0 || (c.Before != nil && c.First <= 0)
+}
+
+// Limit returns the number of rows requested, falling back to defaultSize when neither
+// "first" nor "last" is given
+func (c CursorOptions) Limit(defaultSize int) int {
+	if c.IsBackward() && c.Last > 0 {
+		return c.Last
+	}
+	if !c.IsBackward() && c.First > 0 {
+		return c.First
+	}
+	if defaultSize <= 0 {
+		return 10 // Default page size
+	}
+	return defaultSize
+}
+
+// KeysetPage finalizes a keyset page. Adapters fetch limit+1 rows in scan order
+// (reversed when reading backwards) so that the extra row tells whether more rows exist.
+// KeysetPage drops that look-ahead row, restores the requested order and reports
+// whether there are rows after and before the returned page.
+func KeysetPage[T any](c CursorOptions, items []T, limit int) ([]T, bool, bool) {
+	more := len(items) > limit
+	if more {
+		items = items[:limit]
+	}
+
+	if !c.IsBackward() {
+		return items, more, c.After != nil
+	}
+
+	slices.Reverse(items)
+	return items, c.Before != nil, more
+}
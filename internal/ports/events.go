@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+)
+
+// EventPublisher defines the interface for publishing domain events
+type EventPublisher interface {
+	// Publish delivers an event to all current subscribers
+	Publish(ctx context.Context, event domain.Event) error
+}
+
+// EventSubscriber defines the interface for receiving domain events
+type EventSubscriber interface {
+	// Subscribe returns a channel of events that is closed when ctx is done
+	Subscribe(ctx context.Context) (<-chan domain.Event, error)
+}
+
+// EventBroker defines the interface for a publish/subscribe broker of domain events
+type EventBroker interface {
+	EventPublisher
+	EventSubscriber
+
+	// Close releases the broker's resources and closes all subscriptions
+	Close() error
+}
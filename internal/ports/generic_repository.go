@@ -7,6 +7,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
 	"github.com/google/uuid"
@@ -20,12 +21,22 @@ type Repository[T domain.Entity] interface {
 	// GetByID retrieves an entity by ID
 	GetByID(ctx context.Context, id uuid.UUID) (T, error)
 
+	// GetByIDs retrieves the entities with the given IDs in a single query
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]T, error)
+
 	// Update updates an existing entity
 	Update(ctx context.Context, entity T) error
 
 	// Delete marks an entity as deleted
 	Delete(ctx context.Context, id uuid.UUID) error
 
+	// Restore unmarks an entity that was marked as deleted
+	Restore(ctx context.Context, id uuid.UUID) error
+
+	// Purge permanently removes the entities that were marked as deleted before the given time,
+	// and returns how many were removed
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+
 	// List retrieves a list of entities with pagination, filtering, and sorting
 	List(ctx context.Context, options QueryOptions) ([]T, *PagedResult, error)
 
@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/google/uuid"
+)
+
+// GuardianshipRepository defines the interface for storing the guardianships that relate parents
+// and guardians to children. Guardianships belong to the caller's tenant; they are removed when
+// their parent or child is purged.
+type GuardianshipRepository interface {
+	// Create stores a new guardianship of the caller's tenant
+	Create(ctx context.Context, guardianship *domain.Guardianship) error
+
+	// Update stores the type, end date and primary contact mark of a guardianship.
+	// It returns an error wrapping domain.ErrNotFound when no guardianship has the given ID.
+	Update(ctx context.Context, guardianship *domain.Guardianship) error
+
+	// ListByChildIDs retrieves the guardianships of the given children in a single query,
+	// including those that ended, ordered by child and start date
+	ListByChildIDs(ctx context.Context, childIDs []uuid.UUID) ([]*domain.Guardianship, error)
+
+	// ListByParentIDs retrieves the guardianships of the given parents in a single query,
+	// including those that ended, ordered by parent and start date
+	ListByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Guardianship, error)
+}
@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/google/uuid"
+)
+
+// HistoryRepository defines the interface for reading the versioned history of parents and children.
+// A revision is recorded by every write of a parent or child, so the history cannot be written directly.
+// Revisions belong to the caller's tenant, and are removed when their entity is purged.
+type HistoryRepository interface {
+	// GetParentAsOf retrieves a parent as it was at the given time.
+	// It returns an error wrapping domain.ErrNotFound if the parent did not exist at that time,
+	// or was marked as deleted.
+	GetParentAsOf(ctx context.Context, id uuid.UUID, asOf time.Time) (*domain.Parent, error)
+
+	// ListChildrenAsOf retrieves the children a parent had at the given time, leaving out
+	// the children that were marked as deleted at that time, ordered by creation time
+	ListChildrenAsOf(ctx context.Context, parentID uuid.UUID, asOf time.Time) ([]*domain.Child, error)
+
+	// ListParentRevisions retrieves the revisions of a parent, oldest first
+	ListParentRevisions(ctx context.Context, id uuid.UUID) ([]*domain.Revision, error)
+
+	// ListChildRevisions retrieves the revisions of a child, oldest first
+	ListChildRevisions(ctx context.Context, id uuid.UUID) ([]*domain.Revision, error)
+}
@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/google/uuid"
+)
+
+// HouseholdRepository defines the interface for storing the households that group parents and
+// children with their shared address. Households belong to the caller's tenant, and a child is
+// a member of at most one household; storing a second household of a child fails.
+type HouseholdRepository interface {
+	// Create stores a new household of the caller's tenant, with its members
+	Create(ctx context.Context, household *domain.Household) error
+
+	// GetByID retrieves a household by its ID.
+	// It returns an error wrapping domain.ErrNotFound when no household has the given ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Household, error)
+
+	// GetByChildID retrieves the household of a child.
+	// It returns an error wrapping domain.ErrNotFound when the child is not a member of a household.
+	GetByChildID(ctx context.Context, childID uuid.UUID) (*domain.Household, error)
+
+	// Update stores the name, address, phone numbers and members of a household.
+	// It returns an error wrapping domain.ErrNotFound when no household has the given ID.
+	Update(ctx context.Context, household *domain.Household) error
+}
@@ -0,0 +1,50 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+)
+
+// OutboxMessage is a domain event recorded in the outbox that has not been delivered yet
+type OutboxMessage struct {
+	// Sequence orders the messages in the order in which they were recorded
+	Sequence int64
+
+	Event domain.Event
+
+	// Attempts is the number of failed attempts to deliver the message
+	Attempts int
+
+	// NextAttemptAt is the earliest time at which delivery may be attempted again
+	NextAttemptAt time.Time
+
+	// LastError describes why the last attempt to deliver the message failed
+	LastError string
+}
+
+// OutboxRepository defines the interface for the transactional outbox of domain events.
+// Events are appended in the same transaction as the change they describe, so that an event
+// is recorded if and only if the change is committed. A relay later delivers them.
+type OutboxRepository interface {
+	// Append records an event within the transaction carried by ctx
+	Append(ctx context.Context, event domain.Event) error
+
+	// LeasePending leases up to limit undelivered messages of all tenants to the caller until leaseUntil,
+	// ordered by sequence. Only the families whose earliest message is due at now and not leased are leased,
+	// with their later messages, so that the messages of a family are relayed by one relay at a time and in
+	// order, and a family whose earliest message waits for its next attempt does not hold back the others.
+	// Messages that cannot be decoded are quarantined instead of returned.
+	LeasePending(ctx context.Context, limit int, now, leaseUntil time.Time) ([]OutboxMessage, error)
+
+	// Acknowledge removes a delivered message from the outbox
+	Acknowledge(ctx context.Context, sequence int64) error
+
+	// MarkFailed records a failed attempt to deliver a message and when to attempt it again, and releases its lease
+	MarkFailed(ctx context.Context, sequence int64, nextAttemptAt time.Time, lastError string) error
+
+	// Quarantine moves a message that cannot be delivered from the outbox to its dead letters, with the reason,
+	// so that the later messages of its family are delivered
+	Quarantine(ctx context.Context, sequence int64, reason string) error
+}
@@ -7,6 +7,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
 	"github.com/google/uuid"
@@ -19,6 +20,21 @@ type FilterOptions struct {
 	Email     string
 	MinAge    int
 	MaxAge    int
+
+	// PostalCode and Phone restrict the result to the parents whose address has the postal code,
+	// or who can be reached at the phone number in E.164 format
+	PostalCode string
+	Phone      string
+
+	// ParentIDs, when not empty, restricts the result to the given parents, or to the children whose
+	// primary contact is one of them
+	ParentIDs []uuid.UUID
+
+	// ChildIDs, when not empty, restricts a list of children to the given children
+	ChildIDs []uuid.UUID
+
+	// Deleted restricts the result to the entities that are marked as deleted, instead of excluding them
+	Deleted bool
 }
 
 // PaginationOptions represents options for paginating list queries
@@ -33,35 +49,87 @@ type SortOptions struct {
 	Direction string // "asc" or "desc"
 }
 
+// Cursor identifies a position in a sorted list by the sort key and ID of a row.
+// The ID breaks ties between rows that share the same sort key value.
+type Cursor struct {
+	SortField string
+	SortValue interface{} // string or time.Time, depending on SortField
+	ID        uuid.UUID
+}
+
+// CursorOptions represents options for keyset (cursor) pagination.
+// When set, they take precedence over PaginationOptions.
+type CursorOptions struct {
+	First  int
+	After  *Cursor
+	Last   int
+	Before *Cursor
+}
+
 // QueryOptions combines all query options
 type QueryOptions struct {
 	Filter     FilterOptions
 	Pagination PaginationOptions
 	Sort       SortOptions
+	Cursor     CursorOptions
 }
 
 // PagedResult represents a paginated result
 type PagedResult struct {
-	TotalCount int64
-	Page       int
-	PageSize   int
-	HasNext    bool
+	TotalCount  int64
+	Page        int
+	PageSize    int
+	HasNext     bool
+	HasPrevious bool
+}
+
+// PurgeResult reports how many records marked as deleted were permanently removed
+type PurgeResult struct {
+	Parents  int64
+	Children int64
 }
 
 // ParentRepository defines the interface for parent data access
 type ParentRepository interface {
-	// Create creates a new parent
+	// Create creates a new parent. The email of a parent is unique among the non-deleted parents of
+	// a tenant, regardless of case; Create returns a domain.DuplicateError when the email is taken.
 	Create(ctx context.Context, parent *domain.Parent) error
 
+	// CreateMany creates many parents in as few round trips to the database as possible, joining the
+	// transaction of the context. When one of the parents cannot be created, such as because its email is
+	// taken, CreateMany returns a domain.BatchError holding the position and the error of that parent.
+	CreateMany(ctx context.Context, parents []*domain.Parent) error
+
 	// GetByID retrieves a parent by ID
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Parent, error)
 
-	// Update updates an existing parent
+	// GetByIDs retrieves the parents with the given IDs in a single query.
+	// IDs that do not match a parent are skipped; the order of the result is unspecified.
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Parent, error)
+
+	// GetByUserID retrieves the parent linked to the given user account.
+	// It returns an error wrapping domain.ErrNotFound when no parent is linked to the user.
+	GetByUserID(ctx context.Context, userID string) (*domain.Parent, error)
+
+	// FindTakenEmails returns those of the given emails that non-deleted parents already have, ignoring case,
+	// as the parents have them
+	FindTakenEmails(ctx context.Context, emails []string) ([]string, error)
+
+	// Update updates an existing parent. It returns a domain.DuplicateError when the new email is taken.
 	Update(ctx context.Context, parent *domain.Parent) error
 
 	// Delete marks a parent as deleted
 	Delete(ctx context.Context, id uuid.UUID) error
 
+	// Restore unmarks a parent that was marked as deleted, together with the children that were
+	// marked as deleted with it. It returns an error wrapping domain.ErrNotFound when no deleted
+	// parent has the given ID, and a domain.DuplicateError when another parent has taken its email.
+	Restore(ctx context.Context, id uuid.UUID) error
+
+	// Purge permanently removes the parents that were marked as deleted before the given time,
+	// and returns how many were removed. Parents that still have children are kept.
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+
 	// List retrieves a list of parents with pagination, filtering, and sorting
 	List(ctx context.Context, options QueryOptions) ([]*domain.Parent, *PagedResult, error)
 
@@ -74,6 +142,11 @@ type ChildRepository interface {
 	// Create creates a new child
 	Create(ctx context.Context, child *domain.Child) error
 
+	// CreateMany creates many children in as few round trips to the database as possible, joining the
+	// transaction of the context. When one of the children cannot be created, such as because its parent
+	// does not exist, CreateMany returns a domain.BatchError holding the position and the error of that child.
+	CreateMany(ctx context.Context, children []*domain.Child) error
+
 	// GetByID retrieves a child by ID
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Child, error)
 
@@ -83,9 +156,21 @@ type ChildRepository interface {
 	// Delete marks a child as deleted
 	Delete(ctx context.Context, id uuid.UUID) error
 
+	// Restore unmarks a child that was marked as deleted.
+	// It returns an error wrapping domain.ErrNotFound when no deleted child has the given ID.
+	Restore(ctx context.Context, id uuid.UUID) error
+
+	// Purge permanently removes the children that were marked as deleted before the given time,
+	// and returns how many were removed
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+
 	// ListByParentID retrieves children for a specific parent with pagination, filtering, and sorting
 	ListByParentID(ctx context.Context, parentID uuid.UUID, options QueryOptions) ([]*domain.Child, *PagedResult, error)
 
+	// ListByParentIDs retrieves all children of the given parents in a single query,
+	// ordered by parent ID and creation time
+	ListByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Child, error)
+
 	// List retrieves a list of children with pagination, filtering, and sorting
 	List(ctx context.Context, options QueryOptions) ([]*domain.Child, *PagedResult, error)
 
@@ -113,6 +198,24 @@ type RepositoryFactory interface {
 	// NewChildRepository creates a new child repository
 	NewChildRepository() ChildRepository
 
+	// NewOutboxRepository creates a new outbox repository that shares the transactions of the other repositories
+	NewOutboxRepository() OutboxRepository
+
+	// NewWebhookRepository creates a new repository of webhook subscriptions and deliveries
+	NewWebhookRepository() WebhookRepository
+
+	// NewAuditLogRepository creates a new audit log repository that shares the transactions of the other repositories
+	NewAuditLogRepository() AuditLogRepository
+
+	// NewHistoryRepository creates a new repository of the revisions of parents and children
+	NewHistoryRepository() HistoryRepository
+
+	// NewGuardianshipRepository creates a new repository of the guardianships between parents and children
+	NewGuardianshipRepository() GuardianshipRepository
+
+	// NewHouseholdRepository creates a new repository of the households that group parents and children
+	NewHouseholdRepository() HouseholdRepository
+
 	// GetTransactionManager returns the transaction manager
 	GetTransactionManager() TransactionManager
 }
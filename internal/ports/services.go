@@ -7,6 +7,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
 	"github.com/google/uuid"
@@ -24,11 +25,37 @@ type ParentService interface {
 	//   - lastName: The parent's last name
 	//   - email: The parent's email address
 	//   - birthDate: The parent's birth date as a string in RFC3339 format
+	//   - contact: The parent's phone numbers, postal address and emergency contacts, or nil if none are known
 	//
 	// Returns:
 	//   - *domain.Parent: The newly created parent entity if successful
 	//   - error: An error if validation fails or if there's a database error
-	CreateParent(ctx context.Context, firstName, lastName, email string, birthDate string) (*domain.Parent, error)
+	CreateParent(ctx context.Context, firstName, lastName, email string, birthDate string, contact *domain.ContactDetails) (*domain.Parent, error)
+
+	// CreateParents creates many parents at once. Every input gets a result at its position, holding the
+	// created parent or the error that kept it from being created. In atomic mode, the parents are created
+	// in a single transaction, so that either all or none of them are; when one of them fails, the others
+	// fail with an error wrapping domain.ErrAborted. Otherwise, every parent is created on its own.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - inputs: The information of the parents to create, at most MaxBatchSize of them
+	//   - atomic: Whether to create all of the parents or none of them
+	//
+	// Returns:
+	//   - []ParentResult: The results of the inputs, in the same order
+	//   - error: A validation error if there are too many inputs, or an error if the transaction fails
+	CreateParents(ctx context.Context, inputs []ParentInput, atomic bool) ([]ParentResult, error)
+
+	// ValidateParents checks whether parents could be created, without creating them. An input is invalid
+	// when CreateParent would reject it, when its email is taken, or when an earlier input has the same email.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - inputs: The information of the parents to check, at most MaxBatchSize of them
+	//
+	// Returns:
+	//   - []error: The error of every input at its position, or nil for the valid ones
+	//   - error: A validation error if there are too many inputs, or a database error
+	ValidateParents(ctx context.Context, inputs []ParentInput) ([]error, error)
 
 	// GetParentByID retrieves a parent by their unique identifier.
 	// Parameters:
@@ -40,6 +67,40 @@ type ParentService interface {
 	//   - error: An error if the parent doesn't exist or if there's a database error
 	GetParentByID(ctx context.Context, id uuid.UUID) (*domain.Parent, error)
 
+	// GetParentsByIDs retrieves the parents with the given identifiers in a single batch.
+	// Identifiers that do not match a parent are skipped rather than reported as errors.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - ids: The unique identifiers of the parents to retrieve
+	//
+	// Returns:
+	//   - []*domain.Parent: The parents that were found, in no particular order
+	//   - error: An error if there's a database error
+	GetParentsByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Parent, error)
+
+	// GetParentByUserID retrieves the parent linked to a user account.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - userID: The ID of the user account
+	//
+	// Returns:
+	//   - *domain.Parent: The parent linked to the user
+	//   - error: An error if no parent is linked to the user or if there's a database error
+	GetParentByUserID(ctx context.Context, userID string) (*domain.Parent, error)
+
+	// LinkParentUser links a parent to a user account, so that the user can access the parent's family.
+	// A user account can be linked to at most one parent; an empty user ID removes the link.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - id: The unique identifier of the parent
+	//   - userID: The ID of the user account
+	//
+	// Returns:
+	//   - *domain.Parent: The updated parent entity if successful
+	//   - error: An error if the parent doesn't exist, the user is linked to another parent,
+	//     or if there's a database error
+	LinkParentUser(ctx context.Context, id uuid.UUID, userID string) (*domain.Parent, error)
+
 	// UpdateParent updates an existing parent with the provided information.
 	// Parameters:
 	//   - ctx: The context for the operation, used for tracing and cancellation
@@ -48,11 +109,14 @@ type ParentService interface {
 	//   - lastName: The new last name
 	//   - email: The new email address
 	//   - birthDate: The new birth date as a string in RFC3339 format
+	//   - contact: The new phone numbers, postal address and emergency contacts, or nil to keep the current ones
+	//   - expectedVersion: The version the caller last read, or nil to update whatever version is current
 	//
 	// Returns:
 	//   - *domain.Parent: The updated parent entity if successful
-	//   - error: An error if the parent doesn't exist, validation fails, or if there's a database error
-	UpdateParent(ctx context.Context, id uuid.UUID, firstName, lastName, email string, birthDate string) (*domain.Parent, error)
+	//   - error: An error if the parent doesn't exist, validation fails, or if there's a database error,
+	//     or a ConflictError if the parent is no longer at the expected version
+	UpdateParent(ctx context.Context, id uuid.UUID, firstName, lastName, email string, birthDate string, contact *domain.ContactDetails, expectedVersion *int) (*domain.Parent, error)
 
 	// DeleteParent marks a parent as deleted.
 	// This is typically a soft delete operation that maintains the record but marks it as deleted.
@@ -64,6 +128,16 @@ type ParentService interface {
 	//   - error: An error if the parent doesn't exist or if there's a database error
 	DeleteParent(ctx context.Context, id uuid.UUID) error
 
+	// RestoreParent unmarks a deleted parent, together with the children that were deleted with it.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - id: The unique identifier of the deleted parent to restore
+	//
+	// Returns:
+	//   - *domain.Parent: The restored parent entity if successful
+	//   - error: An error if no deleted parent has the ID or if there's a database error
+	RestoreParent(ctx context.Context, id uuid.UUID) (*domain.Parent, error)
+
 	// ListParents retrieves a list of parents with pagination, filtering, and sorting.
 	// Parameters:
 	//   - ctx: The context for the operation, used for tracing and cancellation
@@ -84,6 +158,20 @@ type ParentService interface {
 	//   - int64: The number of parents matching the filter criteria
 	//   - error: An error if there's a database error or if the filter options are invalid
 	CountParents(ctx context.Context, filter FilterOptions) (int64, error)
+
+	// FindDuplicateParents lists the parents that may be the same person recorded twice, by the similarity
+	// of their normalized names, email addresses and birth dates, most similar first.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - parentID: The unique identifier of the parent whose duplicates to find, or nil to find every pair
+	//   - minScore: The score from which two parents are possible duplicates, between 0 and 1, or 0 for the default
+	//   - limit: How many possible duplicates to list, or 0 for the default
+	//
+	// Returns:
+	//   - []*domain.ParentDuplicate: The possible duplicates
+	//   - error: A validation error if the minimum score or limit is out of range, an error if the parent
+	//     doesn't exist, or if there's a database error
+	FindDuplicateParents(ctx context.Context, parentID *uuid.UUID, minScore float64, limit int) ([]*domain.ParentDuplicate, error)
 }
 
 // ChildService defines the interface for child business operations.
@@ -104,6 +192,31 @@ type ChildService interface {
 	//   - error: An error if validation fails, if the parent doesn't exist, or if there's a database error
 	CreateChild(ctx context.Context, firstName, lastName string, birthDate string, parentID uuid.UUID) (*domain.Child, error)
 
+	// CreateChildren creates many children at once, each with its parent as its first guardian. Every input
+	// gets a result at its position, holding the created child or the error that kept it from being created.
+	// In atomic mode, the children are created in a single transaction, so that either all or none of them are;
+	// when one of them fails, the others fail with an error wrapping domain.ErrAborted.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - inputs: The information of the children to create, at most MaxBatchSize of them
+	//   - atomic: Whether to create all of the children or none of them
+	//
+	// Returns:
+	//   - []ChildResult: The results of the inputs, in the same order
+	//   - error: A validation error if there are too many inputs, or an error if the transaction fails
+	CreateChildren(ctx context.Context, inputs []ChildInput, atomic bool) ([]ChildResult, error)
+
+	// ValidateChildren checks whether children could be created, without creating them. An input is invalid
+	// when CreateChild would reject it, such as when its parent does not exist.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - inputs: The information of the children to check, at most MaxBatchSize of them
+	//
+	// Returns:
+	//   - []error: The error of every input at its position, or nil for the valid ones
+	//   - error: A validation error if there are too many inputs, or a database error
+	ValidateChildren(ctx context.Context, inputs []ChildInput) ([]error, error)
+
 	// GetChildByID retrieves a child by their unique identifier.
 	// Parameters:
 	//   - ctx: The context for the operation, used for tracing and cancellation
@@ -121,11 +234,13 @@ type ChildService interface {
 	//   - firstName: The new first name
 	//   - lastName: The new last name
 	//   - birthDate: The new birth date as a string in RFC3339 format
+	//   - expectedVersion: The version the caller last read, or nil to update whatever version is current
 	//
 	// Returns:
 	//   - *domain.Child: The updated child entity if successful
-	//   - error: An error if the child doesn't exist, validation fails, or if there's a database error
-	UpdateChild(ctx context.Context, id uuid.UUID, firstName, lastName string, birthDate string) (*domain.Child, error)
+	//   - error: An error if the child doesn't exist, validation fails, or if there's a database error,
+	//     or a ConflictError if the child is no longer at the expected version
+	UpdateChild(ctx context.Context, id uuid.UUID, firstName, lastName string, birthDate string, expectedVersion *int) (*domain.Child, error)
 
 	// DeleteChild marks a child as deleted.
 	// This is typically a soft delete operation that maintains the record but marks it as deleted.
@@ -137,6 +252,30 @@ type ChildService interface {
 	//   - error: An error if the child doesn't exist or if there's a database error
 	DeleteChild(ctx context.Context, id uuid.UUID) error
 
+	// DeleteChildren marks many children as deleted at once. Every ID gets a result at its position, holding
+	// the error that kept the child from being deleted, if any. In atomic mode, the children are deleted
+	// in a single transaction, so that either all or none of them are; when one of them fails, the others
+	// fail with an error wrapping domain.ErrAborted.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - ids: The unique identifiers of the children to delete, at most MaxBatchSize of them
+	//   - atomic: Whether to delete all of the children or none of them
+	//
+	// Returns:
+	//   - []DeleteResult: The results of the IDs, in the same order
+	//   - error: A validation error if there are too many IDs, or an error if the transaction fails
+	DeleteChildren(ctx context.Context, ids []uuid.UUID, atomic bool) ([]DeleteResult, error)
+
+	// RestoreChild unmarks a deleted child and adds it back to its parent's children.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - id: The unique identifier of the deleted child to restore
+	//
+	// Returns:
+	//   - *domain.Child: The restored child entity if successful
+	//   - error: An error if no deleted child has the ID, if its parent is deleted, or if there's a database error
+	RestoreChild(ctx context.Context, id uuid.UUID) (*domain.Child, error)
+
 	// ListChildrenByParentID retrieves children for a specific parent with pagination, filtering, and sorting.
 	// Parameters:
 	//   - ctx: The context for the operation, used for tracing and cancellation
@@ -149,6 +288,16 @@ type ChildService interface {
 	//   - error: An error if there's a database error or if the query options are invalid
 	ListChildrenByParentID(ctx context.Context, parentID uuid.UUID, options QueryOptions) ([]*domain.Child, *PagedResult, error)
 
+	// ListChildrenByParentIDs retrieves all children of the given parents in a single batch.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - parentIDs: The unique identifiers of the parents whose children to retrieve
+	//
+	// Returns:
+	//   - []*domain.Child: The children of all the given parents, ordered by parent and creation time
+	//   - error: An error if there's a database error
+	ListChildrenByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Child, error)
+
 	// ListChildren retrieves a list of all children with pagination, filtering, and sorting.
 	// Parameters:
 	//   - ctx: The context for the operation, used for tracing and cancellation
@@ -179,26 +328,236 @@ type FamilyService interface {
 	ParentService
 	ChildService
 
-	// AddChildToParent adds a child to a parent by establishing a parent-child relationship.
+	// AddChildToParent makes a parent a guardian of a child, or changes the type and primary contact
+	// mark of the parent's active guardianship of the child. The parent becomes the primary contact,
+	// and the child's ParentID, when asked to or when the child has no other active guardian.
 	// Parameters:
 	//   - ctx: The context for the operation, used for tracing and cancellation
-	//   - parentID: The unique identifier of the parent
-	//   - childID: The unique identifier of the child to add to the parent
+	//   - parentID: The unique identifier of the parent or guardian
+	//   - childID: The unique identifier of the child
+	//   - guardianshipType: How the parent is related to the child
+	//   - primaryContact: Whether the parent becomes the primary contact of the child
+	//   - startDate: The date the guardianship started, or nil for now
 	//
 	// Returns:
-	//   - error: An error if either the parent or child doesn't exist, or if there's a database error
-	AddChildToParent(ctx context.Context, parentID, childID uuid.UUID) error
+	//   - error: A validation error if the type is unknown, an error if either the parent or child
+	//     doesn't exist, or if there's a database error
+	AddChildToParent(ctx context.Context, parentID, childID uuid.UUID, guardianshipType domain.GuardianshipType, primaryContact bool, startDate *time.Time) error
 
-	// RemoveChildFromParent removes a child from a parent by breaking the parent-child relationship.
+	// RemoveChildFromParent ends the active guardianship of a parent over a child. When the parent was
+	// the primary contact, the child's longest-standing other guardian becomes the primary contact.
 	// Parameters:
 	//   - ctx: The context for the operation, used for tracing and cancellation
 	//   - parentID: The unique identifier of the parent
 	//   - childID: The unique identifier of the child to remove from the parent
 	//
 	// Returns:
-	//   - error: An error if either the parent or child doesn't exist, if the child is not
-	//     associated with the parent, or if there's a database error
+	//   - error: An error if either the parent or child doesn't exist, if the parent is not an active
+	//     guardian of the child, a validation error if the parent is the child's only guardian,
+	//     or if there's a database error
 	RemoveChildFromParent(ctx context.Context, parentID, childID uuid.UUID) error
+
+	// TransferChild moves a child from one parent to another in a single transaction. The guardianship of the
+	// parent the child leaves ends, and the parent the child joins becomes a guardian of the same type and,
+	// when the parent it replaces was the primary contact, the child's primary contact.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - childID: The unique identifier of the child to transfer
+	//   - fromParentID: The unique identifier of the parent the child leaves
+	//   - toParentID: The unique identifier of the parent the child joins
+	//   - reason: Why the child is transferred, recorded with the event and the audit record
+	//
+	// Returns:
+	//   - *domain.Child: The transferred child
+	//   - error: A validation error if the parents are the same, the reason is missing or the parent the child
+	//     leaves is not an active guardian of the child, an error if the child or a parent doesn't exist,
+	//     or if there's a database error
+	TransferChild(ctx context.Context, childID, fromParentID, toParentID uuid.UUID, reason string) (*domain.Child, error)
+
+	// ListGuardianshipsByChildIDs retrieves the guardianships of the given children in a single query.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - childIDs: The unique identifiers of the children
+	//
+	// Returns:
+	//   - []*domain.Guardianship: The guardianships, including those that ended, ordered by child and start date
+	//   - error: An error if there's a database error
+	ListGuardianshipsByChildIDs(ctx context.Context, childIDs []uuid.UUID) ([]*domain.Guardianship, error)
+
+	// ListGuardianshipsByParentIDs retrieves the guardianships of the given parents in a single query.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - parentIDs: The unique identifiers of the parents
+	//
+	// Returns:
+	//   - []*domain.Guardianship: The guardianships, including those that ended, ordered by parent and start date
+	//   - error: An error if there's a database error
+	ListGuardianshipsByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]*domain.Guardianship, error)
+
+	// IsEventInFamily reports whether an event concerns the family the caller may access: the caller's own
+	// parent, or a child the caller's parent is an active guardian of. Callers who may access every family
+	// may see every event.
+	// Parameters:
+	//   - ctx: The context for the operation, carrying the caller's access scope
+	//   - event: The event to check
+	//
+	// Returns:
+	//   - bool: Whether the caller may see the event
+	//   - error: An error if there's a database error
+	IsEventInFamily(ctx context.Context, event domain.Event) (bool, error)
+
+	// MergeParents merges a parent recorded twice into the parent that is kept. The guardianships and children
+	// of the duplicate move to the survivor, and the duplicate records the survivor and is marked as deleted.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - survivorID: The unique identifier of the parent that is kept
+	//   - duplicateID: The unique identifier of the parent that is merged into the survivor
+	//
+	// Returns:
+	//   - *domain.Parent: The survivor, with the children it took over
+	//   - error: A validation error if both IDs are the same, an error if either parent doesn't exist,
+	//     or if there's a database error
+	MergeParents(ctx context.Context, survivorID, duplicateID uuid.UUID) (*domain.Parent, error)
+
+	// CreateHousehold creates a household of parents and children who share an address.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - name: The name of the household
+	//   - address: The address the members share
+	//   - phoneNumbers: The phone numbers of the household
+	//   - parentIDs: The unique identifiers of the parents who live in the household
+	//   - childIDs: The unique identifiers of the children who live in the household
+	//
+	// Returns:
+	//   - *domain.Household: The newly created household
+	//   - error: A validation error if the input is invalid or a child already belongs to a household,
+	//     an error if a member doesn't exist, or if there's a database error
+	CreateHousehold(ctx context.Context, name string, address domain.Address, phoneNumbers []string, parentIDs, childIDs []uuid.UUID) (*domain.Household, error)
+
+	// GetHouseholdByID retrieves a household by its unique identifier.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - id: The unique identifier of the household
+	//
+	// Returns:
+	//   - *domain.Household: The household
+	//   - error: An error if the household doesn't exist, or if there's a database error
+	GetHouseholdByID(ctx context.Context, id uuid.UUID) (*domain.Household, error)
+
+	// MoveChildToHousehold makes a child a member of a household, removing it from the household
+	// it belonged to, since a child belongs to at most one household.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - childID: The unique identifier of the child
+	//   - householdID: The unique identifier of the household the child moves to
+	//
+	// Returns:
+	//   - *domain.Household: The household the child moved to
+	//   - error: An error if either the child or household doesn't exist, or if there's a database error
+	MoveChildToHousehold(ctx context.Context, childID, householdID uuid.UUID) (*domain.Household, error)
+
+	// PurgeDeleted permanently removes the parents and children that were deleted longer ago than the retention period.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//
+	// Returns:
+	//   - *PurgeResult: The numbers of parents and children removed
+	//   - error: An error if there's a database error
+	PurgeDeleted(ctx context.Context) (*PurgeResult, error)
+
+	// GetParentAsOf retrieves a parent as it was at a point in time.
+	// The parent's AsOf is set, and its children at that time are listed by ListChildrenByParentIDAsOf.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - id: The unique identifier of the parent to retrieve
+	//   - asOf: The point in time
+	//
+	// Returns:
+	//   - *domain.Parent: The parent as it was at that time
+	//   - error: An error if the parent did not exist or was deleted at that time, or if there's a database error
+	GetParentAsOf(ctx context.Context, id uuid.UUID, asOf time.Time) (*domain.Parent, error)
+
+	// ListChildrenByParentIDAsOf retrieves the children a parent had at a point in time.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - parentID: The unique identifier of the parent whose children to retrieve
+	//   - asOf: The point in time
+	//
+	// Returns:
+	//   - []*domain.Child: The children as they were at that time, ordered by creation time
+	//   - error: An error if there's a database error
+	ListChildrenByParentIDAsOf(ctx context.Context, parentID uuid.UUID, asOf time.Time) ([]*domain.Child, error)
+
+	// GetHistory retrieves every recorded version of a parent or child.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - id: The unique identifier of the parent or child
+	//
+	// Returns:
+	//   - []*domain.Revision: The revisions, oldest first
+	//   - error: An error if no parent or child has a history with the ID, or if there's a database error
+	GetHistory(ctx context.Context, id uuid.UUID) ([]*domain.Revision, error)
+}
+
+// WebhookService defines the interface for managing the webhook subscriptions of the caller's tenant
+// and inspecting the deliveries made to them.
+// This interface is implemented by the application layer and used by adapters like GraphQL resolvers.
+type WebhookService interface {
+	// CreateWebhookSubscription subscribes a URL to the events of the given types.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - url: The https URL that receives the callbacks, which must not be local, see domain.ValidateWebhookURL
+	//   - eventTypes: The types of the events delivered to the URL
+	//   - secret: The secret the payloads are signed with
+	//
+	// Returns:
+	//   - *domain.WebhookSubscription: The newly created subscription if successful
+	//   - error: An error if validation fails or if there's a database error
+	CreateWebhookSubscription(ctx context.Context, url string, eventTypes []domain.EventType, secret string) (*domain.WebhookSubscription, error)
+
+	// GetWebhookSubscriptions retrieves the webhook subscriptions of the caller's tenant.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//
+	// Returns:
+	//   - []*domain.WebhookSubscription: The subscriptions, oldest first
+	//   - error: An error if there's a database error
+	GetWebhookSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error)
+
+	// DeleteWebhookSubscription removes a webhook subscription and its deliveries.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - id: The unique identifier of the subscription to remove
+	//
+	// Returns:
+	//   - error: An error if the subscription doesn't exist or if there's a database error
+	DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error
+
+	// GetWebhookDeliveries retrieves the webhook deliveries of the caller's tenant, for debugging receivers.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - filter: Selects the deliveries by subscription and status, and limits their number
+	//
+	// Returns:
+	//   - []*domain.WebhookDelivery: The deliveries, newest first
+	//   - error: An error if there's a database error
+	GetWebhookDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error)
+}
+
+// AuditService defines the interface for reading the audit log of changes to parents and children.
+// This interface is implemented by the application layer and used by adapters like GraphQL resolvers.
+type AuditService interface {
+	// GetAuditLog retrieves the audit records of an entity of the caller's tenant.
+	// Parameters:
+	//   - ctx: The context for the operation, used for tracing and cancellation
+	//   - entityID: The unique identifier of the parent or child
+	//   - from: The earliest time of the records to retrieve, or nil for no lower bound
+	//   - to: The latest time of the records to retrieve, or nil for no upper bound
+	//
+	// Returns:
+	//   - []*domain.AuditRecord: The records, oldest first
+	//   - error: An error if the time range is invalid or if there's a database error
+	GetAuditLog(ctx context.Context, entityID uuid.UUID, from, to *time.Time) ([]*domain.AuditRecord, error)
 }
 
 // AuthorizationService defines the interface for authorization operations.
@@ -242,4 +601,13 @@ type AuthorizationService interface {
 	//   - []string: A slice of role names assigned to the authenticated user
 	//   - error: An error if the user roles cannot be retrieved or if the user is not authenticated
 	GetUserRoles(ctx context.Context) ([]string, error)
+
+	// GetPermissions retrieves the operations the user is authorized to perform.
+	// Parameters:
+	//   - ctx: The context containing user authentication information
+	//
+	// Returns:
+	//   - []string: The sorted names of the operations the user is authorized to perform
+	//   - error: An error if the permissions cannot be determined due to technical reasons
+	GetPermissions(ctx context.Context) ([]string, error)
 }
@@ -0,0 +1,20 @@
+package ports
+
+import "context"
+
+// tenantIDKey is the context key for the tenant of a caller
+type tenantIDKey struct{}
+
+// WithTenantID returns a copy of ctx that carries the given tenant ID.
+// Repositories only read and write the data of the tenant carried by the context.
+func WithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantIDKey{}, tenantID)
+}
+
+// TenantIDFromContext returns the tenant ID carried by ctx.
+// A context without a tenant ID belongs to the default tenant, whose ID is empty,
+// so that single-tenant deployments need no tenant claim.
+func TenantIDFromContext(ctx context.Context) string {
+	tenantID, _ := ctx.Value(tenantIDKey{}).(string)
+	return tenantID
+}
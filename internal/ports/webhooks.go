@@ -0,0 +1,59 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/abitofhelp/family_service_hexarch_graphql/internal/domain"
+	"github.com/google/uuid"
+)
+
+// WebhookDeliveryFilter selects the webhook deliveries to list
+type WebhookDeliveryFilter struct {
+	// SubscriptionID restricts the deliveries to those of a subscription, if set
+	SubscriptionID *uuid.UUID
+
+	// Status restricts the deliveries to those with the given status, if set
+	Status *domain.DeliveryStatus
+
+	// Limit is the maximum number of deliveries to list
+	Limit int
+}
+
+// WebhookRepository defines the interface for storing webhook subscriptions and their deliveries.
+// Subscriptions and deliveries belong to the caller's tenant, except where noted.
+type WebhookRepository interface {
+	// CreateSubscription stores a new subscription of the caller's tenant
+	CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error
+
+	// GetSubscription retrieves a subscription of the caller's tenant by ID
+	GetSubscription(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error)
+
+	// ListSubscriptions retrieves the subscriptions of the caller's tenant, oldest first
+	ListSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error)
+
+	// DeleteSubscription removes a subscription of the caller's tenant together with its deliveries
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+
+	// EnqueueDelivery stores a new delivery. It does nothing when the event has already been
+	// enqueued for the subscription, so that an event received twice is delivered once.
+	EnqueueDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// FetchDueDeliveries retrieves up to limit pending deliveries of all tenants whose next attempt
+	// is due at the given time, oldest first
+	FetchDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error)
+
+	// UpdateDelivery stores the outcome of an attempt to deliver
+	UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// ListDeliveries retrieves the deliveries of the caller's tenant selected by the filter, newest first
+	ListDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error)
+}
+
+// WebhookSender defines the interface for sending a delivery to the URL of its subscription
+type WebhookSender interface {
+	// Send makes a single attempt to deliver the payload, signed with the subscription's secret.
+	// It returns the HTTP status code of the response, or 0 when there was no response, and an error
+	// unless the receiver acknowledged the delivery.
+	Send(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) (int, error)
+}